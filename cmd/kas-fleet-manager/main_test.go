@@ -26,7 +26,7 @@ func TestInjections(t *testing.T) {
 
 	var bootList []environments.BootService
 	env.MustResolve(&bootList)
-	g.Expect(len(bootList)).To(gomega.Equal(5))
+	g.Expect(len(bootList)).To(gomega.Equal(6))
 
 	_, ok := bootList[0].(signalbus.SignalBus)
 	g.Expect(ok).To(gomega.Equal(true))
@@ -41,6 +41,6 @@ func TestInjections(t *testing.T) {
 
 	var workerList []workers.Worker
 	env.MustResolve(&workerList)
-	g.Expect(workerList).To(gomega.HaveLen(12))
+	g.Expect(workerList).To(gomega.HaveLen(18))
 
 }
@@ -48,6 +48,8 @@ type APIClient struct {
 
 	// API Services
 
+	AuditEventsAdminApi *AuditEventsAdminApiService
+
 	ConnectorClustersAdminApi *ConnectorClustersAdminApiService
 
 	ConnectorNamespacesAdminApi *ConnectorNamespacesAdminApiService
@@ -71,6 +73,7 @@ func NewAPIClient(cfg *Configuration) *APIClient {
 	c.common.client = c
 
 	// API Services
+	c.AuditEventsAdminApi = (*AuditEventsAdminApiService)(&c.common)
 	c.ConnectorClustersAdminApi = (*ConnectorClustersAdminApiService)(&c.common)
 	c.ConnectorNamespacesAdminApi = (*ConnectorNamespacesAdminApiService)(&c.common)
 	c.ConnectorTypesApi = (*ConnectorTypesApiService)(&c.common)
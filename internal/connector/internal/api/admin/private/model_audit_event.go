@@ -0,0 +1,37 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// AuditEvent A create, update or delete request to the connector APIs
+type AuditEvent struct {
+	Id string `json:"id"`
+	// The username of the user or of the service account that sent the request
+	Actor string `json:"actor"`
+	// The id of the organisation of the resource, or of the actor when the resource could not be looked up
+	OrganisationId string `json:"organisation_id,omitempty"`
+	// The collection of the resource in the path of the request, e.g. kafka_connectors
+	ResourceType string `json:"resource_type"`
+	ResourceId   string `json:"resource_id,omitempty"`
+	Action       string `json:"action"`
+	Method       string `json:"method"`
+	// The path template of the request, e.g. /api/connector_mgmt/v1/kafka_connectors/{connector_id}
+	Route string `json:"route"`
+	// The unified diff of the resource before and after the request, when it could be looked up
+	Diff string `json:"diff,omitempty"`
+	// The operation id of the request, also found in the logs
+	RequestId  string    `json:"request_id,omitempty"`
+	StatusCode int32     `json:"status_code"`
+	Outcome    string    `json:"outcome"`
+	CreatedAt  time.Time `json:"created_at,omitempty"`
+}
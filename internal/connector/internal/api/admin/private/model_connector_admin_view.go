@@ -27,7 +27,10 @@ type ConnectorAdminView struct {
 	Channel         Channel               `json:"channel,omitempty"`
 	DesiredState    ConnectorDesiredState `json:"desired_state"`
 	// Name-value string annotations for resource
-	Annotations     map[string]string     `json:"annotations,omitempty"`
-	ResourceVersion int64                 `json:"resource_version,omitempty"`
-	Status          ConnectorStatusStatus `json:"status,omitempty"`
+	Annotations     map[string]string       `json:"annotations,omitempty"`
+	RestartPolicy   *ConnectorRestartPolicy `json:"restart_policy,omitempty"`
+	ResourceVersion int64                   `json:"resource_version,omitempty"`
+	Status          ConnectorStatusStatus   `json:"status,omitempty"`
+	// Warnings about the connector, such as the deprecation of its connector type or channel
+	Warnings []string `json:"warnings,omitempty"`
 }
@@ -0,0 +1,25 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// ConnectorAvailableDeprecationUpgrade A migration of a connector of a deprecated connector type or channel to its replacement
+type ConnectorAvailableDeprecationUpgrade struct {
+	ConnectorId                string    `json:"connector_id,omitempty"`
+	NamespaceId                string    `json:"namespace_id,omitempty"`
+	ConnectorTypeId            string    `json:"connector_type_id,omitempty"`
+	Channel                    string    `json:"channel,omitempty"`
+	ReplacementConnectorTypeId string    `json:"replacement_connector_type_id,omitempty"`
+	ReplacementChannel         string    `json:"replacement_channel,omitempty"`
+	SunsetAt                   time.Time `json:"sunset_at,omitempty"`
+}
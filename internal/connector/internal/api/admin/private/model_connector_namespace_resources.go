@@ -0,0 +1,16 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ConnectorNamespaceResources Resources reserved by the connectors in a namespace, and left available in the namespace quota. Only the resources limited by the namespace quota are available.
+type ConnectorNamespaceResources struct {
+	Used      ConnectorNamespaceQuota `json:"used,omitempty"`
+	Available ConnectorNamespaceQuota `json:"available,omitempty"`
+}
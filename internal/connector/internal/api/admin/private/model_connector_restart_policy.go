@@ -0,0 +1,21 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ConnectorRestartPolicy The policy used to restart the connector when it fails. The first restart attempt is immediate, then the delay between attempts doubles at every attempt, and the connector is stopped once the maximum number of attempts is reached. The attempts are counted from the last update of the connector.
+type ConnectorRestartPolicy struct {
+	Type ConnectorRestartPolicyType `json:"type"`
+	// The maximum number of restart attempts, unlimited when 0 or not set
+	MaxAttempts int32 `json:"max_attempts,omitempty"`
+	// The delay between the first two restart attempts in seconds, 30 when 0 or not set
+	BackoffSeconds int32 `json:"backoff_seconds,omitempty"`
+	// The maximum delay between restart attempts in seconds, 600 when 0 or not set
+	MaxBackoffSeconds int32 `json:"max_backoff_seconds,omitempty"`
+}
@@ -0,0 +1,19 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ConnectorRestartPolicyType the model 'ConnectorRestartPolicyType'
+type ConnectorRestartPolicyType string
+
+// List of ConnectorRestartPolicyType
+const (
+	CONNECTORRESTARTPOLICYTYPE_NEVER      ConnectorRestartPolicyType = "never"
+	CONNECTORRESTARTPOLICYTYPE_ON_FAILURE ConnectorRestartPolicyType = "on_failure"
+)
@@ -0,0 +1,22 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ConnectorSecretsReport struct for ConnectorSecretsReport
+type ConnectorSecretsReport struct {
+	// The number of connectors whose secrets were rotated
+	Connectors int32 `json:"connectors"`
+	// The number of secrets written to the vault
+	Secrets int32 `json:"secrets"`
+	// The names of the orphaned secrets found in the vault
+	OrphanedSecrets []string `json:"orphaned_secrets,omitempty"`
+	// The errors of the connectors or secrets that could not be processed
+	Errors []string `json:"errors,omitempty"`
+}
@@ -0,0 +1,28 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// ConnectorSecretsRotation struct for ConnectorSecretsRotation
+type ConnectorSecretsRotation struct {
+	Id     string `json:"id"`
+	Status string `json:"status"`
+	// The number of connectors whose secrets were rotated
+	Connectors int32 `json:"connectors"`
+	// The number of secrets written to the vault
+	Secrets int32 `json:"secrets"`
+	// The errors of the connectors that could not be processed
+	Errors      []string  `json:"errors,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	CompletedAt time.Time `json:"completed_at,omitempty"`
+}
@@ -0,0 +1,15 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ConnectorSecretsRotationRequest struct for ConnectorSecretsRotationRequest
+type ConnectorSecretsRotationRequest struct {
+	ServiceAccount *ServiceAccount `json:"service_account,omitempty"`
+}
@@ -12,4 +12,6 @@ package private
 // ConnectorStatus struct for ConnectorStatus
 type ConnectorStatus struct {
 	Status ConnectorStatusStatus `json:"status,omitempty"`
+	// Warnings about the connector, such as the deprecation of its connector type or channel
+	Warnings []string `json:"warnings,omitempty"`
 }
@@ -32,5 +32,8 @@ type ConnectorTypeAdminView struct {
 	// The capabilities supported by the connector
 	Capabilities []string `json:"capabilities,omitempty"`
 	// A json schema that can be used to validate a ConnectorRequest connector field.
-	Schema map[string]interface{} `json:"schema"`
+	Schema      map[string]interface{}    `json:"schema"`
+	Deprecation *ConnectorTypeDeprecation `json:"deprecation,omitempty"`
+	// The deprecations of the channels of the connector type, by channel
+	ChannelDeprecations map[string]ConnectorTypeDeprecation `json:"channel_deprecations,omitempty"`
 }
@@ -0,0 +1,24 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// ConnectorTypeDeprecation The deprecation of a connector type or channel. Connectors of a deprecated connector type or channel are migrated to the replacement connector type and channel by an admin, before the sunset date.
+type ConnectorTypeDeprecation struct {
+	// The date after which the connector type or channel is removed
+	SunsetAt time.Time `json:"sunset_at"`
+	// The id of the connector type replacing the deprecated one, the same connector type if empty
+	ReplacementConnectorTypeId string  `json:"replacement_connector_type_id,omitempty"`
+	ReplacementChannel         Channel `json:"replacement_channel,omitempty"`
+	Reason                     string  `json:"reason,omitempty"`
+}
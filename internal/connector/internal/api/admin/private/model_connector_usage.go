@@ -0,0 +1,25 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// ConnectorUsage The connector hours of the running connectors of a connector type in a namespace during a period
+type ConnectorUsage struct {
+	OrganisationId  string `json:"organisation_id"`
+	NamespaceId     string `json:"namespace_id"`
+	ConnectorTypeId string `json:"connector_type_id"`
+	// The start of the period in RFC 3339 format
+	PeriodStart time.Time `json:"period_start"`
+	// The sum of the running times of the connectors in hours
+	ConnectorHours float64 `json:"connector_hours"`
+}
@@ -1,12 +1,15 @@
 package dbapi
 
 import (
+	"time"
+
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 )
 
 type ConnectorDesiredState string
 type ConnectorStatusPhase string
+type ConnectorRestartPolicyType string
 
 const (
 	ConnectorUnassigned ConnectorDesiredState = "unassigned"
@@ -24,6 +27,9 @@ const (
 	ConnectorStatusPhaseDeprovisioning ConnectorStatusPhase = "deprovisioning" // set by kas-agent
 	ConnectorStatusPhaseDeleting       ConnectorStatusPhase = "deleting"       // set by the kas-fleet-manager - user request
 	ConnectorStatusPhaseDeleted        ConnectorStatusPhase = "deleted"        // set by the agent
+
+	ConnectorRestartPolicyNever     ConnectorRestartPolicyType = "never"
+	ConnectorRestartPolicyOnFailure ConnectorRestartPolicyType = "on_failure"
 )
 
 var ValidDesiredStates = []string{
@@ -33,6 +39,11 @@ var ValidDesiredStates = []string{
 	string(ConnectorDeleted),
 }
 
+var ValidRestartPolicyTypes = []string{
+	string(ConnectorRestartPolicyNever),
+	string(ConnectorRestartPolicyOnFailure),
+}
+
 var AgentConnectorStatusPhase = []string{
 	string(ConnectorStatusPhaseProvisioning),
 	string(ConnectorStatusPhaseDeprovisioning),
@@ -63,6 +74,7 @@ type Connector struct {
 	Kafka           KafkaConnectionSettings          `gorm:"embedded;embeddedPrefix:kafka_"`
 	SchemaRegistry  SchemaRegistryConnectionSettings `gorm:"embedded;embeddedPrefix:schema_registry_"`
 	ServiceAccount  ServiceAccount                   `gorm:"embedded;embeddedPrefix:service_account_"`
+	RestartPolicy   ConnectorRestartPolicy           `gorm:"embedded;embeddedPrefix:restart_policy_"`
 
 	Status ConnectorStatus `gorm:"foreignKey:ID"`
 }
@@ -73,10 +85,20 @@ type ConnectorAnnotation struct {
 	Value       string `gorm:"not null"`
 }
 
+// ConnectorRestartPolicy the policy used to restart a connector when its deployment fails, a connector without a policy is never restarted
+type ConnectorRestartPolicy struct {
+	Type              ConnectorRestartPolicyType
+	MaxAttempts       int32
+	BackoffSeconds    int32
+	MaxBackoffSeconds int32
+}
+
 type ConnectorStatus struct {
 	db.Model
 	NamespaceID *string
 	Phase       ConnectorStatusPhase
+	// Conditions holds the restart attempts of the connector since it was last updated
+	Conditions api.JSON `gorm:"type:jsonb"`
 }
 
 type ConnectorList []*Connector
@@ -88,6 +110,20 @@ type ConnectorWithConditions struct {
 
 type ConnectorWithConditionsList []*ConnectorWithConditions
 
+// ConnectorRevision Holds an immutable snapshot of the configuration of a connector, the spec only holds secret references
+type ConnectorRevision struct {
+	db.Model
+	ConnectorID              string `gorm:"index:idx_connector_revisions_connector_id_revision,unique"`
+	Revision                 int64  `gorm:"index:idx_connector_revisions_connector_id_revision,unique"`
+	ConnectorTypeId          string
+	ConnectorSpec            api.JSON `gorm:"type:jsonb"`
+	Channel                  string
+	ConnectorShardMetadataID int64
+	CreatedBy                string
+}
+
+type ConnectorRevisionList []*ConnectorRevision
+
 // ConnectorDeployment Holds the deployment configuration of a connector
 type ConnectorDeployment struct {
 	db.Model
@@ -148,6 +184,19 @@ type ConnectorOperatorUpgrade struct {
 
 type ConnectorDeploymentOperatorUpgradeList []ConnectorDeploymentOperatorUpgrade
 
+type ConnectorDeploymentDeprecationUpgrade struct {
+	ConnectorID                string    `json:"connector_id,omitempty"`
+	DeploymentID               string    `json:"deployment_id,omitempty"`
+	ConnectorTypeId            string    `json:"connector_type_id,omitempty"`
+	NamespaceID                string    `json:"namespace_id,omitempty"`
+	Channel                    string    `json:"channel,omitempty"`
+	ReplacementConnectorTypeId string    `json:"replacement_connector_type_id,omitempty"`
+	ReplacementChannel         string    `json:"replacement_channel,omitempty"`
+	SunsetAt                   time.Time `json:"sunset_at,omitempty"`
+}
+
+type ConnectorDeploymentDeprecationUpgradeList []ConnectorDeploymentDeprecationUpgrade
+
 type ConnectorOperator struct {
 	// the id of the operator
 	Id string `json:"id,omitempty"`
@@ -156,3 +205,37 @@ type ConnectorOperator struct {
 	// the version of the operator
 	Version string `json:"version,omitempty"`
 }
+
+// ConnectorSecretsReport summarizes a rotation, a migration or a garbage collection of the connector secrets
+type ConnectorSecretsReport struct {
+	// Connectors is the number of connectors whose secrets were rotated
+	Connectors int
+	// Secrets is the number of secrets written to the vault
+	Secrets int
+	// OrphanedSecrets are the names of the orphaned secrets found in the vault
+	OrphanedSecrets []string
+	// Errors are the errors of the connectors or secrets that could not be processed
+	Errors []error
+}
+
+type ConnectorSecretsRotationStatus string
+
+const (
+	ConnectorSecretsRotationPending   ConnectorSecretsRotationStatus = "pending"
+	ConnectorSecretsRotationRunning   ConnectorSecretsRotationStatus = "running"
+	ConnectorSecretsRotationCompleted ConnectorSecretsRotationStatus = "completed"
+)
+
+// ConnectorSecretsRotation is a rotation of the secrets of all the connectors requested through the admin API,
+// it is run in the background by the secrets rotation worker
+type ConnectorSecretsRotation struct {
+	db.Model
+	Status ConnectorSecretsRotationStatus `gorm:"index"`
+	// Connectors is the number of connectors whose secrets were rotated
+	Connectors int
+	// Secrets is the number of secrets written to the vault
+	Secrets int
+	// Errors is the JSON array of the errors of the connectors that could not be processed
+	Errors      api.JSON `gorm:"type:jsonb"`
+	CompletedAt *time.Time
+}
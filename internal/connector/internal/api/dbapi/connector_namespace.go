@@ -58,6 +58,20 @@ type ConnectorNamespace struct {
 	TenantOrganisation   *ConnectorTenantOrganisation `gorm:"foreignKey:TenantOrganisationId"`
 
 	Status ConnectorNamespaceStatus `gorm:"embedded;embeddedPrefix:status_"`
+
+	// resources reserved by the connectors in the namespace, and left in the namespace quota
+	ResourcesUsed      ConnectorNamespaceResources `gorm:"-:all"` // gorm ignored field set using query from connectors table
+	ResourcesAvailable ConnectorNamespaceResources `gorm:"-:all"` // gorm ignored field set from the namespace quota profile
+}
+
+// ConnectorNamespaceResources holds the number of connectors and the CPU and memory quantities of a namespace,
+// empty quantities are not limited
+type ConnectorNamespaceResources struct {
+	Connectors     int32
+	MemoryRequests string
+	MemoryLimits   string
+	CPURequests    string
+	CPULimits      string
 }
 
 type ConnectorNamespaceStatus struct {
@@ -35,6 +35,8 @@ type ConnectorType struct {
 	Capabilities []ConnectorTypeCapability `gorm:"foreignKey:ConnectorTypeID"`
 	Checksum     *string
 	FeaturedRank int32 `gorm:"not null;default:0"`
+	// deprecations of the connector type and its channels
+	Deprecations []ConnectorTypeDeprecation `gorm:"foreignKey:ConnectorTypeID"`
 }
 
 type ConnectorTypeList []*ConnectorType
@@ -70,6 +72,16 @@ type ConnectorTypeCapability struct {
 	Capability      string `gorm:"primaryKey"`
 }
 
+// ConnectorTypeDeprecation deprecates a connector type when Channel is empty, or one of its channels
+type ConnectorTypeDeprecation struct {
+	ConnectorTypeID            string `gorm:"primaryKey"`
+	Channel                    string `gorm:"primaryKey"`
+	SunsetAt                   time.Time
+	ReplacementConnectorTypeId string
+	ReplacementChannel         string
+	Reason                     string
+}
+
 type ConnectorShardMetadata struct {
 	ID              int64  `gorm:"primaryKey:autoIncrement"`
 	ConnectorTypeId string `gorm:"index:idx_typeid_channel_revision;index:idx_typeid_channel"`
@@ -134,6 +146,20 @@ func (ct *ConnectorType) SetLabels(labels []string) {
 	}
 }
 
+// ChannelDeprecation returns the deprecation of a channel, or of the connector type when the channel isn't deprecated
+func (ct *ConnectorType) ChannelDeprecation(channel string) *ConnectorTypeDeprecation {
+	var result *ConnectorTypeDeprecation
+	for i, deprecation := range ct.Deprecations {
+		if deprecation.Channel == channel {
+			return &ct.Deprecations[i]
+		}
+		if deprecation.Channel == "" {
+			result = &ct.Deprecations[i]
+		}
+	}
+	return result
+}
+
 func (ct *ConnectorType) JsonSchemaAsMap() (map[string]interface{}, *errors.ServiceError) {
 	schema, err := ct.JsonSchema.Object()
 	if err != nil {
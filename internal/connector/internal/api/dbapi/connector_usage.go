@@ -0,0 +1,25 @@
+package dbapi
+
+import (
+	"time"
+)
+
+// ConnectorUsage Holds the running time of the connectors of a connector type in a namespace during an hour
+type ConnectorUsage struct {
+	OrganisationId   string    `gorm:"primaryKey"`
+	NamespaceId      string    `gorm:"primaryKey"`
+	ConnectorTypeId  string    `gorm:"primaryKey"`
+	PeriodStart      time.Time `gorm:"primaryKey"`
+	ConnectorSeconds int64     `gorm:"not null;default:0"`
+	Samples          int64     `gorm:"not null;default:0"`
+	Reported         bool      `gorm:"not null;default:false;index"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type ConnectorUsageList []ConnectorUsage
+
+// ConnectorHours returns the running time of the connectors in hours
+func (u *ConnectorUsage) ConnectorHours() float64 {
+	return float64(u.ConnectorSeconds) / time.Hour.Seconds()
+}
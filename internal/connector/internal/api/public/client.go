@@ -58,6 +58,10 @@ type APIClient struct {
 	ConnectorTypesApi *ConnectorTypesApiService
 
 	ConnectorsApi *ConnectorsApiService
+
+	GrantsApi *GrantsApiService
+
+	WebhooksApi *WebhooksApiService
 }
 
 type service struct {
@@ -81,6 +85,8 @@ func NewAPIClient(cfg *Configuration) *APIClient {
 	c.ConnectorServiceApi = (*ConnectorServiceApiService)(&c.common)
 	c.ConnectorTypesApi = (*ConnectorTypesApiService)(&c.common)
 	c.ConnectorsApi = (*ConnectorsApiService)(&c.common)
+	c.GrantsApi = (*GrantsApiService)(&c.common)
+	c.WebhooksApi = (*WebhooksApiService)(&c.common)
 
 	return c
 }
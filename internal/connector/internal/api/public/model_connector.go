@@ -29,10 +29,13 @@ type Connector struct {
 	DesiredState    ConnectorDesiredState `json:"desired_state"`
 	// Name-value string annotations for resource
 	Annotations     map[string]string                `json:"annotations,omitempty"`
+	RestartPolicy   *ConnectorRestartPolicy          `json:"restart_policy,omitempty"`
 	ResourceVersion int64                            `json:"resource_version,omitempty"`
 	Kafka           KafkaConnectionSettings          `json:"kafka"`
 	ServiceAccount  ServiceAccount                   `json:"service_account"`
 	SchemaRegistry  SchemaRegistryConnectionSettings `json:"schema_registry,omitempty"`
 	Connector       map[string]interface{}           `json:"connector"`
 	Status          ConnectorStatusStatus            `json:"status,omitempty"`
+	// Warnings about the connector, such as the deprecation of its connector type or channel
+	Warnings []string `json:"warnings,omitempty"`
 }
@@ -0,0 +1,22 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorApplyAction the model 'ConnectorApplyAction'
+type ConnectorApplyAction string
+
+// List of ConnectorApplyAction
+const (
+	CONNECTORAPPLYACTION_CREATE ConnectorApplyAction = "create"
+	CONNECTORAPPLYACTION_UPDATE ConnectorApplyAction = "update"
+	CONNECTORAPPLYACTION_DELETE ConnectorApplyAction = "delete"
+	CONNECTORAPPLYACTION_NONE   ConnectorApplyAction = "none"
+)
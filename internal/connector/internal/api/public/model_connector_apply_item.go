@@ -0,0 +1,24 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorApplyItem An item of the plan of a connector apply
+type ConnectorApplyItem struct {
+	Name        string `json:"name"`
+	NamespaceId string `json:"namespace_id"`
+	// The id of the connector, it is only set for a created connector once the plan is applied
+	Id     string               `json:"id,omitempty"`
+	Action ConnectorApplyAction `json:"action"`
+	// The unified diff of an updated connector, secrets are not compared in the diff
+	Diff string `json:"diff,omitempty"`
+	// The reason the item is invalid
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,20 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorApplyRequest A list of desired connectors, identified by name and namespace
+type ConnectorApplyRequest struct {
+	// Delete the connectors of the namespaces listed in namespace_ids that are not in the list of desired connectors
+	Prune bool `json:"prune,omitempty"`
+	// The namespaces pruned by the apply, required when prune is true
+	NamespaceIds []string           `json:"namespace_ids,omitempty"`
+	Connectors   []ConnectorRequest `json:"connectors"`
+}
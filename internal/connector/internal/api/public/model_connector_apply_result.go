@@ -0,0 +1,19 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorApplyResult The plan to reconcile the connectors with the desired connectors and its result
+type ConnectorApplyResult struct {
+	DryRun bool `json:"dry_run"`
+	// True when the plan was applied, it is not applied in dry run mode or when any item is invalid
+	Applied bool                 `json:"applied"`
+	Items   []ConnectorApplyItem `json:"items"`
+}
@@ -25,6 +25,7 @@ type ConnectorMeta struct {
 	Channel         Channel               `json:"channel,omitempty"`
 	DesiredState    ConnectorDesiredState `json:"desired_state"`
 	// Name-value string annotations for resource
-	Annotations     map[string]string `json:"annotations,omitempty"`
-	ResourceVersion int64             `json:"resource_version,omitempty"`
+	Annotations     map[string]string       `json:"annotations,omitempty"`
+	RestartPolicy   *ConnectorRestartPolicy `json:"restart_policy,omitempty"`
+	ResourceVersion int64                   `json:"resource_version,omitempty"`
 }
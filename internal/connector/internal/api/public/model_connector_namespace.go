@@ -29,7 +29,8 @@ type ConnectorNamespace struct {
 	Quota           ConnectorNamespaceQuota `json:"quota,omitempty"`
 	ClusterId       string                  `json:"cluster_id"`
 	// Namespace expiration timestamp in RFC 3339 format
-	Expiration string                   `json:"expiration,omitempty"`
-	Tenant     ConnectorNamespaceTenant `json:"tenant"`
-	Status     ConnectorNamespaceStatus `json:"status"`
+	Expiration string                      `json:"expiration,omitempty"`
+	Tenant     ConnectorNamespaceTenant    `json:"tenant"`
+	Status     ConnectorNamespaceStatus    `json:"status"`
+	Resources  ConnectorNamespaceResources `json:"resources,omitempty"`
 }
@@ -0,0 +1,17 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorNamespaceResources Resources reserved by the connectors in a namespace, and left available in the namespace quota. Only the resources limited by the namespace quota are available.
+type ConnectorNamespaceResources struct {
+	Used      ConnectorNamespaceQuota `json:"used,omitempty"`
+	Available ConnectorNamespaceQuota `json:"available,omitempty"`
+}
@@ -19,6 +19,7 @@ type ConnectorRequest struct {
 	DesiredState    ConnectorDesiredState `json:"desired_state"`
 	// Name-value string annotations for resource
 	Annotations    map[string]string                `json:"annotations,omitempty"`
+	RestartPolicy  *ConnectorRestartPolicy          `json:"restart_policy,omitempty"`
 	Kafka          KafkaConnectionSettings          `json:"kafka"`
 	ServiceAccount ServiceAccount                   `json:"service_account"`
 	SchemaRegistry SchemaRegistryConnectionSettings `json:"schema_registry,omitempty"`
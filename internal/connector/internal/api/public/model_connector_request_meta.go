@@ -18,5 +18,6 @@ type ConnectorRequestMeta struct {
 	Channel         Channel               `json:"channel,omitempty"`
 	DesiredState    ConnectorDesiredState `json:"desired_state"`
 	// Name-value string annotations for resource
-	Annotations map[string]string `json:"annotations,omitempty"`
+	Annotations   map[string]string       `json:"annotations,omitempty"`
+	RestartPolicy *ConnectorRestartPolicy `json:"restart_policy,omitempty"`
 }
@@ -0,0 +1,20 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorRestartPolicyType the model 'ConnectorRestartPolicyType'
+type ConnectorRestartPolicyType string
+
+// List of ConnectorRestartPolicyType
+const (
+	CONNECTORRESTARTPOLICYTYPE_NEVER      ConnectorRestartPolicyType = "never"
+	CONNECTORRESTARTPOLICYTYPE_ON_FAILURE ConnectorRestartPolicyType = "on_failure"
+)
@@ -0,0 +1,32 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// ConnectorRevision An immutable snapshot of the configuration of a connector, recorded every time the connector is created or updated. Secrets are never returned.
+type ConnectorRevision struct {
+	Id   string `json:"id,omitempty"`
+	Kind string `json:"kind,omitempty"`
+	Href string `json:"href,omitempty"`
+	// The resource version of the connector the revision was recorded for
+	Revision        int64   `json:"revision,omitempty"`
+	ConnectorId     string  `json:"connector_id,omitempty"`
+	ConnectorTypeId string  `json:"connector_type_id,omitempty"`
+	Channel         Channel `json:"channel,omitempty"`
+	// The id of the connector type shard metadata deployed with the revision
+	ShardMetadataId int64                  `json:"shard_metadata_id,omitempty"`
+	Connector       map[string]interface{} `json:"connector,omitempty"`
+	CreatedAt       time.Time              `json:"created_at,omitempty"`
+	CreatedBy       string                 `json:"created_by,omitempty"`
+}
@@ -0,0 +1,25 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// ConnectorTypeDeprecation The deprecation of a connector type or channel. Connectors of a deprecated connector type or channel are migrated to the replacement connector type and channel by an admin, before the sunset date.
+type ConnectorTypeDeprecation struct {
+	// The date after which the connector type or channel is removed
+	SunsetAt time.Time `json:"sunset_at"`
+	// The id of the connector type replacing the deprecated one, the same connector type if empty
+	ReplacementConnectorTypeId string  `json:"replacement_connector_type_id,omitempty"`
+	ReplacementChannel         Channel `json:"replacement_channel,omitempty"`
+	Reason                     string  `json:"reason,omitempty"`
+}
@@ -0,0 +1,18 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorValidationError A validation error of a field of a proposed connector
+type ConnectorValidationError struct {
+	// The path of the invalid field, e.g. connector.aws_region
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
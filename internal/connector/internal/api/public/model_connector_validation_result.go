@@ -0,0 +1,23 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorValidationResult The result of the validation of a proposed connector, nothing is persisted
+type ConnectorValidationResult struct {
+	// True when the connector can be created as proposed
+	Valid   bool                       `json:"valid"`
+	Errors  []ConnectorValidationError `json:"errors,omitempty"`
+	Channel Channel                    `json:"channel,omitempty"`
+	// The id of the connector type shard metadata the connector would be deployed with
+	ShardMetadataId int64 `json:"shard_metadata_id,omitempty"`
+	// The paths of the fields of the connector spec that would be stored in the vault
+	SecretFields []string `json:"secret_fields,omitempty"`
+}
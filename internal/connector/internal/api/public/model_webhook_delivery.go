@@ -0,0 +1,32 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// WebhookDelivery struct for WebhookDelivery
+type WebhookDelivery struct {
+	Id        string `json:"id"`
+	Kind      string `json:"kind"`
+	Href      string `json:"href"`
+	WebhookId string `json:"webhook_id,omitempty"`
+	EventId   string `json:"event_id,omitempty"`
+	EventType string `json:"event_type,omitempty"`
+	Status    string `json:"status,omitempty"`
+	// The number of attempts made to deliver the event
+	Attempts int32 `json:"attempts,omitempty"`
+	// The error of the last failed attempt
+	LastError string    `json:"last_error,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,18 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// WebhookEndpointRequest An HTTP endpoint the lifecycle events of the resources of an organisation are delivered to
+type WebhookEndpointRequest struct {
+	// The absolute https URL the events are posted to. It must not target a loopback, private or link local address.
+	Url         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
@@ -0,0 +1,57 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/golang/glog"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+func NewGCCommand(env *environments.Env) *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Delete the orphaned connector secrets",
+		Long:  "Delete the vault secrets owned by deleted connectors",
+
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			err := env.CreateServices()
+			if err != nil {
+				glog.Fatalf("Unable to initialize environment: %s", err.Error())
+			}
+		},
+
+		Run: func(cmd *cobra.Command, args []string) {
+			env.MustInvoke(func(secretsService services.ConnectorSecretsService) {
+				report, err := secretsService.DeleteOrphanedSecrets(context.Background(), dryRun)
+				if err != nil {
+					glog.Fatalf("Unable to delete the orphaned connector secrets: %s", err.Error())
+				}
+				printReport(report)
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only list the orphaned secrets without deleting them")
+	return cmd
+}
+
+func printReport(report *dbapi.ConnectorSecretsReport) {
+	fmt.Printf("connectors: %d, secrets: %d\n", report.Connectors, report.Secrets)
+	if len(report.OrphanedSecrets) > 0 {
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Orphaned Secret Key"})
+		for _, key := range report.OrphanedSecrets {
+			table.Append([]string{key})
+		}
+		table.Render()
+	}
+	for _, err := range report.Errors {
+		fmt.Println("error:", err)
+	}
+}
@@ -0,0 +1,59 @@
+package vault
+
+import (
+	"context"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+func NewMigrateCommand(env *environments.Env) *cobra.Command {
+	var fromKind string
+	var deleteSource bool
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the connector secrets from another kind of vault",
+		Long: "Migrate the connector secrets from another kind of vault to the configured vault and bump the connector versions so that the agents pick up the new references. " +
+			"The source vault is configured with the same vault flags as the configured vault, except for its kind.",
+
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			err := env.CreateServices()
+			if err != nil {
+				glog.Fatalf("Unable to initialize environment: %s", err.Error())
+			}
+		},
+
+		Run: func(cmd *cobra.Command, args []string) {
+			env.MustInvoke(func(vaultConfig *vault.Config, secretsService services.ConnectorSecretsService) {
+				if fromKind == vaultConfig.Kind {
+					glog.Fatalf("The source vault kind must be different from the configured vault kind %s", vaultConfig.Kind)
+				}
+				sourceConfig := *vaultConfig
+				sourceConfig.Kind = fromKind
+				if err := sourceConfig.ReadFiles(); err != nil {
+					glog.Fatalf("Unable to read the source vault configuration: %s", err.Error())
+				}
+				if err := sourceConfig.Validate(env); err != nil {
+					glog.Fatalf("Invalid source vault configuration: %s", err.Error())
+				}
+				source, err := vault.NewVaultService(&sourceConfig)
+				if err != nil {
+					glog.Fatalf("Unable to create the source vault: %s", err.Error())
+				}
+
+				report, serr := secretsService.RotateAllSecrets(context.Background(), source, deleteSource)
+				if serr != nil {
+					glog.Fatalf("Unable to migrate the connector secrets: %s", serr.Error())
+				}
+				printReport(report)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&fromKind, "from-kind", "", "The kind of vault to migrate the secrets from: aws|hashicorp|tmp")
+	cmd.Flags().BoolVar(&deleteSource, "delete-source", false, "Delete the secrets from the source vault once they are migrated")
+	_ = cmd.MarkFlagRequired("from-kind")
+	return cmd
+}
@@ -0,0 +1,46 @@
+package vault
+
+import (
+	"context"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+func NewRotateCommand(env *environments.Env) *cobra.Command {
+	var connectorId string
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Rotate the connector secrets",
+		Long:  "Rotate the connector secrets, they are written to the vault under new keys and the connector versions are bumped so that the agents pick up the new references",
+
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			err := env.CreateServices()
+			if err != nil {
+				glog.Fatalf("Unable to initialize environment: %s", err.Error())
+			}
+		},
+
+		Run: func(cmd *cobra.Command, args []string) {
+			env.MustInvoke(func(secretsService services.ConnectorSecretsService) {
+				if connectorId != "" {
+					connector, err := secretsService.RotateConnectorSecrets(context.Background(), connectorId, nil)
+					if err != nil {
+						glog.Fatalf("Unable to rotate the secrets of connector %s: %s", connectorId, err.Error())
+					}
+					glog.Infof("Rotated the secrets of connector %s, new version %d", connector.ID, connector.Version)
+					return
+				}
+				report, err := secretsService.RotateAllSecrets(context.Background(), nil, true)
+				if err != nil {
+					glog.Fatalf("Unable to rotate the connector secrets: %s", err.Error())
+				}
+				printReport(report)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&connectorId, "connector", "", "Only rotate the secrets of the connector with this id")
+	return cmd
+}
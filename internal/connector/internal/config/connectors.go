@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/fs"
+	"net/http"
 	"os"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/files"
 
@@ -25,10 +27,25 @@ type ConnectorsConfig struct {
 	ConnectorEvalOrganizations          []string                `json:"connector_eval_organizations"`
 	ConnectorNamespaceLifecycleAPI      bool                    `json:"connector_namespace_lifecycle_api"`
 	ConnectorEnableUnassignedConnectors bool                    `json:"connector_enable_unassigned_connectors"`
+	ConnectorRevisionsLimit             int                     `json:"connector_revisions_limit"`
 	ConnectorCatalogDirs                []string                `json:"connector_types"`
 	ConnectorMetadataDirs               []string                `json:"connector_metadata"`
 	CatalogEntries                      []ConnectorCatalogEntry `json:"connector_type_urls"`
 	CatalogChecksums                    map[string]string       `json:"connector_catalog_checksums"`
+	ConnectorCatalogSources             []string                `json:"connector_catalog_sources"`
+	ConnectorCatalogReloadInterval      time.Duration           `json:"connector_catalog_reload_interval"`
+	ConnectorCatalogCacheDir            string                  `json:"connector_catalog_cache_dir"`
+	ConnectorMeteringInterval           time.Duration           `json:"connector_metering_interval"`
+	ConnectorUsageReporterURL           string                  `json:"connector_usage_reporter_url"`
+
+	// catalogMutex guards the catalog entries and checksums replaced by ReloadCatalogSources
+	catalogMutex         sync.RWMutex
+	catalogHttpClient    *http.Client
+	connectorMetadata    map[string]ConnectorMetadata
+	localCatalogEntries  []ConnectorCatalogEntry
+	catalogSourceEntries map[string][]ConnectorCatalogEntry
+	catalogSourceETags   map[string]string
+	catalogSourcesLoaded bool
 }
 
 var _ environments.ConfigModule = &ConnectorsConfig{}
@@ -51,17 +68,26 @@ type ConnectorMetadata struct {
 
 func NewConnectorsConfig() *ConnectorsConfig {
 	return &ConnectorsConfig{
-		CatalogChecksums: make(map[string]string),
+		ConnectorRevisionsLimit:        10,
+		CatalogChecksums:               make(map[string]string),
+		ConnectorCatalogReloadInterval: 5 * time.Minute,
+		ConnectorMeteringInterval:      time.Minute,
 	}
 }
 
 func (c *ConnectorsConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringArrayVar(&c.ConnectorCatalogDirs, "connector-catalog", c.ConnectorCatalogDirs, "Directory containing connector catalog entries")
 	fs.StringArrayVar(&c.ConnectorMetadataDirs, "connector-metadata", c.ConnectorMetadataDirs, "Directory containing connector metadata configuration files")
+	fs.StringArrayVar(&c.ConnectorCatalogSources, "connector-catalog-source", c.ConnectorCatalogSources, "HTTP(S) URL or oci:// artifact reference of a remote connector catalog, polled for updates")
+	fs.DurationVar(&c.ConnectorCatalogReloadInterval, "connector-catalog-reload-interval", c.ConnectorCatalogReloadInterval, "Interval between reloads of the remote connector catalog sources in golang duration format")
+	fs.StringVar(&c.ConnectorCatalogCacheDir, "connector-catalog-cache-dir", c.ConnectorCatalogCacheDir, "Directory the remote connector catalog sources are cached in, read when a source is unreachable")
 	fs.DurationVar(&c.ConnectorEvalDuration, "connector-eval-duration", c.ConnectorEvalDuration, "Connector eval duration in golang duration format")
 	fs.StringArrayVar(&c.ConnectorEvalOrganizations, "connector-eval-organizations", c.ConnectorEvalOrganizations, "Connector eval organization IDs")
 	fs.BoolVar(&c.ConnectorNamespaceLifecycleAPI, "connector-namespace-lifecycle-api", c.ConnectorNamespaceLifecycleAPI, "Enable APIs to create, update, delete non-eval Namespaces")
 	fs.BoolVar(&c.ConnectorEnableUnassignedConnectors, "connector-enable-unassigned-connectors", c.ConnectorEnableUnassignedConnectors, "Enable support for 'unassigned' state for Connectors")
+	fs.DurationVar(&c.ConnectorMeteringInterval, "connector-metering-interval", c.ConnectorMeteringInterval, "Interval between samples of the running connectors for usage metering in golang duration format, disabled when 0")
+	fs.StringVar(&c.ConnectorUsageReporterURL, "connector-usage-reporter-url", c.ConnectorUsageReporterURL, "URL of a usage service the hourly connector usage is reported to, not reported when empty")
+	fs.IntVar(&c.ConnectorRevisionsLimit, "connector-revisions-limit", c.ConnectorRevisionsLimit, "Maximum number of configuration revisions kept for each connector, unlimited when 0")
 }
 
 func (c *ConnectorsConfig) ReadFiles() error {
@@ -71,15 +97,32 @@ func (c *ConnectorsConfig) ReadFiles() error {
 		return err
 	}
 
+	// keep metadata to merge with remote catalog sources on reload
+	c.connectorMetadata = make(map[string]ConnectorMetadata, len(connectorMetadata))
+	for id, m := range connectorMetadata {
+		c.connectorMetadata[id] = m
+	}
+
 	// read catalogs and merge metadata, removing entries from the map
 	err = c.readConnectorCatalog(connectorMetadata)
 	if err != nil {
 		return err
 	}
 
-	// check if there are any unused metadata entries left
+	// read remote catalog sources and merge them with the local catalog entries, an unreachable source falls back
+	// to its cached entries or is left out of the catalog until it is reloaded
+	c.localCatalogEntries = append([]ConnectorCatalogEntry{}, c.CatalogEntries...)
+	_, sourceErr := c.ReloadCatalogSources()
+	if sourceErr != nil {
+		glog.Warningf("connector catalog sources not loaded, retrying on reload: %s", sourceErr)
+	}
+	for _, entry := range c.CatalogEntries {
+		delete(connectorMetadata, entry.ConnectorType.Id)
+	}
+
+	// check if there are any unused metadata entries left, the metadata of unreachable sources are kept for reloads
 	remainingIds := len(connectorMetadata)
-	if remainingIds > 0 {
+	if remainingIds > 0 && sourceErr == nil {
 		ids := make([]string, 0, remainingIds)
 		for id := range connectorMetadata {
 			ids = append(ids, id)
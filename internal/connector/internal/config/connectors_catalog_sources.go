@@ -0,0 +1,403 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+const (
+	ociSourcePrefix       = "oci://"
+	ociManifestMediaTypes = "application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json"
+	catalogSourceTimeout  = 30 * time.Second
+)
+
+// GetCatalogEntries returns the connector catalog entries, sorted by connector type id
+func (c *ConnectorsConfig) GetCatalogEntries() []ConnectorCatalogEntry {
+	c.catalogMutex.RLock()
+	defer c.catalogMutex.RUnlock()
+	return c.CatalogEntries
+}
+
+// GetCatalogChecksums returns a copy of the checksums of the connector catalog entries, by connector type id
+func (c *ConnectorsConfig) GetCatalogChecksums() map[string]string {
+	c.catalogMutex.RLock()
+	defer c.catalogMutex.RUnlock()
+	checksums := make(map[string]string, len(c.CatalogChecksums))
+	for id, sum := range c.CatalogChecksums {
+		checksums[id] = sum
+	}
+	return checksums
+}
+
+// CatalogSourcesLoaded returns true when all the remote connector catalog sources were read by the last reload
+func (c *ConnectorsConfig) CatalogSourcesLoaded() bool {
+	return len(c.ConnectorCatalogSources) == 0 || c.catalogSourcesLoaded
+}
+
+// ReloadCatalogSources fetches the remote connector catalog sources that changed since they were last fetched, using
+// their ETag, and merges their entries with the local catalog entries. It returns true when the checksums of the
+// catalog entries changed. The sources that could not be read keep their last entries, or the entries cached in the
+// catalog cache directory, and are reported in the returned error so that they are fetched again.
+func (c *ConnectorsConfig) ReloadCatalogSources() (bool, error) {
+	if len(c.ConnectorCatalogSources) == 0 {
+		return false, nil
+	}
+	if c.catalogSourceEntries == nil {
+		c.catalogSourceEntries = make(map[string][]ConnectorCatalogEntry)
+		c.catalogSourceETags = make(map[string]string)
+	}
+
+	c.catalogSourcesLoaded = false
+	sourceEntries := make(map[string][]ConnectorCatalogEntry, len(c.ConnectorCatalogSources))
+	sourceETags := make(map[string]string, len(c.ConnectorCatalogSources))
+	modified := false
+	var sourceErrors []string
+	for _, source := range c.ConnectorCatalogSources {
+		entries, etag, err := c.fetchCatalogSource(source, c.catalogSourceETags[source])
+		if err != nil {
+			sourceErrors = append(sourceErrors, fmt.Sprintf("error reading connector catalog source %s: %s", source, err))
+			if previous, found := c.catalogSourceEntries[source]; found {
+				sourceEntries[source] = previous
+				sourceETags[source] = c.catalogSourceETags[source]
+				continue
+			}
+			cached, cerr := c.readCachedCatalogSource(source)
+			if cerr != nil {
+				glog.Warningf("error reading cached connector catalog source %s: %s", source, cerr)
+				continue
+			}
+			if cached != nil {
+				glog.Warningf("using cached connector catalog source %s", source)
+				sourceEntries[source] = cached
+				modified = true
+			}
+			continue
+		}
+		if entries == nil {
+			// not modified
+			sourceEntries[source] = c.catalogSourceEntries[source]
+			sourceETags[source] = c.catalogSourceETags[source]
+			continue
+		}
+		if err := c.writeCachedCatalogSource(source, entries); err != nil {
+			glog.Warningf("error caching connector catalog source %s: %s", source, err)
+		}
+		sourceEntries[source] = entries
+		sourceETags[source] = etag
+		modified = true
+	}
+	var sourceErr error
+	if len(sourceErrors) > 0 {
+		sourceErr = fmt.Errorf("%s", strings.Join(sourceErrors, ", "))
+	}
+	if !modified {
+		c.catalogSourcesLoaded = sourceErr == nil
+		return false, sourceErr
+	}
+
+	catalogEntries := append([]ConnectorCatalogEntry{}, c.localCatalogEntries...)
+	checksums := make(map[string]string, len(catalogEntries))
+	for _, entry := range catalogEntries {
+		sum, err := checksum(entry)
+		if err != nil {
+			return false, fmt.Errorf("error computing checksum for connector %s: %s", entry.ConnectorType.Id, err)
+		}
+		checksums[entry.ConnectorType.Id] = sum
+	}
+	for _, source := range c.ConnectorCatalogSources {
+		for _, entry := range sourceEntries[source] {
+			id := entry.ConnectorType.Id
+			if id == "" {
+				return false, fmt.Errorf("missing connector type id in connector catalog source %s", source)
+			}
+
+			// set catalog metadata from metadata config if any, remote entries may carry their own
+			if meta, found := c.connectorMetadata[id]; found {
+				entry.ConnectorType.FeaturedRank = meta.FeaturedRank
+				entry.ConnectorType.Labels = meta.Labels
+				entry.ConnectorType.Annotations = meta.Annotations
+			}
+
+			sum, err := checksum(entry)
+			if err != nil {
+				return false, fmt.Errorf("error computing checksum for connector %s: %s", id, err)
+			}
+			if prev, found := checksums[id]; found {
+				if prev == sum {
+					continue
+				}
+				return false, fmt.Errorf("connector type '%s' defined more than once, last in connector catalog source %s", id, source)
+			}
+			checksums[id] = sum
+			catalogEntries = append(catalogEntries, entry)
+		}
+	}
+	sort.Slice(catalogEntries, func(i, j int) bool {
+		return catalogEntries[i].ConnectorType.Id < catalogEntries[j].ConnectorType.Id
+	})
+
+	c.catalogSourceEntries = sourceEntries
+	c.catalogSourceETags = sourceETags
+	c.catalogSourcesLoaded = sourceErr == nil
+
+	c.catalogMutex.Lock()
+	defer c.catalogMutex.Unlock()
+
+	changed := len(checksums) != len(c.CatalogChecksums)
+	for id, sum := range checksums {
+		changed = changed || c.CatalogChecksums[id] != sum
+	}
+	if !changed {
+		return false, sourceErr
+	}
+	c.CatalogEntries = catalogEntries
+	c.CatalogChecksums = checksums
+
+	glog.Infof("loaded %d connector types with %d remote catalog sources", len(catalogEntries), len(c.ConnectorCatalogSources))
+
+	return true, sourceErr
+}
+
+// cachedCatalogSourcePath returns the path of the cache file of a catalog source, or an empty path without cache directory
+func (c *ConnectorsConfig) cachedCatalogSourcePath(source string) string {
+	if c.ConnectorCatalogCacheDir == "" {
+		return ""
+	}
+	return filepath.Join(c.ConnectorCatalogCacheDir, fmt.Sprintf("%x.json", sha256.Sum256([]byte(source))))
+}
+
+// readCachedCatalogSource returns the entries of a catalog source last cached, or nil entries when it was never cached
+func (c *ConnectorsConfig) readCachedCatalogSource(source string) ([]ConnectorCatalogEntry, error) {
+	file := c.cachedCatalogSourcePath(source)
+	if file == "" {
+		return nil, nil
+	}
+	buf, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parseCatalogDocument(buf)
+}
+
+// writeCachedCatalogSource caches the entries of a catalog source to read them when the source is unreachable
+func (c *ConnectorsConfig) writeCachedCatalogSource(source string, entries []ConnectorCatalogEntry) error {
+	file := c.cachedCatalogSourcePath(source)
+	if file == "" {
+		return nil
+	}
+	buf, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	// written to a temporary file first so that a partially written cache is never read
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
+// fetchCatalogSource reads the catalog entries of a source, it returns nil entries when the source ETag matches etag
+func (c *ConnectorsConfig) fetchCatalogSource(source string, etag string) ([]ConnectorCatalogEntry, string, error) {
+	if strings.HasPrefix(source, ociSourcePrefix) {
+		return c.fetchOciCatalogSource(strings.TrimPrefix(source, ociSourcePrefix), etag)
+	}
+
+	body, etag, err := c.fetchCatalogUrl(source, etag, "application/json", "")
+	if err != nil || body == nil {
+		return nil, etag, err
+	}
+	entries, err := parseCatalogDocument(body)
+	if err != nil {
+		return nil, "", err
+	}
+	return entries, etag, nil
+}
+
+// fetchOciCatalogSource reads the catalog entries of an OCI artifact referenced as host/repository[:tag|@digest], each
+// layer of the artifact is a catalog document. The manifest digest is used as the artifact ETag.
+func (c *ConnectorsConfig) fetchOciCatalogSource(reference string, etag string) ([]ConnectorCatalogEntry, string, error) {
+	host, repository, found := strings.Cut(reference, "/")
+	if !found || host == "" || repository == "" {
+		return nil, "", fmt.Errorf("invalid oci artifact reference %s", reference)
+	}
+	tag := "latest"
+	if i := strings.LastIndex(repository, "@"); i > 0 {
+		repository, tag = repository[:i], repository[i+1:]
+	} else if i := strings.LastIndex(repository, ":"); i > 0 {
+		repository, tag = repository[:i], repository[i+1:]
+	}
+	baseUrl := fmt.Sprintf("https://%s/v2/%s", host, repository)
+
+	token := ""
+	body, digest, err := c.fetchCatalogUrl(fmt.Sprintf("%s/manifests/%s", baseUrl, tag), etag, ociManifestMediaTypes, token)
+	if challenge, ok := err.(*registryAuthChallenge); ok {
+		// anonymous pull token
+		if token, err = c.fetchRegistryToken(challenge.header); err != nil {
+			return nil, "", err
+		}
+		body, digest, err = c.fetchCatalogUrl(fmt.Sprintf("%s/manifests/%s", baseUrl, tag), etag, ociManifestMediaTypes, token)
+	}
+	if err != nil || body == nil {
+		return nil, digest, err
+	}
+
+	var manifest struct {
+		Layers []struct {
+			MediaType string `json:"mediaType"`
+			Digest    string `json:"digest"`
+		} `json:"layers"`
+	}
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, "", fmt.Errorf("error unmarshaling oci manifest: %s", err)
+	}
+	if digest == "" {
+		digest = fmt.Sprintf("sha256:%x", sha256.Sum256(body))
+	}
+
+	entries := make([]ConnectorCatalogEntry, 0)
+	for _, layer := range manifest.Layers {
+		blob, _, err := c.fetchCatalogUrl(fmt.Sprintf("%s/blobs/%s", baseUrl, layer.Digest), "", layer.MediaType, token)
+		if err != nil {
+			return nil, "", err
+		}
+		if sum := fmt.Sprintf("sha256:%x", sha256.Sum256(blob)); sum != layer.Digest {
+			return nil, "", fmt.Errorf("oci layer digest %s does not match its content digest %s", layer.Digest, sum)
+		}
+		layerEntries, err := parseCatalogDocument(blob)
+		if err != nil {
+			return nil, "", fmt.Errorf("error reading oci layer %s: %s", layer.Digest, err)
+		}
+		entries = append(entries, layerEntries...)
+	}
+
+	return entries, digest, nil
+}
+
+// registryAuthChallenge is returned by fetchCatalogUrl when a registry requires a bearer token
+type registryAuthChallenge struct {
+	header string
+}
+
+func (e *registryAuthChallenge) Error() string {
+	return "unauthorized: " + e.header
+}
+
+// fetchCatalogUrl returns the body and ETag of a url, or a nil body when its ETag matches etag
+func (c *ConnectorsConfig) fetchCatalogUrl(u string, etag string, accept string, token string) ([]byte, string, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", accept)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, "", fmt.Errorf("error reading %s: %s", u, err)
+		}
+		etag := resp.Header.Get("Docker-Content-Digest")
+		if etag == "" {
+			etag = resp.Header.Get("ETag")
+		}
+		return body, etag, nil
+	case http.StatusNotModified:
+		return nil, etag, nil
+	case http.StatusUnauthorized:
+		if header := resp.Header.Get("WWW-Authenticate"); token == "" && strings.HasPrefix(header, "Bearer ") {
+			return nil, "", &registryAuthChallenge{header: header}
+		}
+	}
+	return nil, "", fmt.Errorf("error reading %s: %s", u, resp.Status)
+}
+
+// fetchRegistryToken gets an anonymous token from the realm of a registry bearer auth challenge
+func (c *ConnectorsConfig) fetchRegistryToken(challenge string) (string, error) {
+	params := map[string]string{}
+	for _, param := range strings.Split(strings.TrimPrefix(challenge, "Bearer "), ",") {
+		if key, value, found := strings.Cut(strings.TrimSpace(param), "="); found {
+			params[key] = strings.Trim(value, `"`)
+		}
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("invalid registry auth challenge %s", challenge)
+	}
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	body, _, err := c.fetchCatalogUrl(realm.String(), "", "application/json", "")
+	if err != nil {
+		return "", err
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("error unmarshaling registry token: %s", err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	return token.Token, nil
+}
+
+func (c *ConnectorsConfig) httpClient() *http.Client {
+	if c.catalogHttpClient == nil {
+		c.catalogHttpClient = &http.Client{Timeout: catalogSourceTimeout}
+	}
+	return c.catalogHttpClient
+}
+
+// parseCatalogDocument reads a catalog entry, or a list of catalog entries
+func parseCatalogDocument(buf []byte) ([]ConnectorCatalogEntry, error) {
+	buf = bytes.TrimSpace(buf)
+	if bytes.HasPrefix(buf, []byte("[")) {
+		var entries []ConnectorCatalogEntry
+		if err := json.Unmarshal(buf, &entries); err != nil {
+			return nil, fmt.Errorf("error unmarshaling catalog: %s", err)
+		}
+		return entries, nil
+	}
+	entry := ConnectorCatalogEntry{}
+	if err := json.Unmarshal(buf, &entry); err != nil {
+		return nil, fmt.Errorf("error unmarshaling catalog: %s", err)
+	}
+	return []ConnectorCatalogEntry{entry}, nil
+}
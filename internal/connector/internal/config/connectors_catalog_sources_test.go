@@ -0,0 +1,212 @@
+package config
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/onsi/gomega"
+)
+
+// catalogServer serves a catalog document with an ETag
+type catalogServer struct {
+	mutex    sync.Mutex
+	document []byte
+	etag     string
+	status   int
+}
+
+func (s *catalogServer) set(document []byte, etag string, status int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.document, s.etag, s.status = document, etag, status
+}
+
+func (s *catalogServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.status != http.StatusOK {
+		w.WriteHeader(s.status)
+		return
+	}
+	if r.Header.Get("If-None-Match") == s.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", s.etag)
+	_, _ = w.Write(s.document)
+}
+
+func TestConnectorsConfig_ReloadCatalogSources(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	// local catalog with the aws-sqs-source connector type only
+	catalogDir, err := os.MkdirTemp("", "connector-catalog-")
+	g.Expect(err).To(gomega.BeNil())
+	defer func() {
+		_ = os.RemoveAll(catalogDir)
+	}()
+	source := shared.BuildFullFilePath("./internal/connector/test/integration/connector-catalog")
+	data, err := os.ReadFile(path.Join(source, "aws-sqs-source-v1alpha1.json"))
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(os.WriteFile(path.Join(catalogDir, "aws-sqs-source-v1alpha1.json"), data, 0644)).To(gomega.Succeed())
+
+	// remote catalog with the log_sink connector type
+	logSink, err := os.ReadFile(path.Join(source, "log_sink_0.1.json"))
+	g.Expect(err).To(gomega.BeNil())
+	server := &catalogServer{}
+	server.set(logSink, `"1"`, http.StatusOK)
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	c := NewConnectorsConfig()
+	c.ConnectorMetadataDirs = []string{"./internal/connector/test/integration/connector-metadata"}
+	c.ConnectorCatalogDirs = []string{catalogDir}
+	c.ConnectorCatalogSources = []string{httpServer.URL}
+	g.Expect(c.ReadFiles()).To(gomega.Succeed())
+	g.Expect(c.GetCatalogEntries()).To(gomega.HaveLen(2))
+	g.Expect(c.GetCatalogEntries()[1].ConnectorType.Id).To(gomega.Equal("log_sink_0.1"))
+	// metadata is merged with remote catalog entries
+	g.Expect(c.GetCatalogEntries()[1].ConnectorType.Labels).To(gomega.Equal([]string{"sink"}))
+	checksums := c.GetCatalogChecksums()
+	g.Expect(checksums).To(gomega.HaveLen(2))
+
+	// not modified
+	changed, err := c.ReloadCatalogSources()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(changed).To(gomega.BeFalse())
+
+	// modified
+	server.set([]byte(strings.Replace(string(logSink), `"Multi Line"`, `"Multiple Lines"`, 1)), `"2"`, http.StatusOK)
+	changed, err = c.ReloadCatalogSources()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(changed).To(gomega.BeTrue())
+	g.Expect(c.GetCatalogChecksums()["aws-sqs-source-v1alpha1"]).To(gomega.Equal(checksums["aws-sqs-source-v1alpha1"]))
+	g.Expect(c.GetCatalogChecksums()["log_sink_0.1"]).ToNot(gomega.Equal(checksums["log_sink_0.1"]))
+
+	// catalog left unchanged when a source can't be read
+	server.set(nil, "", http.StatusInternalServerError)
+	changed, err = c.ReloadCatalogSources()
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("500 Internal Server Error")))
+	g.Expect(changed).To(gomega.BeFalse())
+	g.Expect(c.GetCatalogEntries()).To(gomega.HaveLen(2))
+
+	// removed
+	server.set([]byte("[]"), `"3"`, http.StatusOK)
+	changed, err = c.ReloadCatalogSources()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(changed).To(gomega.BeTrue())
+	g.Expect(c.GetCatalogEntries()).To(gomega.HaveLen(1))
+	g.Expect(c.GetCatalogChecksums()).To(gomega.HaveLen(1))
+
+	// conflicting with a local connector type
+	server.set([]byte(strings.Replace(string(data), `"name" : "aws-sqs-source",`, `"name" : "aws-queue-source",`, 1)), `"4"`, http.StatusOK)
+	_, err = c.ReloadCatalogSources()
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("connector type 'aws-sqs-source-v1alpha1' defined more than once")))
+}
+
+func TestConnectorsConfig_ReadFilesWithUnreachableCatalogSource(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	cacheDir, err := os.MkdirTemp("", "connector-catalog-cache-")
+	g.Expect(err).To(gomega.BeNil())
+	defer func() {
+		_ = os.RemoveAll(cacheDir)
+	}()
+	source := shared.BuildFullFilePath("./internal/connector/test/integration/connector-catalog")
+	logSink, err := os.ReadFile(path.Join(source, "log_sink_0.1.json"))
+	g.Expect(err).To(gomega.BeNil())
+	server := &catalogServer{}
+	server.set(nil, "", http.StatusServiceUnavailable)
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	newConfig := func() *ConnectorsConfig {
+		c := NewConnectorsConfig()
+		c.ConnectorMetadataDirs = []string{"./internal/connector/test/integration/connector-metadata"}
+		c.ConnectorCatalogDirs = []string{"./internal/connector/test/integration/connector-catalog"}
+		c.ConnectorCatalogSources = []string{httpServer.URL + "/log_sink_0.1.json"}
+		c.ConnectorCatalogCacheDir = cacheDir
+		return c
+	}
+
+	// the local catalog is loaded when the source was never cached
+	c := newConfig()
+	g.Expect(c.ReadFiles()).To(gomega.Succeed())
+	g.Expect(c.CatalogSourcesLoaded()).To(gomega.BeFalse())
+	local := len(c.GetCatalogEntries())
+
+	// the source is cached once it is reachable
+	server.set([]byte(strings.Replace(string(logSink), `"id" : "log_sink_0.1"`, `"id" : "remote_log_sink_0.1"`, 1)), `"1"`, http.StatusOK)
+	changed, err := c.ReloadCatalogSources()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(changed).To(gomega.BeTrue())
+	g.Expect(c.CatalogSourcesLoaded()).To(gomega.BeTrue())
+	g.Expect(c.GetCatalogEntries()).To(gomega.HaveLen(local + 1))
+
+	// the cached source is loaded when it is unreachable
+	server.set(nil, "", http.StatusServiceUnavailable)
+	c = newConfig()
+	g.Expect(c.ReadFiles()).To(gomega.Succeed())
+	g.Expect(c.CatalogSourcesLoaded()).To(gomega.BeFalse())
+	g.Expect(c.GetCatalogEntries()).To(gomega.HaveLen(local + 1))
+	_, err = c.ReloadCatalogSources()
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("503 Service Unavailable")))
+	g.Expect(c.GetCatalogEntries()).To(gomega.HaveLen(local + 1))
+}
+
+func TestConnectorsConfig_ReloadOciCatalogSource(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	source := shared.BuildFullFilePath("./internal/connector/test/integration/connector-catalog")
+	logSink, err := os.ReadFile(path.Join(source, "log_sink_0.1.json"))
+	g.Expect(err).To(gomega.BeNil())
+	layerDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(logSink))
+	manifest := fmt.Sprintf(`{"schemaVersion":2,"layers":[{"mediaType":"application/json","digest":"%s"}]}`, layerDigest)
+	manifestDigest := fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(manifest)))
+
+	var registry *httptest.Server
+	registry = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			g.Expect(r.URL.Query().Get("scope")).To(gomega.Equal("repository:catalogs/connectors:pull"))
+			_, _ = w.Write([]byte(`{"token":"anonymous"}`))
+		case r.Header.Get("Authorization") != "Bearer anonymous":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:catalogs/connectors:pull"`, registry.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/catalogs/connectors/manifests/v1":
+			if r.Header.Get("If-None-Match") == manifestDigest {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", manifestDigest)
+			_, _ = w.Write([]byte(manifest))
+		case r.URL.Path == "/v2/catalogs/connectors/blobs/"+layerDigest:
+			_, _ = w.Write(logSink)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer registry.Close()
+
+	c := NewConnectorsConfig()
+	c.catalogHttpClient = registry.Client()
+	c.ConnectorCatalogSources = []string{"oci://" + strings.TrimPrefix(registry.URL, "https://") + "/catalogs/connectors:v1"}
+
+	changed, err := c.ReloadCatalogSources()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(changed).To(gomega.BeTrue())
+	g.Expect(c.GetCatalogEntries()).To(gomega.HaveLen(1))
+	g.Expect(c.GetCatalogEntries()[0].ConnectorType.Id).To(gomega.Equal("log_sink_0.1"))
+
+	changed, err = c.ReloadCatalogSources()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(changed).To(gomega.BeFalse())
+}
@@ -1,11 +1,13 @@
 package handlers
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/admin/private"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
@@ -36,6 +38,8 @@ type ConnectorAdminHandler struct {
 	QuotaConfig           *config.ConnectorsQuotaConfig
 	ConnectorCluster      *ConnectorClusterHandler //TODO: eventually move deployment handling into a deployment service
 	ConnectorTypesService services.ConnectorTypesService
+	SecretsService        services.ConnectorSecretsService
+	UsageService          services.ConnectorUsageService
 }
 
 func NewConnectorAdminHandler(handler ConnectorAdminHandler) *ConnectorAdminHandler {
@@ -143,6 +147,73 @@ func (h *ConnectorAdminHandler) UpgradeConnectorsByOperator(writer http.Response
 	handlers.Handle(writer, request, &cfg, http.StatusNoContent)
 }
 
+// connectorWarnings returns the deprecation warnings of a connector, if its connector type is available
+func (h *ConnectorAdminHandler) connectorWarnings(connector *dbapi.Connector) []string {
+	ct, err := h.ConnectorTypesService.Get(connector.ConnectorTypeId)
+	if err != nil {
+		return nil
+	}
+	return connectorWarnings(ct, connector.Channel)
+}
+
+func (h *ConnectorAdminHandler) GetConnectorUpgradesByDeprecation(writer http.ResponseWriter, request *http.Request) {
+	id := mux.Vars(request)["connector_cluster_id"]
+	cfg := handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_cluster_id", &id, handlers.MinLen(1), handlers.MaxLen(maxConnectorClusterIdLength)),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+
+			if _, serviceError = h.Service.Get(request.Context(), id); serviceError != nil {
+				return nil, serviceError
+			}
+			listArgs := coreservices.NewListArguments(request.URL.Query())
+			upgrades, paging, serviceError := h.Service.GetAvailableDeploymentDeprecationUpgrades(id, listArgs)
+			if serviceError != nil {
+				return nil, serviceError
+			}
+			result := make([]private.ConnectorAvailableDeprecationUpgrade, len(upgrades))
+			for i := range upgrades {
+				result[i] = *presenters.PresentConnectorAvailableDeprecationUpgrade(&upgrades[i])
+			}
+
+			i = private.ConnectorAvailableDeprecationUpgradeList{
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: result,
+			}
+			return
+		},
+	}
+
+	handlers.HandleGet(writer, request, &cfg)
+}
+
+func (h *ConnectorAdminHandler) UpgradeConnectorsByDeprecation(writer http.ResponseWriter, request *http.Request) {
+	var resource []private.ConnectorAvailableDeprecationUpgrade
+	id := mux.Vars(request)["connector_cluster_id"]
+	cfg := handlers.HandlerConfig{
+		MarshalInto: &resource,
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_cluster_id", &id, handlers.MinLen(1), handlers.MaxLen(maxConnectorClusterIdLength)),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+
+			if _, serviceError = h.Service.Get(request.Context(), id); serviceError != nil {
+				return nil, serviceError
+			}
+			upgrades := make(dbapi.ConnectorDeploymentDeprecationUpgradeList, len(resource))
+			for i2 := range resource {
+				upgrades[i2] = *presenters.ConvertConnectorAvailableDeprecationUpgrade(&resource[i2])
+			}
+			return nil, h.Service.UpgradeConnectorsByDeprecation(request.Context(), id, upgrades)
+		},
+	}
+
+	handlers.Handle(writer, request, &cfg, http.StatusNoContent)
+}
+
 func (h *ConnectorAdminHandler) GetClusterNamespaces(writer http.ResponseWriter, request *http.Request) {
 	id := mux.Vars(request)["connector_cluster_id"]
 	listArgs := coreservices.NewListArguments(request.URL.Query())
@@ -334,6 +405,7 @@ func (h *ConnectorAdminHandler) GetClusterConnectors(writer http.ResponseWriter,
 				if err != nil {
 					return nil, err
 				}
+				result.Items[i].Warnings = h.connectorWarnings(&namespace.Connector)
 			}
 
 			return result, nil
@@ -375,6 +447,7 @@ func (h *ConnectorAdminHandler) GetNamespaceConnectors(writer http.ResponseWrite
 				if err != nil {
 					return nil, err
 				}
+				result.Items[i].Warnings = h.connectorWarnings(&namespace.Connector)
 			}
 
 			return result, nil
@@ -396,7 +469,12 @@ func (h *ConnectorAdminHandler) GetConnector(writer http.ResponseWriter, request
 			if serviceError != nil {
 				return nil, serviceError
 			}
-			return presenters.PresentConnectorAdminView(connector)
+			view, serviceError := presenters.PresentConnectorAdminView(connector)
+			if serviceError != nil {
+				return nil, serviceError
+			}
+			view.Warnings = h.connectorWarnings(&connector.Connector)
+			return view, nil
 		},
 	}
 
@@ -449,6 +527,185 @@ func (h *ConnectorAdminHandler) DeleteConnector(writer http.ResponseWriter, requ
 	handlers.HandleDelete(writer, request, &cfg, http.StatusNoContent)
 }
 
+func (h *ConnectorAdminHandler) RotateConnectorSecrets(writer http.ResponseWriter, request *http.Request) {
+	connectorId := mux.Vars(request)["connector_id"]
+	var resource private.ConnectorSecretsRotationRequest
+	cfg := handlers.HandlerConfig{
+		MarshalInto: &resource,
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+			func() *errors.ServiceError {
+				if resource.ServiceAccount == nil {
+					return nil
+				}
+				if err := handlers.Validation("service_account.client_id", &resource.ServiceAccount.ClientId, handlers.MinLen(1))(); err != nil {
+					return err
+				}
+				return handlers.Validation("service_account.client_secret", &resource.ServiceAccount.ClientSecret, handlers.MinLen(1))()
+			},
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+
+			var serviceAccount *dbapi.ServiceAccount
+			if resource.ServiceAccount != nil {
+				serviceAccount = &dbapi.ServiceAccount{
+					ClientId:     resource.ServiceAccount.ClientId,
+					ClientSecret: resource.ServiceAccount.ClientSecret,
+				}
+			}
+
+			ctx := request.Context()
+			if _, serviceError = h.SecretsService.RotateConnectorSecrets(ctx, connectorId, serviceAccount); serviceError != nil {
+				return nil, serviceError
+			}
+			connector, serviceError := h.ConnectorsService.Get(ctx, connectorId)
+			if serviceError != nil {
+				return nil, serviceError
+			}
+			return presenters.PresentConnectorAdminView(connector)
+		},
+	}
+
+	handlers.Handle(writer, request, &cfg, http.StatusAccepted)
+}
+
+func (h *ConnectorAdminHandler) RotateConnectorsSecrets(writer http.ResponseWriter, request *http.Request) {
+	cfg := handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+
+			rotation, serviceError := h.SecretsService.RequestSecretsRotation(request.Context())
+			if serviceError != nil {
+				return nil, serviceError
+			}
+			return presenters.PresentConnectorSecretsRotation(rotation)
+		},
+	}
+
+	handlers.Handle(writer, request, &cfg, http.StatusAccepted)
+}
+
+func (h *ConnectorAdminHandler) GetConnectorsSecretsRotation(writer http.ResponseWriter, request *http.Request) {
+	rotationId := mux.Vars(request)["rotation_id"]
+	cfg := handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("rotation_id", &rotationId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+
+			rotation, serviceError := h.SecretsService.GetSecretsRotation(request.Context(), rotationId)
+			if serviceError != nil {
+				return nil, serviceError
+			}
+			return presenters.PresentConnectorSecretsRotation(rotation)
+		},
+	}
+
+	handlers.HandleGet(writer, request, &cfg)
+}
+
+func (h *ConnectorAdminHandler) DeleteOrphanedConnectorSecrets(writer http.ResponseWriter, request *http.Request) {
+	dryRun := parseBoolParam(request.URL.Query().Get("dry_run"))
+	cfg := handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+
+			report, serviceError := h.SecretsService.DeleteOrphanedSecrets(request.Context(), dryRun)
+			if serviceError != nil {
+				return nil, serviceError
+			}
+			return presenters.PresentConnectorSecretsReport(report), nil
+		},
+	}
+
+	handlers.Handle(writer, request, &cfg, http.StatusOK)
+}
+
+func (h *ConnectorAdminHandler) GetConnectorUsage(writer http.ResponseWriter, request *http.Request) {
+	queryParams := request.URL.Query()
+	from := queryParams.Get("from")
+	to := queryParams.Get("to")
+	format := queryParams.Get("format")
+	query := services.ConnectorUsageQuery{
+		OrganisationId: queryParams.Get("organisation_id"),
+		Period:         queryParams.Get("period"),
+	}
+
+	// usage defaults to the current month
+	now := time.Now().UTC()
+	query.From = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	query.To = now
+
+	cfg := handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("period", &query.Period, handlers.WithDefault(services.ConnectorUsagePeriodHour),
+				handlers.IsOneOf(services.ValidConnectorUsagePeriods...)),
+			handlers.Validation("format", &format, handlers.WithDefault("json"), handlers.IsOneOf("json", "csv")),
+			validateTimeParam("from", from, &query.From),
+			validateTimeParam("to", to, &query.To),
+			func() *errors.ServiceError {
+				if !query.From.Before(query.To) {
+					return errors.BadRequest("from must be before to")
+				}
+				return nil
+			},
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			usage, serviceError := h.UsageService.List(request.Context(), query)
+			if serviceError != nil {
+				return nil, serviceError
+			}
+
+			result := private.ConnectorUsageList{
+				Kind:  "ConnectorUsageList",
+				Page:  1,
+				Size:  int32(len(usage)),
+				Total: int32(len(usage)),
+				Items: make([]private.ConnectorUsage, len(usage)),
+			}
+			for i := range usage {
+				result.Items[i] = presenters.PresentConnectorUsage(&usage[i])
+			}
+			if format == "csv" {
+				// csv export for billing
+				return handlers.FileResponse{
+					ContentType: "text/csv",
+					FileName:    "connector_usage.csv",
+					Write: func(w io.Writer) error {
+						return writeConnectorUsageCSV(w, result)
+					},
+				}, nil
+			}
+			return result, nil
+		},
+	}
+
+	handlers.HandleGet(writer, request, &cfg)
+}
+
+func writeConnectorUsageCSV(w io.Writer, usage private.ConnectorUsageList) error {
+	csvWriter := csv.NewWriter(w)
+	_ = csvWriter.Write([]string{"organisation_id", "namespace_id", "connector_type_id", "period_start", "connector_hours"})
+	for _, u := range usage.Items {
+		_ = csvWriter.Write([]string{u.OrganisationId, u.NamespaceId, u.ConnectorTypeId,
+			u.PeriodStart.Format(time.RFC3339), strconv.FormatFloat(u.ConnectorHours, 'f', -1, 64)})
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func validateTimeParam(field string, value string, result *time.Time) handlers.Validate {
+	return func() *errors.ServiceError {
+		if value == "" {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return errors.BadRequest("%s is not valid. Must be a RFC3339 date-time: %s", field, err)
+		}
+		*result = t
+		return nil
+	}
+}
+
 func (h *ConnectorAdminHandler) GetClusterDeployments(writer http.ResponseWriter, request *http.Request) {
 	clusterId := mux.Vars(request)["connector_cluster_id"]
 	channelUpdates := request.URL.Query().Get("channel_updates")
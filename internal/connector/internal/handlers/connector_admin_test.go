@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+)
+
+func TestConnectorAdminHandler_GetConnectorUsage(t *testing.T) {
+	hour := time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+	usage := dbapi.ConnectorUsageList{
+		{OrganisationId: "org-1", NamespaceId: "namespace-1", ConnectorTypeId: "type-1", PeriodStart: hour, ConnectorSeconds: 5400},
+	}
+
+	tests := []struct {
+		name            string
+		query           string
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+		wantQuery       *services.ConnectorUsageQuery
+	}{
+		{
+			name:            "should export the usage as csv",
+			query:           "?format=csv&organisation_id=org-1&period=day&from=2030-01-01T00:00:00Z&to=2030-01-02T00:00:00Z",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv",
+			wantBody: "organisation_id,namespace_id,connector_type_id,period_start,connector_hours\n" +
+				"org-1,namespace-1,type-1,2030-01-01T10:00:00Z,1.5\n",
+			wantQuery: &services.ConnectorUsageQuery{
+				OrganisationId: "org-1",
+				Period:         services.ConnectorUsagePeriodDay,
+				From:           time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:             time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:            "should return the usage as json by default",
+			query:           "?from=2030-01-01T00:00:00Z&to=2030-01-02T00:00:00Z",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantQuery: &services.ConnectorUsageQuery{
+				Period: services.ConnectorUsagePeriodHour,
+				From:   time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:     time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "should reject a from that is not a RFC3339 date-time",
+			query:      "?format=csv&from=2030-01-01",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "should reject a to that is not a RFC3339 date-time",
+			query:      "?to=tomorrow",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "should reject a from that is not before to",
+			query:      "?format=csv&from=2030-01-02T00:00:00Z&to=2030-01-01T00:00:00Z",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "should reject an unknown format",
+			query:      "?format=xml",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "should reject an unknown period",
+			query:      "?format=csv&period=week",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			usageService := &services.ConnectorUsageServiceMock{
+				ListFunc: func(ctx context.Context, query services.ConnectorUsageQuery) (dbapi.ConnectorUsageList, *errors.ServiceError) {
+					return usage, nil
+				},
+			}
+			h := &ConnectorAdminHandler{UsageService: usageService}
+			request := httptest.NewRequest(http.MethodGet, "/api/connector_mgmt/v1/admin/kafka_connector_usage"+tt.query, nil)
+			recorder := httptest.NewRecorder()
+
+			h.GetConnectorUsage(recorder, request)
+			g.Expect(recorder.Code).To(gomega.Equal(tt.wantStatus))
+			if tt.wantQuery == nil {
+				g.Expect(usageService.ListCalls()).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(usageService.ListCalls()).To(gomega.HaveLen(1))
+			g.Expect(usageService.ListCalls()[0].Query).To(gomega.Equal(*tt.wantQuery))
+			g.Expect(recorder.Header().Get("Content-Type")).To(gomega.Equal(tt.wantContentType))
+			if tt.wantBody != "" {
+				g.Expect(recorder.Header().Get("Content-Disposition")).To(gomega.Equal(`attachment; filename="connector_usage.csv"`))
+				g.Expect(recorder.Body.String()).To(gomega.Equal(tt.wantBody))
+			} else {
+				var result private.ConnectorUsageList
+				g.Expect(json.Unmarshal(recorder.Body.Bytes(), &result)).To(gomega.Succeed())
+				g.Expect(result.Total).To(gomega.Equal(int32(1)))
+				g.Expect(result.Items[0].ConnectorHours).To(gomega.Equal(1.5))
+			}
+		})
+	}
+}
+
+func Test_validateTimeParam(t *testing.T) {
+	defaultTime := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "should keep the default time when the param is not set",
+			value: "",
+			want:  defaultTime,
+		},
+		{
+			name:  "should parse a RFC3339 date-time",
+			value: "2030-02-01T10:00:00+01:00",
+			want:  time.Date(2030, 2, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "should reject a date without time",
+			value:   "2030-02-01",
+			want:    defaultTime,
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			result := defaultTime
+			err := validateTimeParam("from", tt.value, &result)()
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if err != nil {
+				g.Expect(err.Code).To(gomega.Equal(errors.ErrorBadRequest))
+				g.Expect(err.Reason).To(gomega.ContainSubstring("from is not valid"))
+			}
+			g.Expect(result.Equal(tt.want)).To(gomega.BeTrue())
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,7 +14,6 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/private"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/presenters"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/getsentry/sentry-go"
@@ -199,7 +197,7 @@ func (h *ConnectorClusterHandler) ListDeployments(w http.ResponseWriter, r *http
 										return nil, errors.GeneralError("internal error")
 									}
 
-									if waitForCancelOrTimeoutOrNotification(ctx, 30*time.Second, sub) {
+									if handlers.WaitForCancelOrTimeoutOrNotification(ctx, 30*time.Second, sub) {
 										// ctx was canceled... likely due to the http connection being closed by
 										// the client.  Signal the event stream is done.
 										return io.EOF, nil
@@ -244,20 +242,6 @@ func (h *ConnectorClusterHandler) resolveConnectorRefsAndPresentDeployment(conne
 	return converted, nil
 }
 
-// waitForCancelOrTimeoutOrNotification returns true if the context has been canceled or false after the timeout or sub signal
-func waitForCancelOrTimeoutOrNotification(ctx context.Context, timeout time.Duration, sub *signalbus.Subscription) bool {
-	tc, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-	select {
-	case <-tc.Done():
-		return false
-	case <-sub.Signal():
-		return false
-	case <-ctx.Done():
-		return true
-	}
-}
-
 func (h *ConnectorClusterHandler) GetDeployment(w http.ResponseWriter, r *http.Request) {
 	connectorClusterId := mux.Vars(r)["connector_cluster_id"]
 	deploymentId := mux.Vars(r)["deployment_id"]
@@ -0,0 +1,564 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/authz"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/phase"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/secrets"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/spyzhov/ajson"
+)
+
+// connectorApplyStep is a step of the plan of a connector apply
+type connectorApplyStep struct {
+	item public.ConnectorApplyItem
+	ct   *dbapi.ConnectorType
+	// the connector to create or update, nil when there is nothing to apply
+	connector *dbapi.Connector
+	// the vault secrets of the connector before the update
+	originalSecrets []string
+}
+
+// Apply is the handler for applying a list of desired connectors
+func (h ConnectorsHandler) Apply(w http.ResponseWriter, r *http.Request) {
+	dryRun := parseBoolParam(r.URL.Query().Get("dry_run"))
+
+	var request public.ConnectorApplyRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &request,
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			steps, serr := h.planConnectorApply(ctx, &request)
+			if serr != nil {
+				return nil, serr
+			}
+
+			result := public.ConnectorApplyResult{
+				DryRun: dryRun,
+				Items:  make([]public.ConnectorApplyItem, 0, len(steps)),
+			}
+			failed := false
+			for _, step := range steps {
+				failed = failed || step.item.Error != ""
+			}
+			if !dryRun && !failed {
+				if serr := h.applyConnectorPlan(ctx, steps); serr != nil {
+					return nil, serr
+				}
+				result.Applied = true
+			}
+
+			for _, step := range steps {
+				if step.item.Action == public.CONNECTORAPPLYACTION_CREATE && result.Applied {
+					step.item.Id = step.connector.ID
+				}
+				result.Items = append(result.Items, step.item)
+			}
+			return result, nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// planConnectorApply computes the steps to reconcile the connectors of the namespaces with the desired connectors,
+// the steps of invalid items hold the reason in their error. The connectors that are not desired are only deleted
+// when pruning, from the namespaces listed explicitly in the request.
+func (h ConnectorsHandler) planConnectorApply(ctx context.Context, request *public.ConnectorApplyRequest) ([]*connectorApplyStep, *errors.ServiceError) {
+	user := h.authZService.GetValidationUser(ctx)
+
+	if request.Prune && len(request.NamespaceIds) == 0 {
+		return nil, errors.BadRequest("namespace_ids is required to prune connectors")
+	}
+	if !request.Prune && len(request.NamespaceIds) > 0 {
+		return nil, errors.BadRequest("namespace_ids is only allowed to prune connectors")
+	}
+
+	// the namespaces of the desired connectors and the pruned namespaces
+	namespaceIds := append([]string{}, request.NamespaceIds...)
+	desired := make(map[string]bool, len(request.Connectors))
+	for i := range request.Connectors {
+		c := &request.Connectors[i]
+		if c.NamespaceId == "" {
+			return nil, errors.BadRequest("connectors[%d].namespace_id is required", i)
+		}
+		if c.Name == "" {
+			return nil, errors.BadRequest("connectors[%d].name is required", i)
+		}
+		key := c.NamespaceId + "/" + c.Name
+		if desired[key] {
+			return nil, errors.BadRequest("duplicate connector %s in namespace %s", c.Name, c.NamespaceId)
+		}
+		desired[key] = true
+		if !arrays.Contains(namespaceIds, c.NamespaceId) {
+			namespaceIds = append(namespaceIds, c.NamespaceId)
+		}
+	}
+
+	existing := make(map[string]*dbapi.ConnectorWithConditions)
+	for i := range namespaceIds {
+		if err := handlers.Validation("namespace_ids", &namespaceIds[i], handlers.MinLen(1), handlers.MaxLen(maxConnectorNamespaceIdLength),
+			user.AuthorizedNamespaceUser(errors.ErrorBadRequest))(); err != nil {
+			return nil, err
+		}
+		listArgs := coreServices.NewListArguments(url.Values{
+			"search": []string{fmt.Sprintf("namespace_id = %s", namespaceIds[i])},
+			"size":   []string{"65500"},
+		})
+		resources, _, serr := h.connectorsService.List(ctx, listArgs, "")
+		if serr != nil {
+			return nil, serr
+		}
+		for _, resource := range resources {
+			if resource.DesiredState == dbapi.ConnectorDeleted {
+				continue
+			}
+			key := namespaceIds[i] + "/" + resource.Name
+			if _, ok := existing[key]; ok {
+				return nil, errors.BadRequest("connector name %s is not unique in namespace %s", resource.Name, namespaceIds[i])
+			}
+			existing[key] = resource
+		}
+	}
+
+	var steps []*connectorApplyStep
+	// the connectors planned to be created in each namespace, counted in the quota of the next ones
+	pending := make(map[string][]*dbapi.Connector)
+	for i := range request.Connectors {
+		c := &request.Connectors[i]
+		if resource, ok := existing[c.NamespaceId+"/"+c.Name]; ok {
+			steps = append(steps, h.planConnectorUpdate(ctx, user, resource, c))
+		} else {
+			step := h.planConnectorCreate(ctx, user, c, pending[c.NamespaceId])
+			if step.connector != nil {
+				pending[c.NamespaceId] = append(pending[c.NamespaceId], step.connector)
+			}
+			steps = append(steps, step)
+		}
+	}
+
+	// connectors of the pruned namespaces that are not desired are deleted
+	var deleted []string
+	for key, resource := range existing {
+		if request.Prune && !desired[key] && arrays.Contains(request.NamespaceIds, *resource.NamespaceId) {
+			deleted = append(deleted, key)
+		}
+	}
+	sort.Strings(deleted)
+	for _, key := range deleted {
+		steps = append(steps, h.planConnectorDelete(ctx, existing[key]))
+	}
+
+	return steps, nil
+}
+
+func (h ConnectorsHandler) planConnectorCreate(ctx context.Context, user *authz.ValidationUser, resource *public.ConnectorRequest,
+	pending []*dbapi.Connector) *connectorApplyStep {
+	step := &connectorApplyStep{
+		item: public.ConnectorApplyItem{
+			Name:        resource.Name,
+			NamespaceId: resource.NamespaceId,
+			Action:      public.CONNECTORAPPLYACTION_CREATE,
+		},
+	}
+
+	validates := []handlers.Validate{
+		handlers.Validation("channel", (*string)(&resource.Channel), handlers.WithDefault("stable"), handlers.MaxLen(40)),
+		handlers.Validation("name", &resource.Name, handlers.MinLen(1), handlers.MaxLen(100)),
+		handlers.Validation("kafka.id", &resource.Kafka.Id, handlers.MinLen(1), handlers.MaxLen(maxKafkaNameLength)),
+		handlers.Validation("kafka.url", &resource.Kafka.Url, handlers.MinLen(1)),
+		handlers.Validation("service_account.client_id", &resource.ServiceAccount.ClientId, handlers.MinLen(1)),
+		handlers.Validation("service_account.client_secret", &resource.ServiceAccount.ClientSecret, handlers.MinLen(1)),
+		handlers.Validation("connector_type_id", &resource.ConnectorTypeId, handlers.MinLen(1), handlers.MaxLen(maxConnectorTypeIdLength)),
+		handlers.Validation("desired_state", (*string)(&resource.DesiredState), handlers.WithDefault("ready"), handlers.IsOneOf(dbapi.ValidDesiredStates...)),
+		validateConnectorRequest(h.connectorTypesService, resource),
+		handlers.Validation("namespace_id", &resource.NamespaceId,
+			user.ValidateNamespaceConnectorQuota(&resource.ConnectorTypeId, (*string)(&resource.Channel), pending...)),
+		validateCreateAnnotations(resource.Annotations),
+		validateRestartPolicy(&resource.RestartPolicy),
+	}
+	for _, v := range validates {
+		if err := v(); err != nil {
+			step.item.Error = err.Reason
+			return step
+		}
+	}
+
+	ct, serr := h.connectorTypesService.Get(resource.ConnectorTypeId)
+	if serr != nil {
+		step.item.Error = fmt.Sprintf("invalid connector type id: %s", resource.ConnectorTypeId)
+		return step
+	}
+
+	addSystemAnnotations(&resource.Annotations, user)
+	// copy type annotations to connector, e.g. for pricing
+	for _, a := range ct.Annotations {
+		resource.Annotations[a.Key] = a.Value
+	}
+
+	convResource, serr := presenters.ConvertConnectorRequest(api.NewID(), *resource)
+	if serr != nil {
+		step.item.Error = serr.Reason
+		return step
+	}
+	convResource.Owner = user.UserId()
+	convResource.OrganisationId = user.OrgId()
+
+	if serr := ValidateConnectorOperation(ctx, h.namespaceService, convResource, phase.CreateConnector); serr != nil {
+		step.item.Error = serr.Reason
+		return step
+	}
+
+	step.ct = ct
+	step.connector = convResource
+	return step
+}
+
+func (h ConnectorsHandler) planConnectorUpdate(ctx context.Context, user *authz.ValidationUser,
+	dbresource *dbapi.ConnectorWithConditions, desired *public.ConnectorRequest) *connectorApplyStep {
+	step := &connectorApplyStep{
+		item: public.ConnectorApplyItem{
+			Name:        desired.Name,
+			NamespaceId: desired.NamespaceId,
+			Id:          dbresource.ID,
+			Action:      public.CONNECTORAPPLYACTION_UPDATE,
+		},
+	}
+
+	ct, serr := h.connectorTypesService.Get(dbresource.ConnectorTypeId)
+	if serr != nil {
+		step.item.Error = fmt.Sprintf("invalid connector type id: %s", dbresource.ConnectorTypeId)
+		return step
+	}
+	if desired.ConnectorTypeId != dbresource.ConnectorTypeId {
+		step.item.Error = fmt.Sprintf("connector_type_id of connector %s cannot be changed from %s", dbresource.ID, dbresource.ConnectorTypeId)
+		return step
+	}
+	if desired.Channel != "" && string(desired.Channel) != dbresource.Channel {
+		step.item.Error = fmt.Sprintf("channel of connector %s cannot be changed from %s", dbresource.ID, dbresource.Channel)
+		return step
+	}
+	if desired.DesiredState == "" {
+		desired.DesiredState = public.CONNECTORDESIREDSTATE_READY
+	}
+
+	originalSecrets, err := getSecretRefs(&dbresource.Connector, ct)
+	if err != nil {
+		step.item.Error = fmt.Sprintf("could not get existing secrets: %v", err)
+		return step
+	}
+	originalResource, serr := presenters.PresentConnector(&dbresource.Connector)
+	if serr != nil {
+		step.item.Error = serr.Reason
+		return step
+	}
+
+	// secrets with unchanged values keep their vault references
+	spec, serr := h.keepUnchangedSecrets(ct, dbresource.ConnectorSpec, desired.Connector)
+	if serr != nil {
+		step.item.Error = serr.Reason
+		return step
+	}
+	serviceAccount := desired.ServiceAccount
+	if serviceAccount.ClientSecret != "" && dbresource.ServiceAccount.ClientSecretRef != "" {
+		if value, err := h.vaultService.GetSecretString(dbresource.ServiceAccount.ClientSecretRef); err == nil && value == serviceAccount.ClientSecret {
+			serviceAccount.ClientSecret = ""
+		}
+	}
+
+	// system and connector type annotations are kept
+	annotations := make(map[string]string, len(desired.Annotations))
+	for k, v := range desired.Annotations {
+		annotations[k] = v
+	}
+	kept := append([]string{}, reservedAnnotations...)
+	for _, a := range ct.Annotations {
+		kept = append(kept, a.Key)
+	}
+	for _, k := range kept {
+		if v, ok := originalResource.Annotations[k]; ok {
+			annotations[k] = v
+		}
+	}
+
+	operation, serr := h.getOperation(originalResource, *desired)
+	if serr != nil {
+		step.item.Error = serr.Reason
+		return step
+	}
+	if operation == phase.UnassignConnector {
+		step.item.Error = fmt.Sprintf("unsupported connector state %s", desired.DesiredState)
+		return step
+	}
+
+	resource, _ := presenters.PresentConnector(&dbresource.Connector)
+	if serr = ValidateConnectorOperation(ctx, h.namespaceService, &dbresource.Connector, operation,
+		func(connector *dbapi.Connector) *errors.ServiceError {
+			resource.DesiredState = public.ConnectorDesiredState(connector.DesiredState)
+			return nil
+		}); serr != nil {
+		step.item.Error = serr.Reason
+		return step
+	}
+
+	// copy over the fields that are allowed to be modified
+	resource.Connector = spec
+	resource.Annotations = annotations
+	resource.Kafka = desired.Kafka
+	resource.ServiceAccount = serviceAccount
+	resource.SchemaRegistry = desired.SchemaRegistry
+	resource.RestartPolicy = desired.RestartPolicy
+
+	if reflect.DeepEqual(originalResource, resource) {
+		step.item.Action = public.CONNECTORAPPLYACTION_NONE
+		return step
+	}
+
+	validates := []handlers.Validate{
+		handlers.Validation("name", &resource.Name, handlers.MinLen(1), handlers.MaxLen(100)),
+		handlers.Validation("connector_type_id", &resource.ConnectorTypeId, handlers.MinLen(1), handlers.MaxLen(maxConnectorTypeIdLength)),
+		handlers.Validation("service_account.client_id", &resource.ServiceAccount.ClientId, handlers.MinLen(1)),
+		handlers.Validation("desired_state", (*string)(&resource.DesiredState), handlers.IsOneOf(dbapi.ValidDesiredStates...)),
+		validatePatchAnnotations(resource.Annotations, originalResource.Annotations),
+		validateRestartPolicy(&resource.RestartPolicy),
+		validateConnector(h.connectorTypesService, &resource),
+		handlers.Validation("namespace_id", &resource.NamespaceId,
+			user.ValidateNamespaceConnectorUpdateQuota(dbresource.ID, &resource.ConnectorTypeId, (*string)(&resource.Channel))),
+	}
+	for _, v := range validates {
+		if err := v(); err != nil {
+			step.item.Error = err.Reason
+			return step
+		}
+	}
+
+	p, serr := presenters.ConvertConnector(resource)
+	if serr != nil {
+		step.item.Error = serr.Reason
+		return step
+	}
+	p.ServiceAccount.ClientSecretRef = dbresource.ServiceAccount.ClientSecretRef
+
+	// update connector phase before desired state
+	if originalResource.Status.State != public.ConnectorState(dbapi.ConnectorStatusPhaseAssigning) {
+		dbresource.Status.Phase = phase.ConnectorStartingPhase[operation]
+		// an update resets the restart attempts of the connector restart policy
+		dbresource.Status.Conditions = nil
+	}
+	p.Status = dbresource.Status
+
+	if step.item.Diff, serr = diffConnectorsWithoutSecrets(&dbresource.Connector, p, ct); serr != nil {
+		step.item.Error = serr.Reason
+		return step
+	}
+
+	step.ct = ct
+	step.connector = p
+	step.originalSecrets = originalSecrets
+	return step
+}
+
+func (h ConnectorsHandler) planConnectorDelete(ctx context.Context, dbresource *dbapi.ConnectorWithConditions) *connectorApplyStep {
+	step := &connectorApplyStep{
+		item: public.ConnectorApplyItem{
+			Name:        dbresource.Name,
+			NamespaceId: *dbresource.NamespaceId,
+			Id:          dbresource.ID,
+			Action:      public.CONNECTORAPPLYACTION_DELETE,
+		},
+	}
+
+	connector := dbresource.Connector
+	if serr := ValidateConnectorOperation(ctx, h.namespaceService, &connector, phase.DeleteConnector); serr != nil {
+		step.item.Error = serr.Reason
+		return step
+	}
+
+	step.connector = &connector
+	return step
+}
+
+// applyConnectorPlan moves the secrets of the created and updated connectors to the vault and applies the plan in a
+// single transaction, the moved secrets are deleted if the plan could not be applied
+func (h ConnectorsHandler) applyConnectorPlan(ctx context.Context, steps []*connectorApplyStep) *errors.ServiceError {
+	var creates, updates []*dbapi.Connector
+	var movedSecrets []string
+	deleteMovedSecrets := func() {
+		for _, s := range movedSecrets {
+			if err := h.vaultService.DeleteSecretString(s); err != nil {
+				logger.Logger.Errorf("failed to delete vault secret key '%s': %v", s, err)
+			}
+		}
+	}
+
+	for _, step := range steps {
+		if step.connector == nil {
+			continue
+		}
+		switch step.item.Action {
+		case public.CONNECTORAPPLYACTION_CREATE, public.CONNECTORAPPLYACTION_UPDATE:
+			before, err := getSecretRefs(step.connector, step.ct)
+			if err != nil {
+				deleteMovedSecrets()
+				return errors.GeneralError("could not get existing secrets: %v", err)
+			}
+			serr := moveSecretsToVault(step.connector, step.ct, h.vaultService, step.item.Action == public.CONNECTORAPPLYACTION_CREATE)
+			after, err := getSecretRefs(step.connector, step.ct)
+			if err == nil {
+				movedSecrets = append(movedSecrets, StringListSubtract(after, before...)...)
+			}
+			if serr != nil {
+				deleteMovedSecrets()
+				return serr
+			}
+			if step.item.Action == public.CONNECTORAPPLYACTION_CREATE {
+				creates = append(creates, step.connector)
+			} else {
+				updates = append(updates, step.connector)
+			}
+		case public.CONNECTORAPPLYACTION_DELETE:
+			updates = append(updates, step.connector)
+		}
+	}
+
+	if serr := h.connectorsService.Apply(ctx, creates, updates); serr != nil {
+		deleteMovedSecrets()
+		return serr
+	}
+
+	// delete the secrets replaced by the updates, unless the connector revisions still reference them
+	var staleSecrets []string
+	for _, step := range steps {
+		if step.connector == nil || step.item.Action != public.CONNECTORAPPLYACTION_UPDATE {
+			continue
+		}
+		newSecrets, err := getSecretRefs(step.connector, step.ct)
+		if err != nil {
+			return errors.GeneralError("could not get existing secrets: %v", err)
+		}
+		revisionSecrets, serr := h.connectorRevisionsService.SecretRefs(step.connector.ID)
+		if serr != nil {
+			return serr
+		}
+		staleSecrets = append(staleSecrets, StringListSubtract(step.originalSecrets, append(newSecrets, revisionSecrets...)...)...)
+	}
+	if len(staleSecrets) > 0 {
+		_ = db.AddPostCommitAction(ctx, func() {
+			for _, s := range staleSecrets {
+				if err := h.vaultService.DeleteSecretString(s); err != nil {
+					logger.Logger.Errorf("failed to delete vault secret key '%s': %v", s, err)
+				}
+			}
+		})
+	}
+
+	return nil
+}
+
+// keepUnchangedSecrets returns the desired connector spec with the secret fields that are empty objects, or set to
+// their current value, replaced by the vault references of the current connector spec
+func (h ConnectorsHandler) keepUnchangedSecrets(ct *dbapi.ConnectorType, current api.JSON, desired map[string]interface{}) (map[string]interface{}, *errors.ServiceError) {
+	refs := make(map[string]map[string]string)
+	if len(current) != 0 {
+		if _, err := secrets.ModifySecrets(ct.JsonSchema, current, func(node *ajson.Node) error {
+			if node.Type() != ajson.Object {
+				return nil
+			}
+			ref := make(map[string]string)
+			for _, key := range node.Keys() {
+				if value, err := node.MustKey(key).GetString(); err == nil {
+					ref[key] = value
+				}
+			}
+			if ref["ref"] != "" {
+				refs[node.Path()] = ref
+			}
+			return nil
+		}); err != nil {
+			return nil, errors.GeneralError("could not get existing secrets: %v", err)
+		}
+	}
+
+	spec, err := json.Marshal(desired)
+	if err != nil {
+		return nil, errors.BadRequest("invalid connector spec: %v", err)
+	}
+	updated, err := secrets.ModifySecrets(ct.JsonSchema, spec, func(node *ajson.Node) error {
+		ref, ok := refs[node.Path()]
+		if !ok {
+			return nil
+		}
+		switch node.Type() {
+		case ajson.Object:
+			if len(node.Keys()) > 0 {
+				return errors.BadRequest("secret field must be set to a string: " + node.Path())
+			}
+		case ajson.String:
+			value, err := node.GetString()
+			if err != nil {
+				return err
+			}
+			if current, err := h.vaultService.GetSecretString(ref["ref"]); err != nil || current != value {
+				return nil
+			}
+		default:
+			return nil
+		}
+		object := make(map[string]*ajson.Node, len(ref))
+		for k, v := range ref {
+			object[k] = ajson.StringNode("", v)
+		}
+		return node.SetObject(object)
+	})
+	if err != nil {
+		switch err := err.(type) {
+		case *errors.ServiceError:
+			return nil, err
+		default:
+			return nil, errors.GeneralError("could not get existing secrets: %v", err)
+		}
+	}
+
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(updated, &result); err != nil {
+		return nil, errors.BadRequest("invalid connector spec: %v", err)
+	}
+	return result, nil
+}
+
+// diffConnectorsWithoutSecrets returns the unified diff of a connector and its update, without their secrets
+func diffConnectorsWithoutSecrets(current *dbapi.Connector, updated *dbapi.Connector, ct *dbapi.ConnectorType) (string, *errors.ServiceError) {
+	a, b := *current, *updated
+	if err := stripSecretReferences(&a, ct); err != nil {
+		return "", err
+	}
+	if err := stripSecretReferences(&b, ct); err != nil {
+		return "", err
+	}
+	presentedA, err := presenters.PresentConnector(&a)
+	if err != nil {
+		return "", err
+	}
+	presentedB, err := presenters.PresentConnector(&b)
+	if err != nil {
+		return "", err
+	}
+	return shared.DiffAsJson(presentedA, presentedB, "current", "desired"), nil
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+)
+
+// connectorWarnings returns warnings about a deprecated connector type or channel of a connector,
+// or about a connector channel that is no longer available
+func connectorWarnings(ct *dbapi.ConnectorType, channel string) []string {
+	var warnings []string
+	if !arrays.Contains(ct.ChannelNames(), channel) {
+		warnings = append(warnings, fmt.Sprintf("channel %s of connector type %s is no longer available", channel, ct.ID))
+	}
+
+	deprecation := ct.ChannelDeprecation(channel)
+	if deprecation == nil {
+		return warnings
+	}
+	warning := fmt.Sprintf("connector type %s is deprecated", ct.ID)
+	if deprecation.Channel != "" {
+		warning = fmt.Sprintf("channel %s of connector type %s is deprecated", channel, ct.ID)
+	}
+	warning += fmt.Sprintf(" and will be removed after %s", deprecation.SunsetAt.UTC().Format(time.RFC3339))
+
+	replacementType, replacementChannel := deprecation.ReplacementConnectorTypeId, deprecation.ReplacementChannel
+	if replacementType == "" {
+		replacementType = ct.ID
+	}
+	if replacementChannel == "" {
+		replacementChannel = channel
+	}
+	if replacementType != ct.ID || replacementChannel != channel {
+		warning += fmt.Sprintf(", the connector will be migrated to connector type %s channel %s", replacementType, replacementChannel)
+	}
+	if deprecation.Reason != "" {
+		warning += ": " + deprecation.Reason
+	}
+
+	return append(warnings, warning)
+}
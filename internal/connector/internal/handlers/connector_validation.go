@@ -2,8 +2,15 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/authz"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/secrets"
+	"github.com/spyzhov/ajson"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"sort"
 	"strings"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
@@ -54,6 +61,26 @@ func connectorValidationFunction(connectorTypesService services.ConnectorTypesSe
 	}
 }
 
+// validateRestartPolicy returns an error for an invalid connector restart policy, a missing policy is valid
+func validateRestartPolicy(policy **public.ConnectorRestartPolicy) handlers.Validate {
+	return func() *errors.ServiceError {
+		p := *policy
+		if p == nil {
+			return nil
+		}
+		if !arrays.Contains(dbapi.ValidRestartPolicyTypes, string(p.Type)) {
+			return errors.BadRequest("restart_policy.type is not valid. Must be one of: %s", strings.Join(dbapi.ValidRestartPolicyTypes, ", "))
+		}
+		if p.MaxAttempts < 0 || p.BackoffSeconds < 0 || p.MaxBackoffSeconds < 0 {
+			return errors.BadRequest("restart_policy max_attempts, backoff_seconds and max_backoff_seconds must not be negative")
+		}
+		if p.MaxBackoffSeconds > 0 && p.MaxBackoffSeconds < p.BackoffSeconds {
+			return errors.BadRequest("restart_policy.max_backoff_seconds must be greater than or equal to restart_policy.backoff_seconds")
+		}
+		return nil
+	}
+}
+
 // annotations are mapped to k8s labels, check that it's not used to set any reserved domain labels
 var reservedDomains = []string{"kubernetes.io/", "k8s.io/", "openshift.io/"}
 
@@ -111,3 +138,117 @@ func validatePatchAnnotations(patched, existing map[string]string) handlers.Vali
 		return nil
 	}
 }
+
+// connectorFieldValidation is a validation of a single field of a proposed connector
+type connectorFieldValidation struct {
+	field    string
+	validate handlers.Validate
+}
+
+// dryRunConnectorRequest validates a proposed connector like a connector create does, without persisting it. The
+// validation errors are collected per field instead of failing on the first error.
+func dryRunConnectorRequest(connectorTypesService services.ConnectorTypesService, connectorsConfig *config.ConnectorsConfig,
+	user *authz.ValidationUser, resource *public.ConnectorRequest) public.ConnectorValidationResult {
+
+	result := public.ConnectorValidationResult{}
+	addError := func(field string, reason string) {
+		result.Errors = append(result.Errors, public.ConnectorValidationError{Field: field, Reason: reason})
+	}
+
+	validations := []connectorFieldValidation{
+		{"channel", handlers.Validation("channel", (*string)(&resource.Channel), handlers.WithDefault("stable"), handlers.MaxLen(40))},
+		{"name", handlers.Validation("name", &resource.Name, handlers.WithDefault("New Connector"), handlers.MinLen(1), handlers.MaxLen(100))},
+		{"kafka.id", handlers.Validation("kafka.id", &resource.Kafka.Id, handlers.MinLen(1), handlers.MaxLen(maxKafkaNameLength))},
+		{"kafka.url", handlers.Validation("kafka.url", &resource.Kafka.Url, handlers.MinLen(1))},
+		{"service_account.client_id", handlers.Validation("service_account.client_id", &resource.ServiceAccount.ClientId, handlers.MinLen(1))},
+		{"service_account.client_secret", handlers.Validation("service_account.client_secret", &resource.ServiceAccount.ClientSecret, handlers.MinLen(1))},
+		{"connector_type_id", handlers.Validation("connector_type_id", &resource.ConnectorTypeId, handlers.MinLen(1), handlers.MaxLen(maxConnectorTypeIdLength))},
+		{"desired_state", handlers.Validation("desired_state", (*string)(&resource.DesiredState), handlers.WithDefault("ready"), handlers.IsOneOf(dbapi.ValidDesiredStates...))},
+		{"namespace_id", handlers.Validation("namespace_id", &resource.NamespaceId,
+			handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceUser(errors.ErrorBadRequest),
+			user.ValidateNamespaceConnectorQuota(&resource.ConnectorTypeId, (*string)(&resource.Channel)))},
+		{"annotations", validateCreateAnnotations(resource.Annotations)},
+		{"restart_policy", validateRestartPolicy(&resource.RestartPolicy)},
+	}
+	for _, v := range validations {
+		if err := v.validate(); err != nil {
+			addError(v.field, err.Reason)
+		}
+	}
+	if !connectorsConfig.ConnectorEnableUnassignedConnectors && resource.NamespaceId == "" {
+		addError("namespace_id", "namespace_id is not valid. Minimum length 1 is required.")
+	}
+
+	ct, serr := connectorTypesService.Get(resource.ConnectorTypeId)
+	if serr != nil {
+		addError("connector_type_id", fmt.Sprintf("invalid connector type id: %s", resource.ConnectorTypeId))
+		result.Valid = len(result.Errors) == 0
+		return result
+	}
+
+	// resolve the shard metadata the connector would be deployed with
+	if !arrays.Contains(ct.ChannelNames(), string(resource.Channel)) {
+		addError("channel", fmt.Sprintf("channel is not valid. Must be one of: %s", strings.Join(ct.ChannelNames(), ", ")))
+	} else if shardMetadata, err := connectorTypesService.GetLatestConnectorShardMetadata(ct.ID, string(resource.Channel)); err != nil {
+		addError("channel", fmt.Sprintf("no shard metadata found for channel %s: %s", resource.Channel, err.Reason))
+	} else {
+		result.Channel = resource.Channel
+		result.ShardMetadataId = shardMetadata.ID
+	}
+
+	result.Errors = append(result.Errors, validateConnectorSpec(ct, resource.Connector)...)
+
+	// detect the secret fields that would be moved to the vault
+	if spec, err := json.Marshal(resource.Connector); err == nil {
+		if _, err := secrets.ModifySecrets(ct.JsonSchema, spec, func(node *ajson.Node) error {
+			field := connectorSpecFieldPath(node.Path())
+			if node.Type() == ajson.String {
+				result.SecretFields = append(result.SecretFields, field)
+			} else if node.Type() != ajson.Null {
+				addError(field, "secret field must be set to a string")
+			}
+			return nil
+		}); err != nil {
+			addError("connector", fmt.Sprintf("could not detect connector secrets: %v", err))
+		}
+	}
+	sort.Strings(result.SecretFields)
+
+	result.Valid = len(result.Errors) == 0
+	return result
+}
+
+// validateConnectorSpec validates a connector spec against the JSON schema of its connector type and returns the
+// errors per field
+func validateConnectorSpec(ct *dbapi.ConnectorType, spec map[string]interface{}) (result []public.ConnectorValidationError) {
+	schemaDom, serr := ct.JsonSchemaAsMap()
+	if serr != nil {
+		return []public.ConnectorValidationError{{Field: "connector_type_id", Reason: serr.Reason}}
+	}
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(schemaDom))
+	if err != nil {
+		return []public.ConnectorValidationError{{Field: "connector_type_id", Reason: fmt.Sprintf("invalid connector type schema: %v", err)}}
+	}
+	r, err := schema.Validate(gojsonschema.NewGoLoader(spec))
+	if err != nil {
+		return []public.ConnectorValidationError{{Field: "connector", Reason: fmt.Sprintf("invalid connector spec: %v", err)}}
+	}
+	for _, e := range r.Errors() {
+		field := "connector"
+		if e.Field() != gojsonschema.STRING_ROOT_SCHEMA_PROPERTY {
+			field += "." + e.Field()
+		} else if property, ok := e.Details()["property"].(string); ok {
+			field += "." + property
+		}
+		result = append(result, public.ConnectorValidationError{Field: field, Reason: e.Description()})
+	}
+	return result
+}
+
+// connectorSpecFieldPath converts a JSON path like $['a']['b'][0] to the field path connector.a.b[0]
+func connectorSpecFieldPath(path string) string {
+	path = strings.TrimPrefix(path, "$")
+	path = strings.ReplaceAll(path, "['", ".")
+	path = strings.ReplaceAll(path, "']", "")
+	return "connector" + path
+}
@@ -20,6 +20,7 @@ import (
 	"io"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
@@ -39,12 +40,13 @@ const (
 )
 
 type ConnectorsHandler struct {
-	connectorsService     services.ConnectorsService
-	connectorTypesService services.ConnectorTypesService
-	namespaceService      services.ConnectorNamespaceService
-	vaultService          vault.VaultService
-	authZService          authz.AuthZService
-	connectorsConfig      *config.ConnectorsConfig
+	connectorsService         services.ConnectorsService
+	connectorTypesService     services.ConnectorTypesService
+	namespaceService          services.ConnectorNamespaceService
+	connectorRevisionsService services.ConnectorRevisionsService
+	vaultService              vault.VaultService
+	authZService              authz.AuthZService
+	connectorsConfig          *config.ConnectorsConfig
 }
 
 // this is an initial guess at what operation is being performed in update
@@ -55,15 +57,16 @@ var stateToOperationsMap = map[public.ConnectorDesiredState]phase.ConnectorOpera
 }
 
 func NewConnectorsHandler(connectorsService services.ConnectorsService, connectorTypesService services.ConnectorTypesService,
-	namespaceService services.ConnectorNamespaceService, vaultService vault.VaultService, authZService authz.AuthZService,
-	connectorsConfig *config.ConnectorsConfig) *ConnectorsHandler {
+	namespaceService services.ConnectorNamespaceService, connectorRevisionsService services.ConnectorRevisionsService,
+	vaultService vault.VaultService, authZService authz.AuthZService, connectorsConfig *config.ConnectorsConfig) *ConnectorsHandler {
 	return &ConnectorsHandler{
-		connectorsService:     connectorsService,
-		connectorTypesService: connectorTypesService,
-		namespaceService:      namespaceService,
-		vaultService:          vaultService,
-		authZService:          authZService,
-		connectorsConfig:      connectorsConfig,
+		connectorsService:         connectorsService,
+		connectorTypesService:     connectorTypesService,
+		namespaceService:          namespaceService,
+		connectorRevisionsService: connectorRevisionsService,
+		vaultService:              vaultService,
+		authZService:              authZService,
+		connectorsConfig:          connectorsConfig,
 	}
 }
 
@@ -87,8 +90,10 @@ func (h ConnectorsHandler) Create(w http.ResponseWriter, r *http.Request) {
 			handlers.Validation("desired_state", (*string)(&resource.DesiredState), handlers.WithDefault("ready"), handlers.IsOneOf(dbapi.ValidDesiredStates...)),
 			validateConnectorRequest(h.connectorTypesService, &resource),
 			handlers.Validation("namespace_id", &resource.NamespaceId,
-				handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceUser(errors.ErrorBadRequest), user.ValidateNamespaceConnectorQuota()),
+				handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceUser(errors.ErrorBadRequest),
+				user.ValidateNamespaceConnectorQuota(&resource.ConnectorTypeId, (*string)(&resource.Channel))),
 			validateCreateAnnotations(resource.Annotations),
+			validateRestartPolicy(&resource.RestartPolicy),
 		},
 
 		Action: func() (interface{}, *errors.ServiceError) {
@@ -143,6 +148,22 @@ func (h ConnectorsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	handlers.Handle(w, r, cfg, http.StatusAccepted)
 }
 
+// Validate is the handler for validating a proposed connector without creating it
+func (h ConnectorsHandler) Validate(w http.ResponseWriter, r *http.Request) {
+
+	user := h.authZService.GetValidationUser(r.Context())
+
+	var resource public.ConnectorRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &resource,
+		Action: func() (interface{}, *errors.ServiceError) {
+			return dryRunConnectorRequest(h.connectorTypesService, h.connectorsConfig, user, &resource), nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
 func (h ConnectorsHandler) Patch(w http.ResponseWriter, r *http.Request) {
 
 	connectorId := mux.Vars(r)["connector_id"]
@@ -233,6 +254,7 @@ func (h ConnectorsHandler) Patch(w http.ResponseWriter, r *http.Request) {
 			resource.Kafka = patch.Kafka
 			resource.ServiceAccount = patch.ServiceAccount
 			resource.SchemaRegistry = patch.SchemaRegistry
+			resource.RestartPolicy = patch.RestartPolicy
 
 			if h.connectorsConfig.ConnectorEnableUnassignedConnectors {
 				// check namespace id change, from unassigned to assigned and vice versa
@@ -257,12 +279,14 @@ func (h ConnectorsHandler) Patch(w http.ResponseWriter, r *http.Request) {
 				handlers.Validation("service_account.client_id", &resource.ServiceAccount.ClientId, handlers.MinLen(1)),
 				handlers.Validation("desired_state", (*string)(&resource.DesiredState), handlers.IsOneOf(dbapi.ValidDesiredStates...)),
 				validatePatchAnnotations(resource.Annotations, originalResource.Annotations),
+				validateRestartPolicy(&resource.RestartPolicy),
 				validateConnector(h.connectorTypesService, &resource),
 			}
 
 			// Don't validate user's tenancy in admin api calls
 			if strings.Compare(r.URL.Path, fmt.Sprintf("%s/%s", "/api/connector_mgmt/v1/admin/kafka_connectors", connectorId)) != 0 {
-				validates = append(validates, handlers.Validation("namespace_id", &resource.NamespaceId, handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceUser(errors.ErrorBadRequest)))
+				validates = append(validates, handlers.Validation("namespace_id", &resource.NamespaceId, handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceUser(errors.ErrorBadRequest),
+					user.ValidateNamespaceConnectorUpdateQuota(connectorId, &resource.ConnectorTypeId, (*string)(&resource.Channel))))
 			}
 
 			for _, v := range validates {
@@ -285,6 +309,8 @@ func (h ConnectorsHandler) Patch(w http.ResponseWriter, r *http.Request) {
 			// update connector phase before desired state
 			if originalResource.Status.State != public.ConnectorState(dbapi.ConnectorStatusPhaseAssigning) {
 				dbresource.Status.Phase = phase.ConnectorStartingPhase[operation]
+				// a user update resets the restart attempts of the connector restart policy
+				dbresource.Status.Conditions = nil
 				p.Status.Phase = dbresource.Status.Phase
 				serr = h.connectorsService.SaveStatus(r.Context(), dbresource.Status)
 				if serr != nil {
@@ -302,7 +328,12 @@ func (h ConnectorsHandler) Patch(w http.ResponseWriter, r *http.Request) {
 				return nil, errors.GeneralError("could not get existing secrets: %v", err)
 			}
 
-			staleSecrets := StringListSubtract(originalSecrets, newSecrets...)
+			// keep the secrets the connector revisions still reference, so that they can be rolled back
+			revisionSecrets, serr := h.connectorRevisionsService.SecretRefs(connectorId)
+			if serr != nil {
+				return nil, serr
+			}
+			staleSecrets := StringListSubtract(originalSecrets, append(newSecrets, revisionSecrets...)...)
 			if len(staleSecrets) > 0 {
 				_ = db.AddPostCommitAction(r.Context(), func() {
 					for _, s := range staleSecrets {
@@ -475,7 +506,14 @@ func (h ConnectorsHandler) Get(w http.ResponseWriter, r *http.Request) {
 				}
 			}
 
-			return presenters.PresentConnectorWithError(resource)
+			connector, err := presenters.PresentConnectorWithError(resource)
+			if err != nil {
+				return nil, err
+			}
+			if ct != nil {
+				connector.Warnings = connectorWarnings(ct, resource.Channel)
+			}
+			return connector, nil
 		},
 	}
 	handlers.HandleGet(w, r, cfg)
@@ -564,6 +602,9 @@ func (h ConnectorsHandler) List(w http.ResponseWriter, r *http.Request) {
 					glog.Errorf("connector id='%s' presentation failed: %v", resource.ID, err)
 					return nil, errors.GeneralError("internal error")
 				}
+				if ct != nil {
+					converted.Warnings = connectorWarnings(ct, resource.Channel)
+				}
 				resourceList.Items = append(resourceList.Items, converted)
 
 			}
@@ -574,3 +615,113 @@ func (h ConnectorsHandler) List(w http.ResponseWriter, r *http.Request) {
 
 	handlers.HandleList(w, r, cfg)
 }
+
+func (h ConnectorsHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	connectorId := mux.Vars(r)["connector_id"]
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			// validates that the user can read the connector
+			if _, err := h.connectorsService.Get(ctx, connectorId); err != nil {
+				return nil, err
+			}
+
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+			resources, paging, err := h.connectorRevisionsService.List(ctx, connectorId, listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			resourceList := public.ConnectorRevisionList{
+				Kind:  "ConnectorRevisionList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []public.ConnectorRevision{},
+			}
+
+			for _, resource := range resources {
+
+				ct, serr := h.connectorTypesService.Get(resource.ConnectorTypeId)
+				if serr != nil {
+					// gracefully degrade by not showing the connector spec
+					resource.ConnectorSpec = api.JSON("{}")
+				} else {
+					// strip the secret references from the spec of the revision
+					stripped := dbapi.Connector{ConnectorSpec: resource.ConnectorSpec}
+					if err := stripSecretReferences(&stripped, ct); err != nil {
+						return nil, err
+					}
+					resource.ConnectorSpec = stripped.ConnectorSpec
+				}
+
+				converted, err := presenters.PresentConnectorRevision(resource)
+				if err != nil {
+					glog.Errorf("connector id='%s' revision %d presentation failed: %v", connectorId, resource.Revision, err)
+					return nil, errors.GeneralError("internal error")
+				}
+				resourceList.Items = append(resourceList.Items, converted)
+			}
+
+			return resourceList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+func (h ConnectorsHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	connectorId := mux.Vars(r)["connector_id"]
+	revisionParam := mux.Vars(r)["revision"]
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			revision, err := strconv.ParseInt(revisionParam, 10, 64)
+			if err != nil || revision < 1 {
+				return nil, errors.BadRequest("invalid connector revision: %s", revisionParam)
+			}
+
+			ctx := r.Context()
+			// validates that the user can update the connector
+			connector, serr := h.connectorsService.Get(ctx, connectorId)
+			if serr != nil {
+				return nil, serr
+			}
+			connectorRevision, serr := h.connectorRevisionsService.Get(ctx, connectorId, revision)
+			if serr != nil {
+				return nil, serr
+			}
+
+			// like an update, the channel of the revision must be allowed by the quota of the namespace
+			user := h.authZService.GetValidationUser(ctx)
+			validate := handlers.Validation("namespace_id", connector.NamespaceId, user.AuthorizedNamespaceUser(errors.ErrorBadRequest),
+				user.ValidateNamespaceConnectorUpdateQuota(connectorId, &connectorRevision.ConnectorTypeId, &connectorRevision.Channel))
+			if serr := validate(); serr != nil {
+				return nil, serr
+			}
+
+			resource, serr := h.connectorRevisionsService.Rollback(ctx, connectorId, revision)
+			if serr != nil {
+				return nil, serr
+			}
+
+			ct, serr := h.connectorTypesService.Get(resource.ConnectorTypeId)
+			if serr != nil {
+				return nil, errors.BadRequest("invalid connector type id: %s", resource.ConnectorTypeId)
+			}
+			if err := stripSecretReferences(resource, ct); err != nil {
+				return nil, err
+			}
+
+			return presenters.PresentConnector(resource)
+		},
+	}
+
+	// return 202 status accepted
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
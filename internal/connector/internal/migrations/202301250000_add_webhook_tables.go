@@ -0,0 +1,52 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addWebhookTables(migrationId string) *gormigrate.Migration {
+
+	type OutboxEvent struct {
+		db.Model
+		Type           string
+		Source         string
+		Subject        string
+		OrganisationId string     `gorm:"index"`
+		Data           string     `gorm:"type:jsonb"`
+		DispatchedAt   *time.Time `gorm:"index"`
+	}
+
+	type WebhookEndpoint struct {
+		db.Model
+		OrganisationId string `gorm:"index"`
+		Owner          string
+		URL            string
+		Description    string
+		Secret         string
+	}
+
+	type WebhookDelivery struct {
+		db.Model
+		WebhookId     string `gorm:"index"`
+		EventId       string
+		EventType     string
+		Status        string `gorm:"index"`
+		Attempts      int
+		NextAttemptAt *time.Time `gorm:"index"`
+		LastError     string
+		DeliveredAt   *time.Time
+	}
+
+	return db.CreateMigrationFromActions(migrationId,
+		db.CreateSharedTablesAction(&OutboxEvent{}, &WebhookEndpoint{}, &WebhookDelivery{}),
+		db.CreateSharedLeaderLeaseAction("webhook_dispatcher"),
+	)
+}
@@ -0,0 +1,36 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addConnectorRestartPolicy(migrationId string) *gormigrate.Migration {
+	type ConnectorRestartPolicy struct {
+		Type              string `gorm:"index"`
+		MaxAttempts       int32
+		BackoffSeconds    int32
+		MaxBackoffSeconds int32
+	}
+
+	type Connector struct {
+		RestartPolicy ConnectorRestartPolicy `gorm:"embedded;embeddedPrefix:restart_policy_"`
+	}
+
+	type ConnectorStatus struct {
+		Conditions api.JSON `gorm:"type:jsonb"`
+	}
+
+	return db.CreateMigrationFromActions(migrationId,
+		// add restart policy
+		db.AddTableColumnsAction(&Connector{}),
+		// add restart attempts history
+		db.AddTableColumnsAction(&ConnectorStatus{}),
+	)
+}
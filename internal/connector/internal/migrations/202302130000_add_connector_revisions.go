@@ -0,0 +1,82 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addConnectorRevisions(migrationId string) *gormigrate.Migration {
+
+	type ConnectorRevision struct {
+		db.Model
+		ConnectorID              string `gorm:"index:idx_connector_revisions_connector_id_revision,unique"`
+		Revision                 int64  `gorm:"index:idx_connector_revisions_connector_id_revision,unique"`
+		ConnectorTypeId          string
+		ConnectorSpec            api.JSON `gorm:"type:jsonb"`
+		Channel                  string
+		ConnectorShardMetadataID int64
+		CreatedBy                string
+	}
+
+	return db.CreateMigrationFromActions(migrationId,
+		db.CreateTableAction(&ConnectorRevision{}),
+
+		// record the current configuration of existing connectors as their first revision
+		db.FuncAction(func(tx *gorm.DB) error {
+			type connector struct {
+				ID                       string
+				Version                  int64
+				ConnectorTypeId          string
+				ConnectorSpec            api.JSON
+				Channel                  string
+				Owner                    string
+				UpdatedAt                time.Time
+				ConnectorShardMetadataID int64
+			}
+			var connectors []connector
+			if err := tx.Table("connectors").
+				Select("connectors.id, connectors.version, connectors.connector_type_id, connectors.connector_spec, " +
+					"connectors.channel, connectors.owner, connectors.updated_at, " +
+					"COALESCE(connector_deployments.connector_shard_metadata_id, (SELECT id FROM connector_shard_metadata " +
+					"WHERE connector_type_id = connectors.connector_type_id AND channel = connectors.channel " +
+					"ORDER BY revision DESC LIMIT 1), 0) AS connector_shard_metadata_id").
+				Joins("LEFT JOIN connector_deployments ON connector_deployments.connector_id = connectors.id " +
+					"AND connector_deployments.deleted_at IS NULL").
+				Where("connectors.deleted_at IS NULL").
+				Scan(&connectors).Error; err != nil {
+				return err
+			}
+			for _, c := range connectors {
+				if err := tx.Create(&ConnectorRevision{
+					Model: db.Model{
+						ID:        api.NewID(),
+						CreatedAt: c.UpdatedAt,
+						UpdatedAt: c.UpdatedAt,
+					},
+					ConnectorID:              c.ID,
+					Revision:                 c.Version,
+					ConnectorTypeId:          c.ConnectorTypeId,
+					ConnectorSpec:            c.ConnectorSpec,
+					Channel:                  c.Channel,
+					ConnectorShardMetadataID: c.ConnectorShardMetadataID,
+					CreatedBy:                c.Owner,
+				}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}, func(tx *gorm.DB) error {
+			// the table is dropped
+			return nil
+		}),
+	)
+}
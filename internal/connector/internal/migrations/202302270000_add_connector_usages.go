@@ -0,0 +1,32 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addConnectorUsages(migrationId string) *gormigrate.Migration {
+
+	type ConnectorUsage struct {
+		OrganisationId   string    `gorm:"primaryKey"`
+		NamespaceId      string    `gorm:"primaryKey"`
+		ConnectorTypeId  string    `gorm:"primaryKey"`
+		PeriodStart      time.Time `gorm:"primaryKey"`
+		ConnectorSeconds int64     `gorm:"not null;default:0"`
+		Samples          int64     `gorm:"not null;default:0"`
+		Reported         bool      `gorm:"not null;default:false;index"`
+		CreatedAt        time.Time
+		UpdatedAt        time.Time
+	}
+
+	return db.CreateMigrationFromActions(migrationId,
+		db.CreateTableAction(&ConnectorUsage{}),
+	)
+}
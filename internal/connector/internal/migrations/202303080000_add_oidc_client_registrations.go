@@ -0,0 +1,31 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addOidcClientRegistrations(migrationId string) *gormigrate.Migration {
+
+	type OidcClientRegistration struct {
+		db.Model
+		ClientId                string `gorm:"uniqueIndex"`
+		Name                    string
+		Description             string
+		Owner                   string
+		OwnerAccountId          string
+		OrganisationId          string `gorm:"index"`
+		Internal                bool
+		RegistrationAccessToken string
+		RegistrationClientURI   string
+	}
+
+	return db.CreateMigrationFromActions(migrationId,
+		db.CreateSharedTablesAction(&OidcClientRegistration{}),
+	)
+}
@@ -0,0 +1,31 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addConnectorMeteringLeaderLease(migrationId string) *gormigrate.Migration {
+	return db.CreateMigrationFromActions(migrationId,
+		db.FuncAction(func(tx *gorm.DB) error {
+			now := time.Now().Add(-time.Minute) //set to a expired time
+			return tx.Create(&api.LeaderLease{
+				Expires:   &now,
+				LeaseType: "connector_metering",
+			}).Error
+		}, func(tx *gorm.DB) error {
+			// The leader lease table may have already been dropped, by the kafka migration rollback, ignore error
+			_ = tx.Where("lease_type = ?", "connector_metering").Delete(&api.LeaderLease{})
+			return nil
+		}),
+	)
+}
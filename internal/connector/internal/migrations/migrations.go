@@ -49,6 +49,16 @@ var migrations = []*gormigrate.Migration{
 	addConnectorResourceAnnotations("202211070000"),
 	renameNamespaceProfileAnnotations("202211280000"),
 	addOrgIDAnnotations("202212050000"),
+	addWebhookTables("202301250000"),
+	addConnectorRestartPolicy("202302060000"),
+	addConnectorRevisions("202302130000"),
+	addConnectorTypeDeprecations("202302200000"),
+	addConnectorUsages("202302270000"),
+	addOidcClientRegistrations("202303080000"),
+	addResourceGrants("202303150000"),
+	addAuditEvents("202303220000"),
+	addConnectorSecretsRotations("202303290000"),
+	addConnectorMeteringLeaderLease("202303300000"),
 }
 
 func New(dbConfig *db.DatabaseConfig) (*db.Migration, func(), error) {
@@ -47,7 +47,8 @@ func ConvertConnector(from public.Connector) (*dbapi.Connector, *errors.ServiceE
 			ClientId:     from.ServiceAccount.ClientId,
 			ClientSecret: from.ServiceAccount.ClientSecret,
 		},
-		Annotations: ConvertConnectorAnnotations(from.Id, from.Annotations),
+		Annotations:   ConvertConnectorAnnotations(from.Id, from.Annotations),
+		RestartPolicy: ConvertConnectorRestartPolicy(from.RestartPolicy),
 		Status: dbapi.ConnectorStatus{
 			Phase: dbapi.ConnectorStatusPhase(from.Status.State),
 		},
@@ -68,6 +69,30 @@ func ConvertConnectorAnnotations(id string, annotations map[string]string) []dba
 	return res
 }
 
+func ConvertConnectorRestartPolicy(from *public.ConnectorRestartPolicy) dbapi.ConnectorRestartPolicy {
+	if from == nil {
+		return dbapi.ConnectorRestartPolicy{}
+	}
+	return dbapi.ConnectorRestartPolicy{
+		Type:              dbapi.ConnectorRestartPolicyType(from.Type),
+		MaxAttempts:       from.MaxAttempts,
+		BackoffSeconds:    from.BackoffSeconds,
+		MaxBackoffSeconds: from.MaxBackoffSeconds,
+	}
+}
+
+func PresentConnectorRestartPolicy(from dbapi.ConnectorRestartPolicy) *public.ConnectorRestartPolicy {
+	if from.Type == "" {
+		return nil
+	}
+	return &public.ConnectorRestartPolicy{
+		Type:              public.ConnectorRestartPolicyType(from.Type),
+		MaxAttempts:       from.MaxAttempts,
+		BackoffSeconds:    from.BackoffSeconds,
+		MaxBackoffSeconds: from.MaxBackoffSeconds,
+	}
+}
+
 func PresentConnectorWithError(from *dbapi.ConnectorWithConditions) (public.Connector, *errors.ServiceError) {
 	connector, err := PresentConnector(&from.Connector)
 	if err != nil {
@@ -109,6 +134,14 @@ func PresentConnectorAdminView(from *dbapi.ConnectorWithConditions) (admin.Conne
 		Channel:      admin.Channel(from.Channel),
 		Annotations:  PresentConnectorAnnotations(from.Annotations),
 	}
+	if from.RestartPolicy.Type != "" {
+		connector.RestartPolicy = &admin.ConnectorRestartPolicy{
+			Type:              admin.ConnectorRestartPolicyType(from.RestartPolicy.Type),
+			MaxAttempts:       from.RestartPolicy.MaxAttempts,
+			BackoffSeconds:    from.RestartPolicy.BackoffSeconds,
+			MaxBackoffSeconds: from.RestartPolicy.MaxBackoffSeconds,
+		}
+	}
 	reference := PresentReference(connector.Id, connector)
 	connector.Kind = reference.Kind
 	connector.Href = reference.Href
@@ -182,6 +215,7 @@ func PresentConnector(from *dbapi.Connector) (public.Connector, *errors.ServiceE
 		ConnectorTypeId: from.ConnectorTypeId,
 		Connector:       spec,
 		Annotations:     PresentConnectorAnnotations(from.Annotations),
+		RestartPolicy:   PresentConnectorRestartPolicy(from.RestartPolicy),
 		Status: public.ConnectorStatusStatus{
 			State: public.ConnectorState(from.Status.Phase),
 		},
@@ -201,3 +235,34 @@ func PresentConnector(from *dbapi.Connector) (public.Connector, *errors.ServiceE
 		},
 	}, nil
 }
+
+func PresentConnectorSecretsReport(from *dbapi.ConnectorSecretsReport) admin.ConnectorSecretsReport {
+	report := admin.ConnectorSecretsReport{
+		Connectors:      int32(from.Connectors),
+		Secrets:         int32(from.Secrets),
+		OrphanedSecrets: from.OrphanedSecrets,
+	}
+	for _, err := range from.Errors {
+		report.Errors = append(report.Errors, err.Error())
+	}
+	return report
+}
+
+func PresentConnectorSecretsRotation(from *dbapi.ConnectorSecretsRotation) (admin.ConnectorSecretsRotation, *errors.ServiceError) {
+	rotation := admin.ConnectorSecretsRotation{
+		Id:         from.ID,
+		Status:     string(from.Status),
+		Connectors: int32(from.Connectors),
+		Secrets:    int32(from.Secrets),
+		CreatedAt:  from.CreatedAt,
+	}
+	if from.CompletedAt != nil {
+		rotation.CompletedAt = *from.CompletedAt
+	}
+	if len(from.Errors) != 0 {
+		if err := json.Unmarshal(from.Errors, &rotation.Errors); err != nil {
+			return rotation, errors.GeneralError("invalid errors of connector secrets rotation %s: %v", from.ID, err)
+		}
+	}
+	return rotation, nil
+}
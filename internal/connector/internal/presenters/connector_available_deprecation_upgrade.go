@@ -0,0 +1,30 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+)
+
+func PresentConnectorAvailableDeprecationUpgrade(req *dbapi.ConnectorDeploymentDeprecationUpgrade) *private.ConnectorAvailableDeprecationUpgrade {
+	return &private.ConnectorAvailableDeprecationUpgrade{
+		ConnectorId:                req.ConnectorID,
+		ConnectorTypeId:            req.ConnectorTypeId,
+		NamespaceId:                req.NamespaceID,
+		Channel:                    req.Channel,
+		ReplacementConnectorTypeId: req.ReplacementConnectorTypeId,
+		ReplacementChannel:         req.ReplacementChannel,
+		SunsetAt:                   req.SunsetAt,
+	}
+}
+
+func ConvertConnectorAvailableDeprecationUpgrade(req *private.ConnectorAvailableDeprecationUpgrade) *dbapi.ConnectorDeploymentDeprecationUpgrade {
+	return &dbapi.ConnectorDeploymentDeprecationUpgrade{
+		ConnectorID:                req.ConnectorId,
+		ConnectorTypeId:            req.ConnectorTypeId,
+		NamespaceID:                req.NamespaceId,
+		Channel:                    req.Channel,
+		ReplacementConnectorTypeId: req.ReplacementConnectorTypeId,
+		ReplacementChannel:         req.ReplacementChannel,
+		SunsetAt:                   req.SunsetAt,
+	}
+}
@@ -179,6 +179,10 @@ func PresentConnectorNamespace(namespace *dbapi.ConnectorNamespace, quotaConfig
 			ConnectorsDeployed: namespace.Status.ConnectorsDeployed,
 			Error:              getError(namespace.Status.Conditions),
 		},
+		Resources: public.ConnectorNamespaceResources{
+			Used:      presentNamespaceResources(namespace.ResourcesUsed),
+			Available: presentNamespaceResources(namespace.ResourcesAvailable),
+		},
 	}
 	if namespace.TenantUser != nil {
 		result.Tenant.Kind = public.CONNECTORNAMESPACETENANTKIND_USER
@@ -300,6 +304,10 @@ func PresentPrivateConnectorNamespace(namespace *dbapi.ConnectorNamespace, quota
 			ConnectorsDeployed: namespace.Status.ConnectorsDeployed,
 			Error:              getError(namespace.Status.Conditions),
 		},
+		Resources: admin.ConnectorNamespaceResources{
+			Used:      admin.ConnectorNamespaceQuota(presentNamespaceResources(namespace.ResourcesUsed)),
+			Available: admin.ConnectorNamespaceQuota(presentNamespaceResources(namespace.ResourcesAvailable)),
+		},
 	}
 	if namespace.TenantUser != nil {
 		result.Tenant.Kind = admin.CONNECTORNAMESPACETENANTKIND_USER
@@ -354,3 +362,13 @@ func ConvertNamespaceAnnotations(namespaceId string, annotations map[string]stri
 
 	return result
 }
+
+func presentNamespaceResources(resources dbapi.ConnectorNamespaceResources) public.ConnectorNamespaceQuota {
+	return public.ConnectorNamespaceQuota{
+		Connectors:     resources.Connectors,
+		MemoryRequests: resources.MemoryRequests,
+		MemoryLimits:   resources.MemoryLimits,
+		CpuRequests:    resources.CPURequests,
+		CpuLimits:      resources.CPULimits,
+	}
+}
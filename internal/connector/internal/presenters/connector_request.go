@@ -31,6 +31,7 @@ func ConvertConnectorRequest(id string, from public.ConnectorRequest) (*dbapi.Co
 		DesiredState:    dbapi.ConnectorDesiredState(from.DesiredState),
 		Channel:         string(from.Channel),
 		Annotations:     ConvertConnectorAnnotations(id, from.Annotations),
+		RestartPolicy:   ConvertConnectorRestartPolicy(from.RestartPolicy),
 		Kafka: dbapi.KafkaConnectionSettings{
 			KafkaID:         from.Kafka.Id,
 			BootstrapServer: from.Kafka.Url,
@@ -0,0 +1,31 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+func PresentConnectorRevision(from *dbapi.ConnectorRevision) (public.ConnectorRevision, *errors.ServiceError) {
+	spec := map[string]interface{}{}
+	err := from.ConnectorSpec.Unmarshal(&spec)
+	if err != nil {
+		return public.ConnectorRevision{}, errors.BadRequest("invalid connector spec: %v", err)
+	}
+
+	reference := PresentReference(from.ID, from)
+	return public.ConnectorRevision{
+		Id:   reference.Id,
+		Kind: reference.Kind,
+		Href: reference.Href,
+
+		Revision:        from.Revision,
+		ConnectorId:     from.ConnectorID,
+		ConnectorTypeId: from.ConnectorTypeId,
+		Channel:         public.Channel(from.Channel),
+		ShardMetadataId: from.ConnectorShardMetadataID,
+		Connector:       spec,
+		CreatedAt:       from.CreatedAt,
+		CreatedBy:       from.CreatedBy,
+	}, nil
+}
@@ -49,6 +49,7 @@ func ConvertConnectorType(from public.ConnectorType) (*dbapi.ConnectorType, erro
 	ct.SetLabels(from.Labels)
 	ct.SetChannels(toStringSlice(from.Channels))
 	ct.SetCapabilities(from.Capabilities)
+	ct.Deprecations = ConvertTypeDeprecations(from.Id, from.Deprecation, from.ChannelDeprecations)
 	schemaToBeSet := from.Schema
 	if schemaToBeSet == nil {
 		schemaToBeSet = from.Schema
@@ -73,13 +74,36 @@ func ConvertTypeAnnotations(id string, annotations map[string]string) []dbapi.Co
 	return res
 }
 
+func ConvertTypeDeprecations(id string, deprecation *public.ConnectorTypeDeprecation, channelDeprecations map[string]public.ConnectorTypeDeprecation) []dbapi.ConnectorTypeDeprecation {
+	res := make([]dbapi.ConnectorTypeDeprecation, 0, len(channelDeprecations)+1)
+	if deprecation != nil {
+		res = append(res, convertTypeDeprecation(id, "", *deprecation))
+	}
+	for channel, d := range channelDeprecations {
+		res = append(res, convertTypeDeprecation(id, channel, d))
+	}
+
+	return res
+}
+
+func convertTypeDeprecation(id string, channel string, from public.ConnectorTypeDeprecation) dbapi.ConnectorTypeDeprecation {
+	return dbapi.ConnectorTypeDeprecation{
+		ConnectorTypeID:            id,
+		Channel:                    channel,
+		SunsetAt:                   from.SunsetAt,
+		ReplacementConnectorTypeId: from.ReplacementConnectorTypeId,
+		ReplacementChannel:         string(from.ReplacementChannel),
+		Reason:                     from.Reason,
+	}
+}
+
 func PresentConnectorType(from *dbapi.ConnectorType) (*public.ConnectorType, error) {
 	schemaDom, err := from.JsonSchemaAsMap()
 	if err != nil {
 		return nil, err
 	}
 	reference := PresentReference(from.ID, from)
-	return &public.ConnectorType{
+	result := &public.ConnectorType{
 		Id:           reference.Id,
 		Kind:         reference.Kind,
 		Href:         reference.Href,
@@ -93,7 +117,32 @@ func PresentConnectorType(from *dbapi.ConnectorType) (*public.ConnectorType, err
 		Channels:     toChannelSlice(from.ChannelNames()),
 		Capabilities: from.CapabilitiesNames(),
 		Annotations:  PresentTypeAnnotations(from.Annotations),
-	}, nil
+	}
+	result.Deprecation, result.ChannelDeprecations = PresentTypeDeprecations(from.Deprecations)
+	return result, nil
+}
+
+// PresentTypeDeprecations returns the deprecation of a connector type, and the deprecations of its channels
+func PresentTypeDeprecations(deprecations []dbapi.ConnectorTypeDeprecation) (*public.ConnectorTypeDeprecation, map[string]public.ConnectorTypeDeprecation) {
+	var deprecation *public.ConnectorTypeDeprecation
+	var channelDeprecations map[string]public.ConnectorTypeDeprecation
+	for _, d := range deprecations {
+		res := public.ConnectorTypeDeprecation{
+			SunsetAt:                   d.SunsetAt,
+			ReplacementConnectorTypeId: d.ReplacementConnectorTypeId,
+			ReplacementChannel:         public.Channel(d.ReplacementChannel),
+			Reason:                     d.Reason,
+		}
+		if d.Channel == "" {
+			deprecation = &res
+			continue
+		}
+		if channelDeprecations == nil {
+			channelDeprecations = make(map[string]public.ConnectorTypeDeprecation)
+		}
+		channelDeprecations[d.Channel] = res
+	}
+	return deprecation, channelDeprecations
 }
 
 func PresentTypeAnnotations(annotations []dbapi.ConnectorTypeAnnotation) map[string]string {
@@ -128,6 +177,18 @@ func PresentConnectorTypeAdminView(from dbapi.ConnectorCatalogEntry) (*admin.Con
 		view.Labels[i] = l.Label
 	}
 
+	deprecation, channelDeprecations := PresentTypeDeprecations(from.ConnectorType.Deprecations)
+	if deprecation != nil {
+		d := presentAdminTypeDeprecation(*deprecation)
+		view.Deprecation = &d
+	}
+	if channelDeprecations != nil {
+		view.ChannelDeprecations = make(map[string]admin.ConnectorTypeDeprecation, len(channelDeprecations))
+		for channel, d := range channelDeprecations {
+			view.ChannelDeprecations[channel] = presentAdminTypeDeprecation(d)
+		}
+	}
+
 	for name, channel := range from.Channels {
 		meta, err := channel.ShardMetadata.Object()
 		if err != nil {
@@ -152,3 +213,12 @@ func PresentConnectorTypeAdminView(from dbapi.ConnectorCatalogEntry) (*admin.Con
 
 	return &view, nil
 }
+
+func presentAdminTypeDeprecation(from public.ConnectorTypeDeprecation) admin.ConnectorTypeDeprecation {
+	return admin.ConnectorTypeDeprecation{
+		SunsetAt:                   from.SunsetAt,
+		ReplacementConnectorTypeId: from.ReplacementConnectorTypeId,
+		ReplacementChannel:         admin.Channel(from.ReplacementChannel),
+		Reason:                     from.Reason,
+	}
+}
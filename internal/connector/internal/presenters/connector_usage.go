@@ -0,0 +1,16 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+)
+
+func PresentConnectorUsage(usage *dbapi.ConnectorUsage) private.ConnectorUsage {
+	return private.ConnectorUsage{
+		OrganisationId:  usage.OrganisationId,
+		NamespaceId:     usage.NamespaceId,
+		ConnectorTypeId: usage.ConnectorTypeId,
+		PeriodStart:     usage.PeriodStart.UTC(),
+		ConnectorHours:  usage.ConnectorHours(),
+	}
+}
@@ -23,6 +23,8 @@ const (
 	KindConnectorDeploymentAdminView = "ConnectorDeploymentAdminView"
 	// KindConnectorNamespace is a string identifier for the type dbapi.ConnectorNamespace
 	KindConnectorNamespace = "ConnectorNamespace"
+	// KindConnectorRevision is a string identifier for the type dbapi.ConnectorRevision
+	KindConnectorRevision = "ConnectorRevision"
 	// KindConnectorType is a string identifier for the type dbapi.ConnectorType
 	KindConnectorType = "ConnectorType"
 	// ConnectorTypeAdminView is a string identifier for the type admin.ConnectorTypeAdminView
@@ -49,6 +51,8 @@ func objectKind(i interface{}) string {
 		return KindConnectorDeploymentAdminView
 	case dbapi.ConnectorNamespace, *dbapi.ConnectorNamespace:
 		return KindConnectorNamespace
+	case dbapi.ConnectorRevision, *dbapi.ConnectorRevision:
+		return KindConnectorRevision
 	case dbapi.ConnectorType, *dbapi.ConnectorType:
 		return KindConnectorType
 	case admin.ConnectorTypeAdminView:
@@ -66,6 +70,10 @@ func objectPath(id string, obj interface{}) string {
 		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connectors/%s", id)
 	case admin.ConnectorAdminView, *admin.ConnectorAdminView:
 		return fmt.Sprintf("/api/connector_mgmt/v1/admin/kafka_connectors/%s", id)
+	case dbapi.ConnectorRevision:
+		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connectors/%s/revisions/%d", obj.ConnectorID, obj.Revision)
+	case *dbapi.ConnectorRevision:
+		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connectors/%s/revisions/%d", obj.ConnectorID, obj.Revision)
 	case dbapi.ConnectorType, *dbapi.ConnectorType:
 		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connector_types/%s", id)
 	case admin.ConnectorTypeAdminView:
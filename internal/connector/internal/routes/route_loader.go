@@ -1,10 +1,15 @@
 package routes
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/rbac"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/handlers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/acl"
@@ -15,6 +20,7 @@ import (
 	kerrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	coreHandlers "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/server"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/goava/di"
 	gorillaHandlers "github.com/gorilla/handlers"
@@ -39,6 +45,11 @@ type options struct {
 	ConnectorNamespaceHandler *handlers.ConnectorNamespaceHandler
 	DB                        *db.ConnectionFactory
 	AdminRoleAuthZConfig      *auth.AdminRoleAuthZConfig
+	WebhookService            webhooks.WebhookService
+	RBACService               rbac.RBACService
+	RBACMiddleware            rbac.RBACMiddleware
+	AuditService              audit.AuditService
+	AuditMiddleware           audit.AuditMiddleware
 }
 
 func NewRouteLoader(s options) environments.RouteLoader {
@@ -49,6 +60,13 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 
 	authorizeMiddleware := s.AuthorizeMiddleware.Authorize
 	requireOrgID := auth.NewRequireOrgIDMiddleware().RequireOrgID(kerrors.ErrorUnauthenticated)
+	auditMutations := s.AuditMiddleware.AuditMutations(map[string]audit.Snapshot{
+		"kafka_connectors":           s.snapshotConnector,
+		"kafka_connector_clusters":   s.snapshotConnectorCluster,
+		"kafka_connector_namespaces": s.snapshotConnectorNamespace,
+		"webhooks":                   audit.WebhookSnapshot(s.WebhookService),
+		"grants":                     audit.GrantSnapshot(s.RBACService),
+	})
 
 	openAPIDefinitions, err := shared.LoadOpenAPISpecFromYAML(openapicontents.ConnectorMgmtOpenAPIYAMLBytes())
 	if err != nil {
@@ -92,11 +110,17 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	apiV1ConnectorsRouter := apiV1Router.PathPrefix("/kafka_connectors").Subrouter()
 	apiV1ConnectorsRouter.HandleFunc("", s.ConnectorsHandler.Create).Methods(http.MethodPost)
 	apiV1ConnectorsRouter.HandleFunc("", s.ConnectorsHandler.List).Methods(http.MethodGet)
+	apiV1ConnectorsRouter.HandleFunc("/apply", s.ConnectorsHandler.Apply).Methods(http.MethodPost)
+	apiV1ConnectorsRouter.HandleFunc("/validate", s.ConnectorsHandler.Validate).Methods(http.MethodPost)
 	apiV1ConnectorsRouter.HandleFunc("/{connector_id}", s.ConnectorsHandler.Get).Methods(http.MethodGet)
 	apiV1ConnectorsRouter.HandleFunc("/{connector_id}", s.ConnectorsHandler.Patch).Methods(http.MethodPatch)
 	apiV1ConnectorsRouter.HandleFunc("/{connector_id}", s.ConnectorsHandler.Delete).Methods(http.MethodDelete)
+	apiV1ConnectorsRouter.HandleFunc("/{connector_id}/revisions", s.ConnectorsHandler.ListRevisions).Methods(http.MethodGet)
+	apiV1ConnectorsRouter.HandleFunc("/{connector_id}/revisions/{revision}/rollback", s.ConnectorsHandler.Rollback).Methods(http.MethodPost)
 	apiV1ConnectorsRouter.Use(authorizeMiddleware)
 	apiV1ConnectorsRouter.Use(requireOrgID)
+	apiV1ConnectorsRouter.Use(auditMutations)
+	apiV1ConnectorsRouter.Use(s.RBACMiddleware.RequirePermission("connector_id", s.lookupConnector))
 
 	//  /api/connector_mgmt/v1/kafka_connector_clusters
 	v1Collections = append(v1Collections, api.CollectionMetadata{
@@ -114,6 +138,7 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	apiV1ConnectorClustersRouter.HandleFunc("/{connector_cluster_id}/namespaces", s.ConnectorClusterHandler.GetNamespaces).Methods(http.MethodGet)
 	apiV1ConnectorClustersRouter.Use(authorizeMiddleware)
 	apiV1ConnectorClustersRouter.Use(requireOrgID)
+	apiV1ConnectorClustersRouter.Use(auditMutations)
 
 	//  /api/connector_mgmt/v1/kafka_connector_namespaces
 	v1Collections = append(v1Collections, api.CollectionMetadata{
@@ -136,6 +161,32 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	}
 	apiV1ConnectorNamespacesRouter.Use(authorizeMiddleware)
 	apiV1ConnectorNamespacesRouter.Use(requireOrgID)
+	apiV1ConnectorNamespacesRouter.Use(auditMutations)
+
+	//  /api/connector_mgmt/v1/webhooks
+	webhookHandler := coreHandlers.NewWebhookHandler(s.WebhookService, "/api/connector_mgmt/v1/webhooks")
+	apiV1WebhooksRouter := apiV1Router.PathPrefix("/webhooks").Subrouter()
+	apiV1WebhooksRouter.HandleFunc("", webhookHandler.List).Methods(http.MethodGet)
+	apiV1WebhooksRouter.HandleFunc("", webhookHandler.Create).Methods(http.MethodPost)
+	apiV1WebhooksRouter.HandleFunc("/{id}", webhookHandler.Get).Methods(http.MethodGet)
+	apiV1WebhooksRouter.HandleFunc("/{id}", webhookHandler.Delete).Methods(http.MethodDelete)
+	apiV1WebhooksRouter.HandleFunc("/{id}/dead_letters", webhookHandler.ListDeadLetters).Methods(http.MethodGet)
+	apiV1WebhooksRouter.Use(authorizeMiddleware)
+	apiV1WebhooksRouter.Use(requireOrgID)
+	apiV1WebhooksRouter.Use(auditMutations)
+
+	//  /api/connector_mgmt/v1/grants
+	grantHandler := coreHandlers.NewGrantHandler(s.RBACService, "/api/connector_mgmt/v1/grants", map[api.GrantResourceType]rbac.ResourceLookup{
+		api.GrantResourceTypeConnector: s.lookupConnector,
+	})
+	apiV1GrantsRouter := apiV1Router.PathPrefix("/grants").Subrouter()
+	apiV1GrantsRouter.HandleFunc("", grantHandler.List).Methods(http.MethodGet)
+	apiV1GrantsRouter.HandleFunc("", grantHandler.Create).Methods(http.MethodPost)
+	apiV1GrantsRouter.HandleFunc("/{id}", grantHandler.Get).Methods(http.MethodGet)
+	apiV1GrantsRouter.HandleFunc("/{id}", grantHandler.Delete).Methods(http.MethodDelete)
+	apiV1GrantsRouter.Use(authorizeMiddleware)
+	apiV1GrantsRouter.Use(requireOrgID)
+	apiV1GrantsRouter.Use(auditMutations)
 
 	// This section adds the API's accessed by the connector agent...
 	{
@@ -156,6 +207,7 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	adminRouter.Use(auth.NewRequireIssuerMiddleware().RequireIssuer([]string{s.KeycloakService.GetConfig().AdminAPISSORealm.ValidIssuerURI}, kerrors.ErrorNotFound))
 	adminRouter.Use(auth.NewRolesAuthzMiddleware(s.AdminRoleAuthZConfig).RequireRolesForMethods(kerrors.ErrorNotFound))
 	adminRouter.Use(auth.NewAuditLogMiddleware().AuditLog(kerrors.ErrorNotFound))
+	adminRouter.Use(auditMutations)
 	adminRouter.HandleFunc("/kafka_connector_clusters", s.ConnectorAdminHandler.ListConnectorClusters).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_clusters/{connector_cluster_id}", s.ConnectorAdminHandler.GetConnectorCluster).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_clusters/{connector_cluster_id}/namespaces", s.ConnectorAdminHandler.GetClusterNamespaces).Methods(http.MethodGet)
@@ -165,6 +217,8 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	adminRouter.HandleFunc("/kafka_connector_clusters/{connector_cluster_id}/deployments/{deployment_id}", s.ConnectorAdminHandler.PatchConnectorDeployment).Methods(http.MethodPatch)
 	adminRouter.HandleFunc("/kafka_connector_clusters/{connector_cluster_id}/upgrades/operator", s.ConnectorAdminHandler.GetConnectorUpgradesByOperator).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_clusters/{connector_cluster_id}/upgrades/operator", s.ConnectorAdminHandler.UpgradeConnectorsByOperator).Methods(http.MethodPut)
+	adminRouter.HandleFunc("/kafka_connector_clusters/{connector_cluster_id}/upgrades/deprecation", s.ConnectorAdminHandler.GetConnectorUpgradesByDeprecation).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafka_connector_clusters/{connector_cluster_id}/upgrades/deprecation", s.ConnectorAdminHandler.UpgradeConnectorsByDeprecation).Methods(http.MethodPut)
 	adminRouter.HandleFunc("/kafka_connector_namespaces", s.ConnectorAdminHandler.GetConnectorNamespaces).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_namespaces", s.ConnectorAdminHandler.CreateConnectorNamespace).Methods(http.MethodPost)
 	adminRouter.HandleFunc("/kafka_connector_namespaces/{namespace_id}", s.ConnectorAdminHandler.GetConnectorNamespace).Methods(http.MethodGet)
@@ -177,8 +231,14 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	adminRouter.HandleFunc("/kafka_connectors/{connector_id}", s.ConnectorAdminHandler.GetConnector).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connectors/{connector_id}", s.ConnectorAdminHandler.DeleteConnector).Methods(http.MethodDelete)
 	adminRouter.HandleFunc("/kafka_connectors/{connector_id}", s.ConnectorAdminHandler.PatchConnector).Methods(http.MethodPatch)
+	adminRouter.HandleFunc("/kafka_connectors/{connector_id}/secrets/rotate", s.ConnectorAdminHandler.RotateConnectorSecrets).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/kafka_connector_secrets/rotate", s.ConnectorAdminHandler.RotateConnectorsSecrets).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/kafka_connector_secrets/rotations/{rotation_id}", s.ConnectorAdminHandler.GetConnectorsSecretsRotation).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafka_connector_secrets/gc", s.ConnectorAdminHandler.DeleteOrphanedConnectorSecrets).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/kafka_connector_usage", s.ConnectorAdminHandler.GetConnectorUsage).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_types", s.ConnectorAdminHandler.ListConnectorTypes).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_types/{connector_type_id}", s.ConnectorAdminHandler.GetConnectorType).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/audit_events", coreHandlers.NewAuditEventHandler(s.AuditService).List).Methods(http.MethodGet)
 
 	v1Metadata := api.VersionMetadata{
 		ID:          "v1",
@@ -199,3 +259,90 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	apiRouter.Use(gorillaHandlers.CompressHandler)
 	return nil
 }
+
+// lookupConnector returns the connector roles are granted on, whoever the user of the request is
+func (s *options) lookupConnector(ctx context.Context, id string) (*rbac.Resource, *kerrors.ServiceError) {
+	var connector dbapi.Connector
+	if err := s.DB.New().Select("id", "owner", "organisation_id").Where("id = ?", id).First(&connector).Error; err != nil {
+		return nil, services.HandleGetError("Connector", "id", id, err)
+	}
+	return &rbac.Resource{
+		Type:           api.GrantResourceTypeConnector,
+		Id:             connector.ID,
+		Owner:          connector.Owner,
+		OrganisationId: connector.OrganisationId,
+	}, nil
+}
+
+// snapshotConnector returns the state of a connector recorded in the audit events, without its configuration which holds
+// references to its secrets
+func (s *options) snapshotConnector(ctx context.Context, id string) (interface{}, *kerrors.ServiceError) {
+	var connector dbapi.Connector
+	if err := s.DB.New().Where("id = ?", id).First(&connector).Error; err != nil {
+		return nil, services.HandleGetError("Connector", "id", id, err)
+	}
+	return map[string]interface{}{
+		"id":                connector.ID,
+		"name":              connector.Name,
+		"owner":             connector.Owner,
+		"organisation_id":   connector.OrganisationId,
+		"namespace_id":      connector.NamespaceId,
+		"connector_type_id": connector.ConnectorTypeId,
+		"channel":           connector.Channel,
+		"desired_state":     connector.DesiredState,
+		"kafka_id":          connector.Kafka.KafkaID,
+		"restart_policy":    connector.RestartPolicy.Type,
+		"version":           connector.Version,
+	}, nil
+}
+
+// snapshotConnectorCluster returns the state of a connector cluster recorded in the audit events, without the
+// credentials of its agent
+func (s *options) snapshotConnectorCluster(ctx context.Context, id string) (interface{}, *kerrors.ServiceError) {
+	var cluster dbapi.ConnectorCluster
+	if err := s.DB.New().Preload("Annotations").Where("id = ?", id).First(&cluster).Error; err != nil {
+		return nil, services.HandleGetError("Connector cluster", "id", id, err)
+	}
+	annotations := make(map[string]string, len(cluster.Annotations))
+	for _, annotation := range cluster.Annotations {
+		annotations[annotation.Key] = annotation.Value
+	}
+	return map[string]interface{}{
+		"id":              cluster.ID,
+		"name":            cluster.Name,
+		"owner":           cluster.Owner,
+		"organisation_id": cluster.OrganisationId,
+		"client_id":       cluster.ClientId,
+		"annotations":     annotations,
+		"status":          cluster.Status.Phase,
+	}, nil
+}
+
+// snapshotConnectorNamespace returns the state of a connector namespace recorded in the audit events
+func (s *options) snapshotConnectorNamespace(ctx context.Context, id string) (interface{}, *kerrors.ServiceError) {
+	var namespace dbapi.ConnectorNamespace
+	if err := s.DB.New().Preload("Annotations").Where("id = ?", id).First(&namespace).Error; err != nil {
+		return nil, services.HandleGetError("Connector namespace", "id", id, err)
+	}
+	annotations := make(map[string]string, len(namespace.Annotations))
+	for _, annotation := range namespace.Annotations {
+		annotations[annotation.Key] = annotation.Value
+	}
+	snapshot := map[string]interface{}{
+		"id":          namespace.ID,
+		"name":        namespace.Name,
+		"owner":       namespace.Owner,
+		"cluster_id":  namespace.ClusterId,
+		"expiration":  namespace.Expiration,
+		"annotations": annotations,
+		"status":      namespace.Status.Phase,
+		"version":     namespace.Version,
+	}
+	if namespace.TenantOrganisationId != nil {
+		snapshot["organisation_id"] = *namespace.TenantOrganisationId
+	}
+	if namespace.TenantUserId != nil {
+		snapshot["tenant_user_id"] = *namespace.TenantUserId
+	}
+	return snapshot, nil
+}
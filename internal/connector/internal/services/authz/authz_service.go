@@ -3,6 +3,7 @@ package authz
 import (
 	"context"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
@@ -175,13 +176,26 @@ func (u *ValidationUser) AuthorizedCreateEvalNamespace() handlers.Validate {
 	}
 }
 
-func (u *ValidationUser) ValidateNamespaceConnectorQuota() handlers.ValidateOption {
+func (u *ValidationUser) ValidateNamespaceConnectorQuota(connectorTypeId *string, channel *string, pending ...*dbapi.Connector) handlers.ValidateOption {
 	return func(field string, value *string) (err *errors.ServiceError) {
 		if u.err != nil {
 			err = u.err
 		} else {
 			if value != nil && len(*value) > 0 {
-				err = u.service.namespaceService.CheckConnectorQuota(*value)
+				err = u.service.namespaceService.CheckConnectorQuota(*value, *connectorTypeId, *channel, pending...)
+			}
+		}
+		return err
+	}
+}
+
+func (u *ValidationUser) ValidateNamespaceConnectorUpdateQuota(connectorId string, connectorTypeId *string, channel *string) handlers.ValidateOption {
+	return func(field string, value *string) (err *errors.ServiceError) {
+		if u.err != nil {
+			err = u.err
+		} else {
+			if value != nil && len(*value) > 0 {
+				err = u.service.namespaceService.CheckConnectorUpdateQuota(*value, connectorId, *connectorTypeId, *channel)
 			}
 		}
 		return err
@@ -7,6 +7,7 @@ import (
 	"gorm.io/gorm/clause"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/private"
@@ -16,11 +17,17 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/secrets"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	"github.com/golang/glog"
+	"github.com/spyzhov/ajson"
+	"github.com/xeipuuv/gojsonschema"
 	"gorm.io/gorm"
 )
 
@@ -42,6 +49,8 @@ type ConnectorClusterService interface {
 	GetDeployment(ctx context.Context, id string) (dbapi.ConnectorDeployment, *errors.ServiceError)
 	GetAvailableDeploymentOperatorUpgrades(listArgs *services.ListArguments) (dbapi.ConnectorDeploymentOperatorUpgradeList, *api.PagingMeta, *errors.ServiceError)
 	UpgradeConnectorsByOperator(ctx context.Context, clusterId string, upgrades dbapi.ConnectorDeploymentOperatorUpgradeList) *errors.ServiceError
+	GetAvailableDeploymentDeprecationUpgrades(clusterId string, listArgs *services.ListArguments) (dbapi.ConnectorDeploymentDeprecationUpgradeList, *api.PagingMeta, *errors.ServiceError)
+	UpgradeConnectorsByDeprecation(ctx context.Context, clusterId string, upgrades dbapi.ConnectorDeploymentDeprecationUpgradeList) *errors.ServiceError
 	CleanupDeployments() *errors.ServiceError
 	ReconcileEmptyDeletingClusters(ctx context.Context, clusterIds []string) (int, []*errors.ServiceError)
 	ReconcileNonEmptyDeletingClusters(ctx context.Context, clusterIds []string) (int, []*errors.ServiceError)
@@ -50,6 +59,8 @@ type ConnectorClusterService interface {
 	ResetServiceAccount(ctx context.Context, cluster *dbapi.ConnectorCluster) *errors.ServiceError
 }
 
+const connectorClusterEventSource = "/api/connector_mgmt/v1/kafka_connector_clusters"
+
 var _ ConnectorClusterService = &connectorClusterService{}
 var _ auth.AuthAgentService = &connectorClusterService{}
 
@@ -61,11 +72,13 @@ type connectorClusterService struct {
 	keycloakService           sso.KafkaKeycloakService
 	connectorsService         ConnectorsService
 	connectorNamespaceService ConnectorNamespaceService
+	connectorRevisionsService ConnectorRevisionsService
 }
 
 func NewConnectorClusterService(connectionFactory *db.ConnectionFactory, bus signalbus.SignalBus, vaultService vault.VaultService,
 	connectorTypesService ConnectorTypesService, connectorsService ConnectorsService,
-	keycloakService sso.KafkaKeycloakService, connectorNamespaceService ConnectorNamespaceService) *connectorClusterService {
+	keycloakService sso.KafkaKeycloakService, connectorNamespaceService ConnectorNamespaceService,
+	connectorRevisionsService ConnectorRevisionsService) *connectorClusterService {
 	return &connectorClusterService{
 		connectionFactory:         connectionFactory,
 		bus:                       bus,
@@ -74,6 +87,7 @@ func NewConnectorClusterService(connectionFactory *db.ConnectionFactory, bus sig
 		connectorsService:         connectorsService,
 		keycloakService:           keycloakService,
 		connectorNamespaceService: connectorNamespaceService,
+		connectorRevisionsService: connectorRevisionsService,
 	}
 }
 
@@ -336,6 +350,7 @@ func (k *connectorClusterService) UpdateConnectorClusterStatus(ctx context.Conte
 	}
 
 	// compare current and requested cluster phases to validate that agent can connect
+	previousPhase := resource.Status.Phase
 	updated, err := phase.PerformClusterOperation(&resource, phase.ConnectCluster)
 	if err != nil {
 		return err
@@ -355,15 +370,27 @@ func (k *connectorClusterService) UpdateConnectorClusterStatus(ctx context.Conte
 			})
 		}
 
-		if err := dbConn.Updates(&dbapi.ConnectorCluster{
-			Model: db.Model{ID: id},
-			Status: dbapi.ConnectorClusterStatus{
-				Phase:      resource.Status.Phase,
-				Version:    status.Version,
-				Conditions: status.Conditions,
-				Operators:  status.Operators,
-				Platform:   status.Platform,
-			}}).Error; err != nil {
+		if err := dbConn.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Updates(&dbapi.ConnectorCluster{
+				Model: db.Model{ID: id},
+				Status: dbapi.ConnectorClusterStatus{
+					Phase:      resource.Status.Phase,
+					Version:    status.Version,
+					Conditions: status.Conditions,
+					Operators:  status.Operators,
+					Platform:   status.Platform,
+				}}).Error; err != nil {
+				return err
+			}
+			if !updated {
+				return nil
+			}
+			return webhooks.RecordEvent(tx, webhooks.ConnectorClusterStatusChangedEventType, connectorClusterEventSource, id, resource.OrganisationId, webhooks.StatusChange{
+				ID:             id,
+				Status:         string(resource.Status.Phase),
+				PreviousStatus: string(previousPhase),
+			})
+		}); err != nil {
 			return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update status")
 		}
 	}
@@ -523,6 +550,7 @@ func (k *connectorClusterService) UpdateConnectorDeploymentStatus(ctx context.Co
 		return services.HandleGetError("Connector", "id", deployment.ConnectorID, err)
 	}
 
+	previousPhase := connectorStatus.Phase
 	connectorStatus.Phase = deploymentStatus.Phase
 	if deploymentStatus.Phase == dbapi.ConnectorStatusPhaseDeleted {
 		// we don't need the deployment anymore...
@@ -532,7 +560,19 @@ func (k *connectorClusterService) UpdateConnectorDeploymentStatus(ctx context.Co
 	}
 
 	// update the connector status
-	if err := dbConn.Where("id = ?", deployment.ConnectorID).Updates(&connectorStatus).Error; err != nil {
+	if err := dbConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", deployment.ConnectorID).Updates(&connectorStatus).Error; err != nil {
+			return err
+		}
+		// a connector that recovered is restarted from the first attempt of its restart policy if it fails again
+		if connectorStatus.Phase == dbapi.ConnectorStatusPhaseReady && previousPhase != dbapi.ConnectorStatusPhaseReady {
+			if err := tx.Model(&dbapi.ConnectorStatus{}).Where("id = ?", deployment.ConnectorID).
+				Update("conditions", nil).Error; err != nil {
+				return err
+			}
+		}
+		return recordConnectorStatusChange(tx, deployment.ConnectorID, previousPhase, connectorStatus.Phase)
+	}); err != nil {
 		return services.HandleUpdateError("Connector status", err)
 	}
 
@@ -727,6 +767,249 @@ func toOperatorMap(arr []dbapi.ConnectorDeploymentOperatorUpgrade) map[string]db
 	return m
 }
 
+// GetAvailableDeploymentDeprecationUpgrades returns the connectors deployed in a cluster whose connector type or channel
+// is deprecated, with their replacement connector type and channel
+func (k *connectorClusterService) GetAvailableDeploymentDeprecationUpgrades(clusterId string, listArgs *services.ListArguments) (upgrades dbapi.ConnectorDeploymentDeprecationUpgradeList, paging *api.PagingMeta, serr *errors.ServiceError) {
+
+	type Result struct {
+		ConnectorID                string
+		DeploymentID               string
+		ConnectorTypeID            string
+		NamespaceID                string
+		Channel                    string
+		DeprecatedChannel          string
+		ReplacementConnectorTypeID string
+		ReplacementChannel         string
+		SunsetAt                   time.Time
+	}
+
+	results := []Result{}
+	dbConn := k.connectionFactory.New()
+	dbConn = dbConn.Table("connector_deployments")
+	dbConn = dbConn.Select(
+		"connector_deployments.connector_id AS connector_id",
+		"connector_deployments.id AS deployment_id",
+		"connector_deployments.namespace_id AS namespace_id",
+		"connectors.connector_type_id",
+		"connectors.channel",
+		"connector_type_deprecations.channel AS deprecated_channel",
+		"connector_type_deprecations.replacement_connector_type_id",
+		"connector_type_deprecations.replacement_channel",
+		"connector_type_deprecations.sunset_at",
+	)
+	dbConn = dbConn.Joins("JOIN connectors ON connectors.id = connector_deployments.connector_id AND connectors.deleted_at IS NULL")
+	dbConn = dbConn.Joins("JOIN connector_type_deprecations ON connector_type_deprecations.connector_type_id = connectors.connector_type_id AND " +
+		"(connector_type_deprecations.channel = connectors.channel OR connector_type_deprecations.channel = '')")
+	dbConn = dbConn.Where("connector_deployments.cluster_id = ? AND connector_deployments.deleted_at IS NULL", clusterId)
+	dbConn = dbConn.Order("connector_deployments.connector_id, connector_type_deprecations.channel DESC")
+
+	if err := dbConn.Scan(&results).Error; err != nil {
+		return upgrades, paging, services.HandleGetError(`Connector deployment`, `cluster_id`, clusterId, err)
+	}
+
+	// a channel deprecation sorts before the deprecation of its connector type, and takes precedence
+	upgrades = make(dbapi.ConnectorDeploymentDeprecationUpgradeList, 0, len(results))
+	for _, r := range results {
+		if len(upgrades) > 0 && upgrades[len(upgrades)-1].ConnectorID == r.ConnectorID {
+			continue
+		}
+		upgrade := dbapi.ConnectorDeploymentDeprecationUpgrade{
+			ConnectorID:                r.ConnectorID,
+			DeploymentID:               r.DeploymentID,
+			ConnectorTypeId:            r.ConnectorTypeID,
+			NamespaceID:                r.NamespaceID,
+			Channel:                    r.Channel,
+			ReplacementConnectorTypeId: r.ReplacementConnectorTypeID,
+			ReplacementChannel:         r.ReplacementChannel,
+			SunsetAt:                   r.SunsetAt,
+		}
+		if upgrade.ReplacementConnectorTypeId == "" {
+			upgrade.ReplacementConnectorTypeId = r.ConnectorTypeID
+		}
+		if upgrade.ReplacementChannel == "" {
+			upgrade.ReplacementChannel = r.Channel
+		}
+		upgrades = append(upgrades, upgrade)
+	}
+
+	// the upgrades are paged once the deprecations of each connector are merged, all of them are returned without size
+	paging = &api.PagingMeta{
+		Page:  listArgs.Page,
+		Size:  len(upgrades),
+		Total: len(upgrades),
+	}
+	if listArgs.Size > 0 && listArgs.Page > 0 {
+		start := (listArgs.Page - 1) * listArgs.Size
+		if start > len(upgrades) {
+			start = len(upgrades)
+		}
+		end := start + listArgs.Size
+		if end > len(upgrades) {
+			end = len(upgrades)
+		}
+		upgrades = upgrades[start:end]
+		paging.Size = len(upgrades)
+	}
+
+	return
+}
+
+// UpgradeConnectorsByDeprecation migrates connectors of deprecated connector types or channels to their replacement,
+// the connector spec must be valid for the replacement connector type
+func (k *connectorClusterService) UpgradeConnectorsByDeprecation(ctx context.Context, clusterId string, upgrades dbapi.ConnectorDeploymentDeprecationUpgradeList) *errors.ServiceError {
+	available, _, serr := k.GetAvailableDeploymentDeprecationUpgrades(clusterId, &services.ListArguments{})
+	if serr != nil {
+		return serr
+	}
+
+	availableConnectors := make(map[string]dbapi.ConnectorDeploymentDeprecationUpgrade, len(available))
+	for _, upgrade := range available {
+		availableConnectors[upgrade.ConnectorID] = upgrade
+	}
+
+	// validate requested upgrades
+	var errorList errors.ErrorList
+	for _, upgrade := range upgrades {
+		availableUpgrade, ok := availableConnectors[upgrade.ConnectorID]
+		if !ok {
+			errorList = append(errorList, errors.Conflict("deprecation upgrade not available for connector %s", upgrade.ConnectorID))
+			continue
+		}
+
+		// make sure other bits match
+		upgrade.DeploymentID = availableUpgrade.DeploymentID
+		upgrade.SunsetAt = availableUpgrade.SunsetAt
+		if !reflect.DeepEqual(upgrade, availableUpgrade) {
+			errorList = append(errorList, errors.Conflict("deprecation upgrade is outdated for connector %s", upgrade.ConnectorID))
+		}
+	}
+	if len(errorList) != 0 {
+		return errors.Conflict(errorList.Error())
+	}
+
+	// all the connectors are migrated, or none of them
+	if err := k.connectionFactory.New().Transaction(func(dbConn *gorm.DB) error {
+		for _, upgrade := range upgrades {
+			if serr := k.upgradeConnectorByDeprecation(ctx, dbConn, availableConnectors[upgrade.ConnectorID]); serr != nil {
+				errorList = append(errorList, serr)
+			}
+		}
+		if len(errorList) != 0 {
+			return errors.Conflict(errorList.Error())
+		}
+		return nil
+	}); err != nil {
+		return errors.ToServiceError(err)
+	}
+
+	_ = db.AddPostCommitAction(ctx, func() {
+		k.bus.Notify("reconcile:connector")
+		k.bus.Notify(fmt.Sprintf("/kafka_connector_clusters/%s/deployments", clusterId))
+	})
+	return nil
+}
+
+// upgradeConnectorByDeprecation moves a connector and its deployment to the replacement connector type and channel,
+// and records the migrated connector as a new revision
+func (k *connectorClusterService) upgradeConnectorByDeprecation(ctx context.Context, dbConn *gorm.DB, upgrade dbapi.ConnectorDeploymentDeprecationUpgrade) *errors.ServiceError {
+	ct, serr := k.connectorTypesService.Get(upgrade.ReplacementConnectorTypeId)
+	if serr != nil {
+		return errors.Conflict("replacement connector type %s of connector %s is not available: %s",
+			upgrade.ReplacementConnectorTypeId, upgrade.ConnectorID, serr.Reason)
+	}
+	if !arrays.Contains(ct.ChannelNames(), upgrade.ReplacementChannel) {
+		return errors.Conflict("replacement channel %s of connector %s is not a channel of connector type %s",
+			upgrade.ReplacementChannel, upgrade.ConnectorID, ct.ID)
+	}
+	shardMetadata, serr := k.connectorTypesService.GetLatestConnectorShardMetadata(ct.ID, upgrade.ReplacementChannel)
+	if serr != nil {
+		return serr
+	}
+
+	var connector dbapi.Connector
+	if err := dbConn.Where("id = ?", upgrade.ConnectorID).First(&connector).Error; err != nil {
+		return services.HandleGetError("Connector", "id", upgrade.ConnectorID, err)
+	}
+
+	if connector.ConnectorTypeId != ct.ID {
+		if serr := validateDeprecationUpgradeSpec(&connector, ct); serr != nil {
+			return errors.Conflict("connector %s can't be migrated to connector type %s: %s", connector.ID, ct.ID, serr.Reason)
+		}
+
+		// replace the annotations copied from the deprecated connector type
+		if err := dbConn.Exec("DELETE FROM connector_annotations ca USING connector_type_annotations cta "+
+			"WHERE ca.connector_id = ? AND cta.connector_type_id = ? AND ca.key = cta.key",
+			connector.ID, connector.ConnectorTypeId).Error; err != nil {
+			return services.HandleUpdateError("Connector annotations", err)
+		}
+		if err := dbConn.Exec("INSERT INTO connector_annotations (connector_id, key, value) "+
+			"SELECT ?, key, value FROM connector_type_annotations WHERE connector_type_id = ? "+
+			"ON CONFLICT (connector_id, key) DO UPDATE SET value = EXCLUDED.value",
+			connector.ID, ct.ID).Error; err != nil {
+			return services.HandleUpdateError("Connector annotations", err)
+		}
+	}
+
+	// the connectors version trigger bumps the version, which redeploys the connector
+	update := dbConn.Model(&dbapi.Connector{}).
+		Where("id = ? AND version = ?", connector.ID, connector.Version).
+		Updates(map[string]interface{}{
+			"connector_type_id": ct.ID,
+			"channel":           upgrade.ReplacementChannel,
+		})
+	if err := update.Error; err != nil {
+		return services.HandleUpdateError("Connector", err)
+	}
+	if update.RowsAffected == 0 {
+		return errors.Conflict("connector %s changed while it was migrated", connector.ID)
+	}
+	if err := dbConn.Model(&dbapi.ConnectorDeployment{}).Where("id = ?", upgrade.DeploymentID).
+		Update("connector_shard_metadata_id", shardMetadata.ID).Error; err != nil {
+		return services.HandleUpdateError("Connector deployment", err)
+	}
+
+	// read it back to record the migrated connector
+	if err := dbConn.Where("id = ?", connector.ID).First(&connector).Error; err != nil {
+		return services.HandleGetError("Connector", "id", connector.ID, err)
+	}
+	if serr := k.connectorRevisionsService.Record(ctx, dbConn, &connector); serr != nil {
+		return serr
+	}
+
+	glog.Infof("migrated connector %s from connector type %s channel %s to connector type %s channel %s",
+		connector.ID, upgrade.ConnectorTypeId, upgrade.Channel, ct.ID, upgrade.ReplacementChannel)
+	return nil
+}
+
+// validateDeprecationUpgradeSpec validates the connector spec, without its secrets, with the replacement connector type schema
+func validateDeprecationUpgradeSpec(connector *dbapi.Connector, ct *dbapi.ConnectorType) *errors.ServiceError {
+	spec := connector.ConnectorSpec
+	if len(spec) != 0 {
+		var err error
+		spec, err = secrets.ModifySecrets(ct.JsonSchema, spec, func(node *ajson.Node) error {
+			if node.Type() == ajson.Object {
+				return node.SetObject(map[string]*ajson.Node{})
+			} else if node.Type() != ajson.Null {
+				return node.SetNull()
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.GeneralError("could not remove connector secrets: %v", err)
+		}
+	}
+	document, err := spec.Object()
+	if err != nil {
+		return errors.GeneralError("invalid connector spec: %v", err)
+	}
+	schema, serr := ct.JsonSchemaAsMap()
+	if serr != nil {
+		return serr
+	}
+	return handlers.ValidateJsonSchema("connector type schema", gojsonschema.NewGoLoader(schema),
+		"connector spec", gojsonschema.NewGoLoader(document))
+}
+
 // ReconcileDeletingClusters deletes empty clusters with no namespaces that are in phase deleting,
 // it also deletes their service accounts to disconnect from the agent gracefully
 func (k *connectorClusterService) ReconcileEmptyDeletingClusters(_ context.Context, clusterIds []string) (int, []*errors.ServiceError) {
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+	"gorm.io/gorm"
+)
+
+const deprecationTestSchema = `{
+	"type": "object",
+	"required": ["kafka_topic", "aws_secret_key"],
+	"properties": {
+		"kafka_topic": {"type": "string"},
+		"aws_secret_key": {
+			"oneOf": [
+				{"type": "string", "format": "password"},
+				{"type": "object", "properties": {}}
+			]
+		}
+	}
+}`
+
+var deprecationTestSunsetAt = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func Test_validateDeprecationUpgradeSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr bool
+	}{
+		{
+			name: "should accept a spec valid for the replacement connector type",
+			spec: `{"kafka_topic": "topic", "aws_secret_key": {"kind": "base64", "ref": "secret-ref"}}`,
+		},
+		{
+			name:    "should reject a spec missing a field required by the replacement connector type",
+			spec:    `{"aws_secret_key": {"ref": "secret-ref"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "should reject a spec with a field of the wrong type",
+			spec:    `{"kafka_topic": 42, "aws_secret_key": {"ref": "secret-ref"}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			connector := &dbapi.Connector{ConnectorSpec: api.JSON(tt.spec)}
+			ct := &dbapi.ConnectorType{Model: db.Model{ID: "replacement"}, JsonSchema: api.JSON(deprecationTestSchema)}
+			err := validateDeprecationUpgradeSpec(connector, ct)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			// the secrets of the connector are left untouched
+			g.Expect(string(connector.ConnectorSpec)).To(gomega.Equal(tt.spec))
+		})
+	}
+}
+
+func Test_connectorClusterService_GetAvailableDeploymentDeprecationUpgrades(t *testing.T) {
+	rows := []map[string]interface{}{
+		// the channel deprecation of connector-1 sorts before the deprecation of its connector type
+		deprecationRow("connector-1", "stable", "stable", "", "beta"),
+		deprecationRow("connector-1", "stable", "", "replacement-type", ""),
+		deprecationRow("connector-2", "stable", "", "replacement-type", ""),
+		deprecationRow("connector-3", "beta", "", "", ""),
+	}
+
+	tests := []struct {
+		name       string
+		listArgs   *services.ListArguments
+		want       []string
+		wantPaging *api.PagingMeta
+	}{
+		{
+			name:       "should return all the upgrades without a page size",
+			listArgs:   &services.ListArguments{},
+			want:       []string{"connector-1", "connector-2", "connector-3"},
+			wantPaging: &api.PagingMeta{Page: 0, Size: 3, Total: 3},
+		},
+		{
+			name:       "should return a page of the upgrades",
+			listArgs:   &services.ListArguments{Page: 2, Size: 2},
+			want:       []string{"connector-3"},
+			wantPaging: &api.PagingMeta{Page: 2, Size: 1, Total: 3},
+		},
+		{
+			name:       "should return no upgrades past the last page",
+			listArgs:   &services.ListArguments{Page: 3, Size: 2},
+			want:       []string{},
+			wantPaging: &api.PagingMeta{Page: 3, Size: 0, Total: 3},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset().NewMock().WithQuery("connector_type_deprecations").WithReply(rows)
+			k := &connectorClusterService{connectionFactory: db.NewMockConnectionFactory(nil)}
+
+			upgrades, paging, err := k.GetAvailableDeploymentDeprecationUpgrades("cluster-id", tt.listArgs)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(paging).To(gomega.Equal(tt.wantPaging))
+			ids := make([]string, len(upgrades))
+			for i, upgrade := range upgrades {
+				ids[i] = upgrade.ConnectorID
+			}
+			g.Expect(ids).To(gomega.Equal(tt.want))
+		})
+	}
+
+	t.Run("should merge the deprecations of a connector and default the replacement to the deprecated type and channel", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		mocket.Catcher.Reset().NewMock().WithQuery("connector_type_deprecations").WithReply(rows)
+		k := &connectorClusterService{connectionFactory: db.NewMockConnectionFactory(nil)}
+
+		upgrades, _, err := k.GetAvailableDeploymentDeprecationUpgrades("cluster-id", &services.ListArguments{})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(upgrades[0].ReplacementConnectorTypeId).To(gomega.Equal("deprecated-type"))
+		g.Expect(upgrades[0].ReplacementChannel).To(gomega.Equal("beta"))
+		g.Expect(upgrades[1].ReplacementConnectorTypeId).To(gomega.Equal("replacement-type"))
+		g.Expect(upgrades[1].ReplacementChannel).To(gomega.Equal("stable"))
+		g.Expect(upgrades[2].ReplacementConnectorTypeId).To(gomega.Equal("deprecated-type"))
+		g.Expect(upgrades[2].ReplacementChannel).To(gomega.Equal("beta"))
+	})
+}
+
+func Test_connectorClusterService_UpgradeConnectorsByDeprecation(t *testing.T) {
+	available := deprecationRow("connector-1", "stable", "stable", "", "beta")
+	upgrade := dbapi.ConnectorDeploymentDeprecationUpgrade{
+		ConnectorID:                "connector-1",
+		NamespaceID:                "namespace-id",
+		ConnectorTypeId:            "deprecated-type",
+		Channel:                    "stable",
+		ReplacementConnectorTypeId: "deprecated-type",
+		ReplacementChannel:         "beta",
+	}
+	outdated := upgrade
+	outdated.ReplacementChannel = "stable"
+	unknown := upgrade
+	unknown.ConnectorID = "connector-2"
+
+	tests := []struct {
+		name            string
+		upgrades        dbapi.ConnectorDeploymentDeprecationUpgradeList
+		channels        []string
+		updatedRows     int64
+		wantErr         string
+		wantMigrated    bool
+		wantRecordCalls int
+	}{
+		{
+			name:            "should migrate the connector to the replacement channel and record its revision",
+			upgrades:        dbapi.ConnectorDeploymentDeprecationUpgradeList{upgrade},
+			channels:        []string{"stable", "beta"},
+			updatedRows:     1,
+			wantMigrated:    true,
+			wantRecordCalls: 1,
+		},
+		{
+			name:     "should reject an outdated upgrade",
+			upgrades: dbapi.ConnectorDeploymentDeprecationUpgradeList{outdated},
+			channels: []string{"stable", "beta"},
+			wantErr:  "deprecation upgrade is outdated for connector connector-1",
+		},
+		{
+			name:     "should reject the upgrade of a connector without deprecation",
+			upgrades: dbapi.ConnectorDeploymentDeprecationUpgradeList{upgrade, unknown},
+			channels: []string{"stable", "beta"},
+			wantErr:  "deprecation upgrade not available for connector connector-2",
+		},
+		{
+			name:     "should reject a replacement channel that is not a channel of the connector type",
+			upgrades: dbapi.ConnectorDeploymentDeprecationUpgradeList{upgrade},
+			channels: []string{"stable"},
+			wantErr:  "replacement channel beta of connector connector-1 is not a channel of connector type deprecated-type",
+		},
+		{
+			name:         "should reject the upgrade of a connector changed while it is migrated",
+			upgrades:     dbapi.ConnectorDeploymentDeprecationUpgradeList{upgrade},
+			channels:     []string{"stable", "beta"},
+			updatedRows:  0,
+			wantErr:      "connector connector-1 changed while it was migrated",
+			wantMigrated: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			migrated := false
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery("connector_type_deprecations").WithReply([]map[string]interface{}{available})
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "connectors"`).
+				WithReply([]map[string]interface{}{{"id": "connector-1", "connector_type_id": "deprecated-type", "channel": "stable", "version": 3}})
+			mocket.Catcher.NewMock().WithQuery(`UPDATE "connectors"`).WithRowsNum(tt.updatedRows).
+				WithCallback(func(query string, args []driver.NamedValue) {
+					migrated = strings.Contains(query, `"channel"=`)
+				})
+
+			connectorTypesService := &ConnectorTypesServiceMock{
+				GetFunc: func(id string) (*dbapi.ConnectorType, *errors.ServiceError) {
+					channels := make([]dbapi.ConnectorChannel, len(tt.channels))
+					for i, channel := range tt.channels {
+						channels[i] = dbapi.ConnectorChannel{Channel: channel}
+					}
+					return &dbapi.ConnectorType{Model: db.Model{ID: id}, Channels: channels}, nil
+				},
+				GetLatestConnectorShardMetadataFunc: func(typeId string, channel string) (*dbapi.ConnectorShardMetadata, *errors.ServiceError) {
+					return &dbapi.ConnectorShardMetadata{ID: 42, ConnectorTypeId: typeId, Channel: channel}, nil
+				},
+			}
+			connectorRevisionsService := &ConnectorRevisionsServiceMock{
+				RecordFunc: func(ctx context.Context, dbConn *gorm.DB, connector *dbapi.Connector) *errors.ServiceError {
+					return nil
+				},
+			}
+			k := &connectorClusterService{
+				connectionFactory:         db.NewMockConnectionFactory(nil),
+				bus:                       signalbus.NewSignalBus(),
+				connectorTypesService:     connectorTypesService,
+				connectorRevisionsService: connectorRevisionsService,
+			}
+
+			err := k.UpgradeConnectorsByDeprecation(context.Background(), "cluster-id", tt.upgrades)
+			if tt.wantErr != "" {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(errors.ErrorConflict))
+				g.Expect(err.Reason).To(gomega.ContainSubstring(tt.wantErr))
+			} else {
+				g.Expect(err).To(gomega.BeNil())
+			}
+			g.Expect(migrated).To(gomega.Equal(tt.wantMigrated))
+			g.Expect(connectorRevisionsService.RecordCalls()).To(gomega.HaveLen(tt.wantRecordCalls))
+		})
+	}
+}
+
+func deprecationRow(connectorId, channel, deprecatedChannel, replacementType, replacementChannel string) map[string]interface{} {
+	return map[string]interface{}{
+		"connector_id":                  connectorId,
+		"deployment_id":                 "deployment-" + connectorId,
+		"namespace_id":                  "namespace-id",
+		"connector_type_id":             "deprecated-type",
+		"channel":                       channel,
+		"deprecated_channel":            deprecatedChannel,
+		"replacement_connector_type_id": replacementType,
+		"replacement_channel":           replacementChannel,
+		"sunset_at":                     deprecationTestSunsetAt,
+	}
+}
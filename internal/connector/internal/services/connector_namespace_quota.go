@@ -0,0 +1,244 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/profiles"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// connectorShardMetadataResources are the CPU and memory requests and limits a connector type channel declares
+// in its shard metadata, e.g. {"resources": {"requests": {"cpu": "250m", "memory": "256Mi"}, "limits": {...}}}
+type connectorShardMetadataResources struct {
+	Resources struct {
+		Requests map[string]string `json:"requests,omitempty"`
+		Limits   map[string]string `json:"limits,omitempty"`
+	} `json:"resources,omitempty"`
+}
+
+// namespaceResources sums the resources reserved by the connectors in a namespace
+type namespaceResources struct {
+	connectors     int64
+	memoryRequests resource.Quantity
+	memoryLimits   resource.Quantity
+	cpuRequests    resource.Quantity
+	cpuLimits      resource.Quantity
+}
+
+// add adds the resources declared in the shard metadata of count connectors
+func (r *namespaceResources) add(shardMetadata api.JSON, count int64) error {
+	r.connectors += count
+	if len(shardMetadata) == 0 {
+		return nil
+	}
+
+	var meta connectorShardMetadataResources
+	if err := json.Unmarshal(shardMetadata, &meta); err != nil {
+		return err
+	}
+	for _, q := range []struct {
+		values map[string]string
+		name   string
+		total  *resource.Quantity
+	}{
+		{meta.Resources.Requests, "memory", &r.memoryRequests},
+		{meta.Resources.Limits, "memory", &r.memoryLimits},
+		{meta.Resources.Requests, "cpu", &r.cpuRequests},
+		{meta.Resources.Limits, "cpu", &r.cpuLimits},
+	} {
+		if value, ok := q.values[q.name]; ok {
+			quantity, err := resource.ParseQuantity(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s quantity %s: %w", q.name, value, err)
+			}
+			q.total.Add(*resource.NewMilliQuantity(quantity.MilliValue()*count, quantity.Format))
+		}
+	}
+	return nil
+}
+
+// namespaceQuotaResource is a CPU or memory quota of a namespace with the quantity reserved by its connectors
+type namespaceQuotaResource struct {
+	name  string
+	quota string
+	used  resource.Quantity
+}
+
+func (r *namespaceResources) quotaResources(quota config.NamespaceQuota) []namespaceQuotaResource {
+	return []namespaceQuotaResource{
+		{"memory requests", quota.MemoryRequests, r.memoryRequests},
+		{"memory limits", quota.MemoryLimits, r.memoryLimits},
+		{"cpu requests", quota.CPURequests, r.cpuRequests},
+		{"cpu limits", quota.CPULimits, r.cpuLimits},
+	}
+}
+
+func hasResourceQuota(quota config.NamespaceQuota) bool {
+	return quota.MemoryRequests != "" || quota.MemoryLimits != "" || quota.CPURequests != "" || quota.CPULimits != ""
+}
+
+func (k *connectorNamespaceService) CheckConnectorQuota(namespaceId string, connectorTypeId string, channel string, pending ...*dbapi.Connector) *errors.ServiceError {
+	return k.checkConnectorQuota(namespaceId, "", connectorTypeId, channel, pending)
+}
+
+func (k *connectorNamespaceService) CheckConnectorUpdateQuota(namespaceId string, connectorId string, connectorTypeId string, channel string) *errors.ServiceError {
+	return k.checkConnectorQuota(namespaceId, connectorId, connectorTypeId, channel, nil)
+}
+
+// checkConnectorQuota checks the quota of a namespace with a connector added to the connectors in the namespace,
+// a connector updated in the namespace is not counted twice by excluding its id. The pending connectors, that are
+// about to be created along with the connector, are counted as if they were already in the namespace.
+func (k *connectorNamespaceService) checkConnectorQuota(namespaceId string, excludeConnectorId string, connectorTypeId string, channel string, pending []*dbapi.Connector) *errors.ServiceError {
+	dbConn := k.connectionFactory.New()
+	var profileName string
+	if err := dbConn.Model(&dbapi.ConnectorNamespaceAnnotation{}).
+		Where("namespace_id = ? AND key = ?", namespaceId, profiles.AnnotationProfileKey).
+		Select("value").First(&profileName).Error; err != nil {
+		return errors.FailedToCheckQuota("error reading Connector namespace annotation with namespace id %s: %s", namespaceId, err)
+	}
+	quota, _ := k.quotaConfig.GetNamespaceQuota(profileName)
+	if quota.Connectors <= 0 && !hasResourceQuota(quota) {
+		return nil
+	}
+
+	usedResources, serr := k.getResourcesUsed([]string{namespaceId}, excludeConnectorId)
+	if serr != nil {
+		return serr
+	}
+	used := usedResources[namespaceId]
+	if quota.Connectors > 0 && used.connectors+int64(len(pending)) >= int64(quota.Connectors) {
+		return errors.InsufficientQuotaError("the maximum number of allowed connectors has been reached")
+	}
+	if !hasResourceQuota(quota) {
+		return nil
+	}
+
+	// add the resources of the latest shard metadata of the connector type channel, if any
+	added := append([]*dbapi.Connector{{ConnectorTypeId: connectorTypeId, Channel: channel}}, pending...)
+	for _, c := range added {
+		var shardMetadata dbapi.ConnectorShardMetadata
+		if err := dbConn.Where("connector_type_id = ? AND channel = ? AND latest_revision IS NULL", c.ConnectorTypeId, c.Channel).
+			Limit(1).Find(&shardMetadata).Error; err != nil {
+			return services.HandleGetError("Connector type shard metadata", "connector_type_id", c.ConnectorTypeId, err)
+		}
+		if err := used.add(shardMetadata.ShardMetadata, 1); err != nil {
+			return errors.GeneralError("invalid resources in shard metadata of connector type %s channel %s: %v", c.ConnectorTypeId, c.Channel, err)
+		}
+	}
+
+	for _, r := range used.quotaResources(quota) {
+		if r.quota == "" {
+			continue
+		}
+		limit, err := resource.ParseQuantity(r.quota)
+		if err != nil {
+			return errors.FailedToCheckQuota("invalid %s quota %s of quota profile %s: %s", r.name, r.quota, profileName, err)
+		}
+		if r.used.Cmp(limit) > 0 {
+			return errors.InsufficientQuotaError("the %s quota %s of the namespace would be exceeded, %s required", r.name, r.quota, r.used.String())
+		}
+	}
+	return nil
+}
+
+// getResourcesUsed returns the resources reserved by the connectors in the namespaces, using the latest shard metadata
+// of their connector type channel. Stopped connectors keep their resources so that they can always be restarted.
+func (k *connectorNamespaceService) getResourcesUsed(namespaceIds []string, excludeConnectorId string) (map[string]*namespaceResources, *errors.ServiceError) {
+	rows := make([]struct {
+		NamespaceId   string
+		ShardMetadata api.JSON
+		Count         int64
+	}, 0)
+	dbConn := k.connectionFactory.New().Table("connectors").
+		Select("connectors.namespace_id, connector_shard_metadata.shard_metadata, COUNT(connectors.id) AS count").
+		Joins("LEFT JOIN connector_shard_metadata ON connector_shard_metadata.connector_type_id = connectors.connector_type_id AND "+
+			"connector_shard_metadata.channel = connectors.channel AND connector_shard_metadata.latest_revision IS NULL").
+		Where("connectors.namespace_id IN ? AND connectors.deleted_at IS NULL", namespaceIds)
+	if excludeConnectorId != "" {
+		dbConn = dbConn.Where("connectors.id <> ?", excludeConnectorId)
+	}
+	if err := dbConn.Group("connectors.namespace_id, connector_shard_metadata.id, connector_shard_metadata.shard_metadata").
+		Scan(&rows).Error; err != nil {
+		return nil, services.HandleGetError("Connector", "namespace_id", namespaceIds, err)
+	}
+
+	result := make(map[string]*namespaceResources, len(namespaceIds))
+	for _, id := range namespaceIds {
+		result[id] = &namespaceResources{}
+	}
+	for _, row := range rows {
+		if err := result[row.NamespaceId].add(row.ShardMetadata, row.Count); err != nil {
+			return nil, errors.GeneralError("invalid resources in connector type shard metadata: %v", err)
+		}
+	}
+	return result, nil
+}
+
+// setResourcesUsed sets the resources used by the connectors in the namespaces, and the resources left available
+// in their quota
+func (k *connectorNamespaceService) setResourcesUsed(namespaces dbapi.ConnectorNamespaceList) *errors.ServiceError {
+	if len(namespaces) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(namespaces))
+	for i, ns := range namespaces {
+		ids[i] = ns.ID
+	}
+	usedResources, err := k.getResourcesUsed(ids, "")
+	if err != nil {
+		return err
+	}
+
+	for _, ns := range namespaces {
+		var quota config.NamespaceQuota
+		for _, anno := range ns.Annotations {
+			if anno.Key == profiles.AnnotationProfileKey {
+				quota, _ = k.quotaConfig.GetNamespaceQuota(anno.Value)
+			}
+		}
+
+		used := usedResources[ns.ID]
+		ns.ResourcesUsed = dbapi.ConnectorNamespaceResources{
+			Connectors:     int32(used.connectors),
+			MemoryRequests: used.memoryRequests.String(),
+			MemoryLimits:   used.memoryLimits.String(),
+			CPURequests:    used.cpuRequests.String(),
+			CPULimits:      used.cpuLimits.String(),
+		}
+
+		ns.ResourcesAvailable = dbapi.ConnectorNamespaceResources{}
+		if quota.Connectors > 0 && int64(quota.Connectors) > used.connectors {
+			ns.ResourcesAvailable.Connectors = quota.Connectors - int32(used.connectors)
+		}
+		available := make([]string, 0, 4)
+		for _, r := range used.quotaResources(quota) {
+			if r.quota == "" {
+				available = append(available, "")
+				continue
+			}
+			limit, err := resource.ParseQuantity(r.quota)
+			if err != nil {
+				return errors.GeneralError("invalid %s quota %s of namespace %s: %s", r.name, r.quota, ns.ID, err)
+			}
+			if limit.Cmp(r.used) > 0 {
+				limit.Sub(r.used)
+			} else {
+				limit = resource.Quantity{Format: limit.Format}
+			}
+			available = append(available, limit.String())
+		}
+		ns.ResourcesAvailable.MemoryRequests = available[0]
+		ns.ResourcesAvailable.MemoryLimits = available[1]
+		ns.ResourcesAvailable.CPURequests = available[2]
+		ns.ResourcesAvailable.CPULimits = available[3]
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/onsi/gomega"
+)
+
+func TestNamespaceResources_add(t *testing.T) {
+	type add struct {
+		shardMetadata string
+		count         int64
+	}
+	tests := []struct {
+		name           string
+		adds           []add
+		connectors     int64
+		memoryRequests string
+		memoryLimits   string
+		cpuRequests    string
+		cpuLimits      string
+		wantErr        bool
+	}{
+		{
+			name:           "no shard metadata only counts connectors",
+			adds:           []add{{"", 2}},
+			connectors:     2,
+			memoryRequests: "0",
+			memoryLimits:   "0",
+			cpuRequests:    "0",
+			cpuLimits:      "0",
+		},
+		{
+			name:           "shard metadata without resources only counts connectors",
+			adds:           []add{{`{"connector_image": "quay.io/mock-image:1.0.0"}`, 1}},
+			connectors:     1,
+			memoryRequests: "0",
+			memoryLimits:   "0",
+			cpuRequests:    "0",
+			cpuLimits:      "0",
+		},
+		{
+			name:           "resources are multiplied by the connectors count",
+			adds:           []add{{`{"resources": {"requests": {"cpu": "250m", "memory": "256Mi"}, "limits": {"cpu": "1", "memory": "1Gi"}}}`, 3}},
+			connectors:     3,
+			memoryRequests: "768Mi",
+			memoryLimits:   "3Gi",
+			cpuRequests:    "750m",
+			cpuLimits:      "3",
+		},
+		{
+			name: "resources are accumulated",
+			adds: []add{
+				{`{"resources": {"requests": {"cpu": "600m", "memory": "256Mi"}}}`, 1},
+				{`{"resources": {"requests": {"cpu": "600m"}, "limits": {"memory": "512Mi"}}}`, 1},
+				{"", 1},
+			},
+			connectors:     3,
+			memoryRequests: "256Mi",
+			memoryLimits:   "512Mi",
+			cpuRequests:    "1200m",
+			cpuLimits:      "0",
+		},
+		{
+			name:    "invalid quantity",
+			adds:    []add{{`{"resources": {"requests": {"cpu": "one"}}}`, 1}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid shard metadata",
+			adds:    []add{{`{"resources": []}`, 1}},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			r := &namespaceResources{}
+			var err error
+			for _, a := range tt.adds {
+				if err = r.add(api.JSON(a.shardMetadata), a.count); err != nil {
+					break
+				}
+			}
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(r.connectors).To(gomega.Equal(tt.connectors))
+			g.Expect(r.memoryRequests.String()).To(gomega.Equal(tt.memoryRequests))
+			g.Expect(r.memoryLimits.String()).To(gomega.Equal(tt.memoryLimits))
+			g.Expect(r.cpuRequests.String()).To(gomega.Equal(tt.cpuRequests))
+			g.Expect(r.cpuLimits.String()).To(gomega.Equal(tt.cpuLimits))
+		})
+	}
+}
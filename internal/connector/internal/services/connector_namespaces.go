@@ -41,7 +41,11 @@ type ConnectorNamespaceService interface {
 	ReconcileUsedDeletingNamespaces(ctx context.Context) (int64, *errors.ServiceError)
 	ReconcileDeletedNamespaces(ctx context.Context) (int64, *errors.ServiceError)
 	GetNamespaceTenant(namespaceId string) (*dbapi.ConnectorNamespace, *errors.ServiceError)
-	CheckConnectorQuota(namespaceId string) *errors.ServiceError
+	// CheckConnectorQuota checks that a new connector of the given type and channel fits in the namespace quota,
+	// along with the pending connectors about to be created in the namespace
+	CheckConnectorQuota(namespaceId string, connectorTypeId string, channel string, pending ...*dbapi.Connector) *errors.ServiceError
+	// CheckConnectorUpdateQuota checks that an existing connector updated or assigned to the namespace fits in its quota
+	CheckConnectorUpdateQuota(namespaceId string, connectorId string, connectorTypeId string, channel string) *errors.ServiceError
 	CanCreateEvalNamespace(userId string) *errors.ServiceError
 	GetEmptyDeletingNamespaces(clusterId string) (dbapi.ConnectorNamespaceList, *errors.ServiceError)
 }
@@ -137,7 +141,7 @@ func (k *connectorNamespaceService) Create(ctx context.Context, request *dbapi.C
 		return services.HandleGetError("Connector namespace", "id", request.ID, err)
 	}
 
-	return nil
+	return k.setResourcesUsed(dbapi.ConnectorNamespaceList{request})
 }
 
 func (k *connectorNamespaceService) validateAnnotations(request *dbapi.ConnectorNamespace) *errors.ServiceError {
@@ -192,6 +196,9 @@ func (k *connectorNamespaceService) Get(ctx context.Context, namespaceID string)
 	if err := k.setConnectorsDeployed(dbapi.ConnectorNamespaceList{result}); err != nil {
 		return result, err
 	}
+	if err := k.setResourcesUsed(dbapi.ConnectorNamespaceList{result}); err != nil {
+		return result, err
+	}
 
 	return result, nil
 }
@@ -299,6 +306,9 @@ func (k *connectorNamespaceService) List(ctx context.Context, clusterIDs []strin
 	if err := k.setConnectorsDeployed(resourceList); err != nil {
 		return resourceList, &pagingMeta, err
 	}
+	if err := k.setResourcesUsed(resourceList); err != nil {
+		return resourceList, &pagingMeta, err
+	}
 	return resourceList, &pagingMeta, nil
 }
 
@@ -685,30 +695,6 @@ func (k *connectorNamespaceService) GetNamespaceTenant(namespaceId string) (*dba
 	return &namespace, nil
 }
 
-func (k *connectorNamespaceService) CheckConnectorQuota(namespaceId string) *errors.ServiceError {
-	dbConn := k.connectionFactory.New()
-	var profileName string
-	var quota config.NamespaceQuota
-	if err := dbConn.Model(&dbapi.ConnectorNamespaceAnnotation{}).
-		Where("namespace_id = ? AND key = ?", namespaceId, profiles.AnnotationProfileKey).
-		Select("value").First(&profileName).Error; err != nil {
-		return errors.FailedToCheckQuota("error reading Connector namespace annotation with namespace id %s: %s", namespaceId, err)
-	}
-	quota, _ = k.quotaConfig.GetNamespaceQuota(profileName)
-	if quota.Connectors > 0 {
-		// get number of connectors using this namespace
-		var count int64
-		if err := dbConn.Model(&dbapi.Connector{}).Where("namespace_id = ?", namespaceId).
-			Count(&count).Error; err != nil {
-			return services.HandleGetError("Connector", "namespace_id", namespaceId, err)
-		}
-		if count >= int64(quota.Connectors) {
-			return errors.InsufficientQuotaError("the maximum number of allowed connectors has been reached")
-		}
-	}
-	return nil
-}
-
 func (k *connectorNamespaceService) CanCreateEvalNamespace(userId string) *errors.ServiceError {
 	dbConn := k.connectionFactory.New()
 	var count int64
@@ -0,0 +1,383 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/phase"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/secrets"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/spyzhov/ajson"
+	"gorm.io/gorm"
+)
+
+//go:generate moq -out connector_revisions_moq.go . ConnectorRevisionsService
+type ConnectorRevisionsService interface {
+	// Record records the configuration of a connector as a new revision unless it did not change since the latest
+	// revision. It must be called with the transaction that created or updated the connector, the revisions over the
+	// configured limit are deleted along with the secrets only they reference once the request transaction commits.
+	Record(ctx context.Context, dbConn *gorm.DB, connector *dbapi.Connector) *errors.ServiceError
+	List(ctx context.Context, connectorId string, listArgs *services.ListArguments) (dbapi.ConnectorRevisionList, *api.PagingMeta, *errors.ServiceError)
+	Get(ctx context.Context, connectorId string, revision int64) (*dbapi.ConnectorRevision, *errors.ServiceError)
+	// Rollback restores the spec, channel and shard metadata of a connector from one of its revisions and redeploys it.
+	Rollback(ctx context.Context, connectorId string, revision int64) (*dbapi.Connector, *errors.ServiceError)
+	// SecretRefs returns the vault secret references held by the revisions of a connector.
+	SecretRefs(connectorId string) ([]string, *errors.ServiceError)
+	// Delete deletes the revisions of a connector and returns the vault secret references they held. It must be called
+	// with the transaction that deleted the connector.
+	Delete(dbConn *gorm.DB, connectorId string) ([]string, *errors.ServiceError)
+}
+
+var _ ConnectorRevisionsService = &connectorRevisionsService{}
+
+type connectorRevisionsService struct {
+	connectionFactory     *db.ConnectionFactory
+	bus                   signalbus.SignalBus
+	vaultService          vault.VaultService
+	connectorTypesService ConnectorTypesService
+	connectorsConfig      *config.ConnectorsConfig
+}
+
+func NewConnectorRevisionsService(connectionFactory *db.ConnectionFactory, bus signalbus.SignalBus, vaultService vault.VaultService,
+	connectorTypesService ConnectorTypesService, connectorsConfig *config.ConnectorsConfig) *connectorRevisionsService {
+	return &connectorRevisionsService{
+		connectionFactory:     connectionFactory,
+		bus:                   bus,
+		vaultService:          vaultService,
+		connectorTypesService: connectorTypesService,
+		connectorsConfig:      connectorsConfig,
+	}
+}
+
+func (k *connectorRevisionsService) Record(ctx context.Context, dbConn *gorm.DB, connector *dbapi.Connector) *errors.ServiceError {
+
+	// the shard metadata of the deployment, or the one the connector will be deployed with
+	var shardMetadataID int64
+	if err := dbConn.Model(&dbapi.ConnectorDeployment{}).
+		Where("connector_id = ?", connector.ID).
+		Select("connector_shard_metadata_id").
+		Scan(&shardMetadataID).Error; err != nil {
+		return services.HandleGetError("Connector deployment", "connector_id", connector.ID, err)
+	}
+	if shardMetadataID == 0 {
+		if err := dbConn.Model(&dbapi.ConnectorShardMetadata{}).
+			Where("connector_type_id = ? AND channel = ?", connector.ConnectorTypeId, connector.Channel).
+			Order("revision desc").Limit(1).
+			Select("id").
+			Scan(&shardMetadataID).Error; err != nil {
+			return errors.GeneralError("unable to get connector type shard metadata: %s", err)
+		}
+	}
+
+	var latest dbapi.ConnectorRevision
+	if err := dbConn.Where("connector_id = ?", connector.ID).
+		Order("revision desc").Limit(1).
+		Find(&latest).Error; err != nil {
+		return services.HandleGetError("Connector revision", "connector_id", connector.ID, err)
+	}
+	if latest.ID != "" && latest.ConnectorTypeId == connector.ConnectorTypeId && latest.Channel == connector.Channel &&
+		latest.ConnectorShardMetadataID == shardMetadataID && jsonEqual(latest.ConnectorSpec, connector.ConnectorSpec) {
+		return nil
+	}
+
+	var createdBy string
+	if claims, err := auth.GetClaimsFromContext(ctx); err == nil {
+		createdBy, _ = claims.GetUsername()
+	}
+
+	if err := dbConn.Create(&dbapi.ConnectorRevision{
+		Model: db.Model{
+			ID: api.NewID(),
+		},
+		ConnectorID:              connector.ID,
+		Revision:                 connector.Version,
+		ConnectorTypeId:          connector.ConnectorTypeId,
+		ConnectorSpec:            connector.ConnectorSpec,
+		Channel:                  connector.Channel,
+		ConnectorShardMetadataID: shardMetadataID,
+		CreatedBy:                createdBy,
+	}).Error; err != nil {
+		return errors.GeneralError("failed to create connector revision: %v", err)
+	}
+
+	return k.prune(ctx, dbConn, connector)
+}
+
+// prune deletes the revisions of a connector over the configured limit, the secrets that are only referenced by the
+// deleted revisions are deleted from the vault when the request transaction commits
+func (k *connectorRevisionsService) prune(ctx context.Context, dbConn *gorm.DB, connector *dbapi.Connector) *errors.ServiceError {
+	limit := k.connectorsConfig.ConnectorRevisionsLimit
+	if limit <= 0 {
+		return nil
+	}
+
+	var revisions dbapi.ConnectorRevisionList
+	if err := dbConn.Where("connector_id = ?", connector.ID).
+		Order("revision desc").
+		Find(&revisions).Error; err != nil {
+		return services.HandleGetError("Connector revision", "connector_id", connector.ID, err)
+	}
+	if len(revisions) <= limit {
+		return nil
+	}
+
+	retained, serr := k.secretRefs(revisions[:limit])
+	if serr != nil {
+		return serr
+	}
+	pruned, serr := k.secretRefs(revisions[limit:])
+	if serr != nil {
+		return serr
+	}
+
+	ids := make([]string, 0, len(revisions)-limit)
+	for _, r := range revisions[limit:] {
+		ids = append(ids, r.ID)
+	}
+	if err := dbConn.Unscoped().Where("id IN ?", ids).Delete(&dbapi.ConnectorRevision{}).Error; err != nil {
+		return errors.GeneralError("unable to delete connector revisions: %s", err)
+	}
+
+	stale := make([]string, 0, len(pruned))
+	for _, ref := range pruned {
+		if !arrays.Contains(retained, ref) {
+			stale = append(stale, ref)
+		}
+	}
+	if len(stale) > 0 {
+		_ = db.AddPostCommitAction(ctx, func() {
+			for _, s := range stale {
+				if err := k.vaultService.DeleteSecretString(s); err != nil {
+					logger.Logger.Errorf("failed to delete vault secret key '%s': %v", s, err)
+				}
+			}
+		})
+	}
+
+	return nil
+}
+
+func (k *connectorRevisionsService) List(ctx context.Context, connectorId string, listArgs *services.ListArguments) (dbapi.ConnectorRevisionList, *api.PagingMeta, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New().Model(&dbapi.ConnectorRevision{}).Where("connector_id = ?", connectorId)
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	var total int64
+	if err := dbConn.Count(&total).Error; err != nil {
+		return nil, pagingMeta, errors.GeneralError("unable to count connector revisions: %s", err)
+	}
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+
+	var resources dbapi.ConnectorRevisionList
+	if err := dbConn.Offset((pagingMeta.Page - 1) * pagingMeta.Size).Limit(pagingMeta.Size).
+		Order("revision desc").
+		Find(&resources).Error; err != nil {
+		return nil, pagingMeta, errors.GeneralError("unable to list connector revisions: %s", err)
+	}
+
+	return resources, pagingMeta, nil
+}
+
+func (k *connectorRevisionsService) Get(ctx context.Context, connectorId string, revision int64) (*dbapi.ConnectorRevision, *errors.ServiceError) {
+	var resource dbapi.ConnectorRevision
+	if err := k.connectionFactory.New().Where("connector_id = ? AND revision = ?", connectorId, revision).First(&resource).Error; err != nil {
+		return nil, services.HandleGetError("Connector revision", "revision", revision, err)
+	}
+	return &resource, nil
+}
+
+func (k *connectorRevisionsService) Rollback(ctx context.Context, connectorId string, revision int64) (*dbapi.Connector, *errors.ServiceError) {
+	resource, serr := k.Get(ctx, connectorId, revision)
+	if serr != nil {
+		return nil, serr
+	}
+	var connector dbapi.Connector
+	dbConn := k.connectionFactory.New()
+	if err := dbConn.Where("id = ?", connectorId).First(&connector).Error; err != nil {
+		return nil, services.HandleGetError("Connector", "id", connectorId, err)
+	}
+	if connector.DesiredState == dbapi.ConnectorDeleted {
+		return nil, errors.BadRequest("connector %s is being deleted", connectorId)
+	}
+	// like an update, a rollback can't change the type of the connector
+	if resource.ConnectorTypeId != connector.ConnectorTypeId {
+		return nil, errors.BadRequest("connector type %s of revision %d does not match connector type %s", resource.ConnectorTypeId, revision, connector.ConnectorTypeId)
+	}
+
+	// the connector type and channel may have been removed from the catalog since the revision was recorded
+	ct, serr := k.connectorTypesService.Get(resource.ConnectorTypeId)
+	if serr != nil {
+		return nil, errors.BadRequest("connector type %s of revision %d is no longer available", resource.ConnectorTypeId, revision)
+	}
+	if !arrays.Contains(ct.ChannelNames(), resource.Channel) {
+		return nil, errors.BadRequest("channel %s of revision %d is not valid. Must be one of: %s", resource.Channel, revision, strings.Join(ct.ChannelNames(), ", "))
+	}
+	refs, err := connectorSpecSecretRefs(ct, resource.ConnectorSpec)
+	if err != nil {
+		return nil, errors.GeneralError("could not get the secrets of revision %d: %v", revision, err)
+	}
+	// the secrets are looked up before the transaction, the version check below fails if the connector changed meanwhile
+	for _, ref := range refs {
+		if _, err := k.vaultService.GetSecretString(ref); err != nil {
+			return nil, errors.BadRequest("the secrets of revision %d are no longer available, update the connector instead", revision)
+		}
+	}
+
+	if err := k.connectionFactory.New().Transaction(func(dbConn *gorm.DB) error {
+		if err := dbConn.Where("id = ?", connectorId).First(&connector.Status).Error; err != nil {
+			return services.HandleGetError("Connector status", "id", connectorId, err)
+		}
+
+		// the connectors version trigger bumps the version, which redeploys the connector
+		update := dbConn.Model(&dbapi.Connector{}).
+			Where("id = ? AND version = ?", connector.ID, connector.Version).
+			Updates(map[string]interface{}{
+				"connector_spec": resource.ConnectorSpec,
+				"channel":        resource.Channel,
+			})
+		if err := update.Error; err != nil {
+			return services.HandleUpdateError("Connector", err)
+		}
+		if update.RowsAffected == 0 {
+			return errors.Conflict("resource version changed")
+		}
+
+		if resource.ConnectorShardMetadataID != 0 {
+			if err := dbConn.Model(&dbapi.ConnectorDeployment{}).
+				Where("connector_id = ?", connector.ID).
+				Update("connector_shard_metadata_id", resource.ConnectorShardMetadataID).Error; err != nil {
+				return services.HandleUpdateError("Connector deployment", err)
+			}
+		}
+
+		// like a user update, the rollback resets the restart attempts of the connector
+		previous := connector.Status.Phase
+		if previous != dbapi.ConnectorStatusPhaseAssigning {
+			connector.Status.Phase = phase.ConnectorStartingPhase[phase.UpdateConnector]
+		}
+		if err := dbConn.Model(&connector.Status).
+			Updates(map[string]interface{}{"phase": connector.Status.Phase, "conditions": nil}).Error; err != nil {
+			return services.HandleUpdateError("Connector status", err)
+		}
+		if err := recordConnectorStatusChange(dbConn, connector.ID, previous, connector.Status.Phase); err != nil {
+			return err
+		}
+
+		// read it back.... to get the updated version...
+		if err := dbConn.Where("id = ?", connectorId).First(&connector).Error; err != nil {
+			return services.HandleGetError("Connector", "id", connectorId, err)
+		}
+		if serr := k.Record(ctx, dbConn, &connector); serr != nil {
+			return serr
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.ToServiceError(err)
+	}
+
+	_ = db.AddPostCommitAction(ctx, func() {
+		// Wake up the reconcile loop...
+		k.bus.Notify("reconcile:connector")
+	})
+
+	return &connector, nil
+}
+
+func (k *connectorRevisionsService) SecretRefs(connectorId string) ([]string, *errors.ServiceError) {
+	var revisions dbapi.ConnectorRevisionList
+	if err := k.connectionFactory.New().Where("connector_id = ?", connectorId).Find(&revisions).Error; err != nil {
+		return nil, services.HandleGetError("Connector revision", "connector_id", connectorId, err)
+	}
+	return k.secretRefs(revisions)
+}
+
+func (k *connectorRevisionsService) Delete(dbConn *gorm.DB, connectorId string) ([]string, *errors.ServiceError) {
+	var revisions dbapi.ConnectorRevisionList
+	if err := dbConn.Where("connector_id = ?", connectorId).Find(&revisions).Error; err != nil {
+		return nil, services.HandleGetError("Connector revision", "connector_id", connectorId, err)
+	}
+	refs, serr := k.secretRefs(revisions)
+	if serr != nil {
+		return nil, serr
+	}
+	if err := dbConn.Unscoped().Where("connector_id = ?", connectorId).Delete(&dbapi.ConnectorRevision{}).Error; err != nil {
+		return nil, services.HandleDeleteError("Connector revision", "connector_id", connectorId, err)
+	}
+	return refs, nil
+}
+
+func (k *connectorRevisionsService) secretRefs(revisions dbapi.ConnectorRevisionList) ([]string, *errors.ServiceError) {
+	var result []string
+	types := make(map[string]*dbapi.ConnectorType)
+	for _, r := range revisions {
+		ct, ok := types[r.ConnectorTypeId]
+		if !ok {
+			var serr *errors.ServiceError
+			if ct, serr = k.connectorTypesService.Get(r.ConnectorTypeId); serr != nil {
+				// the connector type was removed, its secrets can't be found
+				logger.Logger.Warningf("unable to get the secrets of revision %d of connector %s: %v", r.Revision, r.ConnectorID, serr)
+			}
+			types[r.ConnectorTypeId] = ct
+		}
+		if ct == nil {
+			continue
+		}
+		refs, err := connectorSpecSecretRefs(ct, r.ConnectorSpec)
+		if err != nil {
+			return nil, errors.GeneralError("could not get the secrets of revision %d of connector %s: %v", r.Revision, r.ConnectorID, err)
+		}
+		for _, ref := range refs {
+			if !arrays.Contains(result, ref) {
+				result = append(result, ref)
+			}
+		}
+	}
+	return result, nil
+}
+
+// connectorSpecSecretRefs returns the vault secret references of a connector spec
+func connectorSpecSecretRefs(ct *dbapi.ConnectorType, spec api.JSON) (result []string, err error) {
+	if len(spec) == 0 {
+		return nil, nil
+	}
+	_, err = secrets.ModifySecrets(ct.JsonSchema, spec, func(node *ajson.Node) error {
+		if node.Type() != ajson.Object {
+			return nil
+		}
+		ref, err := node.GetKey("ref")
+		if err != nil {
+			return nil
+		}
+		key, err := ref.GetString()
+		if err != nil {
+			return nil
+		}
+		result = append(result, key)
+		return nil
+	})
+	return result, err
+}
+
+func jsonEqual(a, b api.JSON) bool {
+	var av, bv interface{}
+	if a.Unmarshal(&av) != nil || b.Unmarshal(&bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
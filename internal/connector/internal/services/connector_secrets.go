@@ -0,0 +1,372 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/secrets"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/spyzhov/ajson"
+	"gorm.io/gorm"
+)
+
+// ConnectorSecretsOwningResourcePrefix is the prefix of the owning resource of the vault secrets of a connector,
+// it is followed by the connector id
+const ConnectorSecretsOwningResourcePrefix = "/v1/connector/"
+
+type ConnectorSecretsService interface {
+	// RotateConnectorSecrets re-keys the vault secrets of a connector and bumps its version so that the agent picks up
+	// the new references. The connector service account is replaced when serviceAccount is not nil.
+	RotateConnectorSecrets(ctx context.Context, id string, serviceAccount *dbapi.ServiceAccount) (*dbapi.Connector, *errors.ServiceError)
+	// RotateAllSecrets re-keys the vault secrets of all the connectors. When source is not nil the secrets are read from
+	// it instead of the configured vault, which migrates them to the configured vault, and they are only deleted from
+	// the source when deleteSource is true.
+	RotateAllSecrets(ctx context.Context, source vault.VaultService, deleteSource bool) (*dbapi.ConnectorSecretsReport, *errors.ServiceError)
+	// RequestSecretsRotation requests the rotation of the secrets of all the connectors, which is run in the background
+	// by RunSecretsRotation. It returns the rotation already requested instead if it has not completed yet.
+	RequestSecretsRotation(ctx context.Context) (*dbapi.ConnectorSecretsRotation, *errors.ServiceError)
+	// GetSecretsRotation returns a requested rotation of the secrets of all the connectors
+	GetSecretsRotation(ctx context.Context, id string) (*dbapi.ConnectorSecretsRotation, *errors.ServiceError)
+	// RunSecretsRotation runs the oldest requested rotation that has not completed, if any, and returns it once completed.
+	RunSecretsRotation(ctx context.Context) (*dbapi.ConnectorSecretsRotation, *errors.ServiceError)
+	// DeleteOrphanedSecrets deletes the vault secrets owned by deleted connectors, they are only reported when dryRun
+	// is true.
+	DeleteOrphanedSecrets(ctx context.Context, dryRun bool) (*dbapi.ConnectorSecretsReport, *errors.ServiceError)
+}
+
+var _ ConnectorSecretsService = &connectorSecretsService{}
+
+type connectorSecretsService struct {
+	connectionFactory         *db.ConnectionFactory
+	bus                       signalbus.SignalBus
+	vaultService              vault.VaultService
+	connectorTypesService     ConnectorTypesService
+	connectorRevisionsService ConnectorRevisionsService
+}
+
+func NewConnectorSecretsService(connectionFactory *db.ConnectionFactory, bus signalbus.SignalBus,
+	vaultService vault.VaultService, connectorTypesService ConnectorTypesService,
+	connectorRevisionsService ConnectorRevisionsService) *connectorSecretsService {
+	return &connectorSecretsService{
+		connectionFactory:         connectionFactory,
+		bus:                       bus,
+		vaultService:              vaultService,
+		connectorTypesService:     connectorTypesService,
+		connectorRevisionsService: connectorRevisionsService,
+	}
+}
+
+func (k *connectorSecretsService) RotateConnectorSecrets(ctx context.Context, id string, serviceAccount *dbapi.ServiceAccount) (*dbapi.Connector, *errors.ServiceError) {
+	connector, _, err := k.rotate(id, k.vaultService, true, serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wake up the reconcile loop...
+	k.bus.Notify("reconcile:connector")
+
+	return connector, nil
+}
+
+func (k *connectorSecretsService) RotateAllSecrets(ctx context.Context, source vault.VaultService, deleteSource bool) (*dbapi.ConnectorSecretsReport, *errors.ServiceError) {
+	if source == nil {
+		source = k.vaultService
+		deleteSource = true
+	}
+
+	var ids []string
+	if err := k.connectionFactory.New().Model(&dbapi.Connector{}).Order("version").Pluck("id", &ids).Error; err != nil {
+		return nil, errors.GeneralError("unable to list connectors: %s", err)
+	}
+
+	report := &dbapi.ConnectorSecretsReport{}
+	for _, id := range ids {
+		_, count, err := k.rotate(id, source, deleteSource, nil)
+		if err != nil {
+			if err.Is404() {
+				continue // deleted in the meantime
+			}
+			report.Errors = append(report.Errors, err)
+			continue
+		}
+		report.Connectors++
+		report.Secrets += count
+	}
+
+	if report.Connectors > 0 {
+		// Wake up the reconcile loop...
+		k.bus.Notify("reconcile:connector")
+	}
+
+	return report, nil
+}
+
+func (k *connectorSecretsService) RequestSecretsRotation(ctx context.Context) (*dbapi.ConnectorSecretsRotation, *errors.ServiceError) {
+	var rotation dbapi.ConnectorSecretsRotation
+	if err := k.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		// serializes the requests so that only one rotation is pending or running at a time
+		if err := tx.Exec("LOCK TABLE connector_secrets_rotations IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			return err
+		}
+		// use Limit(1) and Find() to avoid ErrRecordNotFound
+		if err := tx.Where("status <> ?", dbapi.ConnectorSecretsRotationCompleted).Order("created_at").Limit(1).Find(&rotation).Error; err != nil {
+			return err
+		}
+		if rotation.ID != "" {
+			return nil
+		}
+		rotation = dbapi.ConnectorSecretsRotation{
+			Model:  db.Model{ID: api.NewID()},
+			Status: dbapi.ConnectorSecretsRotationPending,
+		}
+		return tx.Create(&rotation).Error
+	}); err != nil {
+		return nil, errors.GeneralError("unable to request the rotation of the connector secrets: %s", err)
+	}
+
+	// Wake up the secrets rotation worker...
+	k.bus.Notify("reconcile:connector_secrets_rotation")
+
+	return &rotation, nil
+}
+
+func (k *connectorSecretsService) GetSecretsRotation(ctx context.Context, id string) (*dbapi.ConnectorSecretsRotation, *errors.ServiceError) {
+	var rotation dbapi.ConnectorSecretsRotation
+	if err := k.connectionFactory.New().Where("id = ?", id).First(&rotation).Error; err != nil {
+		return nil, services.HandleGetError("Connector secrets rotation", "id", id, err)
+	}
+	return &rotation, nil
+}
+
+func (k *connectorSecretsService) RunSecretsRotation(ctx context.Context) (*dbapi.ConnectorSecretsRotation, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+
+	// a running rotation was interrupted, e.g. by a restart, and is run again since re-keying the secrets is idempotent
+	var rotation dbapi.ConnectorSecretsRotation
+	if err := dbConn.Where("status <> ?", dbapi.ConnectorSecretsRotationCompleted).Order("created_at").Limit(1).Find(&rotation).Error; err != nil {
+		return nil, errors.GeneralError("unable to find the requested rotation of the connector secrets: %s", err)
+	}
+	if rotation.ID == "" {
+		return nil, nil
+	}
+	if err := dbConn.Model(&rotation).Update("status", dbapi.ConnectorSecretsRotationRunning).Error; err != nil {
+		return nil, services.HandleUpdateError("Connector secrets rotation", err)
+	}
+
+	report, serr := k.RotateAllSecrets(ctx, nil, true)
+	if serr != nil {
+		return nil, serr
+	}
+
+	var reportErrors []string
+	for _, err := range report.Errors {
+		reportErrors = append(reportErrors, err.Error())
+	}
+	errorsJson, err := json.Marshal(reportErrors)
+	if err != nil {
+		return nil, errors.GeneralError("unable to marshal the errors of the rotation of the connector secrets: %s", err)
+	}
+	now := time.Now()
+	rotation.Status = dbapi.ConnectorSecretsRotationCompleted
+	rotation.Connectors = report.Connectors
+	rotation.Secrets = report.Secrets
+	rotation.Errors = errorsJson
+	rotation.CompletedAt = &now
+	if err := dbConn.Select("status", "connectors", "secrets", "errors", "completed_at").Updates(&rotation).Error; err != nil {
+		return nil, services.HandleUpdateError("Connector secrets rotation", err)
+	}
+
+	return &rotation, nil
+}
+
+// rotate copies the secrets of a connector from the source vault to the configured vault under new keys and updates
+// the connector with the new references, the update bumps the connector version. The copied secrets are deleted from
+// the source once the connector is updated when deleteSource is true, unless they are still referenced by a connector
+// revision, or deleted from the configured vault when the connector could not be updated. It returns the updated connector and the number of secrets written to the vault.
+func (k *connectorSecretsService) rotate(id string, source vault.VaultService, deleteSource bool, serviceAccount *dbapi.ServiceAccount) (*dbapi.Connector, int, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+
+	var connector dbapi.Connector
+	if err := dbConn.Where("id = ?", id).First(&connector).Error; err != nil {
+		return nil, 0, services.HandleGetError("Connector", "id", id, err)
+	}
+	ct, serr := k.connectorTypesService.Get(connector.ConnectorTypeId)
+	if serr != nil {
+		return nil, 0, errors.GeneralError("invalid connector type id %s of connector %s: %s", connector.ConnectorTypeId, id, serr)
+	}
+
+	owningResource := ConnectorSecretsOwningResourcePrefix + connector.ID
+	var oldKeys, newKeys []string
+	setSecret := func(value string) (string, error) {
+		keyId := api.NewID()
+		if err := k.vaultService.SetSecretString(keyId, value, owningResource); err != nil {
+			return "", err
+		}
+		newKeys = append(newKeys, keyId)
+		return keyId, nil
+	}
+	copySecret := func(ref string) (string, error) {
+		value, err := source.GetSecretString(ref)
+		if err != nil {
+			return "", err
+		}
+		keyId, err := setSecret(value)
+		if err != nil {
+			return "", err
+		}
+		oldKeys = append(oldKeys, ref)
+		return keyId, nil
+	}
+
+	if err := func() error {
+		if serviceAccount != nil {
+			keyId, err := setSecret(serviceAccount.ClientSecret)
+			if err != nil {
+				return err
+			}
+			if connector.ServiceAccount.ClientSecretRef != "" {
+				oldKeys = append(oldKeys, connector.ServiceAccount.ClientSecretRef)
+			}
+			connector.ServiceAccount.ClientId = serviceAccount.ClientId
+			connector.ServiceAccount.ClientSecretRef = keyId
+		} else if connector.ServiceAccount.ClientSecretRef != "" {
+			keyId, err := copySecret(connector.ServiceAccount.ClientSecretRef)
+			if err != nil {
+				return err
+			}
+			connector.ServiceAccount.ClientSecretRef = keyId
+		}
+
+		if len(connector.ConnectorSpec) != 0 {
+			updated, err := secrets.ModifySecrets(ct.JsonSchema, connector.ConnectorSpec, func(node *ajson.Node) error {
+				if node.Type() != ajson.Object {
+					return nil
+				}
+				ref, err := node.GetKey("ref")
+				if err != nil {
+					return nil
+				}
+				r, err := ref.GetString()
+				if err != nil {
+					return nil
+				}
+				keyId, err := copySecret(r)
+				if err != nil {
+					return err
+				}
+				return node.SetObject(map[string]*ajson.Node{
+					"kind": ajson.StringNode("", k.vaultService.Kind()),
+					"ref":  ajson.StringNode("", keyId),
+				})
+			})
+			if err != nil {
+				return err
+			}
+			connector.ConnectorSpec = updated
+		}
+		return nil
+	}(); err != nil {
+		k.deleteSecrets(k.vaultService, newKeys)
+		return nil, 0, errors.GeneralError("could not rotate the secrets of connector %s: %v", id, err)
+	}
+
+	// the connectors version trigger bumps the version, which is propagated to the connector deployment
+	update := dbConn.Model(&dbapi.Connector{}).
+		Where("id = ? AND version = ?", connector.ID, connector.Version).
+		Updates(map[string]interface{}{
+			"service_account_client_id":     connector.ServiceAccount.ClientId,
+			"service_account_client_secret": connector.ServiceAccount.ClientSecretRef,
+			"connector_spec":                connector.ConnectorSpec,
+		})
+	if err := update.Error; err != nil {
+		k.deleteSecrets(k.vaultService, newKeys)
+		return nil, 0, services.HandleUpdateError("Connector", err)
+	}
+	if update.RowsAffected == 0 {
+		k.deleteSecrets(k.vaultService, newKeys)
+		return nil, 0, errors.Conflict("connector %s changed while rotating its secrets", id)
+	}
+
+	if deleteSource {
+		// keep the secrets the connector revisions still reference, so that they can be rolled back, in the source
+		// vault too since the revisions are not migrated
+		revisionKeys, err := k.connectorRevisionsService.SecretRefs(connector.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		oldKeys = arrays.Filter(oldKeys, func(key string) bool {
+			return !arrays.Contains(revisionKeys, key)
+		})
+		k.deleteSecrets(source, oldKeys)
+	}
+
+	// read it back.... to get the updated version...
+	if err := dbConn.Where("id = ?", id).First(&connector).Error; err != nil {
+		return nil, 0, services.HandleGetError("Connector", "id", id, err)
+	}
+
+	return &connector, len(newKeys), nil
+}
+
+func (k *connectorSecretsService) deleteSecrets(vaultService vault.VaultService, keys []string) {
+	for _, key := range keys {
+		if err := vaultService.DeleteSecretString(key); err != nil {
+			logger.Logger.Errorf("failed to delete vault secret key '%s': %v", key, err)
+		}
+	}
+}
+
+// DeleteOrphanedSecrets only deletes the secrets of connectors that have been deleted. Secrets owned by connectors
+// that do not exist yet, or not referenced by their connector, may belong to a connector create or update in progress.
+func (k *connectorSecretsService) DeleteOrphanedSecrets(ctx context.Context, dryRun bool) (*dbapi.ConnectorSecretsReport, *errors.ServiceError) {
+	owners := make(map[string][]string)
+	if err := k.vaultService.ForEachSecret(func(name string, owningResource string) bool {
+		if strings.HasPrefix(owningResource, ConnectorSecretsOwningResourcePrefix) {
+			id := strings.TrimPrefix(owningResource, ConnectorSecretsOwningResourcePrefix)
+			owners[id] = append(owners[id], name)
+		}
+		return true
+	}); err != nil {
+		return nil, errors.GeneralError("unable to list vault secrets: %s", err)
+	}
+
+	report := &dbapi.ConnectorSecretsReport{}
+	if len(owners) == 0 {
+		return report, nil
+	}
+
+	ids := make([]string, 0, len(owners))
+	for id := range owners {
+		ids = append(ids, id)
+	}
+	var deleted []string
+	if err := k.connectionFactory.New().Unscoped().Model(&dbapi.Connector{}).
+		Where("id IN ? AND deleted_at IS NOT NULL", ids).
+		Order("id").Pluck("id", &deleted).Error; err != nil {
+		return nil, errors.GeneralError("unable to list deleted connectors: %s", err)
+	}
+
+	for _, id := range deleted {
+		for _, name := range owners[id] {
+			report.OrphanedSecrets = append(report.OrphanedSecrets, name)
+			if dryRun {
+				continue
+			}
+			if err := k.vaultService.DeleteSecretString(name); err != nil {
+				report.Errors = append(report.Errors, errors.GeneralError("failed to delete vault secret key '%s': %v", name, err))
+			}
+		}
+	}
+
+	return report, nil
+}
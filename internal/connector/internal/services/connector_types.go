@@ -27,6 +27,7 @@ import (
 	"github.com/golang/glog"
 )
 
+//go:generate moq -out connector_types_moq.go . ConnectorTypesService
 type ConnectorTypesService interface {
 	Get(id string) (*dbapi.ConnectorType, *errors.ServiceError)
 	List(listArgs *services.ListArguments) (dbapi.ConnectorTypeList, *api.PagingMeta, *errors.ServiceError)
@@ -93,6 +94,9 @@ func (cts *connectorTypesService) Create(resource *dbapi.ConnectorType) *errors.
 			if err := dbConn.Where("connector_type_id = ?", tid).Delete(&dbapi.ConnectorTypeCapability{}).Error; err != nil {
 				return errors.GeneralError("failed to remove connector type capabilities %q: %v", tid, err)
 			}
+			if err := dbConn.Where("connector_type_id = ?", tid).Delete(&dbapi.ConnectorTypeDeprecation{}).Error; err != nil {
+				return errors.GeneralError("failed to remove connector type deprecations %q: %v", tid, err)
+			}
 
 			// update the existing connector type
 			if err := dbConn.Session(&gorm.Session{FullSaveAssociations: true}).Updates(resource).Error; err != nil {
@@ -291,7 +295,8 @@ func (cts *connectorTypesService) ListLabels(listArgs *services.ListArguments) (
 
 func (cts *connectorTypesService) ForEachConnectorCatalogEntry(f func(id string, channel string, ccc *config.ConnectorChannelConfig) *errors.ServiceError) *errors.ServiceError {
 
-	for _, entry := range cts.connectorsConfig.CatalogEntries {
+	checksums := cts.connectorsConfig.GetCatalogChecksums()
+	for _, entry := range cts.connectorsConfig.GetCatalogEntries() {
 		// create/update connector type
 		connectorType, err := presenters.ConvertConnectorType(entry.ConnectorType)
 		if err != nil {
@@ -313,7 +318,7 @@ func (cts *connectorTypesService) ForEachConnectorCatalogEntry(f func(id string,
 		// update type checksum for latest catalog shard metadata
 		dbConn := cts.connectionFactory.New()
 		if err = dbConn.Model(connectorType).Where("id = ?", connectorType.ID).
-			UpdateColumn("checksum", cts.connectorsConfig.CatalogChecksums[connectorType.ID]).Error; err != nil {
+			UpdateColumn("checksum", checksums[connectorType.ID]).Error; err != nil {
 			return errors.GeneralError("failed to update connector type %s checksum: %v", entry.ConnectorType.Id, err.Error())
 		}
 	}
@@ -421,7 +426,7 @@ func (cts *connectorTypesService) GetLatestConnectorShardMetadata(typeId, channe
 
 func (cts *connectorTypesService) CatalogEntriesReconciled() (bool, *errors.ServiceError) {
 	var typeIds []string
-	catalogChecksums := cts.connectorsConfig.CatalogChecksums
+	catalogChecksums := cts.connectorsConfig.GetCatalogChecksums()
 	for id := range catalogChecksums {
 		typeIds = append(typeIds, id)
 	}
@@ -445,8 +450,9 @@ func (cts *connectorTypesService) CatalogEntriesReconciled() (bool, *errors.Serv
 }
 
 func (cts *connectorTypesService) DeleteUnusedAndNotInCatalog() *errors.ServiceError {
-	notToBeDeletedIDs := make([]string, len(cts.connectorsConfig.CatalogEntries))
-	for _, entry := range cts.connectorsConfig.CatalogEntries {
+	catalogEntries := cts.connectorsConfig.GetCatalogEntries()
+	notToBeDeletedIDs := make([]string, len(catalogEntries))
+	for _, entry := range catalogEntries {
 		notToBeDeletedIDs = append(notToBeDeletedIDs, entry.ConnectorType.Id)
 	}
 	glog.V(5).Infof("Connector Type IDs in catalog not to be deleted: %v", notToBeDeletedIDs)
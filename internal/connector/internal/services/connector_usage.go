@@ -0,0 +1,251 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+)
+
+const (
+	ConnectorUsagePeriodHour  = "hour"
+	ConnectorUsagePeriodDay   = "day"
+	ConnectorUsagePeriodMonth = "month"
+
+	connectorUsageReportHours     = 24
+	connectorUsageReporterTimeout = 30 * time.Second
+)
+
+var ValidConnectorUsagePeriods = []string{
+	ConnectorUsagePeriodHour,
+	ConnectorUsagePeriodDay,
+	ConnectorUsagePeriodMonth,
+}
+
+// ConnectorUsageQuery selects the connector usage to list, an empty OrganisationId selects all organisations
+type ConnectorUsageQuery struct {
+	OrganisationId string
+	From           time.Time
+	To             time.Time
+	Period         string
+}
+
+//go:generate moq -out connector_usage_moq.go . ConnectorUsageService
+type ConnectorUsageService interface {
+	// SampleUsage adds the elapsed time to the usage of the connectors running at the given time, in the hour the sample
+	// is taken. It returns the number of usage rows updated.
+	SampleUsage(ctx context.Context, now time.Time, elapsed time.Duration) (int64, *errors.ServiceError)
+	// List returns the connector usage aggregated by organisation, namespace, connector type and query period.
+	List(ctx context.Context, query ConnectorUsageQuery) (dbapi.ConnectorUsageList, *errors.ServiceError)
+	// ReportUsage reports the usage of the hours completed before the given time that were not reported yet.
+	// It returns the number of usage rows reported.
+	ReportUsage(ctx context.Context, before time.Time) (int64, *errors.ServiceError)
+}
+
+var _ ConnectorUsageService = &connectorUsageService{}
+
+type connectorUsageService struct {
+	connectionFactory *db.ConnectionFactory
+	reporter          ConnectorUsageReporter
+}
+
+func NewConnectorUsageService(connectionFactory *db.ConnectionFactory, reporter ConnectorUsageReporter) *connectorUsageService {
+	return &connectorUsageService{
+		connectionFactory: connectionFactory,
+		reporter:          reporter,
+	}
+}
+
+func (k *connectorUsageService) SampleUsage(_ context.Context, now time.Time, elapsed time.Duration) (int64, *errors.ServiceError) {
+	now = now.UTC()
+	dbConn := k.connectionFactory.New()
+
+	// upsert the running connectors grouped by organisation, namespace and connector type into the hour bucket
+	result := dbConn.Exec(`INSERT INTO connector_usages (organisation_id, namespace_id, connector_type_id, period_start,
+		connector_seconds, samples, reported, created_at, updated_at)
+	SELECT connectors.organisation_id, connector_deployments.namespace_id, connectors.connector_type_id, ?,
+		COUNT(*) * ?, 1, false, ?, ?
+	FROM connector_deployments
+		JOIN connector_deployment_statuses ON connector_deployment_statuses.id = connector_deployments.id
+		JOIN connectors ON connectors.id = connector_deployments.connector_id
+	WHERE connector_deployments.deleted_at IS NULL AND connector_deployment_statuses.deleted_at IS NULL
+		AND connectors.deleted_at IS NULL AND connector_deployment_statuses.phase = ?
+	GROUP BY connectors.organisation_id, connector_deployments.namespace_id, connectors.connector_type_id
+	ON CONFLICT (organisation_id, namespace_id, connector_type_id, period_start) DO UPDATE SET
+		connector_seconds = connector_usages.connector_seconds + EXCLUDED.connector_seconds,
+		samples = connector_usages.samples + 1,
+		updated_at = EXCLUDED.updated_at`,
+		now.Truncate(time.Hour), int64(elapsed.Seconds()), now, now, dbapi.ConnectorStatusPhaseReady)
+	if err := result.Error; err != nil {
+		return 0, services.HandleUpdateError("Connector usage", err)
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (k *connectorUsageService) List(_ context.Context, query ConnectorUsageQuery) (dbapi.ConnectorUsageList, *errors.ServiceError) {
+	period := query.Period
+	if period == "" {
+		period = ConnectorUsagePeriodHour
+	}
+	if !arrays.Contains(ValidConnectorUsagePeriods, period) {
+		return nil, errors.BadRequest("invalid usage period %s, must be one of %v", period, ValidConnectorUsagePeriods)
+	}
+
+	dbConn := k.connectionFactory.New()
+	periodStart := fmt.Sprintf("date_trunc('%s', period_start AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'", period)
+	dbConn = dbConn.Table("connector_usages").
+		Select(fmt.Sprintf("organisation_id, namespace_id, connector_type_id, %s AS period_start, "+
+			"SUM(connector_seconds) AS connector_seconds, SUM(samples) AS samples", periodStart)).
+		Where("period_start >= ? AND period_start < ?", query.From, query.To)
+	if query.OrganisationId != "" {
+		dbConn = dbConn.Where("organisation_id = ?", query.OrganisationId)
+	}
+
+	var usage dbapi.ConnectorUsageList
+	if err := dbConn.Group(fmt.Sprintf("organisation_id, namespace_id, connector_type_id, %s", periodStart)).
+		Order("organisation_id, period_start, namespace_id, connector_type_id").
+		Scan(&usage).Error; err != nil {
+		return nil, errors.GeneralError("failed to list connector usage: %v", err)
+	}
+
+	return usage, nil
+}
+
+func (k *connectorUsageService) ReportUsage(_ context.Context, before time.Time) (int64, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+
+	// only report hours that completed before the given time, since they can't be sampled anymore
+	var hours []time.Time
+	if err := dbConn.Model(&dbapi.ConnectorUsage{}).
+		Where("reported = false AND period_start < ?", before.UTC().Truncate(time.Hour)).
+		Distinct("period_start").Order("period_start").Limit(connectorUsageReportHours).
+		Pluck("period_start", &hours).Error; err != nil {
+		return 0, services.HandleGetError("Connector usage", "reported", false, err)
+	}
+
+	var count int64
+	for _, hour := range hours {
+		var usage dbapi.ConnectorUsageList
+		if err := dbConn.Where("reported = false AND period_start = ?", hour).
+			Order("organisation_id, namespace_id, connector_type_id").
+			Find(&usage).Error; err != nil {
+			return count, services.HandleGetError("Connector usage", "period_start", hour, err)
+		}
+		if len(usage) == 0 {
+			continue
+		}
+
+		// the usage is reported outside of a transaction, the usage of an hour that is reported again because it
+		// could not be marked reported has the same idempotency key, for the usage service to drop it
+		if err := k.reporter.Report(connectorUsageIdempotencyKey(hour), usage); err != nil {
+			return count, errors.GeneralError("failed to report connector usage: %v", err)
+		}
+
+		if err := dbConn.Model(&dbapi.ConnectorUsage{}).
+			Where("reported = false AND period_start = ?", hour).
+			Update("reported", true).Error; err != nil {
+			return count, services.HandleUpdateError("Connector usage", err)
+		}
+		count += int64(len(usage))
+	}
+
+	return count, nil
+}
+
+// connectorUsageIdempotencyKey returns the idempotency key of the report of the usage of an hour
+func connectorUsageIdempotencyKey(hour time.Time) string {
+	return fmt.Sprintf("connector-usage-%s", hour.UTC().Format(time.RFC3339))
+}
+
+// ConnectorUsageReporter reports the hourly connector usage to a usage service such as AMS
+//
+//go:generate moq -out connector_usage_reporter_moq.go . ConnectorUsageReporter
+type ConnectorUsageReporter interface {
+	// Report reports the usage of an hour, the usage service must drop a report with an idempotency key it already got
+	Report(idempotencyKey string, usage dbapi.ConnectorUsageList) error
+}
+
+// NewConnectorUsageReporter returns a reporter that posts the usage to the configured usage service url,
+// or a reporter that drops the usage when no url is configured
+func NewConnectorUsageReporter(connectorsConfig *config.ConnectorsConfig) ConnectorUsageReporter {
+	if connectorsConfig.ConnectorUsageReporterURL == "" {
+		return &noopConnectorUsageReporter{}
+	}
+	return &httpConnectorUsageReporter{
+		url:    connectorsConfig.ConnectorUsageReporterURL,
+		client: &http.Client{Timeout: connectorUsageReporterTimeout},
+	}
+}
+
+type noopConnectorUsageReporter struct{}
+
+func (r *noopConnectorUsageReporter) Report(_ string, _ dbapi.ConnectorUsageList) error {
+	return nil
+}
+
+// connectorUsageRecord is an AMS style usage record of a connector type in a namespace for an hour
+type connectorUsageRecord struct {
+	OrganizationId string            `json:"organization_id"`
+	Product        string            `json:"product"`
+	Metric         string            `json:"metric"`
+	Value          float64           `json:"value"`
+	Timestamp      time.Time         `json:"timestamp"`
+	Labels         map[string]string `json:"labels"`
+}
+
+type connectorUsageRecordList struct {
+	Items []connectorUsageRecord `json:"items"`
+}
+
+type httpConnectorUsageReporter struct {
+	url    string
+	client *http.Client
+}
+
+func (r *httpConnectorUsageReporter) Report(idempotencyKey string, usage dbapi.ConnectorUsageList) error {
+	records := connectorUsageRecordList{Items: make([]connectorUsageRecord, len(usage))}
+	for i, u := range usage {
+		records.Items[i] = connectorUsageRecord{
+			OrganizationId: u.OrganisationId,
+			Product:        "rhoc",
+			Metric:         "connector_hours",
+			Value:          u.ConnectorHours(),
+			Timestamp:      u.PeriodStart,
+			Labels: map[string]string{
+				"namespace_id":      u.NamespaceId,
+				"connector_type_id": u.ConnectorTypeId,
+			},
+		}
+	}
+
+	body, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("usage service %s returned status %s", r.url, resp.Status)
+	}
+
+	return nil
+}
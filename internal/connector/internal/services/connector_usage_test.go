@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_connectorUsageService_SampleUsage(t *testing.T) {
+	now := time.Date(2030, 1, 1, 10, 42, 7, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		setupFn func()
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "should upsert the usage of the running connectors in the hour of the sample",
+			setupFn: func() {
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO connector_usages`).
+					WithCallback(func(query string, args []driver.NamedValue) {
+						g := gomega.NewWithT(t)
+						g.Expect(query).To(gomega.ContainSubstring("ON CONFLICT (organisation_id, namespace_id, connector_type_id, period_start) DO UPDATE"))
+						g.Expect(args).To(gomega.HaveLen(5))
+						g.Expect(args[0].Value).To(gomega.Equal(time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)))
+						g.Expect(args[1].Value).To(gomega.Equal(int64(60)))
+						g.Expect(args[4].Value).To(gomega.Equal(string(dbapi.ConnectorStatusPhaseReady)))
+					})
+			},
+			want: 1,
+		},
+		{
+			name: "should return an error when the usage can't be upserted",
+			setupFn: func() {
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO connector_usages`).WithExecException()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			tt.setupFn()
+			k := NewConnectorUsageService(db.NewMockConnectionFactory(nil), &ConnectorUsageReporterMock{})
+
+			count, err := k.SampleUsage(context.Background(), now, time.Minute)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(count).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_connectorUsageService_ReportUsage(t *testing.T) {
+	hour := time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+	usage := []map[string]interface{}{
+		{"organisation_id": "org-1", "namespace_id": "namespace-1", "connector_type_id": "type-1", "period_start": hour, "connector_seconds": 3600},
+		{"organisation_id": "org-2", "namespace_id": "namespace-2", "connector_type_id": "type-1", "period_start": hour, "connector_seconds": 1800},
+	}
+
+	tests := []struct {
+		name         string
+		reportErr    error
+		want         int64
+		wantErr      bool
+		wantReported bool
+	}{
+		{
+			name:         "should report the usage of a completed hour and mark it reported",
+			want:         2,
+			wantReported: true,
+		},
+		{
+			name:      "should not mark the usage reported when it can't be reported",
+			reportErr: fmt.Errorf("usage service unavailable"),
+			wantErr:   true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			reported := false
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`SELECT DISTINCT "period_start"`).
+				WithReply([]map[string]interface{}{{"period_start": hour}})
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "connector_usages"`).WithReply(usage)
+			mocket.Catcher.NewMock().WithQuery(`UPDATE "connector_usages"`).
+				WithCallback(func(query string, args []driver.NamedValue) {
+					reported = true
+				})
+			reporter := &ConnectorUsageReporterMock{
+				ReportFunc: func(idempotencyKey string, usage dbapi.ConnectorUsageList) error {
+					return tt.reportErr
+				},
+			}
+			k := NewConnectorUsageService(db.NewMockConnectionFactory(nil), reporter)
+
+			// the hour is reported once it's completed
+			count, err := k.ReportUsage(context.Background(), hour.Add(61*time.Minute))
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(count).To(gomega.Equal(tt.want))
+			g.Expect(reported).To(gomega.Equal(tt.wantReported))
+			g.Expect(reporter.ReportCalls()).To(gomega.HaveLen(1))
+			g.Expect(reporter.ReportCalls()[0].IdempotencyKey).To(gomega.Equal("connector-usage-2030-01-01T10:00:00Z"))
+			g.Expect(reporter.ReportCalls()[0].Usage).To(gomega.HaveLen(2))
+		})
+	}
+}
+
+func Test_connectorUsageIdempotencyKey(t *testing.T) {
+	g := gomega.NewWithT(t)
+	hour := time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// a report retried for the same hour must have the same key, whatever the time zone of the hour
+	g.Expect(connectorUsageIdempotencyKey(hour)).To(gomega.Equal("connector-usage-2030-01-01T10:00:00Z"))
+	g.Expect(connectorUsageIdempotencyKey(hour.In(time.FixedZone("CET", 3600)))).To(gomega.Equal(connectorUsageIdempotencyKey(hour)))
+	g.Expect(connectorUsageIdempotencyKey(hour.Add(time.Hour))).ToNot(gomega.Equal(connectorUsageIdempotencyKey(hour)))
+}
+
+func Test_httpConnectorUsageReporter_Report(t *testing.T) {
+	hour := time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+	usage := dbapi.ConnectorUsageList{
+		{OrganisationId: "org-1", NamespaceId: "namespace-1", ConnectorTypeId: "type-1", PeriodStart: hour, ConnectorSeconds: 5400},
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{
+			name:       "should post the usage records with the idempotency key",
+			statusCode: http.StatusAccepted,
+		},
+		{
+			name:       "should return an error when the usage service rejects the usage",
+			statusCode: http.StatusInternalServerError,
+			wantErr:    true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var request *http.Request
+			var records connectorUsageRecordList
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request = r
+				_ = json.NewDecoder(r.Body).Decode(&records)
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+			reporter := NewConnectorUsageReporter(&config.ConnectorsConfig{ConnectorUsageReporterURL: server.URL})
+
+			err := reporter.Report("connector-usage-2030-01-01T10:00:00Z", usage)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(request.Method).To(gomega.Equal(http.MethodPost))
+			g.Expect(request.Header.Get("Idempotency-Key")).To(gomega.Equal("connector-usage-2030-01-01T10:00:00Z"))
+			g.Expect(request.Header.Get("Content-Type")).To(gomega.Equal("application/json"))
+			g.Expect(records.Items).To(gomega.Equal([]connectorUsageRecord{{
+				OrganizationId: "org-1",
+				Product:        "rhoc",
+				Metric:         "connector_hours",
+				Value:          1.5,
+				Timestamp:      hour,
+				Labels:         map[string]string{"namespace_id": "namespace-1", "connector_type_id": "type-1"},
+			}}))
+		})
+	}
+
+	t.Run("should drop the usage when no usage service url is configured", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		reporter := NewConnectorUsageReporter(&config.ConnectorsConfig{})
+		g.Expect(reporter).To(gomega.BeAssignableToTypeOf(&noopConnectorUsageReporter{}))
+		g.Expect(reporter.Report("connector-usage-2030-01-01T10:00:00Z", usage)).To(gomega.BeNil())
+	})
+}
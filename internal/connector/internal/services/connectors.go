@@ -16,7 +16,9 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/secrets"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	goerrors "github.com/pkg/errors"
 	"github.com/spyzhov/ajson"
 
@@ -37,26 +39,34 @@ type ConnectorsService interface {
 	Delete(ctx context.Context, id string) *errors.ServiceError
 	ForEach(f func(*dbapi.Connector) *errors.ServiceError, query string, args ...interface{}) []error
 	ForceDelete(ctx context.Context, id string) *errors.ServiceError
+	// Apply creates and updates connectors along with their statuses in a single transaction, connectors are deleted
+	// by updating them with the deleted desired state
+	Apply(ctx context.Context, creates []*dbapi.Connector, updates []*dbapi.Connector) *errors.ServiceError
 
 	ResolveConnectorRefsWithBase64Secrets(resource *dbapi.Connector) (bool, *errors.ServiceError)
 }
 
+const connectorEventSource = "/api/connector_mgmt/v1/kafka_connectors"
+
 var _ ConnectorsService = &connectorsService{}
 
 type connectorsService struct {
-	connectionFactory     *db.ConnectionFactory
-	bus                   signalbus.SignalBus
-	vaultService          vault.VaultService
-	connectorTypesService ConnectorTypesService
+	connectionFactory         *db.ConnectionFactory
+	bus                       signalbus.SignalBus
+	vaultService              vault.VaultService
+	connectorTypesService     ConnectorTypesService
+	connectorRevisionsService ConnectorRevisionsService
 }
 
 func NewConnectorsService(connectionFactory *db.ConnectionFactory, bus signalbus.SignalBus,
-	vaultService vault.VaultService, connectorTypesService ConnectorTypesService) *connectorsService {
+	vaultService vault.VaultService, connectorTypesService ConnectorTypesService,
+	connectorRevisionsService ConnectorRevisionsService) *connectorsService {
 	return &connectorsService{
-		connectionFactory:     connectionFactory,
-		bus:                   bus,
-		vaultService:          vaultService,
-		connectorTypesService: connectorTypesService,
+		connectionFactory:         connectionFactory,
+		bus:                       bus,
+		vaultService:              vaultService,
+		connectorTypesService:     connectorTypesService,
+		connectorRevisionsService: connectorRevisionsService,
 	}
 }
 
@@ -67,7 +77,19 @@ func (k *connectorsService) Create(ctx context.Context, resource *dbapi.Connecto
 	//	return errors.Validation("kafka id is undefined")
 	//}
 
-	dbConn := k.connectionFactory.New()
+	if err := k.create(ctx, k.connectionFactory.New(), resource); err != nil {
+		return err
+	}
+
+	_ = db.AddPostCommitAction(ctx, func() {
+		// Wake up the reconcile loop...
+		k.bus.Notify("reconcile:connector")
+	})
+
+	return nil
+}
+
+func (k *connectorsService) create(ctx context.Context, dbConn *gorm.DB, resource *dbapi.Connector) *errors.ServiceError {
 	if err := dbConn.Create(resource).Error; err != nil {
 		return errors.GeneralError("failed to create connector: %v", err)
 	}
@@ -83,12 +105,7 @@ func (k *connectorsService) Create(ctx context.Context, resource *dbapi.Connecto
 		return errors.GeneralError("failed to save status: %v", err)
 	}
 
-	_ = db.AddPostCommitAction(ctx, func() {
-		// Wake up the reconcile loop...
-		k.bus.Notify("reconcile:connector")
-	})
-
-	return nil
+	return k.connectorRevisionsService.Record(ctx, dbConn, resource)
 }
 
 // Get gets a connector by id from the database
@@ -168,9 +185,14 @@ func (k *connectorsService) Delete(ctx context.Context, id string) *errors.Servi
 	if err := dbConn.Where("id = ?", id).Delete(&dbapi.ConnectorStatus{}).Error; err != nil {
 		return services.HandleGetError("ConnectorStatus", "id", id, err)
 	}
+	revisionSecrets, serr := k.connectorRevisionsService.Delete(dbConn, id)
+	if serr != nil {
+		return serr
+	}
 
 	_ = db.AddPostCommitAction(ctx, func() {
 		// delete related distributed resources...
+		var deleted []string
 
 		if resource.ServiceAccount.ClientSecretRef != "" {
 			err := k.vaultService.DeleteSecretString(resource.ServiceAccount.ClientSecretRef)
@@ -197,10 +219,21 @@ func (k *connectorsService) Delete(ctx context.Context, id string) *errors.Servi
 					if err != nil {
 						logger.Logger.Errorf("failed to delete vault secret key '%s': %v", r, err)
 					}
+					deleted = append(deleted, r)
 					return nil
 				})
 			}
 		}
+
+		// the secrets of previous revisions
+		for _, r := range revisionSecrets {
+			if arrays.Contains(deleted, r) {
+				continue
+			}
+			if err := k.vaultService.DeleteSecretString(r); err != nil {
+				logger.Logger.Errorf("failed to delete vault secret key '%s': %v", r, err)
+			}
+		}
 	})
 
 	return nil
@@ -316,23 +349,10 @@ func (k *connectorsService) Update(ctx context.Context, resource *dbapi.Connecto
 	}
 
 	if err := k.connectionFactory.New().Transaction(func(dbConn *gorm.DB) error {
-
-		// remove old annotations
-		if err := dbConn.Where("connector_id = ?", resource.ID).Delete(&dbapi.ConnectorAnnotation{}).Error; err != nil {
-			return services.HandleUpdateError("Connector", err)
-		}
-
-		update := dbConn.Model(resource).Session(&gorm.Session{FullSaveAssociations: true}).
-			Where("id = ? AND version = ?", resource.ID, resource.Version).Updates(resource)
-		if err := update.Error; err != nil {
-			return services.HandleUpdateError(`Connector`, err)
-		}
-		if update.RowsAffected == 0 {
-			return errors.Conflict("resource version changed")
+		if err := k.update(ctx, dbConn, resource); err != nil {
+			return err
 		}
-
 		return nil
-
 	}); err != nil {
 		return errors.ToServiceError(err)
 	}
@@ -351,14 +371,115 @@ func (k *connectorsService) Update(ctx context.Context, resource *dbapi.Connecto
 	return nil
 }
 
+func (k *connectorsService) update(ctx context.Context, dbConn *gorm.DB, resource *dbapi.Connector) *errors.ServiceError {
+
+	// remove old annotations
+	if err := dbConn.Where("connector_id = ?", resource.ID).Delete(&dbapi.ConnectorAnnotation{}).Error; err != nil {
+		return services.HandleUpdateError("Connector", err)
+	}
+
+	// Updates skips zero values, so all the columns are selected for a removed restart policy or a zero restart policy
+	// field to be updated, in a single update so that the connector version is only bumped once
+	update := dbConn.Model(resource).Session(&gorm.Session{FullSaveAssociations: true}).
+		Select("*").Omit("created_at").
+		Where("id = ? AND version = ?", resource.ID, resource.Version).Updates(resource)
+	if err := update.Error; err != nil {
+		return services.HandleUpdateError(`Connector`, err)
+	}
+	if update.RowsAffected == 0 {
+		return errors.Conflict("resource version changed")
+	}
+
+	// record the updated configuration with the updated version
+	var updated dbapi.Connector
+	if err := dbConn.Where("id = ?", resource.ID).First(&updated).Error; err != nil {
+		return services.HandleGetError("Connector", "id", resource.ID, err)
+	}
+	return k.connectorRevisionsService.Record(ctx, dbConn, &updated)
+}
+
 func (k *connectorsService) SaveStatus(ctx context.Context, resource dbapi.ConnectorStatus) *errors.ServiceError {
-	dbConn := k.connectionFactory.New()
-	if err := dbConn.Model(resource).Save(resource).Error; err != nil {
+	if err := k.connectionFactory.New().Transaction(func(dbConn *gorm.DB) error {
+		return saveConnectorStatus(dbConn, resource)
+	}); err != nil {
 		return errors.GeneralError("failed to update: %s", err.Error())
 	}
 	return nil
 }
 
+func saveConnectorStatus(dbConn *gorm.DB, resource dbapi.ConnectorStatus) error {
+	var previous dbapi.ConnectorStatus
+	if err := dbConn.Model(&dbapi.ConnectorStatus{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("phase").
+		Where("id = ?", resource.ID).
+		Scan(&previous).Error; err != nil {
+		return err
+	}
+	if err := dbConn.Model(resource).Save(resource).Error; err != nil {
+		return err
+	}
+	return recordConnectorStatusChange(dbConn, resource.ID, previous.Phase, resource.Phase)
+}
+
+func (k *connectorsService) Apply(ctx context.Context, creates []*dbapi.Connector, updates []*dbapi.Connector) *errors.ServiceError {
+	if len(creates) == 0 && len(updates) == 0 {
+		return nil
+	}
+
+	if err := k.connectionFactory.New().Transaction(func(dbConn *gorm.DB) error {
+		for _, resource := range creates {
+			if err := k.create(ctx, dbConn, resource); err != nil {
+				return err
+			}
+		}
+		for _, resource := range updates {
+			if resource.Version == 0 {
+				return errors.BadRequest("resource version is required")
+			}
+			// update connector phase before desired state
+			if err := saveConnectorStatus(dbConn, resource.Status); err != nil {
+				return errors.GeneralError("failed to update: %s", err.Error())
+			}
+			if err := k.update(ctx, dbConn, resource); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return errors.ToServiceError(err)
+	}
+
+	_ = db.AddPostCommitAction(ctx, func() {
+		// Wake up the reconcile loop...
+		k.bus.Notify("reconcile:connector")
+	})
+
+	return nil
+}
+
+// recordConnectorStatusChange adds a status changed event to the outbox when the connector phase changed.
+// It must be called with the transaction that updated the connector status.
+func recordConnectorStatusChange(dbConn *gorm.DB, connectorID string, previous, current dbapi.ConnectorStatusPhase) error {
+	if previous == current {
+		return nil
+	}
+
+	var connector dbapi.Connector
+	if err := dbConn.Unscoped().Model(&dbapi.Connector{}).
+		Select("organisation_id").
+		Where("id = ?", connectorID).
+		Scan(&connector).Error; err != nil {
+		return err
+	}
+
+	return webhooks.RecordEvent(dbConn, webhooks.ConnectorStatusChangedEventType, connectorEventSource, connectorID, connector.OrganisationId, webhooks.StatusChange{
+		ID:             connectorID,
+		Status:         string(current),
+		PreviousStatus: string(previous),
+	})
+}
+
 func (k *connectorsService) ForEach(f func(*dbapi.Connector) *errors.ServiceError, query string, args ...interface{}) []error {
 	dbConn := k.connectionFactory.New()
 	rows, err := dbConn.
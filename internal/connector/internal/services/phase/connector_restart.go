@@ -0,0 +1,56 @@
+package phase
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+)
+
+const (
+	DefaultRestartBackoff    = 30 * time.Second
+	DefaultMaxRestartBackoff = 10 * time.Minute
+)
+
+// NextRestartAttempt returns the time of the next restart attempt of a failed connector given the times of its
+// previous attempts, the first attempt is immediate and the delay between attempts doubles at every attempt up to the
+// policy maximum. Second return value is false if the connector must not be restarted, because the policy never
+// restarts it or because the maximum number of attempts has been reached.
+func NextRestartAttempt(policy dbapi.ConnectorRestartPolicy, attempts []time.Time) (time.Time, bool) {
+	if policy.Type != dbapi.ConnectorRestartPolicyOnFailure {
+		return time.Time{}, false
+	}
+	if policy.MaxAttempts > 0 && len(attempts) >= int(policy.MaxAttempts) {
+		return time.Time{}, false
+	}
+	if len(attempts) == 0 {
+		return time.Time{}, true
+	}
+
+	backoff := DefaultRestartBackoff
+	if policy.BackoffSeconds > 0 {
+		backoff = time.Duration(policy.BackoffSeconds) * time.Second
+	}
+	maxBackoff := DefaultMaxRestartBackoff
+	if policy.MaxBackoffSeconds > 0 {
+		maxBackoff = time.Duration(policy.MaxBackoffSeconds) * time.Second
+	}
+	if maxBackoff < backoff {
+		maxBackoff = backoff
+	}
+
+	delay := backoff
+	for i := 1; i < len(attempts) && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+
+	last := attempts[0]
+	for _, attempt := range attempts[1:] {
+		if attempt.After(last) {
+			last = attempt
+		}
+	}
+	return last.Add(delay), true
+}
@@ -0,0 +1,100 @@
+package phase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/onsi/gomega"
+)
+
+func Test_NextRestartAttempt(t *testing.T) {
+	now := time.Date(2023, 2, 6, 12, 0, 0, 0, time.UTC)
+	attempts := func(n int) []time.Time {
+		result := make([]time.Time, n)
+		for i := range result {
+			result[i] = now.Add(time.Duration(i-n) * time.Minute)
+		}
+		return result
+	}
+	last := now.Add(-time.Minute)
+
+	tests := []struct {
+		scenario string
+		policy   dbapi.ConnectorRestartPolicy
+		attempts []time.Time
+		restart  bool
+		next     time.Time
+	}{
+		{
+			scenario: "no restart policy",
+			policy:   dbapi.ConnectorRestartPolicy{},
+			restart:  false,
+		},
+		{
+			scenario: "never restart policy",
+			policy:   dbapi.ConnectorRestartPolicy{Type: dbapi.ConnectorRestartPolicyNever},
+			restart:  false,
+		},
+		{
+			scenario: "first attempt is immediate",
+			policy:   dbapi.ConnectorRestartPolicy{Type: dbapi.ConnectorRestartPolicyOnFailure},
+			restart:  true,
+			next:     time.Time{},
+		},
+		{
+			scenario: "second attempt uses the default backoff",
+			policy:   dbapi.ConnectorRestartPolicy{Type: dbapi.ConnectorRestartPolicyOnFailure},
+			attempts: attempts(1),
+			restart:  true,
+			next:     last.Add(DefaultRestartBackoff),
+		},
+		{
+			scenario: "backoff doubles at every attempt",
+			policy:   dbapi.ConnectorRestartPolicy{Type: dbapi.ConnectorRestartPolicyOnFailure, BackoffSeconds: 10},
+			attempts: attempts(3),
+			restart:  true,
+			next:     last.Add(40 * time.Second),
+		},
+		{
+			scenario: "backoff is capped by the max backoff",
+			policy:   dbapi.ConnectorRestartPolicy{Type: dbapi.ConnectorRestartPolicyOnFailure, BackoffSeconds: 10, MaxBackoffSeconds: 60},
+			attempts: attempts(10),
+			restart:  true,
+			next:     last.Add(time.Minute),
+		},
+		{
+			scenario: "backoff is capped by the default max backoff",
+			policy:   dbapi.ConnectorRestartPolicy{Type: dbapi.ConnectorRestartPolicyOnFailure},
+			attempts: attempts(100),
+			restart:  true,
+			next:     last.Add(DefaultMaxRestartBackoff),
+		},
+		{
+			scenario: "attempts below max attempts",
+			policy:   dbapi.ConnectorRestartPolicy{Type: dbapi.ConnectorRestartPolicyOnFailure, MaxAttempts: 3},
+			attempts: attempts(2),
+			restart:  true,
+			next:     last.Add(2 * DefaultRestartBackoff),
+		},
+		{
+			scenario: "max attempts reached",
+			policy:   dbapi.ConnectorRestartPolicy{Type: dbapi.ConnectorRestartPolicyOnFailure, MaxAttempts: 3},
+			attempts: attempts(3),
+			restart:  false,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.scenario, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			next, restart := NextRestartAttempt(tt.policy, tt.attempts)
+			g.Expect(restart).To(gomega.Equal(tt.restart))
+			if tt.restart {
+				g.Expect(next).To(gomega.Equal(tt.next))
+			}
+		})
+	}
+}
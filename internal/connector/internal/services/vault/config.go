@@ -18,6 +18,19 @@ type Config struct {
 	SecretPrefix        string `json:"secret_prefix"`
 	SecretPrefixEnable  bool   `json:"secret_prefix_enable"`
 	Region              string `json:"region"`
+	// Used for the HashiCorp Vault (KV v2) vault service
+	Address             string `json:"address"`
+	Namespace           string `json:"namespace"`
+	KvMountPath         string `json:"kv_mount_path"`
+	CACertFile          string `json:"ca_cert_file"`
+	AuthMethod          string `json:"auth_method"`
+	AuthMountPath       string `json:"auth_mount_path"`
+	AppRoleRoleID       string `json:"approle_role_id"`
+	AppRoleRoleIDFile   string `json:"approle_role_id_file"`
+	AppRoleSecretID     string `json:"approle_secret_id"`
+	AppRoleSecretIDFile string `json:"approle_secret_id_file"`
+	KubernetesRole      string `json:"kubernetes_role"`
+	KubernetesTokenFile string `json:"kubernetes_token_file"`
 }
 
 func NewConfig() *Config {
@@ -28,22 +41,57 @@ func NewConfig() *Config {
 		Region:              DefaultRegion,
 		SecretPrefixEnable:  false,
 		SecretPrefix:        "managed-connectors",
+		KvMountPath:         "secret",
+		AuthMethod:          AuthMethodAppRole,
+		AppRoleRoleIDFile:   "secrets/vault/approle_role_id",
+		AppRoleSecretIDFile: "secrets/vault/approle_secret_id",
+		KubernetesTokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token",
 	}
 }
 
 func (c *Config) AddFlags(fs *pflag.FlagSet) {
-	fs.StringVar(&c.Kind, "vault-kind", c.Kind, "The kind of vault to use: aws|tmp")
+	fs.StringVar(&c.Kind, "vault-kind", c.Kind, "The kind of vault to use: aws|hashicorp|tmp")
 	fs.StringVar(&c.AccessKeyFile, "vault-access-key-file", c.AccessKeyFile, "File containing vault access key")
 	fs.StringVar(&c.SecretAccessKeyFile, "vault-secret-access-key-file", c.SecretAccessKeyFile, "File containing vault secret access key")
 	fs.BoolVar(&c.SecretPrefixEnable, "vault-secret-prefix-enable", c.SecretPrefixEnable, "Enable use of a prefix for all managed connectors secret names in AWS vault, default false")
 	fs.StringVar(&c.SecretPrefix, "vault-secret-prefix", c.SecretPrefix, "Prefix to use for all managed connectors secret names in AWS vault")
 	fs.StringVar(&c.Region, "vault-region", c.Region, "The region of the vault")
+	fs.StringVar(&c.Address, "vault-address", c.Address, "The address of the HashiCorp vault server, e.g. https://vault.example.com:8200")
+	fs.StringVar(&c.Namespace, "vault-namespace", c.Namespace, "The HashiCorp vault enterprise namespace to use, empty for the root namespace")
+	fs.StringVar(&c.KvMountPath, "vault-kv-mount-path", c.KvMountPath, "The mount path of the KV v2 secrets engine in HashiCorp vault")
+	fs.StringVar(&c.CACertFile, "vault-ca-cert-file", c.CACertFile, "File containing the CA certificate of the HashiCorp vault server, the system CAs are used when empty")
+	fs.StringVar(&c.AuthMethod, "vault-auth-method", c.AuthMethod, "The auth method used to log in to HashiCorp vault: approle|kubernetes")
+	fs.StringVar(&c.AuthMountPath, "vault-auth-mount-path", c.AuthMountPath, "The mount path of the auth method in HashiCorp vault, defaults to the name of the auth method")
+	fs.StringVar(&c.AppRoleRoleIDFile, "vault-approle-role-id-file", c.AppRoleRoleIDFile, "File containing the role id used to log in to HashiCorp vault with the approle auth method")
+	fs.StringVar(&c.AppRoleSecretIDFile, "vault-approle-secret-id-file", c.AppRoleSecretIDFile, "File containing the secret id used to log in to HashiCorp vault with the approle auth method")
+	fs.StringVar(&c.KubernetesRole, "vault-kubernetes-role", c.KubernetesRole, "The role used to log in to HashiCorp vault with the kubernetes auth method")
+	fs.StringVar(&c.KubernetesTokenFile, "vault-kubernetes-token-file", c.KubernetesTokenFile, "File containing the service account token used to log in to HashiCorp vault with the kubernetes auth method")
 }
 
 func (c *Config) Validate(env *environments.Env) error {
 	if c.Kind == KindAws && c.SecretPrefixEnable && len(c.SecretPrefix) == 0 {
 		return fmt.Errorf("error validating AWS vault config, vault-secret-prefix must be set to a non-empty value if vault-secret-prefix-enable is true")
 	}
+	if c.Kind == KindHashicorp {
+		if c.SecretPrefixEnable && len(c.SecretPrefix) == 0 {
+			return fmt.Errorf("error validating HashiCorp vault config, vault-secret-prefix must be set to a non-empty value if vault-secret-prefix-enable is true")
+		}
+		if c.Address == "" {
+			return fmt.Errorf("error validating HashiCorp vault config, vault-address must be set")
+		}
+		if c.KvMountPath == "" {
+			return fmt.Errorf("error validating HashiCorp vault config, vault-kv-mount-path must be set")
+		}
+		switch c.AuthMethod {
+		case AuthMethodAppRole:
+		case AuthMethodKubernetes:
+			if c.KubernetesRole == "" {
+				return fmt.Errorf("error validating HashiCorp vault config, vault-kubernetes-role must be set when vault-auth-method is %s", AuthMethodKubernetes)
+			}
+		default:
+			return fmt.Errorf("error validating HashiCorp vault config, invalid vault-auth-method %q, only %s and %s are supported", c.AuthMethod, AuthMethodAppRole, AuthMethodKubernetes)
+		}
+	}
 	return nil
 }
 
@@ -58,5 +106,15 @@ func (c *Config) ReadFiles() error {
 			return err
 		}
 	}
+	if c.Kind == KindHashicorp && c.AuthMethod == AuthMethodAppRole {
+		err := shared.ReadFileValueString(c.AppRoleRoleIDFile, &c.AppRoleRoleID)
+		if err != nil {
+			return err
+		}
+		err = shared.ReadFileValueString(c.AppRoleSecretIDFile, &c.AppRoleSecretID)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
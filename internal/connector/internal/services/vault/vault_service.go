@@ -6,8 +6,9 @@ import (
 )
 
 const (
-	KindTmp = "tmp"
-	KindAws = "aws"
+	KindTmp       = "tmp"
+	KindAws       = "aws"
+	KindHashicorp = "hashicorp"
 
 	DefaultRegion = "us-east-1"
 )
@@ -16,6 +17,8 @@ type VaultService interface {
 	SetSecretString(name string, value string, owningResource string) error
 	GetSecretString(name string) (string, error)
 	DeleteSecretString(name string) error
+	// ForEachSecret calls f for every secret in the vault until it returns false. The name passed to f is the one
+	// used to set the secret, without the configured secret prefix, so that it can be used to get or delete it.
 	ForEachSecret(f func(name string, owningResource string) bool) error
 	Kind() string
 }
@@ -25,6 +28,8 @@ func NewVaultService(vaultConfig *Config) (VaultService, error) {
 	switch vaultConfig.Kind {
 	case KindAws:
 		return NewAwsVaultService(vaultConfig)
+	case KindHashicorp:
+		return NewHashicorpVaultService(vaultConfig)
 	case KindTmp:
 		return NewTmpVaultService()
 	default:
@@ -1,6 +1,8 @@
 package vault
 
 import (
+	"strings"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -119,6 +121,9 @@ func (k *awsVaultService) ForEachSecret(f func(name string, owningResource strin
 			if entry.Name != nil {
 				name = *entry.Name
 			}
+			if k.secretPrefixEnable {
+				name = strings.TrimPrefix(name, k.secretPrefix)
+			}
 			metrics.IncreaseVaultServiceSuccessCount("get")
 			if !f(name, owner) {
 				return false
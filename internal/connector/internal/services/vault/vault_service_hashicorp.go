@@ -0,0 +1,434 @@
+package vault
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/metrics"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/golang/glog"
+)
+
+const (
+	AuthMethodAppRole    = "approle"
+	AuthMethodKubernetes = "kubernetes"
+
+	// the value of a secret is stored under this key of the KV v2 secret data
+	hashicorpSecretValueKey = "value"
+	// the namespace of a vault enterprise server is selected with this header
+	hashicorpNamespaceHeader = "X-Vault-Namespace"
+	hashicorpTokenHeader     = "X-Vault-Token"
+	// tokens are renewed once two thirds of their lease have elapsed
+	hashicorpTokenRenewalRatio = 2.0 / 3.0
+	hashicorpRequestTimeout    = 30 * time.Second
+)
+
+var _ VaultService = &hashicorpVaultService{}
+
+// hashicorpAuth is the token the service is authenticated with in HashiCorp Vault
+type hashicorpAuth struct {
+	token     string
+	renewable bool
+	// zero when the token does not expire
+	expiresAt time.Time
+	renewAt   time.Time
+}
+
+// hashicorpVaultService stores the secrets in a KV v2 secrets engine of HashiCorp Vault, the owning resource of a secret
+// being kept in its custom metadata.
+type hashicorpVaultService struct {
+	client             *http.Client
+	address            string
+	namespace          string
+	kvMountPath        string
+	authMethod         string
+	authMountPath      string
+	appRoleRoleID      string
+	appRoleSecretID    string
+	kubernetesRole     string
+	kubernetesJWTFile  string
+	secretPrefixEnable bool
+	secretPrefix       string
+	now                func() time.Time
+
+	mu   sync.Mutex
+	auth hashicorpAuth
+}
+
+type hashicorpAuthResponse struct {
+	Auth *struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int64  `json:"lease_duration"`
+		Renewable     bool   `json:"renewable"`
+	} `json:"auth"`
+}
+
+type hashicorpSecretResponse struct {
+	Data struct {
+		Data map[string]string `json:"data"`
+	} `json:"data"`
+}
+
+type hashicorpMetadataResponse struct {
+	Data struct {
+		CustomMetadata map[string]string `json:"custom_metadata"`
+	} `json:"data"`
+}
+
+type hashicorpListResponse struct {
+	Data struct {
+		Keys []string `json:"keys"`
+	} `json:"data"`
+}
+
+// hashicorpError is returned when HashiCorp Vault answers a request with an unexpected status
+type hashicorpError struct {
+	StatusCode int
+	Errors     []string `json:"errors"`
+}
+
+func (e *hashicorpError) Error() string {
+	return fmt.Sprintf("vault request failed with status %d: %s", e.StatusCode, strings.Join(e.Errors, ", "))
+}
+
+func NewHashicorpVaultService(vaultConfig *Config) (*hashicorpVaultService, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if vaultConfig.CACertFile != "" {
+		caCert, err := os.ReadFile(shared.BuildFullFilePath(vaultConfig.CACertFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read vault CA certificate: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("failed to parse vault CA certificate %s", vaultConfig.CACertFile)
+		}
+	}
+
+	authMountPath := vaultConfig.AuthMountPath
+	if authMountPath == "" {
+		authMountPath = vaultConfig.AuthMethod
+	}
+
+	return &hashicorpVaultService{
+		client: &http.Client{
+			Timeout:   hashicorpRequestTimeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment},
+		},
+		address:            strings.TrimSuffix(vaultConfig.Address, "/"),
+		namespace:          vaultConfig.Namespace,
+		kvMountPath:        strings.Trim(vaultConfig.KvMountPath, "/"),
+		authMethod:         vaultConfig.AuthMethod,
+		authMountPath:      strings.Trim(authMountPath, "/"),
+		appRoleRoleID:      vaultConfig.AppRoleRoleID,
+		appRoleSecretID:    vaultConfig.AppRoleSecretID,
+		kubernetesRole:     vaultConfig.KubernetesRole,
+		kubernetesJWTFile:  vaultConfig.KubernetesTokenFile,
+		secretPrefixEnable: vaultConfig.SecretPrefixEnable,
+		secretPrefix:       strings.Trim(vaultConfig.SecretPrefix, "/") + "/",
+		now:                time.Now,
+	}, nil
+}
+
+func (k *hashicorpVaultService) Kind() string {
+	return KindHashicorp
+}
+
+func (k *hashicorpVaultService) GetSecretString(name string) (string, error) {
+	name = k.getVaultSecretName(name)
+	metrics.IncreaseVaultServiceTotalCount("get")
+
+	var secret hashicorpSecretResponse
+	if err := k.do(http.MethodGet, k.dataPath(name), nil, &secret); err != nil {
+		if isHashicorpNotFound(err) {
+			metrics.IncreaseVaultServiceErrorsCount("get")
+			return "", NotFound
+		}
+		metrics.IncreaseVaultServiceFailureCount("get")
+		return "", err
+	}
+
+	value, found := secret.Data.Data[hashicorpSecretValueKey]
+	if !found {
+		metrics.IncreaseVaultServiceErrorsCount("get")
+		return "", NotFound
+	}
+	metrics.IncreaseVaultServiceSuccessCount("get")
+	return value, nil
+}
+
+func (k *hashicorpVaultService) SetSecretString(name string, value string, owningResource string) error {
+	name = k.getVaultSecretName(name)
+	metrics.IncreaseVaultServiceTotalCount("set")
+
+	data := map[string]interface{}{
+		"data": map[string]string{hashicorpSecretValueKey: value},
+	}
+	if err := k.do(http.MethodPost, k.dataPath(name), data, nil); err != nil {
+		metrics.IncreaseVaultServiceFailureCount("set")
+		return err
+	}
+
+	if owningResource != "" {
+		metadata := map[string]interface{}{
+			"custom_metadata": map[string]string{OwnerResourceTagKey: owningResource},
+		}
+		if err := k.do(http.MethodPost, k.metadataPath(name), metadata, nil); err != nil {
+			metrics.IncreaseVaultServiceFailureCount("set")
+			return err
+		}
+	}
+
+	metrics.IncreaseVaultServiceSuccessCount("set")
+	return nil
+}
+
+func (k *hashicorpVaultService) DeleteSecretString(name string) error {
+	name = k.getVaultSecretName(name)
+	metrics.IncreaseVaultServiceTotalCount("delete")
+
+	// deleting the metadata of a missing secret succeeds in vault, so it is looked up first to report it as missing
+	if err := k.do(http.MethodGet, k.metadataPath(name), nil, &hashicorpMetadataResponse{}); err != nil {
+		if isHashicorpNotFound(err) {
+			metrics.IncreaseVaultServiceErrorsCount("delete")
+			return NotFound
+		}
+		metrics.IncreaseVaultServiceFailureCount("delete")
+		return err
+	}
+
+	// deleting the metadata removes all the versions of the secret
+	if err := k.do(http.MethodDelete, k.metadataPath(name), nil, nil); err != nil {
+		metrics.IncreaseVaultServiceFailureCount("delete")
+		return err
+	}
+	metrics.IncreaseVaultServiceSuccessCount("delete")
+	return nil
+}
+
+func (k *hashicorpVaultService) ForEachSecret(f func(name string, owningResource string) bool) error {
+	prefix := ""
+	if k.secretPrefixEnable {
+		prefix = k.secretPrefix
+	}
+	_, err := k.forEachSecretIn(prefix, f)
+	if err != nil {
+		metrics.IncreaseVaultServiceFailureCount("get")
+		return err
+	}
+	return nil
+}
+
+// forEachSecretIn calls f for every secret under the given folder and its sub folders, it returns false once f has
+// asked to stop
+func (k *hashicorpVaultService) forEachSecretIn(folder string, f func(name string, owningResource string) bool) (bool, error) {
+	var list hashicorpListResponse
+	if err := k.do("LIST", k.metadataPath(folder), nil, &list); err != nil {
+		if isHashicorpNotFound(err) {
+			return true, nil // no secret in the folder
+		}
+		return false, err
+	}
+
+	for _, key := range list.Data.Keys {
+		name := folder + key
+		if strings.HasSuffix(key, "/") {
+			next, err := k.forEachSecretIn(name, f)
+			if err != nil || !next {
+				return next, err
+			}
+			continue
+		}
+
+		metrics.IncreaseVaultServiceTotalCount("get")
+		var metadata hashicorpMetadataResponse
+		if err := k.do(http.MethodGet, k.metadataPath(name), nil, &metadata); err != nil {
+			return false, err
+		}
+		metrics.IncreaseVaultServiceSuccessCount("get")
+		if !f(k.trimSecretPrefix(name), metadata.Data.CustomMetadata[OwnerResourceTagKey]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (k *hashicorpVaultService) getVaultSecretName(name string) string {
+	if k.secretPrefixEnable {
+		return k.secretPrefix + name
+	}
+	return name
+}
+
+func (k *hashicorpVaultService) trimSecretPrefix(name string) string {
+	if k.secretPrefixEnable {
+		return strings.TrimPrefix(name, k.secretPrefix)
+	}
+	return name
+}
+
+func (k *hashicorpVaultService) dataPath(name string) string {
+	return fmt.Sprintf("/v1/%s/data/%s", k.kvMountPath, escapePath(name))
+}
+
+func (k *hashicorpVaultService) metadataPath(name string) string {
+	return fmt.Sprintf("/v1/%s/metadata/%s", k.kvMountPath, escapePath(name))
+}
+
+func escapePath(name string) string {
+	segments := strings.Split(name, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	return strings.Join(segments, "/")
+}
+
+// do sends an authenticated request to vault and decodes its response into result when given. The request is retried
+// once with a new token when vault rejects the current one, as it may have been revoked before its expiry.
+func (k *hashicorpVaultService) do(method string, path string, body interface{}, result interface{}) error {
+	token, err := k.getToken()
+	if err != nil {
+		return err
+	}
+
+	err = k.send(method, path, token, body, result)
+	if herr, ok := err.(*hashicorpError); ok && herr.StatusCode == http.StatusForbidden {
+		k.resetToken(token)
+		if token, err = k.getToken(); err != nil {
+			return err
+		}
+		err = k.send(method, path, token, body, result)
+	}
+	return err
+}
+
+func (k *hashicorpVaultService) send(method string, path string, token string, body interface{}, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequest(method, k.address+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set(hashicorpTokenHeader, token)
+	}
+	if k.namespace != "" {
+		req.Header.Set(hashicorpNamespaceHeader, k.namespace)
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer shared.CloseQuietly(resp.Body)()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		herr := &hashicorpError{StatusCode: resp.StatusCode}
+		_ = json.NewDecoder(resp.Body).Decode(herr)
+		return herr
+	}
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// getToken returns a valid token, renewing the current one when it is about to expire or logging in again when it
+// cannot be renewed
+func (k *hashicorpVaultService) getToken() (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := k.now()
+	if k.auth.token != "" && (k.auth.renewAt.IsZero() || now.Before(k.auth.renewAt)) {
+		return k.auth.token, nil
+	}
+
+	if k.auth.token != "" && k.auth.renewable && now.Before(k.auth.expiresAt) {
+		var resp hashicorpAuthResponse
+		err := k.send(http.MethodPost, "/v1/auth/token/renew-self", k.auth.token, map[string]interface{}{}, &resp)
+		if err == nil && resp.Auth != nil {
+			k.setAuth(resp)
+			return k.auth.token, nil
+		}
+		glog.Warningf("failed to renew vault token, logging in again: %v", err)
+	}
+
+	resp, err := k.login()
+	if err != nil {
+		return "", err
+	}
+	k.setAuth(resp)
+	return k.auth.token, nil
+}
+
+func (k *hashicorpVaultService) resetToken(token string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.auth.token == token {
+		k.auth = hashicorpAuth{}
+	}
+}
+
+func (k *hashicorpVaultService) setAuth(resp hashicorpAuthResponse) {
+	now := k.now()
+	k.auth = hashicorpAuth{
+		token:     resp.Auth.ClientToken,
+		renewable: resp.Auth.Renewable,
+	}
+	if resp.Auth.LeaseDuration > 0 {
+		lease := time.Duration(resp.Auth.LeaseDuration) * time.Second
+		k.auth.expiresAt = now.Add(lease)
+		k.auth.renewAt = now.Add(time.Duration(float64(lease) * hashicorpTokenRenewalRatio))
+	}
+}
+
+func (k *hashicorpVaultService) login() (hashicorpAuthResponse, error) {
+	var body map[string]string
+	switch k.authMethod {
+	case AuthMethodAppRole:
+		body = map[string]string{"role_id": k.appRoleRoleID, "secret_id": k.appRoleSecretID}
+	case AuthMethodKubernetes:
+		// the service account token is read on every login as it is rotated by kubernetes
+		jwt, err := shared.ReadFile(k.kubernetesJWTFile)
+		if err != nil {
+			return hashicorpAuthResponse{}, fmt.Errorf("failed to read kubernetes service account token: %w", err)
+		}
+		body = map[string]string{"role": k.kubernetesRole, "jwt": strings.TrimSpace(jwt)}
+	default:
+		return hashicorpAuthResponse{}, fmt.Errorf("invalid vault auth method: %s", k.authMethod)
+	}
+
+	var resp hashicorpAuthResponse
+	if err := k.send(http.MethodPost, fmt.Sprintf("/v1/auth/%s/login", k.authMountPath), "", body, &resp); err != nil {
+		return hashicorpAuthResponse{}, fmt.Errorf("failed to log in to vault with %s auth method: %w", k.authMethod, err)
+	}
+	if resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return hashicorpAuthResponse{}, fmt.Errorf("failed to log in to vault with %s auth method: no token returned", k.authMethod)
+	}
+	return resp, nil
+}
+
+func isHashicorpNotFound(err error) bool {
+	herr, ok := err.(*hashicorpError)
+	return ok && herr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,400 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+const (
+	fakeHashicorpRoleID         = "role-id"
+	fakeHashicorpSecretID       = "secret-id"
+	fakeHashicorpKubernetesRole = "cos-fleet-manager"
+	fakeHashicorpKubernetesJWT  = "service-account-token"
+)
+
+type fakeHashicorpSecret struct {
+	data           map[string]string
+	customMetadata map[string]string
+}
+
+// fakeHashicorpVault is an in-process fake of the HashiCorp Vault API used by the vault service: the approle and
+// kubernetes logins, the token self renewal and a KV v2 secrets engine mounted at 'secret'
+type fakeHashicorpVault struct {
+	mu            sync.Mutex
+	namespace     string
+	leaseDuration int64
+	secrets       map[string]fakeHashicorpSecret
+	tokens        map[string]bool
+	logins        int
+	renewals      int
+}
+
+func newFakeHashicorpVault(t *testing.T, namespace string, leaseDuration int64) (*fakeHashicorpVault, *httptest.Server) {
+	fake := &fakeHashicorpVault{
+		namespace:     namespace,
+		leaseDuration: leaseDuration,
+		secrets:       map[string]fakeHashicorpSecret{},
+		tokens:        map[string]bool{},
+	}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+// NewFakeHashicorpVaultServer starts a fake HashiCorp Vault server accepting the approle credentials of
+// NewFakeHashicorpVaultConfig
+func NewFakeHashicorpVaultServer(t *testing.T) *httptest.Server {
+	_, server := newFakeHashicorpVault(t, "", 3600)
+	return server
+}
+
+// NewFakeHashicorpVaultConfig returns the config of a vault service using the given fake HashiCorp Vault server
+func NewFakeHashicorpVaultConfig(server *httptest.Server) *Config {
+	return &Config{
+		Kind:            KindHashicorp,
+		Address:         server.URL,
+		KvMountPath:     "secret",
+		AuthMethod:      AuthMethodAppRole,
+		AppRoleRoleID:   fakeHashicorpRoleID,
+		AppRoleSecretID: fakeHashicorpSecretID,
+	}
+}
+
+func (f *fakeHashicorpVault) revokeTokens() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tokens = map[string]bool{}
+}
+
+func (f *fakeHashicorpVault) counts() (int, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.logins, f.renewals
+}
+
+func (f *fakeHashicorpVault) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get(hashicorpNamespaceHeader) != f.namespace {
+		writeFakeHashicorpError(w, http.StatusNotFound, "no handler for route")
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Body != nil && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeFakeHashicorpError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/approle/login":
+		if body["role_id"] != fakeHashicorpRoleID || body["secret_id"] != fakeHashicorpSecretID {
+			writeFakeHashicorpError(w, http.StatusBadRequest, "invalid role or secret ID")
+			return
+		}
+		f.writeAuth(w, true)
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/kubernetes/login":
+		if body["role"] != fakeHashicorpKubernetesRole || body["jwt"] != fakeHashicorpKubernetesJWT {
+			writeFakeHashicorpError(w, http.StatusForbidden, "permission denied")
+			return
+		}
+		f.writeAuth(w, true)
+	case !f.tokens[r.Header.Get(hashicorpTokenHeader)]:
+		writeFakeHashicorpError(w, http.StatusForbidden, "permission denied")
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/token/renew-self":
+		f.renewals++
+		writeFakeHashicorpJSON(w, map[string]interface{}{
+			"auth": map[string]interface{}{
+				"client_token":   r.Header.Get(hashicorpTokenHeader),
+				"lease_duration": f.leaseDuration,
+				"renewable":      true,
+			},
+		})
+	case strings.HasPrefix(r.URL.Path, "/v1/secret/data/"):
+		f.serveData(w, r, strings.TrimPrefix(r.URL.Path, "/v1/secret/data/"), body)
+	case strings.HasPrefix(r.URL.Path, "/v1/secret/metadata/"):
+		f.serveMetadata(w, r, strings.TrimPrefix(r.URL.Path, "/v1/secret/metadata/"), body)
+	default:
+		writeFakeHashicorpError(w, http.StatusNotFound, "no handler for route")
+	}
+}
+
+func (f *fakeHashicorpVault) writeAuth(w http.ResponseWriter, renewable bool) {
+	f.logins++
+	token := fmt.Sprintf("token-%d", f.logins)
+	f.tokens[token] = true
+	writeFakeHashicorpJSON(w, map[string]interface{}{
+		"auth": map[string]interface{}{
+			"client_token":   token,
+			"lease_duration": f.leaseDuration,
+			"renewable":      renewable,
+		},
+	})
+}
+
+func (f *fakeHashicorpVault) serveData(w http.ResponseWriter, r *http.Request, path string, body map[string]interface{}) {
+	switch r.Method {
+	case http.MethodGet:
+		secret, found := f.secrets[path]
+		if !found || secret.data == nil {
+			writeFakeHashicorpError(w, http.StatusNotFound)
+			return
+		}
+		writeFakeHashicorpJSON(w, map[string]interface{}{"data": map[string]interface{}{"data": secret.data}})
+	case http.MethodPost:
+		secret := f.secrets[path]
+		secret.data = toStringMap(body["data"])
+		f.secrets[path] = secret
+		writeFakeHashicorpJSON(w, map[string]interface{}{"data": map[string]interface{}{"version": 1}})
+	default:
+		writeFakeHashicorpError(w, http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeHashicorpVault) serveMetadata(w http.ResponseWriter, r *http.Request, path string, body map[string]interface{}) {
+	switch {
+	case r.Method == "LIST" || r.URL.Query().Get("list") == "true":
+		keys := map[string]bool{}
+		for name := range f.secrets {
+			if strings.HasPrefix(name, path) {
+				key := strings.TrimPrefix(name, path)
+				if i := strings.Index(key, "/"); i >= 0 {
+					key = key[:i+1]
+				}
+				keys[key] = true
+			}
+		}
+		if len(keys) == 0 {
+			writeFakeHashicorpError(w, http.StatusNotFound)
+			return
+		}
+		var list []string
+		for key := range keys {
+			list = append(list, key)
+		}
+		sort.Strings(list)
+		writeFakeHashicorpJSON(w, map[string]interface{}{"data": map[string]interface{}{"keys": list}})
+	case r.Method == http.MethodGet:
+		secret, found := f.secrets[path]
+		if !found {
+			writeFakeHashicorpError(w, http.StatusNotFound)
+			return
+		}
+		writeFakeHashicorpJSON(w, map[string]interface{}{"data": map[string]interface{}{"custom_metadata": secret.customMetadata}})
+	case r.Method == http.MethodPost:
+		secret := f.secrets[path]
+		secret.customMetadata = toStringMap(body["custom_metadata"])
+		f.secrets[path] = secret
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete:
+		delete(f.secrets, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeHashicorpError(w, http.StatusMethodNotAllowed)
+	}
+}
+
+func toStringMap(value interface{}) map[string]string {
+	result := map[string]string{}
+	if m, ok := value.(map[string]interface{}); ok {
+		for k, v := range m {
+			result[k] = fmt.Sprint(v)
+		}
+	}
+	return result
+}
+
+func writeFakeHashicorpJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeFakeHashicorpError(w http.ResponseWriter, status int, errs ...string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if errs == nil {
+		errs = []string{}
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": errs})
+}
+
+func Test_hashicorpVaultService_KubernetesAuth(t *testing.T) {
+	g := gomega.NewWithT(t)
+	_, server := newFakeHashicorpVault(t, "connectors", 3600)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	g.Expect(os.WriteFile(tokenFile, []byte(fakeHashicorpKubernetesJWT+"\n"), 0600)).To(gomega.Succeed())
+
+	svc, err := NewHashicorpVaultService(&Config{
+		Kind:                KindHashicorp,
+		Address:             server.URL,
+		Namespace:           "connectors",
+		KvMountPath:         "secret",
+		AuthMethod:          AuthMethodKubernetes,
+		KubernetesRole:      fakeHashicorpKubernetesRole,
+		KubernetesTokenFile: tokenFile,
+		SecretPrefixEnable:  true,
+		SecretPrefix:        "managed-connectors",
+	})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	g.Expect(svc.SetSecretString("first", "one", "connector/first")).To(gomega.Succeed())
+	g.Expect(svc.SetSecretString("nested/second", "two", "connector/second")).To(gomega.Succeed())
+	g.Expect(svc.SetSecretString("third", "three", "")).To(gomega.Succeed())
+
+	value, err := svc.GetSecretString("nested/second")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(value).To(gomega.Equal("two"))
+
+	owners := map[string]string{}
+	g.Expect(svc.ForEachSecret(func(name string, owningResource string) bool {
+		owners[name] = owningResource
+		return true
+	})).To(gomega.Succeed())
+	g.Expect(owners).To(gomega.Equal(map[string]string{
+		"first":         "connector/first",
+		"nested/second": "connector/second",
+		"third":         "",
+	}))
+
+	count := 0
+	g.Expect(svc.ForEachSecret(func(name string, owningResource string) bool {
+		count++
+		return false
+	})).To(gomega.Succeed())
+	g.Expect(count).To(gomega.Equal(1))
+
+	g.Expect(svc.DeleteSecretString("first")).To(gomega.Succeed())
+	_, err = svc.GetSecretString("first")
+	g.Expect(err).To(gomega.Equal(NotFound))
+	g.Expect(svc.DeleteSecretString("first")).To(gomega.Equal(NotFound))
+}
+
+func Test_hashicorpVaultService_TokenRenewal(t *testing.T) {
+	g := gomega.NewWithT(t)
+	fake, server := newFakeHashicorpVault(t, "", 60)
+
+	svc, err := NewHashicorpVaultService(NewFakeHashicorpVaultConfig(server))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+
+	g.Expect(svc.SetSecretString("secret", "value", "")).To(gomega.Succeed())
+	logins, renewals := fake.counts()
+	g.Expect(logins).To(gomega.Equal(1))
+	g.Expect(renewals).To(gomega.Equal(0))
+
+	// the token is renewed once two thirds of its lease have elapsed
+	now = now.Add(30 * time.Second)
+	_, err = svc.GetSecretString("secret")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	logins, renewals = fake.counts()
+	g.Expect(logins).To(gomega.Equal(1))
+	g.Expect(renewals).To(gomega.Equal(0))
+
+	now = now.Add(15 * time.Second)
+	_, err = svc.GetSecretString("secret")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	logins, renewals = fake.counts()
+	g.Expect(logins).To(gomega.Equal(1))
+	g.Expect(renewals).To(gomega.Equal(1))
+
+	// an expired token is replaced by logging in again
+	now = now.Add(2 * time.Minute)
+	_, err = svc.GetSecretString("secret")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	logins, renewals = fake.counts()
+	g.Expect(logins).To(gomega.Equal(2))
+	g.Expect(renewals).To(gomega.Equal(1))
+
+	// a revoked token is replaced by logging in again
+	fake.revokeTokens()
+	_, err = svc.GetSecretString("secret")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	logins, _ = fake.counts()
+	g.Expect(logins).To(gomega.Equal(3))
+}
+
+func Test_hashicorpVaultService_InvalidCredentials(t *testing.T) {
+	g := gomega.NewWithT(t)
+	_, server := newFakeHashicorpVault(t, "", 3600)
+
+	config := NewFakeHashicorpVaultConfig(server)
+	config.AppRoleSecretID = "wrong"
+	svc, err := NewHashicorpVaultService(config)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	_, err = svc.GetSecretString("secret")
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err).ToNot(gomega.Equal(NotFound))
+}
+
+func TestConfig_ValidateHashicorp(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(config *Config)
+		wantErr bool
+	}{
+		{
+			name: "should accept an approle config",
+		},
+		{
+			name: "should accept a kubernetes config",
+			modify: func(config *Config) {
+				config.AuthMethod = AuthMethodKubernetes
+				config.KubernetesRole = fakeHashicorpKubernetesRole
+			},
+		},
+		{
+			name:    "should reject a config without address",
+			modify:  func(config *Config) { config.Address = "" },
+			wantErr: true,
+		},
+		{
+			name:    "should reject a kubernetes config without role",
+			modify:  func(config *Config) { config.AuthMethod = AuthMethodKubernetes },
+			wantErr: true,
+		},
+		{
+			name:    "should reject an unknown auth method",
+			modify:  func(config *Config) { config.AuthMethod = "userpass" },
+			wantErr: true,
+		},
+		{
+			name: "should reject an empty secret prefix",
+			modify: func(config *Config) {
+				config.SecretPrefixEnable = true
+				config.SecretPrefix = ""
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := NewConfig()
+			config.Kind = KindHashicorp
+			config.Address = "https://vault.example.com:8200"
+			if tt.modify != nil {
+				tt.modify(config)
+			}
+			g.Expect(config.Validate(nil) != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
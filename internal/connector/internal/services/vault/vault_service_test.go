@@ -61,6 +61,10 @@ func TestNewVaultService(t *testing.T) {
 			skip: vc.Kind != vault.KindAws,
 			name: vault.KindAws + "-with-prefix",
 		},
+		{
+			config: vault.NewFakeHashicorpVaultConfig(vault.NewFakeHashicorpVaultServer(t)),
+			name:   vault.KindHashicorp,
+		},
 		{
 			config:       &vault.Config{Kind: "wrong"},
 			wantErrOnNew: true,
@@ -3,11 +3,14 @@ package workers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/phase"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
@@ -22,20 +25,32 @@ import (
 // ConnectorManager represents a connector manager that periodically reconciles connector requests
 type ConnectorManager struct {
 	workers.BaseWorker
-	connectorService        services.ConnectorsService
-	connectorClusterService services.ConnectorClusterService
-	connectorTypesService   services.ConnectorTypesService
-	vaultService            vault.VaultService
-	lastVersion             int64
-	db                      *db.ConnectionFactory
-	ctx                     context.Context
+	connectorService          services.ConnectorsService
+	connectorClusterService   services.ConnectorClusterService
+	connectorNamespaceService services.ConnectorNamespaceService
+	connectorTypesService     services.ConnectorTypesService
+	vaultService              vault.VaultService
+	lastVersion               int64
+	db                        *db.ConnectionFactory
+	ctx                       context.Context
 }
 
+const (
+	connectorRestartAttemptCondition   = "RestartAttempt"
+	connectorRestartExhaustedCondition = "RestartPolicyExhausted"
+
+	// maxRecordedRestartAttempts caps the restart attempts recorded in the status conditions of a connector whose
+	// restart policy has no maximum number of attempts, the delay between attempts has then long reached its maximum
+	// for the default backoff
+	maxRecordedRestartAttempts = 10
+)
+
 // NewConnectorManager creates a new connector manager
 func NewConnectorManager(
 	connectorTypesService services.ConnectorTypesService,
 	connectorService services.ConnectorsService,
 	connectorClusterService services.ConnectorClusterService,
+	connectorNamespaceService services.ConnectorNamespaceService,
 	vaultService vault.VaultService,
 	db *db.ConnectionFactory,
 	reconciler workers.Reconciler,
@@ -46,11 +61,12 @@ func NewConnectorManager(
 			WorkerType: "connector",
 			Reconciler: reconciler,
 		},
-		connectorService:        connectorService,
-		connectorClusterService: connectorClusterService,
-		connectorTypesService:   connectorTypesService,
-		vaultService:            vaultService,
-		db:                      db,
+		connectorService:          connectorService,
+		connectorClusterService:   connectorClusterService,
+		connectorNamespaceService: connectorNamespaceService,
+		connectorTypesService:     connectorTypesService,
+		vaultService:              vaultService,
+		db:                        db,
 	}
 
 	return result
@@ -95,6 +111,11 @@ func (k *ConnectorManager) Reconcile() []error {
 		"desired_state = ? AND phase IN ?", dbapi.ConnectorDeleted,
 		[]string{string(dbapi.ConnectorStatusPhaseAssigning), string(dbapi.ConnectorStatusPhaseDeleted)})
 
+	// reconcile failed connectors in "ready" desired state with an "on_failure" restart policy
+	k.doReconcile(&errs, "failed", k.reconcileFailed,
+		"desired_state = ? AND phase = ? AND restart_policy_type = ? AND connectors.namespace_id IS NOT NULL",
+		dbapi.ConnectorReady, dbapi.ConnectorStatusPhaseFailed, dbapi.ConnectorRestartPolicyOnFailure)
+
 	// reconcile connector updates for assigned connectors that aren't being deleted...
 	k.doReconcile(&errs, "updated", k.reconcileConnectorUpdate,
 		"version > ? AND phase NOT IN ?", k.lastVersion,
@@ -232,6 +253,83 @@ func (k *ConnectorManager) reconcileDeleted(ctx context.Context, connector *dbap
 	return nil
 }
 
+// reconcileFailed restarts a failed connector according to its restart policy, or stops it once the policy is exhausted.
+// The restart attempts are recorded in the connector status conditions, which are reset when the connector is updated
+// or once it is ready again.
+func (k *ConnectorManager) reconcileFailed(ctx context.Context, connector *dbapi.Connector) error {
+	var conditions []dbapi.Condition
+	if len(connector.Status.Conditions) > 0 {
+		if err := json.Unmarshal(connector.Status.Conditions, &conditions); err != nil {
+			return errors.Wrapf(err, "failed to read status conditions of connector %s", connector.ID)
+		}
+	}
+	var attempts []time.Time
+	for _, c := range conditions {
+		if c.Type != connectorRestartAttemptCondition {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, c.LastTransitionTime)
+		if err != nil {
+			return errors.Wrapf(err, "invalid restart attempt time in status conditions of connector %s", connector.ID)
+		}
+		attempts = append(attempts, t)
+	}
+
+	now := time.Now().UTC()
+	next, restart := phase.NextRestartAttempt(connector.RestartPolicy, attempts)
+	if restart && now.Before(next) {
+		// we will try to restart the connector again in the next reconcile
+		return nil
+	}
+
+	condition := dbapi.Condition{
+		Status:             "True",
+		LastTransitionTime: now.Format(time.RFC3339),
+	}
+	if restart {
+		// restarting a ready connector is not a desired state change, only redeploy it
+		connector.Status.Phase = phase.ConnectorStartingPhase[phase.RestartConnector]
+		condition.Type = connectorRestartAttemptCondition
+		condition.Reason = "ConnectorFailed"
+		if connector.RestartPolicy.MaxAttempts > 0 {
+			condition.Message = fmt.Sprintf("restart attempt %d of %d", len(attempts)+1, connector.RestartPolicy.MaxAttempts)
+		} else {
+			condition.Message = "restart attempt, the connector is restarted until it recovers"
+		}
+	} else {
+		namespace, serr := k.connectorNamespaceService.Get(ctx, *connector.NamespaceId)
+		if serr != nil {
+			return errors.Wrapf(serr, "failed to get namespace %s for connector %s", *connector.NamespaceId, connector.ID)
+		}
+		if _, serr = phase.PerformConnectorOperation(namespace, connector, phase.StopConnector); serr != nil {
+			return errors.Wrapf(serr, "failed to stop connector %s", connector.ID)
+		}
+		condition.Type = connectorRestartExhaustedCondition
+		condition.Reason = "MaxAttemptsReached"
+		condition.Message = fmt.Sprintf("connector stopped after %d failed restart attempts", len(attempts))
+	}
+
+	var err error
+	conditions = append(conditions, condition)
+	if connector.RestartPolicy.MaxAttempts == 0 && len(conditions) > maxRecordedRestartAttempts {
+		conditions = conditions[len(conditions)-maxRecordedRestartAttempts:]
+	}
+	if connector.Status.Conditions, err = json.Marshal(conditions); err != nil {
+		return errors.Wrapf(err, "failed to write status conditions of connector %s", connector.ID)
+	}
+	if err := k.connectorService.SaveStatus(ctx, connector.Status); err != nil {
+		return errors.Wrapf(err, "failed to update status of failed connector %s", connector.ID)
+	}
+
+	// the update bumps the connector version, which redeploys the connector
+	if err := k.db.New().Model(&dbapi.Connector{}).Where("id = ?", connector.ID).
+		Update("desired_state", connector.DesiredState).Error; err != nil {
+		return errors.Wrapf(err, "failed to update desired state of failed connector %s", connector.ID)
+	}
+
+	return nil
+}
+
 func (k *ConnectorManager) reconcileConnectorUpdate(ctx context.Context, connector *dbapi.Connector) (err error) {
 
 	// Get the deployment for the connector...
@@ -22,13 +22,16 @@ import (
 
 const checkCatalogEntriesDuration = 5 * time.Second
 
-// ConnectorTypeManager represents a connector manager that reconciles connector types at startup
+// ConnectorTypeManager represents a connector manager that reconciles connector types at startup,
+// and when the remote connector catalog sources change
 type ConnectorTypeManager struct {
 	workers.BaseWorker
 	connectorClusterService services.ConnectorClusterService
 	connectorTypesService   services.ConnectorTypesService
+	connectorsConfig        *config.ConnectorsConfig
 	startupReconcileDone    bool
 	startupReconcileWG      sync.WaitGroup
+	lastCatalogReload       time.Time
 }
 
 // NewApiServerReadyCondition is used to inject a server.ApiServerReadyCondition into the server.ApiServer
@@ -46,6 +49,7 @@ func NewConnectorTypeManager(
 	db *db.ConnectionFactory,
 	reconciler workers.Reconciler,
 	env *environments.Env,
+	connectorsConfig *config.ConnectorsConfig,
 ) *ConnectorTypeManager {
 	result := &ConnectorTypeManager{
 		BaseWorker: workers.BaseWorker{
@@ -55,8 +59,13 @@ func NewConnectorTypeManager(
 		},
 		connectorClusterService: connectorClusterService,
 		connectorTypesService:   connectorTypesService,
+		connectorsConfig:        connectorsConfig,
 		startupReconcileDone:    false,
 	}
+	// the sources that could not be read when the configuration was loaded are fetched again right after startup
+	if connectorsConfig.CatalogSourcesLoaded() {
+		result.lastCatalogReload = time.Now()
+	}
 
 	// The release of this waiting group signal the http service to start serving request
 	// this needs to be done across multiple instances of fleetmanager running,
@@ -81,9 +90,10 @@ func (k *ConnectorTypeManager) Stop() {
 	k.StopWorker(k)
 }
 
-// HasTerminated indicates whether the worker should be stopped and terminated
+// HasTerminated indicates whether the worker should be stopped and terminated,
+// it keeps running to reload the remote connector catalog sources if any
 func (k *ConnectorTypeManager) HasTerminated() bool {
-	return k.startupReconcileDone
+	return k.startupReconcileDone && len(k.connectorsConfig.ConnectorCatalogSources) == 0
 }
 
 func (k *ConnectorTypeManager) Reconcile() []error {
@@ -97,7 +107,7 @@ func (k *ConnectorTypeManager) Reconcile() []error {
 		}
 
 		// We only need to reconcile channel updates once per process startup since,
-		// configured channel settings are only loaded on startup.
+		// configured channel settings are only loaded on startup, remote catalog sources are reloaded below.
 		// These operations, once completed successfully, make the condition at runStartupReconcileCheckWorker() to pass
 		// practically starting the serving of requests from the service.
 		// IMPORTANT: Everything that should run before the first request is served should happen before this
@@ -111,11 +121,39 @@ func (k *ConnectorTypeManager) Reconcile() []error {
 
 		k.startupReconcileDone = true
 		glog.V(5).Infoln("Catalog updates processed")
+	} else if time.Since(k.lastCatalogReload) >= k.connectorsConfig.ConnectorCatalogReloadInterval {
+		if err := k.reloadConnectorCatalog(); err != nil {
+			return []error{err}
+		}
 	}
 
 	return nil
 }
 
+// reloadConnectorCatalog reloads the remote connector catalog sources, and reconciles the connector types
+// and channels when the catalog changed. The sources that could not be read are reloaded on the next reconcile
+// rather than after the reload interval.
+func (k *ConnectorTypeManager) reloadConnectorCatalog() error {
+	changed, sourceErr := k.connectorsConfig.ReloadCatalogSources()
+	if sourceErr == nil {
+		k.lastCatalogReload = time.Now()
+	}
+	if !changed {
+		return sourceErr
+	}
+
+	glog.Infoln("Reconciling connector catalog updates...")
+	if err := k.connectorTypesService.DeleteUnusedAndNotInCatalog(); err != nil {
+		return err
+	}
+	if err := k.connectorTypesService.ForEachConnectorCatalogEntry(k.ReconcileConnectorCatalogEntry); err != nil {
+		return err
+	}
+	glog.Infoln("Connector catalog updates processed")
+
+	return sourceErr
+}
+
 func (k *ConnectorTypeManager) ReconcileConnectorCatalogEntry(id string, channel string, connectorChannelConfig *config.ConnectorChannelConfig) *serviceError.ServiceError {
 
 	connectorShardMetadata := dbapi.ConnectorShardMetadata{
@@ -0,0 +1,84 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+)
+
+var _ workers.Worker = &MeteringManager{}
+
+// MeteringManager samples the running connectors at the configured metering interval to meter their usage,
+// and reports the usage of completed hours
+type MeteringManager struct {
+	workers.BaseWorker
+	connectorUsageService services.ConnectorUsageService
+	connectorsConfig      *config.ConnectorsConfig
+	lastSample            time.Time
+}
+
+func NewMeteringManager(connectorUsageService services.ConnectorUsageService, connectorsConfig *config.ConnectorsConfig,
+	reconciler workers.Reconciler) *MeteringManager {
+	return &MeteringManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "connector_metering",
+			Reconciler: reconciler,
+		},
+		connectorUsageService: connectorUsageService,
+		connectorsConfig:      connectorsConfig,
+	}
+}
+
+func (m *MeteringManager) Start() {
+	m.StartWorker(m)
+}
+
+func (m *MeteringManager) Stop() {
+	m.StopWorker(m)
+}
+
+// HasTerminated indicates whether the worker should be stopped and terminated, it stops when metering is disabled
+func (m *MeteringManager) HasTerminated() bool {
+	return m.connectorsConfig.ConnectorMeteringInterval <= 0
+}
+
+func (m *MeteringManager) Reconcile() []error {
+	interval := m.connectorsConfig.ConnectorMeteringInterval
+	now := time.Now()
+	if interval <= 0 || now.Sub(m.lastSample) < interval {
+		return nil
+	}
+
+	// the time elapsed since the previous sample is charged to the running connectors,
+	// it's capped so that a leader change or an outage doesn't charge the time nobody sampled
+	elapsed := now.Sub(m.lastSample)
+	if m.lastSample.IsZero() {
+		elapsed = interval
+	} else if elapsed > 2*interval {
+		elapsed = 2 * interval
+	}
+
+	var errs []error
+	ctx := context.Background()
+	glog.V(5).Infoln("Sampling connector usage...")
+	if count, err := m.connectorUsageService.SampleUsage(ctx, now, elapsed); err != nil {
+		errs = append(errs, err)
+	} else {
+		m.lastSample = now
+		glog.V(5).Infof("Sampled usage of %d connector types in namespaces", count)
+	}
+
+	if count, err := m.connectorUsageService.ReportUsage(ctx, now); err != nil {
+		errs = append(errs, err)
+	} else if count > 0 {
+		glog.V(5).Infof("Reported %d connector usage records", count)
+	}
+
+	return errs
+}
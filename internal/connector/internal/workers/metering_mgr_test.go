@@ -0,0 +1,111 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+)
+
+func TestMeteringManager_Reconcile(t *testing.T) {
+	interval := time.Minute
+
+	tests := []struct {
+		name            string
+		interval        time.Duration
+		sinceLastSample time.Duration
+		sampleErr       *errors.ServiceError
+		reportErr       *errors.ServiceError
+		wantSamples     int
+		wantElapsed     time.Duration
+		wantErrs        int
+		wantLastSample  bool
+	}{
+		{
+			name:           "should charge one interval to the first sample of a leader",
+			interval:       interval,
+			wantSamples:    1,
+			wantElapsed:    interval,
+			wantLastSample: true,
+		},
+		{
+			name:            "should charge the time elapsed since the previous sample",
+			interval:        interval,
+			sinceLastSample: 90 * time.Second,
+			wantSamples:     1,
+			wantElapsed:     90 * time.Second,
+			wantLastSample:  true,
+		},
+		{
+			name:            "should cap the time elapsed after a leader change or an outage",
+			interval:        interval,
+			sinceLastSample: time.Hour,
+			wantSamples:     1,
+			wantElapsed:     2 * interval,
+			wantLastSample:  true,
+		},
+		{
+			name:            "should not sample before the interval elapsed",
+			interval:        interval,
+			sinceLastSample: 30 * time.Second,
+		},
+		{
+			name:     "should not sample when metering is disabled",
+			interval: 0,
+		},
+		{
+			name:        "should keep the previous sample time when the sample fails and still report the usage",
+			interval:    interval,
+			sampleErr:   errors.GeneralError("sample failed"),
+			wantSamples: 1,
+			wantElapsed: interval,
+			wantErrs:    1,
+		},
+		{
+			name:           "should return the error of the report",
+			interval:       interval,
+			reportErr:      errors.GeneralError("report failed"),
+			wantSamples:    1,
+			wantElapsed:    interval,
+			wantErrs:       1,
+			wantLastSample: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			usageService := &services.ConnectorUsageServiceMock{
+				SampleUsageFunc: func(ctx context.Context, now time.Time, elapsed time.Duration) (int64, *errors.ServiceError) {
+					return 1, tt.sampleErr
+				},
+				ReportUsageFunc: func(ctx context.Context, before time.Time) (int64, *errors.ServiceError) {
+					return 0, tt.reportErr
+				},
+			}
+			m := NewMeteringManager(usageService, &config.ConnectorsConfig{ConnectorMeteringInterval: tt.interval}, workers.Reconciler{})
+			var lastSample time.Time
+			if tt.sinceLastSample > 0 {
+				lastSample = time.Now().Add(-tt.sinceLastSample)
+				m.lastSample = lastSample
+			}
+
+			errs := m.Reconcile()
+			g.Expect(errs).To(gomega.HaveLen(tt.wantErrs))
+			g.Expect(usageService.SampleUsageCalls()).To(gomega.HaveLen(tt.wantSamples))
+			g.Expect(usageService.ReportUsageCalls()).To(gomega.HaveLen(tt.wantSamples))
+			if tt.wantSamples > 0 {
+				// the time elapsed since the previous sample grows while the test runs
+				g.Expect(usageService.SampleUsageCalls()[0].Elapsed).To(gomega.BeNumerically("~", tt.wantElapsed, time.Second))
+			}
+			g.Expect(m.lastSample != lastSample).To(gomega.Equal(tt.wantLastSample))
+			g.Expect(m.HasTerminated()).To(gomega.Equal(tt.interval <= 0))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package workers
+
+import (
+	"context"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+)
+
+var _ workers.Worker = &SecretsRotationManager{}
+
+// SecretsRotationManager runs the rotations of the secrets of all the connectors requested through the admin API
+type SecretsRotationManager struct {
+	workers.BaseWorker
+	connectorSecretsService services.ConnectorSecretsService
+}
+
+func NewSecretsRotationManager(connectorSecretsService services.ConnectorSecretsService,
+	reconciler workers.Reconciler) *SecretsRotationManager {
+	return &SecretsRotationManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "connector_secrets_rotation",
+			Reconciler: reconciler,
+		},
+		connectorSecretsService: connectorSecretsService,
+	}
+}
+
+func (m *SecretsRotationManager) Start() {
+	m.StartWorker(m)
+}
+
+func (m *SecretsRotationManager) Stop() {
+	m.StopWorker(m)
+}
+
+func (m *SecretsRotationManager) Reconcile() []error {
+	rotation, err := m.connectorSecretsService.RunSecretsRotation(context.Background())
+	if err != nil {
+		return []error{err}
+	}
+	if rotation != nil {
+		glog.Infof("Completed connector secrets rotation %s, rotated %d secrets of %d connectors",
+			rotation.ID, rotation.Secrets, rotation.Connectors)
+	}
+	return nil
+}
@@ -53,6 +53,10 @@ func serviceProviders() di.Option {
 		di.Provide(services.NewConnectorTypesService, di.As(new(services.ConnectorTypesService))),
 		di.Provide(services.NewConnectorClusterService, di.As(new(services.ConnectorClusterService)), di.As(new(auth.AuthAgentService))),
 		di.Provide(services.NewConnectorNamespaceService, di.As(new(services.ConnectorNamespaceService))),
+		di.Provide(services.NewConnectorSecretsService, di.As(new(services.ConnectorSecretsService))),
+		di.Provide(services.NewConnectorRevisionsService, di.As(new(services.ConnectorRevisionsService))),
+		di.Provide(services.NewConnectorUsageService, di.As(new(services.ConnectorUsageService))),
+		di.Provide(services.NewConnectorUsageReporter),
 		di.Provide(authz.NewAuthZService, di.As(new(authz.AuthZService))),
 		di.Provide(handlers.NewConnectorNamespaceHandler),
 		di.Provide(handlers.NewConnectorAdminHandler),
@@ -64,6 +68,8 @@ func serviceProviders() di.Option {
 		di.Provide(workers.NewClusterManager, di.As(new(coreWorkers.Worker))),
 		di.Provide(workers.NewConnectorManager, di.As(new(coreWorkers.Worker))),
 		di.Provide(workers.NewNamespaceManager, di.As(new(coreWorkers.Worker))),
+		di.Provide(workers.NewMeteringManager, di.As(new(coreWorkers.Worker))),
+		di.Provide(workers.NewSecretsRotationManager, di.As(new(coreWorkers.Worker))),
 		di.Provide(workers.NewApiServerReadyCondition),
 	)
 }
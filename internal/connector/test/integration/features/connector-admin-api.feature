@@ -183,3 +183,177 @@ Feature: connector admin api
     Then the response code should be 404
 
     And UNLOCK--------------------------------------------------------------
+
+  Scenario: Ricky rotates the secrets of a connector and deletes the orphaned secrets
+    Given LOCK--------------------------------------------------------------
+
+    Given a user named "Secret Sam" in organization "13640203"
+    Given I am logged in as "Secret Sam"
+    When I POST path "/v1/kafka_connectors?async=true" with json body:
+      """
+      {
+        "kind": "Connector",
+        "name": "secrets",
+        "connector_type_id": "aws-sqs-source-v1alpha1",
+        "kafka": {
+          "id":"mykafka",
+          "url": "kafka.hostname"
+        },
+        "schema_registry": {
+          "id":"myregistry",
+          "url": "registry.hostname"
+        },
+        "service_account": {
+          "client_secret": "test",
+          "client_id": "myclient"
+        },
+        "connector": {
+            "aws_queue_name_or_arn": "test",
+            "aws_access_key": "test",
+            "aws_secret_key": "test",
+            "aws_region": "east",
+            "kafka_topic": "test"
+        }
+      }
+      """
+    Then the response code should be 202
+    Given I store the ".id" selection from the response as ${connector_id}
+    Given I store the ".resource_version" selection from the response as ${connector_version}
+
+    # the service account secret and the two connector secrets are re-keyed
+    Given I reset the vault counters
+    Given I am logged in as "Ricky Bobby"
+    When I POST path "/v1/admin/kafka_connectors/${connector_id}/secrets/rotate" with json body:
+      """
+      {}
+      """
+    Then the response code should be 202
+    And the ".id" selection from the response should match "${connector_id}"
+    And the vault delete counter should be 3
+    And I run SQL "SELECT count(*) FROM connectors WHERE id = '${connector_id}' AND version > ${connector_version}" gives results:
+      | count |
+      | 1     |
+
+    # the service account is replaced
+    Given I reset the vault counters
+    When I POST path "/v1/admin/kafka_connectors/${connector_id}/secrets/rotate" with json body:
+      """
+      {
+        "service_account": {
+          "client_secret": "rotated",
+          "client_id": "rotatedclient"
+        }
+      }
+      """
+    Then the response code should be 202
+    And the vault delete counter should be 3
+    And I run SQL "SELECT service_account_client_id FROM connectors WHERE id = '${connector_id}'" gives results:
+      | service_account_client_id |
+      | rotatedclient             |
+
+    When I POST path "/v1/admin/kafka_connectors/${connector_id}/secrets/rotate" with json body:
+      """
+      {
+        "service_account": {
+          "client_secret": "",
+          "client_id": "rotatedclient"
+        }
+      }
+      """
+    Then the response code should be 400
+
+    # the secrets of all the connectors are rotated in the background
+    When I POST path "/v1/admin/kafka_connector_secrets/rotate"
+    Then the response code should be 202
+    Given I store the ".id" selection from the response as ${rotation_id}
+    Given I wait up to "10" seconds for a GET on path "/v1/admin/kafka_connector_secrets/rotations/${rotation_id}" response ".status" selection to match "completed"
+    When I GET path "/v1/admin/kafka_connector_secrets/rotations/${rotation_id}"
+    Then the response code should be 200
+    And the ".connectors >= 1" selection from the response should match "true"
+    And I run SQL "SELECT count(*) FROM connectors WHERE id = '${connector_id}' AND service_account_client_id = 'rotatedclient'" gives results:
+      | count |
+      | 1     |
+
+    When I GET path "/v1/admin/kafka_connector_secrets/rotations/unknown"
+    Then the response code should be 404
+
+    # soft deleting the connector leaves its secrets in the vault
+    When I run SQL "UPDATE connectors SET deleted_at = now() WHERE id = '${connector_id}';" expect 1 row to be affected.
+    Given I reset the vault counters
+    When I POST path "/v1/admin/kafka_connector_secrets/gc?dry_run=true"
+    Then the response code should be 200
+    And the ".orphaned_secrets | length >= 3" selection from the response should match "true"
+    And the vault delete counter should be 0
+
+    When I POST path "/v1/admin/kafka_connector_secrets/gc"
+    Then the response code should be 200
+    And the ".orphaned_secrets | length >= 3" selection from the response should match "true"
+    And the ".errors" selection from the response should match "null"
+
+    When I POST path "/v1/admin/kafka_connector_secrets/gc?dry_run=true"
+    Then the response code should be 200
+    And the ".orphaned_secrets | length" selection from the response should match "0"
+
+    Given I am logged in as "Regular Bob"
+    When I POST path "/v1/admin/kafka_connector_secrets/gc?dry_run=true"
+    Then the response code should be 404
+
+    And UNLOCK--------------------------------------------------------------
+
+  Scenario: Ricky exports the connector usage for billing
+    Given LOCK--------------------------------------------------------------
+
+    Given I run SQL "INSERT INTO connector_usages (organisation_id, namespace_id, connector_type_id, period_start, connector_seconds, samples, reported, created_at, updated_at) VALUES ('13640290', 'usage-namespace', 'aws-sqs-source-v1alpha1', '2030-01-01T10:00:00Z', 3600, 60, false, now(), now()), ('13640290', 'usage-namespace', 'aws-sqs-source-v1alpha1', '2030-01-01T11:00:00Z', 1800, 30, false, now(), now());" expect 2 row to be affected.
+
+    Given I am logged in as "Ricky Bobby"
+    When I GET path "/v1/admin/kafka_connector_usage?organisation_id=13640290&from=2030-01-01T00:00:00Z&to=2030-01-02T00:00:00Z"
+    Then the response code should be 200
+    And the response should match json:
+      """
+      {
+        "kind": "ConnectorUsageList",
+        "page": 1,
+        "size": 2,
+        "total": 2,
+        "items": [
+          {
+            "organisation_id": "13640290",
+            "namespace_id": "usage-namespace",
+            "connector_type_id": "aws-sqs-source-v1alpha1",
+            "period_start": "2030-01-01T10:00:00Z",
+            "connector_hours": 1
+          },
+          {
+            "organisation_id": "13640290",
+            "namespace_id": "usage-namespace",
+            "connector_type_id": "aws-sqs-source-v1alpha1",
+            "period_start": "2030-01-01T11:00:00Z",
+            "connector_hours": 0.5
+          }
+        ]
+      }
+      """
+
+    # the csv export sums the usage of the hours of the requested period, the empty line ends the last record
+    When I GET path "/v1/admin/kafka_connector_usage?organisation_id=13640290&period=day&format=csv&from=2030-01-01T00:00:00Z&to=2030-01-02T00:00:00Z"
+    Then the response code should be 200
+    And the response header "Content-Type" should match "text/csv"
+    And the response should match:
+      """
+      organisation_id,namespace_id,connector_type_id,period_start,connector_hours
+      13640290,usage-namespace,aws-sqs-source-v1alpha1,2030-01-01T00:00:00Z,1.5
+
+      """
+
+    When I GET path "/v1/admin/kafka_connector_usage?format=csv&from=2030-01-01"
+    Then the response code should be 400
+    When I GET path "/v1/admin/kafka_connector_usage?format=csv&from=2030-01-02T00:00:00Z&to=2030-01-01T00:00:00Z"
+    Then the response code should be 400
+
+    Given I am logged in as "Regular Bob"
+    When I GET path "/v1/admin/kafka_connector_usage?format=csv"
+    Then the response code should be 404
+
+    And I run SQL "DELETE FROM connector_usages WHERE organisation_id = '13640290';" expect 2 row to be affected.
+
+    And UNLOCK--------------------------------------------------------------
@@ -173,7 +173,7 @@ Feature: connector agent API
     """
 
     # agent and public APIs should be compatible at this stage, the only expected differences
-    # are about kind and href
+    # are about kind, href and the resources used by the connectors in the namespace
 
     Given I am logged in as "Jimmy"
 
@@ -192,6 +192,10 @@ Feature: connector agent API
       "name": "default-connector-namespace",
       "owner": "${response.owner}",
       "quota": {},
+      "resources": {
+        "used": { "connectors": 1, "cpu_limits": "0", "cpu_requests": "0", "memory_limits": "0", "memory_requests": "0" },
+        "available": {}
+      },
       "resource_version": ${response.resource_version},
       "status": {
         "connectors_deployed": 0,
@@ -1161,6 +1165,10 @@ Feature: connector agent API
       "name": "default-connector-namespace",
       "owner": "${response.owner}",
       "quota": {},
+      "resources": {
+        "used": { "connectors": 1, "cpu_limits": "0", "cpu_requests": "0", "memory_limits": "0", "memory_requests": "0" },
+        "available": {}
+      },
       "resource_version": ${response.resource_version},
       "status": {
         "connectors_deployed": 1,
@@ -1631,6 +1639,120 @@ Feature: connector agent API
       }
       """
 
+    #-----------------------------------------------------------------------------------------------------------------
+    # In this part of the Scenario we test the deprecation of a connector channel and the migration of its connectors
+    #-----------------------------------------------------------------------------------------------------------------
+    Given I run SQL "INSERT INTO connector_type_deprecations (connector_type_id, channel, sunset_at, replacement_connector_type_id, replacement_channel, reason) VALUES ('aws-sqs-source-v1alpha1', 'stable', '2030-01-01T00:00:00Z', '', 'beta', 'the stable channel is replaced by the beta channel');" expect 1 row to be affected.
+
+    # users are warned about the deprecated channel
+    Given I am logged in as "Jimmy"
+    When I GET path "/v1/kafka_connectors/${connector_id}"
+    Then the response code should be 200
+    And the ".warnings" selection from the response should match json:
+      """
+      [
+        "channel stable of connector type aws-sqs-source-v1alpha1 is deprecated and will be removed after 2030-01-01T00:00:00Z, the connector will be migrated to connector type aws-sqs-source-v1alpha1 channel beta: the stable channel is replaced by the beta channel"
+      ]
+      """
+
+    Then I am logged in as "Ricky Bobby"
+    And I GET path "/v1/admin/kafka_connector_clusters/${connector_cluster_id}/upgrades/deprecation"
+    And the response code should be 200
+    And the response should match json:
+      """
+      {
+       "items":
+          [{
+            "connector_id": "${connector_id}",
+            "namespace_id": "${connector_namespace_id}",
+            "connector_type_id": "aws-sqs-source-v1alpha1",
+            "channel": "stable",
+            "replacement_connector_type_id": "aws-sqs-source-v1alpha1",
+            "replacement_channel": "beta",
+            "sunset_at": "2030-01-01T00:00:00Z"
+          }],
+       "kind": "",
+       "page": 1,
+       "size": 1,
+       "total": 1
+      }
+      """
+    And I store the ".items" selection from the response as ${deprecation_upgrade_items}
+
+    # the deprecation upgrades are paged
+    And I GET path "/v1/admin/kafka_connector_clusters/${connector_cluster_id}/upgrades/deprecation?page=2&size=1"
+    And the response code should be 200
+    And the response should match json:
+      """
+      {
+       "items": [],
+       "kind": "",
+       "page": 2,
+       "size": 0,
+       "total": 1
+      }
+      """
+
+    # outdated and unknown upgrades are rejected, and no connector is migrated
+    Then I PUT path "/v1/admin/kafka_connector_clusters/${connector_cluster_id}/upgrades/deprecation" with json body:
+      """
+      [{
+        "connector_id": "${connector_id}",
+        "namespace_id": "${connector_namespace_id}",
+        "connector_type_id": "aws-sqs-source-v1alpha1",
+        "channel": "stable",
+        "replacement_connector_type_id": "aws-sqs-source-v1alpha1",
+        "replacement_channel": "stable",
+        "sunset_at": "2030-01-01T00:00:00Z"
+      }, {
+        "connector_id": "unknown",
+        "connector_type_id": "aws-sqs-source-v1alpha1",
+        "channel": "stable",
+        "replacement_connector_type_id": "aws-sqs-source-v1alpha1",
+        "replacement_channel": "beta"
+      }]
+      """
+    And the response code should be 409
+    And the ".reason | contains("deprecation upgrade is outdated for connector")" selection from the response should match "true"
+    And the ".reason | contains("deprecation upgrade not available for connector unknown")" selection from the response should match "true"
+    And I run SQL "SELECT channel FROM connectors WHERE id = '${connector_id}'" gives results:
+      | channel |
+      | stable  |
+
+    # Migrate the connector to the replacement channel
+    Then I PUT path "/v1/admin/kafka_connector_clusters/${connector_cluster_id}/upgrades/deprecation" with json body:
+      """
+      ${deprecation_upgrade_items}
+      """
+    And the response code should be 204
+    And the response should match ""
+    And I run SQL "SELECT channel FROM connectors WHERE id = '${connector_id}'" gives results:
+      | channel |
+      | beta    |
+    And I run SQL "SELECT channel FROM connector_revisions WHERE connector_id = '${connector_id}' ORDER BY revision DESC LIMIT 1" gives results:
+      | channel |
+      | beta    |
+
+    # agent should get the shard metadata of the replacement channel
+    Given I am logged in as "Shard"
+    And I set the "Authorization" header to "Bearer ${shard_token}"
+    When I GET path "/v1/agent/kafka_connector_clusters/${connector_cluster_id}/deployments"
+    Then the response code should be 200
+    And the ".items[0].spec.shard_metadata.connector_image" selection from the response should match "quay.io/mock-image:beta"
+
+    # the migrated connector is not deprecated anymore
+    Given I am logged in as "Jimmy"
+    When I GET path "/v1/kafka_connectors/${connector_id}"
+    Then the response code should be 200
+    And the ".warnings" selection from the response should match "null"
+
+    Then I am logged in as "Ricky Bobby"
+    And I GET path "/v1/admin/kafka_connector_clusters/${connector_cluster_id}/upgrades/deprecation"
+    And the response code should be 200
+    And the ".total" selection from the response should match "0"
+
+    Given I run SQL "DELETE FROM connector_type_deprecations WHERE connector_type_id = 'aws-sqs-source-v1alpha1';" expect 1 row to be affected.
+
     #----------------------------------------------------------------------------
     # In this part of the Scenario we test admin connector and namespace deletion
     #----------------------------------------------------------------------------
@@ -1666,6 +1666,49 @@ Feature: create a connector
       }
       """
 
+  Scenario: Gary tries to create a connector with an invalid restart policy
+    Given I am logged in as "Gary"
+    When I POST path "/v1/kafka_connectors?async=true" with json body:
+      """
+      {
+        "kind": "Connector",
+        "name": "example 1",
+        "connector_type_id": "aws-sqs-source-v1alpha1",
+        "kafka": {
+          "id":"mykafka",
+          "url": "kafka.hostname"
+        },
+        "service_account": {
+          "client_secret": "test",
+          "client_id": "myclient"
+        },
+        "restart_policy": {
+          "type": "on_failure",
+          "backoff_seconds": 60,
+          "max_backoff_seconds": 30
+        },
+        "connector": {
+            "aws_queue_name_or_arn": "test",
+            "aws_access_key": "test",
+            "aws_secret_key": "test",
+            "aws_region": "east",
+            "kafka_topic": "test"
+        }
+      }
+      """
+    Then the response code should be 400
+    And the response should match json:
+      """
+      {
+        "code": "CONNECTOR-MGMT-21",
+        "href": "/api/connector_mgmt/v1/errors/21",
+        "id": "21",
+        "kind": "Error",
+        "operation_id": "${response.operation_id}",
+        "reason": "restart_policy.max_backoff_seconds must be greater than or equal to restart_policy.backoff_seconds"
+      }
+      """
+
   Scenario: Gary creates lists and deletes a connector verifying that Evil Bob can't access Garys Connectors
   but Coworker Sally can.
     Given I am logged in as "Gary"
@@ -2673,3 +2716,149 @@ Feature: create a connector
     When I wait up to "10" seconds for a GET on path "/v1/kafka_connectors/${connector_id}" response code to match "410"
     Then I GET path "/v1/kafka_connectors/${connector_id}"
     And the response code should be 410
+
+  Scenario: Tommy lists the revisions of a connector and rolls it back to its first revision
+    Given I am logged in as "Tommy"
+    When I POST path "/v1/kafka_connectors?async=true" with json body:
+      """
+      {
+        "kind": "Connector",
+        "name": "Tommy's revisioned connector",
+        "connector_type_id": "aws-sqs-source-v1alpha1",
+        "kafka": {
+          "id":"mykafka",
+          "url": "kafka.hostname"
+        },
+        "service_account": {
+          "client_secret": "test",
+          "client_id": "myclient"
+        },
+        "connector": {
+            "aws_queue_name_or_arn": "test",
+            "aws_access_key": "test",
+            "aws_secret_key": "test",
+            "aws_region": "east",
+            "kafka_topic": "test"
+        }
+      }
+      """
+    Then the response code should be 202
+    And I store the ".id" selection from the response as ${connector_id}
+
+    Given I set the "Content-Type" header to "application/merge-patch+json"
+    When I PATCH path "/v1/kafka_connectors/${connector_id}" with json body:
+      """
+      {
+        "connector": {
+          "aws_region": "west"
+        }
+      }
+      """
+    Then the response code should be 202
+    And the ".connector.aws_region" selection from the response should match "west"
+
+    When I GET path "/v1/kafka_connectors/${connector_id}/revisions"
+    Then the response code should be 200
+    And the ".kind" selection from the response should match "ConnectorRevisionList"
+    And the ".total" selection from the response should match "2"
+    And the ".items[0].connector.aws_region" selection from the response should match "west"
+    And the ".items[1].connector.aws_region" selection from the response should match "east"
+    And the ".items[1].connector.aws_secret_key" selection from the response should match json:
+      """
+      {}
+      """
+    And I store the ".items[1].revision" selection from the response as ${revision}
+
+    When I POST path "/v1/kafka_connectors/${connector_id}/revisions/${revision}/rollback"
+    Then the response code should be 202
+    And the ".connector.aws_region" selection from the response should match "east"
+
+    When I GET path "/v1/kafka_connectors/${connector_id}/revisions"
+    Then the response code should be 200
+    And the ".total" selection from the response should match "3"
+    And the ".items[0].connector.aws_region" selection from the response should match "east"
+
+    When I POST path "/v1/kafka_connectors/${connector_id}/revisions/0/rollback"
+    Then the response code should be 400
+
+    Given I am logged in as "Evil Bob"
+    When I GET path "/v1/kafka_connectors/${connector_id}/revisions"
+    Then the response code should be 404
+
+    Given I am logged in as "Tommy"
+    When I DELETE path "/v1/kafka_connectors/${connector_id}"
+    Then the response code should be 204
+
+  Scenario: Gary validates connectors without creating them
+    Given I am logged in as "Gary"
+    When I POST path "/v1/kafka_connectors/validate" with json body:
+      """
+      {
+        "kind": "Connector",
+        "name": "example 1",
+        "connector_type_id": "aws-sqs-source-v1alpha1",
+        "kafka": {
+          "id":"mykafka",
+          "url": "kafka.hostname"
+        },
+        "service_account": {
+          "client_secret": "test",
+          "client_id": "myclient"
+        },
+        "connector": {
+            "aws_queue_name_or_arn": "test",
+            "aws_access_key": "test",
+            "aws_secret_key": "test",
+            "aws_region": "east",
+            "kafka_topic": "test"
+        }
+      }
+      """
+    Then the response code should be 200
+    And the ".valid" selection from the response should match "true"
+    And the ".channel" selection from the response should match "stable"
+    And the ".secret_fields" selection from the response should match json:
+      """
+      [ "connector.aws_access_key", "connector.aws_secret_key" ]
+      """
+
+    When I POST path "/v1/kafka_connectors/validate" with json body:
+      """
+      {
+        "kind": "Connector",
+        "name": "example 1",
+        "connector_type_id": "aws-sqs-source-v1alpha1",
+        "kafka": {
+          "id":"mykafka"
+        },
+        "service_account": {
+          "client_secret": "test",
+          "client_id": "myclient"
+        },
+        "connector": {
+            "aws_access_key": "test",
+            "aws_secret_key": { "ref": "hack" },
+            "aws_region": "east",
+            "kafka_topic": "test"
+        }
+      }
+      """
+    Then the response code should be 200
+    And the ".valid" selection from the response should match "false"
+    And the ".errors" selection from the response should match json:
+      """
+      [
+        {
+          "field": "kafka.url",
+          "reason": "kafka.url is not valid. Minimum length 1 is required."
+        },
+        {
+          "field": "connector.aws_queue_name_or_arn",
+          "reason": "aws_queue_name_or_arn is required"
+        },
+        {
+          "field": "connector.aws_secret_key",
+          "reason": "secret field must be set to a string"
+        }
+      ]
+      """
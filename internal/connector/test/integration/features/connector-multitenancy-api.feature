@@ -47,6 +47,12 @@ Feature: connector namespaces API
     Given an org admin user named "El Guapo" in organization "13640231"
     Given I store userid for "El Guapo" as ${guapo_user_id}
 
+    # users in organization 13640232
+    Given an org admin user named "Marie" in organization "13640232"
+
+    # users in organization 13640233
+    Given an org admin user named "Tess" in organization "13640233"
+
     # agent user
     Given a user named "Gru_shard"
 
@@ -250,6 +256,10 @@ Feature: connector namespaces API
         "memory_limits": "2Gi",
         "memory_requests": "1Gi"
       },
+      "resources": {
+        "used": { "cpu_limits": "0", "cpu_requests": "0", "memory_limits": "0", "memory_requests": "0" },
+        "available": { "connectors": 4, "cpu_limits": "2", "cpu_requests": "1", "memory_limits": "2Gi", "memory_requests": "1Gi" }
+      },
       "annotations": {
           "cos.bf2.org/profile": "evaluation-profile"
       },
@@ -308,6 +318,10 @@ Feature: connector namespaces API
              "memory_limits": "2Gi",
              "memory_requests": "1Gi"
            },
+           "resources": {
+             "used": { "cpu_limits": "0", "cpu_requests": "0", "memory_limits": "0", "memory_requests": "0" },
+             "available": { "connectors": 4, "cpu_limits": "2", "cpu_requests": "1", "memory_limits": "2Gi", "memory_requests": "1Gi" }
+           },
            "created_at": "${response.items[0].created_at}",
            "modified_at": "${response.items[0].modified_at}",
            "expiration": "${response.items[0].expiration}",
@@ -446,6 +460,10 @@ Feature: connector namespaces API
            "owner": "${dusty_user_id}",
            "resource_version": ${response.items[0].resource_version},
            "quota": {},
+           "resources": {
+             "used": { "cpu_limits": "0", "cpu_requests": "0", "memory_limits": "0", "memory_requests": "0" },
+             "available": {}
+           },
            "tenant": {
              "kind": "organisation",
              "id": "13640230"
@@ -512,6 +530,10 @@ Feature: connector namespaces API
       "owner": "${dusty_user_id}",
       "resource_version": ${response.resource_version},
       "quota": {},
+      "resources": {
+        "used": { "cpu_limits": "0", "cpu_requests": "0", "memory_limits": "0", "memory_requests": "0" },
+        "available": {}
+      },
       "cluster_id": "${connector_cluster_id}",
       "created_at": "${response.created_at}",
       "modified_at": "${response.modified_at}",
@@ -552,6 +574,10 @@ Feature: connector namespaces API
       "owner": "${lucky_user_id}",
       "resource_version": ${response.resource_version},
       "quota": {},
+      "resources": {
+        "used": { "cpu_limits": "0", "cpu_requests": "0", "memory_limits": "0", "memory_requests": "0" },
+        "available": {}
+      },
       "cluster_id": "${connector_cluster_id}",
       "created_at": "${response.created_at}",
       "modified_at": "${response.modified_at}",
@@ -595,6 +621,10 @@ Feature: connector namespaces API
            "owner": "${dusty_user_id}",
            "resource_version": ${response.items[0].resource_version},
            "quota": {},
+           "resources": {
+             "used": { "cpu_limits": "0", "cpu_requests": "0", "memory_limits": "0", "memory_requests": "0" },
+             "available": {}
+           },
            "tenant": {
              "kind": "organisation",
              "id": "13640230"
@@ -615,6 +645,10 @@ Feature: connector namespaces API
            "owner": "${dusty_user_id}",
            "resource_version": ${response.items[1].resource_version},
            "quota": {},
+           "resources": {
+             "used": { "cpu_limits": "0", "cpu_requests": "0", "memory_limits": "0", "memory_requests": "0" },
+             "available": {}
+           },
            "cluster_id": "${connector_cluster_id}",
            "created_at": "${response.items[1].created_at}",
            "modified_at": "${response.items[1].modified_at}",
@@ -749,6 +783,10 @@ Feature: connector namespaces API
            "owner": "${drnefario_user_id}",
            "resource_version": ${response.items[0].resource_version},
            "quota": {},
+           "resources": {
+             "used": { "cpu_limits": "0", "cpu_requests": "0", "memory_limits": "0", "memory_requests": "0" },
+             "available": {}
+           },
            "tenant": {
              "kind": "organisation",
              "id": "${response.items[0].tenant.id}"
@@ -796,6 +834,10 @@ Feature: connector namespaces API
       "owner": "${<user_id>}",
       "resource_version": ${response.resource_version},
       "quota": {},
+      "resources": {
+        "used": { "cpu_limits": "0", "cpu_requests": "0", "memory_limits": "0", "memory_requests": "0" },
+        "available": {}
+      },
       "cluster_id": "${connector_cluster_id}",
       "created_at": "${response.created_at}",
       "modified_at": "${response.modified_at}",
@@ -836,6 +878,10 @@ Feature: connector namespaces API
            "owner": "${drnefario_user_id}",
            "resource_version": ${response.items[0].resource_version},
            "quota": {},
+           "resources": {
+             "used": { "cpu_limits": "0", "cpu_requests": "0", "memory_limits": "0", "memory_requests": "0" },
+             "available": {}
+           },
            "tenant": {
              "kind": "organisation",
              "id": "${response.items[0].tenant.id}"
@@ -918,6 +964,10 @@ Feature: connector namespaces API
            "owner": "${guapo_user_id}",
            "resource_version": ${response.items[0].resource_version},
            "quota": {},
+           "resources": {
+             "used": { "cpu_limits": "0", "cpu_requests": "0", "memory_limits": "0", "memory_requests": "0" },
+             "available": {}
+           },
            "tenant": {
              "kind": "organisation",
              "id": "13640231"
@@ -1011,6 +1061,10 @@ Feature: connector namespaces API
       "owner": "Ricky Bobby",
       "resource_version": ${response.resource_version},
       "quota": {},
+      "resources": {
+        "used": { "cpu_limits": "0", "cpu_requests": "0", "memory_limits": "0", "memory_requests": "0" },
+        "available": {}
+      },
       "cluster_id": "${connector_cluster_id}",
       "created_at": "${response.created_at}",
       "modified_at": "${response.modified_at}",
@@ -1074,6 +1128,10 @@ Feature: connector namespaces API
            "owner": "${guapo_user_id}",
            "resource_version": ${response.items[0].resource_version},
            "quota": {},
+           "resources": {
+             "used": { "cpu_limits": "0", "cpu_requests": "0", "memory_limits": "0", "memory_requests": "0" },
+             "available": {}
+           },
            "tenant": {
              "kind": "organisation",
              "id": "13640231"
@@ -1105,3 +1163,390 @@ Feature: connector namespaces API
     And I GET path "/v1/kafka_connector_clusters/${connector_cluster_id}"
     Then the response code should be 410
     And I can forget keycloak clientID: ${clientID}
+
+  Scenario: Marie applies the connectors of a namespace
+    Given I am logged in as "Marie"
+    When I POST path "/v1/kafka_connector_clusters" with json body:
+     """
+     {
+      "name": "Marie's Cluster"
+     }
+     """
+    Then the response code should be 202
+    And I store the ".id" selection from the response as ${connector_cluster_id}
+    When I GET path "/v1/kafka_connector_clusters/${connector_cluster_id}/addon_parameters"
+    Then the response code should be 200
+    And get and store access token using the addon parameter response as ${shard_token} and clientID as ${clientID}
+    And I remember keycloak client for cleanup with clientID: ${clientID}
+
+    When I GET path "/v1/kafka_connector_namespaces"
+    Then the response code should be 200
+    And the ".total" selection from the response should match "1"
+    And I store the ".items[0].id" selection from the response as ${namespace_id}
+
+   # a dry run only returns the plan
+    When I POST path "/v1/kafka_connectors/apply?dry_run=true" with json body:
+      """
+      {
+        "connectors": [
+          {
+            "kind": "Connector",
+            "name": "first",
+            "namespace_id": "${namespace_id}",
+            "connector_type_id": "aws-sqs-source-v1alpha1",
+            "kafka": {
+              "id":"mykafka",
+              "url": "kafka.hostname"
+            },
+            "service_account": {
+              "client_secret": "test",
+              "client_id": "myclient"
+            },
+            "connector": {
+                "aws_queue_name_or_arn": "test",
+                "aws_access_key": "test",
+                "aws_secret_key": "test",
+                "aws_region": "east",
+                "kafka_topic": "test"
+            }
+          },
+          {
+            "kind": "Connector",
+            "name": "second",
+            "namespace_id": "${namespace_id}",
+            "connector_type_id": "aws-sqs-source-v1alpha1",
+            "kafka": {
+              "id":"mykafka",
+              "url": "kafka.hostname"
+            },
+            "service_account": {
+              "client_secret": "test",
+              "client_id": "myclient"
+            },
+            "connector": {
+                "aws_queue_name_or_arn": "test",
+                "aws_access_key": "test",
+                "aws_secret_key": "test",
+                "aws_region": "east",
+                "kafka_topic": "test"
+            }
+          }
+        ]
+      }
+      """
+    Then the response code should be 200
+    And the ".dry_run" selection from the response should match "true"
+    And the ".applied" selection from the response should match "false"
+    And the ".items[0].action" selection from the response should match "create"
+    And the ".items[1].action" selection from the response should match "create"
+    When I GET path "/v1/kafka_connectors/?search=namespace_id=${namespace_id}"
+    Then the response code should be 200
+    And the ".total" selection from the response should match "0"
+
+    When I POST path "/v1/kafka_connectors/apply" with json body:
+      """
+      {
+        "connectors": [
+          {
+            "kind": "Connector",
+            "name": "first",
+            "namespace_id": "${namespace_id}",
+            "connector_type_id": "aws-sqs-source-v1alpha1",
+            "kafka": {
+              "id":"mykafka",
+              "url": "kafka.hostname"
+            },
+            "service_account": {
+              "client_secret": "test",
+              "client_id": "myclient"
+            },
+            "connector": {
+                "aws_queue_name_or_arn": "test",
+                "aws_access_key": "test",
+                "aws_secret_key": "test",
+                "aws_region": "east",
+                "kafka_topic": "test"
+            }
+          },
+          {
+            "kind": "Connector",
+            "name": "second",
+            "namespace_id": "${namespace_id}",
+            "connector_type_id": "aws-sqs-source-v1alpha1",
+            "kafka": {
+              "id":"mykafka",
+              "url": "kafka.hostname"
+            },
+            "service_account": {
+              "client_secret": "test",
+              "client_id": "myclient"
+            },
+            "connector": {
+                "aws_queue_name_or_arn": "test",
+                "aws_access_key": "test",
+                "aws_secret_key": "test",
+                "aws_region": "east",
+                "kafka_topic": "test"
+            }
+          }
+        ]
+      }
+      """
+    Then the response code should be 200
+    And the ".dry_run" selection from the response should match "false"
+    And the ".applied" selection from the response should match "true"
+    And I store the ".items[0].id" selection from the response as ${first_id}
+    And I store the ".items[1].id" selection from the response as ${second_id}
+    When I GET path "/v1/kafka_connectors/?search=namespace_id=${namespace_id}"
+    Then the response code should be 200
+    And the ".total" selection from the response should match "2"
+
+   # the connectors that are not desired are only deleted when pruning the namespace
+    When I POST path "/v1/kafka_connectors/apply?dry_run=true" with json body:
+      """
+      {
+        "connectors": []
+      }
+      """
+    Then the response code should be 200
+    And the ".items | length" selection from the response should match "0"
+
+   # the first connector is updated and the second one, that is no longer desired, is deleted
+    When I POST path "/v1/kafka_connectors/apply" with json body:
+      """
+      {
+        "prune": true,
+        "namespace_ids": [ "${namespace_id}" ],
+        "connectors": [
+          {
+            "kind": "Connector",
+            "name": "first",
+            "namespace_id": "${namespace_id}",
+            "connector_type_id": "aws-sqs-source-v1alpha1",
+            "kafka": {
+              "id":"mykafka",
+              "url": "kafka.hostname"
+            },
+            "service_account": {
+              "client_secret": "test",
+              "client_id": "myclient"
+            },
+            "connector": {
+                "aws_queue_name_or_arn": "test",
+                "aws_access_key": "test",
+                "aws_secret_key": "test",
+                "aws_region": "west",
+                "kafka_topic": "test"
+            }
+          }
+        ]
+      }
+      """
+    Then the response code should be 200
+    And the ".applied" selection from the response should match "true"
+    And the ".items[0].action" selection from the response should match "update"
+    And the ".items[0].id" selection from the response should match "${first_id}"
+    And the ".items[1].action" selection from the response should match "delete"
+    And the ".items[1].id" selection from the response should match "${second_id}"
+    When I GET path "/v1/kafka_connectors/${first_id}"
+    Then the response code should be 200
+    And the ".connector.aws_region" selection from the response should match "west"
+    When I GET path "/v1/kafka_connectors/${second_id}"
+    Then the response code should be 200
+    And the ".desired_state" selection from the response should match "deleted"
+
+   # applying the same connectors again leaves them unchanged
+    When I POST path "/v1/kafka_connectors/apply" with json body:
+      """
+      {
+        "connectors": [
+          {
+            "kind": "Connector",
+            "name": "first",
+            "namespace_id": "${namespace_id}",
+            "connector_type_id": "aws-sqs-source-v1alpha1",
+            "kafka": {
+              "id":"mykafka",
+              "url": "kafka.hostname"
+            },
+            "service_account": {
+              "client_secret": "test",
+              "client_id": "myclient"
+            },
+            "connector": {
+                "aws_queue_name_or_arn": "test",
+                "aws_access_key": "test",
+                "aws_secret_key": "test",
+                "aws_region": "west",
+                "kafka_topic": "test"
+            }
+          }
+        ]
+      }
+      """
+    Then the response code should be 200
+    And the ".items[0].action" selection from the response should match "none"
+    And the ".items[0].id" selection from the response should match "${first_id}"
+
+   # applying no connectors deletes all the connectors of the namespace
+    When I POST path "/v1/kafka_connectors/apply" with json body:
+      """
+      {
+        "prune": true,
+        "namespace_ids": [ "${namespace_id}" ],
+        "connectors": []
+      }
+      """
+    Then the response code should be 200
+    And the ".items[0].action" selection from the response should match "delete"
+    And the ".items[0].id" selection from the response should match "${first_id}"
+
+   #cleanup
+    When I DELETE path "/v1/kafka_connector_clusters/${connector_cluster_id}"
+    Then the response code should be 204
+    Given I wait up to "10" seconds for a GET on path "/v1/kafka_connector_clusters/${connector_cluster_id}" response code to match "410"
+    And I can forget keycloak clientID: ${clientID}
+
+  Scenario: Tess can't create or assign connectors over the resources quota of a namespace
+    Given I am logged in as "Tess"
+    When I POST path "/v1/kafka_connector_clusters" with json body:
+     """
+     {
+      "name": "Tess's Cluster"
+     }
+     """
+    Then the response code should be 202
+    And I store the ".id" selection from the response as ${connector_cluster_id}
+    When I GET path "/v1/kafka_connector_clusters/${connector_cluster_id}/addon_parameters"
+    Then the response code should be 200
+    And get and store access token using the addon parameter response as ${shard_token} and clientID as ${clientID}
+    And I remember keycloak client for cleanup with clientID: ${clientID}
+
+   # the evaluation profile allows 1 cpu of requests, a connector of the beta channel requests 600m
+    Given I am logged in as "Ricky Bobby"
+    When I POST path "/v1/admin/kafka_connector_namespaces/" with json body:
+    """
+    {
+      "name": "tess_namespace",
+      "cluster_id": "${connector_cluster_id}",
+      "tenant": {
+        "kind": "organisation",
+        "id": "13640233"
+      },
+      "annotations": {
+        "cos.bf2.org/profile": "evaluation-profile"
+      }
+    }
+    """
+    Then the response code should be 201
+    And I store the ".id" selection from the response as ${namespace_id}
+
+    Given I am logged in as "Tess"
+    When I POST path "/v1/kafka_connectors?async=true" with json body:
+    """
+      {
+        "kind": "Connector",
+        "name": "first",
+        "namespace_id": "${namespace_id}",
+        "channel": "beta",
+        "connector_type_id": "aws-sqs-source-v1alpha1",
+        "kafka": {
+          "id":"mykafka",
+          "url": "kafka.hostname"
+        },
+        "service_account": {
+          "client_secret": "test",
+          "client_id": "myclient"
+        },
+        "connector": {
+            "aws_queue_name_or_arn": "test",
+            "aws_access_key": "test",
+            "aws_secret_key": "test",
+            "aws_region": "east",
+            "kafka_topic": "test"
+        }
+      }
+    """
+    Then the response code should be 202
+    When I GET path "/v1/kafka_connector_namespaces/${namespace_id}"
+    Then the response code should be 200
+    And the ".resources.used.cpu_requests" selection from the response should match "600m"
+    And the ".resources.used.memory_requests" selection from the response should match "256Mi"
+    And the ".resources.available.cpu_requests" selection from the response should match "400m"
+
+   # a second connector would request 1200m
+    When I POST path "/v1/kafka_connectors?async=true" with json body:
+    """
+      {
+        "kind": "Connector",
+        "name": "second",
+        "namespace_id": "${namespace_id}",
+        "channel": "beta",
+        "connector_type_id": "aws-sqs-source-v1alpha1",
+        "kafka": {
+          "id":"mykafka",
+          "url": "kafka.hostname"
+        },
+        "service_account": {
+          "client_secret": "test",
+          "client_id": "myclient"
+        },
+        "connector": {
+            "aws_queue_name_or_arn": "test",
+            "aws_access_key": "test",
+            "aws_secret_key": "test",
+            "aws_region": "east",
+            "kafka_topic": "test"
+        }
+      }
+    """
+    Then the response code should be 403
+    And the ".reason" selection from the response should match "the cpu requests quota 1 of the namespace would be exceeded, 1200m required"
+
+   # nor can it be assigned to the namespace
+    When I POST path "/v1/kafka_connectors?async=true" with json body:
+    """
+      {
+        "kind": "Connector",
+        "name": "unassigned",
+        "desired_state": "unassigned",
+        "namespace_id": "",
+        "channel": "beta",
+        "connector_type_id": "aws-sqs-source-v1alpha1",
+        "kafka": {
+          "id":"mykafka",
+          "url": "kafka.hostname"
+        },
+        "service_account": {
+          "client_secret": "test",
+          "client_id": "myclient"
+        },
+        "connector": {
+            "aws_queue_name_or_arn": "test",
+            "aws_access_key": "test",
+            "aws_secret_key": "test",
+            "aws_region": "east",
+            "kafka_topic": "test"
+        }
+      }
+    """
+    Then the response code should be 202
+    And I store the ".id" selection from the response as ${unassigned_connector_id}
+    Given I set the "Content-Type" header to "application/merge-patch+json"
+    When I PATCH path "/v1/kafka_connectors/${unassigned_connector_id}" with json body:
+    """
+    {
+      "namespace_id": "${namespace_id}",
+      "desired_state": "ready"
+    }
+    """
+    Then the response code should be 403
+    And the ".reason" selection from the response should match "the cpu requests quota 1 of the namespace would be exceeded, 1200m required"
+
+   #cleanup
+    When I DELETE path "/v1/kafka_connectors/${unassigned_connector_id}"
+    Then the response code should be 204
+    When I DELETE path "/v1/kafka_connector_clusters/${connector_cluster_id}"
+    Then the response code should be 204
+    Given I wait up to "10" seconds for a GET on path "/v1/kafka_connector_clusters/${connector_cluster_id}" response code to match "410"
+    And I can forget keycloak clientID: ${clientID}
@@ -1,6 +1,7 @@
 package compat
 
 import (
+	admin "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/private"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
 )
@@ -14,5 +15,15 @@ type PrivateError = private.Error
 type WatchEvent = private.WatchEvent
 type ErrorList = public.ErrorList
 type ObjectReference = public.ObjectReference
+type WebhookEndpoint = public.WebhookEndpoint
+type WebhookEndpointRequest = public.WebhookEndpointRequest
+type WebhookEndpointList = public.WebhookEndpointList
+type WebhookDelivery = public.WebhookDelivery
+type WebhookDeliveryList = public.WebhookDeliveryList
+type ResourceGrant = public.ResourceGrant
+type ResourceGrantRequest = public.ResourceGrantRequest
+type ResourceGrantList = public.ResourceGrantList
+type AuditEvent = admin.AuditEvent
+type AuditEventList = admin.AuditEventList
 
 var ContextAccessToken = public.ContextAccessToken
@@ -55,6 +55,14 @@ const (
 	// AcceptedKafkaMaxRetryDurationWhileWaitingForClusterAssignment the maximum duration, in hours, where KAS Fleet Manager
 	// will retry reconciliation of a Kafka request in an 'accepted' state in order to assign it into a data plane cluster.
 	AcceptedKafkaMaxRetryDurationWhileWaitingForClusterAssignment = 1 * time.Hour
+
+	// SuspendingKafkaMaxDuration the maximum duration a Kafka request is expected to stay in a 'suspending' state
+	// before KAS Fleet Manager reports it as stuck. The Kafka request is never transitioned to 'failed' from this state.
+	SuspendingKafkaMaxDuration = 1 * time.Hour
+
+	// ResumingKafkaMaxDuration the maximum duration a Kafka request may stay in a 'resuming' state
+	// before KAS Fleet Manager marks it as 'failed'
+	ResumingKafkaMaxDuration = 2 * time.Hour
 )
 
 // ordinals - Used to decide if a status comes after or before a given state
@@ -126,3 +134,87 @@ func GetAllStatuses() []string {
 func GetSuspendedStatuses() []string {
 	return []string{KafkaRequestStatusSuspending.String(), KafkaRequestStatusSuspended.String()}
 }
+
+// KafkaMigrationStatus type
+type KafkaMigrationStatus string
+
+const (
+	// KafkaMigrationStatusPending - the migration was requested and is waiting for a target cluster to be assigned
+	KafkaMigrationStatusPending KafkaMigrationStatus = "pending"
+	// KafkaMigrationStatusProvisioning - the ManagedKafka is being installed on the target cluster
+	KafkaMigrationStatusProvisioning KafkaMigrationStatus = "provisioning"
+	// KafkaMigrationStatusSwitchingRoutes - the kafka is ready on the target cluster and its CNAME records are being switched to it
+	KafkaMigrationStatusSwitchingRoutes KafkaMigrationStatus = "switching_routes"
+	// KafkaMigrationStatusDeprovisioningSource - the kafka is assigned to the target cluster and is being removed from the source cluster
+	KafkaMigrationStatusDeprovisioningSource KafkaMigrationStatus = "deprovisioning_source"
+	// KafkaMigrationStatusFailed - the migration failed. The kafka is still served by the source cluster
+	KafkaMigrationStatusFailed KafkaMigrationStatus = "failed"
+)
+
+func (k KafkaMigrationStatus) String() string {
+	return string(k)
+}
+
+// GetInProgressMigrationStatuses returns the migration statuses of a kafka that is being migrated
+func GetInProgressMigrationStatuses() []string {
+	return []string{
+		KafkaMigrationStatusPending.String(),
+		KafkaMigrationStatusProvisioning.String(),
+		KafkaMigrationStatusSwitchingRoutes.String(),
+		KafkaMigrationStatusDeprovisioningSource.String(),
+	}
+}
+
+// KafkaDrRole type
+type KafkaDrRole string
+
+const (
+	// KafkaDrRolePrimary - the kafka serves the clients of its disaster recovery pair
+	KafkaDrRolePrimary KafkaDrRole = "primary"
+	// KafkaDrRoleStandby - the kafka mirrors the topics and consumer groups of the primary of its disaster recovery pair
+	KafkaDrRoleStandby KafkaDrRole = "standby"
+)
+
+func (k KafkaDrRole) String() string {
+	return string(k)
+}
+
+// KafkaUpgradeRolloutStatus type
+type KafkaUpgradeRolloutStatus string
+
+const (
+	// KafkaUpgradeRolloutStatusInProgress - the waves of the rollout are being upgraded one after the other
+	KafkaUpgradeRolloutStatusInProgress KafkaUpgradeRolloutStatus = "in_progress"
+	// KafkaUpgradeRolloutStatusPaused - no further wave is started until the rollout is resumed or rolled back
+	KafkaUpgradeRolloutStatusPaused KafkaUpgradeRolloutStatus = "paused"
+	// KafkaUpgradeRolloutStatusCompleted - all the kafkas targeted by the rollout have been upgraded
+	KafkaUpgradeRolloutStatusCompleted KafkaUpgradeRolloutStatus = "completed"
+	// KafkaUpgradeRolloutStatusRollingBack - the kafkas touched by the rollout are being reverted to their previous versions
+	KafkaUpgradeRolloutStatusRollingBack KafkaUpgradeRolloutStatus = "rolling_back"
+	// KafkaUpgradeRolloutStatusRolledBack - the kafkas touched by the rollout have been reverted to their previous versions
+	KafkaUpgradeRolloutStatusRolledBack KafkaUpgradeRolloutStatus = "rolled_back"
+)
+
+func (k KafkaUpgradeRolloutStatus) String() string {
+	return string(k)
+}
+
+// KafkaUpgradeRolloutTargetStatus type
+type KafkaUpgradeRolloutTargetStatus string
+
+const (
+	// KafkaUpgradeRolloutTargetStatusPending - the wave of the kafka has not been started yet
+	KafkaUpgradeRolloutTargetStatusPending KafkaUpgradeRolloutTargetStatus = "pending"
+	// KafkaUpgradeRolloutTargetStatusUpgrading - the desired versions of the kafka have been set and the data plane is upgrading it
+	KafkaUpgradeRolloutTargetStatusUpgrading KafkaUpgradeRolloutTargetStatus = "upgrading"
+	// KafkaUpgradeRolloutTargetStatusUpgraded - the data plane reports the versions of the rollout for the kafka
+	KafkaUpgradeRolloutTargetStatusUpgraded KafkaUpgradeRolloutTargetStatus = "upgraded"
+	// KafkaUpgradeRolloutTargetStatusFailed - the data plane reported a failure of the kafka while it was being upgraded
+	KafkaUpgradeRolloutTargetStatusFailed KafkaUpgradeRolloutTargetStatus = "failed"
+	// KafkaUpgradeRolloutTargetStatusRolledBack - the desired versions of the kafka have been reverted to their previous values
+	KafkaUpgradeRolloutTargetStatusRolledBack KafkaUpgradeRolloutTargetStatus = "rolled_back"
+)
+
+func (k KafkaUpgradeRolloutTargetStatus) String() string {
+	return string(k)
+}
@@ -0,0 +1,38 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// AuditEvent Create, update or delete request to the APIs
+type AuditEvent struct {
+	Id string `json:"id"`
+	// Username of the user or of the service account that sent the request
+	Actor string `json:"actor"`
+	// Id of the organisation of the resource, or of the actor when the resource could not be looked up
+	OrganisationId string `json:"organisation_id,omitempty"`
+	// Collection of the resource in the path of the request, e.g. kafkas
+	ResourceType string `json:"resource_type"`
+	ResourceId   string `json:"resource_id,omitempty"`
+	Action       string `json:"action"`
+	Method       string `json:"method"`
+	// Path template of the request, e.g. /api/kafkas_mgmt/v1/kafkas/{id}
+	Route string `json:"route"`
+	// Unified diff of the resource before and after the request, when it could be looked up
+	Diff string `json:"diff,omitempty"`
+	// Operation id of the request, also found in the logs
+	RequestId  string    `json:"request_id,omitempty"`
+	StatusCode int32     `json:"status_code"`
+	Outcome    string    `json:"outcome"`
+	CreatedAt  time.Time `json:"created_at,omitempty"`
+}
@@ -56,4 +56,20 @@ type Kafka struct {
 	Namespace                  string                           `json:"namespace,omitempty"`
 	SizeId                     string                           `json:"size_id,omitempty"`
 	MaxDataRetentionSize       SupportedKafkaSizeBytesValueItem `json:"max_data_retention_size,omitempty"`
+	// Status of the migration of the Kafka instance to another data plane cluster. Values: [pending, provisioning, switching_routes, deprovisioning_source, failed]. Not set when the Kafka instance is not being migrated
+	MigrationStatus string `json:"migration_status,omitempty"`
+	// ID of the data plane cluster the Kafka instance is migrated from
+	MigrationSourceClusterId string `json:"migration_source_cluster_id,omitempty"`
+	// ID of the data plane cluster the Kafka instance is migrated to
+	MigrationTargetClusterId string `json:"migration_target_cluster_id,omitempty"`
+	// Strimzi version the Kafka instance will be upgraded to in its next maintenance window
+	PendingStrimziVersion string `json:"pending_strimzi_version,omitempty"`
+	// Kafka version the Kafka instance will be upgraded to in its next maintenance window
+	PendingKafkaVersion string `json:"pending_kafka_version,omitempty"`
+	// Kafka IBP version the Kafka instance will be upgraded to in its next maintenance window
+	PendingKafkaIbpVersion string `json:"pending_kafka_ibp_version,omitempty"`
+	// The role of the Kafka instance in its disaster recovery pair. Values: [primary, standby]. Empty when the Kafka instance is not paired
+	DrRole string `json:"dr_role,omitempty"`
+	// The ID of the other Kafka instance of the disaster recovery pair. Empty when the Kafka instance is not paired
+	DrPeerId string `json:"dr_peer_id,omitempty"`
 }
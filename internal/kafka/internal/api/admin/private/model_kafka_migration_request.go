@@ -0,0 +1,17 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// KafkaMigrationRequest struct for KafkaMigrationRequest
+type KafkaMigrationRequest struct {
+	// ID of the data plane cluster to migrate the Kafka instance to. When not set, the target cluster is selected by the cluster placement strategy
+	TargetClusterId string `json:"target_cluster_id,omitempty"`
+}
@@ -0,0 +1,24 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// KafkaPlacementCandidate struct for KafkaPlacementCandidate
+type KafkaPlacementCandidate struct {
+	ClusterId string `json:"cluster_id"`
+	// Whether the Kafka instance can be placed on the cluster
+	Eligible bool `json:"eligible"`
+	// Why the Kafka instance cannot be placed on the cluster
+	Reason string `json:"reason,omitempty"`
+	// Weighted sum of the individual scores of the cluster
+	Score float64 `json:"score,omitempty"`
+	// Individual, unweighted scores of the cluster keyed by criteria
+	Scores map[string]float64 `json:"scores,omitempty"`
+}
@@ -0,0 +1,25 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// KafkaPlacementDryRunRequest struct for KafkaPlacementDryRunRequest
+type KafkaPlacementDryRunRequest struct {
+	// Name of the cloud provider the Kafka instance would be deployed to. For example aws
+	CloudProvider string `json:"cloud_provider"`
+	// Region of the cloud provider the Kafka instance would be deployed to. For example us-east-1
+	Region string `json:"region"`
+	// Instance type of the Kafka instance. For example standard
+	InstanceType string `json:"instance_type"`
+	// Size of the Kafka instance. For example x1
+	SizeId string `json:"size_id"`
+	// Organisation the Kafka instance would belong to. Used by the organisation specific placement criteria
+	OrganisationId string `json:"organisation_id,omitempty"`
+}
@@ -0,0 +1,21 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// KafkaPlacementDryRunResponse struct for KafkaPlacementDryRunResponse
+type KafkaPlacementDryRunResponse struct {
+	// Name of the cluster placement strategy that took the decision
+	Strategy string `json:"strategy"`
+	// ID of the cluster the Kafka instance would be placed on. Not set when no cluster can host the Kafka instance
+	ClusterId string `json:"cluster_id,omitempty"`
+	// The clusters that were considered
+	Candidates []KafkaPlacementCandidate `json:"candidates"`
+}
@@ -0,0 +1,45 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// KafkaUpgradeRollout struct for KafkaUpgradeRollout
+type KafkaUpgradeRollout struct {
+	Id   string `json:"id"`
+	Kind string `json:"kind"`
+	Href string `json:"href"`
+	// Search query selecting the Kafka instances to upgrade, with the syntax of the search parameter of the list endpoints. Allowed fields are `cloud_provider`, `region`, `cluster_id`, `instance_type`, `size_id`, `organisation_id`, `owner`, `name`, `actual_strimzi_version`, `actual_kafka_version` and `actual_kafka_ibp_version`. Only the ready and suspended Kafka instances are targeted
+	Query string `json:"query"`
+	// Strimzi version to upgrade the Kafka instances to
+	StrimziVersion string `json:"strimzi_version,omitempty"`
+	// Kafka version to upgrade the Kafka instances to
+	KafkaVersion string `json:"kafka_version,omitempty"`
+	// Kafka IBP version to upgrade the Kafka instances to
+	KafkaIbpVersion string `json:"kafka_ibp_version,omitempty"`
+	// Number of Kafka instances upgraded by the first wave
+	CanaryCount int32 `json:"canary_count"`
+	// Percentage of the targeted Kafka instances upgraded by each wave following the canary wave
+	BatchPercentage int32 `json:"batch_percentage"`
+	// Time to wait once all the Kafka instances of a wave have been upgraded before starting the next wave
+	SoakTimeMinutes int32 `json:"soak_time_minutes"`
+	// Status of the rollout. A rollout in progress is paused when one of the Kafka instances it upgrades fails
+	Status string `json:"status"`
+	// Why the rollout has been paused
+	StatusReason string `json:"status_reason,omitempty"`
+	// Index of the wave being upgraded, starting at 0 for the canary wave
+	CurrentWave int32     `json:"current_wave"`
+	TotalWaves  int32     `json:"total_waves"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
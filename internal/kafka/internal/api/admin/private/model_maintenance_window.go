@@ -0,0 +1,33 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// MaintenanceWindow struct for MaintenanceWindow
+type MaintenanceWindow struct {
+	Id   string `json:"id"`
+	Kind string `json:"kind"`
+	Href string `json:"href"`
+	// The day of the week the maintenance window starts on
+	DayOfWeek string `json:"day_of_week"`
+	// The UTC time of the day the maintenance window starts at, in the 'HH:MM' format
+	StartTime string `json:"start_time"`
+	// The duration of the maintenance window in hours, between 1 and 24
+	DurationHours  int32  `json:"duration_hours"`
+	OrganisationId string `json:"organisation_id,omitempty"`
+	// The id of the Kafka instance the maintenance window applies to. It is empty for the maintenance window of an organisation.
+	KafkaId   string    `json:"kafka_id,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,21 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// MaintenanceWindowRequest Weekly time slot during which the upgrades of Kafka instances are rolled out
+type MaintenanceWindowRequest struct {
+	// The day of the week the maintenance window starts on
+	DayOfWeek string `json:"day_of_week"`
+	// The UTC time of the day the maintenance window starts at, in the 'HH:MM' format
+	StartTime string `json:"start_time"`
+	// The duration of the maintenance window in hours, between 1 and 24
+	DurationHours int32 `json:"duration_hours"`
+}
@@ -0,0 +1,23 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// QuotaListBillingModel Quota granted for a billing model of an instance type
+type QuotaListBillingModel struct {
+	// Id of the billing model, e.g. standard, marketplace or enterprise
+	Id string `json:"id"`
+	// Number of streaming units that can be created with the billing model. The max_allowed_instances of the organisation or service account applies when 0
+	MaxAllowedInstances int32 `json:"max_allowed_instances,omitempty"`
+	// Date the quota expires at, in the 'YYYY-MM-DD {+,-}HH:MM' format. The Kafka instances with the billing model are suspended once the quota expired. The quota never expires when omitted
+	ExpirationDate string `json:"expiration_date,omitempty"`
+	// Number of days the Kafka instances are kept suspended after the quota expired before being deleted, unlimited when 0
+	GracePeriodDays int32 `json:"grace_period_days,omitempty"`
+}
@@ -0,0 +1,29 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// QuotaListChange Change of an organisation or a service account of the quota management list
+type QuotaListChange struct {
+	Id           string `json:"id"`
+	ResourceType string `json:"resource_type"`
+	// Id of the organisation or username of the service account
+	ResourceId string `json:"resource_id"`
+	Action     string `json:"action"`
+	// Username of the admin who made the change, or path of the configuration file the entry has been imported from
+	ChangedBy string `json:"changed_by,omitempty"`
+	// The organisation or service account after the change, or before it when it has been deleted
+	Value     map[string]interface{} `json:"value,omitempty"`
+	CreatedAt time.Time              `json:"created_at,omitempty"`
+}
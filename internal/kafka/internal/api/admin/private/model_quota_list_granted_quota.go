@@ -0,0 +1,19 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// QuotaListGrantedQuota Quota granted for an instance type
+type QuotaListGrantedQuota struct {
+	// Id of the instance type, as in the supported instance types
+	InstanceTypeId string `json:"instance_type_id"`
+	// Billing models granted for the instance type, the standard billing model when empty
+	KafkaBillingModels []QuotaListBillingModel `json:"kafka_billing_models,omitempty"`
+}
@@ -0,0 +1,23 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// QuotaListOrganisationRequest Users and quota of an organisation of the quota management list
+type QuotaListOrganisationRequest struct {
+	// Whether any user of the organisation can use its quota when no user is registered
+	AnyUser bool `json:"any_user,omitempty"`
+	// Number of streaming units of each granted billing model that does not set its own limit. The default limit of the service applies when 0
+	MaxAllowedInstances int32 `json:"max_allowed_instances,omitempty"`
+	// Usernames of the users of the organisation allowed to use its quota
+	RegisteredUsers []string `json:"registered_users,omitempty"`
+	// Quota granted per instance type, standard instances with the standard billing model when empty
+	GrantedQuota []QuotaListGrantedQuota `json:"granted_quota,omitempty"`
+}
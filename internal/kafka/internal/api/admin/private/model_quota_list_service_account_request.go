@@ -0,0 +1,19 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// QuotaListServiceAccountRequest Quota of a service account of the quota management list
+type QuotaListServiceAccountRequest struct {
+	// Number of streaming units of each granted billing model that does not set its own limit. The default limit of the service applies when 0
+	MaxAllowedInstances int32 `json:"max_allowed_instances,omitempty"`
+	// Quota granted per instance type, standard instances with the standard billing model when empty
+	GrantedQuota []QuotaListGrantedQuota `json:"granted_quota,omitempty"`
+}
@@ -59,6 +59,27 @@ type KafkaRequest struct {
 	// ExpiresAt contains the timestamp of when a Kafka instance is scheduled to expire.
 	// On expiration, the Kafka instance will be marked for deletion, its status will be set to 'deprovision'.
 	ExpiresAt time.Time `json:"expires_at"`
+	// MigrationStatus is the state of the migration of the kafka to another data plane cluster. It is empty when no migration was requested
+	MigrationStatus string `json:"migration_status" gorm:"index"`
+	// MigrationSourceClusterID is the data plane cluster the kafka is migrated from
+	MigrationSourceClusterID string `json:"migration_source_cluster_id"`
+	// MigrationTargetClusterID is the data plane cluster the kafka is migrated to. Capacity is reserved on it until the migration completes
+	MigrationTargetClusterID string `json:"migration_target_cluster_id"`
+	// MigrationPlacementId is the placement id of the ManagedKafka installed on the migration cluster the kafka is not assigned to.
+	// It is swapped with PlacementId when the kafka is switched over to the target cluster.
+	MigrationPlacementId string `json:"migration_placement_id"`
+	// PendingKafkaVersion, PendingStrimziVersion and PendingKafkaIBPVersion hold the upgrades that are queued until the next
+	// maintenance window of the kafka. They are moved to the desired versions when the window opens.
+	PendingKafkaVersion    string `json:"pending_kafka_version"`
+	PendingStrimziVersion  string `json:"pending_strimzi_version"`
+	PendingKafkaIBPVersion string `json:"pending_kafka_ibp_version"`
+	// DrRole is the role of the kafka in its disaster recovery pair. It is empty when the kafka is not part of a pair
+	DrRole string `json:"dr_role"`
+	// DrPeerKafkaID is the id of the other kafka of the disaster recovery pair of the kafka
+	DrPeerKafkaID string `json:"dr_peer_kafka_id" gorm:"index"`
+	// ResourceVersion is bumped by the database on every change of the kafka request, soft deletes included.
+	// It is used to stream the changes of the kafka requests to watchers.
+	ResourceVersion int64 `json:"resource_version" gorm:"type:bigserial;index"`
 }
 
 type KafkaList []*KafkaRequest
@@ -102,6 +123,28 @@ func (k *KafkaRequest) SetRoutes(routes []DataPlaneKafkaRoute) error {
 	}
 }
 
+// IsMigrating returns true if a ManagedKafka of the kafka is installed, or about to be installed, on a cluster
+// other than the one it is assigned to
+func (k *KafkaRequest) IsMigrating() bool {
+	return k.MigrationTargetClusterID != ""
+}
+
+// IsDrPaired returns whether the kafka is part of a disaster recovery pair
+func (k *KafkaRequest) IsDrPaired() bool {
+	return k.DrPeerKafkaID != ""
+}
+
+// GetMigrationClusterID returns the migration cluster the kafka is not assigned to, or an empty string if there is none
+func (k *KafkaRequest) GetMigrationClusterID() string {
+	if k.MigrationTargetClusterID != "" && k.MigrationTargetClusterID != k.ClusterID {
+		return k.MigrationTargetClusterID
+	}
+	if k.MigrationSourceClusterID != "" && k.MigrationSourceClusterID != k.ClusterID {
+		return k.MigrationSourceClusterID
+	}
+	return ""
+}
+
 // GetExpirationTime returns when the Kafka request will expire based on the
 // provided lifespanSeconds value. lifespanSeconds is assumed to be greater
 // than 0
@@ -109,3 +152,8 @@ func (k *KafkaRequest) GetExpirationTime(lifespanSeconds int) *time.Time {
 	expireTime := k.CreatedAt.Add(time.Duration(lifespanSeconds) * time.Second)
 	return &expireTime
 }
+
+// HasPendingUpgrade returns true if an upgrade of the kafka is queued until its next maintenance window
+func (k *KafkaRequest) HasPendingUpgrade() bool {
+	return k.PendingKafkaVersion != "" || k.PendingStrimziVersion != "" || k.PendingKafkaIBPVersion != ""
+}
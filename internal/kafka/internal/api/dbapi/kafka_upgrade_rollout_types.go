@@ -0,0 +1,130 @@
+package dbapi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"gorm.io/gorm"
+)
+
+// KafkaUpgradeRollout upgrades the kafkas matching a search query to the same versions in waves.
+// The first wave is made of the canary kafkas. Each following wave upgrades a percentage of the targeted kafkas and is only
+// started once the previous wave has been upgraded and has soaked for the configured time.
+type KafkaUpgradeRollout struct {
+	api.Meta
+	// Query is the search query, in the syntax of the 'search' parameter of the list endpoints, selecting the kafkas to upgrade
+	Query           string `json:"query"`
+	StrimziVersion  string `json:"strimzi_version"`
+	KafkaVersion    string `json:"kafka_version"`
+	KafkaIBPVersion string `json:"kafka_ibp_version"`
+	CanaryCount     int    `json:"canary_count"`
+	// BatchPercentage is the percentage of the targeted kafkas upgraded by each wave following the canary wave
+	BatchPercentage int    `json:"batch_percentage"`
+	SoakTimeMinutes int    `json:"soak_time_minutes"`
+	Status          string `json:"status" gorm:"index"`
+	// StatusReason explains why the rollout has been paused
+	StatusReason string `json:"status_reason"`
+	CurrentWave  int    `json:"current_wave"`
+	TotalWaves   int    `json:"total_waves"`
+	// WaveUpgradedAt is the time all the kafkas of the current wave were upgraded at. The soak time of the wave starts then.
+	WaveUpgradedAt *time.Time `json:"wave_upgraded_at"`
+}
+
+func (r *KafkaUpgradeRollout) BeforeCreate(scope *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = api.NewID()
+	}
+	return nil
+}
+
+// Validate checks that the rollout targets at least one version, that its ibp version is not above its kafka version and that
+// the wave settings are within bounds. The versions are checked against the clusters of the targeted kafkas when the rollout is created.
+func (r *KafkaUpgradeRollout) Validate() error {
+	if r.StrimziVersion == "" && r.KafkaVersion == "" && r.KafkaIBPVersion == "" {
+		return fmt.Errorf("at least one of strimzi_version, kafka_version or kafka_ibp_version must be set")
+	}
+	if r.KafkaVersion != "" && r.KafkaIBPVersion != "" {
+		if comparison, err := api.CompareBuildAwareSemanticVersions(r.KafkaIBPVersion, r.KafkaVersion); err != nil {
+			return fmt.Errorf("unable to compare kafka_ibp_version %q with kafka_version %q: %v", r.KafkaIBPVersion, r.KafkaVersion, err)
+		} else if comparison > 0 {
+			return fmt.Errorf("kafka_ibp_version %q must not be above kafka_version %q", r.KafkaIBPVersion, r.KafkaVersion)
+		}
+	}
+	if r.CanaryCount < 0 {
+		return fmt.Errorf("canary_count must not be negative")
+	}
+	if r.BatchPercentage < 1 || r.BatchPercentage > 100 {
+		return fmt.Errorf("batch_percentage must be between 1 and 100")
+	}
+	if r.SoakTimeMinutes < 0 {
+		return fmt.Errorf("soak_time_minutes must not be negative")
+	}
+	return nil
+}
+
+// SoakTimeElapsed returns whether the current wave has been upgraded for at least the soak time of the rollout
+func (r *KafkaUpgradeRollout) SoakTimeElapsed(now time.Time) bool {
+	if r.WaveUpgradedAt == nil {
+		return false
+	}
+	return !now.Before(r.WaveUpgradedAt.Add(time.Duration(r.SoakTimeMinutes) * time.Minute))
+}
+
+// AssignWaves returns the wave of each of the given kafka ids: the first CanaryCount kafkas are in the canary wave, then every
+// wave holds BatchPercentage percent of all the kafkas, rounded up. The returned count is the number of waves.
+func (r *KafkaUpgradeRollout) AssignWaves(kafkaIDs []string) (map[string]int, int) {
+	waves := map[string]int{}
+	if len(kafkaIDs) == 0 {
+		return waves, 0
+	}
+
+	batchSize := (len(kafkaIDs)*r.BatchPercentage + 99) / 100
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	wave := 0
+	canaries := r.CanaryCount
+	if canaries > len(kafkaIDs) {
+		canaries = len(kafkaIDs)
+	}
+	for i := 0; i < canaries; i++ {
+		waves[kafkaIDs[i]] = wave
+	}
+	if canaries > 0 {
+		wave++
+	}
+
+	for i := canaries; i < len(kafkaIDs); i++ {
+		waves[kafkaIDs[i]] = wave + (i-canaries)/batchSize
+	}
+
+	total := wave
+	if len(kafkaIDs) > canaries {
+		total += (len(kafkaIDs) - canaries + batchSize - 1) / batchSize
+	}
+	return waves, total
+}
+
+type KafkaUpgradeRolloutList []*KafkaUpgradeRollout
+
+// KafkaUpgradeRolloutTarget tracks the upgrade of a kafka by a rollout. The desired versions of the kafka before the rollout
+// are recorded so that the rollout can be rolled back.
+type KafkaUpgradeRolloutTarget struct {
+	api.Meta
+	RolloutId                      string `json:"rollout_id" gorm:"index"`
+	KafkaId                        string `json:"kafka_id" gorm:"index"`
+	Wave                           int    `json:"wave"`
+	Status                         string `json:"status"`
+	PreviousDesiredStrimziVersion  string `json:"previous_desired_strimzi_version"`
+	PreviousDesiredKafkaVersion    string `json:"previous_desired_kafka_version"`
+	PreviousDesiredKafkaIBPVersion string `json:"previous_desired_kafka_ibp_version"`
+}
+
+func (t *KafkaUpgradeRolloutTarget) BeforeCreate(scope *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = api.NewID()
+	}
+	return nil
+}
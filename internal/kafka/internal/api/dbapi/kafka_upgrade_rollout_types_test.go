@@ -0,0 +1,98 @@
+package dbapi
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestKafkaUpgradeRollout_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rollout KafkaUpgradeRollout
+		wantErr bool
+	}{
+		{
+			name:    "should accept a valid rollout",
+			rollout: KafkaUpgradeRollout{KafkaVersion: "3.3.0", CanaryCount: 1, BatchPercentage: 25},
+			wantErr: false,
+		},
+		{
+			name:    "should reject a rollout without any version",
+			rollout: KafkaUpgradeRollout{CanaryCount: 1, BatchPercentage: 25},
+			wantErr: true,
+		},
+		{
+			name:    "should reject a batch percentage above 100",
+			rollout: KafkaUpgradeRollout{KafkaVersion: "3.3.0", BatchPercentage: 101},
+			wantErr: true,
+		},
+		{
+			name:    "should reject an ibp version above the kafka version",
+			rollout: KafkaUpgradeRollout{KafkaVersion: "3.2.0", KafkaIBPVersion: "3.3", BatchPercentage: 25},
+			wantErr: true,
+		},
+		{
+			name:    "should reject a negative soak time",
+			rollout: KafkaUpgradeRollout{KafkaVersion: "3.3.0", BatchPercentage: 25, SoakTimeMinutes: -1},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(tt.rollout.Validate() != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func TestKafkaUpgradeRollout_AssignWaves(t *testing.T) {
+	tests := []struct {
+		name           string
+		rollout        KafkaUpgradeRollout
+		kafkaIDs       []string
+		wantWaves      map[string]int
+		wantTotalWaves int
+	}{
+		{
+			name:           "should not assign any wave when no kafka is targeted",
+			rollout:        KafkaUpgradeRollout{CanaryCount: 1, BatchPercentage: 50},
+			kafkaIDs:       []string{},
+			wantWaves:      map[string]int{},
+			wantTotalWaves: 0,
+		},
+		{
+			name:           "should put the canaries in the first wave and split the other kafkas in batches",
+			rollout:        KafkaUpgradeRollout{CanaryCount: 1, BatchPercentage: 40},
+			kafkaIDs:       []string{"a", "b", "c", "d", "e"},
+			wantWaves:      map[string]int{"a": 0, "b": 1, "c": 1, "d": 2, "e": 2},
+			wantTotalWaves: 3,
+		},
+		{
+			name:           "should only create batches when there is no canary",
+			rollout:        KafkaUpgradeRollout{CanaryCount: 0, BatchPercentage: 50},
+			kafkaIDs:       []string{"a", "b", "c"},
+			wantWaves:      map[string]int{"a": 0, "b": 0, "c": 1},
+			wantTotalWaves: 2,
+		},
+		{
+			name:           "should only create the canary wave when all kafkas are canaries",
+			rollout:        KafkaUpgradeRollout{CanaryCount: 5, BatchPercentage: 50},
+			kafkaIDs:       []string{"a", "b"},
+			wantWaves:      map[string]int{"a": 0, "b": 0},
+			wantTotalWaves: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			waves, total := tt.rollout.AssignWaves(tt.kafkaIDs)
+			g.Expect(waves).To(gomega.Equal(tt.wantWaves))
+			g.Expect(total).To(gomega.Equal(tt.wantTotalWaves))
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package dbapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxMaintenanceWindowDurationHours is the longest maintenance window that can be configured
+	MaxMaintenanceWindowDurationHours = 24
+	maintenanceWindowStartTimeLayout  = "15:04"
+)
+
+// MaintenanceWindow is a weekly time slot during which the upgrades of kafkas are allowed to be rolled out.
+// A window is either defined for a single kafka or for all the kafkas of an organisation. The window of a kafka takes
+// precedence over the window of its organisation.
+type MaintenanceWindow struct {
+	api.Meta
+	OrganisationId string `json:"organisation_id" gorm:"index"`
+	// KafkaId is empty when the window applies to all the kafkas of the organisation
+	KafkaId string `json:"kafka_id" gorm:"index"`
+	// DayOfWeek is the lower case english name of the day the window starts on, e.g. 'sunday'
+	DayOfWeek string `json:"day_of_week"`
+	// StartTime is the UTC time of the day the window starts at, in the 'HH:MM' format
+	StartTime     string `json:"start_time"`
+	DurationHours int    `json:"duration_hours"`
+}
+
+func (m *MaintenanceWindow) BeforeCreate(scope *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = api.NewID()
+	}
+	return nil
+}
+
+// ParseMaintenanceWindowDay returns the weekday of the given day name, e.g. 'monday'
+func ParseMaintenanceWindowDay(day string) (time.Weekday, error) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(weekday.String(), day) {
+			return weekday, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("%q is not a valid day of the week", day)
+}
+
+// ParseMaintenanceWindowStartTime returns the number of minutes after midnight UTC of a start time in the 'HH:MM' format
+func ParseMaintenanceWindowStartTime(startTime string) (int, error) {
+	t, err := time.Parse(maintenanceWindowStartTimeLayout, startTime)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid start time, expected format is 'HH:MM'", startTime)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Validate checks that the day, start time and duration of the window are valid
+func (m *MaintenanceWindow) Validate() error {
+	if _, err := ParseMaintenanceWindowDay(m.DayOfWeek); err != nil {
+		return err
+	}
+	if _, err := ParseMaintenanceWindowStartTime(m.StartTime); err != nil {
+		return err
+	}
+	if m.DurationHours < 1 || m.DurationHours > MaxMaintenanceWindowDurationHours {
+		return fmt.Errorf("duration must be between 1 and %d hours", MaxMaintenanceWindowDurationHours)
+	}
+	return nil
+}
+
+// Contains returns true if the given time falls into an occurrence of the window. An invalid window never contains any time.
+func (m *MaintenanceWindow) Contains(t time.Time) bool {
+	weekday, err := ParseMaintenanceWindowDay(m.DayOfWeek)
+	if err != nil {
+		return false
+	}
+	startMinutes, err := ParseMaintenanceWindowStartTime(m.StartTime)
+	if err != nil {
+		return false
+	}
+
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	duration := time.Duration(m.DurationHours) * time.Hour
+	// windows last at most a day, so only an occurrence starting today or yesterday can contain the given time
+	for daysAgo := 0; daysAgo <= 1; daysAgo++ {
+		day := midnight.AddDate(0, 0, -daysAgo)
+		if day.Weekday() != weekday {
+			continue
+		}
+		start := day.Add(time.Duration(startMinutes) * time.Minute)
+		if !t.Before(start) && t.Before(start.Add(duration)) {
+			return true
+		}
+	}
+	return false
+}
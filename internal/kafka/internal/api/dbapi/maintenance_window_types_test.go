@@ -0,0 +1,107 @@
+package dbapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestMaintenanceWindow_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  MaintenanceWindow
+		wantErr bool
+	}{
+		{
+			name:    "should accept a valid window",
+			window:  MaintenanceWindow{DayOfWeek: "Sunday", StartTime: "22:30", DurationHours: 4},
+			wantErr: false,
+		},
+		{
+			name:    "should reject an unknown day",
+			window:  MaintenanceWindow{DayOfWeek: "someday", StartTime: "22:30", DurationHours: 4},
+			wantErr: true,
+		},
+		{
+			name:    "should reject a start time with an invalid format",
+			window:  MaintenanceWindow{DayOfWeek: "sunday", StartTime: "10pm", DurationHours: 4},
+			wantErr: true,
+		},
+		{
+			name:    "should reject a window shorter than an hour",
+			window:  MaintenanceWindow{DayOfWeek: "sunday", StartTime: "22:30", DurationHours: 0},
+			wantErr: true,
+		},
+		{
+			name:    "should reject a window longer than a day",
+			window:  MaintenanceWindow{DayOfWeek: "sunday", StartTime: "22:30", DurationHours: 25},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(tt.window.Validate() != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func TestMaintenanceWindow_Contains(t *testing.T) {
+	// 2023-01-08 is a sunday
+	sundayWindow := MaintenanceWindow{DayOfWeek: "sunday", StartTime: "22:00", DurationHours: 4}
+
+	tests := []struct {
+		name   string
+		window MaintenanceWindow
+		time   time.Time
+		want   bool
+	}{
+		{
+			name:   "should contain the start of the window",
+			window: sundayWindow,
+			time:   time.Date(2023, 1, 8, 22, 0, 0, 0, time.UTC),
+			want:   true,
+		},
+		{
+			name:   "should not contain a time before the start of the window",
+			window: sundayWindow,
+			time:   time.Date(2023, 1, 8, 21, 59, 0, 0, time.UTC),
+			want:   false,
+		},
+		{
+			name:   "should contain a time on the next day when the window spans midnight",
+			window: sundayWindow,
+			time:   time.Date(2023, 1, 9, 1, 30, 0, 0, time.UTC),
+			want:   true,
+		},
+		{
+			name:   "should not contain the end of the window",
+			window: sundayWindow,
+			time:   time.Date(2023, 1, 9, 2, 0, 0, 0, time.UTC),
+			want:   false,
+		},
+		{
+			name:   "should compare times in UTC",
+			window: sundayWindow,
+			time:   time.Date(2023, 1, 8, 18, 0, 0, 0, time.FixedZone("UTC-5", -5*60*60)),
+			want:   true,
+		},
+		{
+			name:   "should not contain any time for an invalid window",
+			window: MaintenanceWindow{DayOfWeek: "someday", StartTime: "22:00", DurationHours: 4},
+			time:   time.Date(2023, 1, 8, 22, 0, 0, 0, time.UTC),
+			want:   false,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(tt.window.Contains(tt.time)).To(gomega.Equal(tt.want))
+		})
+	}
+}
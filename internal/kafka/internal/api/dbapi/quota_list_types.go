@@ -0,0 +1,189 @@
+package dbapi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
+	"gorm.io/gorm"
+)
+
+const (
+	QuotaListResourceOrganisation   = "organisation"
+	QuotaListResourceServiceAccount = "service_account"
+
+	QuotaListChangeCreated = "created"
+	QuotaListChangeUpdated = "updated"
+	QuotaListChangeDeleted = "deleted"
+)
+
+// QuotaListOrganisation is an organisation of the quota management list. The registered users of the organisation, or any
+// of its users when AnyUser is set and no user is registered, can create the instances granted to the organisation.
+type QuotaListOrganisation struct {
+	api.Meta
+	OrganisationId      string `json:"organisation_id" gorm:"uniqueIndex"`
+	AnyUser             bool   `json:"any_user"`
+	MaxAllowedInstances int    `json:"max_allowed_instances"`
+	// RegisteredUsers is the JSON array of the usernames of the users registered in the organisation
+	RegisteredUsers api.JSON `json:"registered_users"`
+	// GrantedQuota is the JSON array of the quota_management.Quota granted to the organisation
+	GrantedQuota api.JSON `json:"granted_quota"`
+}
+
+func (o *QuotaListOrganisation) BeforeCreate(scope *gorm.DB) error {
+	if o.ID == "" {
+		o.ID = api.NewID()
+	}
+	return nil
+}
+
+// NewQuotaListOrganisation returns the quota management list entry of the given organisation
+func NewQuotaListOrganisation(org quota_management.Organisation) (*QuotaListOrganisation, error) {
+	usernames := make([]string, 0, len(org.RegisteredUsers))
+	for _, user := range org.RegisteredUsers {
+		usernames = append(usernames, user.Username)
+	}
+	registeredUsers, err := marshalQuotaListJSON(usernames)
+	if err != nil {
+		return nil, err
+	}
+	grantedQuota, err := marshalGrantedQuota(org.GrantedQuota)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuotaListOrganisation{
+		OrganisationId:      org.Id,
+		AnyUser:             org.AnyUser,
+		MaxAllowedInstances: org.MaxAllowedInstances,
+		RegisteredUsers:     registeredUsers,
+		GrantedQuota:        grantedQuota,
+	}, nil
+}
+
+// Organisation returns the organisation of the quota management list entry
+func (o *QuotaListOrganisation) Organisation() (quota_management.Organisation, error) {
+	org := quota_management.Organisation{
+		Id:                  o.OrganisationId,
+		AnyUser:             o.AnyUser,
+		MaxAllowedInstances: o.MaxAllowedInstances,
+	}
+
+	var usernames []string
+	if err := o.RegisteredUsers.Unmarshal(&usernames); err != nil {
+		return org, fmt.Errorf("invalid registered users of organisation %q: %w", o.OrganisationId, err)
+	}
+	for _, username := range usernames {
+		org.RegisteredUsers = append(org.RegisteredUsers, quota_management.Account{Username: username})
+	}
+	if err := o.GrantedQuota.Unmarshal(&org.GrantedQuota); err != nil {
+		return org, fmt.Errorf("invalid granted quota of organisation %q: %w", o.OrganisationId, err)
+	}
+
+	return org, nil
+}
+
+type QuotaListOrganisationList []*QuotaListOrganisation
+
+// QuotaListServiceAccount is a service account of the quota management list, granted quota regardless of its organisation
+type QuotaListServiceAccount struct {
+	api.Meta
+	Username            string `json:"username" gorm:"uniqueIndex"`
+	MaxAllowedInstances int    `json:"max_allowed_instances"`
+	// GrantedQuota is the JSON array of the quota_management.Quota granted to the service account
+	GrantedQuota api.JSON `json:"granted_quota"`
+}
+
+func (a *QuotaListServiceAccount) BeforeCreate(scope *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = api.NewID()
+	}
+	return nil
+}
+
+// NewQuotaListServiceAccount returns the quota management list entry of the given service account
+func NewQuotaListServiceAccount(account quota_management.Account) (*QuotaListServiceAccount, error) {
+	grantedQuota, err := marshalGrantedQuota(account.GrantedQuota)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuotaListServiceAccount{
+		Username:            account.Username,
+		MaxAllowedInstances: account.MaxAllowedInstances,
+		GrantedQuota:        grantedQuota,
+	}, nil
+}
+
+// Account returns the service account of the quota management list entry
+func (a *QuotaListServiceAccount) Account() (quota_management.Account, error) {
+	account := quota_management.Account{
+		Username:            a.Username,
+		MaxAllowedInstances: a.MaxAllowedInstances,
+	}
+	if err := a.GrantedQuota.Unmarshal(&account.GrantedQuota); err != nil {
+		return account, fmt.Errorf("invalid granted quota of service account %q: %w", a.Username, err)
+	}
+
+	return account, nil
+}
+
+type QuotaListServiceAccountList []*QuotaListServiceAccount
+
+// QuotaListChange records a change of an organisation or a service account of the quota management list for auditing
+type QuotaListChange struct {
+	api.Meta
+	// ResourceType is either QuotaListResourceOrganisation or QuotaListResourceServiceAccount
+	ResourceType string `json:"resource_type" gorm:"index"`
+	// ResourceId is the id of the organisation or the username of the service account
+	ResourceId string `json:"resource_id" gorm:"index"`
+	Action     string `json:"action"`
+	ChangedBy  string `json:"changed_by"`
+	// Value is the JSON of the entry after the change, or before it when it has been deleted
+	Value api.JSON `json:"value"`
+}
+
+func (c *QuotaListChange) BeforeCreate(scope *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = api.NewID()
+	}
+	return nil
+}
+
+type QuotaListChangeList []*QuotaListChange
+
+// ValidateGrantedQuota checks that the instance types and billing models of the granted quota are identified and that
+// their limits are not negative
+func ValidateGrantedQuota(grantedQuota quota_management.QuotaList) error {
+	for _, quota := range grantedQuota {
+		if quota.InstanceTypeID == "" {
+			return fmt.Errorf("instance_type_id of granted quota must not be empty")
+		}
+		for _, bm := range quota.KafkaBillingModels {
+			if bm.Id == "" {
+				return fmt.Errorf("id of billing model of instance type %q must not be empty", quota.InstanceTypeID)
+			}
+			if bm.MaxAllowedInstances < 0 || bm.GracePeriodDays < 0 {
+				return fmt.Errorf("max_allowed_instances and grace_period_days of billing model %q of instance type %q must not be negative", bm.Id, quota.InstanceTypeID)
+			}
+		}
+	}
+	return nil
+}
+
+// marshalGrantedQuota stores an empty granted quota as an empty array, since null JSON columns can't be scanned
+func marshalGrantedQuota(grantedQuota quota_management.QuotaList) (api.JSON, error) {
+	if grantedQuota == nil {
+		grantedQuota = quota_management.QuotaList{}
+	}
+	return marshalQuotaListJSON(grantedQuota)
+}
+
+func marshalQuotaListJSON(v interface{}) (api.JSON, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return api.JSON(b), nil
+}
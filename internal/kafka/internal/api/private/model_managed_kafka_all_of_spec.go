@@ -18,4 +18,5 @@ type ManagedKafkaAllOfSpec struct {
 	Endpoint        ManagedKafkaAllOfSpecEndpoint          `json:"endpoint,omitempty"`
 	Versions        ManagedKafkaVersions                   `json:"versions,omitempty"`
 	Deleted         bool                                   `json:"deleted"`
+	MirrorMaker2    *ManagedKafkaAllOfSpecMirrorMaker2     `json:"mirrorMaker2,omitempty"`
 }
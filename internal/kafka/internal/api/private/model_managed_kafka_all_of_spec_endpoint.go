@@ -11,6 +11,8 @@ package private
 
 // ManagedKafkaAllOfSpecEndpoint struct for ManagedKafkaAllOfSpecEndpoint
 type ManagedKafkaAllOfSpecEndpoint struct {
-	BootstrapServerHost string                            `json:"bootstrapServerHost,omitempty"`
-	Tls                 *ManagedKafkaAllOfSpecEndpointTls `json:"tls,omitempty"`
+	BootstrapServerHost string `json:"bootstrapServerHost,omitempty"`
+	// The hosts served by the kafka in addition to its bootstrap host, that must be in the SANs of its certificate. Set to the bootstrap host of the primary on the standby of a disaster recovery pair
+	AdditionalBootstrapServerHosts []string                          `json:"additionalBootstrapServerHosts,omitempty"`
+	Tls                            *ManagedKafkaAllOfSpecEndpointTls `json:"tls,omitempty"`
 }
@@ -0,0 +1,22 @@
+/*
+ * Kafka Service Fleet Manager
+ *
+ * Kafka Service Fleet Manager APIs that are used by internal services e.g kas-fleetshard operators.
+ *
+ * API version: 1.7.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ManagedKafkaAllOfSpecMirrorMaker2 The MirrorMaker2 replication of a disaster recovery standby from its primary. Only set on the standby of a disaster recovery pair
+type ManagedKafkaAllOfSpecMirrorMaker2 struct {
+	SourceClusterAlias        string                                                 `json:"sourceClusterAlias,omitempty"`
+	SourceBootstrapServerHost string                                                 `json:"sourceBootstrapServerHost,omitempty"`
+	Topics                    string                                                 `json:"topics,omitempty"`
+	Groups                    string                                                 `json:"groups,omitempty"`
+	SyncGroupOffsets          bool                                                   `json:"syncGroupOffsets,omitempty"`
+	SourceAuthentication      *ManagedKafkaAllOfSpecMirrorMaker2SourceAuthentication `json:"sourceAuthentication,omitempty"`
+	// The certificate trusted for the TLS connections to the source cluster
+	SourceTlsTrustedCertificate *string `json:"sourceTlsTrustedCertificate,omitempty"`
+}
@@ -0,0 +1,18 @@
+/*
+ * Kafka Service Fleet Manager
+ *
+ * Kafka Service Fleet Manager APIs that are used by internal services e.g kas-fleetshard operators.
+ *
+ * API version: 1.7.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ManagedKafkaAllOfSpecMirrorMaker2SourceAuthentication The SASL OAUTHBEARER authentication to the source cluster
+type ManagedKafkaAllOfSpecMirrorMaker2SourceAuthentication struct {
+	TokenEndpointURI      string  `json:"tokenEndpointURI,omitempty"`
+	ClientId              string  `json:"clientId,omitempty"`
+	ClientSecret          string  `json:"clientSecret,omitempty"`
+	TlsTrustedCertificate *string `json:"tlsTrustedCertificate,omitempty"`
+}
@@ -68,4 +68,8 @@ type KafkaRequest struct {
 	BillingCloudAccountId                 string `json:"billing_cloud_account_id,omitempty"`
 	Marketplace                           string `json:"marketplace,omitempty"`
 	BillingModel                          string `json:"billing_model,omitempty"`
+	// The role of the Kafka instance in its disaster recovery pair. Values: [primary, standby]. Empty when the Kafka instance is not paired
+	DrRole string `json:"dr_role,omitempty"`
+	// The ID of the other Kafka instance of the disaster recovery pair. Empty when the Kafka instance is not paired
+	DrPeerId string `json:"dr_peer_id,omitempty"`
 }
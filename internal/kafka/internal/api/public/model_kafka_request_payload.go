@@ -28,4 +28,6 @@ type KafkaRequestPayload struct {
 	Marketplace *string `json:"marketplace,omitempty"`
 	// billing model to use
 	BillingModel *string `json:"billing_model,omitempty"`
+	// The ID of a ready Kafka instance to create this Kafka instance as its disaster recovery standby. The standby must be in another region or cloud provider and have the same plan as the primary, which replicates its topics and consumer groups to it
+	DrPrimaryId *string `json:"dr_primary_id,omitempty"`
 }
@@ -0,0 +1,21 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.14.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// KafkaRequestWatchEvent A change of a Kafka request streamed to the watchers of the Kafka requests
+type KafkaRequestWatchEvent struct {
+	// The type of the event. One of ADDED, MODIFIED, DELETED or BOOKMARK. A BOOKMARK event is sent once all the changes up to its resource version have been streamed
+	Type string `json:"type"`
+	// The resource version of the Kafka request after the change. Pass it as gt_version to resume the watch after this event
+	ResourceVersion int64         `json:"resource_version,omitempty"`
+	Object          *KafkaRequest `json:"object,omitempty"`
+	Error           *Error        `json:"error,omitempty"`
+}
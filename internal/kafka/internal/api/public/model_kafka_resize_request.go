@@ -0,0 +1,17 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.14.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// KafkaResizeRequest struct for KafkaResizeRequest
+type KafkaResizeRequest struct {
+	// The id of the size the Kafka instance is resized to. The size must be one of the sizes of the instance type of the Kafka instance.
+	SizeId string `json:"size_id"`
+}
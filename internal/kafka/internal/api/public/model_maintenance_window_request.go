@@ -0,0 +1,21 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.14.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// MaintenanceWindowRequest Weekly time slot during which the upgrades of Kafka instances are rolled out
+type MaintenanceWindowRequest struct {
+	// The day of the week the maintenance window starts on
+	DayOfWeek string `json:"day_of_week"`
+	// The UTC time of the day the maintenance window starts at, in the 'HH:MM' format
+	StartTime string `json:"start_time"`
+	// The duration of the maintenance window in hours, between 1 and 24
+	DurationHours int32 `json:"duration_hours"`
+}
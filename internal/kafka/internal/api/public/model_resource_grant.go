@@ -0,0 +1,33 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.14.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// ResourceGrant struct for ResourceGrant
+type ResourceGrant struct {
+	Id   string `json:"id"`
+	Kind string `json:"kind"`
+	Href string `json:"href"`
+	// The username of the user or of the service account the role is granted to
+	Subject string `json:"subject"`
+	// The role granted to the user. The billing and member roles can only be granted on the organisation.
+	Role string `json:"role"`
+	// The type of the resource the role is granted on
+	ResourceType string `json:"resource_type"`
+	// The id of the resource the role is granted on. It is ignored for the grants on the organisation.
+	ResourceId     string    `json:"resource_id,omitempty"`
+	OrganisationId string    `json:"organisation_id,omitempty"`
+	CreatedBy      string    `json:"created_by,omitempty"`
+	CreatedAt      time.Time `json:"created_at,omitempty"`
+}
@@ -0,0 +1,31 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.14.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// WebhookEndpoint struct for WebhookEndpoint
+type WebhookEndpoint struct {
+	Id   string `json:"id"`
+	Kind string `json:"kind"`
+	Href string `json:"href"`
+	// The absolute https URL the events are posted to. It must not target a loopback, private or link local address.
+	Url            string `json:"url"`
+	Description    string `json:"description,omitempty"`
+	OrganisationId string `json:"organisation_id,omitempty"`
+	Owner          string `json:"owner,omitempty"`
+	// The secret used to sign the events delivered to the endpoint. It is only returned when the endpoint is registered.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,18 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.14.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// WebhookEndpointRequest An HTTP endpoint the lifecycle events of the resources of an organisation are delivered to
+type WebhookEndpointRequest struct {
+	// The absolute https URL the events are posted to. It must not target a loopback, private or link local address.
+	Url         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
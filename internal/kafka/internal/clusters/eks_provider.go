@@ -0,0 +1,543 @@
+package clusters
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/clusters/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	awsclient "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// eksDefaultNodegroupName is the name of the node group created alongside every new EKS cluster
+	eksDefaultNodegroupName = "kas-default"
+	// eksManagedByTagKey is the tag added to every EKS resource created by the kas-fleet-manager
+	eksManagedByTagKey = "managed-by"
+)
+
+// eksTaintEffects maps the kubernetes taint effects to the ones used by the EKS API
+var eksTaintEffects = map[string]string{
+	"NoSchedule":       eks.TaintEffectNoSchedule,
+	"PreferNoSchedule": eks.TaintEffectPreferNoSchedule,
+	"NoExecute":        eks.TaintEffectNoExecute,
+}
+
+// eksClusterInfo is the provider specific information stored in the cluster spec of EKS clusters
+type eksClusterInfo struct {
+	Region  string `json:"region"`
+	MultiAZ bool   `json:"multi_az"`
+}
+
+// EKSProvider provisions and manages data plane clusters on AWS EKS.
+// EKS clusters don't come with OpenShift addons, so the operators are installed through OLM the same way as for standalone clusters.
+type EKSProvider struct {
+	eksClientFactory   awsclient.EKSClientFactory
+	connectionFactory  *db.ConnectionFactory
+	awsConfig          *config.AWSConfig
+	eksConfig          *config.EKSConfig
+	standaloneProvider *StandaloneProvider
+}
+
+// blank assignment to verify that EKSProvider implements Provider
+var _ Provider = &EKSProvider{}
+
+func newEKSProvider(eksClientFactory awsclient.EKSClientFactory, connectionFactory *db.ConnectionFactory, awsConfig *config.AWSConfig, eksConfig *config.EKSConfig, dataplaneClusterConfig *config.DataplaneClusterConfig) *EKSProvider {
+	return &EKSProvider{
+		eksClientFactory:   eksClientFactory,
+		connectionFactory:  connectionFactory,
+		awsConfig:          awsConfig,
+		eksConfig:          eksConfig,
+		standaloneProvider: newStandaloneProvider(connectionFactory, dataplaneClusterConfig),
+	}
+}
+
+func (e *EKSProvider) Create(request *types.ClusterRequest) (*types.ClusterSpec, error) {
+	regionConfig, ok := e.eksConfig.GetRegion(request.Region)
+	if !ok {
+		return nil, errors.Errorf("region %s is not configured for EKS clusters", request.Region)
+	}
+	if request.MultiAZ && !regionConfig.SupportsMultiAZ {
+		return nil, errors.Errorf("region %s does not support multi AZ EKS clusters", request.Region)
+	}
+
+	eksClient, err := e.newEKSClient(request.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterName := api.NewID()
+	input := &eks.CreateClusterInput{
+		Name:    aws.String(clusterName),
+		RoleArn: aws.String(e.eksConfig.Configuration.ClusterRoleARN),
+		ResourcesVpcConfig: &eks.VpcConfigRequest{
+			SubnetIds:        aws.StringSlice(regionConfig.SubnetIDs),
+			SecurityGroupIds: aws.StringSlice(regionConfig.SecurityGroupIDs),
+		},
+		Tags: e.tags(),
+	}
+	if e.eksConfig.Configuration.KubernetesVersion != "" {
+		input.Version = aws.String(e.eksConfig.Configuration.KubernetesVersion)
+	}
+
+	if _, err := eksClient.CreateCluster(input); err != nil {
+		return nil, errors.Wrapf(err, "failed to create EKS cluster")
+	}
+
+	clusterInfo, err := json.Marshal(eksClusterInfo{
+		Region:  request.Region,
+		MultiAZ: request.MultiAZ,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal EKS cluster info")
+	}
+
+	return &types.ClusterSpec{
+		InternalID:     clusterName,
+		Status:         api.ClusterProvisioning,
+		AdditionalInfo: clusterInfo,
+	}, nil
+}
+
+// CheckClusterStatus reports the cluster as provisioned once both the control plane and the default node group are active.
+// The default node group is created as soon as the control plane becomes active.
+func (e *EKSProvider) CheckClusterStatus(spec *types.ClusterSpec) (*types.ClusterSpec, error) {
+	eksClient, _, err := e.newEKSClientForSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := eksClient.DescribeCluster(spec.InternalID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get EKS cluster %s", spec.InternalID)
+	}
+	if cluster == nil {
+		spec.Status = api.ClusterFailed
+		spec.StatusDetails = fmt.Sprintf("EKS cluster %s not found", spec.InternalID)
+		return spec, nil
+	}
+
+	if spec.Status == "" {
+		spec.Status = api.ClusterProvisioning
+	}
+
+	switch aws.StringValue(cluster.Status) {
+	case eks.ClusterStatusFailed:
+		spec.Status = api.ClusterFailed
+		spec.StatusDetails = clusterHealthDetails(cluster.Health)
+		return spec, nil
+	case eks.ClusterStatusActive:
+		// the control plane is ready, continue with the default node group
+	default:
+		return spec, nil
+	}
+
+	nodegroup, err := eksClient.DescribeNodegroup(spec.InternalID, eksDefaultNodegroupName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get default node group of EKS cluster %s", spec.InternalID)
+	}
+	if nodegroup == nil {
+		_, err := e.CreateMachinePool(&types.MachinePoolRequest{
+			ID:           eksDefaultNodegroupName,
+			ClusterID:    spec.InternalID,
+			InstanceSize: e.eksConfig.Configuration.DefaultNodegroup.InstanceType,
+			Replicas:     e.eksConfig.Configuration.DefaultNodegroup.NodeCount,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create default node group of EKS cluster %s", spec.InternalID)
+		}
+		return spec, nil
+	}
+
+	switch aws.StringValue(nodegroup.Status) {
+	case eks.NodegroupStatusActive:
+		spec.ExternalID = aws.StringValue(cluster.Arn)
+		spec.Status = api.ClusterProvisioned
+	case eks.NodegroupStatusCreateFailed:
+		spec.Status = api.ClusterFailed
+		spec.StatusDetails = nodegroupHealthDetails(nodegroup.Health)
+	}
+
+	return spec, nil
+}
+
+// Delete removes every node group of the cluster before removing the cluster itself, as EKS refuses to delete a cluster with node groups attached.
+// It returns true once the cluster is gone.
+func (e *EKSProvider) Delete(spec *types.ClusterSpec) (bool, error) {
+	eksClient, _, err := e.newEKSClientForSpec(spec)
+	if err != nil {
+		return false, err
+	}
+
+	nodegroups, err := eksClient.ListNodegroups(spec.InternalID)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to list node groups of EKS cluster %s", spec.InternalID)
+	}
+	if len(nodegroups) > 0 {
+		for _, nodegroup := range nodegroups {
+			if _, err := eksClient.DeleteNodegroup(spec.InternalID, nodegroup); err != nil {
+				return false, errors.Wrapf(err, "failed to delete node group %s of EKS cluster %s", nodegroup, spec.InternalID)
+			}
+		}
+		return false, nil
+	}
+
+	cluster, err := eksClient.DescribeCluster(spec.InternalID)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get EKS cluster %s", spec.InternalID)
+	}
+	if cluster == nil {
+		return true, nil
+	}
+	if aws.StringValue(cluster.Status) == eks.ClusterStatusDeleting {
+		return false, nil
+	}
+
+	cluster, err = eksClient.DeleteCluster(spec.InternalID)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to delete EKS cluster %s", spec.InternalID)
+	}
+	return cluster == nil, nil
+}
+
+// GetClusterDNS returns <cluster name>.<base domain> as EKS doesn't provide a default ingress domain
+func (e *EKSProvider) GetClusterDNS(clusterSpec *types.ClusterSpec) (string, error) {
+	baseDomain := e.eksConfig.Configuration.ClusterBaseDomain
+	if baseDomain == "" {
+		return "", errors.Errorf("failed to get dns for EKS cluster %s: no cluster base domain is configured", clusterSpec.InternalID)
+	}
+	return fmt.Sprintf("%s.%s", clusterSpec.InternalID, baseDomain), nil
+}
+
+// AddIdentityProvider is a noop, EKS clusters don't run the OpenShift OAuth server
+func (e *EKSProvider) AddIdentityProvider(clusterSpec *types.ClusterSpec, identityProvider types.IdentityProviderInfo) (*types.IdentityProviderInfo, error) {
+	return &identityProvider, nil
+}
+
+// ApplyResources applies the resources with the kubernetes API of the cluster.
+// Resources whose kind isn't served by the cluster, e.g. OpenShift specific ones, are skipped and left out of the returned resource set.
+func (e *EKSProvider) ApplyResources(clusterSpec *types.ClusterSpec, resources types.ResourceSet) (*types.ResourceSet, error) {
+	applied, err := e.applyResources(clusterSpec, resources, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &applied, nil
+}
+
+func (e *EKSProvider) InstallStrimzi(clusterSpec *types.ClusterSpec) (bool, error) {
+	err := e.installOperator(clusterSpec, types.ResourceSet{
+		Resources: e.standaloneProvider.buildStrimziOperatorResources(),
+	})
+
+	return err == nil, err
+}
+
+func (e *EKSProvider) InstallClusterLogging(clusterSpec *types.ClusterSpec, params []types.Parameter) (bool, error) {
+	return true, nil // NOOP for now
+}
+
+func (e *EKSProvider) InstallKasFleetshard(clusterSpec *types.ClusterSpec, params []types.Parameter) (bool, error) {
+	err := e.installOperator(clusterSpec, types.ResourceSet{
+		Resources: e.standaloneProvider.buildKASFleetShardOperatorResources(params),
+	})
+
+	return err == nil, err
+}
+
+// installOperator applies the OLM resources of an operator. Unlike ApplyResources, none of them can be skipped:
+// the operator is not installed if the cluster doesn't serve the OLM kinds.
+func (e *EKSProvider) installOperator(clusterSpec *types.ClusterSpec, resources types.ResourceSet) error {
+	_, err := e.applyResources(clusterSpec, resources, false)
+	if err != nil && meta.IsNoMatchError(errors.Cause(err)) {
+		return errors.Wrapf(err, "OLM must be installed on EKS cluster %s to install the operators", clusterSpec.InternalID)
+	}
+	return err
+}
+
+func (e *EKSProvider) applyResources(clusterSpec *types.ClusterSpec, resources types.ResourceSet, skipUnsupportedKinds bool) (types.ResourceSet, error) {
+	eksClient, _, err := e.newEKSClientForSpec(clusterSpec)
+	if err != nil {
+		return types.ResourceSet{}, err
+	}
+
+	restConfig, err := buildEKSRestConfig(eksClient, clusterSpec.InternalID)
+	if err != nil {
+		return types.ResourceSet{}, err
+	}
+
+	applied, err := applyResources(restConfig, resources, skipUnsupportedKinds)
+	if err != nil {
+		return applied, errors.Wrapf(err, "failed to apply resources to EKS cluster %s", clusterSpec.InternalID)
+	}
+
+	return applied, nil
+}
+
+func (e *EKSProvider) GetCloudProviders() (*types.CloudProviderInfoList, error) {
+	items := []types.CloudProviderInfo{}
+	if len(e.eksConfig.Configuration.Regions) > 0 {
+		items = append(items, types.CloudProviderInfo{
+			ID:          "aws",
+			Name:        "aws",
+			DisplayName: "Amazon Web Services",
+		})
+	}
+
+	return &types.CloudProviderInfoList{Items: items}, nil
+}
+
+func (e *EKSProvider) GetCloudProviderRegions(providerInf types.CloudProviderInfo) (*types.CloudProviderRegionInfoList, error) {
+	items := []types.CloudProviderRegionInfo{}
+	if providerInf.ID != "aws" {
+		return &types.CloudProviderRegionInfoList{Items: items}, nil
+	}
+
+	for _, region := range e.eksConfig.Configuration.Regions {
+		items = append(items, types.CloudProviderRegionInfo{
+			ID:              region.Name,
+			Name:            region.Name,
+			DisplayName:     region.Name,
+			SupportsMultiAZ: region.SupportsMultiAZ,
+			CloudProviderID: providerInf.ID,
+		})
+	}
+
+	return &types.CloudProviderRegionInfoList{Items: items}, nil
+}
+
+func (e *EKSProvider) GetMachinePool(clusterID string, id string) (*types.MachinePoolInfo, error) {
+	eksClient, _, err := e.newEKSClientForClusterID(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodegroup, err := eksClient.DescribeNodegroup(clusterID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if nodegroup == nil {
+		return nil, nil
+	}
+
+	var nodeTaints []types.CluserNodeTaint
+	for _, taint := range nodegroup.Taints {
+		nodeTaints = append(nodeTaints, types.CluserNodeTaint{
+			Effect: kubernetesTaintEffect(aws.StringValue(taint.Effect)),
+			Key:    aws.StringValue(taint.Key),
+			Value:  aws.StringValue(taint.Value),
+		})
+	}
+
+	res := &types.MachinePoolInfo{
+		ID:         aws.StringValue(nodegroup.NodegroupName),
+		ClusterID:  clusterID,
+		MultiAZ:    len(nodegroup.Subnets) > 1,
+		NodeLabels: aws.StringValueMap(nodegroup.Labels),
+		NodeTaints: nodeTaints,
+	}
+	if len(nodegroup.InstanceTypes) > 0 {
+		res.InstanceSize = aws.StringValue(nodegroup.InstanceTypes[0])
+	}
+	if scaling := nodegroup.ScalingConfig; scaling != nil {
+		minNodes := int(aws.Int64Value(scaling.MinSize))
+		maxNodes := int(aws.Int64Value(scaling.MaxSize))
+		res.Replicas = int(aws.Int64Value(scaling.DesiredSize))
+		res.AutoScalingEnabled = minNodes != maxNodes
+		if res.AutoScalingEnabled {
+			res.AutoScaling = types.MachinePoolAutoScaling{
+				MinNodes: minNodes,
+				MaxNodes: maxNodes,
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (e *EKSProvider) CreateMachinePool(request *types.MachinePoolRequest) (*types.MachinePoolRequest, error) {
+	eksClient, region, err := e.newEKSClientForClusterID(request.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	regionConfig, ok := e.eksConfig.GetRegion(region)
+	if !ok || len(regionConfig.SubnetIDs) == 0 {
+		return nil, errors.Errorf("error creating MachinePool '%s' for cluster id '%s': no subnets are configured for region %s", request.ID, request.ClusterID, region)
+	}
+
+	scalingConfig := &eks.NodegroupScalingConfig{
+		MinSize:     aws.Int64(int64(request.Replicas)),
+		MaxSize:     aws.Int64(int64(request.Replicas)),
+		DesiredSize: aws.Int64(int64(request.Replicas)),
+	}
+	if request.AutoScalingEnabled {
+		if request.AutoScaling.MinNodes > request.AutoScaling.MaxNodes {
+			return nil, fmt.Errorf("error creating MachinePool '%s' for cluster id '%s': minimum number of nodes cannot be more than maximum number of nodes", request.ID, request.ClusterID)
+		}
+		scalingConfig = &eks.NodegroupScalingConfig{
+			MinSize:     aws.Int64(int64(request.AutoScaling.MinNodes)),
+			MaxSize:     aws.Int64(int64(request.AutoScaling.MaxNodes)),
+			DesiredSize: aws.Int64(int64(request.AutoScaling.MinNodes)),
+		}
+	}
+
+	// single AZ machine pools are placed in the first configured subnet only
+	subnets := regionConfig.SubnetIDs
+	if !request.MultiAZ {
+		subnets = subnets[:1]
+	}
+
+	var taints []*eks.Taint
+	for _, nodeTaint := range request.NodeTaints {
+		effect, ok := eksTaintEffects[nodeTaint.Effect]
+		if !ok {
+			return nil, fmt.Errorf("error creating MachinePool '%s' for cluster id '%s': unsupported taint effect %q", request.ID, request.ClusterID, nodeTaint.Effect)
+		}
+		taints = append(taints, &eks.Taint{
+			Effect: aws.String(effect),
+			Key:    aws.String(nodeTaint.Key),
+			Value:  aws.String(nodeTaint.Value),
+		})
+	}
+
+	input := &eks.CreateNodegroupInput{
+		ClusterName:   aws.String(request.ClusterID),
+		NodegroupName: aws.String(request.ID),
+		NodeRole:      aws.String(e.eksConfig.Configuration.NodeRoleARN),
+		InstanceTypes: aws.StringSlice([]string{request.InstanceSize}),
+		ScalingConfig: scalingConfig,
+		Subnets:       aws.StringSlice(subnets),
+		Taints:        taints,
+		Tags:          e.tags(),
+	}
+	if len(request.NodeLabels) > 0 {
+		input.Labels = aws.StringMap(request.NodeLabels)
+	}
+
+	if _, err := eksClient.CreateNodegroup(input); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// noop method, it will always return a nil slice as EKS resources are not tracked through quota
+func (e *EKSProvider) GetClusterResourceQuotaCosts() ([]types.QuotaCost, error) {
+	var quotaCostList []types.QuotaCost
+	return quotaCostList, nil
+}
+
+func (e *EKSProvider) newEKSClient(region string) (awsclient.EKSClient, error) {
+	eksClient, err := e.eksClientFactory.NewEKSClient(awsclient.Config{
+		AccessKeyID:     e.awsConfig.AccessKey,
+		SecretAccessKey: e.awsConfig.SecretAccessKey,
+	}, region)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create EKS client for region %s", region)
+	}
+	return eksClient, nil
+}
+
+// newEKSClientForSpec returns an EKS client for the region stored in the cluster spec
+func (e *EKSProvider) newEKSClientForSpec(spec *types.ClusterSpec) (awsclient.EKSClient, string, error) {
+	var info eksClusterInfo
+	if err := spec.AdditionalInfo.Unmarshal(&info); err != nil {
+		return nil, "", errors.Wrapf(err, "failed to read EKS cluster info of cluster %s", spec.InternalID)
+	}
+	if info.Region == "" {
+		return e.newEKSClientForClusterID(spec.InternalID)
+	}
+	eksClient, err := e.newEKSClient(info.Region)
+	return eksClient, info.Region, err
+}
+
+// newEKSClientForClusterID returns an EKS client for the region of the cluster with the given id as stored in the database
+func (e *EKSProvider) newEKSClientForClusterID(clusterID string) (awsclient.EKSClient, string, error) {
+	var cluster api.Cluster
+	if err := e.connectionFactory.New().Where("cluster_id = ?", clusterID).First(&cluster).Error; err != nil {
+		return nil, "", errors.Wrapf(err, "failed to find region of EKS cluster %s", clusterID)
+	}
+	eksClient, err := e.newEKSClient(cluster.Region)
+	return eksClient, cluster.Region, err
+}
+
+func (e *EKSProvider) tags() map[string]*string {
+	return aws.StringMap(map[string]string{
+		eksManagedByTagKey: fieldManager,
+	})
+}
+
+func buildEKSRestConfig(eksClient awsclient.EKSClient, clusterName string) (*rest.Config, error) {
+	cluster, err := eksClient.DescribeCluster(clusterName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get EKS cluster %s", clusterName)
+	}
+	if cluster == nil {
+		return nil, errors.Errorf("EKS cluster %s not found", clusterName)
+	}
+
+	var caData []byte
+	if cluster.CertificateAuthority != nil {
+		caData, err = base64.StdEncoding.DecodeString(aws.StringValue(cluster.CertificateAuthority.Data))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode certificate authority of EKS cluster %s", clusterName)
+		}
+	}
+
+	token, err := eksClient.GetClusterToken(clusterName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get token for EKS cluster %s", clusterName)
+	}
+
+	return &rest.Config{
+		Host:        aws.StringValue(cluster.Endpoint),
+		BearerToken: token,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: caData,
+		},
+	}, nil
+}
+
+func kubernetesTaintEffect(eksEffect string) string {
+	for kubernetesEffect, effect := range eksTaintEffects {
+		if effect == eksEffect {
+			return kubernetesEffect
+		}
+	}
+	glog.Warningf("unknown EKS taint effect %q", eksEffect)
+	return eksEffect
+}
+
+func clusterHealthDetails(health *eks.ClusterHealth) string {
+	if health == nil {
+		return ""
+	}
+	var details []string
+	for _, issue := range health.Issues {
+		details = append(details, aws.StringValue(issue.Message))
+	}
+	return strings.Join(details, "; ")
+}
+
+func nodegroupHealthDetails(health *eks.NodegroupHealth) string {
+	if health == nil {
+		return ""
+	}
+	var details []string
+	for _, issue := range health.Issues {
+		details = append(details, aws.StringValue(issue.Message))
+	}
+	return strings.Join(details, "; ")
+}
@@ -0,0 +1,599 @@
+package clusters
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/clusters/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	awsclient "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+const (
+	testEKSClusterName = "test-eks-cluster"
+	testEKSRegion      = "us-east-1"
+)
+
+var testEKSClusterInfo = api.JSON(`{"region":"us-east-1","multi_az":true}`)
+
+func newTestEKSConfig() *config.EKSConfig {
+	c := config.NewEKSConfig()
+	c.Configuration.ClusterRoleARN = "arn:aws:iam::123456789012:role/cluster"
+	c.Configuration.NodeRoleARN = "arn:aws:iam::123456789012:role/node"
+	c.Configuration.ClusterBaseDomain = "eks.example.com"
+	c.Configuration.Regions = []config.EKSRegionConfig{
+		{
+			Name:             testEKSRegion,
+			SubnetIDs:        []string{"subnet-a", "subnet-b", "subnet-c"},
+			SecurityGroupIDs: []string{"sg-a"},
+			SupportsMultiAZ:  true,
+		},
+	}
+	return c
+}
+
+func newTestEKSProvider(eksClient awsclient.EKSClient) *EKSProvider {
+	return newEKSProvider(awsclient.NewMockEKSClientFactory(eksClient), db.NewMockConnectionFactory(nil), &config.AWSConfig{}, newTestEKSConfig(), config.NewDataplaneClusterConfig())
+}
+
+func mockEKSClusterRegionQuery() {
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "clusters"`).WithReply([]map[string]interface{}{{"cluster_id": testEKSClusterName, "region": testEKSRegion}})
+}
+
+func TestEKSProvider_Create(t *testing.T) {
+	tests := []struct {
+		name      string
+		eksClient *awsclient.EKSClientMock
+		request   *types.ClusterRequest
+		wantErr   bool
+	}{
+		{
+			name:      "should return an error when the region is not configured",
+			eksClient: &awsclient.EKSClientMock{},
+			request:   &types.ClusterRequest{CloudProvider: "aws", Region: "eu-west-1"},
+			wantErr:   true,
+		},
+		{
+			name: "should return an error when the cluster creation fails",
+			eksClient: &awsclient.EKSClientMock{
+				CreateClusterFunc: func(input *eks.CreateClusterInput) (*eks.Cluster, error) {
+					return nil, errors.New("create failed")
+				},
+			},
+			request: &types.ClusterRequest{CloudProvider: "aws", Region: testEKSRegion},
+			wantErr: true,
+		},
+		{
+			name: "should create the cluster in the configured subnets",
+			eksClient: &awsclient.EKSClientMock{
+				CreateClusterFunc: func(input *eks.CreateClusterInput) (*eks.Cluster, error) {
+					return &eks.Cluster{Name: input.Name}, nil
+				},
+			},
+			request: &types.ClusterRequest{CloudProvider: "aws", Region: testEKSRegion, MultiAZ: true},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			provider := newTestEKSProvider(tt.eksClient)
+			spec, err := provider.Create(tt.request)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(spec.Status).To(gomega.Equal(api.ClusterProvisioning))
+			g.Expect(spec.InternalID).ToNot(gomega.BeEmpty())
+			g.Expect([]byte(spec.AdditionalInfo)).To(gomega.MatchJSON([]byte(testEKSClusterInfo)))
+
+			g.Expect(tt.eksClient.CreateClusterCalls()).To(gomega.HaveLen(1))
+			input := tt.eksClient.CreateClusterCalls()[0].Input
+			g.Expect(aws.StringValue(input.Name)).To(gomega.Equal(spec.InternalID))
+			g.Expect(aws.StringValue(input.RoleArn)).To(gomega.Equal("arn:aws:iam::123456789012:role/cluster"))
+			g.Expect(aws.StringValueSlice(input.ResourcesVpcConfig.SubnetIds)).To(gomega.Equal([]string{"subnet-a", "subnet-b", "subnet-c"}))
+		})
+	}
+}
+
+func TestEKSProvider_CheckClusterStatus(t *testing.T) {
+	tests := []struct {
+		name                   string
+		eksClient              *awsclient.EKSClientMock
+		wantStatus             api.ClusterStatus
+		wantNodegroupCreations int
+		wantErr                bool
+	}{
+		{
+			name: "should return an error when describing the cluster fails",
+			eksClient: &awsclient.EKSClientMock{
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return nil, errors.New("describe failed")
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should be failed when the cluster does not exist",
+			eksClient: &awsclient.EKSClientMock{
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return nil, nil
+				},
+			},
+			wantStatus: api.ClusterFailed,
+		},
+		{
+			name: "should be provisioning while the control plane is being created",
+			eksClient: &awsclient.EKSClientMock{
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return &eks.Cluster{Status: aws.String(eks.ClusterStatusCreating)}, nil
+				},
+			},
+			wantStatus: api.ClusterProvisioning,
+		},
+		{
+			name: "should be failed when the control plane failed",
+			eksClient: &awsclient.EKSClientMock{
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return &eks.Cluster{Status: aws.String(eks.ClusterStatusFailed)}, nil
+				},
+			},
+			wantStatus: api.ClusterFailed,
+		},
+		{
+			name: "should create the default node group once the control plane is active",
+			eksClient: &awsclient.EKSClientMock{
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return &eks.Cluster{Status: aws.String(eks.ClusterStatusActive)}, nil
+				},
+				DescribeNodegroupFunc: func(clusterName, nodegroupName string) (*eks.Nodegroup, error) {
+					return nil, nil
+				},
+				CreateNodegroupFunc: func(input *eks.CreateNodegroupInput) (*eks.Nodegroup, error) {
+					return &eks.Nodegroup{}, nil
+				},
+			},
+			wantStatus:             api.ClusterProvisioning,
+			wantNodegroupCreations: 1,
+		},
+		{
+			name: "should be provisioned once the default node group is active",
+			eksClient: &awsclient.EKSClientMock{
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return &eks.Cluster{Status: aws.String(eks.ClusterStatusActive), Arn: aws.String("arn")}, nil
+				},
+				DescribeNodegroupFunc: func(clusterName, nodegroupName string) (*eks.Nodegroup, error) {
+					return &eks.Nodegroup{Status: aws.String(eks.NodegroupStatusActive)}, nil
+				},
+			},
+			wantStatus: api.ClusterProvisioned,
+		},
+		{
+			name: "should be failed when the default node group failed",
+			eksClient: &awsclient.EKSClientMock{
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return &eks.Cluster{Status: aws.String(eks.ClusterStatusActive)}, nil
+				},
+				DescribeNodegroupFunc: func(clusterName, nodegroupName string) (*eks.Nodegroup, error) {
+					return &eks.Nodegroup{Status: aws.String(eks.NodegroupStatusCreateFailed)}, nil
+				},
+			},
+			wantStatus: api.ClusterFailed,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mockEKSClusterRegionQuery()
+			provider := newTestEKSProvider(tt.eksClient)
+			spec, err := provider.CheckClusterStatus(&types.ClusterSpec{
+				InternalID:     testEKSClusterName,
+				Status:         api.ClusterProvisioning,
+				AdditionalInfo: testEKSClusterInfo,
+			})
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(spec.Status).To(gomega.Equal(tt.wantStatus))
+			}
+			g.Expect(tt.eksClient.CreateNodegroupCalls()).To(gomega.HaveLen(tt.wantNodegroupCreations))
+		})
+	}
+}
+
+func TestEKSProvider_Delete(t *testing.T) {
+	tests := []struct {
+		name                   string
+		eksClient              *awsclient.EKSClientMock
+		want                   bool
+		wantNodegroupDeletions int
+		wantClusterDeletions   int
+		wantErr                bool
+	}{
+		{
+			name: "should delete the node groups before the cluster",
+			eksClient: &awsclient.EKSClientMock{
+				ListNodegroupsFunc: func(clusterName string) ([]string, error) {
+					return []string{"a", "b"}, nil
+				},
+				DeleteNodegroupFunc: func(clusterName, nodegroupName string) (*eks.Nodegroup, error) {
+					return &eks.Nodegroup{}, nil
+				},
+			},
+			want:                   false,
+			wantNodegroupDeletions: 2,
+		},
+		{
+			name: "should delete the cluster once it has no node groups",
+			eksClient: &awsclient.EKSClientMock{
+				ListNodegroupsFunc: func(clusterName string) ([]string, error) {
+					return nil, nil
+				},
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return &eks.Cluster{Status: aws.String(eks.ClusterStatusActive)}, nil
+				},
+				DeleteClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return &eks.Cluster{Status: aws.String(eks.ClusterStatusDeleting)}, nil
+				},
+			},
+			want:                 false,
+			wantClusterDeletions: 1,
+		},
+		{
+			name: "should not delete the cluster again while it is being deleted",
+			eksClient: &awsclient.EKSClientMock{
+				ListNodegroupsFunc: func(clusterName string) ([]string, error) {
+					return nil, nil
+				},
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return &eks.Cluster{Status: aws.String(eks.ClusterStatusDeleting)}, nil
+				},
+			},
+			want: false,
+		},
+		{
+			name: "should return true once the cluster is gone",
+			eksClient: &awsclient.EKSClientMock{
+				ListNodegroupsFunc: func(clusterName string) ([]string, error) {
+					return nil, nil
+				},
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return nil, nil
+				},
+			},
+			want: true,
+		},
+		{
+			name: "should return an error when listing the node groups fails",
+			eksClient: &awsclient.EKSClientMock{
+				ListNodegroupsFunc: func(clusterName string) ([]string, error) {
+					return nil, errors.New("list failed")
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			provider := newTestEKSProvider(tt.eksClient)
+			got, err := provider.Delete(&types.ClusterSpec{
+				InternalID:     testEKSClusterName,
+				AdditionalInfo: testEKSClusterInfo,
+			})
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+			g.Expect(tt.eksClient.DeleteNodegroupCalls()).To(gomega.HaveLen(tt.wantNodegroupDeletions))
+			g.Expect(tt.eksClient.DeleteClusterCalls()).To(gomega.HaveLen(tt.wantClusterDeletions))
+		})
+	}
+}
+
+func TestEKSProvider_GetClusterDNS(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider := newTestEKSProvider(&awsclient.EKSClientMock{})
+
+	dns, err := provider.GetClusterDNS(&types.ClusterSpec{InternalID: testEKSClusterName})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(dns).To(gomega.Equal("test-eks-cluster.eks.example.com"))
+
+	provider.eksConfig.Configuration.ClusterBaseDomain = ""
+	_, err = provider.GetClusterDNS(&types.ClusterSpec{InternalID: testEKSClusterName})
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestEKSProvider_ApplyResources(t *testing.T) {
+	tests := []struct {
+		name      string
+		eksClient *awsclient.EKSClientMock
+	}{
+		{
+			name: "should return an error when the cluster does not exist",
+			eksClient: &awsclient.EKSClientMock{
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return nil, nil
+				},
+			},
+		},
+		{
+			name: "should return an error when the token cannot be retrieved",
+			eksClient: &awsclient.EKSClientMock{
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return &eks.Cluster{Endpoint: aws.String("https://example.com")}, nil
+				},
+				GetClusterTokenFunc: func(clusterName string) (string, error) {
+					return "", errors.New("token failed")
+				},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			provider := newTestEKSProvider(tt.eksClient)
+			_, err := provider.ApplyResources(&types.ClusterSpec{
+				InternalID:     testEKSClusterName,
+				AdditionalInfo: testEKSClusterInfo,
+			}, types.ResourceSet{})
+			g.Expect(err).To(gomega.HaveOccurred())
+		})
+	}
+}
+
+// newTestKubernetesServer returns a kubernetes API server serving only the namespaces, i.e. a cluster without OLM
+func newTestKubernetesServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"kind": "APIVersions", "versions": []string{"v1"}})
+		case r.URL.Path == "/apis":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"kind": "APIGroupList", "apiVersion": "v1", "groups": []interface{}{}})
+		case r.URL.Path == "/api/v1":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"kind":         "APIResourceList",
+				"groupVersion": "v1",
+				"resources": []map[string]interface{}{
+					{"name": "namespaces", "singularName": "namespace", "namespaced": false, "kind": "Namespace", "verbs": []string{"create", "get", "update"}},
+				},
+			})
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "NotFound", "code": http.StatusNotFound})
+		default:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"kind":"Namespace","apiVersion":"v1","metadata":{"name":"created"}}`))
+		}
+	}))
+}
+
+func TestEKSProvider_InstallStrimzi(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newTestKubernetesServer()
+	defer server.Close()
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	provider := newTestEKSProvider(&awsclient.EKSClientMock{
+		DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+			return &eks.Cluster{
+				Endpoint:             aws.String(server.URL),
+				CertificateAuthority: &eks.Certificate{Data: aws.String(base64.StdEncoding.EncodeToString(ca))},
+			}, nil
+		},
+		GetClusterTokenFunc: func(clusterName string) (string, error) {
+			return "token", nil
+		},
+	})
+	clusterSpec := &types.ClusterSpec{
+		InternalID:     testEKSClusterName,
+		AdditionalInfo: testEKSClusterInfo,
+	}
+
+	installed, err := provider.InstallStrimzi(clusterSpec)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("OLM must be installed")))
+	g.Expect(installed).To(gomega.BeFalse())
+
+	applied, err := provider.ApplyResources(clusterSpec, types.ResourceSet{
+		Resources: provider.standaloneProvider.buildStrimziOperatorResources(),
+	})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(applied.Resources).To(gomega.HaveLen(1))
+}
+
+func TestEKSProvider_GetCloudProviderRegions(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider := newTestEKSProvider(&awsclient.EKSClientMock{})
+
+	providers, err := provider.GetCloudProviders()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(providers.Items).To(gomega.HaveLen(1))
+
+	regions, err := provider.GetCloudProviderRegions(providers.Items[0])
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(regions.Items).To(gomega.Equal([]types.CloudProviderRegionInfo{
+		{
+			ID:              testEKSRegion,
+			CloudProviderID: "aws",
+			Name:            testEKSRegion,
+			DisplayName:     testEKSRegion,
+			SupportsMultiAZ: true,
+		},
+	}))
+
+	regions, err = provider.GetCloudProviderRegions(types.CloudProviderInfo{ID: "gcp"})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(regions.Items).To(gomega.BeEmpty())
+}
+
+func TestEKSProvider_GetMachinePool(t *testing.T) {
+	tests := []struct {
+		name      string
+		eksClient *awsclient.EKSClientMock
+		want      *types.MachinePoolInfo
+		wantErr   bool
+	}{
+		{
+			name: "should return nil when the node group does not exist",
+			eksClient: &awsclient.EKSClientMock{
+				DescribeNodegroupFunc: func(clusterName, nodegroupName string) (*eks.Nodegroup, error) {
+					return nil, nil
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "should return an error when describing the node group fails",
+			eksClient: &awsclient.EKSClientMock{
+				DescribeNodegroupFunc: func(clusterName, nodegroupName string) (*eks.Nodegroup, error) {
+					return nil, errors.New("describe failed")
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should map the node group to a machine pool",
+			eksClient: &awsclient.EKSClientMock{
+				DescribeNodegroupFunc: func(clusterName, nodegroupName string) (*eks.Nodegroup, error) {
+					return &eks.Nodegroup{
+						NodegroupName: aws.String(nodegroupName),
+						InstanceTypes: aws.StringSlice([]string{"m5.xlarge"}),
+						Subnets:       aws.StringSlice([]string{"subnet-a", "subnet-b"}),
+						Labels:        aws.StringMap(map[string]string{"bf2.org/kafkaInstanceProfileType": "standard"}),
+						Taints: []*eks.Taint{
+							{Effect: aws.String(eks.TaintEffectNoExecute), Key: aws.String("bf2.org/kafkaInstanceProfileType"), Value: aws.String("standard")},
+						},
+						ScalingConfig: &eks.NodegroupScalingConfig{
+							MinSize:     aws.Int64(3),
+							MaxSize:     aws.Int64(9),
+							DesiredSize: aws.Int64(3),
+						},
+					}, nil
+				},
+			},
+			want: &types.MachinePoolInfo{
+				ID:                 "kafka-standard",
+				ClusterID:          testEKSClusterName,
+				InstanceSize:       "m5.xlarge",
+				MultiAZ:            true,
+				AutoScalingEnabled: true,
+				AutoScaling:        types.MachinePoolAutoScaling{MinNodes: 3, MaxNodes: 9},
+				Replicas:           3,
+				NodeLabels:         map[string]string{"bf2.org/kafkaInstanceProfileType": "standard"},
+				NodeTaints: []types.CluserNodeTaint{
+					{Effect: "NoExecute", Key: "bf2.org/kafkaInstanceProfileType", Value: "standard"},
+				},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mockEKSClusterRegionQuery()
+			provider := newTestEKSProvider(tt.eksClient)
+			got, err := provider.GetMachinePool(testEKSClusterName, "kafka-standard")
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestEKSProvider_CreateMachinePool(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    *types.MachinePoolRequest
+		wantErr    bool
+		wantSubnet []string
+		wantSize   *eks.NodegroupScalingConfig
+	}{
+		{
+			name: "should create a single AZ node group with a fixed size",
+			request: &types.MachinePoolRequest{
+				ID:           "kafka-standard",
+				ClusterID:    testEKSClusterName,
+				InstanceSize: "m5.xlarge",
+				Replicas:     3,
+			},
+			wantSubnet: []string{"subnet-a"},
+			wantSize:   &eks.NodegroupScalingConfig{MinSize: aws.Int64(3), MaxSize: aws.Int64(3), DesiredSize: aws.Int64(3)},
+		},
+		{
+			name: "should create a multi AZ node group with autoscaling",
+			request: &types.MachinePoolRequest{
+				ID:                 "kafka-standard",
+				ClusterID:          testEKSClusterName,
+				InstanceSize:       "m5.xlarge",
+				MultiAZ:            true,
+				AutoScalingEnabled: true,
+				AutoScaling:        types.MachinePoolAutoScaling{MinNodes: 3, MaxNodes: 6},
+				NodeTaints:         []types.CluserNodeTaint{{Effect: "NoExecute", Key: "key", Value: "value"}},
+			},
+			wantSubnet: []string{"subnet-a", "subnet-b", "subnet-c"},
+			wantSize:   &eks.NodegroupScalingConfig{MinSize: aws.Int64(3), MaxSize: aws.Int64(6), DesiredSize: aws.Int64(3)},
+		},
+		{
+			name: "should return an error when the minimum number of nodes is greater than the maximum",
+			request: &types.MachinePoolRequest{
+				ID:                 "kafka-standard",
+				ClusterID:          testEKSClusterName,
+				AutoScalingEnabled: true,
+				AutoScaling:        types.MachinePoolAutoScaling{MinNodes: 6, MaxNodes: 3},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the taint effect is not supported",
+			request: &types.MachinePoolRequest{
+				ID:         "kafka-standard",
+				ClusterID:  testEKSClusterName,
+				NodeTaints: []types.CluserNodeTaint{{Effect: "Unknown"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mockEKSClusterRegionQuery()
+			eksClient := &awsclient.EKSClientMock{
+				CreateNodegroupFunc: func(input *eks.CreateNodegroupInput) (*eks.Nodegroup, error) {
+					return &eks.Nodegroup{}, nil
+				},
+			}
+			provider := newTestEKSProvider(eksClient)
+			got, err := provider.CreateMachinePool(tt.request)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				g.Expect(eksClient.CreateNodegroupCalls()).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(got).To(gomega.Equal(tt.request))
+			g.Expect(eksClient.CreateNodegroupCalls()).To(gomega.HaveLen(1))
+			input := eksClient.CreateNodegroupCalls()[0].Input
+			g.Expect(aws.StringValueSlice(input.Subnets)).To(gomega.Equal(tt.wantSubnet))
+			g.Expect(input.ScalingConfig).To(gomega.Equal(tt.wantSize))
+			g.Expect(input.Taints).To(gomega.HaveLen(len(tt.request.NodeTaints)))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/clusters/types"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/ocm"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 
@@ -61,16 +62,19 @@ func NewDefaultProviderFactory(
 	ocmConfig *ocm.OCMConfig,
 	awsConfig *config.AWSConfig,
 	gcpConfig *config.GCPConfig,
+	eksConfig *config.EKSConfig,
 	dataplaneClusterConfig *config.DataplaneClusterConfig,
 ) *DefaultProviderFactory {
 
 	clusterBuilder := NewClusterBuilder(awsConfig, gcpConfig, dataplaneClusterConfig)
 	ocmProvider := newOCMProvider(ocmClient, clusterBuilder, ocmConfig)
 	standaloneProvider := newStandaloneProvider(connectionFactory, dataplaneClusterConfig)
+	eksProvider := newEKSProvider(aws.NewDefaultEKSClientFactory(), connectionFactory, awsConfig, eksConfig, dataplaneClusterConfig)
 	return &DefaultProviderFactory{
 		providerContainer: map[api.ClusterProviderType]Provider{
 			api.ClusterProviderStandalone: standaloneProvider,
 			api.ClusterProviderOCM:        ocmProvider,
+			api.ClusterProviderAwsEKS:     eksProvider,
 		},
 	}
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/ocm"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/onsi/gomega"
@@ -17,6 +18,7 @@ func TestNewDefaultProviderFactory(t *testing.T) {
 		ocmConfig              *ocm.OCMConfig
 		awsConfig              *config.AWSConfig
 		gcpConfig              *config.GCPConfig
+		eksConfig              *config.EKSConfig
 		dataplaneClusterConfig *config.DataplaneClusterConfig
 	}
 	tests := []struct {
@@ -35,6 +37,10 @@ func TestNewDefaultProviderFactory(t *testing.T) {
 							idGenerator: ocm.NewIDGenerator("mk-"),
 						},
 					},
+					api.ClusterProviderAwsEKS: &EKSProvider{
+						eksClientFactory:   aws.NewDefaultEKSClientFactory(),
+						standaloneProvider: &StandaloneProvider{},
+					},
 				},
 			},
 		},
@@ -43,7 +49,7 @@ func TestNewDefaultProviderFactory(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			got := NewDefaultProviderFactory(tt.args.ocmClient, tt.args.connectionFactory, tt.args.ocmConfig, tt.args.awsConfig, tt.args.gcpConfig, tt.args.dataplaneClusterConfig)
+			got := NewDefaultProviderFactory(tt.args.ocmClient, tt.args.connectionFactory, tt.args.ocmConfig, tt.args.awsConfig, tt.args.gcpConfig, tt.args.eksConfig, tt.args.dataplaneClusterConfig)
 			g.Expect(got).To(gomega.Equal(tt.want))
 		})
 	}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/golang/glog"
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	operatorsv1alpha2 "github.com/operator-framework/api/pkg/operators/v1alpha2"
@@ -21,6 +22,7 @@ import (
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -74,17 +76,22 @@ func (s *StandaloneProvider) Delete(spec *types.ClusterSpec) (bool, error) {
 
 func (s *StandaloneProvider) InstallStrimzi(clusterSpec *types.ClusterSpec) (bool, error) {
 	_, err := s.ApplyResources(clusterSpec, types.ResourceSet{
-		Resources: []interface{}{
-			s.buildStrimziOperatorNamespace(),
-			s.buildStrimziOperatorCatalogSource(),
-			s.buildStrimziOperatorOperatorGroup(),
-			s.buildStrimziOperatorSubscription(),
-		},
+		Resources: s.buildStrimziOperatorResources(),
 	})
 
 	return true, err
 }
 
+// buildStrimziOperatorResources builds the OLM resources needed to install the strimzi operator
+func (s *StandaloneProvider) buildStrimziOperatorResources() []interface{} {
+	return []interface{}{
+		s.buildStrimziOperatorNamespace(),
+		s.buildStrimziOperatorCatalogSource(),
+		s.buildStrimziOperatorOperatorGroup(),
+		s.buildStrimziOperatorSubscription(),
+	}
+}
+
 func StrimziOperatorCommonLabels() map[string]string {
 	return map[string]string{
 		"app.kubernetes.io/component": "strimzi-bundle",
@@ -173,18 +180,23 @@ func (s *StandaloneProvider) buildStrimziOperatorSubscription() *operatorsv1alph
 
 func (s *StandaloneProvider) InstallKasFleetshard(clusterSpec *types.ClusterSpec, params []types.Parameter) (bool, error) {
 	_, err := s.ApplyResources(clusterSpec, types.ResourceSet{
-		Resources: []interface{}{
-			s.buildKASFleetShardOperatorNamespace(),
-			s.buildKASFleetShardSyncSecret(params),
-			s.buildKASFleetShardOperatorCatalogSource(),
-			s.buildKASFleetShardOperatorOperatorGroup(),
-			s.buildKASFleetShardOperatorSubscription(),
-		},
+		Resources: s.buildKASFleetShardOperatorResources(params),
 	})
 
 	return true, err
 }
 
+// buildKASFleetShardOperatorResources builds the OLM resources needed to install the kas-fleetshard operator
+func (s *StandaloneProvider) buildKASFleetShardOperatorResources(params []types.Parameter) []interface{} {
+	return []interface{}{
+		s.buildKASFleetShardOperatorNamespace(),
+		s.buildKASFleetShardSyncSecret(params),
+		s.buildKASFleetShardOperatorCatalogSource(),
+		s.buildKASFleetShardOperatorOperatorGroup(),
+		s.buildKASFleetShardOperatorSubscription(),
+	}
+}
+
 func (s *StandaloneProvider) buildKASFleetShardOperatorNamespace() *v1.Namespace {
 	kasFleetshardOLMConfig := s.dataplaneClusterConfig.KasFleetshardOperatorOLMConfig
 	return &v1.Namespace{
@@ -375,15 +387,28 @@ func (s *StandaloneProvider) ApplyResources(clusterSpec *types.ClusterSpec, reso
 		return nil, err
 	}
 
-	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	applied, err := applyResources(restConfig, resources, false)
 	if err != nil {
 		return nil, err
 	}
 
+	return &applied, nil
+}
+
+// applyResources applies the given resources to the cluster reachable with restConfig and returns the applied ones.
+// When skipUnsupportedKinds is true, resources whose kind is not served by the cluster are skipped instead of failing the whole apply,
+// and are left out of the returned resource set.
+func applyResources(restConfig *rest.Config, resources types.ResourceSet, skipUnsupportedKinds bool) (types.ResourceSet, error) {
+	applied := types.ResourceSet{Name: resources.Name}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return applied, err
+	}
+
 	// Create a REST mapper that tracks information about the available resources in the cluster.
 	dc, err := discovery.NewDiscoveryClientForConfig(restConfig)
 	if err != nil {
-		return nil, err
+		return applied, err
 	}
 
 	discoveryCachedClient := memory.NewMemCacheClient(dc)
@@ -392,11 +417,16 @@ func (s *StandaloneProvider) ApplyResources(clusterSpec *types.ClusterSpec, reso
 	for _, resource := range resources.Resources {
 		_, err = applyResource(dynamicClient, mapper, resource)
 		if err != nil {
-			return nil, err
+			if skipUnsupportedKinds && meta.IsNoMatchError(err) {
+				glog.Warningf("skipping resource of set %q: %v", resources.Name, err)
+				continue
+			}
+			return applied, err
 		}
+		applied.Resources = append(applied.Resources, resource)
 	}
 
-	return &resources, nil
+	return applied, nil
 }
 
 func (s *StandaloneProvider) GetCloudProviders() (*types.CloudProviderInfoList, error) {
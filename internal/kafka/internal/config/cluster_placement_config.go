@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// FirstFitClusterPlacementStrategy places a kafka on the first cluster that passes the capacity checks.
+	// This is the historical behaviour of the fleet manager
+	FirstFitClusterPlacementStrategy = "first-fit"
+	// ScoredClusterPlacementStrategy places a kafka on the eligible cluster with the highest weighted score
+	ScoredClusterPlacementStrategy = "scored"
+
+	// BinPackingPlacementMode favours the most utilised clusters so that the fleet is filled up one cluster at a time
+	BinPackingPlacementMode = "bin-packing"
+	// SpreadPlacementMode favours the least utilised clusters so that kafkas are spread evenly across the fleet
+	SpreadPlacementMode = "spread"
+
+	defaultClusterPlacementConfigFilePath = "config/cluster-placement-configuration.yaml"
+)
+
+// ClusterPlacementConfig contains the settings used to decide which data plane cluster a kafka is placed on
+type ClusterPlacementConfig struct {
+	Strategy      string
+	ConfigFile    string
+	Configuration ClusterPlacementConfiguration
+}
+
+// ClusterPlacementConfiguration contains the settings of the scored cluster placement strategy
+type ClusterPlacementConfiguration struct {
+	// Mode is either bin-packing or spread. It decides whether the utilization score favours the most or the least utilised clusters
+	Mode string `yaml:"mode"`
+	// Weights are the multipliers applied to each individual score before they are summed up
+	Weights ClusterPlacementWeights `yaml:"weights"`
+	// PreferredClusters maps an organisation id to the ids of the clusters its kafkas should preferably be placed on
+	PreferredClusters map[string][]string `yaml:"preferred_clusters"`
+	// Zones maps a cluster id to the zone it belongs to. Clusters without a zone are considered to be in the same zone
+	Zones map[string]string `yaml:"zones"`
+}
+
+type ClusterPlacementWeights struct {
+	Utilization              float64 `yaml:"utilization"`
+	OrganisationAntiAffinity float64 `yaml:"organisation_anti_affinity"`
+	PreferredCluster         float64 `yaml:"preferred_cluster"`
+	ZoneBalance              float64 `yaml:"zone_balance"`
+}
+
+func NewClusterPlacementConfig() *ClusterPlacementConfig {
+	return &ClusterPlacementConfig{
+		Strategy:   FirstFitClusterPlacementStrategy,
+		ConfigFile: defaultClusterPlacementConfigFilePath,
+		Configuration: ClusterPlacementConfiguration{
+			Mode: BinPackingPlacementMode,
+			Weights: ClusterPlacementWeights{
+				Utilization:              1,
+				OrganisationAntiAffinity: 1,
+				PreferredCluster:         1,
+				ZoneBalance:              1,
+			},
+		},
+	}
+}
+
+func (c *ClusterPlacementConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.Strategy, "cluster-placement-strategy", c.Strategy, fmt.Sprintf("The strategy used to place kafkas on data plane clusters. Valid values are %q and %q", FirstFitClusterPlacementStrategy, ScoredClusterPlacementStrategy))
+	fs.StringVar(&c.ConfigFile, "cluster-placement-config-file", c.ConfigFile, "File containing the weights and preferences of the scored cluster placement strategy")
+}
+
+func (c *ClusterPlacementConfig) ReadFiles() error {
+	err := shared.ReadYamlFile(c.ConfigFile, &c.Configuration)
+	if err != nil {
+		if os.IsNotExist(err) {
+			logger.Logger.Warningf("the cluster placement configuration file '%s' does not exist. The default weights will be used", c.ConfigFile)
+			return nil
+		}
+		return fmt.Errorf("error reading cluster placement configuration file %q: %v", c.ConfigFile, err)
+	}
+
+	return nil
+}
+
+func (c *ClusterPlacementConfig) Validate(env *environments.Env) error {
+	if c.Strategy != FirstFitClusterPlacementStrategy && c.Strategy != ScoredClusterPlacementStrategy {
+		return fmt.Errorf("invalid cluster placement strategy %q. Valid values are %q and %q", c.Strategy, FirstFitClusterPlacementStrategy, ScoredClusterPlacementStrategy)
+	}
+
+	if c.Configuration.Mode != BinPackingPlacementMode && c.Configuration.Mode != SpreadPlacementMode {
+		return fmt.Errorf("invalid cluster placement mode %q. Valid values are %q and %q", c.Configuration.Mode, BinPackingPlacementMode, SpreadPlacementMode)
+	}
+
+	weights := c.Configuration.Weights
+	if weights.Utilization < 0 || weights.OrganisationAntiAffinity < 0 || weights.PreferredCluster < 0 || weights.ZoneBalance < 0 {
+		return fmt.Errorf("cluster placement weights must not be negative: %+v", weights)
+	}
+
+	return nil
+}
+
+// IsScoredStrategyEnabled returns true if kafkas are placed by the scored cluster placement strategy
+func (c *ClusterPlacementConfig) IsScoredStrategyEnabled() bool {
+	return c.Strategy == ScoredClusterPlacementStrategy
+}
+
+// IsPreferredCluster returns true if the given cluster is one of the preferred clusters of the organisation
+func (c *ClusterPlacementConfig) IsPreferredCluster(organisationID, clusterID string) bool {
+	for _, id := range c.Configuration.PreferredClusters[organisationID] {
+		if id == clusterID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetClusterZone returns the zone of the given cluster, or an empty string if the cluster is not assigned to a zone
+func (c *ClusterPlacementConfig) GetClusterZone(clusterID string) string {
+	return c.Configuration.Zones[clusterID]
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_ClusterPlacementConfig_ReadFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		modifyFn func(config *ClusterPlacementConfig)
+		want     ClusterPlacementConfiguration
+		wantErr  bool
+	}{
+		{
+			name: "should read the default configuration file",
+			want: ClusterPlacementConfiguration{
+				Mode: BinPackingPlacementMode,
+				Weights: ClusterPlacementWeights{
+					Utilization:              1,
+					OrganisationAntiAffinity: 1,
+					PreferredCluster:         1,
+					ZoneBalance:              1,
+				},
+				PreferredClusters: map[string][]string{},
+				Zones:             map[string]string{},
+			},
+		},
+		{
+			name: "should keep the default configuration when the configuration file does not exist",
+			modifyFn: func(config *ClusterPlacementConfig) {
+				config.ConfigFile = "invalid"
+			},
+			want: NewClusterPlacementConfig().Configuration,
+		},
+		{
+			name: "should return an error when the configuration file is not valid",
+			modifyFn: func(config *ClusterPlacementConfig) {
+				config.ConfigFile = "config/kafka-owner-list.yaml"
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := NewClusterPlacementConfig()
+			if tt.modifyFn != nil {
+				tt.modifyFn(config)
+			}
+			err := config.ReadFiles()
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(config.Configuration).To(gomega.Equal(tt.want))
+			}
+		})
+	}
+}
+
+func Test_ClusterPlacementConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modifyFn func(config *ClusterPlacementConfig)
+		wantErr  bool
+	}{
+		{
+			name: "should accept the default configuration",
+		},
+		{
+			name: "should accept the scored strategy with the spread mode",
+			modifyFn: func(config *ClusterPlacementConfig) {
+				config.Strategy = ScoredClusterPlacementStrategy
+				config.Configuration.Mode = SpreadPlacementMode
+			},
+		},
+		{
+			name: "should return an error when the strategy is unknown",
+			modifyFn: func(config *ClusterPlacementConfig) {
+				config.Strategy = "random"
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the mode is unknown",
+			modifyFn: func(config *ClusterPlacementConfig) {
+				config.Configuration.Mode = "round-robin"
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when a weight is negative",
+			modifyFn: func(config *ClusterPlacementConfig) {
+				config.Configuration.Weights.ZoneBalance = -1
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := NewClusterPlacementConfig()
+			if tt.modifyFn != nil {
+				tt.modifyFn(config)
+			}
+			g.Expect(config.Validate(nil) != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_ClusterPlacementConfig_IsPreferredCluster(t *testing.T) {
+	g := gomega.NewWithT(t)
+	config := NewClusterPlacementConfig()
+	config.Configuration.PreferredClusters = map[string][]string{"org-id": {"cluster-a"}}
+
+	g.Expect(config.IsPreferredCluster("org-id", "cluster-a")).To(gomega.BeTrue())
+	g.Expect(config.IsPreferredCluster("org-id", "cluster-b")).To(gomega.BeFalse())
+	g.Expect(config.IsPreferredCluster("other-org-id", "cluster-a")).To(gomega.BeFalse())
+}
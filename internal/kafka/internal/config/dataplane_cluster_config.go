@@ -248,6 +248,16 @@ func (conf *ClusterConfig) IsNumberOfKafkaWithinClusterLimit(clusterId string, c
 	return true
 }
 
+// GetKafkaInstanceLimit returns the kafka instance limit of the given cluster and whether the cluster is limited.
+// Clusters that are not in the configuration or that have a limit of -1 are not limited.
+func (conf *ClusterConfig) GetKafkaInstanceLimit(clusterId string) (int, bool) {
+	manualCluster, exist := conf.clusterConfigMap[clusterId]
+	if !exist || manualCluster.KafkaInstanceLimit == -1 {
+		return 0, false
+	}
+	return manualCluster.KafkaInstanceLimit, true
+}
+
 func (conf *ClusterConfig) IsClusterSchedulable(clusterId string) bool {
 	if _, exist := conf.clusterConfigMap[clusterId]; exist {
 		return conf.clusterConfigMap[clusterId].Schedulable
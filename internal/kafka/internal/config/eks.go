@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/spf13/pflag"
+)
+
+const (
+	defaultEKSConfigFilePath = "config/eks-configuration.yaml"
+
+	defaultEKSNodegroupInstanceType = "m5.2xlarge"
+	defaultEKSNodegroupNodeCount    = 3
+)
+
+// EKSConfig contains the settings used to provision and manage AWS EKS data plane clusters.
+// The AWS credentials are taken from the AWSConfig.
+type EKSConfig struct {
+	ConfigFile    string
+	Configuration EKSConfiguration
+}
+
+type EKSConfiguration struct {
+	// ClusterRoleARN is the IAM role assumed by the EKS control plane
+	ClusterRoleARN string `yaml:"cluster_role_arn"`
+	// NodeRoleARN is the IAM role assumed by the worker nodes of the managed node groups
+	NodeRoleARN string `yaml:"node_role_arn"`
+	// KubernetesVersion is the kubernetes version of new clusters. The EKS default is used when empty
+	KubernetesVersion string `yaml:"kubernetes_version"`
+	// ClusterBaseDomain is the domain under which each cluster exposes its ingress, as <cluster name>.<base domain>
+	ClusterBaseDomain string `yaml:"cluster_base_domain"`
+	// DefaultNodegroup is the node group created alongside every new cluster
+	DefaultNodegroup EKSNodegroupConfig `yaml:"default_nodegroup"`
+	// Regions lists the regions clusters can be created in, together with their networking configuration
+	Regions []EKSRegionConfig `yaml:"regions"`
+}
+
+type EKSNodegroupConfig struct {
+	InstanceType string `yaml:"instance_type"`
+	NodeCount    int    `yaml:"node_count"`
+}
+
+type EKSRegionConfig struct {
+	Name             string   `yaml:"name"`
+	SubnetIDs        []string `yaml:"subnet_ids"`
+	SecurityGroupIDs []string `yaml:"security_group_ids"`
+	SupportsMultiAZ  bool     `yaml:"supports_multi_az"`
+}
+
+func NewEKSConfig() *EKSConfig {
+	return &EKSConfig{
+		ConfigFile: defaultEKSConfigFilePath,
+		Configuration: EKSConfiguration{
+			DefaultNodegroup: EKSNodegroupConfig{
+				InstanceType: defaultEKSNodegroupInstanceType,
+				NodeCount:    defaultEKSNodegroupNodeCount,
+			},
+		},
+	}
+}
+
+func (c *EKSConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.ConfigFile, "eks-config-file", c.ConfigFile, "File containing the configuration used to provision AWS EKS data plane clusters")
+}
+
+func (c *EKSConfig) ReadFiles() error {
+	err := shared.ReadYamlFile(c.ConfigFile, &c.Configuration)
+	if err != nil {
+		if os.IsNotExist(err) {
+			logger.Logger.Warningf("the EKS configuration file '%s' does not exist. AWS EKS data plane clusters cannot be provisioned", c.ConfigFile)
+			return nil
+		}
+		return fmt.Errorf("error reading EKS configuration file %q: %v", c.ConfigFile, err)
+	}
+
+	return nil
+}
+
+// GetRegion returns the configuration of the given region and whether it was found
+func (c *EKSConfig) GetRegion(name string) (EKSRegionConfig, bool) {
+	for _, region := range c.Configuration.Regions {
+		if region.Name == name {
+			return region, true
+		}
+	}
+	return EKSRegionConfig{}, false
+}
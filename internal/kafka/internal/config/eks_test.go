@@ -0,0 +1,103 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_EKSConfig_ReadFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		modifyFn func(config *EKSConfig)
+		want     EKSConfiguration
+		wantErr  bool
+	}{
+		{
+			name: "should keep the default node group when reading the default configuration file",
+			want: EKSConfiguration{
+				DefaultNodegroup: EKSNodegroupConfig{
+					InstanceType: defaultEKSNodegroupInstanceType,
+					NodeCount:    defaultEKSNodegroupNodeCount,
+				},
+				Regions: []EKSRegionConfig{},
+			},
+		},
+		{
+			name: "should not return an error when the configuration file does not exist",
+			modifyFn: func(config *EKSConfig) {
+				config.ConfigFile = "invalid"
+			},
+			want: EKSConfiguration{
+				DefaultNodegroup: EKSNodegroupConfig{
+					InstanceType: defaultEKSNodegroupInstanceType,
+					NodeCount:    defaultEKSNodegroupNodeCount,
+				},
+			},
+		},
+		{
+			name: "should return an error when the configuration file is not valid",
+			modifyFn: func(config *EKSConfig) {
+				config.ConfigFile = "config/kafka-owner-list.yaml"
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := NewEKSConfig()
+			if tt.modifyFn != nil {
+				tt.modifyFn(config)
+			}
+			err := config.ReadFiles()
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(config.Configuration).To(gomega.Equal(tt.want))
+			}
+		})
+	}
+}
+
+func Test_EKSConfig_GetRegion(t *testing.T) {
+	config := &EKSConfig{
+		Configuration: EKSConfiguration{
+			Regions: []EKSRegionConfig{
+				{Name: "us-east-1", SubnetIDs: []string{"subnet-a"}},
+				{Name: "eu-west-1", SubnetIDs: []string{"subnet-b"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		region    string
+		want      EKSRegionConfig
+		wantFound bool
+	}{
+		{
+			name:      "should return the configured region",
+			region:    "eu-west-1",
+			want:      EKSRegionConfig{Name: "eu-west-1", SubnetIDs: []string{"subnet-b"}},
+			wantFound: true,
+		},
+		{
+			name:      "should return false when the region is not configured",
+			region:    "ap-south-1",
+			want:      EKSRegionConfig{},
+			wantFound: false,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			got, found := config.GetRegion(tt.region)
+			g.Expect(found).To(gomega.Equal(tt.wantFound))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -7,20 +7,32 @@ import (
 func ConvertKafkaRequest(request *dbapi.KafkaRequest) []map[string]interface{} {
 	return []map[string]interface{}{
 		{
-			"id":                    request.ID,
-			"region":                request.Region,
-			"cloud_provider":        request.CloudProvider,
-			"multi_az":              request.MultiAZ,
-			"name":                  request.Name,
-			"status":                request.Status,
-			"owner":                 request.Owner,
-			"cluster_id":            request.ClusterID,
-			"bootstrap_server_host": request.BootstrapServerHost,
-			"created_at":            request.Meta.CreatedAt,
-			"updated_at":            request.Meta.UpdatedAt,
-			"deleted_at":            request.Meta.DeletedAt.Time,
-			"size_id":               request.SizeId,
-			"instance_type":         request.InstanceType,
+			"id":                          request.ID,
+			"region":                      request.Region,
+			"cloud_provider":              request.CloudProvider,
+			"multi_az":                    request.MultiAZ,
+			"name":                        request.Name,
+			"status":                      request.Status,
+			"owner":                       request.Owner,
+			"organisation_id":             request.OrganisationId,
+			"cluster_id":                  request.ClusterID,
+			"bootstrap_server_host":       request.BootstrapServerHost,
+			"created_at":                  request.Meta.CreatedAt,
+			"updated_at":                  request.Meta.UpdatedAt,
+			"deleted_at":                  request.Meta.DeletedAt.Time,
+			"size_id":                     request.SizeId,
+			"instance_type":               request.InstanceType,
+			"placement_id":                request.PlacementId,
+			"migration_status":            request.MigrationStatus,
+			"migration_source_cluster_id": request.MigrationSourceClusterID,
+			"migration_target_cluster_id": request.MigrationTargetClusterID,
+			"migration_placement_id":      request.MigrationPlacementId,
+			"pending_kafka_version":       request.PendingKafkaVersion,
+			"pending_strimzi_version":     request.PendingStrimziVersion,
+			"pending_kafka_ibp_version":   request.PendingKafkaIBPVersion,
+			"resource_version":            request.ResourceVersion,
+			"dr_role":                     request.DrRole,
+			"dr_peer_kafka_id":            request.DrPeerKafkaID,
 		},
 	}
 }
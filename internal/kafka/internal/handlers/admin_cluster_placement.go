@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+)
+
+type adminClusterPlacementHandler struct {
+	clusterPlacementStrategy services.ClusterPlacementStrategy
+	kafkaConfig              *config.KafkaConfig
+}
+
+func NewAdminClusterPlacementHandler(clusterPlacementStrategy services.ClusterPlacementStrategy, kafkaConfig *config.KafkaConfig) *adminClusterPlacementHandler {
+	return &adminClusterPlacementHandler{
+		clusterPlacementStrategy: clusterPlacementStrategy,
+		kafkaConfig:              kafkaConfig,
+	}
+}
+
+// DryRun returns the cluster a kafka with the given properties would be placed on, without creating the kafka
+func (h adminClusterPlacementHandler) DryRun(w http.ResponseWriter, r *http.Request) {
+	var dryRunRequest private.KafkaPlacementDryRunRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &dryRunRequest,
+		Validate: []handlers.Validate{
+			handlers.ValidateLength(&dryRunRequest.CloudProvider, "cloud_provider", 1, nil),
+			handlers.ValidateLength(&dryRunRequest.Region, "region", 1, nil),
+			handlers.ValidateLength(&dryRunRequest.InstanceType, "instance_type", 1, nil),
+			handlers.ValidateLength(&dryRunRequest.SizeId, "size_id", 1, nil),
+			func() *errors.ServiceError {
+				if _, err := h.kafkaConfig.GetKafkaInstanceSize(dryRunRequest.InstanceType, dryRunRequest.SizeId); err != nil {
+					return errors.NewWithCause(errors.ErrorValidation, err, "unsupported size %q for instance type %q", dryRunRequest.SizeId, dryRunRequest.InstanceType)
+				}
+				return nil
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			decision, err := h.clusterPlacementStrategy.ExplainPlacement(presenters.ConvertKafkaPlacementDryRunRequest(dryRunRequest))
+			if err != nil {
+				return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to dry run the kafka placement")
+			}
+			return presenters.PresentClusterPlacementDecision(decision), nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
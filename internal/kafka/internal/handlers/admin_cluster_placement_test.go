@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+func Test_adminClusterPlacementHandler_DryRun(t *testing.T) {
+	tests := []struct {
+		name                     string
+		clusterPlacementStrategy services.ClusterPlacementStrategy
+		body                     []byte
+		wantStatusCode           int
+		want                     private.KafkaPlacementDryRunResponse
+	}{
+		{
+			name: "should return the placement decision",
+			clusterPlacementStrategy: &services.ClusterPlacementStrategyMock{
+				ExplainPlacementFunc: func(kafka *dbapi.KafkaRequest) (*services.ClusterPlacementDecision, error) {
+					if !kafka.MultiAZ || kafka.OrganisationId != "org-id" {
+						return nil, errors.New("unexpected kafka")
+					}
+					return &services.ClusterPlacementDecision{
+						Strategy: "scored",
+						Cluster:  &api.Cluster{ClusterID: "cluster-id"},
+						Candidates: []services.ClusterPlacementCandidate{
+							{ClusterID: "cluster-id", Eligible: true, Score: 1, Scores: map[string]float64{"utilization": 1}},
+						},
+					}, nil
+				},
+			},
+			body:           []byte(`{"cloud_provider": "aws", "region": "us-east-1", "instance_type": "standard", "size_id": "x1", "organisation_id": "org-id"}`),
+			wantStatusCode: http.StatusOK,
+			want: private.KafkaPlacementDryRunResponse{
+				Strategy:  "scored",
+				ClusterId: "cluster-id",
+				Candidates: []private.KafkaPlacementCandidate{
+					{ClusterId: "cluster-id", Eligible: true, Score: 1, Scores: map[string]float64{"utilization": 1}},
+				},
+			},
+		},
+		{
+			name:                     "should return bad request when a required field is missing",
+			clusterPlacementStrategy: &services.ClusterPlacementStrategyMock{},
+			body:                     []byte(`{"cloud_provider": "aws", "instance_type": "standard", "size_id": "x1"}`),
+			wantStatusCode:           http.StatusBadRequest,
+		},
+		{
+			name:                     "should return bad request when the size is not supported",
+			clusterPlacementStrategy: &services.ClusterPlacementStrategyMock{},
+			body:                     []byte(`{"cloud_provider": "aws", "region": "us-east-1", "instance_type": "standard", "size_id": "x100"}`),
+			wantStatusCode:           http.StatusBadRequest,
+		},
+		{
+			name: "should return internal server error when the placement fails",
+			clusterPlacementStrategy: &services.ClusterPlacementStrategyMock{
+				ExplainPlacementFunc: func(kafka *dbapi.KafkaRequest) (*services.ClusterPlacementDecision, error) {
+					return nil, errors.New("failed to find clusters")
+				},
+			},
+			body:           []byte(`{"cloud_provider": "aws", "region": "us-east-1", "instance_type": "standard", "size_id": "x1"}`),
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminClusterPlacementHandler(tt.clusterPlacementStrategy, &fullKafkaConfig)
+			req, rw := GetHandlerParams(http.MethodPost, "/kafkas/placement_dry_run", bytes.NewBuffer(tt.body), t)
+			h.DryRun(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusOK {
+				var got private.KafkaPlacementDryRunResponse
+				g.Expect(json.NewDecoder(resp.Body).Decode(&got)).To(gomega.Succeed())
+				g.Expect(got).To(gomega.Equal(tt.want))
+			}
+		})
+	}
+}
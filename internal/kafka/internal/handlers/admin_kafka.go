@@ -21,18 +21,20 @@ import (
 )
 
 type adminKafkaHandler struct {
-	kafkaService   services.KafkaService
-	accountService account.AccountService
-	providerConfig *config.ProviderConfig
-	clusterService services.ClusterService
+	kafkaService             services.KafkaService
+	accountService           account.AccountService
+	providerConfig           *config.ProviderConfig
+	clusterService           services.ClusterService
+	maintenanceWindowService services.MaintenanceWindowService
 }
 
-func NewAdminKafkaHandler(kafkaService services.KafkaService, accountService account.AccountService, providerConfig *config.ProviderConfig, clusterService services.ClusterService) *adminKafkaHandler {
+func NewAdminKafkaHandler(kafkaService services.KafkaService, accountService account.AccountService, providerConfig *config.ProviderConfig, clusterService services.ClusterService, maintenanceWindowService services.MaintenanceWindowService) *adminKafkaHandler {
 	return &adminKafkaHandler{
-		kafkaService:   kafkaService,
-		accountService: accountService,
-		providerConfig: providerConfig,
-		clusterService: clusterService,
+		kafkaService:             kafkaService,
+		accountService:           accountService,
+		providerConfig:           providerConfig,
+		clusterService:           clusterService,
+		maintenanceWindowService: maintenanceWindowService,
 	}
 }
 
@@ -107,6 +109,111 @@ func (h adminKafkaHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	handlers.HandleDelete(w, r, cfg, http.StatusAccepted)
 }
 
+func (h adminKafkaHandler) Suspend(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			ctx := r.Context()
+			kafkaRequest, err := h.kafkaService.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if err := h.kafkaService.SuspendKafka(kafkaRequest); err != nil {
+				return nil, err
+			}
+			return presenters.PresentKafkaRequestAdminEndpoint(kafkaRequest, h.accountService)
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
+
+func (h adminKafkaHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			ctx := r.Context()
+			kafkaRequest, err := h.kafkaService.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if err := h.kafkaService.ResumeKafka(kafkaRequest); err != nil {
+				return nil, err
+			}
+			return presenters.PresentKafkaRequestAdminEndpoint(kafkaRequest, h.accountService)
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
+
+func (h adminKafkaHandler) Failover(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			ctx := r.Context()
+			kafkaRequest, err := h.kafkaService.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if err := h.kafkaService.FailoverKafka(kafkaRequest); err != nil {
+				return nil, err
+			}
+			return presenters.PresentKafkaRequestAdminEndpoint(kafkaRequest, h.accountService)
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
+
+func (h adminKafkaHandler) Migrate(w http.ResponseWriter, r *http.Request) {
+	var kafkaMigrationRequest private.KafkaMigrationRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &kafkaMigrationRequest,
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			ctx := r.Context()
+			kafkaRequest, err := h.kafkaService.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if err := h.kafkaService.MigrateKafka(kafkaRequest, kafkaMigrationRequest.TargetClusterId); err != nil {
+				return nil, err
+			}
+			return presenters.PresentKafkaRequestAdminEndpoint(kafkaRequest, h.accountService)
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
+
+func (h adminKafkaHandler) DrainCluster(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			clusterID := mux.Vars(r)["id"]
+			kafkaRequests, err := h.kafkaService.DrainCluster(clusterID)
+			if err != nil {
+				return nil, err
+			}
+
+			kafkaRequestList := private.KafkaList{
+				Kind:  "KafkaList",
+				Page:  1,
+				Size:  int32(len(kafkaRequests)),
+				Total: int32(len(kafkaRequests)),
+				Items: []private.Kafka{},
+			}
+
+			for _, kafkaRequest := range kafkaRequests {
+				converted, err := presenters.PresentKafkaRequestAdminEndpoint(kafkaRequest, h.accountService)
+				if err != nil {
+					return nil, err
+				}
+				kafkaRequestList.Items = append(kafkaRequestList.Items, *converted)
+			}
+
+			return kafkaRequestList, nil
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
+
 func (h *adminKafkaHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	id := mux.Vars(r)["id"]
@@ -202,11 +309,40 @@ func (h *adminKafkaHandler) Update(w http.ResponseWriter, r *http.Request) {
 				return kafka.Status
 			}
 
+			// queue sets the pending version to be applied in the next maintenance window.
+			// Requesting the currently desired version cancels any queued upgrade.
+			queue := func(pending *string, desired string, requested string) bool {
+				if requested == "" {
+					return false
+				}
+				if requested == desired {
+					requested = ""
+				}
+				if *pending != requested {
+					*pending = requested
+					return true
+				}
+				return false
+			}
+
+			maintenanceWindow, mwErr := h.maintenanceWindowService.FindForKafka(kafkaRequest)
+			if mwErr != nil {
+				return nil, mwErr
+			}
+
 			requestedStorageSize, _ := arrays.FirstNonEmpty(kafkaUpdateReq.MaxDataRetentionSize, kafkaUpdateReq.DeprecatedKafkaStorageSize)
 
-			updateRequired := update(&kafkaRequest.DesiredKafkaVersion, kafkaUpdateReq.KafkaVersion)
-			updateRequired = update(&kafkaRequest.DesiredStrimziVersion, kafkaUpdateReq.StrimziVersion) || updateRequired
-			updateRequired = update(&kafkaRequest.DesiredKafkaIBPVersion, kafkaUpdateReq.KafkaIbpVersion) || updateRequired
+			var updateRequired bool
+			if maintenanceWindow != nil {
+				// version upgrades are deferred to the maintenance window configured for the kafka or its organisation
+				updateRequired = queue(&kafkaRequest.PendingKafkaVersion, kafkaRequest.DesiredKafkaVersion, kafkaUpdateReq.KafkaVersion)
+				updateRequired = queue(&kafkaRequest.PendingStrimziVersion, kafkaRequest.DesiredStrimziVersion, kafkaUpdateReq.StrimziVersion) || updateRequired
+				updateRequired = queue(&kafkaRequest.PendingKafkaIBPVersion, kafkaRequest.DesiredKafkaIBPVersion, kafkaUpdateReq.KafkaIbpVersion) || updateRequired
+			} else {
+				updateRequired = update(&kafkaRequest.DesiredKafkaVersion, kafkaUpdateReq.KafkaVersion)
+				updateRequired = update(&kafkaRequest.DesiredStrimziVersion, kafkaUpdateReq.StrimziVersion) || updateRequired
+				updateRequired = update(&kafkaRequest.DesiredKafkaIBPVersion, kafkaUpdateReq.KafkaIbpVersion) || updateRequired
+			}
 			updateRequired = update(&kafkaRequest.KafkaStorageSize, requestedStorageSize) || updateRequired
 
 			newStatus := getStatusBasedOnSuspendedParam(kafkaUpdateReq.Suspended, kafkaRequest)
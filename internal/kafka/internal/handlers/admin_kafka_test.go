@@ -79,7 +79,7 @@ func Test_Get(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService)
+			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService, nil)
 			req, rw := GetHandlerParams("GET", "/{id}", nil, t)
 			h.Get(rw, req)
 			resp := rw.Result()
@@ -168,7 +168,7 @@ func Test_List(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService)
+			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService, nil)
 			req, rw := GetHandlerParams("GET", tt.args.url, nil, t)
 			h.List(rw, req)
 			resp := rw.Result()
@@ -223,7 +223,7 @@ func Test_Delete(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService)
+			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService, nil)
 			req, rw := GetHandlerParams("DELETE", tt.args.url, nil, t)
 			h.Delete(rw, req)
 			resp := rw.Result()
@@ -233,23 +233,263 @@ func Test_Delete(t *testing.T) {
 	}
 }
 
-func Test_adminKafkaHandler_Update(t *testing.T) {
+func Test_Migrate(t *testing.T) {
 	type fields struct {
 		kafkaService   services.KafkaService
 		accountService account.AccountService
-		providerConfig *config.ProviderConfig
-		clusterService services.ClusterService
+	}
+
+	tests := []struct {
+		name            string
+		fields          fields
+		body            []byte
+		wantStatusCode  int
+		wantTargetID    string
+		wantMigrateCall bool
+	}{
+		{
+			name: "should accept the migration of a kafka to the requested target cluster",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+					},
+					MigrateKafkaFunc: func(kafkaRequest *dbapi.KafkaRequest, targetClusterID string) *errors.ServiceError {
+						return nil
+					},
+				},
+				accountService: account.NewMockAccountService(),
+			},
+			body:            []byte(`{"target_cluster_id": "target-cluster"}`),
+			wantStatusCode:  http.StatusAccepted,
+			wantTargetID:    "target-cluster",
+			wantMigrateCall: true,
+		},
+		{
+			name: "should accept the migration of a kafka without a target cluster",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+					},
+					MigrateKafkaFunc: func(kafkaRequest *dbapi.KafkaRequest, targetClusterID string) *errors.ServiceError {
+						return nil
+					},
+				},
+				accountService: account.NewMockAccountService(),
+			},
+			body:            []byte(`{}`),
+			wantStatusCode:  http.StatusAccepted,
+			wantMigrateCall: true,
+		},
+		{
+			name: "should return a conflict if the kafka is already being migrated",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+					},
+					MigrateKafkaFunc: func(kafkaRequest *dbapi.KafkaRequest, targetClusterID string) *errors.ServiceError {
+						return errors.Conflict("already migrating")
+					},
+				},
+				accountService: account.NewMockAccountService(),
+			},
+			body:            []byte(`{}`),
+			wantStatusCode:  http.StatusConflict,
+			wantMigrateCall: true,
+		},
+		{
+			name: "should return an error if the kafka cannot be found",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return nil, errors.NotFound("not found")
+					},
+				},
+				accountService: account.NewMockAccountService(),
+			},
+			body:           []byte(`{}`),
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, nil, nil, nil)
+			req, rw := GetHandlerParams("POST", "/kafkas/{id}/migrate", bytes.NewBuffer(tt.body), t)
+			h.Migrate(rw, req)
+			resp := rw.Result()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			resp.Body.Close()
+
+			calls := tt.fields.kafkaService.(*services.KafkaServiceMock).MigrateKafkaCalls()
+			g.Expect(len(calls) == 1).To(gomega.Equal(tt.wantMigrateCall))
+			if tt.wantMigrateCall {
+				g.Expect(calls[0].TargetClusterID).To(gomega.Equal(tt.wantTargetID))
+			}
+		})
+	}
+}
+
+func Test_Failover(t *testing.T) {
+	type fields struct {
+		kafkaService   services.KafkaService
+		accountService account.AccountService
+	}
+
+	tests := []struct {
+		name             string
+		fields           fields
+		wantStatusCode   int
+		wantFailoverCall bool
+	}{
+		{
+			name: "should accept the failover of a kafka disaster recovery pair",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+					},
+					FailoverKafkaFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						return nil
+					},
+				},
+				accountService: account.NewMockAccountService(),
+			},
+			wantStatusCode:   http.StatusAccepted,
+			wantFailoverCall: true,
+		},
+		{
+			name: "should return a bad request if the kafka is not paired",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+					},
+					FailoverKafkaFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						return errors.Validation("not paired")
+					},
+				},
+				accountService: account.NewMockAccountService(),
+			},
+			wantStatusCode:   http.StatusBadRequest,
+			wantFailoverCall: true,
+		},
+		{
+			name: "should return an error if the kafka cannot be found",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return nil, errors.NotFound("not found")
+					},
+				},
+				accountService: account.NewMockAccountService(),
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, nil, nil, nil)
+			req, rw := GetHandlerParams("POST", "/kafkas/{id}/failover", nil, t)
+			h.Failover(rw, req)
+			resp := rw.Result()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			resp.Body.Close()
+
+			calls := tt.fields.kafkaService.(*services.KafkaServiceMock).FailoverKafkaCalls()
+			g.Expect(len(calls) == 1).To(gomega.Equal(tt.wantFailoverCall))
+		})
+	}
+}
+
+func Test_DrainCluster(t *testing.T) {
+	type fields struct {
+		kafkaService   services.KafkaService
+		accountService account.AccountService
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantStatusCode int
+		wantTotal      int32
+	}{
+		{
+			name: "should return the kafkas whose migration has been scheduled",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					DrainClusterFunc: func(clusterID string) (dbapi.KafkaList, *errors.ServiceError) {
+						return dbapi.KafkaList{
+							mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()),
+							mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()),
+						}, nil
+					},
+				},
+				accountService: account.NewMockAccountService(),
+			},
+			wantStatusCode: http.StatusAccepted,
+			wantTotal:      2,
+		},
+		{
+			name: "should return an error if the cluster cannot be found",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					DrainClusterFunc: func(clusterID string) (dbapi.KafkaList, *errors.ServiceError) {
+						return nil, errors.NotFound("not found")
+					},
+				},
+				accountService: account.NewMockAccountService(),
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, nil, nil, nil)
+			req, rw := GetHandlerParams("POST", "/clusters/{id}/drain", nil, t)
+			h.DrainCluster(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusAccepted {
+				var kafkaList private.KafkaList
+				g.Expect(json.NewDecoder(resp.Body).Decode(&kafkaList)).To(gomega.Succeed())
+				g.Expect(kafkaList.Total).To(gomega.Equal(tt.wantTotal))
+				g.Expect(kafkaList.Items).To(gomega.HaveLen(int(tt.wantTotal)))
+			}
+		})
+	}
+}
+
+func Test_adminKafkaHandler_Update(t *testing.T) {
+	type fields struct {
+		kafkaService             services.KafkaService
+		accountService           account.AccountService
+		providerConfig           *config.ProviderConfig
+		clusterService           services.ClusterService
+		maintenanceWindowService services.MaintenanceWindowService
 	}
 	type args struct {
 		url  string
 		body []byte
 	}
 	tests := []struct {
-		name            string
-		fields          fields
-		args            args
-		wantStatusCode  int
-		wantKafkaStatus constants.KafkaStatus
+		name                    string
+		fields                  fields
+		args                    args
+		wantStatusCode          int
+		wantKafkaStatus         constants.KafkaStatus
+		wantPendingKafkaVersion string
 	}{
 		{
 			name: "should return an error if retrieving kafka to update fails",
@@ -873,13 +1113,82 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 			wantStatusCode:  http.StatusOK,
 			wantKafkaStatus: constants.KafkaRequestStatusSuspending,
 		},
+		{
+			name: "should queue the kafka version upgrade when a maintenance window is configured",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return &api.Cluster{
+							Meta: api.Meta{
+								ID: "id",
+							},
+							ClusterID: clusterID,
+						}, nil
+					},
+					IsStrimziKafkaVersionAvailableInClusterFunc: func(cluster *api.Cluster, strimziVersion, kafkaVersion, ibpVersion string) (bool, error) {
+						return true, nil
+					},
+					CheckStrimziVersionReadyFunc: func(cluster *api.Cluster, strimziVersion string) (bool, error) {
+						return true, nil
+					},
+				},
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return &dbapi.KafkaRequest{
+							Status: constants.KafkaRequestStatusReady.String(),
+							Meta: api.Meta{
+								ID: "id",
+							},
+							ClusterID:              "cluster-id",
+							ActualKafkaIBPVersion:  "2.8",
+							DesiredKafkaIBPVersion: "2.8",
+							ActualKafkaVersion:     "2.8",
+							DesiredKafkaVersion:    "2.8",
+							DesiredStrimziVersion:  "2.8",
+							KafkaStorageSize:       "100",
+						}, nil
+					},
+					VerifyAndUpdateKafkaAdminFunc: func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						if kafkaRequest.DesiredKafkaVersion != "2.8" {
+							return errors.GeneralError("desired kafka version should not be updated")
+						}
+						return nil
+					},
+				},
+				accountService: account.NewMockAccountService(),
+				maintenanceWindowService: &services.MaintenanceWindowServiceMock{
+					FindForKafkaFunc: func(kafka *dbapi.KafkaRequest) (*dbapi.MaintenanceWindow, *errors.ServiceError) {
+						return &dbapi.MaintenanceWindow{
+							DayOfWeek:     "sunday",
+							StartTime:     "02:00",
+							DurationHours: 4,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				url:  kafkaByIdUrl,
+				body: []byte(`{"kafka_version": "2.8.1"}`),
+			},
+			wantStatusCode:          http.StatusOK,
+			wantKafkaStatus:         constants.KafkaRequestStatusReady,
+			wantPendingKafkaVersion: "2.8.1",
+		},
 	}
 
 	for _, testcase := range tests {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService)
+			maintenanceWindowService := tt.fields.maintenanceWindowService
+			if maintenanceWindowService == nil {
+				maintenanceWindowService = &services.MaintenanceWindowServiceMock{
+					FindForKafkaFunc: func(kafka *dbapi.KafkaRequest) (*dbapi.MaintenanceWindow, *errors.ServiceError) {
+						return nil, nil
+					},
+				}
+			}
+			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService, maintenanceWindowService)
 			req, rw := GetHandlerParams("PATCH", tt.args.url, bytes.NewBuffer(tt.args.body), t)
 			h.Update(rw, req)
 			resp := rw.Result()
@@ -889,6 +1198,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 				err := json.NewDecoder(resp.Body).Decode(&kafka)
 				g.Expect(err).NotTo(gomega.HaveOccurred())
 				g.Expect(kafka.Status).To(gomega.Equal(tt.wantKafkaStatus.String()))
+				g.Expect(kafka.PendingKafkaVersion).To(gomega.Equal(tt.wantPendingKafkaVersion))
 			}
 			resp.Body.Close()
 		})
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/gorilla/mux"
+)
+
+const rolloutPausedByAdminReason = "paused by admin"
+
+type adminKafkaUpgradeRolloutHandler struct {
+	rolloutService services.KafkaUpgradeRolloutService
+}
+
+func NewAdminKafkaUpgradeRolloutHandler(rolloutService services.KafkaUpgradeRolloutService) *adminKafkaUpgradeRolloutHandler {
+	return &adminKafkaUpgradeRolloutHandler{
+		rolloutService: rolloutService,
+	}
+}
+
+// Create starts a staged upgrade of the kafka instances matching the query of the request
+func (h adminKafkaUpgradeRolloutHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var rolloutRequest private.KafkaUpgradeRolloutRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &rolloutRequest,
+		Validate: []handlers.Validate{
+			handlers.ValidateLength(&rolloutRequest.Query, "query", handlers.MinRequiredFieldLength, nil),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			rollout := presenters.ConvertKafkaUpgradeRolloutRequest(rolloutRequest)
+			if err := h.rolloutService.Create(rollout); err != nil {
+				return nil, err
+			}
+			return presenters.PresentKafkaUpgradeRollout(rollout), nil
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusCreated)
+}
+
+func (h adminKafkaUpgradeRolloutHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+
+			rollouts, paging, err := h.rolloutService.List(listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			rolloutList := private.KafkaUpgradeRolloutList{
+				Kind:  "KafkaUpgradeRolloutList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []private.KafkaUpgradeRollout{},
+			}
+
+			for _, rollout := range rollouts {
+				rolloutList.Items = append(rolloutList.Items, presenters.PresentKafkaUpgradeRollout(rollout))
+			}
+
+			return rolloutList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+func (h adminKafkaUpgradeRolloutHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			rollout, err := h.rolloutService.Get(mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentKafkaUpgradeRollout(rollout), nil
+		},
+	}
+	handlers.HandleGet(w, r, cfg)
+}
+
+// Pause stops the rollout from upgrading further kafka instances
+func (h adminKafkaUpgradeRolloutHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, func(rollout *dbapi.KafkaUpgradeRollout) *errors.ServiceError {
+		return h.rolloutService.Pause(rollout, rolloutPausedByAdminReason)
+	})
+}
+
+func (h adminKafkaUpgradeRolloutHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.rolloutService.Resume)
+}
+
+// Rollback reverts the desired versions of the kafka instances the rollout has started to upgrade
+func (h adminKafkaUpgradeRolloutHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.rolloutService.Rollback)
+}
+
+func (h adminKafkaUpgradeRolloutHandler) transition(w http.ResponseWriter, r *http.Request, transition func(rollout *dbapi.KafkaUpgradeRollout) *errors.ServiceError) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			rollout, err := h.rolloutService.Get(mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+			if err := transition(rollout); err != nil {
+				return nil, err
+			}
+			return presenters.PresentKafkaUpgradeRollout(rollout), nil
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
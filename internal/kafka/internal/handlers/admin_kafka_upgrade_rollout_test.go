@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+func Test_adminKafkaUpgradeRolloutHandler_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		rolloutService services.KafkaUpgradeRolloutService
+		body           []byte
+		wantStatusCode int
+		want           private.KafkaUpgradeRollout
+	}{
+		{
+			name: "should return the created rollout",
+			rolloutService: &services.KafkaUpgradeRolloutServiceMock{
+				CreateFunc: func(rollout *dbapi.KafkaUpgradeRollout) *errors.ServiceError {
+					if rollout.Query != "region = us-east-1" || rollout.StrimziVersion != "strimzi-cluster-operator.v0.30.0-0" ||
+						rollout.CanaryCount != 1 || rollout.BatchPercentage != 50 || rollout.SoakTimeMinutes != 30 {
+						return errors.GeneralError("unexpected rollout")
+					}
+					rollout.ID = "rollout-id"
+					rollout.Status = constants.KafkaUpgradeRolloutStatusInProgress.String()
+					rollout.TotalWaves = 3
+					return nil
+				},
+			},
+			body:           []byte(`{"query": "region = us-east-1", "strimzi_version": "strimzi-cluster-operator.v0.30.0-0", "canary_count": 1, "batch_percentage": 50, "soak_time_minutes": 30}`),
+			wantStatusCode: http.StatusCreated,
+			want: private.KafkaUpgradeRollout{
+				Id:              "rollout-id",
+				Kind:            "KafkaUpgradeRollout",
+				Href:            "/api/kafkas_mgmt/v1/admin/kafka_upgrade_rollouts/rollout-id",
+				Query:           "region = us-east-1",
+				StrimziVersion:  "strimzi-cluster-operator.v0.30.0-0",
+				CanaryCount:     1,
+				BatchPercentage: 50,
+				SoakTimeMinutes: 30,
+				Status:          constants.KafkaUpgradeRolloutStatusInProgress.String(),
+				TotalWaves:      3,
+			},
+		},
+		{
+			name:           "should return bad request when the query is missing",
+			rolloutService: &services.KafkaUpgradeRolloutServiceMock{},
+			body:           []byte(`{"strimzi_version": "strimzi-cluster-operator.v0.30.0-0", "canary_count": 1, "batch_percentage": 50}`),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return bad request when no kafka matches the query",
+			rolloutService: &services.KafkaUpgradeRolloutServiceMock{
+				CreateFunc: func(rollout *dbapi.KafkaUpgradeRollout) *errors.ServiceError {
+					return errors.Validation("no kafka instance matches the query")
+				},
+			},
+			body:           []byte(`{"query": "region = us-east-1", "strimzi_version": "strimzi-cluster-operator.v0.30.0-0", "canary_count": 1, "batch_percentage": 50}`),
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminKafkaUpgradeRolloutHandler(tt.rolloutService)
+			req, rw := GetHandlerParams(http.MethodPost, "/kafka_upgrade_rollouts", bytes.NewBuffer(tt.body), t)
+			h.Create(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusCreated {
+				var got private.KafkaUpgradeRollout
+				g.Expect(json.NewDecoder(resp.Body).Decode(&got)).To(gomega.Succeed())
+				g.Expect(got).To(gomega.Equal(tt.want))
+			}
+		})
+	}
+}
+
+func Test_adminKafkaUpgradeRolloutHandler_Pause(t *testing.T) {
+	inProgressRollout := func() *dbapi.KafkaUpgradeRollout {
+		return &dbapi.KafkaUpgradeRollout{
+			Meta:   api.Meta{ID: "rollout-id"},
+			Status: constants.KafkaUpgradeRolloutStatusInProgress.String(),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		rolloutService services.KafkaUpgradeRolloutService
+		wantStatusCode int
+		wantStatus     string
+	}{
+		{
+			name: "should pause the rollout",
+			rolloutService: &services.KafkaUpgradeRolloutServiceMock{
+				GetFunc: func(id string) (*dbapi.KafkaUpgradeRollout, *errors.ServiceError) {
+					return inProgressRollout(), nil
+				},
+				PauseFunc: func(rollout *dbapi.KafkaUpgradeRollout, reason string) *errors.ServiceError {
+					rollout.Status = constants.KafkaUpgradeRolloutStatusPaused.String()
+					rollout.StatusReason = reason
+					return nil
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantStatus:     constants.KafkaUpgradeRolloutStatusPaused.String(),
+		},
+		{
+			name: "should return not found when the rollout does not exist",
+			rolloutService: &services.KafkaUpgradeRolloutServiceMock{
+				GetFunc: func(id string) (*dbapi.KafkaUpgradeRollout, *errors.ServiceError) {
+					return nil, errors.NotFound("rollout %q not found", id)
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should return conflict when the rollout status changed concurrently",
+			rolloutService: &services.KafkaUpgradeRolloutServiceMock{
+				GetFunc: func(id string) (*dbapi.KafkaUpgradeRollout, *errors.ServiceError) {
+					return inProgressRollout(), nil
+				},
+				PauseFunc: func(rollout *dbapi.KafkaUpgradeRollout, reason string) *errors.ServiceError {
+					return errors.Conflict("rollout is no longer in progress")
+				},
+			},
+			wantStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminKafkaUpgradeRolloutHandler(tt.rolloutService)
+			req, rw := GetHandlerParams(http.MethodPost, "/kafka_upgrade_rollouts/rollout-id/pause", nil, t)
+			req = mux.SetURLVars(req, map[string]string{"id": "rollout-id"})
+			h.Pause(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusOK {
+				var got private.KafkaUpgradeRollout
+				g.Expect(json.NewDecoder(resp.Body).Decode(&got)).To(gomega.Succeed())
+				g.Expect(got.Status).To(gomega.Equal(tt.wantStatus))
+				g.Expect(got.StatusReason).To(gomega.Equal(rolloutPausedByAdminReason))
+			}
+		})
+	}
+}
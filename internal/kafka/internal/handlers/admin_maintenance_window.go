@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"github.com/gorilla/mux"
+)
+
+type adminMaintenanceWindowHandler struct {
+	kafkaService             services.KafkaService
+	maintenanceWindowService services.MaintenanceWindowService
+}
+
+func NewAdminMaintenanceWindowHandler(kafkaService services.KafkaService, maintenanceWindowService services.MaintenanceWindowService) *adminMaintenanceWindowHandler {
+	return &adminMaintenanceWindowHandler{
+		kafkaService:             kafkaService,
+		maintenanceWindowService: maintenanceWindowService,
+	}
+}
+
+// GetForKafka returns the maintenance window configured for a kafka instance
+func (h adminMaintenanceWindowHandler) GetForKafka(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			kafkaRequest, err := h.kafkaService.Get(r.Context(), id)
+			if err != nil {
+				return nil, err
+			}
+			window, err := h.maintenanceWindowService.Get(kafkaRequest.OrganisationId, kafkaRequest.ID)
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentMaintenanceWindowAdminEndpoint(window), nil
+		},
+	}
+	handlers.HandleGet(w, r, cfg)
+}
+
+// UpdateForKafka creates or replaces the maintenance window of a kafka instance
+func (h adminMaintenanceWindowHandler) UpdateForKafka(w http.ResponseWriter, r *http.Request) {
+	var windowRequest private.MaintenanceWindowRequest
+	id := mux.Vars(r)["id"]
+	kafkaRequest, kafkaGetError := h.kafkaService.Get(r.Context(), id)
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &windowRequest,
+		Validate: []handlers.Validate{
+			func() *errors.ServiceError {
+				return kafkaGetError
+			},
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			window := presenters.ConvertMaintenanceWindowAdminRequest(windowRequest)
+			window.OrganisationId = kafkaRequest.OrganisationId
+			window.KafkaId = kafkaRequest.ID
+			if err := h.maintenanceWindowService.Upsert(window); err != nil {
+				return nil, err
+			}
+			return presenters.PresentMaintenanceWindowAdminEndpoint(window), nil
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// DeleteForKafka removes the maintenance window of a kafka instance
+func (h adminMaintenanceWindowHandler) DeleteForKafka(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	kafkaRequest, kafkaGetError := h.kafkaService.Get(r.Context(), id)
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			func() *errors.ServiceError {
+				return kafkaGetError
+			},
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			return nil, h.maintenanceWindowService.Delete(kafkaRequest.OrganisationId, kafkaRequest.ID)
+		},
+	}
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+// GetForOrganisation returns the maintenance window configured for an organisation
+func (h adminMaintenanceWindowHandler) GetForOrganisation(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			window, err := h.maintenanceWindowService.Get(mux.Vars(r)["id"], "")
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentMaintenanceWindowAdminEndpoint(window), nil
+		},
+	}
+	handlers.HandleGet(w, r, cfg)
+}
+
+// UpdateForOrganisation creates or replaces the maintenance window of an organisation
+func (h adminMaintenanceWindowHandler) UpdateForOrganisation(w http.ResponseWriter, r *http.Request) {
+	var windowRequest private.MaintenanceWindowRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &windowRequest,
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			window := presenters.ConvertMaintenanceWindowAdminRequest(windowRequest)
+			window.OrganisationId = mux.Vars(r)["id"]
+			if err := h.maintenanceWindowService.Upsert(window); err != nil {
+				return nil, err
+			}
+			return presenters.PresentMaintenanceWindowAdminEndpoint(window), nil
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// DeleteForOrganisation removes the maintenance window of an organisation
+func (h adminMaintenanceWindowHandler) DeleteForOrganisation(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			return nil, h.maintenanceWindowService.Delete(mux.Vars(r)["id"], "")
+		},
+	}
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
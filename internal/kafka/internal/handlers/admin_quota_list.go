@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/gorilla/mux"
+)
+
+type adminQuotaListHandler struct {
+	quotaListService services.QuotaListService
+}
+
+func NewAdminQuotaListHandler(quotaListService services.QuotaListService) *adminQuotaListHandler {
+	return &adminQuotaListHandler{
+		quotaListService: quotaListService,
+	}
+}
+
+func (h adminQuotaListHandler) ListOrganisations(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+
+			entries, paging, err := h.quotaListService.ListOrganisations(listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			orgList := private.QuotaListOrganisationList{
+				Kind:  "QuotaListOrganisationList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []private.QuotaListOrganisation{},
+			}
+
+			for _, entry := range entries {
+				org, err := presenters.PresentQuotaListOrganisation(entry)
+				if err != nil {
+					return nil, err
+				}
+				orgList.Items = append(orgList.Items, org)
+			}
+
+			return orgList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+func (h adminQuotaListHandler) GetOrganisation(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			entry, err := h.quotaListService.GetOrganisation(mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentQuotaListOrganisation(entry)
+		},
+	}
+	handlers.HandleGet(w, r, cfg)
+}
+
+// UpdateOrganisation adds the organisation to the quota management list or replaces it
+func (h adminQuotaListHandler) UpdateOrganisation(w http.ResponseWriter, r *http.Request) {
+	var orgRequest private.QuotaListOrganisationRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &orgRequest,
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			org, err := presenters.ConvertQuotaListOrganisationRequest(mux.Vars(r)["id"], orgRequest)
+			if err != nil {
+				return nil, err
+			}
+			entry, err := h.quotaListService.UpsertOrganisation(org, changedBy(r))
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentQuotaListOrganisation(entry)
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// DeleteOrganisation removes the organisation from the quota management list
+func (h adminQuotaListHandler) DeleteOrganisation(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			return nil, h.quotaListService.DeleteOrganisation(mux.Vars(r)["id"], changedBy(r))
+		},
+	}
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+func (h adminQuotaListHandler) ListServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+
+			entries, paging, err := h.quotaListService.ListServiceAccounts(listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			accountList := private.QuotaListServiceAccountList{
+				Kind:  "QuotaListServiceAccountList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []private.QuotaListServiceAccount{},
+			}
+
+			for _, entry := range entries {
+				account, err := presenters.PresentQuotaListServiceAccount(entry)
+				if err != nil {
+					return nil, err
+				}
+				accountList.Items = append(accountList.Items, account)
+			}
+
+			return accountList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+func (h adminQuotaListHandler) GetServiceAccount(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			entry, err := h.quotaListService.GetServiceAccount(mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentQuotaListServiceAccount(entry)
+		},
+	}
+	handlers.HandleGet(w, r, cfg)
+}
+
+// UpdateServiceAccount adds the service account to the quota management list or replaces it
+func (h adminQuotaListHandler) UpdateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var accountRequest private.QuotaListServiceAccountRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &accountRequest,
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			account, err := presenters.ConvertQuotaListServiceAccountRequest(mux.Vars(r)["id"], accountRequest)
+			if err != nil {
+				return nil, err
+			}
+			entry, err := h.quotaListService.UpsertServiceAccount(account, changedBy(r))
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentQuotaListServiceAccount(entry)
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// DeleteServiceAccount removes the service account from the quota management list
+func (h adminQuotaListHandler) DeleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			return nil, h.quotaListService.DeleteServiceAccount(mux.Vars(r)["id"], changedBy(r))
+		},
+	}
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+// ListChanges returns the changes of the quota management list, optionally filtered by resource type and id
+func (h adminQuotaListHandler) ListChanges(w http.ResponseWriter, r *http.Request) {
+	resourceType := r.URL.Query().Get("resource_type")
+	resourceID := r.URL.Query().Get("resource_id")
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			func() *errors.ServiceError {
+				if resourceType != "" && resourceType != dbapi.QuotaListResourceOrganisation && resourceType != dbapi.QuotaListResourceServiceAccount {
+					return errors.BadRequest("resource_type must be either %q or %q", dbapi.QuotaListResourceOrganisation, dbapi.QuotaListResourceServiceAccount)
+				}
+				return nil
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+
+			changes, paging, err := h.quotaListService.ListChanges(resourceType, resourceID, listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			changeList := private.QuotaListChangeList{
+				Kind:  "QuotaListChangeList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []private.QuotaListChange{},
+			}
+
+			for _, change := range changes {
+				item, err := presenters.PresentQuotaListChange(change)
+				if err != nil {
+					return nil, err
+				}
+				changeList.Items = append(changeList.Items, item)
+			}
+
+			return changeList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+// changedBy returns the username of the admin making the request, recorded with the changes of the quota management list
+func changedBy(r *http.Request) string {
+	claims, err := getClaims(r.Context())
+	if err != nil {
+		return ""
+	}
+	username, _ := claims.GetUsername()
+	return username
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+func Test_adminQuotaListHandler_UpdateOrganisation(t *testing.T) {
+	tests := []struct {
+		name             string
+		quotaListService services.QuotaListService
+		body             []byte
+		wantStatusCode   int
+		want             private.QuotaListOrganisation
+	}{
+		{
+			name: "should return the added organisation",
+			quotaListService: &services.QuotaListServiceMock{
+				UpsertOrganisationFunc: func(org quota_management.Organisation, changedBy string) (*dbapi.QuotaListOrganisation, *errors.ServiceError) {
+					if org.Id != "org-id" || len(org.RegisteredUsers) != 1 || len(org.GrantedQuota) != 1 ||
+						org.GrantedQuota[0].KafkaBillingModels[0].ExpirationDate == nil {
+						return nil, errors.GeneralError("unexpected organisation")
+					}
+					entry, err := dbapi.NewQuotaListOrganisation(org)
+					if err != nil {
+						return nil, errors.GeneralError("unexpected organisation")
+					}
+					entry.Meta = api.Meta{ID: "entry-id"}
+					return entry, nil
+				},
+			},
+			body:           []byte(`{"max_allowed_instances": 5, "registered_users": ["user-1"], "granted_quota": [{"instance_type_id": "standard", "kafka_billing_models": [{"id": "enterprise", "expiration_date": "2023-12-31 +00:00", "grace_period_days": 7}]}]}`),
+			wantStatusCode: http.StatusOK,
+			want: private.QuotaListOrganisation{
+				Id:                  "org-id",
+				Kind:                "QuotaListOrganisation",
+				Href:                "/api/kafkas_mgmt/v1/admin/quota_management_list/organisations/org-id",
+				MaxAllowedInstances: 5,
+				RegisteredUsers:     []string{"user-1"},
+				GrantedQuota: []private.QuotaListGrantedQuota{
+					{
+						InstanceTypeId: "standard",
+						KafkaBillingModels: []private.QuotaListBillingModel{
+							{Id: "enterprise", ExpirationDate: "2023-12-31 +00:00", GracePeriodDays: 7},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:             "should return bad request when the expiration date is invalid",
+			quotaListService: &services.QuotaListServiceMock{},
+			body:             []byte(`{"granted_quota": [{"instance_type_id": "standard", "kafka_billing_models": [{"id": "enterprise", "expiration_date": "2023-12-31"}]}]}`),
+			wantStatusCode:   http.StatusBadRequest,
+		},
+		{
+			name: "should return bad request when the granted quota is invalid",
+			quotaListService: &services.QuotaListServiceMock{
+				UpsertOrganisationFunc: func(org quota_management.Organisation, changedBy string) (*dbapi.QuotaListOrganisation, *errors.ServiceError) {
+					return nil, errors.Validation("invalid granted quota")
+				},
+			},
+			body:           []byte(`{"granted_quota": [{"instance_type_id": ""}]}`),
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminQuotaListHandler(tt.quotaListService)
+			req, rw := GetHandlerParams(http.MethodPut, "/quota_management_list/organisations/org-id", bytes.NewBuffer(tt.body), t)
+			req = mux.SetURLVars(req, map[string]string{"id": "org-id"})
+			h.UpdateOrganisation(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusOK {
+				var got private.QuotaListOrganisation
+				g.Expect(json.NewDecoder(resp.Body).Decode(&got)).To(gomega.Succeed())
+				g.Expect(got).To(gomega.Equal(tt.want))
+			}
+		})
+	}
+}
+
+func Test_adminQuotaListHandler_ListChanges(t *testing.T) {
+	tests := []struct {
+		name             string
+		quotaListService services.QuotaListService
+		query            string
+		wantStatusCode   int
+		wantItems        int
+	}{
+		{
+			name: "should return the changes of the organisation",
+			quotaListService: &services.QuotaListServiceMock{
+				ListChangesFunc: func(resourceType string, resourceID string, listArgs *coreServices.ListArguments) (dbapi.QuotaListChangeList, *api.PagingMeta, *errors.ServiceError) {
+					if resourceType != dbapi.QuotaListResourceOrganisation || resourceID != "org-id" {
+						return nil, nil, errors.GeneralError("unexpected filter")
+					}
+					return dbapi.QuotaListChangeList{
+						{
+							Meta:         api.Meta{ID: "change-id"},
+							ResourceType: resourceType,
+							ResourceId:   resourceID,
+							Action:       dbapi.QuotaListChangeCreated,
+							ChangedBy:    "admin",
+							Value:        api.JSON(`{"organisation_id": "org-id"}`),
+						},
+					}, &api.PagingMeta{Page: 1, Size: 1, Total: 1}, nil
+				},
+			},
+			query:          "?resource_type=organisation&resource_id=org-id",
+			wantStatusCode: http.StatusOK,
+			wantItems:      1,
+		},
+		{
+			name:             "should return bad request when the resource type is unknown",
+			quotaListService: &services.QuotaListServiceMock{},
+			query:            "?resource_type=cluster",
+			wantStatusCode:   http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminQuotaListHandler(tt.quotaListService)
+			req, rw := GetHandlerParams(http.MethodGet, "/quota_management_list/changes"+tt.query, nil, t)
+			h.ListChanges(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusOK {
+				var got private.QuotaListChangeList
+				g.Expect(json.NewDecoder(resp.Body).Decode(&got)).To(gomega.Succeed())
+				g.Expect(got.Items).To(gomega.HaveLen(tt.wantItems))
+				g.Expect(got.Items[0].Value).To(gomega.HaveKeyWithValue("organisation_id", "org-id"))
+			}
+		})
+	}
+}
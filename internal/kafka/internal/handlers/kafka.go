@@ -1,14 +1,20 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
 	config "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
 
 	"github.com/gorilla/mux"
 
@@ -21,18 +27,30 @@ type kafkaHandler struct {
 	providerConfig *config.ProviderConfig
 	authService    authorization.Authorization
 	kafkaConfig    *config.KafkaConfig
+	bus            signalbus.SignalBus
 }
 
+const (
+	// kafkaWatchSignal is notified on the signalbus by the database whenever a kafka request is changed
+	kafkaWatchSignal = "/kafkas"
+
+	kafkaWatchEventAdded    = "ADDED"
+	kafkaWatchEventModified = "MODIFIED"
+	kafkaWatchEventDeleted  = "DELETED"
+	kafkaWatchEventBookmark = "BOOKMARK"
+)
+
 func GetAcceptedOrderByParams() []string {
 	return []string{"bootstrap_server_host", "cloud_provider", "cluster_id", "created_at", "href", "id", "instance_type", "multi_az", "name", "organisation_id", "owner", "reauthentication_enabled", "region", "status", "updated_at", "version"}
 }
 
-func NewKafkaHandler(service services.KafkaService, providerConfig *config.ProviderConfig, authService authorization.Authorization, kafkaConfig *config.KafkaConfig) *kafkaHandler {
+func NewKafkaHandler(service services.KafkaService, providerConfig *config.ProviderConfig, authService authorization.Authorization, kafkaConfig *config.KafkaConfig, bus signalbus.SignalBus) *kafkaHandler {
 	return &kafkaHandler{
 		service:        service,
 		providerConfig: providerConfig,
 		authService:    authService,
 		kafkaConfig:    kafkaConfig,
+		bus:            bus,
 	}
 }
 
@@ -52,6 +70,7 @@ func (h kafkaHandler) Create(w http.ResponseWriter, r *http.Request) {
 			ValidateKafkaPlan(ctx, h.service, h.kafkaConfig, &kafkaRequestPayload),
 			ValidateBillingCloudAccountIdAndMarketplace(ctx, h.service, &kafkaRequestPayload),
 			ValidateBillingModel(&kafkaRequestPayload),
+			ValidateKafkaDrPrimary(ctx, h.service, &kafkaRequestPayload),
 		},
 		Action: func() (interface{}, *errors.ServiceError) {
 			convKafka := presenters.ConvertKafkaRequest(kafkaRequestPayload)
@@ -120,6 +139,10 @@ func (h kafkaHandler) List(w http.ResponseWriter, r *http.Request) {
 				return nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list kafka requests: %s", err.Error())
 			}
 
+			if r.URL.Query().Get("watch") == "true" {
+				return h.watch(ctx, listArgs, r.URL.Query().Get("gt_version"))
+			}
+
 			kafkaRequests, paging, err := h.service.List(ctx, listArgs)
 			if err != nil {
 				return nil, err
@@ -148,6 +171,108 @@ func (h kafkaHandler) List(w http.ResponseWriter, r *http.Request) {
 	handlers.HandleList(w, r, cfg)
 }
 
+// watch streams the changes of the kafka requests matching listArgs, starting after the given resource version.
+// A watch started without resource version first streams the existing kafka requests as ADDED events.
+// As a resumed watch does not know which kafka requests the client has seen, the first change of a kafka request
+// it streams is reported as ADDED, unless the kafka request has been deleted.
+func (h kafkaHandler) watch(ctx context.Context, listArgs *coreServices.ListArguments, gtVersionParam string) (interface{}, *errors.ServiceError) {
+	gtVersion := int64(0)
+	if gtVersionParam != "" {
+		v, err := strconv.ParseInt(gtVersionParam, 10, 64)
+		if err != nil || v < 0 {
+			return nil, errors.BadRequest("gt_version must be a positive integer")
+		}
+		gtVersion = v
+	}
+
+	resumed := gtVersion > 0
+	seen := map[string]bool{}
+	idx := 0
+	bookmarkSent := false
+
+	getChanges := func() (dbapi.KafkaList, *errors.ServiceError) {
+		// deleted kafka requests are only of interest once the existing ones have been streamed
+		return h.service.ListChanges(ctx, listArgs, gtVersion, gtVersion > 0)
+	}
+
+	changes, err := getChanges()
+	if err != nil {
+		return nil, err
+	}
+
+	sub := h.bus.Subscribe(kafkaWatchSignal)
+	return handlers.EventStream{
+		ContentType: "application/json;stream=watch",
+		Close:       sub.Close,
+		GetNextEvent: func() (interface{}, *errors.ServiceError) {
+			for { // This function blocks until there is an event to return...
+				for idx < len(changes) {
+					kafkaRequest := changes[idx]
+					idx++
+					gtVersion = kafkaRequest.ResourceVersion
+
+					eventType := kafkaWatchEventAdded
+					if kafkaRequest.DeletedAt.Valid {
+						if !seen[kafkaRequest.ID] && !resumed {
+							continue // the client has never been told about this kafka request
+						}
+						delete(seen, kafkaRequest.ID)
+						eventType = kafkaWatchEventDeleted
+					} else if seen[kafkaRequest.ID] {
+						eventType = kafkaWatchEventModified
+					} else {
+						seen[kafkaRequest.ID] = true
+					}
+
+					converted, err := presenters.PresentKafkaRequest(kafkaRequest, h.kafkaConfig)
+					if err != nil {
+						return nil, err
+					}
+					return public.KafkaRequestWatchEvent{
+						Type:            eventType,
+						ResourceVersion: kafkaRequest.ResourceVersion,
+						Object:          &converted,
+					}, nil
+				}
+
+				// get the next changes..
+				changes, err = getChanges()
+				if err != nil {
+					return nil, err
+				}
+				idx = 0
+
+				// did we run out of changes to send?
+				if len(changes) == 0 {
+					if !bookmarkSent {
+						bookmarkSent = true
+						return public.KafkaRequestWatchEvent{
+							Type:            kafkaWatchEventBookmark,
+							ResourceVersion: gtVersion,
+						}, nil
+					}
+
+					// release the DB connection so that we don't tie those up while we wait to poll again..
+					if err := db.Resolve(ctx); err != nil {
+						return nil, errors.GeneralError("internal error")
+					}
+
+					if handlers.WaitForCancelOrTimeoutOrNotification(ctx, 30*time.Second, sub) {
+						// ctx was canceled... likely due to the http connection being closed by
+						// the client.  Signal the event stream is done.
+						return nil, nil
+					}
+
+					// get a new DB connection...
+					if err := db.Begin(ctx); err != nil {
+						return nil, errors.GeneralError("internal error")
+					}
+				}
+			}
+		},
+	}, nil
+}
+
 // Update is the handler for updating a kafka request
 func (h kafkaHandler) Update(w http.ResponseWriter, r *http.Request) {
 	var kafkaUpdateReq public.KafkaUpdateRequest
@@ -193,3 +318,72 @@ func (h kafkaHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 	handlers.Handle(w, r, cfg, http.StatusOK)
 }
+
+// Suspend is the handler for suspending a kafka request
+func (h kafkaHandler) Suspend(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	ctx := r.Context()
+	kafkaRequest, kafkaGetError := h.service.Get(ctx, id)
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			func() *errors.ServiceError {
+				return kafkaGetError
+			},
+			ValidateKafkaOwnerOrOrgAdmin(ctx, kafkaRequest),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			if err := h.service.SuspendKafka(kafkaRequest); err != nil {
+				return nil, err
+			}
+			return presenters.PresentKafkaRequest(kafkaRequest, h.kafkaConfig)
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
+
+// Resize is the handler for changing the size of a kafka request
+func (h kafkaHandler) Resize(w http.ResponseWriter, r *http.Request) {
+	var kafkaResizeReq public.KafkaResizeRequest
+	id := mux.Vars(r)["id"]
+	ctx := r.Context()
+	kafkaRequest, kafkaGetError := h.service.Get(ctx, id)
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &kafkaResizeReq,
+		Validate: []handlers.Validate{
+			func() *errors.ServiceError {
+				return kafkaGetError
+			},
+			ValidateKafkaOwnerOrOrgAdmin(ctx, kafkaRequest),
+			handlers.ValidateMinLength(&kafkaResizeReq.SizeId, "size_id", handlers.MinRequiredFieldLength),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			if err := h.service.ResizeKafka(kafkaRequest, kafkaResizeReq.SizeId); err != nil {
+				return nil, err
+			}
+			return presenters.PresentKafkaRequest(kafkaRequest, h.kafkaConfig)
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
+
+// Resume is the handler for resuming a suspended kafka request
+func (h kafkaHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	ctx := r.Context()
+	kafkaRequest, kafkaGetError := h.service.Get(ctx, id)
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			func() *errors.ServiceError {
+				return kafkaGetError
+			},
+			ValidateKafkaOwnerOrOrgAdmin(ctx, kafkaRequest),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			if err := h.service.ResumeKafka(kafkaRequest); err != nil {
+				return nil, err
+			}
+			return presenters.PresentKafkaRequest(kafkaRequest, h.kafkaConfig)
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
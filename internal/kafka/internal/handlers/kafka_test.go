@@ -3,10 +3,12 @@ package handlers
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
@@ -14,12 +16,16 @@ import (
 	mocksupportedinstancetypes "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/supported_instance_types"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	s "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/gorilla/mux"
 	"github.com/onsi/gomega"
+
+	mocket "github.com/selvatico/go-mocket"
 )
 
 var (
@@ -77,7 +83,7 @@ func Test_KafkaHandler_Get(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig)
+			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig, nil)
 			req, rw := GetHandlerParams("GET", "/{id}", nil, t)
 			req = mux.SetURLVars(req, map[string]string{"id": id})
 			h.Get(rw, req)
@@ -145,7 +151,7 @@ func Test_KafkaHandler_Delete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig)
+			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig, nil)
 			req, rw := GetHandlerParams("DELETE", tt.args.url, nil, t)
 			h.Delete(rw, req)
 			resp := rw.Result()
@@ -238,7 +244,7 @@ func Test_KafkaHandler_List(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig)
+			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig, nil)
 			req, rw := GetHandlerParams("GET", tt.args.url, nil, t)
 			h.List(rw, req)
 			resp := rw.Result()
@@ -248,6 +254,150 @@ func Test_KafkaHandler_List(t *testing.T) {
 	}
 }
 
+func Test_KafkaHandler_ListWatch(t *testing.T) {
+	buildKafka := func(id string, version int64, deleted bool) *dbapi.KafkaRequest {
+		return mocks.BuildKafkaRequest(
+			mocks.WithPredefinedTestValues(),
+			mocks.With(mocks.ID, id),
+			mocks.WithResourceVersion(version),
+			mocks.WithDeleted(deleted),
+		)
+	}
+
+	type args struct {
+		url     string
+		changes []dbapi.KafkaList
+	}
+
+	tests := []struct {
+		name           string
+		args           args
+		listErr        *errors.ServiceError
+		wantStatusCode int
+		wantEvents     []public.KafkaRequestWatchEvent
+	}{
+		{
+			name: "fails if gt_version is not a number",
+			args: args{
+				url: "/kafkas?watch=true&gt_version=abc",
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "fails if ListChanges in the kafka service returns an error",
+			args: args{
+				url: "/kafkas?watch=true",
+			},
+			listErr:        errors.GeneralError("ListChangesFunc returned an error"),
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "streams the existing kafka requests as ADDED events followed by a BOOKMARK event",
+			args: args{
+				url: "/kafkas?watch=true",
+				changes: []dbapi.KafkaList{
+					{buildKafka("kafka-1", 1, false), buildKafka("kafka-2", 2, false)},
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantEvents: []public.KafkaRequestWatchEvent{
+				{Type: kafkaWatchEventAdded, ResourceVersion: 1},
+				{Type: kafkaWatchEventAdded, ResourceVersion: 2},
+				{Type: kafkaWatchEventBookmark, ResourceVersion: 2},
+			},
+		},
+		{
+			name: "streams the changes of the streamed kafka requests as MODIFIED and DELETED events",
+			args: args{
+				url: "/kafkas?watch=true",
+				changes: []dbapi.KafkaList{
+					{buildKafka("kafka-1", 1, false), buildKafka("kafka-2", 2, false)},
+					{buildKafka("kafka-1", 3, false), buildKafka("kafka-2", 4, true), buildKafka("kafka-3", 5, true)},
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantEvents: []public.KafkaRequestWatchEvent{
+				{Type: kafkaWatchEventAdded, ResourceVersion: 1},
+				{Type: kafkaWatchEventAdded, ResourceVersion: 2},
+				{Type: kafkaWatchEventModified, ResourceVersion: 3},
+				{Type: kafkaWatchEventDeleted, ResourceVersion: 4},
+				{Type: kafkaWatchEventBookmark, ResourceVersion: 5},
+			},
+		},
+		{
+			name: "streams the deletion of kafka requests not streamed before when resuming a watch",
+			args: args{
+				url: "/kafkas?watch=true&gt_version=10",
+				changes: []dbapi.KafkaList{
+					{buildKafka("kafka-1", 11, true), buildKafka("kafka-2", 12, false)},
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantEvents: []public.KafkaRequestWatchEvent{
+				{Type: kafkaWatchEventDeleted, ResourceVersion: 11},
+				{Type: kafkaWatchEventAdded, ResourceVersion: 12},
+				{Type: kafkaWatchEventBookmark, ResourceVersion: 12},
+			},
+		},
+	}
+
+	// the watch releases and begins the transaction of the request while waiting for changes
+	mocket.Catcher.Reset().NewMock().WithQuery("select txid_current()").WithReply([]map[string]interface{}{{"txid_current": 1}})
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			watchCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			watchCtx, err := db.NewMockConnectionFactory(nil).NewContext(watchCtx)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+
+			var gtVersions []int64
+			service := &services.KafkaServiceMock{
+				ListChangesFunc: func(ctx context.Context, listArgs *s.ListArguments, gtVersion int64, includeDeleted bool) (dbapi.KafkaList, *errors.ServiceError) {
+					g.Expect(includeDeleted).To(gomega.Equal(gtVersion > 0))
+					if tt.listErr != nil {
+						return nil, tt.listErr
+					}
+					call := len(gtVersions)
+					gtVersions = append(gtVersions, gtVersion)
+					if call < len(tt.args.changes) {
+						return tt.args.changes[call], nil
+					}
+					if call > len(tt.args.changes) {
+						// all the changes and the bookmark have been streamed, close the stream
+						cancel()
+					}
+					return dbapi.KafkaList{}, nil
+				},
+			}
+
+			h := NewKafkaHandler(service, nil, nil, &fullKafkaConfig, signalbus.NewSignalBus())
+			req, rw := GetHandlerParams("GET", tt.args.url, nil, t)
+			h.List(rw, req.WithContext(watchCtx))
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var events []public.KafkaRequestWatchEvent
+			decoder := json.NewDecoder(resp.Body)
+			for decoder.More() {
+				var event public.KafkaRequestWatchEvent
+				g.Expect(decoder.Decode(&event)).To(gomega.Succeed())
+				event.Object = nil
+				events = append(events, event)
+			}
+			g.Expect(events).To(gomega.Equal(tt.wantEvents))
+		})
+	}
+}
+
 func Test_KafkaHandler_Update(t *testing.T) {
 	type fields struct {
 		service        services.KafkaService
@@ -351,7 +501,7 @@ func Test_KafkaHandler_Update(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig)
+			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig, nil)
 			req, rw := GetHandlerParams("PATCH", tt.args.url, bytes.NewBuffer(tt.args.body), t)
 			req = req.WithContext(tt.args.ctx)
 			h.Update(rw, req)
@@ -580,7 +730,7 @@ func Test_KafkaHandler_Create(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig)
+			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig, nil)
 			req, rw := GetHandlerParams("CREATE", tt.args.url, bytes.NewBuffer(tt.args.body), t)
 			req = req.WithContext(tt.args.ctx)
 			h.Create(rw, req)
@@ -590,3 +740,277 @@ func Test_KafkaHandler_Create(t *testing.T) {
 		})
 	}
 }
+
+func Test_KafkaHandler_Suspend(t *testing.T) {
+	type fields struct {
+		service     services.KafkaService
+		kafkaConfig *config.KafkaConfig
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		ctx            context.Context
+		wantStatusCode int
+	}{
+		{
+			name: "should fail if kafka is not found",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return nil, errors.NotFound("not found")
+					},
+				},
+			},
+			ctx:            ctx,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should fail if user is neither the owner nor an org admin",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues(), func(kafkaRequest *dbapi.KafkaRequest) {
+							kafkaRequest.Owner = "another-user"
+						}), nil
+					},
+				},
+			},
+			ctx: auth.SetTokenInContext(context.TODO(), &jwt.Token{
+				Claims: jwt.MapClaims{
+					"username":     "test-user",
+					"org_id":       mocks.DefaultOrganisationId,
+					"is_org_admin": false,
+				},
+			}),
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "should fail if the kafka cannot be suspended",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+					},
+					SuspendKafkaFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						return errors.Validation("cannot be suspended")
+					},
+				},
+			},
+			ctx:            ctx,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should succeed if the kafka is suspended",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+					},
+					SuspendKafkaFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						return nil
+					},
+				},
+				kafkaConfig: &fullKafkaConfig,
+			},
+			ctx:            ctx,
+			wantStatusCode: http.StatusAccepted,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			h := NewKafkaHandler(tt.fields.service, nil, nil, tt.fields.kafkaConfig, nil)
+			req, rw := GetHandlerParams("POST", "/{id}/suspend", nil, t)
+			req = mux.SetURLVars(req.WithContext(tt.ctx), map[string]string{"id": id})
+			h.Suspend(rw, req)
+			resp := rw.Result()
+			resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+		})
+	}
+}
+
+func Test_KafkaHandler_Resume(t *testing.T) {
+	type fields struct {
+		service     services.KafkaService
+		kafkaConfig *config.KafkaConfig
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantStatusCode int
+	}{
+		{
+			name: "should fail if kafka is not found",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return nil, errors.NotFound("not found")
+					},
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should fail if the kafka status changed concurrently",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+					},
+					ResumeKafkaFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						return errors.Conflict("status changed")
+					},
+				},
+			},
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name: "should succeed if the kafka is resumed",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+					},
+					ResumeKafkaFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						return nil
+					},
+				},
+				kafkaConfig: &fullKafkaConfig,
+			},
+			wantStatusCode: http.StatusAccepted,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			h := NewKafkaHandler(tt.fields.service, nil, nil, tt.fields.kafkaConfig, nil)
+			req, rw := GetHandlerParams("POST", "/{id}/resume", nil, t)
+			req = mux.SetURLVars(req.WithContext(ctx), map[string]string{"id": id})
+			h.Resume(rw, req)
+			resp := rw.Result()
+			resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+		})
+	}
+}
+
+func Test_KafkaHandler_Resize(t *testing.T) {
+	type fields struct {
+		service     services.KafkaService
+		kafkaConfig *config.KafkaConfig
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		ctx            context.Context
+		body           []byte
+		wantStatusCode int
+	}{
+		{
+			name: "should fail if kafka is not found",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return nil, errors.NotFound("not found")
+					},
+				},
+			},
+			ctx:            ctx,
+			body:           []byte(`{"size_id": "x2"}`),
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should fail if user is neither the owner nor an org admin",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues(), func(kafkaRequest *dbapi.KafkaRequest) {
+							kafkaRequest.Owner = "another-user"
+						}), nil
+					},
+				},
+			},
+			ctx: auth.SetTokenInContext(context.TODO(), &jwt.Token{
+				Claims: jwt.MapClaims{
+					"username":     "test-user",
+					"org_id":       mocks.DefaultOrganisationId,
+					"is_org_admin": false,
+				},
+			}),
+			body:           []byte(`{"size_id": "x2"}`),
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "should fail if the size_id is missing",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+					},
+				},
+			},
+			ctx:            ctx,
+			body:           []byte(`{}`),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should fail if the quota for the new size is refused",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+					},
+					ResizeKafkaFunc: func(kafkaRequest *dbapi.KafkaRequest, sizeId string) *errors.ServiceError {
+						return errors.InsufficientQuotaError("insufficient quota")
+					},
+				},
+			},
+			ctx:            ctx,
+			body:           []byte(`{"size_id": "x2"}`),
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "should succeed if the kafka is resized",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+					},
+					ResizeKafkaFunc: func(kafkaRequest *dbapi.KafkaRequest, sizeId string) *errors.ServiceError {
+						kafkaRequest.SizeId = sizeId
+						return nil
+					},
+				},
+				kafkaConfig: &fullKafkaConfig,
+			},
+			ctx:            ctx,
+			body:           []byte(`{"size_id": "x1"}`),
+			wantStatusCode: http.StatusAccepted,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			h := NewKafkaHandler(tt.fields.service, nil, nil, tt.fields.kafkaConfig, nil)
+			req, rw := GetHandlerParams("POST", "/{id}/resize", bytes.NewBuffer(tt.body), t)
+			req = mux.SetURLVars(req.WithContext(tt.ctx), map[string]string{"id": id})
+			h.Resize(rw, req)
+			resp := rw.Result()
+			resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+		})
+	}
+}
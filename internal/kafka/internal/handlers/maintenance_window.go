@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"github.com/gorilla/mux"
+)
+
+type maintenanceWindowHandler struct {
+	kafkaService             services.KafkaService
+	maintenanceWindowService services.MaintenanceWindowService
+}
+
+func NewMaintenanceWindowHandler(kafkaService services.KafkaService, maintenanceWindowService services.MaintenanceWindowService) *maintenanceWindowHandler {
+	return &maintenanceWindowHandler{
+		kafkaService:             kafkaService,
+		maintenanceWindowService: maintenanceWindowService,
+	}
+}
+
+// GetForKafka returns the maintenance window configured for a kafka instance
+func (h maintenanceWindowHandler) GetForKafka(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			kafkaRequest, err := h.kafkaService.Get(r.Context(), id)
+			if err != nil {
+				return nil, err
+			}
+			window, err := h.maintenanceWindowService.Get(kafkaRequest.OrganisationId, kafkaRequest.ID)
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentMaintenanceWindow(window), nil
+		},
+	}
+	handlers.HandleGet(w, r, cfg)
+}
+
+// UpdateForKafka creates or replaces the maintenance window of a kafka instance
+func (h maintenanceWindowHandler) UpdateForKafka(w http.ResponseWriter, r *http.Request) {
+	var windowRequest public.MaintenanceWindowRequest
+	id := mux.Vars(r)["id"]
+	ctx := r.Context()
+	kafkaRequest, kafkaGetError := h.kafkaService.Get(ctx, id)
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &windowRequest,
+		Validate: []handlers.Validate{
+			func() *errors.ServiceError {
+				return kafkaGetError
+			},
+			ValidateKafkaOwnerOrOrgAdmin(ctx, kafkaRequest),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			window := presenters.ConvertMaintenanceWindowRequest(windowRequest)
+			window.OrganisationId = kafkaRequest.OrganisationId
+			window.KafkaId = kafkaRequest.ID
+			if err := h.maintenanceWindowService.Upsert(window); err != nil {
+				return nil, err
+			}
+			return presenters.PresentMaintenanceWindow(window), nil
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// DeleteForKafka removes the maintenance window of a kafka instance
+func (h maintenanceWindowHandler) DeleteForKafka(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	ctx := r.Context()
+	kafkaRequest, kafkaGetError := h.kafkaService.Get(ctx, id)
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			func() *errors.ServiceError {
+				return kafkaGetError
+			},
+			ValidateKafkaOwnerOrOrgAdmin(ctx, kafkaRequest),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			return nil, h.maintenanceWindowService.Delete(kafkaRequest.OrganisationId, kafkaRequest.ID)
+		},
+	}
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+// GetForOrganisation returns the maintenance window configured for the organisation of the caller
+func (h maintenanceWindowHandler) GetForOrganisation(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			claims, err := getClaims(r.Context())
+			if err != nil {
+				return nil, err
+			}
+			orgId, _ := claims.GetOrgId()
+			window, err := h.maintenanceWindowService.Get(orgId, "")
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentMaintenanceWindow(window), nil
+		},
+	}
+	handlers.HandleGet(w, r, cfg)
+}
+
+// UpdateForOrganisation creates or replaces the maintenance window of the organisation of the caller.
+// Only organisation admins are allowed to change it.
+func (h maintenanceWindowHandler) UpdateForOrganisation(w http.ResponseWriter, r *http.Request) {
+	var windowRequest public.MaintenanceWindowRequest
+	ctx := r.Context()
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &windowRequest,
+		Validate: []handlers.Validate{
+			ValidateOrgAdmin(ctx),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			claims, _ := getClaims(ctx)
+			window := presenters.ConvertMaintenanceWindowRequest(windowRequest)
+			window.OrganisationId, _ = claims.GetOrgId()
+			if err := h.maintenanceWindowService.Upsert(window); err != nil {
+				return nil, err
+			}
+			return presenters.PresentMaintenanceWindow(window), nil
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// DeleteForOrganisation removes the maintenance window of the organisation of the caller.
+// Only organisation admins are allowed to remove it.
+func (h maintenanceWindowHandler) DeleteForOrganisation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			ValidateOrgAdmin(ctx),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			claims, _ := getClaims(ctx)
+			orgId, _ := claims.GetOrgId()
+			return nil, h.maintenanceWindowService.Delete(orgId, "")
+		},
+	}
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	mocks "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+var nonOrgAdminCtx = auth.SetTokenInContext(context.TODO(), &jwt.Token{
+	Claims: jwt.MapClaims{
+		"username":     "test-user",
+		"org_id":       mocks.DefaultOrganisationId,
+		"is_org_admin": false,
+	},
+})
+
+func Test_MaintenanceWindowHandler_UpdateForKafka(t *testing.T) {
+	type fields struct {
+		kafkaService             services.KafkaService
+		maintenanceWindowService services.MaintenanceWindowService
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		ctx            context.Context
+		body           []byte
+		wantStatusCode int
+	}{
+		{
+			name: "should fail if kafka is not found",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return nil, errors.NotFound("not found")
+					},
+				},
+			},
+			ctx:            ctx,
+			body:           []byte(`{"day_of_week": "sunday", "start_time": "02:00", "duration_hours": 4}`),
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should fail if user is neither the owner nor an org admin",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues(), func(kafkaRequest *dbapi.KafkaRequest) {
+							kafkaRequest.Owner = "another-user"
+						}), nil
+					},
+				},
+			},
+			ctx:            nonOrgAdminCtx,
+			body:           []byte(`{"day_of_week": "sunday", "start_time": "02:00", "duration_hours": 4}`),
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "should fail if the maintenance window is invalid",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+					},
+				},
+				maintenanceWindowService: &services.MaintenanceWindowServiceMock{
+					UpsertFunc: func(window *dbapi.MaintenanceWindow) *errors.ServiceError {
+						return errors.Validation("invalid maintenance window")
+					},
+				},
+			},
+			ctx:            ctx,
+			body:           []byte(`{"day_of_week": "someday", "start_time": "02:00", "duration_hours": 4}`),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should succeed if the maintenance window is saved",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues(), func(kafkaRequest *dbapi.KafkaRequest) {
+							kafkaRequest.ID = id
+						}), nil
+					},
+				},
+				maintenanceWindowService: &services.MaintenanceWindowServiceMock{
+					UpsertFunc: func(window *dbapi.MaintenanceWindow) *errors.ServiceError {
+						if window.KafkaId != id || window.OrganisationId != mocks.DefaultOrganisationId {
+							return errors.GeneralError("maintenance window not bound to the kafka")
+						}
+						return nil
+					},
+				},
+			},
+			ctx:            ctx,
+			body:           []byte(`{"day_of_week": "sunday", "start_time": "02:00", "duration_hours": 4}`),
+			wantStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			h := NewMaintenanceWindowHandler(tt.fields.kafkaService, tt.fields.maintenanceWindowService)
+			req, rw := GetHandlerParams("PUT", "/{id}/maintenance_window", bytes.NewBuffer(tt.body), t)
+			req = mux.SetURLVars(req.WithContext(tt.ctx), map[string]string{"id": id})
+			h.UpdateForKafka(rw, req)
+			resp := rw.Result()
+			resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+		})
+	}
+}
+
+func Test_MaintenanceWindowHandler_UpdateForOrganisation(t *testing.T) {
+	tests := []struct {
+		name                     string
+		maintenanceWindowService services.MaintenanceWindowService
+		ctx                      context.Context
+		wantStatusCode           int
+	}{
+		{
+			name:           "should fail if user is not an org admin",
+			ctx:            nonOrgAdminCtx,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "should succeed if user is an org admin",
+			maintenanceWindowService: &services.MaintenanceWindowServiceMock{
+				UpsertFunc: func(window *dbapi.MaintenanceWindow) *errors.ServiceError {
+					if window.KafkaId != "" || window.OrganisationId != mocks.DefaultOrganisationId {
+						return errors.GeneralError("maintenance window not bound to the organisation")
+					}
+					return nil
+				},
+			},
+			ctx:            ctx,
+			wantStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			h := NewMaintenanceWindowHandler(nil, tt.maintenanceWindowService)
+			body := bytes.NewBuffer([]byte(`{"day_of_week": "sunday", "start_time": "02:00", "duration_hours": 4}`))
+			req, rw := GetHandlerParams("PUT", "/maintenance_window", body, t)
+			h.UpdateForOrganisation(rw, req.WithContext(tt.ctx))
+			resp := rw.Result()
+			resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+		})
+	}
+}
+
+func Test_MaintenanceWindowHandler_DeleteForOrganisation(t *testing.T) {
+	tests := []struct {
+		name                     string
+		maintenanceWindowService services.MaintenanceWindowService
+		ctx                      context.Context
+		wantStatusCode           int
+	}{
+		{
+			name:           "should fail if user is not an org admin",
+			ctx:            nonOrgAdminCtx,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "should fail if the organisation has no maintenance window",
+			maintenanceWindowService: &services.MaintenanceWindowServiceMock{
+				DeleteFunc: func(organisationID, kafkaID string) *errors.ServiceError {
+					return errors.NotFound("not found")
+				},
+			},
+			ctx:            ctx,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should succeed if the maintenance window is deleted",
+			maintenanceWindowService: &services.MaintenanceWindowServiceMock{
+				DeleteFunc: func(organisationID, kafkaID string) *errors.ServiceError {
+					return nil
+				},
+			},
+			ctx:            ctx,
+			wantStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			h := NewMaintenanceWindowHandler(nil, tt.maintenanceWindowService)
+			req, rw := GetHandlerParams("DELETE", "/maintenance_window", nil, t)
+			h.DeleteForOrganisation(rw, req.WithContext(tt.ctx))
+			resp := rw.Result()
+			resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+		})
+	}
+}
@@ -46,6 +46,25 @@ func ValidateBillingModel(kafkaRequestPayload *public.KafkaRequestPayload) handl
 	}
 }
 
+// ValidateKafkaDrPrimary checks that the disaster recovery primary of the kafka request, when given, is visible to the caller
+func ValidateKafkaDrPrimary(ctx context.Context, kafkaService services.KafkaService, kafkaRequestPayload *public.KafkaRequestPayload) handlers.Validate {
+	return func() *errors.ServiceError {
+		drPrimaryID := shared.SafeString(kafkaRequestPayload.DrPrimaryId)
+		if drPrimaryID == "" {
+			return nil
+		}
+
+		// Get only returns the kafkas of the organisation of the caller
+		if _, err := kafkaService.Get(ctx, drPrimaryID); err != nil {
+			if err.Is404() {
+				return errors.Validation("disaster recovery primary kafka %q not found", drPrimaryID)
+			}
+			return err
+		}
+		return nil
+	}
+}
+
 func ValidateBillingCloudAccountIdAndMarketplace(ctx context.Context, kafkaService services.KafkaService, kafkaRequestPayload *public.KafkaRequestPayload) handlers.Validate {
 	return func() *errors.ServiceError {
 		// both fields are optional
@@ -294,23 +313,66 @@ func stringSet(value *string) bool {
 	return value != nil && len(strings.Trim(*value, " ")) > 0
 }
 
-func ValidateKafkaUserFacingUpdateFields(ctx context.Context, authService authorization.Authorization, kafkaRequest *dbapi.KafkaRequest, kafkaUpdateReq *public.KafkaUpdateRequest) handlers.Validate {
+// ValidateKafkaOwnerOrOrgAdmin checks that the user is either the owner of the kafka, an admin of the organisation the kafka belongs to
+// or has been granted a role allowing the action by the RBACMiddleware
+func ValidateKafkaOwnerOrOrgAdmin(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) handlers.Validate {
 	return func() *errors.ServiceError {
 		claims, claimsErr := getClaims(ctx)
 		if claimsErr != nil {
 			return claimsErr
 		}
 
-		username, _ := claims.GetUsername()
 		orgId, _ := claims.GetOrgId()
-		isOrgAdmin := claims.IsOrgAdmin()
-		// only Kafka owner or organisation admin is allowed to perform the action
-		isOwner := (isOrgAdmin || kafkaRequest.Owner == username) && kafkaRequest.OrganisationId == orgId
-		if !isOwner {
+		// a role granted on the kafka or on its organisation allows the action in place of the ownership
+		if auth.GetIsGrantedFromContext(ctx) && kafkaRequest.OrganisationId == orgId {
+			return nil
+		}
+
+		return validateKafkaOwnerOrOrgAdmin(claims, kafkaRequest)
+	}
+}
+
+func validateKafkaOwnerOrOrgAdmin(claims auth.KFMClaims, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+	username, _ := claims.GetUsername()
+	orgId, _ := claims.GetOrgId()
+	isOrgAdmin := claims.IsOrgAdmin()
+	// only Kafka owner or organisation admin is allowed to perform the action
+	isOwner := (isOrgAdmin || kafkaRequest.Owner == username) && kafkaRequest.OrganisationId == orgId
+	if !isOwner {
+		return errors.New(errors.ErrorUnauthorized, "user not authorized to perform this action")
+	}
+
+	return nil
+}
+
+func ValidateOrgAdmin(ctx context.Context) handlers.Validate {
+	return func() *errors.ServiceError {
+		claims, claimsErr := getClaims(ctx)
+		if claimsErr != nil {
+			return claimsErr
+		}
+
+		if !claims.IsOrgAdmin() {
 			return errors.New(errors.ErrorUnauthorized, "user not authorized to perform this action")
 		}
 
+		return nil
+	}
+}
+
+func ValidateKafkaUserFacingUpdateFields(ctx context.Context, authService authorization.Authorization, kafkaRequest *dbapi.KafkaRequest, kafkaUpdateReq *public.KafkaUpdateRequest) handlers.Validate {
+	return func() *errors.ServiceError {
+		if err := ValidateKafkaOwnerOrOrgAdmin(ctx, kafkaRequest)(); err != nil {
+			return err
+		}
+
 		if kafkaUpdateReq.Owner != nil {
+			claims, _ := getClaims(ctx)
+			// the ownership can only be transferred by the owner or an organisation admin, whatever the granted roles
+			if err := validateKafkaOwnerOrOrgAdmin(claims, kafkaRequest); err != nil {
+				return err
+			}
+			orgId, _ := claims.GetOrgId()
 			validationError := handlers.ValidateMinLength(kafkaUpdateReq.Owner, "owner", 1)()
 			if validationError != nil {
 				return validationError
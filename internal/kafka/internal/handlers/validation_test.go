@@ -469,6 +469,41 @@ func Test_Validation_ValidateKafkaUserFacingUpdateFields(t *testing.T) {
 				reason:  "user not authorized to perform this action",
 			},
 		},
+		{
+			name: "throw an error when user is neither the owner of the kafka of its organisation nor granted a role on it",
+			arg: args{
+				ctx: auth.SetTokenInContext(context.TODO(), token),
+				kafka: &dbapi.KafkaRequest{
+					Owner:          "other-user",
+					OrganisationId: orgId,
+				},
+				kafkaUpdateRequest: public.KafkaUpdateRequest{
+					ReauthenticationEnabled: &reauthenticationEnabled,
+				},
+				authService: authorization.NewMockAuthorization(),
+			},
+			want: result{
+				wantErr: true,
+				reason:  "user not authorized to perform this action",
+			},
+		},
+		{
+			name: "do not throw an error when user is granted a role on the kafka of its organisation",
+			arg: args{
+				ctx: auth.SetIsGrantedContext(auth.SetTokenInContext(context.TODO(), token), true),
+				kafka: &dbapi.KafkaRequest{
+					Owner:          "other-user",
+					OrganisationId: orgId,
+				},
+				kafkaUpdateRequest: public.KafkaUpdateRequest{
+					ReauthenticationEnabled: &reauthenticationEnabled,
+				},
+				authService: authorization.NewMockAuthorization(),
+			},
+			want: result{
+				wantErr: false,
+			},
+		},
 		{
 			name: "throw an error when empty owner passed",
 			arg: args{
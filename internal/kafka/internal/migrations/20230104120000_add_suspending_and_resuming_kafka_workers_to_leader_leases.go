@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addSuspendingAndResumingKafkaWorkersToLeaderLeases() *gormigrate.Migration {
+	leaseTypes := []string{"suspending_kafka", "resuming_kafka"}
+
+	return &gormigrate.Migration{
+		ID: "20230104120000",
+		Migrate: func(tx *gorm.DB) error {
+			for _, leaseType := range leaseTypes {
+				if err := tx.Create(&api.LeaderLease{Expires: &db.KafkaAdditionalLeasesExpireTime, LeaseType: leaseType, Leader: api.NewID()}).Error; err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Unscoped().Where("lease_type IN (?)", leaseTypes).Delete(&api.LeaderLease{}).Error
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addMigrationColumnsToKafkaRequest() *gormigrate.Migration {
+	type KafkaRequest struct {
+		MigrationStatus          string `json:"migration_status" gorm:"index"`
+		MigrationSourceClusterID string `json:"migration_source_cluster_id"`
+		MigrationTargetClusterID string `json:"migration_target_cluster_id"`
+		MigrationPlacementId     string `json:"migration_placement_id"`
+	}
+
+	columns := []string{"migration_status", "migration_source_cluster_id", "migration_target_cluster_id", "migration_placement_id"}
+
+	return &gormigrate.Migration{
+		ID: "20230110120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaRequest{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range columns {
+				if err := tx.Migrator().DropColumn(&KafkaRequest{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
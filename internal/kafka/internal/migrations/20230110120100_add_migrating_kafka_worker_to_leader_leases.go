@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addMigratingKafkaWorkerToLeaderLeases() *gormigrate.Migration {
+	leaseType := "migrating_kafka"
+
+	return &gormigrate.Migration{
+		ID: "20230110120100",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Create(&api.LeaderLease{Expires: &db.KafkaAdditionalLeasesExpireTime, LeaseType: leaseType, Leader: api.NewID()}).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Unscoped().Where("lease_type = ?", leaseType).Delete(&api.LeaderLease{}).Error
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addMaintenanceWindows() *gormigrate.Migration {
+	type MaintenanceWindow struct {
+		db.Model
+		OrganisationId string `json:"organisation_id" gorm:"index"`
+		KafkaId        string `json:"kafka_id" gorm:"index"`
+		DayOfWeek      string `json:"day_of_week"`
+		StartTime      string `json:"start_time"`
+		DurationHours  int    `json:"duration_hours"`
+	}
+
+	type KafkaRequest struct {
+		PendingKafkaVersion    string `json:"pending_kafka_version"`
+		PendingStrimziVersion  string `json:"pending_strimzi_version"`
+		PendingKafkaIBPVersion string `json:"pending_kafka_ibp_version"`
+	}
+
+	columns := []string{"pending_kafka_version", "pending_strimzi_version", "pending_kafka_ibp_version"}
+
+	return &gormigrate.Migration{
+		ID: "20230117120000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&MaintenanceWindow{}); err != nil {
+				return err
+			}
+			return tx.AutoMigrate(&KafkaRequest{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range columns {
+				if err := tx.Migrator().DropColumn(&KafkaRequest{}, column); err != nil {
+					return err
+				}
+			}
+			return tx.Migrator().DropTable(&MaintenanceWindow{})
+		},
+	}
+}
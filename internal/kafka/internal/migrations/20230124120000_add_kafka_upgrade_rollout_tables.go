@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaUpgradeRollouts() *gormigrate.Migration {
+	type KafkaUpgradeRollout struct {
+		db.Model
+		Query           string     `json:"query"`
+		StrimziVersion  string     `json:"strimzi_version"`
+		KafkaVersion    string     `json:"kafka_version"`
+		KafkaIBPVersion string     `json:"kafka_ibp_version"`
+		CanaryCount     int        `json:"canary_count"`
+		BatchPercentage int        `json:"batch_percentage"`
+		SoakTimeMinutes int        `json:"soak_time_minutes"`
+		Status          string     `json:"status" gorm:"index"`
+		StatusReason    string     `json:"status_reason"`
+		CurrentWave     int        `json:"current_wave"`
+		TotalWaves      int        `json:"total_waves"`
+		WaveUpgradedAt  *time.Time `json:"wave_upgraded_at"`
+	}
+
+	type KafkaUpgradeRolloutTarget struct {
+		db.Model
+		RolloutId                      string `json:"rollout_id" gorm:"index"`
+		KafkaId                        string `json:"kafka_id" gorm:"index"`
+		Wave                           int    `json:"wave"`
+		Status                         string `json:"status"`
+		PreviousDesiredStrimziVersion  string `json:"previous_desired_strimzi_version"`
+		PreviousDesiredKafkaVersion    string `json:"previous_desired_kafka_version"`
+		PreviousDesiredKafkaIBPVersion string `json:"previous_desired_kafka_ibp_version"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230124120000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&KafkaUpgradeRollout{}); err != nil {
+				return err
+			}
+			return tx.AutoMigrate(&KafkaUpgradeRolloutTarget{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&KafkaUpgradeRolloutTarget{}); err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&KafkaUpgradeRollout{})
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addWebhookTables() *gormigrate.Migration {
+	type OutboxEvent struct {
+		db.Model
+		Type           string
+		Source         string
+		Subject        string
+		OrganisationId string     `gorm:"index"`
+		Data           string     `gorm:"type:jsonb"`
+		DispatchedAt   *time.Time `gorm:"index"`
+	}
+
+	type WebhookEndpoint struct {
+		db.Model
+		OrganisationId string `gorm:"index"`
+		Owner          string
+		URL            string
+		Description    string
+		Secret         string
+	}
+
+	type WebhookDelivery struct {
+		db.Model
+		WebhookId     string `gorm:"index"`
+		EventId       string
+		EventType     string
+		Status        string `gorm:"index"`
+		Attempts      int
+		NextAttemptAt *time.Time `gorm:"index"`
+		LastError     string
+		DeliveredAt   *time.Time
+	}
+
+	return db.CreateMigrationFromActions("20230125120000",
+		db.CreateSharedTablesAction(&OutboxEvent{}, &WebhookEndpoint{}, &WebhookDelivery{}),
+	)
+}
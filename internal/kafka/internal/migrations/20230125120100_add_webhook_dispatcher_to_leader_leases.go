@@ -0,0 +1,12 @@
+package migrations
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addWebhookDispatcherToLeaderLeases() *gormigrate.Migration {
+	return db.CreateMigrationFromActions("20230125120100",
+		db.CreateSharedLeaderLeaseAction("webhook_dispatcher"),
+	)
+}
@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addKafkaResourceVersion adds a resource_version column to the kafka_requests table. The column is bumped by a trigger on
+// every insert and update of a kafka request, soft deletes included, and the trigger notifies the '/kafkas' signal on the
+// signalbus channel so that the kafka watch streams are woken up when the change is committed.
+func addKafkaResourceVersion() *gormigrate.Migration {
+	type KafkaRequest struct {
+		ResourceVersion int64 `json:"resource_version" gorm:"type:bigserial;index"`
+	}
+
+	statements := []string{
+		`CREATE OR REPLACE FUNCTION kafka_requests_resource_version_trigger() RETURNS TRIGGER LANGUAGE plpgsql AS '
+		BEGIN
+		NEW.resource_version := nextval(''kafka_requests_resource_version_seq'');
+		PERFORM pg_notify(''signalbus'', ''/kafkas'');
+		RETURN NEW;
+		END;'`,
+		`DROP TRIGGER IF EXISTS kafka_requests_resource_version_trigger ON kafka_requests`,
+		`CREATE TRIGGER kafka_requests_resource_version_trigger BEFORE INSERT OR UPDATE ON kafka_requests
+		FOR EACH ROW EXECUTE PROCEDURE kafka_requests_resource_version_trigger()`,
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230126120000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&KafkaRequest{}); err != nil {
+				return err
+			}
+			for _, statement := range statements {
+				if err := tx.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec("DROP TRIGGER IF EXISTS kafka_requests_resource_version_trigger ON kafka_requests").Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("DROP FUNCTION IF EXISTS kafka_requests_resource_version_trigger").Error; err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&KafkaRequest{}, "resource_version")
+		},
+	}
+}
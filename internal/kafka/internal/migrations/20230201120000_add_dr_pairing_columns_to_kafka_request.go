@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addDrPairingColumnsToKafkaRequest() *gormigrate.Migration {
+	type KafkaRequest struct {
+		DrRole        string `json:"dr_role"`
+		DrPeerKafkaID string `json:"dr_peer_kafka_id" gorm:"index"`
+	}
+
+	columns := []string{"dr_role", "dr_peer_kafka_id"}
+
+	return &gormigrate.Migration{
+		ID: "20230201120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaRequest{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range columns {
+				if err := tx.Migrator().DropColumn(&KafkaRequest{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addQuotaManagementListTables() *gormigrate.Migration {
+	type QuotaListOrganisation struct {
+		db.Model
+		OrganisationId      string `json:"organisation_id" gorm:"uniqueIndex"`
+		AnyUser             bool   `json:"any_user"`
+		MaxAllowedInstances int    `json:"max_allowed_instances"`
+		RegisteredUsers     string `json:"registered_users" gorm:"type:jsonb"`
+		GrantedQuota        string `json:"granted_quota" gorm:"type:jsonb"`
+	}
+
+	type QuotaListServiceAccount struct {
+		db.Model
+		Username            string `json:"username" gorm:"uniqueIndex"`
+		MaxAllowedInstances int    `json:"max_allowed_instances"`
+		GrantedQuota        string `json:"granted_quota" gorm:"type:jsonb"`
+	}
+
+	type QuotaListChange struct {
+		db.Model
+		ResourceType string `json:"resource_type" gorm:"index"`
+		ResourceId   string `json:"resource_id" gorm:"index"`
+		Action       string `json:"action"`
+		ChangedBy    string `json:"changed_by"`
+		Value        string `json:"value" gorm:"type:jsonb"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230301120000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&QuotaListOrganisation{}); err != nil {
+				return err
+			}
+			if err := tx.AutoMigrate(&QuotaListServiceAccount{}); err != nil {
+				return err
+			}
+			return tx.AutoMigrate(&QuotaListChange{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&QuotaListChange{}); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropTable(&QuotaListServiceAccount{}); err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&QuotaListOrganisation{})
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addOidcClientRegistrations() *gormigrate.Migration {
+	type OidcClientRegistration struct {
+		db.Model
+		ClientId                string `gorm:"uniqueIndex"`
+		Name                    string
+		Description             string
+		Owner                   string
+		OwnerAccountId          string
+		OrganisationId          string `gorm:"index"`
+		Internal                bool
+		RegistrationAccessToken string
+		RegistrationClientURI   string
+	}
+
+	return db.CreateMigrationFromActions("20230308120000",
+		db.CreateSharedTablesAction(&OidcClientRegistration{}),
+	)
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addResourceGrants() *gormigrate.Migration {
+	type ResourceGrant struct {
+		db.Model
+		OrganisationId string `gorm:"uniqueIndex:idx_resource_grants_subject_resource"`
+		Subject        string `gorm:"uniqueIndex:idx_resource_grants_subject_resource"`
+		ResourceType   string `gorm:"uniqueIndex:idx_resource_grants_subject_resource"`
+		ResourceId     string `gorm:"uniqueIndex:idx_resource_grants_subject_resource"`
+		Role           string
+		CreatedBy      string
+	}
+
+	return db.CreateMigrationFromActions("20230315120000",
+		db.CreateSharedTablesAction(&ResourceGrant{}),
+	)
+}
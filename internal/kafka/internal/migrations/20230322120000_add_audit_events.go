@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addAuditEvents() *gormigrate.Migration {
+	type AuditEvent struct {
+		db.Model
+		Actor          string `gorm:"index"`
+		OrganisationId string `gorm:"index"`
+		ResourceType   string `gorm:"index"`
+		ResourceId     string `gorm:"index"`
+		Action         string
+		Method         string
+		Route          string
+		Diff           string
+		RequestId      string `gorm:"index"`
+		StatusCode     int
+		Outcome        string
+	}
+
+	return db.CreateMigrationFromActions("20230322120000",
+		db.CreateSharedTablesAction(&AuditEvent{}),
+	)
+}
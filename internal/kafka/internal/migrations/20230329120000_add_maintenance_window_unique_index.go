@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addMaintenanceWindowUniqueIndex makes the maintenance window of a kafka, or of an organisation when the kafka id is empty,
+// unique among the windows that are not deleted so that the concurrent upserts of a window can't create duplicates.
+// The older duplicates created before the index are soft deleted.
+func addMaintenanceWindowUniqueIndex() *gormigrate.Migration {
+	statements := []string{
+		`UPDATE maintenance_windows SET deleted_at = NOW() WHERE deleted_at IS NULL AND id IN (
+			SELECT id FROM (
+				SELECT id, ROW_NUMBER() OVER (PARTITION BY organisation_id, kafka_id ORDER BY updated_at DESC) AS position
+				FROM maintenance_windows WHERE deleted_at IS NULL
+			) AS windows WHERE position > 1
+		)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS uix_maintenance_windows_organisation_id_kafka_id
+		ON maintenance_windows (organisation_id, kafka_id) WHERE deleted_at IS NULL`,
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230329120000",
+		Migrate: func(tx *gorm.DB) error {
+			for _, statement := range statements {
+				if err := tx.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP INDEX IF EXISTS uix_maintenance_windows_organisation_id_kafka_id").Error
+		},
+	}
+}
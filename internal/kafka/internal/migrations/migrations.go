@@ -92,6 +92,22 @@ var migrations = []*gormigrate.Migration{
 	addClusterOrgIdClusterTypeColumns(),
 	addDefaultValueForClusterTypeColumn(),
 	removeWronglyCreatedEnterpriseClusterInProd(),
+	addSuspendingAndResumingKafkaWorkersToLeaderLeases(),
+	addMigrationColumnsToKafkaRequest(),
+	addMigratingKafkaWorkerToLeaderLeases(),
+	addMaintenanceWindows(),
+	addMaintenanceWindowKafkaWorkerToLeaderLeases(),
+	addKafkaUpgradeRollouts(),
+	addUpgradeRolloutKafkaWorkerToLeaderLeases(),
+	addWebhookTables(),
+	addWebhookDispatcherToLeaderLeases(),
+	addKafkaResourceVersion(),
+	addDrPairingColumnsToKafkaRequest(),
+	addQuotaManagementListTables(),
+	addOidcClientRegistrations(),
+	addResourceGrants(),
+	addAuditEvents(),
+	addMaintenanceWindowUniqueIndex(),
 }
 
 func New(dbConfig *db.DatabaseConfig) (*db.Migration, func(), error) {
@@ -68,6 +68,14 @@ func PresentKafkaRequestAdminEndpoint(kafkaRequest *dbapi.KafkaRequest, accountS
 		MaxDataRetentionSize: private.SupportedKafkaSizeBytesValueItem{
 			Bytes: maxDataRetentionSizeBytes,
 		},
+		MigrationStatus:          kafkaRequest.MigrationStatus,
+		MigrationSourceClusterId: kafkaRequest.MigrationSourceClusterID,
+		MigrationTargetClusterId: kafkaRequest.MigrationTargetClusterID,
+		PendingStrimziVersion:    kafkaRequest.PendingStrimziVersion,
+		PendingKafkaVersion:      kafkaRequest.PendingKafkaVersion,
+		PendingKafkaIbpVersion:   kafkaRequest.PendingKafkaIBPVersion,
+		DrRole:                   kafkaRequest.DrRole,
+		DrPeerId:                 kafkaRequest.DrPeerKafkaID,
 	}, nil
 }
 
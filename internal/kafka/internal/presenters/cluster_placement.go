@@ -0,0 +1,44 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+)
+
+// ConvertKafkaPlacementDryRunRequest converts a dry run request into the kafka request to place.
+// As on kafka creation, the multi AZ attribute is determined by the instance type
+func ConvertKafkaPlacementDryRunRequest(request private.KafkaPlacementDryRunRequest) *dbapi.KafkaRequest {
+	return &dbapi.KafkaRequest{
+		CloudProvider:  request.CloudProvider,
+		Region:         request.Region,
+		MultiAZ:        request.InstanceType == types.STANDARD.String(),
+		InstanceType:   request.InstanceType,
+		SizeId:         request.SizeId,
+		OrganisationId: request.OrganisationId,
+	}
+}
+
+func PresentClusterPlacementDecision(decision *services.ClusterPlacementDecision) private.KafkaPlacementDryRunResponse {
+	response := private.KafkaPlacementDryRunResponse{
+		Strategy:   decision.Strategy,
+		Candidates: []private.KafkaPlacementCandidate{},
+	}
+
+	if decision.Cluster != nil {
+		response.ClusterId = decision.Cluster.ClusterID
+	}
+
+	for _, candidate := range decision.Candidates {
+		response.Candidates = append(response.Candidates, private.KafkaPlacementCandidate{
+			ClusterId: candidate.ClusterID,
+			Eligible:  candidate.Eligible,
+			Reason:    candidate.Reason,
+			Score:     candidate.Score,
+			Scores:    candidate.Scores,
+		})
+	}
+
+	return response
+}
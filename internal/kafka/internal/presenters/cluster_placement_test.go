@@ -0,0 +1,95 @@
+package presenters
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/onsi/gomega"
+)
+
+func Test_ConvertKafkaPlacementDryRunRequest(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	got := ConvertKafkaPlacementDryRunRequest(private.KafkaPlacementDryRunRequest{
+		CloudProvider:  "aws",
+		Region:         "us-east-1",
+		InstanceType:   "standard",
+		SizeId:         "x1",
+		OrganisationId: "org-id",
+	})
+
+	g.Expect(got).To(gomega.Equal(&dbapi.KafkaRequest{
+		CloudProvider:  "aws",
+		Region:         "us-east-1",
+		MultiAZ:        true,
+		InstanceType:   "standard",
+		SizeId:         "x1",
+		OrganisationId: "org-id",
+	}))
+}
+
+func Test_PresentClusterPlacementDecision(t *testing.T) {
+	tests := []struct {
+		name     string
+		decision *services.ClusterPlacementDecision
+		want     private.KafkaPlacementDryRunResponse
+	}{
+		{
+			name: "should present the selected cluster and the candidates",
+			decision: &services.ClusterPlacementDecision{
+				Strategy: "scored",
+				Cluster:  &api.Cluster{ClusterID: clusterId},
+				Candidates: []services.ClusterPlacementCandidate{
+					{
+						ClusterID: clusterId,
+						Eligible:  true,
+						Score:     1.5,
+						Scores:    map[string]float64{"utilization": 0.5, "preferred_cluster": 1},
+					},
+					{
+						ClusterID: "other-cluster",
+						Reason:    "cluster is not schedulable",
+					},
+				},
+			},
+			want: private.KafkaPlacementDryRunResponse{
+				Strategy:  "scored",
+				ClusterId: clusterId,
+				Candidates: []private.KafkaPlacementCandidate{
+					{
+						ClusterId: clusterId,
+						Eligible:  true,
+						Score:     1.5,
+						Scores:    map[string]float64{"utilization": 0.5, "preferred_cluster": 1},
+					},
+					{
+						ClusterId: "other-cluster",
+						Reason:    "cluster is not schedulable",
+					},
+				},
+			},
+		},
+		{
+			name: "should not set the cluster id when no cluster was selected",
+			decision: &services.ClusterPlacementDecision{
+				Strategy:   "first-ready-cluster",
+				Candidates: []services.ClusterPlacementCandidate{},
+			},
+			want: private.KafkaPlacementDryRunResponse{
+				Strategy:   "first-ready-cluster",
+				Candidates: []private.KafkaPlacementCandidate{},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(PresentClusterPlacementDecision(tt.decision)).To(gomega.Equal(tt.want))
+		})
+	}
+}
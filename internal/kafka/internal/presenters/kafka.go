@@ -7,6 +7,7 @@ import (
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
@@ -31,6 +32,11 @@ func ConvertKafkaRequest(kafkaRequestPayload public.KafkaRequestPayload, dbKafka
 	kafka.Marketplace = shared.SafeString(kafkaRequestPayload.Marketplace)
 	kafka.DesiredKafkaBillingModel = shared.SafeString(kafkaRequestPayload.BillingModel)
 
+	if drPrimaryID := shared.SafeString(kafkaRequestPayload.DrPrimaryId); drPrimaryID != "" {
+		kafka.DrRole = constants.KafkaDrRoleStandby.String()
+		kafka.DrPeerKafkaID = drPrimaryID
+	}
+
 	if kafkaRequestPayload.ReauthenticationEnabled != nil {
 		kafka.ReauthenticationEnabled = *kafkaRequestPayload.ReauthenticationEnabled
 	} else {
@@ -111,6 +117,8 @@ func PresentKafkaRequest(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.K
 		BillingCloudAccountId:                 kafkaRequest.BillingCloudAccountId,
 		Marketplace:                           kafkaRequest.Marketplace,
 		BillingModel:                          kafkaRequest.ActualKafkaBillingModel,
+		DrRole:                                kafkaRequest.DrRole,
+		DrPeerId:                              kafkaRequest.DrPeerKafkaID,
 	}, nil
 }
 
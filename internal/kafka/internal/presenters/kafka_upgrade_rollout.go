@@ -0,0 +1,40 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+)
+
+func ConvertKafkaUpgradeRolloutRequest(request private.KafkaUpgradeRolloutRequest) *dbapi.KafkaUpgradeRollout {
+	return &dbapi.KafkaUpgradeRollout{
+		Query:           request.Query,
+		StrimziVersion:  request.StrimziVersion,
+		KafkaVersion:    request.KafkaVersion,
+		KafkaIBPVersion: request.KafkaIbpVersion,
+		CanaryCount:     int(request.CanaryCount),
+		BatchPercentage: int(request.BatchPercentage),
+		SoakTimeMinutes: int(request.SoakTimeMinutes),
+	}
+}
+
+func PresentKafkaUpgradeRollout(rollout *dbapi.KafkaUpgradeRollout) private.KafkaUpgradeRollout {
+	reference := PresentReference(rollout.ID, rollout)
+	return private.KafkaUpgradeRollout{
+		Id:              reference.Id,
+		Kind:            reference.Kind,
+		Href:            reference.Href,
+		Query:           rollout.Query,
+		StrimziVersion:  rollout.StrimziVersion,
+		KafkaVersion:    rollout.KafkaVersion,
+		KafkaIbpVersion: rollout.KafkaIBPVersion,
+		CanaryCount:     int32(rollout.CanaryCount),
+		BatchPercentage: int32(rollout.BatchPercentage),
+		SoakTimeMinutes: int32(rollout.SoakTimeMinutes),
+		Status:          rollout.Status,
+		StatusReason:    rollout.StatusReason,
+		CurrentWave:     int32(rollout.CurrentWave),
+		TotalWaves:      int32(rollout.TotalWaves),
+		CreatedAt:       rollout.CreatedAt,
+		UpdatedAt:       rollout.UpdatedAt,
+	}
+}
@@ -0,0 +1,55 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+)
+
+func ConvertMaintenanceWindowRequest(request public.MaintenanceWindowRequest) *dbapi.MaintenanceWindow {
+	return &dbapi.MaintenanceWindow{
+		DayOfWeek:     request.DayOfWeek,
+		StartTime:     request.StartTime,
+		DurationHours: int(request.DurationHours),
+	}
+}
+
+func PresentMaintenanceWindow(window *dbapi.MaintenanceWindow) public.MaintenanceWindow {
+	reference := PresentReference(window.ID, window)
+	return public.MaintenanceWindow{
+		Id:             reference.Id,
+		Kind:           reference.Kind,
+		Href:           reference.Href,
+		DayOfWeek:      window.DayOfWeek,
+		StartTime:      window.StartTime,
+		DurationHours:  int32(window.DurationHours),
+		OrganisationId: window.OrganisationId,
+		KafkaId:        window.KafkaId,
+		CreatedAt:      window.CreatedAt,
+		UpdatedAt:      window.UpdatedAt,
+	}
+}
+
+func ConvertMaintenanceWindowAdminRequest(request private.MaintenanceWindowRequest) *dbapi.MaintenanceWindow {
+	return &dbapi.MaintenanceWindow{
+		DayOfWeek:     request.DayOfWeek,
+		StartTime:     request.StartTime,
+		DurationHours: int(request.DurationHours),
+	}
+}
+
+func PresentMaintenanceWindowAdminEndpoint(window *dbapi.MaintenanceWindow) private.MaintenanceWindow {
+	reference := PresentReference(window.ID, window)
+	return private.MaintenanceWindow{
+		Id:             reference.Id,
+		Kind:           reference.Kind,
+		Href:           reference.Href,
+		DayOfWeek:      window.DayOfWeek,
+		StartTime:      window.StartTime,
+		DurationHours:  int32(window.DurationHours),
+		OrganisationId: window.OrganisationId,
+		KafkaId:        window.KafkaId,
+		CreatedAt:      window.CreatedAt,
+		UpdatedAt:      window.UpdatedAt,
+	}
+}
@@ -44,8 +44,9 @@ func PresentManagedKafka(from *v1.ManagedKafka) private.ManagedKafka {
 				MaximumSessionLifetime: from.Spec.OAuth.MaximumSessionLifetime,
 			},
 			Endpoint: private.ManagedKafkaAllOfSpecEndpoint{
-				Tls:                 getOpenAPIManagedKafkaEndpointTLS(from.Spec.Endpoint.Tls),
-				BootstrapServerHost: from.Spec.Endpoint.BootstrapServerHost,
+				Tls:                            getOpenAPIManagedKafkaEndpointTLS(from.Spec.Endpoint.Tls),
+				BootstrapServerHost:            from.Spec.Endpoint.BootstrapServerHost,
+				AdditionalBootstrapServerHosts: from.Spec.Endpoint.AdditionalBootstrapServerHosts,
 			},
 			Versions: private.ManagedKafkaVersions{
 				Kafka:    from.Spec.Versions.Kafka,
@@ -55,6 +56,7 @@ func PresentManagedKafka(from *v1.ManagedKafka) private.ManagedKafka {
 			Deleted:         from.Spec.Deleted,
 			Owners:          from.Spec.Owners,
 			ServiceAccounts: getServiceAccounts(from.Spec.ServiceAccounts),
+			MirrorMaker2:    getOpenAPIManagedKafkaMirrorMaker2(from.Spec.MirrorMaker2),
 		},
 	}
 
@@ -72,6 +74,35 @@ func getOpenAPIManagedKafkaEndpointTLS(from *v1.TlsSpec) *private.ManagedKafkaAl
 	return res
 }
 
+func getOpenAPIManagedKafkaMirrorMaker2(from *v1.MirrorMaker2Spec) *private.ManagedKafkaAllOfSpecMirrorMaker2 {
+	var res *private.ManagedKafkaAllOfSpecMirrorMaker2
+	if from != nil {
+		res = &private.ManagedKafkaAllOfSpecMirrorMaker2{
+			SourceClusterAlias:          from.SourceClusterAlias,
+			SourceBootstrapServerHost:   from.SourceBootstrapServerHost,
+			Topics:                      from.Topics,
+			Groups:                      from.Groups,
+			SyncGroupOffsets:            from.SyncGroupOffsets,
+			SourceAuthentication:        getOpenAPIManagedKafkaMirrorMaker2Authentication(from.SourceAuthentication),
+			SourceTlsTrustedCertificate: from.SourceTlsTrustedCertificate,
+		}
+	}
+	return res
+}
+
+func getOpenAPIManagedKafkaMirrorMaker2Authentication(from *v1.MirrorMaker2AuthenticationSpec) *private.ManagedKafkaAllOfSpecMirrorMaker2SourceAuthentication {
+	var res *private.ManagedKafkaAllOfSpecMirrorMaker2SourceAuthentication
+	if from != nil {
+		res = &private.ManagedKafkaAllOfSpecMirrorMaker2SourceAuthentication{
+			TokenEndpointURI:      from.TokenEndpointURI,
+			ClientId:              from.ClientID,
+			ClientSecret:          from.ClientSecret,
+			TlsTrustedCertificate: from.TlsTrustedCertificate,
+		}
+	}
+	return res
+}
+
 func getOpenAPIManagedKafkaOAuthTLSTrustedCertificate(from *v1.OAuthSpec) *string {
 	var res *string
 	if from.TlsTrustedCertificate != nil {
@@ -23,6 +23,14 @@ const (
 	KindServiceAccount = "ServiceAccount"
 
 	KindCluster = "Cluster"
+	// KindMaintenanceWindow is a string identifier for the type dbapi.MaintenanceWindow
+	KindMaintenanceWindow = "MaintenanceWindow"
+	// KindKafkaUpgradeRollout is a string identifier for the type dbapi.KafkaUpgradeRollout
+	KindKafkaUpgradeRollout = "KafkaUpgradeRollout"
+	// KindQuotaListOrganisation is a string identifier for the type dbapi.QuotaListOrganisation
+	KindQuotaListOrganisation = "QuotaListOrganisation"
+	// KindQuotaListServiceAccount is a string identifier for the type dbapi.QuotaListServiceAccount
+	KindQuotaListServiceAccount = "QuotaListServiceAccount"
 
 	BasePath = "/api/kafkas_mgmt/v1"
 )
@@ -45,6 +53,14 @@ func objectKind(i interface{}) string {
 		return KindServiceAccount
 	case api.Cluster, *api.Cluster:
 		return KindCluster
+	case dbapi.MaintenanceWindow, *dbapi.MaintenanceWindow:
+		return KindMaintenanceWindow
+	case dbapi.KafkaUpgradeRollout, *dbapi.KafkaUpgradeRollout:
+		return KindKafkaUpgradeRollout
+	case dbapi.QuotaListOrganisation, *dbapi.QuotaListOrganisation:
+		return KindQuotaListOrganisation
+	case dbapi.QuotaListServiceAccount, *dbapi.QuotaListServiceAccount:
+		return KindQuotaListServiceAccount
 	default:
 		return ""
 	}
@@ -60,6 +76,18 @@ func objectPath(id string, obj interface{}) string {
 		return fmt.Sprintf("%s/clusters/%s", BasePath, id)
 	case api.ServiceAccount, *api.ServiceAccount:
 		return fmt.Sprintf("%s/service_accounts/%s", BasePath, id)
+	case *dbapi.MaintenanceWindow:
+		// maintenance windows are addressed through the kafka or the organisation they belong to
+		if window := obj.(*dbapi.MaintenanceWindow); window.KafkaId != "" {
+			return fmt.Sprintf("%s/kafkas/%s/maintenance_window", BasePath, window.KafkaId)
+		}
+		return fmt.Sprintf("%s/maintenance_window", BasePath)
+	case dbapi.KafkaUpgradeRollout, *dbapi.KafkaUpgradeRollout:
+		return fmt.Sprintf("%s/admin/kafka_upgrade_rollouts/%s", BasePath, id)
+	case dbapi.QuotaListOrganisation, *dbapi.QuotaListOrganisation:
+		return fmt.Sprintf("%s/admin/quota_management_list/organisations/%s", BasePath, id)
+	case dbapi.QuotaListServiceAccount, *dbapi.QuotaListServiceAccount:
+		return fmt.Sprintf("%s/admin/quota_management_list/service_accounts/%s", BasePath, id)
 	default:
 		return ""
 	}
@@ -0,0 +1,141 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
+)
+
+func ConvertQuotaListOrganisationRequest(id string, request private.QuotaListOrganisationRequest) (quota_management.Organisation, *errors.ServiceError) {
+	grantedQuota, err := convertQuotaListGrantedQuota(request.GrantedQuota)
+	if err != nil {
+		return quota_management.Organisation{}, err
+	}
+
+	org := quota_management.Organisation{
+		Id:                  id,
+		AnyUser:             request.AnyUser,
+		MaxAllowedInstances: int(request.MaxAllowedInstances),
+		GrantedQuota:        grantedQuota,
+	}
+	for _, username := range request.RegisteredUsers {
+		org.RegisteredUsers = append(org.RegisteredUsers, quota_management.Account{Username: username})
+	}
+	return org, nil
+}
+
+func PresentQuotaListOrganisation(entry *dbapi.QuotaListOrganisation) (private.QuotaListOrganisation, *errors.ServiceError) {
+	org, err := entry.Organisation()
+	if err != nil {
+		return private.QuotaListOrganisation{}, errors.NewWithCause(errors.ErrorGeneral, err, "unable to present the organisation of the quota management list")
+	}
+
+	reference := PresentReference(entry.OrganisationId, entry)
+	result := private.QuotaListOrganisation{
+		Id:                  reference.Id,
+		Kind:                reference.Kind,
+		Href:                reference.Href,
+		AnyUser:             org.AnyUser,
+		MaxAllowedInstances: int32(org.MaxAllowedInstances),
+		GrantedQuota:        presentQuotaListGrantedQuota(org.GrantedQuota),
+		CreatedAt:           entry.CreatedAt,
+		UpdatedAt:           entry.UpdatedAt,
+	}
+	for _, user := range org.RegisteredUsers {
+		result.RegisteredUsers = append(result.RegisteredUsers, user.Username)
+	}
+	return result, nil
+}
+
+func ConvertQuotaListServiceAccountRequest(username string, request private.QuotaListServiceAccountRequest) (quota_management.Account, *errors.ServiceError) {
+	grantedQuota, err := convertQuotaListGrantedQuota(request.GrantedQuota)
+	if err != nil {
+		return quota_management.Account{}, err
+	}
+
+	return quota_management.Account{
+		Username:            username,
+		MaxAllowedInstances: int(request.MaxAllowedInstances),
+		GrantedQuota:        grantedQuota,
+	}, nil
+}
+
+func PresentQuotaListServiceAccount(entry *dbapi.QuotaListServiceAccount) (private.QuotaListServiceAccount, *errors.ServiceError) {
+	account, err := entry.Account()
+	if err != nil {
+		return private.QuotaListServiceAccount{}, errors.NewWithCause(errors.ErrorGeneral, err, "unable to present the service account of the quota management list")
+	}
+
+	reference := PresentReference(entry.Username, entry)
+	return private.QuotaListServiceAccount{
+		Id:                  reference.Id,
+		Kind:                reference.Kind,
+		Href:                reference.Href,
+		MaxAllowedInstances: int32(account.MaxAllowedInstances),
+		GrantedQuota:        presentQuotaListGrantedQuota(account.GrantedQuota),
+		CreatedAt:           entry.CreatedAt,
+		UpdatedAt:           entry.UpdatedAt,
+	}, nil
+}
+
+func PresentQuotaListChange(change *dbapi.QuotaListChange) (private.QuotaListChange, *errors.ServiceError) {
+	var value map[string]interface{}
+	if err := change.Value.Unmarshal(&value); err != nil {
+		return private.QuotaListChange{}, errors.NewWithCause(errors.ErrorGeneral, err, "unable to present the change of the quota management list")
+	}
+
+	return private.QuotaListChange{
+		Id:           change.ID,
+		ResourceType: change.ResourceType,
+		ResourceId:   change.ResourceId,
+		Action:       change.Action,
+		ChangedBy:    change.ChangedBy,
+		Value:        value,
+		CreatedAt:    change.CreatedAt,
+	}, nil
+}
+
+func convertQuotaListGrantedQuota(grantedQuota []private.QuotaListGrantedQuota) (quota_management.QuotaList, *errors.ServiceError) {
+	var result quota_management.QuotaList
+	for _, quota := range grantedQuota {
+		q := quota_management.Quota{InstanceTypeID: quota.InstanceTypeId}
+		for _, bm := range quota.KafkaBillingModels {
+			billingModel := quota_management.BillingModel{
+				Id:                  bm.Id,
+				MaxAllowedInstances: int(bm.MaxAllowedInstances),
+				GracePeriodDays:     int(bm.GracePeriodDays),
+			}
+			if bm.ExpirationDate != "" {
+				expirationDate, err := quota_management.ParseExpirationDate(bm.ExpirationDate)
+				if err != nil {
+					return nil, errors.Validation("expiration_date %q of billing model %q must be in the 'YYYY-MM-DD {+,-}HH:MM' format", bm.ExpirationDate, bm.Id)
+				}
+				billingModel.ExpirationDate = expirationDate
+			}
+			q.KafkaBillingModels = append(q.KafkaBillingModels, billingModel)
+		}
+		result = append(result, q)
+	}
+	return result, nil
+}
+
+func presentQuotaListGrantedQuota(grantedQuota quota_management.QuotaList) []private.QuotaListGrantedQuota {
+	var result []private.QuotaListGrantedQuota
+	for _, quota := range grantedQuota {
+		q := private.QuotaListGrantedQuota{InstanceTypeId: quota.InstanceTypeID}
+		for _, bm := range quota.KafkaBillingModels {
+			billingModel := private.QuotaListBillingModel{
+				Id:                  bm.Id,
+				MaxAllowedInstances: int32(bm.MaxAllowedInstances),
+				GracePeriodDays:     int32(bm.GracePeriodDays),
+			}
+			if bm.ExpirationDate != nil {
+				billingModel.ExpirationDate = bm.ExpirationDate.String()
+			}
+			q.KafkaBillingModels = append(q.KafkaBillingModels, billingModel)
+		}
+		result = append(result, q)
+	}
+	return result
+}
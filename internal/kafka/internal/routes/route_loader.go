@@ -1,14 +1,19 @@
 package routes
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/account"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/rbac"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 
@@ -54,11 +59,20 @@ type options struct {
 	ClusterPlacementStrategy    services.ClusterPlacementStrategy
 	ClusterService              services.ClusterService
 	SupportedKafkaInstanceTypes services.SupportedKafkaInstanceTypesService
+	MaintenanceWindow           services.MaintenanceWindowService
+	KafkaUpgradeRollout         services.KafkaUpgradeRolloutService
+	QuotaList                   services.QuotaListService
+	WebhookService              webhooks.WebhookService
+	RBACService                 rbac.RBACService
+	AuditService                audit.AuditService
+	SignalBus                   signalbus.SignalBus
 
 	AccessControlListMiddleware                       *acl.AccessControlListMiddleware
 	AccessControlListConfig                           *acl.AccessControlListConfig
 	EnterpriseClusterRegistrationAccessListMiddleware *internalAcl.EnterpriseClusterRegistrationAccessListMiddleware
 	AdminRoleAuthZConfig                              *auth.AdminRoleAuthZConfig
+	RBACMiddleware                                    rbac.RBACMiddleware
+	AuditMiddleware                                   audit.AuditMiddleware
 	KasFleetshardOperatorAddon                        services.KasFleetshardOperatorAddon
 }
 
@@ -82,18 +96,31 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 		return pkgerrors.Wrapf(err, "can't load OpenAPI specification")
 	}
 
-	kafkaHandler := handlers.NewKafkaHandler(s.Kafka, s.ProviderConfig, s.AuthService, s.KafkaConfig)
+	kafkaHandler := handlers.NewKafkaHandler(s.Kafka, s.ProviderConfig, s.AuthService, s.KafkaConfig, s.SignalBus)
 	cloudProvidersHandler := handlers.NewCloudProviderHandler(s.CloudProviders, s.ProviderConfig, s.Kafka, s.ClusterPlacementStrategy, s.KafkaConfig)
 	errorsHandler := coreHandlers.NewErrorsHandler()
 	serviceAccountsHandler := handlers.NewServiceAccountHandler(s.Keycloak)
 	metricsHandler := handlers.NewMetricsHandler(s.Observatorium)
 	supportedKafkaInstanceTypesHandler := handlers.NewSupportedKafkaInstanceTypesHandler(s.SupportedKafkaInstanceTypes)
+	maintenanceWindowHandler := handlers.NewMaintenanceWindowHandler(s.Kafka, s.MaintenanceWindow)
+	webhookHandler := coreHandlers.NewWebhookHandler(s.WebhookService, fmt.Sprintf("%s/webhooks", basePath))
+	grantHandler := coreHandlers.NewGrantHandler(s.RBACService, fmt.Sprintf("%s/grants", basePath), map[api.GrantResourceType]rbac.ResourceLookup{
+		api.GrantResourceTypeKafka: s.lookupKafka,
+	})
 
 	authorizeMiddleware := s.AccessControlListMiddleware.Authorize
 	enterpriseClusterMiddleware := s.EnterpriseClusterRegistrationAccessListMiddleware.Authorize
 	requireOrgID := auth.NewRequireOrgIDMiddleware().RequireOrgID(errors.ErrorUnauthenticated)
 	requireIssuer := auth.NewRequireIssuerMiddleware().RequireIssuer([]string{s.ServerConfig.TokenIssuerURL}, errors.ErrorUnauthenticated)
 	requireTermsAcceptance := auth.NewRequireTermsAcceptanceMiddleware().RequireTermsAcceptance(s.ServerConfig.EnableTermsAcceptance, s.AMSClient, errors.ErrorTermsNotAccepted)
+	requireKafkaPermission := s.RBACMiddleware.RequirePermission("id", s.lookupKafka)
+	requireServiceAccountPermission := s.RBACMiddleware.RequirePermission("id", nil)
+	auditMutations := s.AuditMiddleware.AuditMutations(map[string]audit.Snapshot{
+		"kafkas":           s.snapshotKafka,
+		"service_accounts": s.snapshotServiceAccount,
+		"webhooks":         audit.WebhookSnapshot(s.WebhookService),
+		"grants":           audit.GrantSnapshot(s.RBACService),
+	})
 
 	// base path. Could be /api/kafkas_mgmt
 	apiRouter := mainRouter.PathPrefix(basePath).Subrouter()
@@ -129,12 +156,32 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	apiV1KafkasRouter.HandleFunc("/{id}", kafkaHandler.Update).
 		Name(logger.NewLogEvent("update-kafka", "update a kafka instance").ToString()).
 		Methods(http.MethodPatch)
+	apiV1KafkasRouter.HandleFunc("/{id}/suspend", kafkaHandler.Suspend).
+		Name(logger.NewLogEvent("suspend-kafka", "suspend a kafka instance").ToString()).
+		Methods(http.MethodPost)
+	apiV1KafkasRouter.HandleFunc("/{id}/resume", kafkaHandler.Resume).
+		Name(logger.NewLogEvent("resume-kafka", "resume a suspended kafka instance").ToString()).
+		Methods(http.MethodPost)
+	apiV1KafkasRouter.HandleFunc("/{id}/resize", kafkaHandler.Resize).
+		Name(logger.NewLogEvent("resize-kafka", "resize a kafka instance").ToString()).
+		Methods(http.MethodPost)
+	apiV1KafkasRouter.HandleFunc("/{id}/maintenance_window", maintenanceWindowHandler.GetForKafka).
+		Name(logger.NewLogEvent("get-kafka-maintenance-window", "get the maintenance window of a kafka instance").ToString()).
+		Methods(http.MethodGet)
+	apiV1KafkasRouter.HandleFunc("/{id}/maintenance_window", maintenanceWindowHandler.UpdateForKafka).
+		Name(logger.NewLogEvent("update-kafka-maintenance-window", "update the maintenance window of a kafka instance").ToString()).
+		Methods(http.MethodPut)
+	apiV1KafkasRouter.HandleFunc("/{id}/maintenance_window", maintenanceWindowHandler.DeleteForKafka).
+		Name(logger.NewLogEvent("delete-kafka-maintenance-window", "delete the maintenance window of a kafka instance").ToString()).
+		Methods(http.MethodDelete)
 	apiV1KafkasRouter.HandleFunc("", kafkaHandler.List).
 		Name(logger.NewLogEvent("list-kafka", "list all kafkas").ToString()).
 		Methods(http.MethodGet)
 	apiV1KafkasRouter.Use(requireIssuer)
 	apiV1KafkasRouter.Use(requireOrgID)
 	apiV1KafkasRouter.Use(authorizeMiddleware)
+	apiV1KafkasRouter.Use(auditMutations)
+	apiV1KafkasRouter.Use(requireKafkaPermission)
 
 	apiV1KafkasCreateRouter := apiV1KafkasRouter.NewRoute().Subrouter()
 	apiV1KafkasCreateRouter.HandleFunc("", kafkaHandler.Create).Methods(http.MethodPost)
@@ -149,6 +196,63 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 		Name(logger.NewLogEvent("get-metrics-instant", "get metrics by instant").ToString()).
 		Methods(http.MethodGet)
 
+	//  /maintenance_window
+	apiV1MaintenanceWindowRouter := apiV1Router.PathPrefix("/maintenance_window").Subrouter()
+	apiV1MaintenanceWindowRouter.HandleFunc("", maintenanceWindowHandler.GetForOrganisation).
+		Name(logger.NewLogEvent("get-organisation-maintenance-window", "get the maintenance window of the organisation").ToString()).
+		Methods(http.MethodGet)
+	apiV1MaintenanceWindowRouter.HandleFunc("", maintenanceWindowHandler.UpdateForOrganisation).
+		Name(logger.NewLogEvent("update-organisation-maintenance-window", "update the maintenance window of the organisation").ToString()).
+		Methods(http.MethodPut)
+	apiV1MaintenanceWindowRouter.HandleFunc("", maintenanceWindowHandler.DeleteForOrganisation).
+		Name(logger.NewLogEvent("delete-organisation-maintenance-window", "delete the maintenance window of the organisation").ToString()).
+		Methods(http.MethodDelete)
+	apiV1MaintenanceWindowRouter.Use(requireIssuer)
+	apiV1MaintenanceWindowRouter.Use(requireOrgID)
+	apiV1MaintenanceWindowRouter.Use(authorizeMiddleware)
+	apiV1MaintenanceWindowRouter.Use(auditMutations)
+
+	//  /webhooks
+	apiV1WebhooksRouter := apiV1Router.PathPrefix("/webhooks").Subrouter()
+	apiV1WebhooksRouter.HandleFunc("", webhookHandler.List).
+		Name(logger.NewLogEvent("list-webhooks", "list the webhook endpoints of the organisation").ToString()).
+		Methods(http.MethodGet)
+	apiV1WebhooksRouter.HandleFunc("", webhookHandler.Create).
+		Name(logger.NewLogEvent("create-webhook", "register a webhook endpoint").ToString()).
+		Methods(http.MethodPost)
+	apiV1WebhooksRouter.HandleFunc("/{id}", webhookHandler.Get).
+		Name(logger.NewLogEvent("get-webhook", "get a webhook endpoint").ToString()).
+		Methods(http.MethodGet)
+	apiV1WebhooksRouter.HandleFunc("/{id}", webhookHandler.Delete).
+		Name(logger.NewLogEvent("delete-webhook", "delete a webhook endpoint").ToString()).
+		Methods(http.MethodDelete)
+	apiV1WebhooksRouter.HandleFunc("/{id}/dead_letters", webhookHandler.ListDeadLetters).
+		Name(logger.NewLogEvent("list-webhook-dead-letters", "list the dead-lettered deliveries of a webhook endpoint").ToString()).
+		Methods(http.MethodGet)
+	apiV1WebhooksRouter.Use(requireIssuer)
+	apiV1WebhooksRouter.Use(requireOrgID)
+	apiV1WebhooksRouter.Use(authorizeMiddleware)
+	apiV1WebhooksRouter.Use(auditMutations)
+
+	//  /grants
+	apiV1GrantsRouter := apiV1Router.PathPrefix("/grants").Subrouter()
+	apiV1GrantsRouter.HandleFunc("", grantHandler.List).
+		Name(logger.NewLogEvent("list-grants", "list the roles granted on the organisation and on its kafka instances").ToString()).
+		Methods(http.MethodGet)
+	apiV1GrantsRouter.HandleFunc("", grantHandler.Create).
+		Name(logger.NewLogEvent("create-grant", "grant a role on the organisation or on a kafka instance").ToString()).
+		Methods(http.MethodPost)
+	apiV1GrantsRouter.HandleFunc("/{id}", grantHandler.Get).
+		Name(logger.NewLogEvent("get-grant", "get a granted role").ToString()).
+		Methods(http.MethodGet)
+	apiV1GrantsRouter.HandleFunc("/{id}", grantHandler.Delete).
+		Name(logger.NewLogEvent("delete-grant", "revoke a granted role").ToString()).
+		Methods(http.MethodDelete)
+	apiV1GrantsRouter.Use(requireIssuer)
+	apiV1GrantsRouter.Use(requireOrgID)
+	apiV1GrantsRouter.Use(authorizeMiddleware)
+	apiV1GrantsRouter.Use(auditMutations)
+
 	// /kafkas/{id}/metrics/federate
 	// federate endpoint separated from the rest of the /kafkas endpoints as it needs to support auth from both sso.redhat.com and mas-sso
 	// NOTE: this is only a temporary solution. MAS SSO auth support should be removed once we migrate to sso.redhat.com (TODO: to be done as part of MGDSTRM-6159)
@@ -192,6 +296,8 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	apiV1ServiceAccountsRouter.Use(requireIssuer)
 	apiV1ServiceAccountsRouter.Use(requireOrgID)
 	apiV1ServiceAccountsRouter.Use(authorizeMiddleware)
+	apiV1ServiceAccountsRouter.Use(auditMutations)
+	apiV1ServiceAccountsRouter.Use(requireServiceAccountPermission)
 
 	//  /cloud_providers
 	v1Collections = append(v1Collections, api.CollectionMetadata{
@@ -251,11 +357,12 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	// deliberately returns 404 here if the request doesn't have the required role, so that it will appear as if the endpoint doesn't exist
 	auth.UseOperatorAuthorisationMiddleware(apiV1DataPlaneRequestsRouter, s.Keycloak.GetRealmConfig().ValidIssuerURI, "id", s.ClusterService)
 
-	adminKafkaHandler := handlers.NewAdminKafkaHandler(s.Kafka, s.AccountService, s.ProviderConfig, s.ClusterService)
+	adminKafkaHandler := handlers.NewAdminKafkaHandler(s.Kafka, s.AccountService, s.ProviderConfig, s.ClusterService, s.MaintenanceWindow)
 	adminRouter := apiV1Router.PathPrefix("/admin").Subrouter()
 	adminRouter.Use(auth.NewRequireIssuerMiddleware().RequireIssuer([]string{s.Keycloak.GetConfig().AdminAPISSORealm.ValidIssuerURI}, errors.ErrorNotFound))
 	adminRouter.Use(auth.NewRolesAuthzMiddleware(s.AdminRoleAuthZConfig).RequireRolesForMethods(errors.ErrorNotFound))
 	adminRouter.Use(auth.NewAuditLogMiddleware().AuditLog(errors.ErrorNotFound))
+	adminRouter.Use(auditMutations)
 	adminRouter.HandleFunc("/kafkas", adminKafkaHandler.List).
 		Name(logger.NewLogEvent("admin-list-kafkas", "[admin] list all kafkas").ToString()).
 		Methods(http.MethodGet)
@@ -268,6 +375,100 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	adminRouter.HandleFunc("/kafkas/{id}", adminKafkaHandler.Update).
 		Name(logger.NewLogEvent("admin-update-kafka", "[admin] update kafka by id").ToString()).
 		Methods(http.MethodPatch)
+	adminRouter.HandleFunc("/kafkas/{id}/suspend", adminKafkaHandler.Suspend).
+		Name(logger.NewLogEvent("admin-suspend-kafka", "[admin] suspend kafka by id").ToString()).
+		Methods(http.MethodPost)
+	adminRouter.HandleFunc("/kafkas/{id}/resume", adminKafkaHandler.Resume).
+		Name(logger.NewLogEvent("admin-resume-kafka", "[admin] resume kafka by id").ToString()).
+		Methods(http.MethodPost)
+	adminRouter.HandleFunc("/kafkas/{id}/migrate", adminKafkaHandler.Migrate).
+		Name(logger.NewLogEvent("admin-migrate-kafka", "[admin] migrate kafka by id").ToString()).
+		Methods(http.MethodPost)
+	adminRouter.HandleFunc("/kafkas/{id}/failover", adminKafkaHandler.Failover).
+		Name(logger.NewLogEvent("admin-failover-kafka", "[admin] fail over kafka disaster recovery pair by id").ToString()).
+		Methods(http.MethodPost)
+	adminRouter.HandleFunc("/clusters/{id}/drain", adminKafkaHandler.DrainCluster).
+		Name(logger.NewLogEvent("admin-drain-cluster", "[admin] drain data plane cluster by id").ToString()).
+		Methods(http.MethodPost)
+
+	adminMaintenanceWindowHandler := handlers.NewAdminMaintenanceWindowHandler(s.Kafka, s.MaintenanceWindow)
+	adminRouter.HandleFunc("/kafkas/{id}/maintenance_window", adminMaintenanceWindowHandler.GetForKafka).
+		Name(logger.NewLogEvent("admin-get-kafka-maintenance-window", "[admin] get maintenance window of kafka by id").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafkas/{id}/maintenance_window", adminMaintenanceWindowHandler.UpdateForKafka).
+		Name(logger.NewLogEvent("admin-update-kafka-maintenance-window", "[admin] update maintenance window of kafka by id").ToString()).
+		Methods(http.MethodPut)
+	adminRouter.HandleFunc("/kafkas/{id}/maintenance_window", adminMaintenanceWindowHandler.DeleteForKafka).
+		Name(logger.NewLogEvent("admin-delete-kafka-maintenance-window", "[admin] delete maintenance window of kafka by id").ToString()).
+		Methods(http.MethodDelete)
+	adminRouter.HandleFunc("/organisations/{id}/maintenance_window", adminMaintenanceWindowHandler.GetForOrganisation).
+		Name(logger.NewLogEvent("admin-get-organisation-maintenance-window", "[admin] get maintenance window of organisation by id").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/organisations/{id}/maintenance_window", adminMaintenanceWindowHandler.UpdateForOrganisation).
+		Name(logger.NewLogEvent("admin-update-organisation-maintenance-window", "[admin] update maintenance window of organisation by id").ToString()).
+		Methods(http.MethodPut)
+	adminRouter.HandleFunc("/organisations/{id}/maintenance_window", adminMaintenanceWindowHandler.DeleteForOrganisation).
+		Name(logger.NewLogEvent("admin-delete-organisation-maintenance-window", "[admin] delete maintenance window of organisation by id").ToString()).
+		Methods(http.MethodDelete)
+
+	adminClusterPlacementHandler := handlers.NewAdminClusterPlacementHandler(s.ClusterPlacementStrategy, s.KafkaConfig)
+	adminRouter.HandleFunc("/kafkas/placement_dry_run", adminClusterPlacementHandler.DryRun).
+		Name(logger.NewLogEvent("admin-dry-run-kafka-placement", "[admin] dry run kafka placement").ToString()).
+		Methods(http.MethodPost)
+
+	adminKafkaUpgradeRolloutHandler := handlers.NewAdminKafkaUpgradeRolloutHandler(s.KafkaUpgradeRollout)
+	adminRouter.HandleFunc("/kafka_upgrade_rollouts", adminKafkaUpgradeRolloutHandler.List).
+		Name(logger.NewLogEvent("admin-list-kafka-upgrade-rollouts", "[admin] list kafka upgrade rollouts").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafka_upgrade_rollouts", adminKafkaUpgradeRolloutHandler.Create).
+		Name(logger.NewLogEvent("admin-create-kafka-upgrade-rollout", "[admin] create kafka upgrade rollout").ToString()).
+		Methods(http.MethodPost)
+	adminRouter.HandleFunc("/kafka_upgrade_rollouts/{id}", adminKafkaUpgradeRolloutHandler.Get).
+		Name(logger.NewLogEvent("admin-get-kafka-upgrade-rollout", "[admin] get kafka upgrade rollout by id").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafka_upgrade_rollouts/{id}/pause", adminKafkaUpgradeRolloutHandler.Pause).
+		Name(logger.NewLogEvent("admin-pause-kafka-upgrade-rollout", "[admin] pause kafka upgrade rollout by id").ToString()).
+		Methods(http.MethodPost)
+	adminRouter.HandleFunc("/kafka_upgrade_rollouts/{id}/resume", adminKafkaUpgradeRolloutHandler.Resume).
+		Name(logger.NewLogEvent("admin-resume-kafka-upgrade-rollout", "[admin] resume kafka upgrade rollout by id").ToString()).
+		Methods(http.MethodPost)
+	adminRouter.HandleFunc("/kafka_upgrade_rollouts/{id}/rollback", adminKafkaUpgradeRolloutHandler.Rollback).
+		Name(logger.NewLogEvent("admin-rollback-kafka-upgrade-rollout", "[admin] rollback kafka upgrade rollout by id").ToString()).
+		Methods(http.MethodPost)
+
+	adminQuotaListHandler := handlers.NewAdminQuotaListHandler(s.QuotaList)
+	adminRouter.HandleFunc("/quota_management_list/organisations", adminQuotaListHandler.ListOrganisations).
+		Name(logger.NewLogEvent("admin-list-quota-list-organisations", "[admin] list organisations of the quota management list").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/quota_management_list/organisations/{id}", adminQuotaListHandler.GetOrganisation).
+		Name(logger.NewLogEvent("admin-get-quota-list-organisation", "[admin] get organisation of the quota management list by id").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/quota_management_list/organisations/{id}", adminQuotaListHandler.UpdateOrganisation).
+		Name(logger.NewLogEvent("admin-update-quota-list-organisation", "[admin] update organisation of the quota management list by id").ToString()).
+		Methods(http.MethodPut)
+	adminRouter.HandleFunc("/quota_management_list/organisations/{id}", adminQuotaListHandler.DeleteOrganisation).
+		Name(logger.NewLogEvent("admin-delete-quota-list-organisation", "[admin] delete organisation of the quota management list by id").ToString()).
+		Methods(http.MethodDelete)
+	adminRouter.HandleFunc("/quota_management_list/service_accounts", adminQuotaListHandler.ListServiceAccounts).
+		Name(logger.NewLogEvent("admin-list-quota-list-service-accounts", "[admin] list service accounts of the quota management list").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/quota_management_list/service_accounts/{id}", adminQuotaListHandler.GetServiceAccount).
+		Name(logger.NewLogEvent("admin-get-quota-list-service-account", "[admin] get service account of the quota management list by username").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/quota_management_list/service_accounts/{id}", adminQuotaListHandler.UpdateServiceAccount).
+		Name(logger.NewLogEvent("admin-update-quota-list-service-account", "[admin] update service account of the quota management list by username").ToString()).
+		Methods(http.MethodPut)
+	adminRouter.HandleFunc("/quota_management_list/service_accounts/{id}", adminQuotaListHandler.DeleteServiceAccount).
+		Name(logger.NewLogEvent("admin-delete-quota-list-service-account", "[admin] delete service account of the quota management list by username").ToString()).
+		Methods(http.MethodDelete)
+	adminRouter.HandleFunc("/quota_management_list/changes", adminQuotaListHandler.ListChanges).
+		Name(logger.NewLogEvent("admin-list-quota-list-changes", "[admin] list changes of the quota management list").ToString()).
+		Methods(http.MethodGet)
+
+	adminAuditEventHandler := coreHandlers.NewAuditEventHandler(s.AuditService)
+	adminRouter.HandleFunc("/audit_events", adminAuditEventHandler.List).
+		Name(logger.NewLogEvent("admin-list-audit-events", "[admin] list the audit events of the create, update and delete requests").ToString()).
+		Methods(http.MethodGet)
 
 	clusterHandler := handlers.NewClusterHandler(s.KasFleetshardOperatorAddon, s.ClusterService)
 	clusterRouter := apiV1Router.PathPrefix("/clusters").Subrouter()
@@ -281,3 +482,39 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 
 	return nil
 }
+
+// lookupKafka returns the kafka instance roles are granted on, whoever the user of the request is
+func (s *options) lookupKafka(ctx context.Context, id string) (*rbac.Resource, *errors.ServiceError) {
+	kafkaRequest, err := s.Kafka.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return &rbac.Resource{
+		Type:           api.GrantResourceTypeKafka,
+		Id:             kafkaRequest.ID,
+		Owner:          kafkaRequest.Owner,
+		OrganisationId: kafkaRequest.OrganisationId,
+	}, nil
+}
+
+// snapshotKafka returns the state of a kafka instance recorded in the audit events, without the credentials of its canary
+func (s *options) snapshotKafka(ctx context.Context, id string) (interface{}, *errors.ServiceError) {
+	kafkaRequest, err := s.Kafka.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := *kafkaRequest
+	snapshot.CanaryServiceAccountClientSecret = ""
+	return snapshot, nil
+}
+
+// snapshotServiceAccount returns the state of a service account recorded in the audit events, without its secret
+func (s *options) snapshotServiceAccount(ctx context.Context, id string) (interface{}, *errors.ServiceError) {
+	serviceAccount, err := s.Keycloak.GetServiceAccountById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := *serviceAccount
+	snapshot.ClientSecret = ""
+	return snapshot, nil
+}
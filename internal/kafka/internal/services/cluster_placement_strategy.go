@@ -10,13 +10,44 @@ import (
 //go:generate moq -out cluster_placement_strategy_moq.go . ClusterPlacementStrategy
 type ClusterPlacementStrategy interface {
 	// FindCluster finds and returns a Cluster depends on the specific impl.
+	// The cluster the kafka is currently assigned to, if any, is never returned so that the strategy can be used to find the target of a migration.
 	FindCluster(kafka *dbapi.KafkaRequest) (*api.Cluster, error)
+	// ExplainPlacement returns the cluster the kafka would be placed on, together with the reasoning behind the decision.
+	// It has no side effects and can be used to dry run a placement.
+	ExplainPlacement(kafka *dbapi.KafkaRequest) (*ClusterPlacementDecision, error)
+	// FitsCurrentCluster returns whether the cluster the kafka is assigned to has the capacity to host the kafka once resized
+	// to the given size. Shrinking a kafka always fits its current cluster.
+	FitsCurrentCluster(kafka *dbapi.KafkaRequest, sizeId string) (bool, error)
+}
+
+// ClusterPlacementDecision describes the outcome of a placement and how it was reached
+type ClusterPlacementDecision struct {
+	// Strategy is the name of the strategy that took the decision
+	Strategy string
+	// Cluster is the selected cluster. It is nil when no cluster can host the kafka
+	Cluster *api.Cluster
+	// Candidates lists the clusters that were considered. Strategies that do not score clusters only list the selected one
+	Candidates []ClusterPlacementCandidate
+}
+
+// ClusterPlacementCandidate describes how a single cluster was evaluated during a placement
+type ClusterPlacementCandidate struct {
+	ClusterID string
+	Eligible  bool
+	// Reason explains why the cluster is not eligible
+	Reason string
+	// Score is the weighted sum of the individual scores. It is only set for eligible clusters
+	Score float64
+	// Scores contains the individual, unweighted scores keyed by criteria
+	Scores map[string]float64
 }
 
 // NewClusterPlacementStrategy return a concrete strategy impl. depends on the placement configuration
-func NewClusterPlacementStrategy(clusterService ClusterService, dataplaneClusterConfig *config.DataplaneClusterConfig, kafkaConfig *config.KafkaConfig) ClusterPlacementStrategy {
+func NewClusterPlacementStrategy(clusterService ClusterService, dataplaneClusterConfig *config.DataplaneClusterConfig, kafkaConfig *config.KafkaConfig, clusterPlacementConfig *config.ClusterPlacementConfig) ClusterPlacementStrategy {
 	var clusterSelection ClusterPlacementStrategy
 	switch {
+	case clusterPlacementConfig.IsScoredStrategyEnabled():
+		clusterSelection = &ScoredClusterPlacement{clusterService, dataplaneClusterConfig, kafkaConfig, clusterPlacementConfig}
 	case dataplaneClusterConfig.IsDataPlaneManualScalingEnabled():
 		clusterSelection = &FirstSchedulableWithinLimit{dataplaneClusterConfig, clusterService, kafkaConfig}
 	case dataplaneClusterConfig.IsDataPlaneAutoScalingEnabled():
@@ -39,6 +70,7 @@ func (f *FirstReadyCluster) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cluster
 		MultiAZ:               kafka.MultiAZ,
 		Status:                api.ClusterReady,
 		SupportedInstanceType: kafka.InstanceType,
+		ExcludedClusterID:     kafka.ClusterID,
 	}
 
 	cluster, err := f.ClusterService.FindCluster(criteria)
@@ -49,6 +81,50 @@ func (f *FirstReadyCluster) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cluster
 	return cluster, nil
 }
 
+func (f *FirstReadyCluster) ExplainPlacement(kafka *dbapi.KafkaRequest) (*ClusterPlacementDecision, error) {
+	return explainFirstFitPlacement("first-ready-cluster", f.FindCluster, kafka)
+}
+
+func (f *FirstReadyCluster) FitsCurrentCluster(kafka *dbapi.KafkaRequest, sizeId string) (bool, error) {
+	// the strategy does not track the capacity of the clusters
+	return true, nil
+}
+
+// resizeCapacityDelta returns the capacity the kafka consumes in addition to its current one once resized to the given size
+func resizeCapacityDelta(kafkaConfig *config.KafkaConfig, kafka *dbapi.KafkaRequest, sizeId string) (int, error) {
+	currentSize, err := kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get the current size of kafka %q", kafka.ID)
+	}
+
+	newSize, err := kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, sizeId)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get the size %q of kafka %q", sizeId, kafka.ID)
+	}
+
+	return newSize.CapacityConsumed - currentSize.CapacityConsumed, nil
+}
+
+// explainFirstFitPlacement explains the decision of first fit strategies. These strategies stop at the first
+// cluster that passes their checks so only the selected cluster is reported as a candidate.
+func explainFirstFitPlacement(strategy string, findCluster func(kafka *dbapi.KafkaRequest) (*api.Cluster, error), kafka *dbapi.KafkaRequest) (*ClusterPlacementDecision, error) {
+	cluster, err := findCluster(kafka)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &ClusterPlacementDecision{
+		Strategy:   strategy,
+		Cluster:    cluster,
+		Candidates: []ClusterPlacementCandidate{},
+	}
+	if cluster != nil {
+		decision.Candidates = append(decision.Candidates, ClusterPlacementCandidate{ClusterID: cluster.ClusterID, Eligible: true})
+	}
+
+	return decision, nil
+}
+
 // FirstSchedulableWithinLimit finds and returns the first cluster which is schedulable and the number of
 // Kafka clusters associated with it is within the defined limit.
 type FirstSchedulableWithinLimit struct {
@@ -64,6 +140,7 @@ func (f *FirstSchedulableWithinLimit) FindCluster(kafka *dbapi.KafkaRequest) (*a
 		MultiAZ:               kafka.MultiAZ,
 		Status:                api.ClusterReady,
 		SupportedInstanceType: kafka.InstanceType,
+		ExcludedClusterID:     kafka.ClusterID,
 	}
 
 	kafkaInstanceSize, e := f.KafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
@@ -111,6 +188,32 @@ func (f *FirstSchedulableWithinLimit) FindCluster(kafka *dbapi.KafkaRequest) (*a
 	return nil, nil
 }
 
+func (f *FirstSchedulableWithinLimit) ExplainPlacement(kafka *dbapi.KafkaRequest) (*ClusterPlacementDecision, error) {
+	return explainFirstFitPlacement("first-schedulable-within-limit", f.FindCluster, kafka)
+}
+
+func (f *FirstSchedulableWithinLimit) FitsCurrentCluster(kafka *dbapi.KafkaRequest, sizeId string) (bool, error) {
+	delta, err := resizeCapacityDelta(f.KafkaConfig, kafka, sizeId)
+	if err != nil {
+		return false, err
+	}
+	if delta <= 0 {
+		return true, nil
+	}
+
+	dataplaneClusterConfig := f.DataplaneClusterConfig.ClusterConfig
+	if !dataplaneClusterConfig.IsClusterSchedulable(kafka.ClusterID) {
+		return false, nil
+	}
+
+	counts, err := f.findClusterKafkaInstanceCount([]string{kafka.ClusterID})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to find cluster kafka instance count for cluster %q", kafka.ClusterID)
+	}
+
+	return dataplaneClusterConfig.IsNumberOfKafkaWithinClusterLimit(kafka.ClusterID, counts[kafka.ClusterID]+delta), nil
+}
+
 func searchClusterObjInArray(clusters []*api.Cluster, clusterId string) *api.Cluster {
 	for _, cluster := range clusters {
 		if cluster.ClusterID == clusterId {
@@ -147,6 +250,7 @@ func (f *FirstReadyWithCapacity) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cl
 		MultiAZ:               kafka.MultiAZ,
 		Status:                api.ClusterReady,
 		SupportedInstanceType: kafka.InstanceType,
+		ExcludedClusterID:     kafka.ClusterID,
 	}
 
 	clusters, findAllClusterErr := f.ClusterService.FindAllClusters(criteria)
@@ -179,3 +283,35 @@ func (f *FirstReadyWithCapacity) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cl
 	// no cluster found
 	return nil, nil
 }
+
+func (f *FirstReadyWithCapacity) ExplainPlacement(kafka *dbapi.KafkaRequest) (*ClusterPlacementDecision, error) {
+	return explainFirstFitPlacement("first-ready-with-capacity", f.FindCluster, kafka)
+}
+
+func (f *FirstReadyWithCapacity) FitsCurrentCluster(kafka *dbapi.KafkaRequest, sizeId string) (bool, error) {
+	delta, err := resizeCapacityDelta(f.KafkaConfig, kafka, sizeId)
+	if err != nil {
+		return false, err
+	}
+	if delta <= 0 {
+		return true, nil
+	}
+
+	cluster, svcErr := f.ClusterService.FindClusterByID(kafka.ClusterID)
+	if svcErr != nil {
+		return false, errors.Wrapf(svcErr, "failed to find cluster %q", kafka.ClusterID)
+	}
+	if cluster == nil {
+		return false, nil
+	}
+
+	streamingUnitCountPerRegionList, err := f.ClusterService.FindStreamingUnitCountByClusterAndInstanceType()
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get count of streaming units by cluster and instance type")
+	}
+
+	currentStreamingUnitsUsed := streamingUnitCountPerRegionList.GetStreamingUnitCountForClusterAndInstanceType(cluster.ClusterID, kafka.InstanceType)
+	maxStreamingUnits := cluster.RetrieveDynamicCapacityInfo()[kafka.InstanceType].MaxUnits
+
+	return currentStreamingUnitsUsed+delta <= int(maxStreamingUnits), nil
+}
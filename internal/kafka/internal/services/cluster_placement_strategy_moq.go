@@ -19,9 +19,15 @@ var _ ClusterPlacementStrategy = &ClusterPlacementStrategyMock{}
 //
 //		// make and configure a mocked ClusterPlacementStrategy
 //		mockedClusterPlacementStrategy := &ClusterPlacementStrategyMock{
+//			ExplainPlacementFunc: func(kafka *dbapi.KafkaRequest) (*ClusterPlacementDecision, error) {
+//				panic("mock out the ExplainPlacement method")
+//			},
 //			FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
 //				panic("mock out the FindCluster method")
 //			},
+//			FitsCurrentClusterFunc: func(kafka *dbapi.KafkaRequest, sizeId string) (bool, error) {
+//				panic("mock out the FitsCurrentCluster method")
+//			},
 //		}
 //
 //		// use mockedClusterPlacementStrategy in code that requires ClusterPlacementStrategy
@@ -29,18 +35,70 @@ var _ ClusterPlacementStrategy = &ClusterPlacementStrategyMock{}
 //
 //	}
 type ClusterPlacementStrategyMock struct {
+	// ExplainPlacementFunc mocks the ExplainPlacement method.
+	ExplainPlacementFunc func(kafka *dbapi.KafkaRequest) (*ClusterPlacementDecision, error)
+
 	// FindClusterFunc mocks the FindCluster method.
 	FindClusterFunc func(kafka *dbapi.KafkaRequest) (*api.Cluster, error)
 
+	// FitsCurrentClusterFunc mocks the FitsCurrentCluster method.
+	FitsCurrentClusterFunc func(kafka *dbapi.KafkaRequest, sizeId string) (bool, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// ExplainPlacement holds details about calls to the ExplainPlacement method.
+		ExplainPlacement []struct {
+			// Kafka is the kafka argument value.
+			Kafka *dbapi.KafkaRequest
+		}
 		// FindCluster holds details about calls to the FindCluster method.
 		FindCluster []struct {
 			// Kafka is the kafka argument value.
 			Kafka *dbapi.KafkaRequest
 		}
+		// FitsCurrentCluster holds details about calls to the FitsCurrentCluster method.
+		FitsCurrentCluster []struct {
+			// Kafka is the kafka argument value.
+			Kafka *dbapi.KafkaRequest
+			// SizeId is the sizeId argument value.
+			SizeId string
+		}
+	}
+	lockExplainPlacement   sync.RWMutex
+	lockFindCluster        sync.RWMutex
+	lockFitsCurrentCluster sync.RWMutex
+}
+
+// ExplainPlacement calls ExplainPlacementFunc.
+func (mock *ClusterPlacementStrategyMock) ExplainPlacement(kafka *dbapi.KafkaRequest) (*ClusterPlacementDecision, error) {
+	if mock.ExplainPlacementFunc == nil {
+		panic("ClusterPlacementStrategyMock.ExplainPlacementFunc: method is nil but ClusterPlacementStrategy.ExplainPlacement was just called")
+	}
+	callInfo := struct {
+		Kafka *dbapi.KafkaRequest
+	}{
+		Kafka: kafka,
+	}
+	mock.lockExplainPlacement.Lock()
+	mock.calls.ExplainPlacement = append(mock.calls.ExplainPlacement, callInfo)
+	mock.lockExplainPlacement.Unlock()
+	return mock.ExplainPlacementFunc(kafka)
+}
+
+// ExplainPlacementCalls gets all the calls that were made to ExplainPlacement.
+// Check the length with:
+//
+//	len(mockedClusterPlacementStrategy.ExplainPlacementCalls())
+func (mock *ClusterPlacementStrategyMock) ExplainPlacementCalls() []struct {
+	Kafka *dbapi.KafkaRequest
+} {
+	var calls []struct {
+		Kafka *dbapi.KafkaRequest
 	}
-	lockFindCluster sync.RWMutex
+	mock.lockExplainPlacement.RLock()
+	calls = mock.calls.ExplainPlacement
+	mock.lockExplainPlacement.RUnlock()
+	return calls
 }
 
 // FindCluster calls FindClusterFunc.
@@ -74,3 +132,39 @@ func (mock *ClusterPlacementStrategyMock) FindClusterCalls() []struct {
 	mock.lockFindCluster.RUnlock()
 	return calls
 }
+
+// FitsCurrentCluster calls FitsCurrentClusterFunc.
+func (mock *ClusterPlacementStrategyMock) FitsCurrentCluster(kafka *dbapi.KafkaRequest, sizeId string) (bool, error) {
+	if mock.FitsCurrentClusterFunc == nil {
+		panic("ClusterPlacementStrategyMock.FitsCurrentClusterFunc: method is nil but ClusterPlacementStrategy.FitsCurrentCluster was just called")
+	}
+	callInfo := struct {
+		Kafka  *dbapi.KafkaRequest
+		SizeId string
+	}{
+		Kafka:  kafka,
+		SizeId: sizeId,
+	}
+	mock.lockFitsCurrentCluster.Lock()
+	mock.calls.FitsCurrentCluster = append(mock.calls.FitsCurrentCluster, callInfo)
+	mock.lockFitsCurrentCluster.Unlock()
+	return mock.FitsCurrentClusterFunc(kafka, sizeId)
+}
+
+// FitsCurrentClusterCalls gets all the calls that were made to FitsCurrentCluster.
+// Check the length with:
+//
+//	len(mockedClusterPlacementStrategy.FitsCurrentClusterCalls())
+func (mock *ClusterPlacementStrategyMock) FitsCurrentClusterCalls() []struct {
+	Kafka  *dbapi.KafkaRequest
+	SizeId string
+} {
+	var calls []struct {
+		Kafka  *dbapi.KafkaRequest
+		SizeId string
+	}
+	mock.lockFitsCurrentCluster.RLock()
+	calls = mock.calls.FitsCurrentCluster
+	mock.lockFitsCurrentCluster.RUnlock()
+	return calls
+}
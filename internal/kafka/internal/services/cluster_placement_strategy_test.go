@@ -574,3 +574,62 @@ func TestFirstReadyWithCapacity_FindCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestFirstReadyCluster_ExplainPlacement(t *testing.T) {
+	tests := []struct {
+		name           string
+		ClusterService ClusterService
+		want           *ClusterPlacementDecision
+		wantErr        bool
+	}{
+		{
+			name: "should report the selected cluster as the only candidate",
+			ClusterService: &ClusterServiceMock{
+				FindClusterFunc: func(criteria FindClusterCriteria) (*api.Cluster, error) {
+					return &api.Cluster{ClusterID: mockkafkas.DefaultClusterID}, nil
+				},
+			},
+			want: &ClusterPlacementDecision{
+				Strategy:   "first-ready-cluster",
+				Cluster:    &api.Cluster{ClusterID: mockkafkas.DefaultClusterID},
+				Candidates: []ClusterPlacementCandidate{{ClusterID: mockkafkas.DefaultClusterID, Eligible: true}},
+			},
+		},
+		{
+			name: "should not report any candidate when no cluster is found",
+			ClusterService: &ClusterServiceMock{
+				FindClusterFunc: func(criteria FindClusterCriteria) (*api.Cluster, error) {
+					return nil, nil
+				},
+			},
+			want: &ClusterPlacementDecision{
+				Strategy:   "first-ready-cluster",
+				Candidates: []ClusterPlacementCandidate{},
+			},
+		},
+		{
+			name: "should return an error when the cluster cannot be found",
+			ClusterService: &ClusterServiceMock{
+				FindClusterFunc: func(criteria FindClusterCriteria) (*api.Cluster, error) {
+					return nil, errors.New("not found")
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			f := &FirstReadyCluster{
+				ClusterService: tt.ClusterService,
+			}
+
+			got, err := f.ExplainPlacement(&dbapi.KafkaRequest{})
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -19,12 +19,16 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const clusterEventSource = "/api/kafkas_mgmt/v1/clusters"
+
 var kafkaStatusesThatNoLongerConsumeResourcesInTheDataPlane = []string{constants.KafkaRequestStatusDeleting.String()}
 
 //go:generate moq -out clusterservice_moq.go . ClusterService
@@ -61,6 +65,9 @@ type ClusterService interface {
 	// FindKafkaInstanceCount returns the kafka instance counts associated with the list of clusters. If the list is empty, it will list all clusterIDs that have Kafka instances assigned.
 	// Kafkas that are in deleting state won't be included in the count as they no longer consume resources in the data plane cluster.
 	FindKafkaInstanceCount(clusterIDs []string) ([]ResKafkaInstanceCount, error)
+	// FindKafkaInstanceCountByOrganisation returns the number of kafka instances of the given organisation associated with each of the given clusters.
+	// Clusters without any kafka of the organisation are included with a count of 0.
+	FindKafkaInstanceCountByOrganisation(clusterIDs []string, organisationID string) ([]ResKafkaInstanceCount, error)
 	// UpdateMultiClusterStatus updates a list of clusters' status to a status
 	UpdateMultiClusterStatus(clusterIDs []string, status api.ClusterStatus) *apiErrors.ServiceError
 	// CountByStatus returns the count of clusters for each given status in the database
@@ -257,7 +264,31 @@ func (c clusterService) UpdateStatus(cluster api.Cluster, status api.ClusterStat
 		query, arg = "cluster_id = ?", cluster.ClusterID
 	}
 
-	if err := dbConn.Model(&api.Cluster{}).Where(query, arg).Updates(map[string]interface{}{"status": status}).Error; err != nil {
+	err := dbConn.Transaction(func(tx *gorm.DB) error {
+		var previous api.Cluster
+		if err := tx.Model(&api.Cluster{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("cluster_id", "status", "organization_id").
+			Where(query, arg).
+			Scan(&previous).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&api.Cluster{}).Where(query, arg).Updates(map[string]interface{}{"status": status})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 || previous.Status == status {
+			return nil
+		}
+		return webhooks.RecordEvent(tx, webhooks.DataPlaneClusterStatusChangedEventType, clusterEventSource, previous.ClusterID, previous.OrganizationID, webhooks.StatusChange{
+			ID:             previous.ClusterID,
+			Status:         status.String(),
+			PreviousStatus: previous.Status.String(),
+		})
+	})
+	if err != nil {
 		return apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to update cluster status")
 	}
 
@@ -301,6 +332,8 @@ type FindClusterCriteria struct {
 	MultiAZ               bool
 	Status                api.ClusterStatus
 	SupportedInstanceType string
+	// ExcludedClusterID is the id of a cluster that must not be returned, e.g. the source cluster of a kafka migration
+	ExcludedClusterID string
 }
 
 func (c clusterService) FindCluster(criteria FindClusterCriteria) (*api.Cluster, error) {
@@ -320,6 +353,10 @@ func (c clusterService) FindCluster(criteria FindClusterCriteria) (*api.Cluster,
 		dbConn = dbConn.Where("supported_instance_type like ?", fmt.Sprintf("%%%s%%", criteria.SupportedInstanceType))
 	}
 
+	if criteria.ExcludedClusterID != "" {
+		dbConn = dbConn.Where("cluster_id != ?", criteria.ExcludedClusterID)
+	}
+
 	// we order them by "created_at" field instead of the default "id" field.
 	// They are mostly the same as the library we use (xid) does take the generation timestamp into consideration,
 	// However, it only down to the level of seconds. This means that if a few records are created at almost the same time,
@@ -444,7 +481,8 @@ func (c clusterService) FindKafkaInstanceCount(clusterIDs []string) ([]ResKafkaI
 		Where("status not in (?)", kafkaStatusesThatNoLongerConsumeResourcesInTheDataPlane)
 
 	if len(clusterIDs) > 0 {
-		query = query.Where("cluster_id in (?)", clusterIDs)
+		// kafkas being migrated consume capacity on both the source and the target clusters of the migration
+		query = query.Where("cluster_id in (?) OR migration_source_cluster_id in (?) OR migration_target_cluster_id in (?)", clusterIDs, clusterIDs, clusterIDs)
 	}
 
 	query = query.Scan(&kafkas)
@@ -463,6 +501,9 @@ func (c clusterService) FindKafkaInstanceCount(clusterIDs []string) ([]ResKafkaI
 			return nil, e
 		}
 		clusterIdCountMap[k.ClusterID] += kafkaInstanceSize.CapacityConsumed
+		if migrationClusterID := k.GetMigrationClusterID(); migrationClusterID != "" {
+			clusterIdCountMap[migrationClusterID] += kafkaInstanceSize.CapacityConsumed
+		}
 	}
 
 	// the query above won't return a count for a clusterId if that cluster doesn't have any Kafkas,
@@ -482,6 +523,33 @@ func (c clusterService) FindKafkaInstanceCount(clusterIDs []string) ([]ResKafkaI
 	return res, nil
 }
 
+func (c clusterService) FindKafkaInstanceCountByOrganisation(clusterIDs []string, organisationID string) ([]ResKafkaInstanceCount, error) {
+	var counts []ResKafkaInstanceCount
+
+	if err := c.connectionFactory.New().
+		Model(&dbapi.KafkaRequest{}).
+		Select("cluster_id as Clusterid, count(1) as Count").
+		Where("status not in (?)", kafkaStatusesThatNoLongerConsumeResourcesInTheDataPlane).
+		Where("organisation_id = ?", organisationID).
+		Where("cluster_id in (?)", clusterIDs).
+		Group("cluster_id").
+		Scan(&counts).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to count kafkas of organisation %q per cluster", organisationID)
+	}
+
+	countPerCluster := map[string]int{}
+	for _, count := range counts {
+		countPerCluster[count.Clusterid] = count.Count
+	}
+
+	res := make([]ResKafkaInstanceCount, 0, len(clusterIDs))
+	for _, clusterID := range clusterIDs {
+		res = append(res, ResKafkaInstanceCount{Clusterid: clusterID, Count: countPerCluster[clusterID]})
+	}
+
+	return res, nil
+}
+
 func (c clusterService) FindAllClusters(criteria FindClusterCriteria) ([]*api.Cluster, error) {
 	dbConn := c.connectionFactory.New().
 		Model(&api.Cluster{})
@@ -499,6 +567,9 @@ func (c clusterService) FindAllClusters(criteria FindClusterCriteria) ([]*api.Cl
 	if criteria.SupportedInstanceType != "" {
 		dbConn.Where("supported_instance_type like ?", fmt.Sprintf("%%%s%%", criteria.SupportedInstanceType))
 	}
+	if criteria.ExcludedClusterID != "" {
+		dbConn.Where("cluster_id != ?", criteria.ExcludedClusterID)
+	}
 	// we order them by "created_at" field instead of the default "id" field.
 	// They are mostly the same as the library we use (xid) does take the generation timestamp into consideration,
 	// However, it only down to the level of seconds. This means that if a few records are created at almost the same time,
@@ -745,6 +816,10 @@ func (kafkaStreamingUnitCountPerClusterList KafkaStreamingUnitCountPerClusterLis
 	return 0
 }
 
+// kafkaMigrationClusterIDColumn selects the migration cluster a kafka is not assigned to: the target cluster until
+// the kafka is switched over to it, the source cluster afterwards
+const kafkaMigrationClusterIDColumn = "CASE WHEN migration_target_cluster_id = cluster_id THEN migration_source_cluster_id ELSE migration_target_cluster_id END"
+
 // KafkaPerClusterCount is a struct used to query the database using a "group by" clause
 type KafkaPerClusterCount struct {
 	Region        string
@@ -822,6 +897,18 @@ func (c *clusterService) FindStreamingUnitCountByClusterAndInstanceType() (Kafka
 		return nil, errors.Wrap(err, "failed to perform count query on kafkas table")
 	}
 
+	// kafkas being migrated also consume capacity on the migration cluster they are not assigned to
+	var migratingKafkasPerCluster []*KafkaPerClusterCount
+	if err := c.connectionFactory.New().Model(&dbapi.KafkaRequest{}).
+		Select(fmt.Sprintf("cloud_provider, region, count(1) as Count, size_id, %s as cluster_id, instance_type", kafkaMigrationClusterIDColumn)).
+		Group(fmt.Sprintf("size_id, %s, cloud_provider, region, instance_type", kafkaMigrationClusterIDColumn)).
+		Where("status not in (?)", kafkaStatusesThatNoLongerConsumeResourcesInTheDataPlane).
+		Where("migration_target_cluster_id != ''").
+		Scan(&migratingKafkasPerCluster).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to perform count query of migrating kafkas on kafkas table")
+	}
+	kafkasPerCluster = append(kafkasPerCluster, migratingKafkasPerCluster...)
+
 	for _, kafkaCountPerCluster := range kafkasPerCluster {
 		instSize, err := c.kafkaConfig.GetKafkaInstanceSize(kafkaCountPerCluster.InstanceType, kafkaCountPerCluster.SizeId)
 		if err != nil {
@@ -712,7 +712,8 @@ func Test_UpdateStatus(t *testing.T) {
 			wantErr: false,
 			want:    nil,
 			setupFn: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "clusters" SET "status"=$1,"updated_at"=$2 WHERE id = $3`)
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT "cluster_id","status","organization_id" FROM "clusters" WHERE id = $1`)
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "clusters" SET "status"=$1,"updated_at"=$2 WHERE id = $3`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
@@ -728,7 +729,29 @@ func Test_UpdateStatus(t *testing.T) {
 			wantErr: false,
 			want:    nil,
 			setupFn: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "clusters" SET "status"=$1,"updated_at"=$2 WHERE cluster_id = $3`)
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT "cluster_id","status","organization_id" FROM "clusters" WHERE cluster_id = $1`)
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "clusters" SET "status"=$1,"updated_at"=$2 WHERE cluster_id = $3`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "successful status update records a status changed event",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				status:  api.ClusterReady,
+				cluster: api.Cluster{ClusterID: testID},
+			},
+			wantErr: false,
+			want:    nil,
+			setupFn: func() {
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT "cluster_id","status","organization_id" FROM "clusters" WHERE cluster_id = $1`).
+					WithReply([]map[string]interface{}{{"cluster_id": testID, "status": api.ClusterWaitingForKasFleetShardOperator.String()}})
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "clusters" SET "status"=$1,"updated_at"=$2 WHERE cluster_id = $3`).WithRowsNum(1)
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "outbox_events"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
@@ -1299,6 +1322,77 @@ func Test_clusterService_FindKafkaInstanceCount(t *testing.T) {
 	}
 }
 
+func Test_clusterService_FindKafkaInstanceCountByOrganisation(t *testing.T) {
+	type args struct {
+		clusterIDs     []string
+		organisationID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []ResKafkaInstanceCount
+		wantErr bool
+		setupFn func()
+	}{
+		{
+			name: "should return the count of kafkas of the organisation for each of the given clusters",
+			args: args{
+				clusterIDs:     []string{"test01", "test02"},
+				organisationID: "org-id",
+			},
+			want: []ResKafkaInstanceCount{
+				{
+					Clusterid: "test01",
+					Count:     2,
+				},
+				{
+					Clusterid: "test02",
+					Count:     0,
+				},
+			},
+			setupFn: func() {
+				counters := []map[string]interface{}{
+					{
+						"clusterid": "test01",
+						"count":     2,
+					},
+				}
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT cluster_id as Clusterid, count(1) as Count FROM "kafka_requests" WHERE status not in ($1) AND (organisation_id = $2) AND cluster_id in ($3,$4) AND "kafka_requests"."deleted_at" IS NULL GROUP BY "cluster_id"`).WithReply(counters)
+			},
+		},
+		{
+			name: "should return an error when the query fails",
+			args: args{
+				clusterIDs:     []string{"test01"},
+				organisationID: "org-id",
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT`).WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			c := clusterService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			got, err := c.FindKafkaInstanceCountByOrganisation(tt.args.clusterIDs, tt.args.organisationID)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(got).To(gomega.Equal(tt.want))
+			}
+		})
+	}
+}
+
 func Test_clusterService_FindAllClusters(t *testing.T) {
 	type fields struct {
 		connectionFactory *db.ConnectionFactory
@@ -2805,6 +2899,10 @@ func Test_clusterService_FindStreamingUnitCountByClusterAndInstanceType(t *testi
 					WithQuery(`SELECT * FROM "clusters"`).
 					WithReply([]map[string]interface{}{})
 
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT cloud_provider, region, count(1) as Count, size_id, CASE WHEN migration_target_cluster_id = cluster_id`).
+					WithReply([]map[string]interface{}{})
+
 				mocket.Catcher.NewMock().WithQueryException().WithExecException()
 			},
 			want: KafkaStreamingUnitCountPerClusterList{},
@@ -2873,6 +2971,10 @@ func Test_clusterService_FindStreamingUnitCountByClusterAndInstanceType(t *testi
 						},
 					})
 
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT cloud_provider, region, count(1) as Count, size_id, CASE WHEN migration_target_cluster_id = cluster_id`).
+					WithReply([]map[string]interface{}{})
+
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 			want: KafkaStreamingUnitCountPerClusterList{
@@ -58,6 +58,9 @@ var _ ClusterService = &ClusterServiceMock{}
 //			FindKafkaInstanceCountFunc: func(clusterIDs []string) ([]ResKafkaInstanceCount, error) {
 //				panic("mock out the FindKafkaInstanceCount method")
 //			},
+//			FindKafkaInstanceCountByOrganisationFunc: func(clusterIDs []string, organisationID string) ([]ResKafkaInstanceCount, error) {
+//				panic("mock out the FindKafkaInstanceCountByOrganisation method")
+//			},
 //			FindNonEmptyClusterByIDFunc: func(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
 //				panic("mock out the FindNonEmptyClusterByID method")
 //			},
@@ -149,6 +152,9 @@ type ClusterServiceMock struct {
 	// FindKafkaInstanceCountFunc mocks the FindKafkaInstanceCount method.
 	FindKafkaInstanceCountFunc func(clusterIDs []string) ([]ResKafkaInstanceCount, error)
 
+	// FindKafkaInstanceCountByOrganisationFunc mocks the FindKafkaInstanceCountByOrganisation method.
+	FindKafkaInstanceCountByOrganisationFunc func(clusterIDs []string, organisationID string) ([]ResKafkaInstanceCount, error)
+
 	// FindNonEmptyClusterByIDFunc mocks the FindNonEmptyClusterByID method.
 	FindNonEmptyClusterByIDFunc func(clusterID string) (*api.Cluster, *apiErrors.ServiceError)
 
@@ -265,6 +271,13 @@ type ClusterServiceMock struct {
 			// ClusterIDs is the clusterIDs argument value.
 			ClusterIDs []string
 		}
+		// FindKafkaInstanceCountByOrganisation holds details about calls to the FindKafkaInstanceCountByOrganisation method.
+		FindKafkaInstanceCountByOrganisation []struct {
+			// ClusterIDs is the clusterIDs argument value.
+			ClusterIDs []string
+			// OrganisationID is the organisationID argument value.
+			OrganisationID string
+		}
 		// FindNonEmptyClusterByID holds details about calls to the FindNonEmptyClusterByID method.
 		FindNonEmptyClusterByID []struct {
 			// ClusterID is the clusterID argument value.
@@ -370,6 +383,7 @@ type ClusterServiceMock struct {
 	lockFindCluster                                    sync.RWMutex
 	lockFindClusterByID                                sync.RWMutex
 	lockFindKafkaInstanceCount                         sync.RWMutex
+	lockFindKafkaInstanceCountByOrganisation           sync.RWMutex
 	lockFindNonEmptyClusterByID                        sync.RWMutex
 	lockFindStreamingUnitCountByClusterAndInstanceType sync.RWMutex
 	lockGetClientID                                    sync.RWMutex
@@ -784,6 +798,42 @@ func (mock *ClusterServiceMock) FindKafkaInstanceCountCalls() []struct {
 	return calls
 }
 
+// FindKafkaInstanceCountByOrganisation calls FindKafkaInstanceCountByOrganisationFunc.
+func (mock *ClusterServiceMock) FindKafkaInstanceCountByOrganisation(clusterIDs []string, organisationID string) ([]ResKafkaInstanceCount, error) {
+	if mock.FindKafkaInstanceCountByOrganisationFunc == nil {
+		panic("ClusterServiceMock.FindKafkaInstanceCountByOrganisationFunc: method is nil but ClusterService.FindKafkaInstanceCountByOrganisation was just called")
+	}
+	callInfo := struct {
+		ClusterIDs     []string
+		OrganisationID string
+	}{
+		ClusterIDs:     clusterIDs,
+		OrganisationID: organisationID,
+	}
+	mock.lockFindKafkaInstanceCountByOrganisation.Lock()
+	mock.calls.FindKafkaInstanceCountByOrganisation = append(mock.calls.FindKafkaInstanceCountByOrganisation, callInfo)
+	mock.lockFindKafkaInstanceCountByOrganisation.Unlock()
+	return mock.FindKafkaInstanceCountByOrganisationFunc(clusterIDs, organisationID)
+}
+
+// FindKafkaInstanceCountByOrganisationCalls gets all the calls that were made to FindKafkaInstanceCountByOrganisation.
+// Check the length with:
+//
+//	len(mockedClusterService.FindKafkaInstanceCountByOrganisationCalls())
+func (mock *ClusterServiceMock) FindKafkaInstanceCountByOrganisationCalls() []struct {
+	ClusterIDs     []string
+	OrganisationID string
+} {
+	var calls []struct {
+		ClusterIDs     []string
+		OrganisationID string
+	}
+	mock.lockFindKafkaInstanceCountByOrganisation.RLock()
+	calls = mock.calls.FindKafkaInstanceCountByOrganisation
+	mock.lockFindKafkaInstanceCountByOrganisation.RUnlock()
+	return calls
+}
+
 // FindNonEmptyClusterByID calls FindNonEmptyClusterByIDFunc.
 func (mock *ClusterServiceMock) FindNonEmptyClusterByID(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
 	if mock.FindNonEmptyClusterByIDFunc == nil {
@@ -147,6 +147,10 @@ func (d *dataPlaneKafkaService) processRealKafkaDeployment(ks *dbapi.DataPlaneKa
 		glog.Error(errors.Wrapf(getErr, "failed to get kafka request by kafka ID %q", ks.KafkaClusterId))
 		return
 	}
+	if kafka.ClusterID != cluster.ClusterID && kafka.GetMigrationClusterID() == cluster.ClusterID {
+		d.processMigratingKafkaDeployment(kafka, ks, cluster, log)
+		return
+	}
 	if kafka.ClusterID != cluster.ClusterID {
 		log.Warningf("kafka with ID %q does not match cluster's ClusterID. kafka ClusterID = %q, cluster's ClusterID = %q", kafka.ID, kafka.ClusterID, cluster.ClusterID)
 		return
@@ -219,6 +223,81 @@ func (d *dataPlaneKafkaService) processRealKafkaDeployment(ks *dbapi.DataPlaneKa
 	}
 }
 
+// processMigratingKafkaDeployment handles the status of a kafka reported by the migration cluster the kafka is not assigned to.
+// This is the target cluster until the kafka is switched over to it, and the source cluster afterwards.
+//   - the kafka is switched over to the target cluster once the target reports it as ready
+//   - the migration fails if the target reports an error or rejects the kafka. The kafka keeps being served by the source cluster
+//   - the migration completes once the ManagedKafka is reported as deleted by the cluster the kafka is not assigned to
+func (d *dataPlaneKafkaService) processMigratingKafkaDeployment(kafka *dbapi.KafkaRequest, ks *dbapi.DataPlaneKafkaStatus, cluster *api.Cluster, log logger.UHCLogger) {
+	var e *serviceError.ServiceError
+	switch s := d.getManagedKafkaStatus(ks); s {
+	case statusReady:
+		if kafka.MigrationStatus == constants.KafkaMigrationStatusProvisioning.String() {
+			e = d.setKafkaMigrationSwitchingRoutes(kafka, ks, cluster)
+		}
+	case statusError, statusRejected, statusRejectedClusterFull:
+		if kafka.MigrationStatus == constants.KafkaMigrationStatusProvisioning.String() {
+			readyCondition, _ := ks.GetReadyCondition()
+			log.Errorf("migration of kafka %q to cluster %q failed: %q", kafka.ID, cluster.ClusterID, readyCondition.Message)
+			e = d.kafkaService.Updates(kafka, map[string]interface{}{"migration_status": constants.KafkaMigrationStatusFailed.String()})
+		}
+	case statusDeleted:
+		e = d.completeKafkaMigration(kafka)
+	default:
+		log.V(5).Infof("kafka %q is still being migrated to cluster %q", ks.KafkaClusterId, kafka.MigrationTargetClusterID)
+	}
+	if e != nil {
+		log.Error(errors.Wrapf(e, "Error updating migration status of kafka %q", ks.KafkaClusterId))
+	}
+}
+
+// setKafkaMigrationSwitchingRoutes stores the routes of the kafka on the target cluster of its migration so that its
+// CNAME records can be switched over to them
+func (d *dataPlaneKafkaService) setKafkaMigrationSwitchingRoutes(kafka *dbapi.KafkaRequest, kafkaStatus *dbapi.DataPlaneKafkaStatus, cluster *api.Cluster) *serviceError.ServiceError {
+	if len(kafkaStatus.Routes) < 1 {
+		logger.Logger.V(10).Infof("skip switching routes for migrating Kafka %q as they are not available", kafka.ID)
+		return nil
+	}
+
+	routes, err := d.buildKafkaRoutesForCluster(kafka, kafkaStatus, cluster)
+	if err != nil {
+		return err
+	}
+
+	if err := kafka.SetRoutes(routes); err != nil {
+		return serviceError.NewWithCause(serviceError.ErrorGeneral, err, "failed to set routes for kafka %q", kafka.ID)
+	}
+
+	logger.Logger.Infof("kafka %q is ready on migration target cluster %q, switching its routes", kafka.ID, cluster.ClusterID)
+	return d.kafkaService.Updates(kafka, map[string]interface{}{
+		"routes":             kafka.Routes,
+		"routes_created":     false,
+		"routes_creation_id": "",
+		"migration_status":   constants.KafkaMigrationStatusSwitchingRoutes.String(),
+	})
+}
+
+// completeKafkaMigration releases the capacity reserved by the migration once the ManagedKafka has been removed from the
+// cluster the kafka is not assigned to. A failed migration keeps its status so that it can be seen by admins.
+func (d *dataPlaneKafkaService) completeKafkaMigration(kafka *dbapi.KafkaRequest) *serviceError.ServiceError {
+	migrationStatus := ""
+	switch kafka.MigrationStatus {
+	case constants.KafkaMigrationStatusDeprovisioningSource.String():
+		logger.Logger.Infof("kafka %q has been migrated from cluster %q to cluster %q", kafka.ID, kafka.MigrationSourceClusterID, kafka.MigrationTargetClusterID)
+	case constants.KafkaMigrationStatusFailed.String():
+		migrationStatus = constants.KafkaMigrationStatusFailed.String()
+	default:
+		return nil
+	}
+
+	return d.kafkaService.Updates(kafka, map[string]interface{}{
+		"migration_status":            migrationStatus,
+		"migration_source_cluster_id": "",
+		"migration_target_cluster_id": "",
+		"migration_placement_id":      "",
+	})
+}
+
 func (d *dataPlaneKafkaService) setKafkaClusterReady(kafka *dbapi.KafkaRequest) *serviceError.ServiceError {
 	if !kafka.RoutesCreated {
 		logger.Logger.V(10).Infof("routes for kafka %q are not created", kafka.ID)
@@ -437,18 +516,9 @@ func (d *dataPlaneKafkaService) persistKafkaRoutes(kafka *dbapi.KafkaRequest, ka
 	}
 
 	logger.Logger.Infof("store routes information for kafka %q", kafka.ID)
-	clusterDNS, err := d.clusterService.GetClusterDNS(cluster.ClusterID)
-	if err != nil {
-		return serviceError.NewWithCause(err.Code, err, "failed to get DNS entry for ClusterID %q", cluster.ClusterID)
-	}
-
-	routesInRequest := kafkaStatus.Routes
-	var routes []dbapi.DataPlaneKafkaRoute
-
-	var routesErr error
-	baseClusterDomain := strings.TrimPrefix(clusterDNS, fmt.Sprintf("%s.", constants.DefaultIngressDnsNamePrefix))
-	if routes, routesErr = d.buildKafkaRoutes(routesInRequest, kafka, baseClusterDomain); routesErr != nil {
-		return serviceError.NewWithCause(serviceError.ErrorBadRequest, routesErr, "routes are not valid")
+	routes, routesErr := d.buildKafkaRoutesForCluster(kafka, kafkaStatus, cluster)
+	if routesErr != nil {
+		return routesErr
 	}
 
 	if err := kafka.SetRoutes(routes); err != nil {
@@ -462,6 +532,22 @@ func (d *dataPlaneKafkaService) persistKafkaRoutes(kafka *dbapi.KafkaRequest, ka
 	return nil
 }
 
+// buildKafkaRoutesForCluster builds the routes of the kafka from the routes reported by the given cluster
+func (d *dataPlaneKafkaService) buildKafkaRoutesForCluster(kafka *dbapi.KafkaRequest, kafkaStatus *dbapi.DataPlaneKafkaStatus, cluster *api.Cluster) ([]dbapi.DataPlaneKafkaRoute, *serviceError.ServiceError) {
+	clusterDNS, err := d.clusterService.GetClusterDNS(cluster.ClusterID)
+	if err != nil {
+		return nil, serviceError.NewWithCause(err.Code, err, "failed to get DNS entry for ClusterID %q", cluster.ClusterID)
+	}
+
+	baseClusterDomain := strings.TrimPrefix(clusterDNS, fmt.Sprintf("%s.", constants.DefaultIngressDnsNamePrefix))
+	routes, routesErr := d.buildKafkaRoutes(kafkaStatus.Routes, kafka, baseClusterDomain)
+	if routesErr != nil {
+		return nil, serviceError.NewWithCause(serviceError.ErrorBadRequest, routesErr, "routes are not valid")
+	}
+
+	return routes, nil
+}
+
 func (d *dataPlaneKafkaService) getManagedKafkaStatus(status *dbapi.DataPlaneKafkaStatus) managedKafkaStatus {
 	for _, c := range status.Conditions {
 		if strings.EqualFold(c.Type, "Ready") {
@@ -2,14 +2,18 @@ package services
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"strings"
 	"sync"
 
 	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
@@ -29,6 +33,7 @@ import (
 
 	managedkafka "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api/managedkafkas.managedkafka.bf2.org/v1"
 	v1 "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api/managedkafkas.managedkafka.bf2.org/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/aws-sdk-go/service/route53"
@@ -51,12 +56,31 @@ var kafkaManagedCRStatuses = []string{
 	constants.KafkaRequestStatusResuming.String(),
 }
 
+// kafkaMigrationManagedCRStatuses are the migration statuses in which a ManagedKafka CR of the kafka is sent to the
+// migration cluster it is not assigned to
+var kafkaMigrationManagedCRStatuses = []string{
+	constants.KafkaMigrationStatusProvisioning.String(),
+	constants.KafkaMigrationStatusSwitchingRoutes.String(),
+	constants.KafkaMigrationStatusDeprovisioningSource.String(),
+	constants.KafkaMigrationStatusFailed.String(),
+}
+
 type KafkaRoutesAction string
 
 const KafkaRoutesActionCreate KafkaRoutesAction = "CREATE"
 const KafkaRoutesActionDelete KafkaRoutesAction = "DELETE"
+const KafkaRoutesActionUpsert KafkaRoutesAction = "UPSERT"
 const CanaryServiceAccountPrefix = "canary"
 
+// The topics and consumer groups of the primary of a disaster recovery pair are all mirrored to its standby
+const (
+	drMirrorMaker2Topics = ".*"
+	drMirrorMaker2Groups = ".*"
+)
+
+// kafkaEventSource is the source of the lifecycle events of kafkas delivered to webhooks
+const kafkaEventSource = "/api/kafkas_mgmt/v1/kafkas"
+
 type CNameRecordStatus struct {
 	Id     *string
 	Status *string
@@ -78,6 +102,9 @@ type KafkaService interface {
 	// The Kafka Request in the database will be updated with a deleted_at timestamp.
 	Delete(*dbapi.KafkaRequest) *errors.ServiceError
 	List(ctx context.Context, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError)
+	// ListChanges returns, ordered by resource version, up to listArgs.Size Kafka requests the given ctx has access to whose
+	// resource version is greater than gtVersion. Soft deleted Kafka requests are included when includeDeleted is true.
+	ListChanges(ctx context.Context, listArgs *services.ListArguments, gtVersion int64, includeDeleted bool) (dbapi.KafkaList, *errors.ServiceError)
 	// Lists all kafkas. As this returns all Kafka requests without need for authentication, this should only be used for internal purposes
 	ListAll() (dbapi.KafkaList, *errors.ServiceError)
 	GetManagedKafkaByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *errors.ServiceError)
@@ -114,6 +141,32 @@ type KafkaService interface {
 	CountByStatus(status []constants.KafkaStatus) ([]KafkaStatusCount, error)
 	ListKafkasWithRoutesNotCreated() ([]*dbapi.KafkaRequest, *errors.ServiceError)
 	VerifyAndUpdateKafkaAdmin(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError
+	// SuspendKafka moves a 'ready' kafka to the 'suspending' state. The kas-fleetshard operator will then scale down
+	// the Kafka instance and report it as 'suspended'. Kafkas already in 'suspending' or 'suspended' state are left unchanged.
+	SuspendKafka(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError
+	// ResumeKafka moves a 'suspending' or 'suspended' kafka to the 'resuming' state. The kafka will be set back to 'ready'
+	// once the kas-fleetshard operator reports it as ready. Kafkas already in 'resuming' state are left unchanged.
+	ResumeKafka(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError
+	// MigrateKafka schedules the migration of a 'ready' kafka to another data plane cluster. When targetClusterID is empty,
+	// the target cluster is selected by the cluster placement strategy. Otherwise, the given cluster must be a ready cluster
+	// of the same cloud provider, region and availability zones setup as the kafka and it must support the kafka instance type.
+	// Capacity is reserved on the target cluster as soon as it is known, until the migration completes or fails.
+	MigrateKafka(kafkaRequest *dbapi.KafkaRequest, targetClusterID string) *errors.ServiceError
+	// ResizeKafka changes the size of a 'ready' kafka to another size of its instance type. The quota for the new size is
+	// reserved before the kafka is changed. When the cluster the kafka is assigned to does not have the capacity to host
+	// the resized kafka, the kafka is migrated to a cluster selected by the cluster placement strategy. The storage of the
+	// kafka is never shrunk. The capacity of the ManagedKafka is updated on the next synchronization with the data plane.
+	ResizeKafka(kafkaRequest *dbapi.KafkaRequest, sizeId string) *errors.ServiceError
+	// FailoverKafka promotes the standby of the disaster recovery pair of the kafka to primary and demotes the primary to
+	// standby. The CNAME records of the bootstrap hosts served by the primary are switched to the standby, so that the
+	// clients of the pair are moved over to it without changing their bootstrap server host.
+	FailoverKafka(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError
+	// DrainCluster schedules the migration of all the 'ready' kafkas of the given data plane cluster that are not already being migrated.
+	// The returned list contains the kafkas whose migration has been scheduled.
+	DrainCluster(clusterID string) (dbapi.KafkaList, *errors.ServiceError)
+	ListByMigrationStatus(status ...constants.KafkaMigrationStatus) ([]*dbapi.KafkaRequest, *errors.ServiceError)
+	// ListKafkasWithPendingUpgrades returns the kafkas, not under deletion, that have upgrades queued until their next maintenance window
+	ListKafkasWithPendingUpgrades() ([]*dbapi.KafkaRequest, *errors.ServiceError)
 	ListComponentVersions() ([]KafkaComponentVersions, error)
 	HasAvailableCapacityInRegion(kafkaRequest *dbapi.KafkaRequest) (bool, *errors.ServiceError)
 	// GetAvailableSizesInRegion returns a list of ids of the Kafka instance sizes that can still be created according to the specified criteria
@@ -368,6 +421,12 @@ func (k *kafkaService) RegisterKafkaJob(kafkaRequest *dbapi.KafkaRequest) *error
 		kafkaRequest.MultiAZ = false
 	}
 
+	if kafkaRequest.DrRole == constants.KafkaDrRoleStandby.String() {
+		if err := k.validateDrStandby(kafkaRequest); err != nil {
+			return err
+		}
+	}
+
 	hasCapacity, err := k.HasAvailableCapacityInRegion(kafkaRequest)
 	if err != nil {
 		if err.Code == errors.ErrorGeneral {
@@ -426,7 +485,11 @@ func (k *kafkaService) RegisterKafkaJob(kafkaRequest *dbapi.KafkaRequest) *error
 	// the API is restarted this time changing the --quota-type flag to quota-management-list, when kafka A is deleted at this point,
 	// we want to use the correct quota to perform the deletion.
 	kafkaRequest.QuotaType = k.kafkaConfig.Quota.Type
-	if err := dbConn.Create(kafkaRequest).Error; err != nil {
+	if kafkaRequest.DrRole == constants.KafkaDrRoleStandby.String() {
+		if err := k.createDrStandby(kafkaRequest); err != nil {
+			return err
+		}
+	} else if err := dbConn.Create(kafkaRequest).Error; err != nil {
 		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to create kafka request") //hide the db error to http caller
 	}
 
@@ -435,6 +498,71 @@ func (k *kafkaService) RegisterKafkaJob(kafkaRequest *dbapi.KafkaRequest) *error
 	return nil
 }
 
+// validateDrStandby checks that the kafka can be created as the disaster recovery standby of its peer kafka
+func (k *kafkaService) validateDrStandby(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+	primary, err := k.GetByID(kafkaRequest.DrPeerKafkaID)
+	if err != nil {
+		return err
+	}
+
+	if primary.OrganisationId != kafkaRequest.OrganisationId || (primary.OrganisationId == "" && primary.Owner != kafkaRequest.Owner) {
+		return errors.NotFound("Kafka Request with id='%s' not found", primary.ID)
+	}
+
+	if primary.IsDrPaired() {
+		return errors.Conflict("kafka %q is already part of a disaster recovery pair", primary.ID)
+	}
+
+	if primary.Status != constants.KafkaRequestStatusReady.String() {
+		return errors.Validation("kafka %q cannot be the primary of a disaster recovery pair as its status is %q: only %q kafkas can be paired", primary.ID, primary.Status, constants.KafkaRequestStatusReady)
+	}
+
+	if primary.CloudProvider == kafkaRequest.CloudProvider && primary.Region == kafkaRequest.Region {
+		return errors.Validation("the standby of kafka %q must be in another region or cloud provider than %q %q", primary.ID, primary.CloudProvider, primary.Region)
+	}
+
+	if primary.InstanceType != kafkaRequest.InstanceType || primary.SizeId != kafkaRequest.SizeId {
+		return errors.Validation("the standby of kafka %q must have the same plan %q as its primary", primary.ID, fmt.Sprintf("%s.%s", primary.InstanceType, primary.SizeId))
+	}
+
+	return nil
+}
+
+// createDrStandby stores the disaster recovery standby and pairs its primary with it. The primary is paired in the same
+// transaction and only if it is still unpaired, so that it cannot end up with two standbys.
+func (k *kafkaService) createDrStandby(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+	err := k.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(kafkaRequest).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&dbapi.KafkaRequest{Meta: api.Meta{ID: kafkaRequest.DrPeerKafkaID}}).
+			Where("dr_peer_kafka_id = ''").
+			Where("status = ?", constants.KafkaRequestStatusReady.String()).
+			Updates(map[string]interface{}{
+				"dr_role":          constants.KafkaDrRolePrimary.String(),
+				"dr_peer_kafka_id": kafkaRequest.ID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.Conflict("kafka %q is no longer ready or has been paired with another standby", kafkaRequest.DrPeerKafkaID)
+		}
+		return nil
+	})
+
+	if err != nil {
+		if svcErr, ok := err.(*errors.ServiceError); ok {
+			return svcErr
+		}
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to create kafka request") //hide the db error to http caller
+	}
+
+	glog.Infof("paired kafka %q with disaster recovery standby %q", kafkaRequest.DrPeerKafkaID, kafkaRequest.ID)
+	return nil
+}
+
 func (k *kafkaService) PrepareKafkaRequest(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
 	kafkaRequest.Namespace = fmt.Sprintf("kafka-%s", strings.ToLower(kafkaRequest.ID))
 
@@ -568,7 +696,8 @@ func (k *kafkaService) RegisterKafkaDeprovisionJob(ctx context.Context, id strin
 
 	if auth.GetIsAdminFromContext(ctx) {
 		dbConn = dbConn.Where("id = ?", id)
-	} else if claims.IsOrgAdmin() {
+	} else if claims.IsOrgAdmin() || auth.GetIsGrantedFromContext(ctx) {
+		// a role granted on the kafka or on its organisation allows the deletion of the kafkas of the other users
 		orgId, _ := claims.GetOrgId()
 		dbConn = dbConn.Where("id = ?", id).Where("organisation_id = ?", orgId)
 	} else {
@@ -719,8 +848,27 @@ func (k *kafkaService) Delete(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceE
 		}
 	}
 
-	// soft delete the kafka request
-	if err := dbConn.Delete(kafkaRequest).Error; err != nil {
+	err := dbConn.Transaction(func(tx *gorm.DB) error {
+		// soft delete the kafka request
+		if err := tx.Delete(kafkaRequest).Error; err != nil {
+			return errors.NewWithCause(errors.ErrorGeneral, err, "unable to delete kafka request with id %s", kafkaRequest.ID)
+		}
+
+		// the peer of the kafka is left unpaired, a standby stops mirroring once its primary is gone
+		if kafkaRequest.IsDrPaired() {
+			if err := tx.Model(&dbapi.KafkaRequest{}).
+				Where("id = ?", kafkaRequest.DrPeerKafkaID).
+				Where("dr_peer_kafka_id = ?", kafkaRequest.ID).
+				Updates(map[string]interface{}{"dr_role": "", "dr_peer_kafka_id": ""}).Error; err != nil {
+				return errors.NewWithCause(errors.ErrorGeneral, err, "unable to unpair kafka request with id %s", kafkaRequest.DrPeerKafkaID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if svcErr, ok := err.(*errors.ServiceError); ok {
+			return svcErr
+		}
 		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to delete kafka request with id %s", kafkaRequest.ID)
 	}
 
@@ -750,15 +898,69 @@ func (k *kafkaService) List(ctx context.Context, listArgs *services.ListArgument
 		Size: listArgs.Size,
 	}
 
+	dbConn, err := filterKafkaRequests(ctx, dbConn, listArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(listArgs.OrderBy) == 0 {
+		// default orderBy name
+		dbConn = dbConn.Order("name")
+	}
+
+	// Set the order by arguments if any
+	for _, orderByArg := range listArgs.OrderBy {
+		dbConn = dbConn.Order(orderByArg)
+	}
+
+	// set total, limit and paging (based on https://gitlab.cee.redhat.com/service/api-guidelines#user-content-paging)
+	total := int64(pagingMeta.Total)
+	dbConn.Model(&kafkaRequestList).Count(&total)
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+	dbConn = dbConn.Offset((pagingMeta.Page - 1) * pagingMeta.Size).Limit(pagingMeta.Size)
+
+	// execute query
+	if err := dbConn.Find(&kafkaRequestList).Error; err != nil {
+		return kafkaRequestList, pagingMeta, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list kafka requests")
+	}
+
+	return kafkaRequestList, pagingMeta, nil
+}
+
+func (k *kafkaService) ListChanges(ctx context.Context, listArgs *services.ListArguments, gtVersion int64, includeDeleted bool) (dbapi.KafkaList, *errors.ServiceError) {
+	var kafkaRequestList dbapi.KafkaList
+	dbConn := k.connectionFactory.New()
+	if includeDeleted {
+		dbConn = dbConn.Unscoped()
+	}
+
+	dbConn, err := filterKafkaRequests(ctx, dbConn, listArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	dbConn = dbConn.Where("resource_version > ?", gtVersion).Order("resource_version").Limit(listArgs.Size)
+	if err := dbConn.Find(&kafkaRequestList).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list kafka request changes")
+	}
+
+	return kafkaRequestList, nil
+}
+
+// filterKafkaRequests restricts the given query to the kafka requests the ctx has access to and that match the search query of listArgs
+func filterKafkaRequests(ctx context.Context, dbConn *gorm.DB, listArgs *services.ListArguments) (*gorm.DB, *errors.ServiceError) {
 	claims, err := auth.GetClaimsFromContext(ctx)
 	if err != nil {
-		return nil, nil, errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+		return nil, errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
 	}
 
 	if !auth.GetIsAdminFromContext(ctx) {
 		user, _ := claims.GetUsername()
 		if user == "" {
-			return nil, nil, errors.Unauthenticated("user not authenticated")
+			return nil, errors.Unauthenticated("user not authenticated")
 		}
 
 		orgId, _ := claims.GetOrgId()
@@ -778,41 +980,17 @@ func (k *kafkaService) List(ctx context.Context, listArgs *services.ListArgument
 	if len(listArgs.Search) > 0 {
 		searchDbQuery, err := coreServices.NewQueryParser().Parse(listArgs.Search)
 		if err != nil {
-			return kafkaRequestList, pagingMeta, errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "unable to list kafka requests: %s", err.Error())
+			return nil, errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "unable to list kafka requests: %s", err.Error())
 		}
 		dbConn = dbConn.Where(searchDbQuery.Query, searchDbQuery.Values...)
 	}
 
-	if len(listArgs.OrderBy) == 0 {
-		// default orderBy name
-		dbConn = dbConn.Order("name")
-	}
-
-	// Set the order by arguments if any
-	for _, orderByArg := range listArgs.OrderBy {
-		dbConn = dbConn.Order(orderByArg)
-	}
-
-	// set total, limit and paging (based on https://gitlab.cee.redhat.com/service/api-guidelines#user-content-paging)
-	total := int64(pagingMeta.Total)
-	dbConn.Model(&kafkaRequestList).Count(&total)
-	pagingMeta.Total = int(total)
-	if pagingMeta.Size > pagingMeta.Total {
-		pagingMeta.Size = pagingMeta.Total
-	}
-	dbConn = dbConn.Offset((pagingMeta.Page - 1) * pagingMeta.Size).Limit(pagingMeta.Size)
-
-	// execute query
-	if err := dbConn.Find(&kafkaRequestList).Error; err != nil {
-		return kafkaRequestList, pagingMeta, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list kafka requests")
-	}
-
-	return kafkaRequestList, pagingMeta, nil
+	return dbConn, nil
 }
 
 func (k *kafkaService) GetManagedKafkaByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *errors.ServiceError) {
 	dbConn := k.connectionFactory.New().
-		Where("cluster_id = ?", clusterID).
+		Where("cluster_id = ? OR migration_source_cluster_id = ? OR migration_target_cluster_id = ?", clusterID, clusterID, clusterID).
 		Where("status IN (?)", kafkaManagedCRStatuses).
 		Where("bootstrap_server_host != ''")
 
@@ -821,19 +999,93 @@ func (k *kafkaService) GetManagedKafkaByClusterID(clusterID string) ([]managedka
 		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list kafka requests")
 	}
 
+	drPeers, svcErr := k.findDrPeers(kafkaRequestList)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
 	var res []managedkafka.ManagedKafka
 	// convert kafka requests to managed kafka
 	for _, kafkaRequest := range kafkaRequestList {
-		mk, err := buildManagedKafkaCR(kafkaRequest, k.kafkaConfig, k.keycloakService)
+		var mk *managedkafka.ManagedKafka
+		var err *errors.ServiceError
+		switch {
+		case kafkaRequest.ClusterID == clusterID:
+			mk, err = buildManagedKafkaCR(kafkaRequest, k.kafkaConfig, k.keycloakService)
+		case kafkaRequest.GetMigrationClusterID() == clusterID && arrays.Contains(kafkaMigrationManagedCRStatuses, kafkaRequest.MigrationStatus):
+			mk, err = buildMigrationManagedKafkaCR(kafkaRequest, k.kafkaConfig, k.keycloakService)
+		default:
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
+		if peer, ok := drPeers[kafkaRequest.DrPeerKafkaID]; ok {
+			// either kafka of a disaster recovery pair serves the bootstrap hosts of both once failed over
+			mk.Spec.Endpoint.AdditionalBootstrapServerHosts = []string{peer.BootstrapServerHost}
+			if kafkaRequest.DrRole == constants.KafkaDrRoleStandby.String() {
+				mk.Spec.MirrorMaker2 = buildMirrorMaker2Spec(peer, k.kafkaConfig, k.keycloakService)
+			}
+		}
 		res = append(res, *mk)
 	}
 
 	return res, nil
 }
 
+// findDrPeers returns, indexed by id, the disaster recovery peers of the kafkas in the given list
+func (k *kafkaService) findDrPeers(kafkaRequestList dbapi.KafkaList) (dbapi.KafkaIndex, *errors.ServiceError) {
+	var peerIDs []string
+	for _, kafkaRequest := range kafkaRequestList {
+		if kafkaRequest.IsDrPaired() {
+			peerIDs = append(peerIDs, kafkaRequest.DrPeerKafkaID)
+		}
+	}
+	if len(peerIDs) == 0 {
+		return dbapi.KafkaIndex{}, nil
+	}
+
+	var peers dbapi.KafkaList
+	if err := k.connectionFactory.New().
+		Where("id IN (?)", peerIDs).
+		Where("bootstrap_server_host != ''").
+		Find(&peers).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the disaster recovery peers of kafka requests")
+	}
+
+	return peers.Index(), nil
+}
+
+// buildMirrorMaker2Spec builds the MirrorMaker2 configuration replicating the given primary to its standby. MirrorMaker2
+// authenticates to the primary with its canary service account, which has access to all its topics and groups.
+func buildMirrorMaker2Spec(primary *dbapi.KafkaRequest, kafkaConfig *config.KafkaConfig, keycloakService sso.KeycloakService) *managedkafka.MirrorMaker2Spec {
+	spec := &managedkafka.MirrorMaker2Spec{
+		SourceClusterAlias:        primary.ID,
+		SourceBootstrapServerHost: primary.BootstrapServerHost,
+		Topics:                    drMirrorMaker2Topics,
+		Groups:                    drMirrorMaker2Groups,
+		SyncGroupOffsets:          true,
+	}
+
+	keycloakConfig := keycloakService.GetConfig()
+	if keycloakConfig.EnableAuthenticationOnKafka {
+		spec.SourceAuthentication = &managedkafka.MirrorMaker2AuthenticationSpec{
+			TokenEndpointURI: keycloakService.GetRealmConfig().TokenEndpointURI,
+			ClientID:         primary.CanaryServiceAccountClientID,
+			ClientSecret:     primary.CanaryServiceAccountClientSecret,
+		}
+		if keycloakConfig.TLSTrustedCertificatesValue != "" {
+			spec.SourceAuthentication.TlsTrustedCertificate = &keycloakConfig.TLSTrustedCertificatesValue
+		}
+	}
+
+	if kafkaConfig.EnableKafkaExternalCertificate {
+		spec.SourceTlsTrustedCertificate = &kafkaConfig.KafkaTLSCert
+	}
+
+	return spec
+}
+
 func (k *kafkaService) GenerateReservedManagedKafkasByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *errors.ServiceError) {
 	reservedKafkas := []managedkafka.ManagedKafka{}
 	cluster, svcErr := k.clusterService.FindClusterByID(clusterID)
@@ -880,11 +1132,11 @@ func (k *kafkaService) GenerateReservedManagedKafkasByClusterID(clusterID string
 }
 
 func (k *kafkaService) Update(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
-	dbConn := k.connectionFactory.New().
-		Model(kafkaRequest).
-		Where("status not IN (?)", kafkaDeletionStatuses) // ignore updates of kafka under deletion
-
-	if err := dbConn.Updates(kafkaRequest).Error; err != nil {
+	if _, err := k.updateAndRecordStatusChange(kafkaRequest.ID, kafkaRequest.Status, func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(kafkaRequest).
+			Where("status not IN (?)", kafkaDeletionStatuses). // ignore updates of kafka under deletion
+			Updates(kafkaRequest)
+	}); err != nil {
 		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka")
 	}
 
@@ -892,17 +1144,57 @@ func (k *kafkaService) Update(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceE
 }
 
 func (k *kafkaService) Updates(kafkaRequest *dbapi.KafkaRequest, fields map[string]interface{}) *errors.ServiceError {
-	dbConn := k.connectionFactory.New().
-		Model(kafkaRequest).
-		Where("status not IN (?)", kafkaDeletionStatuses) // ignore updates of kafka under deletion
+	status := ""
+	if value, ok := fields["status"]; ok {
+		status = fmt.Sprint(value)
+	}
 
-	if err := dbConn.Updates(fields).Error; err != nil {
+	if _, err := k.updateAndRecordStatusChange(kafkaRequest.ID, status, func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(kafkaRequest).
+			Where("status not IN (?)", kafkaDeletionStatuses). // ignore updates of kafka under deletion
+			Updates(fields)
+	}); err != nil {
 		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka")
 	}
 
 	return nil
 }
 
+// updateAndRecordStatusChange runs the update in a transaction. When the update changes the status of the kafka,
+// a status change event is recorded in the outbox in the same transaction so that it is only delivered to the
+// webhooks of the organisation if the change is committed. It returns the number of rows affected by the update.
+func (k *kafkaService) updateAndRecordStatusChange(id string, status string, update func(tx *gorm.DB) *gorm.DB) (int64, error) {
+	var rowsAffected int64
+	err := k.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		var previous dbapi.KafkaRequest
+		if status != "" {
+			if err := tx.Model(&dbapi.KafkaRequest{}).
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Select("status", "organisation_id").
+				Where("id = ?", id).
+				Scan(&previous).Error; err != nil {
+				return err
+			}
+		}
+
+		result := update(tx)
+		if result.Error != nil {
+			return result.Error
+		}
+		rowsAffected = result.RowsAffected
+
+		if status == "" || status == previous.Status || rowsAffected == 0 {
+			return nil
+		}
+		return webhooks.RecordEvent(tx, webhooks.KafkaStatusChangedEventType, kafkaEventSource, id, previous.OrganisationId, webhooks.StatusChange{
+			ID:             id,
+			Status:         status,
+			PreviousStatus: previous.Status,
+		})
+	})
+	return rowsAffected, err
+}
+
 func (k *kafkaService) VerifyAndUpdateKafkaAdmin(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
 	if !auth.GetIsAdminFromContext(ctx) {
 		return errors.New(errors.ErrorUnauthenticated, "user not authenticated")
@@ -914,6 +1206,9 @@ func (k *kafkaService) VerifyAndUpdateKafkaAdmin(ctx context.Context, kafkaReque
 		"desired_strimzi_version":   kafkaRequest.DesiredStrimziVersion,
 		"desired_kafka_version":     kafkaRequest.DesiredKafkaVersion,
 		"desired_kafka_ibp_version": kafkaRequest.DesiredKafkaIBPVersion,
+		"pending_strimzi_version":   kafkaRequest.PendingStrimziVersion,
+		"pending_kafka_version":     kafkaRequest.PendingKafkaVersion,
+		"pending_kafka_ibp_version": kafkaRequest.PendingKafkaIBPVersion,
 		"status":                    kafkaRequest.Status,
 	}
 
@@ -927,9 +1222,510 @@ func (k *kafkaService) VerifyAndUpdateKafkaAdmin(ctx context.Context, kafkaReque
 	return nil
 }
 
-func (k *kafkaService) UpdateStatus(id string, status constants.KafkaStatus) (bool, *errors.ServiceError) {
+func (k *kafkaService) SuspendKafka(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+	if arrays.Contains(constants.GetSuspendedStatuses(), kafkaRequest.Status) {
+		return nil
+	}
+
+	if kafkaRequest.Status != constants.KafkaRequestStatusReady.String() {
+		return errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be suspended. Kafka instances can only be suspended in the following states: [%q]", kafkaRequest.Status, constants.KafkaRequestStatusReady)
+	}
+
+	return k.transitionKafkaStatus(kafkaRequest, []string{constants.KafkaRequestStatusReady.String()}, constants.KafkaRequestStatusSuspending)
+}
+
+func (k *kafkaService) ResumeKafka(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+	if kafkaRequest.Status == constants.KafkaRequestStatusResuming.String() {
+		return nil
+	}
+
+	if !arrays.Contains(constants.GetSuspendedStatuses(), kafkaRequest.Status) {
+		return errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be resumed. Kafka instances can only be resumed in the following states: %q", kafkaRequest.Status, constants.GetSuspendedStatuses())
+	}
+
+	return k.transitionKafkaStatus(kafkaRequest, constants.GetSuspendedStatuses(), constants.KafkaRequestStatusResuming)
+}
+
+func (k *kafkaService) MigrateKafka(kafkaRequest *dbapi.KafkaRequest, targetClusterID string) *errors.ServiceError {
+	if kafkaRequest.Status != constants.KafkaRequestStatusReady.String() {
+		return errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be migrated. Kafka instances can only be migrated in the following states: [%q]", kafkaRequest.Status, constants.KafkaRequestStatusReady)
+	}
+
+	if kafkaRequest.IsMigrating() || arrays.Contains(constants.GetInProgressMigrationStatuses(), kafkaRequest.MigrationStatus) {
+		return errors.Conflict("kafka %q is already being migrated: migration status is %q", kafkaRequest.ID, kafkaRequest.MigrationStatus)
+	}
+
+	if targetClusterID != "" {
+		if svcErr := k.validateMigrationTargetCluster(kafkaRequest, targetClusterID); svcErr != nil {
+			return svcErr
+		}
+	}
+
+	values := map[string]interface{}{
+		"migration_status":            constants.KafkaMigrationStatusPending.String(),
+		"migration_source_cluster_id": kafkaRequest.ClusterID,
+		"migration_target_cluster_id": targetClusterID,
+		"migration_placement_id":      "",
+	}
+
+	dbConn := k.connectionFactory.New().
+		Model(&dbapi.KafkaRequest{Meta: api.Meta{ID: kafkaRequest.ID}}).
+		Where("status = ?", constants.KafkaRequestStatusReady.String()).
+		Where("migration_target_cluster_id = ''").
+		Where("migration_status NOT IN (?)", constants.GetInProgressMigrationStatuses()).
+		Updates(values)
+
+	if err := dbConn.Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to schedule the migration of kafka %q", kafkaRequest.ID)
+	}
+
+	if dbConn.RowsAffected == 0 {
+		return errors.Conflict("unable to schedule the migration of kafka %q: the kafka is no longer ready or is already being migrated", kafkaRequest.ID)
+	}
+
+	glog.Infof("scheduled the migration of kafka %q from cluster %q to cluster %q", kafkaRequest.ID, kafkaRequest.ClusterID, targetClusterID)
+	kafkaRequest.MigrationStatus = constants.KafkaMigrationStatusPending.String()
+	kafkaRequest.MigrationSourceClusterID = kafkaRequest.ClusterID
+	kafkaRequest.MigrationTargetClusterID = targetClusterID
+	kafkaRequest.MigrationPlacementId = ""
+
+	return nil
+}
+
+func (k *kafkaService) validateMigrationTargetCluster(kafkaRequest *dbapi.KafkaRequest, targetClusterID string) *errors.ServiceError {
+	if targetClusterID == kafkaRequest.ClusterID {
+		return errors.Validation("kafka %q is already assigned to cluster %q", kafkaRequest.ID, targetClusterID)
+	}
+
+	cluster, svcErr := k.clusterService.FindClusterByID(targetClusterID)
+	if svcErr != nil {
+		return svcErr
+	}
+
+	if cluster == nil {
+		return errors.NotFound("data plane cluster %q not found", targetClusterID)
+	}
+
+	if cluster.Status != api.ClusterReady {
+		return errors.Validation("data plane cluster %q is not ready: its status is %q", targetClusterID, cluster.Status)
+	}
+
+	if cluster.CloudProvider != kafkaRequest.CloudProvider || cluster.Region != kafkaRequest.Region || cluster.MultiAZ != kafkaRequest.MultiAZ {
+		return errors.Validation("data plane cluster %q is not in the cloud provider, region and availability zones setup of kafka %q", targetClusterID, kafkaRequest.ID)
+	}
+
+	if !arrays.Contains(cluster.GetSupportedInstanceTypes(), kafkaRequest.InstanceType) {
+		return errors.Validation("data plane cluster %q does not support the %q instance type", targetClusterID, kafkaRequest.InstanceType)
+	}
+
+	return nil
+}
+
+func (k *kafkaService) ResizeKafka(kafkaRequest *dbapi.KafkaRequest, sizeId string) *errors.ServiceError {
+	if kafkaRequest.Status != constants.KafkaRequestStatusReady.String() {
+		return errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be resized. Kafka instances can only be resized in the following states: [%q]", kafkaRequest.Status, constants.KafkaRequestStatusReady)
+	}
+
+	if kafkaRequest.IsMigrating() || arrays.Contains(constants.GetInProgressMigrationStatuses(), kafkaRequest.MigrationStatus) {
+		return errors.Conflict("kafka %q cannot be resized while it is being migrated: migration status is %q", kafkaRequest.ID, kafkaRequest.MigrationStatus)
+	}
+
+	if sizeId == kafkaRequest.SizeId {
+		return nil
+	}
+
+	newSize, err := k.kafkaConfig.GetKafkaInstanceSize(kafkaRequest.InstanceType, sizeId)
+	if err != nil {
+		return errors.Validation("size %q is not a supported size of the %q instance type", sizeId, kafkaRequest.InstanceType)
+	}
+
+	storageSize, svcErr := resizedKafkaStorageSize(kafkaRequest, newSize)
+	if svcErr != nil {
+		return svcErr
+	}
+
+	fitsCurrentCluster, err := k.clusterPlacementStrategy.FitsCurrentCluster(kafkaRequest, sizeId)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to check the capacity of cluster %q for kafka %q", kafkaRequest.ClusterID, kafkaRequest.ID)
+	}
+
+	targetClusterID := ""
+	if !fitsCurrentCluster {
+		resizedKafkaRequest := *kafkaRequest
+		resizedKafkaRequest.SizeId = sizeId
+		cluster, err := k.clusterPlacementStrategy.FindCluster(&resizedKafkaRequest)
+		if err != nil {
+			return errors.NewWithCause(errors.ErrorGeneral, err, "failed to find a data plane cluster for kafka %q", kafkaRequest.ID)
+		}
+		if cluster == nil {
+			return errors.TooManyKafkaInstancesReached("no data plane cluster has the capacity to host kafka %q with size %q", kafkaRequest.ID, sizeId)
+		}
+		targetClusterID = cluster.ClusterID
+	}
+
+	quotaService, factoryErr := k.quotaServiceFactory.GetQuotaService(api.QuotaType(k.kafkaConfig.Quota.Type))
+	if factoryErr != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, factoryErr, "unable to check quota")
+	}
+
+	// the quota is reserved first so that the kafka is left unchanged when it is refused
+	subscriptionId, svcErr := quotaService.ResizeQuota(kafkaRequest, sizeId)
+	if svcErr != nil {
+		return svcErr
+	}
+
+	values := map[string]interface{}{
+		"size_id":            sizeId,
+		"kafka_storage_size": storageSize,
+	}
+	if subscriptionId != "" {
+		values["subscription_id"] = subscriptionId
+	}
+	if targetClusterID != "" {
+		values["migration_status"] = constants.KafkaMigrationStatusPending.String()
+		values["migration_source_cluster_id"] = kafkaRequest.ClusterID
+		values["migration_target_cluster_id"] = targetClusterID
+		values["migration_placement_id"] = ""
+	}
+
+	dbConn := k.connectionFactory.New().
+		Model(&dbapi.KafkaRequest{Meta: api.Meta{ID: kafkaRequest.ID}}).
+		Where("status = ?", constants.KafkaRequestStatusReady.String()).
+		Where("size_id = ?", kafkaRequest.SizeId).
+		Where("migration_target_cluster_id = ''").
+		Where("migration_status NOT IN (?)", constants.GetInProgressMigrationStatuses()).
+		Updates(values)
+
+	if dbConn.Error != nil || dbConn.RowsAffected == 0 {
+		k.rollbackQuotaResize(quotaService, kafkaRequest, sizeId, subscriptionId)
+		if dbConn.Error != nil {
+			return errors.NewWithCause(errors.ErrorGeneral, dbConn.Error, "failed to resize kafka %q", kafkaRequest.ID)
+		}
+		return errors.Conflict("unable to resize kafka %q: the kafka is no longer ready, has been resized or is being migrated", kafkaRequest.ID)
+	}
+
+	glog.Infof("resized kafka %q from size %q to size %q", kafkaRequest.ID, kafkaRequest.SizeId, sizeId)
+	kafkaRequest.SizeId = sizeId
+	kafkaRequest.KafkaStorageSize = storageSize
+	if subscriptionId != "" {
+		kafkaRequest.SubscriptionId = subscriptionId
+	}
+	if targetClusterID != "" {
+		glog.Infof("scheduled the migration of kafka %q from cluster %q to cluster %q as the resized kafka does not fit its cluster", kafkaRequest.ID, kafkaRequest.ClusterID, targetClusterID)
+		kafkaRequest.MigrationStatus = constants.KafkaMigrationStatusPending.String()
+		kafkaRequest.MigrationSourceClusterID = kafkaRequest.ClusterID
+		kafkaRequest.MigrationTargetClusterID = targetClusterID
+		kafkaRequest.MigrationPlacementId = ""
+	}
+
+	return nil
+}
+
+// rollbackQuotaResize gives the quota reserved for the new size of a kafka back when the kafka could not be resized
+func (k *kafkaService) rollbackQuotaResize(quotaService QuotaService, kafkaRequest *dbapi.KafkaRequest, sizeId string, subscriptionId string) {
+	resizedKafkaRequest := *kafkaRequest
+	resizedKafkaRequest.SizeId = sizeId
+	if subscriptionId != "" {
+		resizedKafkaRequest.SubscriptionId = subscriptionId
+	}
+	if _, err := quotaService.ResizeQuota(&resizedKafkaRequest, kafkaRequest.SizeId); err != nil {
+		logger.Logger.Errorf("failed to roll back the quota reserved to resize kafka %q to size %q: %v", kafkaRequest.ID, sizeId, err)
+	}
+}
+
+// resizedKafkaStorageSize returns the storage size of the kafka once resized. Storage volumes cannot be shrunk, so the
+// current storage size is kept when it is bigger than the maximum data retention size of the new size.
+func resizedKafkaStorageSize(kafkaRequest *dbapi.KafkaRequest, newSize *config.KafkaInstanceSize) (string, *errors.ServiceError) {
+	newStorageSize, err := newSize.MaxDataRetentionSize.ToK8Quantity()
+	if err != nil {
+		return "", errors.NewWithCause(errors.ErrorGeneral, err, "failed to parse the maximum data retention size of size %q", newSize.Id)
+	}
+
+	if kafkaRequest.KafkaStorageSize == "" {
+		return newSize.MaxDataRetentionSize.String(), nil
+	}
+
+	currentStorageSize, err := resource.ParseQuantity(kafkaRequest.KafkaStorageSize)
+	if err != nil {
+		return "", errors.NewWithCause(errors.ErrorGeneral, err, "failed to parse the storage size %q of kafka %q", kafkaRequest.KafkaStorageSize, kafkaRequest.ID)
+	}
+
+	if currentStorageSize.Cmp(*newStorageSize) > 0 {
+		return kafkaRequest.KafkaStorageSize, nil
+	}
+	return newSize.MaxDataRetentionSize.String(), nil
+}
+
+func (k *kafkaService) FailoverKafka(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+	if !kafkaRequest.IsDrPaired() {
+		return errors.Validation("kafka %q is not part of a disaster recovery pair", kafkaRequest.ID)
+	}
+
+	if !k.kafkaConfig.EnableKafkaCNAMERegistration {
+		return errors.Validation("kafka %q cannot be failed over as the CNAME registration of kafka instances is disabled", kafkaRequest.ID)
+	}
+
+	peer, svcErr := k.GetByID(kafkaRequest.DrPeerKafkaID)
+	if svcErr != nil {
+		return svcErr
+	}
+
+	primary, standby := kafkaRequest, peer
+	if kafkaRequest.DrRole == constants.KafkaDrRoleStandby.String() {
+		primary, standby = peer, kafkaRequest
+	}
+
+	if standby.Status != constants.KafkaRequestStatusReady.String() {
+		return errors.Validation("kafka %q cannot be promoted to primary as its status is %q: only %q standbys can be promoted", standby.ID, standby.Status, constants.KafkaRequestStatusReady)
+	}
+
+	if svcErr := k.validateDrCertificate(primary); svcErr != nil {
+		return svcErr
+	}
+
+	primaryRoutes, standbyRoutes, svcErr := switchDrBootstrapRoutes(primary, standby)
+	if svcErr != nil {
+		return svcErr
+	}
+
+	err := k.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&dbapi.KafkaRequest{Meta: api.Meta{ID: primary.ID}}).
+			Where("dr_role = ?", constants.KafkaDrRolePrimary.String()).
+			Where("dr_peer_kafka_id = ?", standby.ID).
+			Updates(map[string]interface{}{
+				"dr_role": constants.KafkaDrRoleStandby.String(),
+				"routes":  primaryRoutes,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.Conflict("kafka %q is no longer the primary of the disaster recovery pair", primary.ID)
+		}
+
+		// the CNAME records of the standby are upserted by the kafka routes CNAME manager
+		result = tx.Model(&dbapi.KafkaRequest{Meta: api.Meta{ID: standby.ID}}).
+			Where("dr_role = ?", constants.KafkaDrRoleStandby.String()).
+			Where("dr_peer_kafka_id = ?", primary.ID).
+			Updates(map[string]interface{}{
+				"dr_role":            constants.KafkaDrRolePrimary.String(),
+				"routes":             standbyRoutes,
+				"routes_created":     false,
+				"routes_creation_id": "",
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.Conflict("kafka %q is no longer the standby of the disaster recovery pair", standby.ID)
+		}
+		return nil
+	})
+
+	if err != nil {
+		if svcErr, ok := err.(*errors.ServiceError); ok {
+			return svcErr
+		}
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to fail over kafka %q", kafkaRequest.ID)
+	}
+
+	glog.Infof("failed over disaster recovery pair from primary %q to standby %q", primary.ID, standby.ID)
+	primary.DrRole = constants.KafkaDrRoleStandby.String()
+	primary.Routes = primaryRoutes
+	standby.DrRole = constants.KafkaDrRolePrimary.String()
+	standby.Routes = standbyRoutes
+	standby.RoutesCreated = false
+	standby.RoutesCreationId = ""
+
+	return nil
+}
+
+// validateDrCertificate checks that the external certificate of the kafkas is valid for the bootstrap host of the primary,
+// the standby serving it with that certificate once failed over. Without external certificate, the certificate of the
+// standby is issued by the data plane for its additional bootstrap hosts, which include the one of its primary.
+func (k *kafkaService) validateDrCertificate(primary *dbapi.KafkaRequest) *errors.ServiceError {
+	if !k.kafkaConfig.EnableKafkaExternalCertificate {
+		return nil
+	}
+	block, _ := pem.Decode([]byte(k.kafkaConfig.KafkaTLSCert))
+	if block == nil {
+		return errors.GeneralError("failed to decode the kafka TLS certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to parse the kafka TLS certificate")
+	}
+	if err := cert.VerifyHostname(primary.BootstrapServerHost); err != nil {
+		return errors.Validation("kafka %q cannot be failed over as the kafka TLS certificate is not valid for its bootstrap host %q", primary.ID, primary.BootstrapServerHost)
+	}
+	return nil
+}
+
+// switchDrBootstrapRoutes moves the routes of the bootstrap hosts of the pair served by the primary to the standby, pointing
+// them to the router of the bootstrap host of the standby. It returns the routes of the primary and of the standby once
+// switched.
+func switchDrBootstrapRoutes(primary *dbapi.KafkaRequest, standby *dbapi.KafkaRequest) (api.JSON, api.JSON, *errors.ServiceError) {
+	primaryRoutes, err := primary.GetRoutes()
+	if err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to get routes of kafka %q", primary.ID)
+	}
+	standbyRoutes, err := standby.GetRoutes()
+	if err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to get routes of kafka %q", standby.ID)
+	}
+
+	standbyRouter := ""
+	for _, route := range standbyRoutes {
+		if route.Domain == standby.BootstrapServerHost {
+			standbyRouter = route.Router
+		}
+	}
+	if standbyRouter == "" {
+		return nil, nil, errors.Validation("kafka %q cannot be promoted to primary as the route of its bootstrap host is not available", standby.ID)
+	}
+
+	bootstrapHosts := []string{primary.BootstrapServerHost, standby.BootstrapServerHost}
+	var remainingPrimaryRoutes, movedRoutes []dbapi.DataPlaneKafkaRoute
+	for _, route := range primaryRoutes {
+		if arrays.Contains(bootstrapHosts, route.Domain) {
+			movedRoutes = append(movedRoutes, dbapi.DataPlaneKafkaRoute{Domain: route.Domain, Router: standbyRouter})
+		} else {
+			remainingPrimaryRoutes = append(remainingPrimaryRoutes, route)
+		}
+	}
+	// a primary whose routes were never created still has its bootstrap host switched to the standby
+	if len(movedRoutes) == 0 {
+		movedRoutes = append(movedRoutes, dbapi.DataPlaneKafkaRoute{Domain: primary.BootstrapServerHost, Router: standbyRouter})
+	}
+
+	switchedStandbyRoutes := movedRoutes
+	for _, route := range standbyRoutes {
+		if !arrays.AnyMatch(movedRoutes, func(movedRoute dbapi.DataPlaneKafkaRoute) bool { return movedRoute.Domain == route.Domain }) {
+			switchedStandbyRoutes = append(switchedStandbyRoutes, route)
+		}
+	}
+
+	switchedPrimary := dbapi.KafkaRequest{}
+	if err := switchedPrimary.SetRoutes(remainingPrimaryRoutes); err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to set routes of kafka %q", primary.ID)
+	}
+	switchedStandby := dbapi.KafkaRequest{}
+	if err := switchedStandby.SetRoutes(switchedStandbyRoutes); err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to set routes of kafka %q", standby.ID)
+	}
+
+	return switchedPrimary.Routes, switchedStandby.Routes, nil
+}
+
+func (k *kafkaService) DrainCluster(clusterID string) (dbapi.KafkaList, *errors.ServiceError) {
+	cluster, svcErr := k.clusterService.FindClusterByID(clusterID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if cluster == nil {
+		return nil, errors.NotFound("data plane cluster %q not found", clusterID)
+	}
+
+	var kafkas dbapi.KafkaList
+	dbConn := k.connectionFactory.New()
+	if err := dbConn.
+		Where("cluster_id = ?", clusterID).
+		Where("status = ?", constants.KafkaRequestStatusReady.String()).
+		Where("migration_target_cluster_id = ''").
+		Where("migration_status NOT IN (?)", constants.GetInProgressMigrationStatuses()).
+		Find(&kafkas).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list the kafkas of cluster %q", clusterID)
+	}
+
+	if len(kafkas) == 0 {
+		return kafkas, nil
+	}
+
+	kafkaIDs := make([]string, 0, len(kafkas))
+	for _, kafka := range kafkas {
+		kafkaIDs = append(kafkaIDs, kafka.ID)
+	}
+
+	// the status conditions are repeated so that kafkas changed since they were listed are not migrated
+	if err := dbConn.Model(&dbapi.KafkaRequest{}).
+		Where("id IN (?)", kafkaIDs).
+		Where("status = ?", constants.KafkaRequestStatusReady.String()).
+		Where("migration_target_cluster_id = ''").
+		Where("migration_status NOT IN (?)", constants.GetInProgressMigrationStatuses()).
+		Updates(map[string]interface{}{
+			"migration_status":            constants.KafkaMigrationStatusPending.String(),
+			"migration_source_cluster_id": clusterID,
+			"migration_placement_id":      "",
+		}).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to schedule the migration of the kafkas of cluster %q", clusterID)
+	}
+
+	for _, kafka := range kafkas {
+		kafka.MigrationStatus = constants.KafkaMigrationStatusPending.String()
+		kafka.MigrationSourceClusterID = clusterID
+		kafka.MigrationPlacementId = ""
+	}
+
+	glog.Infof("scheduled the migration of %d kafkas from cluster %q", len(kafkas), clusterID)
+
+	return kafkas, nil
+}
+
+func (k *kafkaService) ListByMigrationStatus(status ...constants.KafkaMigrationStatus) ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+	if len(status) == 0 {
+		return nil, errors.GeneralError("no status provided")
+	}
 	dbConn := k.connectionFactory.New()
 
+	var kafkas []*dbapi.KafkaRequest
+
+	if err := dbConn.Model(&dbapi.KafkaRequest{}).Where("migration_status IN (?)", status).Scan(&kafkas).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list kafka requests by migration status")
+	}
+
+	return kafkas, nil
+}
+
+func (k *kafkaService) ListKafkasWithPendingUpgrades() ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+
+	var kafkas []*dbapi.KafkaRequest
+
+	if err := dbConn.Model(&dbapi.KafkaRequest{}).
+		Where("pending_kafka_version != '' OR pending_strimzi_version != '' OR pending_kafka_ibp_version != ''").
+		Where("status NOT IN (?)", kafkaDeletionStatuses).
+		Scan(&kafkas).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list kafka requests with pending upgrades")
+	}
+
+	return kafkas, nil
+}
+
+// transitionKafkaStatus sets the status of the kafka to the given status only if its status in the database is still one of the 'from' statuses.
+// This guards against overriding a status that has been changed concurrently, e.g. by a kas-fleetshard status update.
+func (k *kafkaService) transitionKafkaStatus(kafkaRequest *dbapi.KafkaRequest, from []string, to constants.KafkaStatus) *errors.ServiceError {
+	rowsAffected, err := k.updateAndRecordStatusChange(kafkaRequest.ID, to.String(), func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&dbapi.KafkaRequest{Meta: api.Meta{ID: kafkaRequest.ID}}).
+			Where("status IN (?)", from).
+			Update("status", to.String())
+	})
+
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update status of kafka %q to %q", kafkaRequest.ID, to)
+	}
+
+	if rowsAffected == 0 {
+		return errors.Conflict("unable to update status of kafka %q to %q: the kafka is no longer in any of the following states: %q", kafkaRequest.ID, to, from)
+	}
+
+	glog.Infof("updated status of kafka %q from %q to %q", kafkaRequest.ID, kafkaRequest.Status, to)
+	kafkaRequest.Status = to.String()
+
+	return nil
+}
+
+func (k *kafkaService) UpdateStatus(id string, status constants.KafkaStatus) (bool, *errors.ServiceError) {
 	if kafka, err := k.GetByID(id); err != nil {
 		return true, errors.NewWithCause(errors.ErrorGeneral, err, "failed to update status")
 	} else {
@@ -944,7 +1740,9 @@ func (k *kafkaService) UpdateStatus(id string, status constants.KafkaStatus) (bo
 		}
 	}
 
-	if err := dbConn.Model(&dbapi.KafkaRequest{Meta: api.Meta{ID: id}}).Update("status", status).Error; err != nil {
+	if _, err := k.updateAndRecordStatusChange(id, status.String(), func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&dbapi.KafkaRequest{Meta: api.Meta{ID: id}}).Update("status", status)
+	}); err != nil {
 		return true, errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka status")
 	}
 
@@ -1064,12 +1862,32 @@ func (k *kafkaService) ListComponentVersions() ([]KafkaComponentVersions, error)
 func (k *kafkaService) ListKafkasWithRoutesNotCreated() ([]*dbapi.KafkaRequest, *errors.ServiceError) {
 	dbConn := k.connectionFactory.New()
 	var results []*dbapi.KafkaRequest
-	if err := dbConn.Where("routes IS NOT NULL").Where("routes_created = ?", "no").Find(&results).Error; err != nil {
+	// the routes of kafkas being migrated are switched over by the migration itself
+	if err := dbConn.Where("routes IS NOT NULL").Where("routes_created = ?", "no").Where("migration_target_cluster_id = ''").Find(&results).Error; err != nil {
 		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list kafka requests")
 	}
 	return results, nil
 }
 
+// buildMigrationManagedKafkaCR builds the ManagedKafka CR of a kafka being migrated, for the migration cluster the kafka
+// is not assigned to. The CR is marked as deleted once the kafka has been switched over to the target cluster, or when the
+// migration failed.
+func buildMigrationManagedKafkaCR(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.KafkaConfig, keycloakService sso.KeycloakService) (*managedkafka.ManagedKafka, *errors.ServiceError) {
+	migrationKafkaRequest := *kafkaRequest
+	migrationKafkaRequest.PlacementId = kafkaRequest.MigrationPlacementId
+
+	managedKafkaCR, err := buildManagedKafkaCR(&migrationKafkaRequest, kafkaConfig, keycloakService)
+	if err != nil {
+		return nil, err
+	}
+
+	if kafkaRequest.MigrationStatus == constants.KafkaMigrationStatusDeprovisioningSource.String() || kafkaRequest.MigrationStatus == constants.KafkaMigrationStatusFailed.String() {
+		managedKafkaCR.Spec.Deleted = true
+	}
+
+	return managedKafkaCR, nil
+}
+
 func buildManagedKafkaCR(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.KafkaConfig, keycloakService sso.KeycloakService) (*managedkafka.ManagedKafka, *errors.ServiceError) {
 	k, err := kafkaConfig.GetKafkaInstanceSize(kafkaRequest.InstanceType, kafkaRequest.SizeId)
 	if err != nil {
@@ -2,8 +2,15 @@ package services
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"net/http"
 	"strconv"
 	"strings"
@@ -501,6 +508,8 @@ func Test_kafkaService_PrepareKafkaRequest(t *testing.T) {
 			},
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery(`SELECT "status","organisation_id" FROM "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "outbox_events"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 			wantErr: false,
@@ -566,6 +575,8 @@ func Test_kafkaService_PrepareKafkaRequest(t *testing.T) {
 			},
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery(`SELECT "status","organisation_id" FROM "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "outbox_events"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 			wantErr:                 false,
@@ -2037,6 +2048,120 @@ func Test_kafkaService_List(t *testing.T) {
 	}
 }
 
+func Test_kafkaService_ListChanges(t *testing.T) {
+	type args struct {
+		ctx            context.Context
+		gtVersion      int64
+		includeDeleted bool
+	}
+
+	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+	if err != nil {
+		t.Fatalf("failed to create auth helper: %s", err.Error())
+	}
+	account, err := authHelper.NewAccount(testUser, "", "", "")
+	if err != nil {
+		t.Fatal("failed to build a new account")
+	}
+
+	jwt, err := authHelper.CreateJWTWithClaims(account, nil)
+	if err != nil {
+		t.Fatalf("failed to create jwt: %s", err.Error())
+	}
+	authenticatedCtx := auth.SetTokenInContext(context.TODO(), jwt)
+
+	kafkaList := dbapi.KafkaList{
+		buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ResourceVersion = 6
+		}),
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    dbapi.KafkaList
+		wantErr bool
+		setupFn func()
+	}{
+		{
+			name: "fails if the user is not authenticated",
+			args: args{
+				ctx: context.TODO(),
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset()
+			},
+		},
+		{
+			name: "lists the kafka requests of the user changed after the given version",
+			args: args{
+				ctx:       authenticatedCtx,
+				gtVersion: 5,
+			},
+			want: kafkaList,
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE owner = $1 AND resource_version > $2 AND "kafka_requests"."deleted_at" IS NULL ORDER BY resource_version LIMIT 100`).
+					WithArgs(testUser, int64(5)).
+					WithReply(converters.ConvertKafkaRequestList(kafkaList))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "includes the deleted kafka requests when requested",
+			args: args{
+				ctx:            authenticatedCtx,
+				gtVersion:      5,
+				includeDeleted: true,
+			},
+			want: kafkaList,
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE owner = $1 AND resource_version > $2 ORDER BY resource_version LIMIT 100`).
+					WithArgs(testUser, int64(5)).
+					WithReply(converters.ConvertKafkaRequestList(kafkaList))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "fails if the database query fails",
+			args: args{
+				ctx: authenticatedCtx,
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("SELECT").WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+
+			result, err := k.ListChanges(tt.args.ctx, &services.ListArguments{Page: 1, Size: 100}, tt.args.gtVersion, tt.args.includeDeleted)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(result).To(gomega.HaveLen(len(tt.want)))
+			for i, got := range result {
+				g.Expect(got.ID).To(gomega.Equal(tt.want[i].ID))
+				g.Expect(got.ResourceVersion).To(gomega.Equal(tt.want[i].ResourceVersion))
+			}
+		})
+	}
+}
+
 func Test_kafkaService_ListAll(t *testing.T) {
 	type fields struct {
 		connectionFactory *db.ConnectionFactory
@@ -2261,6 +2386,10 @@ func Test_kafkaService_UpdateStatus(t *testing.T) {
 					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
 						kafkaRequest.Status = constants.KafkaRequestStatusDeprovision.String()
 					})))
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT "status","organisation_id" FROM "kafka_requests"`).
+					WithReply([]map[string]interface{}{{"status": constants.KafkaRequestStatusDeprovision.String(), "organisation_id": "org-id"}})
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "outbox_events"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 			args: args{
@@ -3228,6 +3357,89 @@ func Test_kafkaService_GetManagedKafkaByClusterID(t *testing.T) {
 			},
 		})
 
+	migratingKafkaRequestList := dbapi.KafkaList{
+		&dbapi.KafkaRequest{
+			ClusterID:                "source-cluster",
+			InstanceType:             "developer",
+			SizeId:                   "x1",
+			PlacementId:              "source-placement",
+			MigrationStatus:          constants.KafkaMigrationStatusProvisioning.String(),
+			MigrationSourceClusterID: "source-cluster",
+			MigrationTargetClusterID: testClusterID,
+			MigrationPlacementId:     "target-placement",
+		},
+		&dbapi.KafkaRequest{
+			ClusterID:                "source-cluster",
+			InstanceType:             "developer",
+			SizeId:                   "x1",
+			MigrationStatus:          constants.KafkaMigrationStatusPending.String(),
+			MigrationSourceClusterID: "source-cluster",
+			MigrationTargetClusterID: testClusterID,
+		},
+		&dbapi.KafkaRequest{
+			ClusterID:                "target-cluster",
+			InstanceType:             "developer",
+			SizeId:                   "x1",
+			PlacementId:              "target-placement",
+			MigrationStatus:          constants.KafkaMigrationStatusDeprovisioningSource.String(),
+			MigrationSourceClusterID: testClusterID,
+			MigrationTargetClusterID: "target-cluster",
+			MigrationPlacementId:     "source-placement",
+		},
+	}
+	buildMigrationCR := func(placementID string, deleted bool) *managedkafka.ManagedKafka {
+		cr, _ := buildManagedKafkaCR(
+			&dbapi.KafkaRequest{
+				InstanceType: "developer",
+				SizeId:       "x1",
+				PlacementId:  placementID,
+			},
+			&config.KafkaConfig{
+				EnableKafkaExternalCertificate: true,
+				EnableKafkaCNAMERegistration:   true,
+				SupportedInstanceTypes:         &kafkaSupportedInstanceTypesConfig,
+			},
+			&sso.KeycloakServiceMock{
+				GetConfigFunc: func() *keycloak.KeycloakConfig {
+					return &keycloak.KeycloakConfig{
+						EnableAuthenticationOnKafka: true,
+					}
+				},
+				GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+					return &keycloak.KeycloakRealmConfig{}
+				},
+			})
+		cr.Spec.Deleted = deleted
+		return cr
+	}
+	migrationTargetCR := buildMigrationCR("target-placement", false)
+	migrationSourceCR := buildMigrationCR("source-placement", true)
+
+	drStandbyKafkaRequestList := dbapi.KafkaList{
+		&dbapi.KafkaRequest{
+			ClusterID:     testClusterID,
+			InstanceType:  "developer",
+			SizeId:        "x1",
+			DrRole:        constants.KafkaDrRoleStandby.String(),
+			DrPeerKafkaID: "primary-id",
+		},
+	}
+	drStandbyCR := buildMigrationCR("", false)
+	drStandbyCR.Spec.Endpoint.AdditionalBootstrapServerHosts = []string{"primary-bootstrap"}
+	drSourceTrustedCertificate := ""
+	drStandbyCR.Spec.MirrorMaker2 = &managedkafka.MirrorMaker2Spec{
+		SourceClusterAlias:        "primary-id",
+		SourceBootstrapServerHost: "primary-bootstrap",
+		Topics:                    ".*",
+		Groups:                    ".*",
+		SyncGroupOffsets:          true,
+		SourceAuthentication: &managedkafka.MirrorMaker2AuthenticationSpec{
+			ClientID:     "canary-primary-id",
+			ClientSecret: "canary-secret",
+		},
+		SourceTlsTrustedCertificate: &drSourceTrustedCertificate,
+	}
+
 	tests := []struct {
 		name    string
 		fields  fields
@@ -3269,6 +3481,83 @@ func Test_kafkaService_GetManagedKafkaByClusterID(t *testing.T) {
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
+		{
+			name: "should return the managed kafkas of the kafkas being migrated to or from the cluster",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				keycloakService: &sso.KeycloakServiceMock{
+					GetConfigFunc: func() *keycloak.KeycloakConfig {
+						return &keycloak.KeycloakConfig{
+							EnableAuthenticationOnKafka: true,
+						}
+					},
+					GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+						return &keycloak.KeycloakRealmConfig{}
+					},
+				},
+				kafkaConfig: &config.KafkaConfig{
+					EnableKafkaExternalCertificate: true,
+					EnableKafkaCNAMERegistration:   true,
+					SupportedInstanceTypes:         &kafkaSupportedInstanceTypesConfig,
+				},
+			},
+			args: args{
+				clusterID: testClusterID,
+			},
+			wantErr: nil,
+			want: []managedkafka.ManagedKafka{
+				*migrationTargetCR,
+				*migrationSourceCR,
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				query := fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)
+				response := converters.ConvertKafkaRequestList(migratingKafkaRequestList)
+				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "should return the managed kafka of a disaster recovery standby with the mirroring of its primary",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				keycloakService: &sso.KeycloakServiceMock{
+					GetConfigFunc: func() *keycloak.KeycloakConfig {
+						return &keycloak.KeycloakConfig{
+							EnableAuthenticationOnKafka: true,
+						}
+					},
+					GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+						return &keycloak.KeycloakRealmConfig{}
+					},
+				},
+				kafkaConfig: &config.KafkaConfig{
+					EnableKafkaExternalCertificate: true,
+					EnableKafkaCNAMERegistration:   true,
+					SupportedInstanceTypes:         &kafkaSupportedInstanceTypesConfig,
+				},
+			},
+			args: args{
+				clusterID: testClusterID,
+			},
+			wantErr: nil,
+			want:    []managedkafka.ManagedKafka{*drStandbyCR},
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				primaryQuery := fmt.Sprintf(`SELECT * FROM "%s" WHERE id IN`, kafkaRequestTableName)
+				primaryResponse := converters.ConvertKafkaRequest(&dbapi.KafkaRequest{
+					Meta:                api.Meta{ID: "primary-id"},
+					BootstrapServerHost: "primary-bootstrap",
+				})
+				primaryResponse[0]["canary_service_account_client_id"] = "canary-primary-id"
+				primaryResponse[0]["canary_service_account_client_secret"] = "canary-secret"
+				mocket.Catcher.NewMock().WithQuery(primaryQuery).WithReply(primaryResponse)
+				query := fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)
+				response := converters.ConvertKafkaRequestList(drStandbyKafkaRequestList)
+				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
 	}
 
 	for _, testcase := range tests {
@@ -4185,3 +4474,866 @@ func Test_kafkaService_getRoute53RegionFromKafkaRequest(t *testing.T) {
 		})
 	}
 }
+
+func Test_kafkaService_SuspendKafka(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     constants.KafkaStatus
+		setupFn    func()
+		wantErr    *errors.ServiceError
+		wantStatus constants.KafkaStatus
+	}{
+		{
+			name:       "should not update a kafka that is already suspended",
+			status:     constants.KafkaRequestStatusSuspended,
+			setupFn:    func() { mocket.Catcher.Reset().NewMock().WithExecException() },
+			wantStatus: constants.KafkaRequestStatusSuspended,
+		},
+		{
+			name:       "should return a validation error if the kafka is not ready",
+			status:     constants.KafkaRequestStatusProvisioning,
+			setupFn:    func() { mocket.Catcher.Reset().NewMock().WithExecException() },
+			wantErr:    errors.Validation(""),
+			wantStatus: constants.KafkaRequestStatusProvisioning,
+		},
+		{
+			name:   "should return a conflict error if the kafka is no longer ready in the database",
+			status: constants.KafkaRequestStatusReady,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "status"=$1`).WithRowsNum(0)
+			},
+			wantErr:    errors.Conflict(""),
+			wantStatus: constants.KafkaRequestStatusReady,
+		},
+		{
+			name:   "should return a general error if the database update fails",
+			status: constants.KafkaRequestStatusReady,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "status"=$1`).WithExecException()
+			},
+			wantErr:    errors.GeneralError(""),
+			wantStatus: constants.KafkaRequestStatusReady,
+		},
+		{
+			name:   "should move a ready kafka to suspending",
+			status: constants.KafkaRequestStatusReady,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "status"=$1`).WithRowsNum(1)
+			},
+			wantStatus: constants.KafkaRequestStatusSuspending,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			kafka := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Status = tt.status.String()
+			})
+			err := k.SuspendKafka(kafka)
+			if tt.wantErr != nil {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+			} else {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+			g.Expect(kafka.Status).To(gomega.Equal(tt.wantStatus.String()))
+		})
+	}
+}
+
+func Test_kafkaService_ResumeKafka(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     constants.KafkaStatus
+		setupFn    func()
+		wantErr    *errors.ServiceError
+		wantStatus constants.KafkaStatus
+	}{
+		{
+			name:       "should not update a kafka that is already resuming",
+			status:     constants.KafkaRequestStatusResuming,
+			setupFn:    func() { mocket.Catcher.Reset().NewMock().WithExecException() },
+			wantStatus: constants.KafkaRequestStatusResuming,
+		},
+		{
+			name:       "should return a validation error if the kafka is not suspended",
+			status:     constants.KafkaRequestStatusReady,
+			setupFn:    func() { mocket.Catcher.Reset().NewMock().WithExecException() },
+			wantErr:    errors.Validation(""),
+			wantStatus: constants.KafkaRequestStatusReady,
+		},
+		{
+			name:   "should return a conflict error if the kafka is no longer suspended in the database",
+			status: constants.KafkaRequestStatusSuspended,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "status"=$1`).WithRowsNum(0)
+			},
+			wantErr:    errors.Conflict(""),
+			wantStatus: constants.KafkaRequestStatusSuspended,
+		},
+		{
+			name:   "should move a suspending kafka to resuming",
+			status: constants.KafkaRequestStatusSuspending,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "status"=$1`).WithRowsNum(1)
+			},
+			wantStatus: constants.KafkaRequestStatusResuming,
+		},
+		{
+			name:   "should move a suspended kafka to resuming",
+			status: constants.KafkaRequestStatusSuspended,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "status"=$1`).WithRowsNum(1)
+			},
+			wantStatus: constants.KafkaRequestStatusResuming,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			kafka := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Status = tt.status.String()
+			})
+			err := k.ResumeKafka(kafka)
+			if tt.wantErr != nil {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+			} else {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+			g.Expect(kafka.Status).To(gomega.Equal(tt.wantStatus.String()))
+		})
+	}
+}
+
+func Test_kafkaService_MigrateKafka(t *testing.T) {
+	readyTargetCluster := &api.Cluster{
+		ClusterID:             "target-cluster",
+		Status:                api.ClusterReady,
+		CloudProvider:         "aws",
+		Region:                "us-east-1",
+		MultiAZ:               true,
+		SupportedInstanceType: "developer,standard",
+	}
+
+	tests := []struct {
+		name                string
+		status              constants.KafkaStatus
+		migrationStatus     constants.KafkaMigrationStatus
+		targetClusterID     string
+		targetCluster       *api.Cluster
+		setupFn             func()
+		wantErr             *errors.ServiceError
+		wantMigrationStatus string
+	}{
+		{
+			name:    "should return a validation error if the kafka is not ready",
+			status:  constants.KafkaRequestStatusSuspended,
+			setupFn: func() { mocket.Catcher.Reset().NewMock().WithExecException() },
+			wantErr: errors.Validation(""),
+		},
+		{
+			name:                "should return a conflict error if the kafka is already being migrated",
+			status:              constants.KafkaRequestStatusReady,
+			migrationStatus:     constants.KafkaMigrationStatusProvisioning,
+			setupFn:             func() { mocket.Catcher.Reset().NewMock().WithExecException() },
+			wantErr:             errors.Conflict(""),
+			wantMigrationStatus: constants.KafkaMigrationStatusProvisioning.String(),
+		},
+		{
+			name:            "should return a not found error if the target cluster does not exist",
+			status:          constants.KafkaRequestStatusReady,
+			targetClusterID: "target-cluster",
+			setupFn:         func() { mocket.Catcher.Reset().NewMock().WithExecException() },
+			wantErr:         errors.NotFound(""),
+		},
+		{
+			name:            "should return a validation error if the target cluster is the current cluster of the kafka",
+			status:          constants.KafkaRequestStatusReady,
+			targetClusterID: testClusterID,
+			setupFn:         func() { mocket.Catcher.Reset().NewMock().WithExecException() },
+			wantErr:         errors.Validation(""),
+		},
+		{
+			name:            "should return a validation error if the target cluster is in another region",
+			status:          constants.KafkaRequestStatusReady,
+			targetClusterID: "target-cluster",
+			targetCluster: &api.Cluster{
+				ClusterID:             "target-cluster",
+				Status:                api.ClusterReady,
+				CloudProvider:         "aws",
+				Region:                "eu-west-1",
+				MultiAZ:               true,
+				SupportedInstanceType: "developer,standard",
+			},
+			setupFn: func() { mocket.Catcher.Reset().NewMock().WithExecException() },
+			wantErr: errors.Validation(""),
+		},
+		{
+			name:   "should return a conflict error if the kafka changed in the database",
+			status: constants.KafkaRequestStatusReady,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "migration_placement_id"=$1`).WithRowsNum(0)
+			},
+			wantErr: errors.Conflict(""),
+		},
+		{
+			name:            "should schedule the migration of a ready kafka to the requested target cluster",
+			status:          constants.KafkaRequestStatusReady,
+			targetClusterID: "target-cluster",
+			targetCluster:   readyTargetCluster,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "migration_placement_id"=$1`).WithRowsNum(1)
+			},
+			wantMigrationStatus: constants.KafkaMigrationStatusPending.String(),
+		},
+		{
+			name:            "should schedule the migration of a kafka whose previous migration failed",
+			status:          constants.KafkaRequestStatusReady,
+			migrationStatus: constants.KafkaMigrationStatusFailed,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "migration_placement_id"=$1`).WithRowsNum(1)
+			},
+			wantMigrationStatus: constants.KafkaMigrationStatusPending.String(),
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return tt.targetCluster, nil
+					},
+				},
+			}
+			kafka := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Status = tt.status.String()
+				kafkaRequest.ClusterID = testClusterID
+				kafkaRequest.CloudProvider = "aws"
+				kafkaRequest.Region = "us-east-1"
+				kafkaRequest.MultiAZ = true
+				kafkaRequest.InstanceType = "standard"
+				kafkaRequest.MigrationStatus = tt.migrationStatus.String()
+			})
+			err := k.MigrateKafka(kafka, tt.targetClusterID)
+			if tt.wantErr != nil {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+			} else {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+				g.Expect(kafka.MigrationSourceClusterID).To(gomega.Equal(testClusterID))
+				g.Expect(kafka.MigrationTargetClusterID).To(gomega.Equal(tt.targetClusterID))
+			}
+			g.Expect(kafka.MigrationStatus).To(gomega.Equal(tt.wantMigrationStatus))
+		})
+	}
+}
+
+func Test_kafkaService_ResizeKafka(t *testing.T) {
+	sizeX2 := supportedKafkaSizeStandard[0]
+	sizeX2.Id = "x2"
+	sizeX2.MaxDataRetentionSize = "200Gi"
+	sizeX2.QuotaConsumed = 2
+	sizeX2.CapacityConsumed = 2
+	kafkaConfig := config.KafkaConfig{
+		Quota: config.NewKafkaQuotaConfig(),
+		SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+			Configuration: config.SupportedKafkaInstanceTypesConfig{
+				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+					{
+						Id:                     "standard",
+						DisplayName:            "Standard",
+						SupportedBillingModels: testSupportedKafkaBillingModelsStandard,
+						Sizes:                  []config.KafkaInstanceSize{supportedKafkaSizeStandard[0], sizeX2},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		status               constants.KafkaStatus
+		migrationStatus      constants.KafkaMigrationStatus
+		currentSizeId        string
+		sizeId               string
+		storageSize          string
+		fitsCurrentCluster   bool
+		targetCluster        *api.Cluster
+		quotaErr             *errors.ServiceError
+		setupFn              func()
+		wantErr              *errors.ServiceError
+		wantSizeId           string
+		wantStorageSize      string
+		wantTargetClusterID  string
+		wantResizeQuotaCalls int
+	}{
+		{
+			name:        "should return a validation error if the kafka is not ready",
+			status:      constants.KafkaRequestStatusSuspended,
+			sizeId:      "x2",
+			storageSize: "100Gi",
+			setupFn:     func() { mocket.Catcher.Reset().NewMock().WithExecException() },
+			wantErr:     errors.Validation(""),
+			wantSizeId:  "x1",
+		},
+		{
+			name:            "should return a conflict error if the kafka is being migrated",
+			status:          constants.KafkaRequestStatusReady,
+			migrationStatus: constants.KafkaMigrationStatusProvisioning,
+			sizeId:          "x2",
+			storageSize:     "100Gi",
+			setupFn:         func() { mocket.Catcher.Reset().NewMock().WithExecException() },
+			wantErr:         errors.Conflict(""),
+			wantSizeId:      "x1",
+		},
+		{
+			name:            "should not update a kafka that already has the requested size",
+			status:          constants.KafkaRequestStatusReady,
+			sizeId:          "x1",
+			storageSize:     "100Gi",
+			setupFn:         func() { mocket.Catcher.Reset().NewMock().WithExecException() },
+			wantSizeId:      "x1",
+			wantStorageSize: "100Gi",
+		},
+		{
+			name:        "should return a validation error if the size is not a size of the instance type",
+			status:      constants.KafkaRequestStatusReady,
+			sizeId:      "x9",
+			storageSize: "100Gi",
+			setupFn:     func() { mocket.Catcher.Reset().NewMock().WithExecException() },
+			wantErr:     errors.Validation(""),
+			wantSizeId:  "x1",
+		},
+		{
+			name:        "should return an error if no cluster has the capacity to host the resized kafka",
+			status:      constants.KafkaRequestStatusReady,
+			sizeId:      "x2",
+			storageSize: "100Gi",
+			setupFn:     func() { mocket.Catcher.Reset().NewMock().WithExecException() },
+			wantErr:     errors.TooManyKafkaInstancesReached(""),
+			wantSizeId:  "x1",
+		},
+		{
+			name:                 "should leave the kafka unchanged if the quota for the new size is refused",
+			status:               constants.KafkaRequestStatusReady,
+			sizeId:               "x2",
+			storageSize:          "100Gi",
+			fitsCurrentCluster:   true,
+			quotaErr:             errors.InsufficientQuotaError(""),
+			setupFn:              func() { mocket.Catcher.Reset().NewMock().WithExecException() },
+			wantErr:              errors.InsufficientQuotaError(""),
+			wantSizeId:           "x1",
+			wantResizeQuotaCalls: 1,
+		},
+		{
+			name:               "should give the reserved quota back if the kafka changed in the database",
+			status:             constants.KafkaRequestStatusReady,
+			sizeId:             "x2",
+			storageSize:        "100Gi",
+			fitsCurrentCluster: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "kafka_storage_size"=$1`).WithRowsNum(0)
+			},
+			wantErr:              errors.Conflict(""),
+			wantSizeId:           "x1",
+			wantResizeQuotaCalls: 2,
+		},
+		{
+			name:               "should resize a kafka that fits its current cluster",
+			status:             constants.KafkaRequestStatusReady,
+			sizeId:             "x2",
+			storageSize:        "100Gi",
+			fitsCurrentCluster: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "kafka_storage_size"=$1`).WithRowsNum(1)
+			},
+			wantSizeId:           "x2",
+			wantStorageSize:      "200Gi",
+			wantResizeQuotaCalls: 1,
+		},
+		{
+			name:          "should schedule the migration of a resized kafka that does not fit its current cluster",
+			status:        constants.KafkaRequestStatusReady,
+			sizeId:        "x2",
+			storageSize:   "100Gi",
+			targetCluster: &api.Cluster{ClusterID: "target-cluster"},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "kafka_storage_size"=$1`).WithRowsNum(1)
+			},
+			wantSizeId:           "x2",
+			wantStorageSize:      "200Gi",
+			wantTargetClusterID:  "target-cluster",
+			wantResizeQuotaCalls: 1,
+		},
+		{
+			name:               "should not shrink the storage of a kafka resized to a smaller size",
+			status:             constants.KafkaRequestStatusReady,
+			currentSizeId:      "x2",
+			sizeId:             "x1",
+			storageSize:        "200Gi",
+			fitsCurrentCluster: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "kafka_storage_size"=$1`).WithRowsNum(1)
+			},
+			wantSizeId:           "x1",
+			wantStorageSize:      "200Gi",
+			wantResizeQuotaCalls: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			quotaService := &QuotaServiceMock{
+				ResizeQuotaFunc: func(kafka *dbapi.KafkaRequest, sizeId string) (string, *errors.ServiceError) {
+					return "", tt.quotaErr
+				},
+			}
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       &kafkaConfig,
+				clusterPlacementStrategy: &ClusterPlacementStrategyMock{
+					FitsCurrentClusterFunc: func(kafka *dbapi.KafkaRequest, sizeId string) (bool, error) {
+						return tt.fitsCurrentCluster, nil
+					},
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return tt.targetCluster, nil
+					},
+				},
+				quotaServiceFactory: &QuotaServiceFactoryMock{
+					GetQuotaServiceFunc: func(quotaType api.QuotaType) (QuotaService, *errors.ServiceError) {
+						return quotaService, nil
+					},
+				},
+			}
+			kafka := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Status = tt.status.String()
+				kafkaRequest.InstanceType = "standard"
+				kafkaRequest.KafkaStorageSize = tt.storageSize
+				kafkaRequest.MigrationStatus = tt.migrationStatus.String()
+				if tt.currentSizeId != "" {
+					kafkaRequest.SizeId = tt.currentSizeId
+				}
+			})
+			err := k.ResizeKafka(kafka, tt.sizeId)
+			if tt.wantErr != nil {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+			} else {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+				g.Expect(kafka.KafkaStorageSize).To(gomega.Equal(tt.wantStorageSize))
+			}
+			g.Expect(kafka.SizeId).To(gomega.Equal(tt.wantSizeId))
+			g.Expect(kafka.MigrationTargetClusterID).To(gomega.Equal(tt.wantTargetClusterID))
+			g.Expect(quotaService.ResizeQuotaCalls()).To(gomega.HaveLen(tt.wantResizeQuotaCalls))
+		})
+	}
+}
+
+func Test_kafkaService_validateDrStandby(t *testing.T) {
+	primaryID := "primary-id"
+
+	tests := []struct {
+		name          string
+		modifyPrimary func(kafkaRequest *dbapi.KafkaRequest)
+		region        string
+		sizeId        string
+		wantErr       *errors.ServiceError
+	}{
+		{
+			name:    "should accept a standby in another region with the plan of its primary",
+			region:  "eu-west-1",
+			sizeId:  "x1",
+			wantErr: nil,
+		},
+		{
+			name: "should return a not found error if the primary belongs to another organisation",
+			modifyPrimary: func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.OrganisationId = "another-org"
+			},
+			region:  "eu-west-1",
+			sizeId:  "x1",
+			wantErr: errors.NotFound(""),
+		},
+		{
+			name: "should return a conflict error if the primary is already paired",
+			modifyPrimary: func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.DrRole = constants.KafkaDrRolePrimary.String()
+				kafkaRequest.DrPeerKafkaID = "another-standby"
+			},
+			region:  "eu-west-1",
+			sizeId:  "x1",
+			wantErr: errors.Conflict(""),
+		},
+		{
+			name: "should return a validation error if the primary is not ready",
+			modifyPrimary: func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Status = constants.KafkaRequestStatusSuspended.String()
+			},
+			region:  "eu-west-1",
+			sizeId:  "x1",
+			wantErr: errors.Validation(""),
+		},
+		{
+			name:    "should return a validation error if the standby is in the region of its primary",
+			region:  testKafkaRequestRegion,
+			sizeId:  "x1",
+			wantErr: errors.Validation(""),
+		},
+		{
+			name:    "should return a validation error if the standby has another size than its primary",
+			region:  "eu-west-1",
+			sizeId:  "x2",
+			wantErr: errors.Validation(""),
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			primary := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.ID = primaryID
+				kafkaRequest.Status = constants.KafkaRequestStatusReady.String()
+				kafkaRequest.OrganisationId = "test-org"
+				kafkaRequest.InstanceType = types.STANDARD.String()
+				if tt.modifyPrimary != nil {
+					tt.modifyPrimary(kafkaRequest)
+				}
+			})
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).WithReply(converters.ConvertKafkaRequest(primary))
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			standby := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.ID = ""
+				kafkaRequest.Region = tt.region
+				kafkaRequest.SizeId = tt.sizeId
+				kafkaRequest.OrganisationId = "test-org"
+				kafkaRequest.InstanceType = types.STANDARD.String()
+				kafkaRequest.DrRole = constants.KafkaDrRoleStandby.String()
+				kafkaRequest.DrPeerKafkaID = primaryID
+			})
+			err := k.validateDrStandby(standby)
+			if tt.wantErr != nil {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+			} else {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+		})
+	}
+}
+
+func Test_kafkaService_createDrStandby(t *testing.T) {
+	tests := []struct {
+		name       string
+		pairedRows int64
+		wantErr    *errors.ServiceError
+	}{
+		{
+			name:       "should create the standby and pair its primary with it",
+			pairedRows: 1,
+		},
+		{
+			name:       "should return a conflict error if the primary has been paired in the meantime",
+			pairedRows: 0,
+			wantErr:    errors.Conflict(""),
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_requests"`)
+			mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "dr_peer_kafka_id"=$1,"dr_role"=$2`).WithRowsNum(tt.pairedRows)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			standby := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.ID = ""
+				kafkaRequest.DrRole = constants.KafkaDrRoleStandby.String()
+				kafkaRequest.DrPeerKafkaID = "primary-id"
+			})
+			err := k.createDrStandby(standby)
+			if tt.wantErr != nil {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+			} else {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+		})
+	}
+}
+
+func Test_kafkaService_FailoverKafka(t *testing.T) {
+	standbyID := "standby-id"
+	standbyRoutes := `[{"Domain":"standby-bootstrap","Router":"standby-router"},{"Domain":"admin-server-standby-bootstrap","Router":"standby-router"}]`
+	primaryRoutes := `[{"Domain":"primary-bootstrap","Router":"primary-router"},{"Domain":"admin-server-primary-bootstrap","Router":"primary-router"}]`
+
+	buildStandbyReply := func(modifyFn func(reply map[string]interface{})) func() {
+		return func() {
+			reply := converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.ID = standbyID
+				kafkaRequest.Status = constants.KafkaRequestStatusReady.String()
+				kafkaRequest.BootstrapServerHost = "standby-bootstrap"
+				kafkaRequest.DrRole = constants.KafkaDrRoleStandby.String()
+				kafkaRequest.DrPeerKafkaID = testID
+			}))
+			reply[0]["routes"] = []byte(standbyRoutes)
+			if modifyFn != nil {
+				modifyFn(reply[0])
+			}
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).WithReply(reply)
+		}
+	}
+
+	tests := []struct {
+		name                         string
+		drPeerKafkaID                string
+		enableKafkaCNAMERegistration bool
+		kafkaTLSCertHost             string
+		setupFn                      func()
+		updatedRows                  int64
+		wantErr                      *errors.ServiceError
+	}{
+		{
+			name:                         "should return a validation error if the kafka is not paired",
+			enableKafkaCNAMERegistration: true,
+			setupFn:                      func() { mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException() },
+			wantErr:                      errors.Validation(""),
+		},
+		{
+			name:          "should return a validation error if the CNAME registration is disabled",
+			drPeerKafkaID: standbyID,
+			setupFn:       func() { mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException() },
+			wantErr:       errors.Validation(""),
+		},
+		{
+			name:                         "should return a validation error if the standby is not ready",
+			drPeerKafkaID:                standbyID,
+			enableKafkaCNAMERegistration: true,
+			setupFn: buildStandbyReply(func(reply map[string]interface{}) {
+				reply["status"] = constants.KafkaRequestStatusProvisioning.String()
+			}),
+			wantErr: errors.Validation(""),
+		},
+		{
+			name:                         "should return a validation error if the route of the bootstrap host of the standby is not available",
+			drPeerKafkaID:                standbyID,
+			enableKafkaCNAMERegistration: true,
+			setupFn: buildStandbyReply(func(reply map[string]interface{}) {
+				reply["routes"] = []byte(`[]`)
+			}),
+			wantErr: errors.Validation(""),
+		},
+		{
+			name:                         "should return a validation error if the external certificate is not valid for the bootstrap host of the primary",
+			drPeerKafkaID:                standbyID,
+			enableKafkaCNAMERegistration: true,
+			kafkaTLSCertHost:             "other-bootstrap",
+			setupFn:                      buildStandbyReply(nil),
+			wantErr:                      errors.Validation(""),
+		},
+		{
+			name:                         "should return a conflict error if the pair changed in the database",
+			drPeerKafkaID:                standbyID,
+			enableKafkaCNAMERegistration: true,
+			setupFn:                      buildStandbyReply(nil),
+			updatedRows:                  0,
+			wantErr:                      errors.Conflict(""),
+		},
+		{
+			name:                         "should promote the standby and switch the bootstrap host of the primary over to it",
+			drPeerKafkaID:                standbyID,
+			enableKafkaCNAMERegistration: true,
+			setupFn:                      buildStandbyReply(nil),
+			updatedRows:                  1,
+		},
+		{
+			name:                         "should fail over when the external certificate is valid for the bootstrap host of the primary",
+			drPeerKafkaID:                standbyID,
+			enableKafkaCNAMERegistration: true,
+			kafkaTLSCertHost:             "primary-bootstrap",
+			setupFn:                      buildStandbyReply(nil),
+			updatedRows:                  1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "dr_role"=$1`).WithRowsNum(tt.updatedRows)
+			kafkaConfig := &config.KafkaConfig{EnableKafkaCNAMERegistration: tt.enableKafkaCNAMERegistration}
+			if tt.kafkaTLSCertHost != "" {
+				kafkaConfig.EnableKafkaExternalCertificate = true
+				kafkaConfig.KafkaTLSCert = generateTestCertificate(t, tt.kafkaTLSCertHost)
+			}
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       kafkaConfig,
+			}
+			kafka := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Status = constants.KafkaRequestStatusReady.String()
+				kafkaRequest.BootstrapServerHost = "primary-bootstrap"
+				kafkaRequest.Routes = api.JSON(primaryRoutes)
+				kafkaRequest.DrPeerKafkaID = tt.drPeerKafkaID
+				if tt.drPeerKafkaID != "" {
+					kafkaRequest.DrRole = constants.KafkaDrRolePrimary.String()
+				}
+			})
+			err := k.FailoverKafka(kafka)
+			if tt.wantErr != nil {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(kafka.DrRole).To(gomega.Equal(constants.KafkaDrRoleStandby.String()))
+			routes, routesErr := kafka.GetRoutes()
+			g.Expect(routesErr).ToNot(gomega.HaveOccurred())
+			g.Expect(routes).To(gomega.Equal([]dbapi.DataPlaneKafkaRoute{
+				{Domain: "admin-server-primary-bootstrap", Router: "primary-router"},
+			}))
+		})
+	}
+}
+
+// generateTestCertificate returns a self signed PEM certificate for the given DNS name
+func generateTestCertificate(t *testing.T, dnsName string) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: dnsName},
+		DNSNames:     []string{dnsName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func Test_switchDrBootstrapRoutes(t *testing.T) {
+	g := gomega.NewWithT(t)
+	primary := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.BootstrapServerHost = "primary-bootstrap"
+		kafkaRequest.Routes = api.JSON(`[{"Domain":"primary-bootstrap","Router":"primary-router"},{"Domain":"admin-server-primary-bootstrap","Router":"primary-router"}]`)
+	})
+	standby := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.BootstrapServerHost = "standby-bootstrap"
+		kafkaRequest.Routes = api.JSON(`[{"Domain":"standby-bootstrap","Router":"standby-router"},{"Domain":"primary-bootstrap","Router":"stale-router"}]`)
+	})
+
+	primaryRoutes, standbyRoutes, err := switchDrBootstrapRoutes(primary, standby)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(string(primaryRoutes)).To(gomega.MatchJSON(`[{"Domain":"admin-server-primary-bootstrap","Router":"primary-router"}]`))
+	g.Expect(string(standbyRoutes)).To(gomega.MatchJSON(`[{"Domain":"primary-bootstrap","Router":"standby-router"},{"Domain":"standby-bootstrap","Router":"standby-router"}]`))
+}
+
+func Test_kafkaService_DrainCluster(t *testing.T) {
+	tests := []struct {
+		name       string
+		cluster    *api.Cluster
+		setupFn    func()
+		wantErr    *errors.ServiceError
+		wantKafkas int
+	}{
+		{
+			name:    "should return a not found error if the cluster does not exist",
+			setupFn: func() { mocket.Catcher.Reset().NewMock().WithExecException() },
+			wantErr: errors.NotFound(""),
+		},
+		{
+			name:    "should return a general error if listing the kafkas of the cluster fails",
+			cluster: &api.Cluster{ClusterID: testClusterID},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "kafka_requests"`).WithQueryException()
+			},
+			wantErr: errors.GeneralError(""),
+		},
+		{
+			name:    "should return a general error if the database update fails",
+			cluster: &api.Cluster{ClusterID: testClusterID},
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "kafka_requests"`).WithReply(converters.ConvertKafkaRequestList(dbapi.KafkaList{buildKafkaRequest(nil)}))
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "migration_placement_id"=$1`).WithExecException()
+			},
+			wantErr: errors.GeneralError(""),
+		},
+		{
+			name:    "should return the kafkas whose migration has been scheduled",
+			cluster: &api.Cluster{ClusterID: testClusterID},
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "kafka_requests"`).WithReply(converters.ConvertKafkaRequestList(dbapi.KafkaList{buildKafkaRequest(nil)}))
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "migration_placement_id"=$1`).WithRowsNum(1)
+			},
+			wantKafkas: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return tt.cluster, nil
+					},
+				},
+			}
+			kafkas, err := k.DrainCluster(testClusterID)
+			if tt.wantErr != nil {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(kafkas).To(gomega.HaveLen(tt.wantKafkas))
+			for _, kafka := range kafkas {
+				g.Expect(kafka.MigrationStatus).To(gomega.Equal(constants.KafkaMigrationStatusPending.String()))
+				g.Expect(kafka.MigrationSourceClusterID).To(gomega.Equal(testClusterID))
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package services
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/golang/glog"
+	"gorm.io/gorm"
+)
+
+// GetValidKafkaUpgradeRolloutQueryColumns returns the kafka columns that can be used in the query of a rollout
+func GetValidKafkaUpgradeRolloutQueryColumns() []string {
+	return []string{"cloud_provider", "region", "cluster_id", "instance_type", "size_id", "organisation_id", "owner", "name",
+		"actual_strimzi_version", "actual_kafka_version", "actual_kafka_ibp_version"}
+}
+
+// kafkaUpgradeRolloutTargetableStatuses are the statuses of the kafkas that can be targeted by a rollout
+var kafkaUpgradeRolloutTargetableStatuses = []string{
+	constants.KafkaRequestStatusReady.String(),
+	constants.KafkaRequestStatusSuspended.String(),
+}
+
+// activeKafkaUpgradeRolloutStatuses are the statuses of the rollouts whose targets cannot be targeted by another rollout
+var activeKafkaUpgradeRolloutStatuses = []string{
+	constants.KafkaUpgradeRolloutStatusInProgress.String(),
+	constants.KafkaUpgradeRolloutStatusPaused.String(),
+	constants.KafkaUpgradeRolloutStatusRollingBack.String(),
+}
+
+//go:generate moq -out kafka_upgrade_rollout_moq.go . KafkaUpgradeRolloutService
+type KafkaUpgradeRolloutService interface {
+	// Create resolves the kafkas matching the query of the rollout, assigns them to waves and starts the rollout.
+	// Kafkas already targeted by another active rollout are left out.
+	Create(rollout *dbapi.KafkaUpgradeRollout) *errors.ServiceError
+	Get(id string) (*dbapi.KafkaUpgradeRollout, *errors.ServiceError)
+	List(listArgs *services.ListArguments) (dbapi.KafkaUpgradeRolloutList, *api.PagingMeta, *errors.ServiceError)
+	// ListByStatus returns the rollouts in any of the given statuses
+	ListByStatus(statuses ...constants.KafkaUpgradeRolloutStatus) (dbapi.KafkaUpgradeRolloutList, *errors.ServiceError)
+	ListTargets(rolloutID string) ([]*dbapi.KafkaUpgradeRolloutTarget, *errors.ServiceError)
+	// Pause stops the rollout from starting further waves. The given reason is reported in the status of the rollout.
+	Pause(rollout *dbapi.KafkaUpgradeRollout, reason string) *errors.ServiceError
+	Resume(rollout *dbapi.KafkaUpgradeRollout) *errors.ServiceError
+	// Rollback reverts the desired versions of the kafkas touched by the rollout to their values before the rollout
+	Rollback(rollout *dbapi.KafkaUpgradeRollout) *errors.ServiceError
+	Updates(rollout *dbapi.KafkaUpgradeRollout, values map[string]interface{}) *errors.ServiceError
+	UpdateTargetStatus(target *dbapi.KafkaUpgradeRolloutTarget, status constants.KafkaUpgradeRolloutTargetStatus) *errors.ServiceError
+}
+
+func NewKafkaUpgradeRolloutService(connectionFactory *db.ConnectionFactory, clusterService ClusterService) KafkaUpgradeRolloutService {
+	return &kafkaUpgradeRolloutService{
+		connectionFactory: connectionFactory,
+		clusterService:    clusterService,
+	}
+}
+
+var _ KafkaUpgradeRolloutService = &kafkaUpgradeRolloutService{}
+
+type kafkaUpgradeRolloutService struct {
+	connectionFactory *db.ConnectionFactory
+	clusterService    ClusterService
+}
+
+func (k *kafkaUpgradeRolloutService) Create(rollout *dbapi.KafkaUpgradeRollout) *errors.ServiceError {
+	if err := rollout.Validate(); err != nil {
+		return errors.NewWithCause(errors.ErrorValidation, err, "invalid rollout: %s", err.Error())
+	}
+
+	searchDbQuery, err := queryparser.NewQueryParser(GetValidKafkaUpgradeRolloutQueryColumns()...).Parse(rollout.Query)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "unable to create rollout: %s", err.Error())
+	}
+
+	dbConn := k.connectionFactory.New()
+
+	var kafkas []*dbapi.KafkaRequest
+	if err := dbConn.Model(&dbapi.KafkaRequest{}).
+		Where(searchDbQuery.Query, searchDbQuery.Values...).
+		Where("status IN (?)", kafkaUpgradeRolloutTargetableStatuses).
+		Where("id NOT IN (?)", dbConn.Model(&dbapi.KafkaUpgradeRolloutTarget{}).
+			Select("kafka_upgrade_rollout_targets.kafka_id").
+			Joins("JOIN kafka_upgrade_rollouts ON kafka_upgrade_rollouts.id = kafka_upgrade_rollout_targets.rollout_id").
+			Where("kafka_upgrade_rollouts.status IN (?)", activeKafkaUpgradeRolloutStatuses)).
+		Order("created_at").
+		Find(&kafkas).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to find the kafkas targeted by the rollout")
+	}
+
+	if len(kafkas) == 0 {
+		return errors.Validation("no kafka that is not already part of another rollout matches the query %q", rollout.Query)
+	}
+
+	if svcErr := k.validateTargetVersions(rollout, kafkas); svcErr != nil {
+		return svcErr
+	}
+
+	kafkaIDs := make([]string, 0, len(kafkas))
+	for _, kafka := range kafkas {
+		kafkaIDs = append(kafkaIDs, kafka.ID)
+	}
+	waves, totalWaves := rollout.AssignWaves(kafkaIDs)
+
+	rollout.Status = constants.KafkaUpgradeRolloutStatusInProgress.String()
+	rollout.CurrentWave = 0
+	rollout.TotalWaves = totalWaves
+
+	targets := make([]*dbapi.KafkaUpgradeRolloutTarget, 0, len(kafkas))
+	for _, kafka := range kafkas {
+		targets = append(targets, &dbapi.KafkaUpgradeRolloutTarget{
+			KafkaId:                        kafka.ID,
+			Wave:                           waves[kafka.ID],
+			Status:                         constants.KafkaUpgradeRolloutTargetStatusPending.String(),
+			PreviousDesiredStrimziVersion:  kafka.DesiredStrimziVersion,
+			PreviousDesiredKafkaVersion:    kafka.DesiredKafkaVersion,
+			PreviousDesiredKafkaIBPVersion: kafka.DesiredKafkaIBPVersion,
+		})
+	}
+
+	// the rollout and its targets are created together so that a rollout is never started without its targets
+	if err := dbConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(rollout).Error; err != nil {
+			return err
+		}
+		for _, target := range targets {
+			target.RolloutId = rollout.ID
+		}
+		return tx.Create(&targets).Error
+	}); err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to create rollout")
+	}
+
+	glog.Infof("created rollout %q targeting %d kafkas in %d waves", rollout.ID, len(targets), totalWaves)
+	return nil
+}
+
+// validateTargetVersions checks that the versions each kafka would be upgraded to are available and ready on its data plane
+// cluster, that the kafka and ibp versions would not be downgraded and that the ibp version is not above the kafka version
+func (k *kafkaUpgradeRolloutService) validateTargetVersions(rollout *dbapi.KafkaUpgradeRollout, kafkas []*dbapi.KafkaRequest) *errors.ServiceError {
+	clusters := map[string]*api.Cluster{}
+	for _, kafka := range kafkas {
+		strimziVersion := arrays.FirstNonEmptyOrDefault(kafka.DesiredStrimziVersion, rollout.StrimziVersion)
+		kafkaVersion := arrays.FirstNonEmptyOrDefault(kafka.DesiredKafkaVersion, rollout.KafkaVersion)
+		ibpVersion := arrays.FirstNonEmptyOrDefault(kafka.DesiredKafkaIBPVersion, rollout.KafkaIBPVersion)
+
+		cluster, ok := clusters[kafka.ClusterID]
+		if !ok {
+			var svcErr *errors.ServiceError
+			cluster, svcErr = k.clusterService.FindClusterByID(kafka.ClusterID)
+			if svcErr != nil {
+				return errors.NewWithCause(errors.ErrorGeneral, svcErr, "unable to find cluster associated with kafka request: %s", kafka.ID)
+			}
+			if cluster == nil {
+				return errors.Validation("unable to get cluster for kafka %s", kafka.ID)
+			}
+			clusters[kafka.ClusterID] = cluster
+		}
+
+		if available, err := k.clusterService.IsStrimziKafkaVersionAvailableInCluster(cluster, strimziVersion, kafkaVersion, ibpVersion); err != nil {
+			return errors.Validation(err.Error())
+		} else if !available {
+			return errors.Validation("kafka version %s and ibp version %s of kafka %s are not available with strimzi version %s on cluster %s",
+				kafkaVersion, ibpVersion, kafka.ID, strimziVersion, cluster.ClusterID)
+		}
+		if ready, err := k.clusterService.CheckStrimziVersionReady(cluster, strimziVersion); err != nil {
+			return errors.Validation(err.Error())
+		} else if !ready {
+			return errors.Validation("strimzi version %s of kafka %s is not ready on cluster %s", strimziVersion, kafka.ID, cluster.ClusterID)
+		}
+
+		if rollout.StrimziVersion != "" && kafka.ActualStrimziVersion != "" {
+			current := api.StrimziVersion{Version: kafka.ActualStrimziVersion}
+			if comparison, err := current.Compare(api.StrimziVersion{Version: strimziVersion}); err != nil {
+				return errors.Validation("unable to compare actual strimzi version: %s with desired strimzi version: %s", kafka.ActualStrimziVersion, strimziVersion)
+			} else if comparison > 0 {
+				return errors.Validation("unable to downgrade kafka: %s strimzi version: %s to a lower version: %s", kafka.ID, kafka.ActualStrimziVersion, strimziVersion)
+			}
+		}
+
+		currentIBPVersion, _ := arrays.FirstNonEmpty(kafka.ActualKafkaIBPVersion, ibpVersion)
+		if comparison, err := api.CompareBuildAwareSemanticVersions(currentIBPVersion, ibpVersion); err != nil {
+			return errors.Validation("unable to compare actual ibp version: %s with desired ibp version: %s", currentIBPVersion, ibpVersion)
+		} else if comparison > 0 {
+			return errors.Validation("unable to downgrade kafka: %s ibp version: %s to a lower version: %s", kafka.ID, currentIBPVersion, ibpVersion)
+		}
+
+		if comparison, err := api.CompareBuildAwareSemanticVersions(ibpVersion, kafkaVersion); err != nil {
+			return errors.Validation("unable to compare kafka ibp version: %s with kafka version: %s", ibpVersion, kafkaVersion)
+		} else if comparison > 0 {
+			return errors.Validation("ibp version: %s of kafka: %s is above its kafka version: %s", ibpVersion, kafka.ID, kafkaVersion)
+		}
+
+		currentKafkaVersion, _ := arrays.FirstNonEmpty(kafka.ActualKafkaVersion, kafkaVersion)
+		if comparison, err := api.CompareSemanticVersionsMajorAndMinor(currentKafkaVersion, kafkaVersion); err != nil {
+			return errors.Validation("unable to compare desired kafka version: %s with actual kafka version: %s", kafkaVersion, currentKafkaVersion)
+		} else if comparison > 0 {
+			return errors.Validation("unable to downgrade kafka: %s version: %s to the following kafka version: %s", kafka.ID, currentKafkaVersion, kafkaVersion)
+		}
+	}
+	return nil
+}
+
+func (k *kafkaUpgradeRolloutService) Get(id string) (*dbapi.KafkaUpgradeRollout, *errors.ServiceError) {
+	if id == "" {
+		return nil, errors.Validation("rollout id is undefined")
+	}
+
+	var rollout dbapi.KafkaUpgradeRollout
+	if err := k.connectionFactory.New().Where("id = ?", id).First(&rollout).Error; err != nil {
+		return nil, services.HandleGetError("KafkaUpgradeRollout", "id", id, err)
+	}
+	return &rollout, nil
+}
+
+func (k *kafkaUpgradeRolloutService) List(listArgs *services.ListArguments) (dbapi.KafkaUpgradeRolloutList, *api.PagingMeta, *errors.ServiceError) {
+	var rollouts dbapi.KafkaUpgradeRolloutList
+	dbConn := k.connectionFactory.New()
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	total := int64(pagingMeta.Total)
+	dbConn.Model(&rollouts).Count(&total)
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+
+	if err := dbConn.Order("created_at desc").
+		Offset((pagingMeta.Page - 1) * pagingMeta.Size).
+		Limit(pagingMeta.Size).
+		Find(&rollouts).Error; err != nil {
+		return rollouts, pagingMeta, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list rollouts")
+	}
+
+	return rollouts, pagingMeta, nil
+}
+
+func (k *kafkaUpgradeRolloutService) ListByStatus(statuses ...constants.KafkaUpgradeRolloutStatus) (dbapi.KafkaUpgradeRolloutList, *errors.ServiceError) {
+	statusValues := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		statusValues = append(statusValues, status.String())
+	}
+
+	var rollouts dbapi.KafkaUpgradeRolloutList
+	if err := k.connectionFactory.New().
+		Where("status IN (?)", statusValues).
+		Order("created_at").
+		Find(&rollouts).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list rollouts with statuses %q", statusValues)
+	}
+	return rollouts, nil
+}
+
+func (k *kafkaUpgradeRolloutService) ListTargets(rolloutID string) ([]*dbapi.KafkaUpgradeRolloutTarget, *errors.ServiceError) {
+	var targets []*dbapi.KafkaUpgradeRolloutTarget
+	if err := k.connectionFactory.New().
+		Where("rollout_id = ?", rolloutID).
+		Order("wave").
+		Find(&targets).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list the targets of rollout %q", rolloutID)
+	}
+	return targets, nil
+}
+
+func (k *kafkaUpgradeRolloutService) Pause(rollout *dbapi.KafkaUpgradeRollout, reason string) *errors.ServiceError {
+	if rollout.Status == constants.KafkaUpgradeRolloutStatusPaused.String() {
+		return nil
+	}
+	return k.transitionStatus(rollout, []string{constants.KafkaUpgradeRolloutStatusInProgress.String()}, constants.KafkaUpgradeRolloutStatusPaused, reason)
+}
+
+func (k *kafkaUpgradeRolloutService) Resume(rollout *dbapi.KafkaUpgradeRollout) *errors.ServiceError {
+	if rollout.Status == constants.KafkaUpgradeRolloutStatusInProgress.String() {
+		return nil
+	}
+	return k.transitionStatus(rollout, []string{constants.KafkaUpgradeRolloutStatusPaused.String()}, constants.KafkaUpgradeRolloutStatusInProgress, "")
+}
+
+func (k *kafkaUpgradeRolloutService) Rollback(rollout *dbapi.KafkaUpgradeRollout) *errors.ServiceError {
+	if rollout.Status == constants.KafkaUpgradeRolloutStatusRollingBack.String() {
+		return nil
+	}
+	return k.transitionStatus(rollout, []string{
+		constants.KafkaUpgradeRolloutStatusInProgress.String(),
+		constants.KafkaUpgradeRolloutStatusPaused.String(),
+		constants.KafkaUpgradeRolloutStatusCompleted.String(),
+	}, constants.KafkaUpgradeRolloutStatusRollingBack, rollout.StatusReason)
+}
+
+func (k *kafkaUpgradeRolloutService) Updates(rollout *dbapi.KafkaUpgradeRollout, values map[string]interface{}) *errors.ServiceError {
+	if err := k.connectionFactory.New().Model(rollout).Updates(values).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update rollout %q", rollout.ID)
+	}
+	return nil
+}
+
+func (k *kafkaUpgradeRolloutService) UpdateTargetStatus(target *dbapi.KafkaUpgradeRolloutTarget, status constants.KafkaUpgradeRolloutTargetStatus) *errors.ServiceError {
+	if err := k.connectionFactory.New().Model(target).Update("status", status.String()).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update status of kafka %q in rollout %q to %q", target.KafkaId, target.RolloutId, status)
+	}
+	target.Status = status.String()
+	return nil
+}
+
+// transitionStatus sets the status of the rollout only if its status in the database is still one of the 'from' statuses
+func (k *kafkaUpgradeRolloutService) transitionStatus(rollout *dbapi.KafkaUpgradeRollout, from []string, to constants.KafkaUpgradeRolloutStatus, reason string) *errors.ServiceError {
+	if !arrays.Contains(from, rollout.Status) {
+		return errors.Validation("rollout with a status of %q cannot be transitioned to %q. Supported statuses are: %q", rollout.Status, to, from)
+	}
+
+	dbConn := k.connectionFactory.New().
+		Model(&dbapi.KafkaUpgradeRollout{Meta: api.Meta{ID: rollout.ID}}).
+		Where("status IN (?)", from).
+		Updates(map[string]interface{}{
+			"status":        to.String(),
+			"status_reason": reason,
+		})
+
+	if err := dbConn.Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update status of rollout %q to %q", rollout.ID, to)
+	}
+
+	if dbConn.RowsAffected == 0 {
+		return errors.Conflict("unable to update status of rollout %q to %q: the rollout is no longer in any of the following states: %q", rollout.ID, to, from)
+	}
+
+	glog.Infof("updated status of rollout %q from %q to %q", rollout.ID, rollout.Status, to)
+	rollout.Status = to.String()
+	rollout.StatusReason = reason
+	return nil
+}
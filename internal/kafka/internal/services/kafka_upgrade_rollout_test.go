@@ -0,0 +1,181 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+var rolloutTestKafkas = []map[string]interface{}{
+	{"id": "kafka-1", "cluster_id": "cluster-1", "actual_kafka_version": "3.2.0", "desired_kafka_version": "3.2.0", "desired_kafka_ibp_version": "3.2"},
+	{"id": "kafka-2", "cluster_id": "cluster-1", "actual_kafka_version": "3.2.0", "desired_kafka_version": "3.2.0", "desired_kafka_ibp_version": "3.2"},
+	{"id": "kafka-3", "cluster_id": "cluster-2", "actual_kafka_version": "3.2.0", "desired_kafka_version": "3.2.0", "desired_kafka_ibp_version": "3.2"},
+	{"id": "kafka-4", "cluster_id": "cluster-2", "actual_kafka_version": "3.2.0", "desired_kafka_version": "3.2.0", "desired_kafka_ibp_version": "3.2"},
+	{"id": "kafka-5", "cluster_id": "cluster-2", "actual_kafka_version": "3.2.0", "desired_kafka_version": "3.2.0", "desired_kafka_ibp_version": "3.2"},
+}
+
+func Test_kafkaUpgradeRolloutService_Create(t *testing.T) {
+	newRollout := func(query string) *dbapi.KafkaUpgradeRollout {
+		return &dbapi.KafkaUpgradeRollout{
+			Query:           query,
+			StrimziVersion:  "strimzi-cluster-operator.v0.30.0-0",
+			CanaryCount:     1,
+			BatchPercentage: 50,
+		}
+	}
+
+	readyCluster := func(available bool) *ClusterServiceMock {
+		return &ClusterServiceMock{
+			FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+				return &api.Cluster{ClusterID: clusterID}, nil
+			},
+			IsStrimziKafkaVersionAvailableInClusterFunc: func(cluster *api.Cluster, strimziVersion, kafkaVersion, ibpVersion string) (bool, error) {
+				return available, nil
+			},
+			CheckStrimziVersionReadyFunc: func(cluster *api.Cluster, strimziVersion string) (bool, error) {
+				return true, nil
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		rollout        *dbapi.KafkaUpgradeRollout
+		clusterService *ClusterServiceMock
+		setupFn        func()
+		wantErrCode    errors.ServiceErrorCode
+		wantTotalWaves int
+	}{
+		{
+			name:        "should return a validation error when no version is set",
+			rollout:     &dbapi.KafkaUpgradeRollout{Query: "region = us-east-1", BatchPercentage: 50},
+			setupFn:     func() { mocket.Catcher.Reset() },
+			wantErrCode: errors.ErrorValidation,
+		},
+		{
+			name:        "should return an error when the query uses an unsupported column",
+			rollout:     newRollout("status = ready"),
+			setupFn:     func() { mocket.Catcher.Reset() },
+			wantErrCode: errors.ErrorFailedToParseSearch,
+		},
+		{
+			name:    "should return a validation error when no kafka matches the query",
+			rollout: newRollout("region = us-east-1"),
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "kafka_requests"`).WithReply([]map[string]interface{}{})
+			},
+			wantErrCode: errors.ErrorValidation,
+		},
+		{
+			name:           "should return a validation error when the versions are not available on the cluster of a kafka",
+			rollout:        newRollout("region = us-east-1"),
+			clusterService: readyCluster(false),
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "kafka_requests"`).WithReply(rolloutTestKafkas)
+			},
+			wantErrCode: errors.ErrorValidation,
+		},
+		{
+			name: "should return a validation error when the kafka version would be downgraded",
+			rollout: &dbapi.KafkaUpgradeRollout{
+				Query:           "region = us-east-1",
+				KafkaVersion:    "3.1.0",
+				BatchPercentage: 50,
+			},
+			clusterService: readyCluster(true),
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "kafka_requests"`).WithReply(rolloutTestKafkas)
+			},
+			wantErrCode: errors.ErrorValidation,
+		},
+		{
+			name:           "should create the rollout and split the matching kafkas in waves",
+			rollout:        newRollout("region = us-east-1"),
+			clusterService: readyCluster(true),
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "kafka_requests"`).WithReply(rolloutTestKafkas)
+			},
+			wantTotalWaves: 3,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := NewKafkaUpgradeRolloutService(db.NewMockConnectionFactory(nil), tt.clusterService)
+			err := k.Create(tt.rollout)
+			if tt.wantErrCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(tt.rollout.Status).To(gomega.Equal(constants.KafkaUpgradeRolloutStatusInProgress.String()))
+			g.Expect(tt.rollout.TotalWaves).To(gomega.Equal(tt.wantTotalWaves))
+		})
+	}
+}
+
+func Test_kafkaUpgradeRolloutService_Resume(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      constants.KafkaUpgradeRolloutStatus
+		setupFn     func()
+		wantErrCode errors.ServiceErrorCode
+		wantStatus  constants.KafkaUpgradeRolloutStatus
+	}{
+		{
+			name:        "should not resume a completed rollout",
+			status:      constants.KafkaUpgradeRolloutStatusCompleted,
+			setupFn:     func() { mocket.Catcher.Reset() },
+			wantErrCode: errors.ErrorValidation,
+		},
+		{
+			name:   "should return a conflict when the rollout is no longer paused",
+			status: constants.KafkaUpgradeRolloutStatusPaused,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_upgrade_rollouts"`).WithRowsNum(0)
+			},
+			wantErrCode: errors.ErrorConflict,
+		},
+		{
+			name:   "should resume a paused rollout",
+			status: constants.KafkaUpgradeRolloutStatusPaused,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_upgrade_rollouts"`).WithRowsNum(1)
+			},
+			wantStatus: constants.KafkaUpgradeRolloutStatusInProgress,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			rollout := &dbapi.KafkaUpgradeRollout{
+				Meta:         api.Meta{ID: "rollout-id"},
+				Status:       tt.status.String(),
+				StatusReason: "kafka failed",
+			}
+			k := NewKafkaUpgradeRolloutService(db.NewMockConnectionFactory(nil), &ClusterServiceMock{})
+			err := k.Resume(rollout)
+			if tt.wantErrCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(rollout.Status).To(gomega.Equal(tt.wantStatus.String()))
+			g.Expect(rollout.StatusReason).To(gomega.BeEmpty())
+		})
+	}
+}
@@ -47,6 +47,12 @@ var _ KafkaService = &KafkaServiceMock{}
 //			DeprovisionKafkaForUsersFunc: func(users []string) *apiErrors.ServiceError {
 //				panic("mock out the DeprovisionKafkaForUsers method")
 //			},
+//			DrainClusterFunc: func(clusterID string) (dbapi.KafkaList, *apiErrors.ServiceError) {
+//				panic("mock out the DrainCluster method")
+//			},
+//			FailoverKafkaFunc: func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
+//				panic("mock out the FailoverKafka method")
+//			},
 //			GenerateReservedManagedKafkasByClusterIDFunc: func(clusterID string) ([]v1.ManagedKafka, *apiErrors.ServiceError) {
 //				panic("mock out the GenerateReservedManagedKafkasByClusterID method")
 //			},
@@ -74,15 +80,27 @@ var _ KafkaService = &KafkaServiceMock{}
 //			ListAllFunc: func() (dbapi.KafkaList, *apiErrors.ServiceError) {
 //				panic("mock out the ListAll method")
 //			},
+//			ListByMigrationStatusFunc: func(status ...constants.KafkaMigrationStatus) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+//				panic("mock out the ListByMigrationStatus method")
+//			},
 //			ListByStatusFunc: func(status ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
 //				panic("mock out the ListByStatus method")
 //			},
+//			ListChangesFunc: func(ctx context.Context, listArgs *services.ListArguments, gtVersion int64, includeDeleted bool) (dbapi.KafkaList, *apiErrors.ServiceError) {
+//				panic("mock out the ListChanges method")
+//			},
 //			ListComponentVersionsFunc: func() ([]KafkaComponentVersions, error) {
 //				panic("mock out the ListComponentVersions method")
 //			},
+//			ListKafkasWithPendingUpgradesFunc: func() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+//				panic("mock out the ListKafkasWithPendingUpgrades method")
+//			},
 //			ListKafkasWithRoutesNotCreatedFunc: func() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
 //				panic("mock out the ListKafkasWithRoutesNotCreated method")
 //			},
+//			MigrateKafkaFunc: func(kafkaRequest *dbapi.KafkaRequest, targetClusterID string) *apiErrors.ServiceError {
+//				panic("mock out the MigrateKafka method")
+//			},
 //			PrepareKafkaRequestFunc: func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
 //				panic("mock out the PrepareKafkaRequest method")
 //			},
@@ -92,6 +110,15 @@ var _ KafkaService = &KafkaServiceMock{}
 //			RegisterKafkaJobFunc: func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
 //				panic("mock out the RegisterKafkaJob method")
 //			},
+//			ResizeKafkaFunc: func(kafkaRequest *dbapi.KafkaRequest, sizeId string) *apiErrors.ServiceError {
+//				panic("mock out the ResizeKafka method")
+//			},
+//			ResumeKafkaFunc: func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
+//				panic("mock out the ResumeKafka method")
+//			},
+//			SuspendKafkaFunc: func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
+//				panic("mock out the SuspendKafka method")
+//			},
 //			UpdateFunc: func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
 //				panic("mock out the Update method")
 //			},
@@ -135,6 +162,12 @@ type KafkaServiceMock struct {
 	// DeprovisionKafkaForUsersFunc mocks the DeprovisionKafkaForUsers method.
 	DeprovisionKafkaForUsersFunc func(users []string) *apiErrors.ServiceError
 
+	// DrainClusterFunc mocks the DrainCluster method.
+	DrainClusterFunc func(clusterID string) (dbapi.KafkaList, *apiErrors.ServiceError)
+
+	// FailoverKafkaFunc mocks the FailoverKafka method.
+	FailoverKafkaFunc func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
+
 	// GenerateReservedManagedKafkasByClusterIDFunc mocks the GenerateReservedManagedKafkasByClusterID method.
 	GenerateReservedManagedKafkasByClusterIDFunc func(clusterID string) ([]v1.ManagedKafka, *apiErrors.ServiceError)
 
@@ -162,15 +195,27 @@ type KafkaServiceMock struct {
 	// ListAllFunc mocks the ListAll method.
 	ListAllFunc func() (dbapi.KafkaList, *apiErrors.ServiceError)
 
+	// ListByMigrationStatusFunc mocks the ListByMigrationStatus method.
+	ListByMigrationStatusFunc func(status ...constants.KafkaMigrationStatus) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError)
+
 	// ListByStatusFunc mocks the ListByStatus method.
 	ListByStatusFunc func(status ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError)
 
+	// ListChangesFunc mocks the ListChanges method.
+	ListChangesFunc func(ctx context.Context, listArgs *services.ListArguments, gtVersion int64, includeDeleted bool) (dbapi.KafkaList, *apiErrors.ServiceError)
+
 	// ListComponentVersionsFunc mocks the ListComponentVersions method.
 	ListComponentVersionsFunc func() ([]KafkaComponentVersions, error)
 
+	// ListKafkasWithPendingUpgradesFunc mocks the ListKafkasWithPendingUpgrades method.
+	ListKafkasWithPendingUpgradesFunc func() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError)
+
 	// ListKafkasWithRoutesNotCreatedFunc mocks the ListKafkasWithRoutesNotCreated method.
 	ListKafkasWithRoutesNotCreatedFunc func() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError)
 
+	// MigrateKafkaFunc mocks the MigrateKafka method.
+	MigrateKafkaFunc func(kafkaRequest *dbapi.KafkaRequest, targetClusterID string) *apiErrors.ServiceError
+
 	// PrepareKafkaRequestFunc mocks the PrepareKafkaRequest method.
 	PrepareKafkaRequestFunc func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
 
@@ -180,6 +225,15 @@ type KafkaServiceMock struct {
 	// RegisterKafkaJobFunc mocks the RegisterKafkaJob method.
 	RegisterKafkaJobFunc func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
 
+	// ResizeKafkaFunc mocks the ResizeKafka method.
+	ResizeKafkaFunc func(kafkaRequest *dbapi.KafkaRequest, sizeId string) *apiErrors.ServiceError
+
+	// ResumeKafkaFunc mocks the ResumeKafka method.
+	ResumeKafkaFunc func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
+
+	// SuspendKafkaFunc mocks the SuspendKafka method.
+	SuspendKafkaFunc func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
+
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
 
@@ -234,6 +288,16 @@ type KafkaServiceMock struct {
 			// Users is the users argument value.
 			Users []string
 		}
+		// DrainCluster holds details about calls to the DrainCluster method.
+		DrainCluster []struct {
+			// ClusterID is the clusterID argument value.
+			ClusterID string
+		}
+		// FailoverKafka holds details about calls to the FailoverKafka method.
+		FailoverKafka []struct {
+			// KafkaRequest is the kafkaRequest argument value.
+			KafkaRequest *dbapi.KafkaRequest
+		}
 		// GenerateReservedManagedKafkasByClusterID holds details about calls to the GenerateReservedManagedKafkasByClusterID method.
 		GenerateReservedManagedKafkasByClusterID []struct {
 			// ClusterID is the clusterID argument value.
@@ -281,17 +345,43 @@ type KafkaServiceMock struct {
 		// ListAll holds details about calls to the ListAll method.
 		ListAll []struct {
 		}
+		// ListByMigrationStatus holds details about calls to the ListByMigrationStatus method.
+		ListByMigrationStatus []struct {
+			// Status is the status argument value.
+			Status []constants.KafkaMigrationStatus
+		}
 		// ListByStatus holds details about calls to the ListByStatus method.
 		ListByStatus []struct {
 			// Status is the status argument value.
 			Status []constants.KafkaStatus
 		}
+		// ListChanges holds details about calls to the ListChanges method.
+		ListChanges []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ListArgs is the listArgs argument value.
+			ListArgs *services.ListArguments
+			// GtVersion is the gtVersion argument value.
+			GtVersion int64
+			// IncludeDeleted is the includeDeleted argument value.
+			IncludeDeleted bool
+		}
 		// ListComponentVersions holds details about calls to the ListComponentVersions method.
 		ListComponentVersions []struct {
 		}
+		// ListKafkasWithPendingUpgrades holds details about calls to the ListKafkasWithPendingUpgrades method.
+		ListKafkasWithPendingUpgrades []struct {
+		}
 		// ListKafkasWithRoutesNotCreated holds details about calls to the ListKafkasWithRoutesNotCreated method.
 		ListKafkasWithRoutesNotCreated []struct {
 		}
+		// MigrateKafka holds details about calls to the MigrateKafka method.
+		MigrateKafka []struct {
+			// KafkaRequest is the kafkaRequest argument value.
+			KafkaRequest *dbapi.KafkaRequest
+			// TargetClusterID is the targetClusterID argument value.
+			TargetClusterID string
+		}
 		// PrepareKafkaRequest holds details about calls to the PrepareKafkaRequest method.
 		PrepareKafkaRequest []struct {
 			// KafkaRequest is the kafkaRequest argument value.
@@ -309,6 +399,23 @@ type KafkaServiceMock struct {
 			// KafkaRequest is the kafkaRequest argument value.
 			KafkaRequest *dbapi.KafkaRequest
 		}
+		// ResizeKafka holds details about calls to the ResizeKafka method.
+		ResizeKafka []struct {
+			// KafkaRequest is the kafkaRequest argument value.
+			KafkaRequest *dbapi.KafkaRequest
+			// SizeId is the sizeId argument value.
+			SizeId string
+		}
+		// ResumeKafka holds details about calls to the ResumeKafka method.
+		ResumeKafka []struct {
+			// KafkaRequest is the kafkaRequest argument value.
+			KafkaRequest *dbapi.KafkaRequest
+		}
+		// SuspendKafka holds details about calls to the SuspendKafka method.
+		SuspendKafka []struct {
+			// KafkaRequest is the kafkaRequest argument value.
+			KafkaRequest *dbapi.KafkaRequest
+		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
 			// KafkaRequest is the kafkaRequest argument value.
@@ -354,6 +461,8 @@ type KafkaServiceMock struct {
 	lockDelete                                   sync.RWMutex
 	lockDeprovisionExpiredKafkas                 sync.RWMutex
 	lockDeprovisionKafkaForUsers                 sync.RWMutex
+	lockDrainCluster                             sync.RWMutex
+	lockFailoverKafka                            sync.RWMutex
 	lockGenerateReservedManagedKafkasByClusterID sync.RWMutex
 	lockGet                                      sync.RWMutex
 	lockGetAvailableSizesInRegion                sync.RWMutex
@@ -363,12 +472,19 @@ type KafkaServiceMock struct {
 	lockHasAvailableCapacityInRegion             sync.RWMutex
 	lockList                                     sync.RWMutex
 	lockListAll                                  sync.RWMutex
+	lockListByMigrationStatus                    sync.RWMutex
 	lockListByStatus                             sync.RWMutex
+	lockListChanges                              sync.RWMutex
 	lockListComponentVersions                    sync.RWMutex
+	lockListKafkasWithPendingUpgrades            sync.RWMutex
 	lockListKafkasWithRoutesNotCreated           sync.RWMutex
+	lockMigrateKafka                             sync.RWMutex
 	lockPrepareKafkaRequest                      sync.RWMutex
 	lockRegisterKafkaDeprovisionJob              sync.RWMutex
 	lockRegisterKafkaJob                         sync.RWMutex
+	lockResizeKafka                              sync.RWMutex
+	lockResumeKafka                              sync.RWMutex
+	lockSuspendKafka                             sync.RWMutex
 	lockUpdate                                   sync.RWMutex
 	lockUpdateStatus                             sync.RWMutex
 	lockUpdates                                  sync.RWMutex
@@ -603,6 +719,70 @@ func (mock *KafkaServiceMock) DeprovisionKafkaForUsersCalls() []struct {
 	return calls
 }
 
+// DrainCluster calls DrainClusterFunc.
+func (mock *KafkaServiceMock) DrainCluster(clusterID string) (dbapi.KafkaList, *apiErrors.ServiceError) {
+	if mock.DrainClusterFunc == nil {
+		panic("KafkaServiceMock.DrainClusterFunc: method is nil but KafkaService.DrainCluster was just called")
+	}
+	callInfo := struct {
+		ClusterID string
+	}{
+		ClusterID: clusterID,
+	}
+	mock.lockDrainCluster.Lock()
+	mock.calls.DrainCluster = append(mock.calls.DrainCluster, callInfo)
+	mock.lockDrainCluster.Unlock()
+	return mock.DrainClusterFunc(clusterID)
+}
+
+// DrainClusterCalls gets all the calls that were made to DrainCluster.
+// Check the length with:
+//
+//	len(mockedKafkaService.DrainClusterCalls())
+func (mock *KafkaServiceMock) DrainClusterCalls() []struct {
+	ClusterID string
+} {
+	var calls []struct {
+		ClusterID string
+	}
+	mock.lockDrainCluster.RLock()
+	calls = mock.calls.DrainCluster
+	mock.lockDrainCluster.RUnlock()
+	return calls
+}
+
+// FailoverKafka calls FailoverKafkaFunc.
+func (mock *KafkaServiceMock) FailoverKafka(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
+	if mock.FailoverKafkaFunc == nil {
+		panic("KafkaServiceMock.FailoverKafkaFunc: method is nil but KafkaService.FailoverKafka was just called")
+	}
+	callInfo := struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}{
+		KafkaRequest: kafkaRequest,
+	}
+	mock.lockFailoverKafka.Lock()
+	mock.calls.FailoverKafka = append(mock.calls.FailoverKafka, callInfo)
+	mock.lockFailoverKafka.Unlock()
+	return mock.FailoverKafkaFunc(kafkaRequest)
+}
+
+// FailoverKafkaCalls gets all the calls that were made to FailoverKafka.
+// Check the length with:
+//
+//	len(mockedKafkaService.FailoverKafkaCalls())
+func (mock *KafkaServiceMock) FailoverKafkaCalls() []struct {
+	KafkaRequest *dbapi.KafkaRequest
+} {
+	var calls []struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}
+	mock.lockFailoverKafka.RLock()
+	calls = mock.calls.FailoverKafka
+	mock.lockFailoverKafka.RUnlock()
+	return calls
+}
+
 // GenerateReservedManagedKafkasByClusterID calls GenerateReservedManagedKafkasByClusterIDFunc.
 func (mock *KafkaServiceMock) GenerateReservedManagedKafkasByClusterID(clusterID string) ([]v1.ManagedKafka, *apiErrors.ServiceError) {
 	if mock.GenerateReservedManagedKafkasByClusterIDFunc == nil {
@@ -894,6 +1074,38 @@ func (mock *KafkaServiceMock) ListAllCalls() []struct {
 	return calls
 }
 
+// ListByMigrationStatus calls ListByMigrationStatusFunc.
+func (mock *KafkaServiceMock) ListByMigrationStatus(status ...constants.KafkaMigrationStatus) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.ListByMigrationStatusFunc == nil {
+		panic("KafkaServiceMock.ListByMigrationStatusFunc: method is nil but KafkaService.ListByMigrationStatus was just called")
+	}
+	callInfo := struct {
+		Status []constants.KafkaMigrationStatus
+	}{
+		Status: status,
+	}
+	mock.lockListByMigrationStatus.Lock()
+	mock.calls.ListByMigrationStatus = append(mock.calls.ListByMigrationStatus, callInfo)
+	mock.lockListByMigrationStatus.Unlock()
+	return mock.ListByMigrationStatusFunc(status...)
+}
+
+// ListByMigrationStatusCalls gets all the calls that were made to ListByMigrationStatus.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListByMigrationStatusCalls())
+func (mock *KafkaServiceMock) ListByMigrationStatusCalls() []struct {
+	Status []constants.KafkaMigrationStatus
+} {
+	var calls []struct {
+		Status []constants.KafkaMigrationStatus
+	}
+	mock.lockListByMigrationStatus.RLock()
+	calls = mock.calls.ListByMigrationStatus
+	mock.lockListByMigrationStatus.RUnlock()
+	return calls
+}
+
 // ListByStatus calls ListByStatusFunc.
 func (mock *KafkaServiceMock) ListByStatus(status ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
 	if mock.ListByStatusFunc == nil {
@@ -926,6 +1138,50 @@ func (mock *KafkaServiceMock) ListByStatusCalls() []struct {
 	return calls
 }
 
+// ListChanges calls ListChangesFunc.
+func (mock *KafkaServiceMock) ListChanges(ctx context.Context, listArgs *services.ListArguments, gtVersion int64, includeDeleted bool) (dbapi.KafkaList, *apiErrors.ServiceError) {
+	if mock.ListChangesFunc == nil {
+		panic("KafkaServiceMock.ListChangesFunc: method is nil but KafkaService.ListChanges was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		ListArgs       *services.ListArguments
+		GtVersion      int64
+		IncludeDeleted bool
+	}{
+		Ctx:            ctx,
+		ListArgs:       listArgs,
+		GtVersion:      gtVersion,
+		IncludeDeleted: includeDeleted,
+	}
+	mock.lockListChanges.Lock()
+	mock.calls.ListChanges = append(mock.calls.ListChanges, callInfo)
+	mock.lockListChanges.Unlock()
+	return mock.ListChangesFunc(ctx, listArgs, gtVersion, includeDeleted)
+}
+
+// ListChangesCalls gets all the calls that were made to ListChanges.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListChangesCalls())
+func (mock *KafkaServiceMock) ListChangesCalls() []struct {
+	Ctx            context.Context
+	ListArgs       *services.ListArguments
+	GtVersion      int64
+	IncludeDeleted bool
+} {
+	var calls []struct {
+		Ctx            context.Context
+		ListArgs       *services.ListArguments
+		GtVersion      int64
+		IncludeDeleted bool
+	}
+	mock.lockListChanges.RLock()
+	calls = mock.calls.ListChanges
+	mock.lockListChanges.RUnlock()
+	return calls
+}
+
 // ListComponentVersions calls ListComponentVersionsFunc.
 func (mock *KafkaServiceMock) ListComponentVersions() ([]KafkaComponentVersions, error) {
 	if mock.ListComponentVersionsFunc == nil {
@@ -953,6 +1209,33 @@ func (mock *KafkaServiceMock) ListComponentVersionsCalls() []struct {
 	return calls
 }
 
+// ListKafkasWithPendingUpgrades calls ListKafkasWithPendingUpgradesFunc.
+func (mock *KafkaServiceMock) ListKafkasWithPendingUpgrades() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.ListKafkasWithPendingUpgradesFunc == nil {
+		panic("KafkaServiceMock.ListKafkasWithPendingUpgradesFunc: method is nil but KafkaService.ListKafkasWithPendingUpgrades was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockListKafkasWithPendingUpgrades.Lock()
+	mock.calls.ListKafkasWithPendingUpgrades = append(mock.calls.ListKafkasWithPendingUpgrades, callInfo)
+	mock.lockListKafkasWithPendingUpgrades.Unlock()
+	return mock.ListKafkasWithPendingUpgradesFunc()
+}
+
+// ListKafkasWithPendingUpgradesCalls gets all the calls that were made to ListKafkasWithPendingUpgrades.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListKafkasWithPendingUpgradesCalls())
+func (mock *KafkaServiceMock) ListKafkasWithPendingUpgradesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockListKafkasWithPendingUpgrades.RLock()
+	calls = mock.calls.ListKafkasWithPendingUpgrades
+	mock.lockListKafkasWithPendingUpgrades.RUnlock()
+	return calls
+}
+
 // ListKafkasWithRoutesNotCreated calls ListKafkasWithRoutesNotCreatedFunc.
 func (mock *KafkaServiceMock) ListKafkasWithRoutesNotCreated() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
 	if mock.ListKafkasWithRoutesNotCreatedFunc == nil {
@@ -980,6 +1263,42 @@ func (mock *KafkaServiceMock) ListKafkasWithRoutesNotCreatedCalls() []struct {
 	return calls
 }
 
+// MigrateKafka calls MigrateKafkaFunc.
+func (mock *KafkaServiceMock) MigrateKafka(kafkaRequest *dbapi.KafkaRequest, targetClusterID string) *apiErrors.ServiceError {
+	if mock.MigrateKafkaFunc == nil {
+		panic("KafkaServiceMock.MigrateKafkaFunc: method is nil but KafkaService.MigrateKafka was just called")
+	}
+	callInfo := struct {
+		KafkaRequest    *dbapi.KafkaRequest
+		TargetClusterID string
+	}{
+		KafkaRequest:    kafkaRequest,
+		TargetClusterID: targetClusterID,
+	}
+	mock.lockMigrateKafka.Lock()
+	mock.calls.MigrateKafka = append(mock.calls.MigrateKafka, callInfo)
+	mock.lockMigrateKafka.Unlock()
+	return mock.MigrateKafkaFunc(kafkaRequest, targetClusterID)
+}
+
+// MigrateKafkaCalls gets all the calls that were made to MigrateKafka.
+// Check the length with:
+//
+//	len(mockedKafkaService.MigrateKafkaCalls())
+func (mock *KafkaServiceMock) MigrateKafkaCalls() []struct {
+	KafkaRequest    *dbapi.KafkaRequest
+	TargetClusterID string
+} {
+	var calls []struct {
+		KafkaRequest    *dbapi.KafkaRequest
+		TargetClusterID string
+	}
+	mock.lockMigrateKafka.RLock()
+	calls = mock.calls.MigrateKafka
+	mock.lockMigrateKafka.RUnlock()
+	return calls
+}
+
 // PrepareKafkaRequest calls PrepareKafkaRequestFunc.
 func (mock *KafkaServiceMock) PrepareKafkaRequest(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
 	if mock.PrepareKafkaRequestFunc == nil {
@@ -1080,6 +1399,106 @@ func (mock *KafkaServiceMock) RegisterKafkaJobCalls() []struct {
 	return calls
 }
 
+// ResizeKafka calls ResizeKafkaFunc.
+func (mock *KafkaServiceMock) ResizeKafka(kafkaRequest *dbapi.KafkaRequest, sizeId string) *apiErrors.ServiceError {
+	if mock.ResizeKafkaFunc == nil {
+		panic("KafkaServiceMock.ResizeKafkaFunc: method is nil but KafkaService.ResizeKafka was just called")
+	}
+	callInfo := struct {
+		KafkaRequest *dbapi.KafkaRequest
+		SizeId       string
+	}{
+		KafkaRequest: kafkaRequest,
+		SizeId:       sizeId,
+	}
+	mock.lockResizeKafka.Lock()
+	mock.calls.ResizeKafka = append(mock.calls.ResizeKafka, callInfo)
+	mock.lockResizeKafka.Unlock()
+	return mock.ResizeKafkaFunc(kafkaRequest, sizeId)
+}
+
+// ResizeKafkaCalls gets all the calls that were made to ResizeKafka.
+// Check the length with:
+//
+//	len(mockedKafkaService.ResizeKafkaCalls())
+func (mock *KafkaServiceMock) ResizeKafkaCalls() []struct {
+	KafkaRequest *dbapi.KafkaRequest
+	SizeId       string
+} {
+	var calls []struct {
+		KafkaRequest *dbapi.KafkaRequest
+		SizeId       string
+	}
+	mock.lockResizeKafka.RLock()
+	calls = mock.calls.ResizeKafka
+	mock.lockResizeKafka.RUnlock()
+	return calls
+}
+
+// ResumeKafka calls ResumeKafkaFunc.
+func (mock *KafkaServiceMock) ResumeKafka(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
+	if mock.ResumeKafkaFunc == nil {
+		panic("KafkaServiceMock.ResumeKafkaFunc: method is nil but KafkaService.ResumeKafka was just called")
+	}
+	callInfo := struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}{
+		KafkaRequest: kafkaRequest,
+	}
+	mock.lockResumeKafka.Lock()
+	mock.calls.ResumeKafka = append(mock.calls.ResumeKafka, callInfo)
+	mock.lockResumeKafka.Unlock()
+	return mock.ResumeKafkaFunc(kafkaRequest)
+}
+
+// ResumeKafkaCalls gets all the calls that were made to ResumeKafka.
+// Check the length with:
+//
+//	len(mockedKafkaService.ResumeKafkaCalls())
+func (mock *KafkaServiceMock) ResumeKafkaCalls() []struct {
+	KafkaRequest *dbapi.KafkaRequest
+} {
+	var calls []struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}
+	mock.lockResumeKafka.RLock()
+	calls = mock.calls.ResumeKafka
+	mock.lockResumeKafka.RUnlock()
+	return calls
+}
+
+// SuspendKafka calls SuspendKafkaFunc.
+func (mock *KafkaServiceMock) SuspendKafka(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
+	if mock.SuspendKafkaFunc == nil {
+		panic("KafkaServiceMock.SuspendKafkaFunc: method is nil but KafkaService.SuspendKafka was just called")
+	}
+	callInfo := struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}{
+		KafkaRequest: kafkaRequest,
+	}
+	mock.lockSuspendKafka.Lock()
+	mock.calls.SuspendKafka = append(mock.calls.SuspendKafka, callInfo)
+	mock.lockSuspendKafka.Unlock()
+	return mock.SuspendKafkaFunc(kafkaRequest)
+}
+
+// SuspendKafkaCalls gets all the calls that were made to SuspendKafka.
+// Check the length with:
+//
+//	len(mockedKafkaService.SuspendKafkaCalls())
+func (mock *KafkaServiceMock) SuspendKafkaCalls() []struct {
+	KafkaRequest *dbapi.KafkaRequest
+} {
+	var calls []struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}
+	mock.lockSuspendKafka.RLock()
+	calls = mock.calls.SuspendKafka
+	mock.lockSuspendKafka.RUnlock()
+	return calls
+}
+
 // Update calls UpdateFunc.
 func (mock *KafkaServiceMock) Update(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
 	if mock.UpdateFunc == nil {
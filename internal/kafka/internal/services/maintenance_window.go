@@ -0,0 +1,126 @@
+package services
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+)
+
+//go:generate moq -out maintenance_window_moq.go . MaintenanceWindowService
+type MaintenanceWindowService interface {
+	// Get returns the maintenance window of the kafka with the given id. The window of the organisation is returned when kafkaID is empty.
+	Get(organisationID, kafkaID string) (*dbapi.MaintenanceWindow, *errors.ServiceError)
+	// Upsert creates the maintenance window, or replaces the schedule of the existing window of the same kafka or organisation
+	Upsert(window *dbapi.MaintenanceWindow) *errors.ServiceError
+	// Delete removes the maintenance window of the kafka with the given id, or the window of the organisation when kafkaID is empty
+	Delete(organisationID, kafkaID string) *errors.ServiceError
+	// FindForKafka returns the maintenance window that applies to the kafka: its own window if it has one, the window of its
+	// organisation otherwise. nil is returned when no window applies, i.e. upgrades of the kafka can be rolled out at any time.
+	FindForKafka(kafka *dbapi.KafkaRequest) (*dbapi.MaintenanceWindow, *errors.ServiceError)
+}
+
+func NewMaintenanceWindowService(connectionFactory *db.ConnectionFactory) MaintenanceWindowService {
+	return &maintenanceWindowService{
+		connectionFactory: connectionFactory,
+	}
+}
+
+var _ MaintenanceWindowService = &maintenanceWindowService{}
+
+// maintenanceWindowUpsertSQL relies on the unique index on the organisation and kafka ids of the windows that are not deleted
+const maintenanceWindowUpsertSQL = `INSERT INTO maintenance_windows
+	(id, created_at, updated_at, organisation_id, kafka_id, day_of_week, start_time, duration_hours)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (organisation_id, kafka_id) WHERE deleted_at IS NULL DO UPDATE SET
+	updated_at = EXCLUDED.updated_at, day_of_week = EXCLUDED.day_of_week, start_time = EXCLUDED.start_time, duration_hours = EXCLUDED.duration_hours
+	RETURNING *`
+
+type maintenanceWindowService struct {
+	connectionFactory *db.ConnectionFactory
+}
+
+func (m *maintenanceWindowService) Get(organisationID, kafkaID string) (*dbapi.MaintenanceWindow, *errors.ServiceError) {
+	if kafkaID == "" && organisationID == "" {
+		return nil, errors.Validation("organisation id is undefined")
+	}
+
+	dbConn := m.connectionFactory.New()
+	if kafkaID != "" {
+		dbConn = dbConn.Where("kafka_id = ?", kafkaID)
+	} else {
+		dbConn = dbConn.Where("organisation_id = ?", organisationID).Where("kafka_id = ''")
+	}
+
+	var window dbapi.MaintenanceWindow
+	if err := dbConn.First(&window).Error; err != nil {
+		if kafkaID != "" {
+			return nil, services.HandleGetError("MaintenanceWindow", "kafka_id", kafkaID, err)
+		}
+		return nil, services.HandleGetError("MaintenanceWindow", "organisation_id", organisationID, err)
+	}
+
+	return &window, nil
+}
+
+func (m *maintenanceWindowService) Upsert(window *dbapi.MaintenanceWindow) *errors.ServiceError {
+	if err := window.Validate(); err != nil {
+		return errors.NewWithCause(errors.ErrorValidation, err, "invalid maintenance window: %s", err.Error())
+	}
+
+	if window.ID == "" {
+		window.ID = api.NewID()
+	}
+	now := time.Now()
+	// the window is inserted, or its schedule is updated when a window of the same kafka or organisation exists,
+	// in a single statement so that concurrent upserts can't create duplicate windows
+	var upserted dbapi.MaintenanceWindow
+	if err := m.connectionFactory.New().Raw(maintenanceWindowUpsertSQL,
+		window.ID, now, now, window.OrganisationId, window.KafkaId, window.DayOfWeek, window.StartTime, window.DurationHours,
+	).Scan(&upserted).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to upsert maintenance window")
+	}
+
+	window.Meta = upserted.Meta
+	return nil
+}
+
+func (m *maintenanceWindowService) Delete(organisationID, kafkaID string) *errors.ServiceError {
+	window, svcErr := m.Get(organisationID, kafkaID)
+	if svcErr != nil {
+		return svcErr
+	}
+
+	if err := m.connectionFactory.New().Delete(window).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to delete maintenance window")
+	}
+
+	return nil
+}
+
+func (m *maintenanceWindowService) FindForKafka(kafka *dbapi.KafkaRequest) (*dbapi.MaintenanceWindow, *errors.ServiceError) {
+	var windows []*dbapi.MaintenanceWindow
+	dbConn := m.connectionFactory.New()
+	if kafka.OrganisationId != "" {
+		dbConn = dbConn.Where("kafka_id = ? OR (organisation_id = ? AND kafka_id = '')", kafka.ID, kafka.OrganisationId)
+	} else {
+		dbConn = dbConn.Where("kafka_id = ?", kafka.ID)
+	}
+
+	if err := dbConn.Find(&windows).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to find the maintenance window of kafka %q", kafka.ID)
+	}
+
+	var organisationWindow *dbapi.MaintenanceWindow
+	for _, window := range windows {
+		if window.KafkaId == kafka.ID {
+			return window, nil
+		}
+		organisationWindow = window
+	}
+
+	return organisationWindow, nil
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_maintenanceWindowService_FindForKafka(t *testing.T) {
+	kafka := &dbapi.KafkaRequest{
+		OrganisationId: "org-id",
+	}
+	kafka.ID = "kafka-id"
+
+	tests := []struct {
+		name         string
+		wantKafkaId  string
+		wantNoWindow bool
+		wantErr      bool
+		setupFn      func()
+	}{
+		{
+			name:    "should return an error when the query fails",
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "maintenance_windows"`).WithQueryException()
+			},
+		},
+		{
+			name:         "should return nil when no maintenance window applies",
+			wantNoWindow: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "maintenance_windows"`).WithReply([]map[string]interface{}{})
+			},
+		},
+		{
+			name:        "should fall back to the organisation window",
+			wantKafkaId: "",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "maintenance_windows"`).WithReply([]map[string]interface{}{
+					{"id": "org-window", "organisation_id": "org-id", "kafka_id": "", "day_of_week": "sunday", "start_time": "02:00", "duration_hours": 4},
+				})
+			},
+		},
+		{
+			name:        "should prefer the kafka window over the organisation window",
+			wantKafkaId: "kafka-id",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "maintenance_windows"`).WithReply([]map[string]interface{}{
+					{"id": "org-window", "organisation_id": "org-id", "kafka_id": "", "day_of_week": "sunday", "start_time": "02:00", "duration_hours": 4},
+					{"id": "kafka-window", "organisation_id": "org-id", "kafka_id": "kafka-id", "day_of_week": "monday", "start_time": "03:00", "duration_hours": 2},
+				})
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			m := NewMaintenanceWindowService(db.NewMockConnectionFactory(nil))
+			window, err := m.FindForKafka(kafka)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			if tt.wantNoWindow {
+				g.Expect(window).To(gomega.BeNil())
+				return
+			}
+			g.Expect(window).ToNot(gomega.BeNil())
+			g.Expect(window.KafkaId).To(gomega.Equal(tt.wantKafkaId))
+		})
+	}
+}
+
+func Test_maintenanceWindowService_Upsert(t *testing.T) {
+	tests := []struct {
+		name     string
+		window   *dbapi.MaintenanceWindow
+		setupFn  func()
+		wantCode errors.ServiceErrorCode
+		wantId   string
+	}{
+		{
+			name: "should return a validation error when the window is invalid",
+			window: &dbapi.MaintenanceWindow{
+				OrganisationId: "org-id",
+				DayOfWeek:      "someday",
+				StartTime:      "02:00",
+				DurationHours:  4,
+			},
+			setupFn:  func() {},
+			wantCode: errors.ErrorValidation,
+		},
+		{
+			name: "should keep the id of the existing window of the organisation",
+			window: &dbapi.MaintenanceWindow{
+				OrganisationId: "org-id",
+				DayOfWeek:      "sunday",
+				StartTime:      "02:00",
+				DurationHours:  4,
+			},
+			setupFn: func() {
+				mocket.Catcher.NewMock().
+					WithQuery(`ON CONFLICT (organisation_id, kafka_id) WHERE deleted_at IS NULL DO UPDATE`).
+					WithReply([]map[string]interface{}{{"id": "existing-window-id", "organisation_id": "org-id", "day_of_week": "sunday"}})
+			},
+			wantId: "existing-window-id",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			tt.setupFn()
+			m := NewMaintenanceWindowService(db.NewMockConnectionFactory(nil))
+			err := m.Upsert(tt.window)
+			if tt.wantCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(tt.window.ID).To(gomega.Equal(tt.wantId))
+		})
+	}
+}
@@ -13,6 +13,10 @@ type QuotaService interface {
 	CheckIfQuotaIsDefinedForInstanceType(username string, externalID string, instanceTypeID types.KafkaInstanceType, kafkaBillingModel config.KafkaBillingModel) (bool, *errors.ServiceError)
 	// ReserveQuota reserves a quota for a user and return the reservation id or an error in case of failure
 	ReserveQuota(kafka *dbapi.KafkaRequest) (string, *errors.ServiceError)
+	// ResizeQuota changes the quota reserved for the kafka to the quota consumed by the given size and returns the id of the
+	// subscription holding the reservation. When the kafka grows, the quota for the difference between the two sizes must be
+	// available, otherwise an error is returned and the reservation is left unchanged.
+	ResizeQuota(kafka *dbapi.KafkaRequest, sizeId string) (string, *errors.ServiceError)
 	// DeleteQuota deletes a reserved quota
 	DeleteQuota(subscriptionId string) *errors.ServiceError
 	// ValidateBillingAccount validates if a billing account is contained in the quota cost response
@@ -345,7 +345,6 @@ func (q amsQuotaService) getBillingModel(kafka *dbapi.KafkaRequest, instanceType
 
 func (q amsQuotaService) ReserveQuota(kafka *dbapi.KafkaRequest) (string, *errors.ServiceError) {
 	instanceType := types.KafkaInstanceType(kafka.InstanceType)
-	kafkaId := kafka.ID
 
 	rr := q.newBaseQuotaReservedResourceBuilder(kafka)
 
@@ -387,35 +386,116 @@ func (q amsQuotaService) ReserveQuota(kafka *dbapi.KafkaRequest) (string, *error
 	// will be empty if no marketplace account is used
 	rr.BillingMarketplaceAccount(kafka.BillingCloudAccountId)
 
+	resp, err := q.amsClient.ClusterAuthorization(q.newClusterAuthorizationRequest(kafka, &rr))
+	if err != nil {
+		return "", errors.NewWithCause(errors.ErrorGeneral, err, "error reserving quota")
+	}
+
+	if !resp.Allowed() {
+		return "", errors.InsufficientQuotaError("Insufficient Quota")
+	}
+
+	// TODO find a better place to update it as it is a side-effect in nested code
+	kafka.ActualKafkaBillingModel = matchedBillingModel
+
+	return resp.Subscription().ID(), nil
+}
+
+func (q amsQuotaService) newClusterAuthorizationRequest(kafka *dbapi.KafkaRequest, rr *amsv1.ReservedResourceBuilder) *amsv1.ClusterAuthorizationRequest {
+	instanceType := types.KafkaInstanceType(kafka.InstanceType)
 	cb, _ := amsv1.NewClusterAuthorizationRequest().
 		AccountUsername(kafka.Owner).
 		CloudProviderID(kafka.CloudProvider).
 		ProductID(instanceType.GetQuotaType().GetProduct()).
 		Managed(true).
-		ClusterID(kafkaId).
-		ExternalClusterID(kafkaId).
+		ClusterID(kafka.ID).
+		ExternalClusterID(kafka.ID).
 		Disconnected(false).
 		BYOC(false).
 		AvailabilityZone(q.getAMSClusterAuthorizationRequestAvailabilityZone(kafka.MultiAZ)).
 		Reserve(true).
-		Resources(&rr).
+		Resources(rr).
 		Build()
+	return cb
+}
+
+// ResizeQuota updates the resources reserved by the cluster authorization of the kafka. As the authorization is keyed by the
+// kafka id, AMS replaces the reserved resources of the existing subscription instead of creating a new one.
+func (q amsQuotaService) ResizeQuota(kafka *dbapi.KafkaRequest, sizeId string) (string, *errors.ServiceError) {
+	currentSize, e := q.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
+	if e != nil {
+		return "", errors.NewWithCause(errors.ErrorGeneral, e, "error resizing quota")
+	}
+
+	newSize, e := q.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, sizeId)
+	if e != nil {
+		return "", errors.NewWithCause(errors.ErrorGeneral, e, "error resizing quota")
+	}
+
+	if delta := newSize.QuotaConsumed - currentSize.QuotaConsumed; delta > 0 {
+		hasQuota, err := q.hasQuotaForDelta(kafka, delta)
+		if err != nil {
+			return "", err
+		}
+		if !hasQuota {
+			return "", errors.InsufficientQuotaError("insufficient quota to resize kafka %q from size %q to size %q", kafka.ID, kafka.SizeId, sizeId)
+		}
+	}
+
+	billingModel := amsv1.BillingModelStandard
+	if kafka.ActualKafkaBillingModel != string(amsv1.BillingModelStandard) {
+		billingModel = amsv1.BillingModelMarketplace
+		if kafka.Marketplace != "" {
+			marketplaceBillingModel, err := getMarketplaceBillingModelForCloudProvider(kafka.Marketplace)
+			if err != nil {
+				return "", errors.ToServiceError(err)
+			}
+			billingModel = marketplaceBillingModel
+		}
+	}
 
-	resp, err := q.amsClient.ClusterAuthorization(cb)
+	rr := q.newBaseQuotaReservedResourceBuilder(kafka)
+	rr.BillingModel(billingModel)
+	rr.Count(newSize.QuotaConsumed)
+	rr.BillingMarketplaceAccount(kafka.BillingCloudAccountId)
+
+	resp, err := q.amsClient.ClusterAuthorization(q.newClusterAuthorizationRequest(kafka, &rr))
 	if err != nil {
-		return "", errors.NewWithCause(errors.ErrorGeneral, err, "error reserving quota")
+		return "", errors.NewWithCause(errors.ErrorGeneral, err, "error resizing quota")
 	}
 
 	if !resp.Allowed() {
 		return "", errors.InsufficientQuotaError("Insufficient Quota")
 	}
 
-	// TODO find a better place to update it as it is a side-effect in nested code
-	kafka.ActualKafkaBillingModel = matchedBillingModel
-
 	return resp.Subscription().ID(), nil
 }
 
+// hasQuotaForDelta checks that the organisation of the kafka has quota left for the given number of additional
+// units with the billing model of the kafka
+func (q amsQuotaService) hasQuotaForDelta(kafka *dbapi.KafkaRequest, delta int) (bool, *errors.ServiceError) {
+	instanceType := types.KafkaInstanceType(kafka.InstanceType)
+	orgId, err := q.amsClient.GetOrganisationIdFromExternalId(kafka.OrganisationId)
+	if err != nil {
+		return false, errors.NewWithCause(errors.ErrorGeneral, err, fmt.Sprintf("error checking quota: failed to get organization with external id %v", kafka.OrganisationId))
+	}
+
+	quotaCosts, err := q.amsClient.GetQuotaCostsForProduct(orgId, instanceType.GetQuotaType().GetResourceName(), instanceType.GetQuotaType().GetProduct())
+	if err != nil {
+		return false, errors.InsufficientQuotaError("%v: error getting quotas for product %s", err, instanceType.GetQuotaType().GetProduct())
+	}
+
+	for _, qc := range quotaCosts {
+		for _, rr := range qc.RelatedResources() {
+			if rr.BillingModel() == kafka.ActualKafkaBillingModel && (rr.Cost() == 0 || qc.Consumed()+delta <= qc.Allowed()) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
 func (q amsQuotaService) DeleteQuota(subscriptionId string) *errors.ServiceError {
 	if subscriptionId == "" {
 		return nil
@@ -552,7 +552,7 @@ func Test_AMSGetBillingModel(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, nil, nil, tt.fields.kafkaConfig)
+			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, nil, nil, nil, tt.fields.kafkaConfig)
 			quotaService, _ := factory.GetQuotaService(api.AMSQuotaType)
 
 			billingModel, err := quotaService.(*amsQuotaService).getBillingModel(&tt.args.request, types.STANDARD)
@@ -692,7 +692,7 @@ func Test_AMSValidateBillingAccount(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, nil, nil, tt.fields.kafkaConfig)
+			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, nil, nil, nil, tt.fields.kafkaConfig)
 			quotaService, _ := factory.GetQuotaService(api.AMSQuotaType)
 			err := quotaService.ValidateBillingAccount(tt.args.orgId, types.STANDARD, tt.args.billingAccountId, tt.args.marketplace)
 			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
@@ -861,7 +861,7 @@ func Test_AMSCheckQuota(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, nil, nil, tt.fields.kafkaConfig)
+			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, nil, nil, nil, tt.fields.kafkaConfig)
 			quotaService, _ := factory.GetQuotaService(api.AMSQuotaType)
 			kafka := &dbapi.KafkaRequest{
 				Meta: api.Meta{
@@ -1421,7 +1421,7 @@ func Test_AMSReserveQuota(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, nil, nil, tt.fields.kafkaConfig)
+			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, nil, nil, nil, tt.fields.kafkaConfig)
 			quotaService, _ := factory.GetQuotaService(api.AMSQuotaType)
 			kafka := &dbapi.KafkaRequest{
 				Meta: api.Meta{
@@ -1454,6 +1454,120 @@ func Test_AMSReserveQuota(t *testing.T) {
 	}
 }
 
+func Test_AMSResizeQuota(t *testing.T) {
+	buildOCMClient := func(allowed, consumed int, authorized bool) *ocm.ClientMock {
+		return &ocm.ClientMock{
+			ClusterAuthorizationFunc: func(cb *v1.ClusterAuthorizationRequest) (*v1.ClusterAuthorizationResponse, error) {
+				sub := v1.SubscriptionBuilder{}
+				sub.ID("1234")
+				sub.Status("Active")
+				ca, _ := v1.NewClusterAuthorizationResponse().Allowed(authorized).Subscription(&sub).Build()
+				return ca, nil
+			},
+			GetOrganisationIdFromExternalIdFunc: func(externalId string) (string, error) {
+				return fmt.Sprintf("fake-org-id-%s", externalId), nil
+			},
+			GetQuotaCostsForProductFunc: func(organizationID, resourceName, product string) ([]*v1.QuotaCost, error) {
+				rrbq1 := v1.NewRelatedResource().BillingModel(string(v1.BillingModelStandard)).Product(string(ocm.RHOSAKProduct)).ResourceName(resourceName).Cost(1)
+				qcb, err := v1.NewQuotaCost().Allowed(allowed).Consumed(consumed).OrganizationID(organizationID).RelatedResources(rrbq1).Build()
+				if err != nil {
+					panic("unexpected error")
+				}
+				return []*v1.QuotaCost{qcb}, nil
+			},
+		}
+	}
+
+	tests := []struct {
+		name                  string
+		ocmClient             *ocm.ClientMock
+		currentSizeId         string
+		sizeId                string
+		wantSubscriptionID    string
+		wantErr               *errors.ServiceError
+		wantQuotaCostsChecked bool
+		wantReservedCount     int
+		wantClusterAuthorized bool
+	}{
+		{
+			name:                  "should reserve the quota of the new size when the organisation has quota left for the delta",
+			ocmClient:             buildOCMClient(3, 1, true),
+			currentSizeId:         "x1",
+			sizeId:                "x2",
+			wantSubscriptionID:    "1234",
+			wantQuotaCostsChecked: true,
+			wantReservedCount:     2,
+			wantClusterAuthorized: true,
+		},
+		{
+			name:                  "should not reserve the quota of the new size when the organisation has no quota left for the delta",
+			ocmClient:             buildOCMClient(1, 1, true),
+			currentSizeId:         "x1",
+			sizeId:                "x2",
+			wantErr:               errors.InsufficientQuotaError(""),
+			wantQuotaCostsChecked: true,
+		},
+		{
+			name:                  "should reserve the quota of the new size without checking the quota costs when the kafka is shrunk",
+			ocmClient:             buildOCMClient(1, 1, true),
+			currentSizeId:         "x2",
+			sizeId:                "x1",
+			wantSubscriptionID:    "1234",
+			wantReservedCount:     1,
+			wantClusterAuthorized: true,
+		},
+		{
+			name:                  "should return an error when the cluster authorization is refused",
+			ocmClient:             buildOCMClient(3, 1, false),
+			currentSizeId:         "x1",
+			sizeId:                "x2",
+			wantErr:               errors.InsufficientQuotaError(""),
+			wantQuotaCostsChecked: true,
+			wantReservedCount:     2,
+			wantClusterAuthorized: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			factory := NewDefaultQuotaServiceFactory(tt.ocmClient, nil, nil, nil, &defaultKafkaConf)
+			quotaService, _ := factory.GetQuotaService(api.AMSQuotaType)
+			kafka := &dbapi.KafkaRequest{
+				Meta: api.Meta{
+					ID: "12231",
+				},
+				Owner:                   "testUser",
+				SizeId:                  tt.currentSizeId,
+				InstanceType:            types.STANDARD.String(),
+				ActualKafkaBillingModel: string(v1.BillingModelStandard),
+			}
+			subId, err := quotaService.ResizeQuota(kafka, tt.sizeId)
+			if tt.wantErr != nil {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+			} else {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+			g.Expect(subId).To(gomega.Equal(tt.wantSubscriptionID))
+			g.Expect(kafka.SizeId).To(gomega.Equal(tt.currentSizeId))
+			g.Expect(len(tt.ocmClient.GetQuotaCostsForProductCalls()) > 0).To(gomega.Equal(tt.wantQuotaCostsChecked))
+
+			clusterAuthorizationCalls := tt.ocmClient.ClusterAuthorizationCalls()
+			if !tt.wantClusterAuthorized {
+				g.Expect(clusterAuthorizationCalls).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(clusterAuthorizationCalls).To(gomega.HaveLen(1))
+			clusterAuthorizationResources := clusterAuthorizationCalls[0].Cb.Resources()
+			g.Expect(clusterAuthorizationResources).To(gomega.HaveLen(1))
+			g.Expect(clusterAuthorizationResources[0].Count()).To(gomega.Equal(tt.wantReservedCount))
+			g.Expect(clusterAuthorizationResources[0].BillingModel()).To(gomega.BeEquivalentTo(v1.BillingModelStandard))
+		})
+	}
+}
+
 func Test_Delete_Quota(t *testing.T) {
 	type fields struct {
 		ocmClient ocm.Client
@@ -1505,7 +1619,7 @@ func Test_Delete_Quota(t *testing.T) {
 	for _, testcase := range tests {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
-			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, nil, nil, &defaultKafkaConf)
+			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, nil, nil, nil, &defaultKafkaConf)
 			quotaService, _ := factory.GetQuotaService(api.AMSQuotaType)
 			err := quotaService.DeleteQuota(tt.args.subscriptionId)
 			if (err != nil) != tt.wantErr {
@@ -1684,7 +1798,7 @@ func Test_amsQuotaService_CheckIfQuotaIsDefinedForInstanceType(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			quotaServiceFactory := NewDefaultQuotaServiceFactory(tt.ocmClient, nil, nil, &defaultKafkaConf)
+			quotaServiceFactory := NewDefaultQuotaServiceFactory(tt.ocmClient, nil, nil, nil, &defaultKafkaConf)
 			quotaService, _ := quotaServiceFactory.GetQuotaService(api.AMSQuotaType)
 
 			// FIXME: fix when implementing support for KAFKA BILLING MODELS
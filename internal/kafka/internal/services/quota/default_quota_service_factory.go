@@ -19,11 +19,12 @@ func NewDefaultQuotaServiceFactory(
 	amsClient ocm.AMSClient,
 	connectionFactory *db.ConnectionFactory,
 	quotaManagementListConfig *quota_management.QuotaManagementListConfig,
+	quotaListService services.QuotaListService,
 	kafkaConfig *config.KafkaConfig,
 ) services.QuotaServiceFactory {
 	quotaServiceContainer := map[api.QuotaType]services.QuotaService{
 		api.AMSQuotaType:                 &amsQuotaService{amsClient: amsClient, kafkaConfig: kafkaConfig},
-		api.QuotaManagementListQuotaType: &QuotaManagementListService{connectionFactory: connectionFactory, quotaManagementList: quotaManagementListConfig, kafkaConfig: kafkaConfig},
+		api.QuotaManagementListQuotaType: &QuotaManagementListService{connectionFactory: connectionFactory, quotaManagementList: quotaManagementListConfig, quotaListService: quotaListService, kafkaConfig: kafkaConfig},
 	}
 	return &DefaultQuotaServiceFactory{quotaServiceContainer: quotaServiceContainer}
 }
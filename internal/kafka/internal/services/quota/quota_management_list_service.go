@@ -23,6 +23,7 @@ const (
 type QuotaManagementListService struct {
 	connectionFactory   *db.ConnectionFactory
 	quotaManagementList *quota_management.QuotaManagementListConfig
+	quotaListService    services.QuotaListService
 	kafkaConfig         *config.KafkaConfig
 }
 
@@ -37,14 +38,20 @@ func (q QuotaManagementListService) ValidateBillingAccount(organisationId string
 func (q QuotaManagementListService) CheckIfQuotaIsDefinedForInstanceType(username string, organisationId string, instanceType types.KafkaInstanceType, kafkaBillingModel config.KafkaBillingModel) (bool, *errors.ServiceError) {
 	orgId := organisationId
 	var account quota_management.Account
-	org, orgFound := q.quotaManagementList.QuotaList.Organisations.GetById(orgId)
+	org, orgFound, err := q.quotaListService.FindOrganisation(orgId)
+	if err != nil {
+		return false, err
+	}
 	userIsRegistered := false
 	serviceAccountIsRegistered := false
 
 	if orgFound && org.IsUserRegistered(username) {
 		userIsRegistered = true
 	} else {
-		account, serviceAccountIsRegistered = q.quotaManagementList.QuotaList.ServiceAccounts.GetByUsername(username)
+		account, serviceAccountIsRegistered, err = q.quotaListService.FindServiceAccount(username)
+		if err != nil {
+			return false, err
+		}
 	}
 
 	// if the user is registered, check that he has quota defined for the desired instance type
@@ -84,14 +91,20 @@ func (q QuotaManagementListService) ReserveQuota(kafka *dbapi.KafkaRequest) (str
 	orgId := kafka.OrganisationId
 	var quotaManagementListItem quota_management.QuotaManagementListItem
 	message := fmt.Sprintf("user '%s' has reached a maximum number of %d allowed streaming units", username, quota_management.GetDefaultMaxAllowedInstances())
-	org, orgFound := q.quotaManagementList.QuotaList.Organisations.GetById(orgId)
+	org, orgFound, err := q.quotaListService.FindOrganisation(orgId)
+	if err != nil {
+		return "", err
+	}
 	filterByOrg := false
 	if orgFound && org.IsUserRegistered(username) {
 		quotaManagementListItem = org
 		message = fmt.Sprintf("organization '%s' has reached a maximum number of %d allowed streaming units", orgId, org.GetMaxAllowedInstances(kafka.InstanceType, kafka.DesiredKafkaBillingModel))
 		filterByOrg = true
 	} else {
-		user, userFound := q.quotaManagementList.QuotaList.ServiceAccounts.GetByUsername(username)
+		user, userFound, err := q.quotaListService.FindServiceAccount(username)
+		if err != nil {
+			return "", err
+		}
 		if userFound {
 			quotaManagementListItem = user
 			message = fmt.Sprintf("user '%s' has reached a maximum number of %d allowed streaming units", username, user.GetMaxAllowedInstances(kafka.InstanceType, kafka.DesiredKafkaBillingModel))
@@ -118,8 +131,12 @@ func (q QuotaManagementListService) ReserveQuota(kafka *dbapi.KafkaRequest) (str
 		return "", errors.GeneralError(errMessage)
 	}
 
-	for _, kafka := range kafkas {
-		kafkaInstanceSize, e := q.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
+	for _, existingKafka := range kafkas {
+		// the kafka is already stored when its quota is reserved again to resize it
+		if existingKafka.ID == kafka.ID {
+			continue
+		}
+		kafkaInstanceSize, e := q.kafkaConfig.GetKafkaInstanceSize(existingKafka.InstanceType, existingKafka.SizeId)
 		if e != nil {
 			return "", errors.NewWithCause(errors.ErrorGeneral, e, errMessage)
 		}
@@ -170,12 +187,18 @@ func (q QuotaManagementListService) detectBillingModel(kafka *dbapi.KafkaRequest
 
 	var grantedQuota []quota_management.Quota
 
-	org, orgFound := q.quotaManagementList.QuotaList.Organisations.GetById(kafka.OrganisationId)
+	org, orgFound, err := q.quotaListService.FindOrganisation(kafka.OrganisationId)
+	if err != nil {
+		return "", err
+	}
 	username := kafka.Owner
 	if orgFound {
 		grantedQuota = org.GetGrantedQuota()
 	} else {
-		user, userFound := q.quotaManagementList.QuotaList.ServiceAccounts.GetByUsername(username)
+		user, userFound, err := q.quotaListService.FindServiceAccount(username)
+		if err != nil {
+			return "", err
+		}
 		if userFound {
 			grantedQuota = user.GetGrantedQuota()
 		} else {
@@ -205,6 +228,35 @@ func (q QuotaManagementListService) detectBillingModel(kafka *dbapi.KafkaRequest
 	return quota.GetKafkaBillingModels()[0].Id, nil
 }
 
+// ResizeQuota checks that the kafka, once resized, still fits in the streaming units allowed by the quota list.
+// No reservation is made by the quota list, so the subscription id of the kafka is returned unchanged.
+func (q QuotaManagementListService) ResizeQuota(kafka *dbapi.KafkaRequest, sizeId string) (string, *errors.ServiceError) {
+	currentSize, e := q.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
+	if e != nil {
+		return "", errors.NewWithCause(errors.ErrorGeneral, e, "error resizing quota")
+	}
+
+	newSize, e := q.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, sizeId)
+	if e != nil {
+		return "", errors.NewWithCause(errors.ErrorGeneral, e, "error resizing quota")
+	}
+
+	// shrinking a kafka always fits in its current quota
+	if newSize.CapacityConsumed <= currentSize.CapacityConsumed {
+		return kafka.SubscriptionId, nil
+	}
+
+	resizedKafka := *kafka
+	resizedKafka.SizeId = sizeId
+	// the billing model of the kafka has been decided when it was created
+	resizedKafka.DesiredKafkaBillingModel = kafka.ActualKafkaBillingModel
+	if _, err := q.ReserveQuota(&resizedKafka); err != nil {
+		return "", err
+	}
+
+	return kafka.SubscriptionId, nil
+}
+
 func (q QuotaManagementListService) DeleteQuota(SubscriptionId string) *errors.ServiceError {
 	return nil // NOOP
 }
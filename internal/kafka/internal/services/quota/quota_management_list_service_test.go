@@ -271,7 +271,7 @@ func Test_QuotaManagementListCheckQuota(t *testing.T) {
 
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			factory := NewDefaultQuotaServiceFactory(nil, tt.fields.connectionFactory, tt.fields.QuotaManagementList, &defaultKafkaConf)
+			factory := NewDefaultQuotaServiceFactory(nil, tt.fields.connectionFactory, tt.fields.QuotaManagementList, quotaListServiceFor(tt.fields.QuotaManagementList), &defaultKafkaConf)
 			quotaService, _ := factory.GetQuotaService(api.QuotaManagementListQuotaType)
 			kafka := &dbapi.KafkaRequest{
 				Owner:          "username",
@@ -303,6 +303,19 @@ var kafkaSupportedInstanceTypesConfig = config.KafkaSupportedInstanceTypesConfig
 						DeprecatedQuotaType:         "rhosak",
 						CapacityConsumed:            1,
 					},
+					{
+						Id:                          "x2",
+						IngressThroughputPerSec:     "60Mi",
+						EgressThroughputPerSec:      "60Mi",
+						TotalMaxConnections:         2000,
+						MaxDataRetentionSize:        "200Gi",
+						MaxPartitions:               2000,
+						MaxDataRetentionPeriod:      "P14D",
+						MaxConnectionAttemptsPerSec: 200,
+						QuotaConsumed:               2,
+						DeprecatedQuotaType:         "rhosak",
+						CapacityConsumed:            2,
+					},
 				},
 			},
 			{
@@ -328,6 +341,24 @@ var kafkaSupportedInstanceTypesConfig = config.KafkaSupportedInstanceTypesConfig
 	},
 }
 
+// quotaListServiceFor returns a quota list service serving the organisations and service accounts of the quota list configuration
+func quotaListServiceFor(quotaManagementList *quota_management.QuotaManagementListConfig) services.QuotaListService {
+	var quotaList quota_management.RegisteredUsersListConfiguration
+	if quotaManagementList != nil {
+		quotaList = quotaManagementList.QuotaList
+	}
+	return &services.QuotaListServiceMock{
+		FindOrganisationFunc: func(organisationID string) (quota_management.Organisation, bool, *errors.ServiceError) {
+			org, found := quotaList.Organisations.GetById(organisationID)
+			return org, found, nil
+		},
+		FindServiceAccountFunc: func(username string) (quota_management.Account, bool, *errors.ServiceError) {
+			account, found := quotaList.ServiceAccounts.GetByUsername(username)
+			return account, found, nil
+		},
+	}
+}
+
 var defaultKafkaConf = config.KafkaConfig{
 	Quota:                  config.NewKafkaQuotaConfig(),
 	SupportedInstanceTypes: &kafkaSupportedInstanceTypesConfig,
@@ -581,7 +612,7 @@ func Test_QuotaManagementListReserveQuota(t *testing.T) {
 			if tt.setupFn != nil {
 				tt.setupFn()
 			}
-			factory := NewDefaultQuotaServiceFactory(nil, tt.fields.connectionFactory, tt.fields.QuotaManagementList, &defaultKafkaConf)
+			factory := NewDefaultQuotaServiceFactory(nil, tt.fields.connectionFactory, tt.fields.QuotaManagementList, quotaListServiceFor(tt.fields.QuotaManagementList), &defaultKafkaConf)
 			quotaService, _ := factory.GetQuotaService(api.QuotaManagementListQuotaType)
 			kafka := &dbapi.KafkaRequest{
 				Owner:          "username",
@@ -594,6 +625,105 @@ func Test_QuotaManagementListReserveQuota(t *testing.T) {
 		})
 	}
 }
+func Test_QuotaManagementListResizeQuota(t *testing.T) {
+	orgQuotaList := func(maxAllowedInstances int) *quota_management.QuotaManagementListConfig {
+		return &quota_management.QuotaManagementListConfig{
+			EnableInstanceLimitControl: true,
+			QuotaList: quota_management.RegisteredUsersListConfiguration{
+				Organisations: quota_management.OrganisationList{
+					quota_management.Organisation{
+						Id:                  "org-id",
+						MaxAllowedInstances: maxAllowedInstances,
+						AnyUser:             true,
+					},
+				},
+			},
+		}
+	}
+	existingKafkas := func() {
+		mocket.Catcher.Reset()
+		mocket.Catcher.NewMock().
+			WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type = $1`).
+			WithReply(converters.ConvertKafkaRequestList([]*dbapi.KafkaRequest{
+				buildKafkaRequest(nil),
+				buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.ID = "another-kafka"
+				}),
+			}))
+		mocket.Catcher.NewMock().WithExecException().WithQueryException()
+	}
+
+	tests := []struct {
+		name                string
+		quotaManagementList *quota_management.QuotaManagementListConfig
+		currentSizeId       string
+		sizeId              string
+		setupFn             func()
+		wantErr             *errors.ServiceError
+	}{
+		{
+			name:                "should not check the quota list when the kafka is shrunk",
+			quotaManagementList: orgQuotaList(1),
+			currentSizeId:       "x2",
+			sizeId:              "x1",
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:                "should not count the streaming units of the resized kafka twice",
+			quotaManagementList: orgQuotaList(3),
+			currentSizeId:       "x1",
+			sizeId:              "x2",
+			setupFn:             existingKafkas,
+		},
+		{
+			name:                "should return an error when the resized kafka exceeds the organisation limit",
+			quotaManagementList: orgQuotaList(2),
+			currentSizeId:       "x1",
+			sizeId:              "x2",
+			setupFn:             existingKafkas,
+			wantErr:             errors.MaximumAllowedInstanceReached(""),
+		},
+		{
+			name:                "should return an error when the size is not supported",
+			quotaManagementList: orgQuotaList(3),
+			currentSizeId:       "x1",
+			sizeId:              "x9",
+			wantErr:             errors.GeneralError(""),
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			factory := NewDefaultQuotaServiceFactory(nil, db.NewMockConnectionFactory(nil), tt.quotaManagementList, quotaListServiceFor(tt.quotaManagementList), &defaultKafkaConf)
+			quotaService, _ := factory.GetQuotaService(api.QuotaManagementListQuotaType)
+			kafka := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.OrganisationId = "org-id"
+				kafkaRequest.SizeId = tt.currentSizeId
+				kafkaRequest.ActualKafkaBillingModel = "standard"
+				kafkaRequest.SubscriptionId = "subscription-id"
+			})
+			subscriptionId, err := quotaService.ResizeQuota(kafka, tt.sizeId)
+			if tt.wantErr != nil {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+			} else {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+				g.Expect(subscriptionId).To(gomega.Equal("subscription-id"))
+			}
+			g.Expect(kafka.SizeId).To(gomega.Equal(tt.currentSizeId))
+		})
+	}
+}
+
 func Test_DefaultQuotaServiceFactory_GetQuotaService(t *testing.T) {
 	type fields struct {
 		QuotaServiceContainer map[api.QuotaType]services.QuotaService
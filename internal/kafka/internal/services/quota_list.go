@@ -0,0 +1,343 @@
+package services
+
+import (
+	"encoding/json"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"gorm.io/gorm"
+)
+
+//go:generate moq -out quota_list_moq.go . QuotaListService
+type QuotaListService interface {
+	// FindOrganisation returns the organisation with the given id of the quota management list. found is false when the
+	// organisation is not in the list.
+	FindOrganisation(organisationID string) (quota_management.Organisation, bool, *errors.ServiceError)
+	// FindServiceAccount returns the service account with the given username of the quota management list. found is false
+	// when the service account is not in the list.
+	FindServiceAccount(username string) (quota_management.Account, bool, *errors.ServiceError)
+	GetOrganisation(organisationID string) (*dbapi.QuotaListOrganisation, *errors.ServiceError)
+	ListOrganisations(listArgs *services.ListArguments) (dbapi.QuotaListOrganisationList, *api.PagingMeta, *errors.ServiceError)
+	// UpsertOrganisation adds the organisation to the quota management list, or replaces the organisation with the same id
+	UpsertOrganisation(org quota_management.Organisation, changedBy string) (*dbapi.QuotaListOrganisation, *errors.ServiceError)
+	DeleteOrganisation(organisationID string, changedBy string) *errors.ServiceError
+	GetServiceAccount(username string) (*dbapi.QuotaListServiceAccount, *errors.ServiceError)
+	ListServiceAccounts(listArgs *services.ListArguments) (dbapi.QuotaListServiceAccountList, *api.PagingMeta, *errors.ServiceError)
+	// UpsertServiceAccount adds the service account to the quota management list, or replaces the service account with the same username
+	UpsertServiceAccount(account quota_management.Account, changedBy string) (*dbapi.QuotaListServiceAccount, *errors.ServiceError)
+	DeleteServiceAccount(username string, changedBy string) *errors.ServiceError
+	// ListChanges returns the changes of the quota management list, most recent first. The changes are filtered by resource
+	// type and id when they are not empty.
+	ListChanges(resourceType string, resourceID string, listArgs *services.ListArguments) (dbapi.QuotaListChangeList, *api.PagingMeta, *errors.ServiceError)
+	// Import adds the organisations and service accounts of the quota list configuration when the quota management list has
+	// never been changed. It returns the number of organisations and service accounts imported.
+	Import(quotaList quota_management.RegisteredUsersListConfiguration, changedBy string) (int, *errors.ServiceError)
+}
+
+func NewQuotaListService(connectionFactory *db.ConnectionFactory) QuotaListService {
+	return &quotaListService{
+		connectionFactory: connectionFactory,
+	}
+}
+
+var _ QuotaListService = &quotaListService{}
+
+type quotaListService struct {
+	connectionFactory *db.ConnectionFactory
+}
+
+func (q *quotaListService) FindOrganisation(organisationID string) (quota_management.Organisation, bool, *errors.ServiceError) {
+	if organisationID == "" {
+		return quota_management.Organisation{}, false, nil
+	}
+
+	entry, svcErr := q.GetOrganisation(organisationID)
+	if svcErr != nil {
+		if svcErr.Is404() {
+			return quota_management.Organisation{}, false, nil
+		}
+		return quota_management.Organisation{}, false, svcErr
+	}
+
+	org, err := entry.Organisation()
+	if err != nil {
+		return quota_management.Organisation{}, false, errors.NewWithCause(errors.ErrorGeneral, err, "failed to read organisation %q of the quota management list", organisationID)
+	}
+	return org, true, nil
+}
+
+func (q *quotaListService) FindServiceAccount(username string) (quota_management.Account, bool, *errors.ServiceError) {
+	if username == "" {
+		return quota_management.Account{}, false, nil
+	}
+
+	entry, svcErr := q.GetServiceAccount(username)
+	if svcErr != nil {
+		if svcErr.Is404() {
+			return quota_management.Account{}, false, nil
+		}
+		return quota_management.Account{}, false, svcErr
+	}
+
+	account, err := entry.Account()
+	if err != nil {
+		return quota_management.Account{}, false, errors.NewWithCause(errors.ErrorGeneral, err, "failed to read service account %q of the quota management list", username)
+	}
+	return account, true, nil
+}
+
+func (q *quotaListService) GetOrganisation(organisationID string) (*dbapi.QuotaListOrganisation, *errors.ServiceError) {
+	if organisationID == "" {
+		return nil, errors.Validation("organisation id is undefined")
+	}
+
+	var entry dbapi.QuotaListOrganisation
+	if err := q.connectionFactory.New().Where("organisation_id = ?", organisationID).First(&entry).Error; err != nil {
+		return nil, services.HandleGetError("QuotaListOrganisation", "organisation_id", organisationID, err)
+	}
+	return &entry, nil
+}
+
+func (q *quotaListService) ListOrganisations(listArgs *services.ListArguments) (dbapi.QuotaListOrganisationList, *api.PagingMeta, *errors.ServiceError) {
+	var entries dbapi.QuotaListOrganisationList
+	pagingMeta, err := listQuotaListEntries(q.connectionFactory.New(), &entries, "organisation_id", listArgs)
+	if err != nil {
+		return entries, pagingMeta, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the organisations of the quota management list")
+	}
+	return entries, pagingMeta, nil
+}
+
+func (q *quotaListService) UpsertOrganisation(org quota_management.Organisation, changedBy string) (*dbapi.QuotaListOrganisation, *errors.ServiceError) {
+	if org.Id == "" {
+		return nil, errors.Validation("organisation id is undefined")
+	}
+	if err := dbapi.ValidateGrantedQuota(org.GrantedQuota); err != nil {
+		return nil, errors.NewWithCause(errors.ErrorValidation, err, "invalid granted quota: %s", err.Error())
+	}
+
+	entry, err := dbapi.NewQuotaListOrganisation(org)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to convert organisation %q", org.Id)
+	}
+
+	if err := q.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		var existing dbapi.QuotaListOrganisationList
+		if err := tx.Where("organisation_id = ?", org.Id).Limit(1).Find(&existing).Error; err != nil {
+			return err
+		}
+
+		action := dbapi.QuotaListChangeCreated
+		if len(existing) > 0 {
+			action = dbapi.QuotaListChangeUpdated
+			entry.Meta = existing[0].Meta
+		}
+		if err := tx.Save(entry).Error; err != nil {
+			return err
+		}
+		return recordQuotaListChange(tx, dbapi.QuotaListResourceOrganisation, org.Id, action, changedBy, entry)
+	}); err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to save organisation %q in the quota management list", org.Id)
+	}
+
+	return entry, nil
+}
+
+func (q *quotaListService) DeleteOrganisation(organisationID string, changedBy string) *errors.ServiceError {
+	entry, svcErr := q.GetOrganisation(organisationID)
+	if svcErr != nil {
+		return svcErr
+	}
+
+	if err := q.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		// entries are hard deleted so that they can be added again, their history is kept in the changes
+		if err := tx.Unscoped().Delete(entry).Error; err != nil {
+			return err
+		}
+		return recordQuotaListChange(tx, dbapi.QuotaListResourceOrganisation, organisationID, dbapi.QuotaListChangeDeleted, changedBy, entry)
+	}); err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to delete organisation %q from the quota management list", organisationID)
+	}
+
+	return nil
+}
+
+func (q *quotaListService) GetServiceAccount(username string) (*dbapi.QuotaListServiceAccount, *errors.ServiceError) {
+	if username == "" {
+		return nil, errors.Validation("service account username is undefined")
+	}
+
+	var entry dbapi.QuotaListServiceAccount
+	if err := q.connectionFactory.New().Where("username = ?", username).First(&entry).Error; err != nil {
+		return nil, services.HandleGetError("QuotaListServiceAccount", "username", username, err)
+	}
+	return &entry, nil
+}
+
+func (q *quotaListService) ListServiceAccounts(listArgs *services.ListArguments) (dbapi.QuotaListServiceAccountList, *api.PagingMeta, *errors.ServiceError) {
+	var entries dbapi.QuotaListServiceAccountList
+	pagingMeta, err := listQuotaListEntries(q.connectionFactory.New(), &entries, "username", listArgs)
+	if err != nil {
+		return entries, pagingMeta, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the service accounts of the quota management list")
+	}
+	return entries, pagingMeta, nil
+}
+
+func (q *quotaListService) UpsertServiceAccount(account quota_management.Account, changedBy string) (*dbapi.QuotaListServiceAccount, *errors.ServiceError) {
+	if account.Username == "" {
+		return nil, errors.Validation("service account username is undefined")
+	}
+	if err := dbapi.ValidateGrantedQuota(account.GrantedQuota); err != nil {
+		return nil, errors.NewWithCause(errors.ErrorValidation, err, "invalid granted quota: %s", err.Error())
+	}
+
+	entry, err := dbapi.NewQuotaListServiceAccount(account)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to convert service account %q", account.Username)
+	}
+
+	if err := q.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		var existing dbapi.QuotaListServiceAccountList
+		if err := tx.Where("username = ?", account.Username).Limit(1).Find(&existing).Error; err != nil {
+			return err
+		}
+
+		action := dbapi.QuotaListChangeCreated
+		if len(existing) > 0 {
+			action = dbapi.QuotaListChangeUpdated
+			entry.Meta = existing[0].Meta
+		}
+		if err := tx.Save(entry).Error; err != nil {
+			return err
+		}
+		return recordQuotaListChange(tx, dbapi.QuotaListResourceServiceAccount, account.Username, action, changedBy, entry)
+	}); err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to save service account %q in the quota management list", account.Username)
+	}
+
+	return entry, nil
+}
+
+func (q *quotaListService) DeleteServiceAccount(username string, changedBy string) *errors.ServiceError {
+	entry, svcErr := q.GetServiceAccount(username)
+	if svcErr != nil {
+		return svcErr
+	}
+
+	if err := q.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Delete(entry).Error; err != nil {
+			return err
+		}
+		return recordQuotaListChange(tx, dbapi.QuotaListResourceServiceAccount, username, dbapi.QuotaListChangeDeleted, changedBy, entry)
+	}); err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to delete service account %q from the quota management list", username)
+	}
+
+	return nil
+}
+
+func (q *quotaListService) ListChanges(resourceType string, resourceID string, listArgs *services.ListArguments) (dbapi.QuotaListChangeList, *api.PagingMeta, *errors.ServiceError) {
+	var changes dbapi.QuotaListChangeList
+	dbConn := q.connectionFactory.New()
+	if resourceType != "" {
+		dbConn = dbConn.Where("resource_type = ?", resourceType)
+	}
+	if resourceID != "" {
+		dbConn = dbConn.Where("resource_id = ?", resourceID)
+	}
+
+	pagingMeta, err := listQuotaListEntries(dbConn, &changes, "created_at desc", listArgs)
+	if err != nil {
+		return changes, pagingMeta, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the changes of the quota management list")
+	}
+	return changes, pagingMeta, nil
+}
+
+func (q *quotaListService) Import(quotaList quota_management.RegisteredUsersListConfiguration, changedBy string) (int, *errors.ServiceError) {
+	imported := 0
+	if err := q.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		// the configuration is only imported once, the list is then managed through the admin API. Any recorded change,
+		// including the deletion of all the entries of the list, means that the list is already managed.
+		var changes int64
+		if err := tx.Model(&dbapi.QuotaListChange{}).Count(&changes).Error; err != nil {
+			return err
+		}
+		if changes > 0 {
+			return nil
+		}
+
+		for _, org := range quotaList.Organisations {
+			entry, err := dbapi.NewQuotaListOrganisation(org)
+			if err != nil {
+				return err
+			}
+			if err := tx.Create(entry).Error; err != nil {
+				return err
+			}
+			if err := recordQuotaListChange(tx, dbapi.QuotaListResourceOrganisation, org.Id, dbapi.QuotaListChangeCreated, changedBy, entry); err != nil {
+				return err
+			}
+			imported++
+		}
+
+		for _, account := range quotaList.ServiceAccounts {
+			entry, err := dbapi.NewQuotaListServiceAccount(account)
+			if err != nil {
+				return err
+			}
+			if err := tx.Create(entry).Error; err != nil {
+				return err
+			}
+			if err := recordQuotaListChange(tx, dbapi.QuotaListResourceServiceAccount, account.Username, dbapi.QuotaListChangeCreated, changedBy, entry); err != nil {
+				return err
+			}
+			imported++
+		}
+
+		return nil
+	}); err != nil {
+		return 0, errors.NewWithCause(errors.ErrorGeneral, err, "failed to import the quota management list configuration")
+	}
+
+	return imported, nil
+}
+
+// listQuotaListEntries returns a page of the entries of the quota management list selected by the query, in the given order
+func listQuotaListEntries(dbConn *gorm.DB, entries interface{}, orderBy string, listArgs *services.ListArguments) (*api.PagingMeta, error) {
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	total := int64(pagingMeta.Total)
+	if err := dbConn.Model(entries).Count(&total).Error; err != nil {
+		return pagingMeta, err
+	}
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+
+	err := dbConn.Order(orderBy).
+		Offset((pagingMeta.Page - 1) * pagingMeta.Size).
+		Limit(pagingMeta.Size).
+		Find(entries).Error
+	return pagingMeta, err
+}
+
+func recordQuotaListChange(tx *gorm.DB, resourceType string, resourceID string, action string, changedBy string, entry interface{}) error {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&dbapi.QuotaListChange{
+		ResourceType: resourceType,
+		ResourceId:   resourceID,
+		Action:       action,
+		ChangedBy:    changedBy,
+		Value:        api.JSON(value),
+	}).Error
+}
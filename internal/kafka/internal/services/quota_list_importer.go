@@ -0,0 +1,37 @@
+package services
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
+	"github.com/golang/glog"
+)
+
+var _ environments.BootService = &QuotaListImporter{}
+
+// QuotaListImporter imports the organisations and service accounts of the quota management list configuration file into the
+// database on boot. The file is only imported while no change of the quota management list has been recorded: the list is
+// then managed through the admin API and later changes of the file are ignored.
+type QuotaListImporter struct {
+	quotaListService          QuotaListService
+	quotaManagementListConfig *quota_management.QuotaManagementListConfig
+}
+
+func NewQuotaListImporter(quotaListService QuotaListService, quotaManagementListConfig *quota_management.QuotaManagementListConfig) *QuotaListImporter {
+	return &QuotaListImporter{
+		quotaListService:          quotaListService,
+		quotaManagementListConfig: quotaManagementListConfig,
+	}
+}
+
+func (i *QuotaListImporter) Start() {
+	imported, err := i.quotaListService.Import(i.quotaManagementListConfig.QuotaList, i.quotaManagementListConfig.QuotaListConfigFile)
+	if err != nil {
+		// the quota checks would otherwise run against an empty quota management list
+		glog.Fatalf("failed to import quota management list configuration file '%s': %v", i.quotaManagementListConfig.QuotaListConfigFile, err)
+	}
+	if imported > 0 {
+		glog.Infof("imported %d organisations and service accounts from quota management list configuration file '%s'", imported, i.quotaManagementListConfig.QuotaListConfigFile)
+	}
+}
+
+func (i *QuotaListImporter) Stop() {}
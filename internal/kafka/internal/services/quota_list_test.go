@@ -0,0 +1,191 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_quotaListService_FindOrganisation(t *testing.T) {
+	tests := []struct {
+		name      string
+		orgId     string
+		wantFound bool
+		want      quota_management.Organisation
+		wantErr   bool
+		setupFn   func()
+	}{
+		{
+			name:    "should not look up an undefined organisation",
+			orgId:   "",
+			setupFn: func() { mocket.Catcher.Reset() },
+		},
+		{
+			name:    "should return an error when the query fails",
+			orgId:   "org-id",
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "quota_list_organisations"`).WithQueryException()
+			},
+		},
+		{
+			name:  "should not find an organisation that is not in the list",
+			orgId: "org-id",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "quota_list_organisations"`).WithReply([]map[string]interface{}{})
+			},
+		},
+		{
+			name:      "should return the organisation with its registered users and granted quota",
+			orgId:     "org-id",
+			wantFound: true,
+			want: quota_management.Organisation{
+				Id:                  "org-id",
+				MaxAllowedInstances: 3,
+				RegisteredUsers:     quota_management.AccountList{{Username: "user-1"}},
+				GrantedQuota: quota_management.QuotaList{
+					{
+						InstanceTypeID: "standard",
+						KafkaBillingModels: quota_management.BillingModelList{
+							{Id: "enterprise", MaxAllowedInstances: 2},
+						},
+					},
+				},
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "quota_list_organisations"`).WithReply([]map[string]interface{}{
+					{
+						"id":                    "entry-id",
+						"organisation_id":       "org-id",
+						"max_allowed_instances": 3,
+						"registered_users":      []byte(`["user-1"]`),
+						"granted_quota":         []byte(`[{"instance_type_id": "standard", "kafka_billing_models": [{"id": "enterprise", "max_allowed_instances": 2}]}]`),
+					},
+				})
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			q := NewQuotaListService(db.NewMockConnectionFactory(nil))
+			org, found, err := q.FindOrganisation(tt.orgId)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(found).To(gomega.Equal(tt.wantFound))
+			if tt.wantFound {
+				g.Expect(org).To(gomega.Equal(tt.want))
+			}
+		})
+	}
+}
+
+func Test_quotaListService_UpsertServiceAccount(t *testing.T) {
+	tests := []struct {
+		name    string
+		account quota_management.Account
+		wantErr bool
+	}{
+		{
+			name:    "should reject a service account without username",
+			account: quota_management.Account{},
+			wantErr: true,
+		},
+		{
+			name: "should reject a granted quota without instance type",
+			account: quota_management.Account{
+				Username:     "service-account",
+				GrantedQuota: quota_management.QuotaList{{InstanceTypeID: ""}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should reject a negative limit",
+			account: quota_management.Account{
+				Username: "service-account",
+				GrantedQuota: quota_management.QuotaList{
+					{
+						InstanceTypeID:     "standard",
+						KafkaBillingModels: quota_management.BillingModelList{{Id: "standard", MaxAllowedInstances: -1}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			q := NewQuotaListService(db.NewMockConnectionFactory(nil))
+			_, err := q.UpsertServiceAccount(tt.account, "admin")
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				g.Expect(err.HttpCode).To(gomega.Equal(http.StatusBadRequest))
+			}
+		})
+	}
+}
+
+func Test_quotaListService_Import(t *testing.T) {
+	quotaList := quota_management.RegisteredUsersListConfiguration{
+		Organisations:   quota_management.OrganisationList{{Id: "org-id", MaxAllowedInstances: 1}},
+		ServiceAccounts: quota_management.AccountList{{Username: "service-account", MaxAllowedInstances: 1}},
+	}
+	tests := []struct {
+		name         string
+		wantImported int
+		wantErr      bool
+		setupFn      func()
+	}{
+		{
+			name:    "should return an error when the changes can't be counted",
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT count(1) FROM "quota_list_changes"`).WithQueryException()
+			},
+		},
+		{
+			name: "should not import the configuration when the list has already been changed",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT count(1) FROM "quota_list_changes"`).WithReply([]map[string]interface{}{{"count": 1}})
+			},
+		},
+		{
+			name:         "should import the configuration when the list has never been changed",
+			wantImported: 2,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT count(1) FROM "quota_list_changes"`).WithReply([]map[string]interface{}{{"count": 0}})
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			q := NewQuotaListService(db.NewMockConnectionFactory(nil))
+			imported, err := q.Import(quotaList, "config/quota-management-list-configuration.yaml")
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(imported).To(gomega.Equal(tt.wantImported))
+		})
+	}
+}
+
+func Test_quotaListService_ListOrganisations(t *testing.T) {
+	g := gomega.NewWithT(t)
+	mocket.Catcher.Reset().NewMock().WithQuery(`SELECT count(1) FROM "quota_list_organisations"`).WithQueryException()
+	q := NewQuotaListService(db.NewMockConnectionFactory(nil))
+	_, _, err := q.ListOrganisations(&services.ListArguments{Page: 1, Size: 10})
+	g.Expect(err).ToNot(gomega.BeNil())
+}
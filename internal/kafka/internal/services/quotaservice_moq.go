@@ -30,6 +30,9 @@ var _ QuotaService = &QuotaServiceMock{}
 //			ReserveQuotaFunc: func(kafka *dbapi.KafkaRequest) (string, *apiErrors.ServiceError) {
 //				panic("mock out the ReserveQuota method")
 //			},
+//			ResizeQuotaFunc: func(kafka *dbapi.KafkaRequest, sizeId string) (string, *apiErrors.ServiceError) {
+//				panic("mock out the ResizeQuota method")
+//			},
 //			ValidateBillingAccountFunc: func(organisationId string, instanceType types.KafkaInstanceType, billingCloudAccountId string, marketplace *string) *apiErrors.ServiceError {
 //				panic("mock out the ValidateBillingAccount method")
 //			},
@@ -49,6 +52,9 @@ type QuotaServiceMock struct {
 	// ReserveQuotaFunc mocks the ReserveQuota method.
 	ReserveQuotaFunc func(kafka *dbapi.KafkaRequest) (string, *apiErrors.ServiceError)
 
+	// ResizeQuotaFunc mocks the ResizeQuota method.
+	ResizeQuotaFunc func(kafka *dbapi.KafkaRequest, sizeId string) (string, *apiErrors.ServiceError)
+
 	// ValidateBillingAccountFunc mocks the ValidateBillingAccount method.
 	ValidateBillingAccountFunc func(organisationId string, instanceType types.KafkaInstanceType, billingCloudAccountId string, marketplace *string) *apiErrors.ServiceError
 
@@ -75,6 +81,13 @@ type QuotaServiceMock struct {
 			// Kafka is the kafka argument value.
 			Kafka *dbapi.KafkaRequest
 		}
+		// ResizeQuota holds details about calls to the ResizeQuota method.
+		ResizeQuota []struct {
+			// Kafka is the kafka argument value.
+			Kafka *dbapi.KafkaRequest
+			// SizeId is the sizeId argument value.
+			SizeId string
+		}
 		// ValidateBillingAccount holds details about calls to the ValidateBillingAccount method.
 		ValidateBillingAccount []struct {
 			// OrganisationId is the organisationId argument value.
@@ -90,6 +103,7 @@ type QuotaServiceMock struct {
 	lockCheckIfQuotaIsDefinedForInstanceType sync.RWMutex
 	lockDeleteQuota                          sync.RWMutex
 	lockReserveQuota                         sync.RWMutex
+	lockResizeQuota                          sync.RWMutex
 	lockValidateBillingAccount               sync.RWMutex
 }
 
@@ -201,6 +215,42 @@ func (mock *QuotaServiceMock) ReserveQuotaCalls() []struct {
 	return calls
 }
 
+// ResizeQuota calls ResizeQuotaFunc.
+func (mock *QuotaServiceMock) ResizeQuota(kafka *dbapi.KafkaRequest, sizeId string) (string, *apiErrors.ServiceError) {
+	if mock.ResizeQuotaFunc == nil {
+		panic("QuotaServiceMock.ResizeQuotaFunc: method is nil but QuotaService.ResizeQuota was just called")
+	}
+	callInfo := struct {
+		Kafka  *dbapi.KafkaRequest
+		SizeId string
+	}{
+		Kafka:  kafka,
+		SizeId: sizeId,
+	}
+	mock.lockResizeQuota.Lock()
+	mock.calls.ResizeQuota = append(mock.calls.ResizeQuota, callInfo)
+	mock.lockResizeQuota.Unlock()
+	return mock.ResizeQuotaFunc(kafka, sizeId)
+}
+
+// ResizeQuotaCalls gets all the calls that were made to ResizeQuota.
+// Check the length with:
+//
+//	len(mockedQuotaService.ResizeQuotaCalls())
+func (mock *QuotaServiceMock) ResizeQuotaCalls() []struct {
+	Kafka  *dbapi.KafkaRequest
+	SizeId string
+} {
+	var calls []struct {
+		Kafka  *dbapi.KafkaRequest
+		SizeId string
+	}
+	mock.lockResizeQuota.RLock()
+	calls = mock.calls.ResizeQuota
+	mock.lockResizeQuota.RUnlock()
+	return calls
+}
+
 // ValidateBillingAccount calls ValidateBillingAccountFunc.
 func (mock *QuotaServiceMock) ValidateBillingAccount(organisationId string, instanceType types.KafkaInstanceType, billingCloudAccountId string, marketplace *string) *apiErrors.ServiceError {
 	if mock.ValidateBillingAccountFunc == nil {
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+const (
+	UtilizationPlacementScore              = "utilization"
+	OrganisationAntiAffinityPlacementScore = "organisation_anti_affinity"
+	PreferredClusterPlacementScore         = "preferred_cluster"
+	ZoneBalancePlacementScore              = "zone_balance"
+)
+
+// ScoredClusterPlacement places a kafka on the eligible cluster with the highest weighted score.
+// A cluster is eligible when it passes the same capacity checks as the first fit strategies of the configured
+// data plane scaling mode. Eligible clusters are then scored between 0 and 1 on:
+//   - utilization: how full the cluster would be once the kafka is placed. Higher is better when bin packing, lower is better when spreading
+//   - organisation anti affinity: how few kafkas of the same organisation the cluster already hosts
+//   - preferred cluster: whether the cluster is one of the preferred clusters of the organisation
+//   - zone balance: how few kafkas are hosted in the zone of the cluster compared to the other zones
+//
+// Ties are broken by the order in which the clusters are returned by the ClusterService.
+type ScoredClusterPlacement struct {
+	ClusterService         ClusterService
+	DataplaneClusterConfig *config.DataplaneClusterConfig
+	KafkaConfig            *config.KafkaConfig
+	ClusterPlacementConfig *config.ClusterPlacementConfig
+}
+
+// clusterUsage is the capacity consumed in a cluster and its total capacity. A capacity of 0 means the capacity is unknown
+type clusterUsage struct {
+	used     int
+	capacity int
+}
+
+func (s *ScoredClusterPlacement) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+	decision, err := s.ExplainPlacement(kafka)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Infof("cluster placement decision for kafka %q: %s", kafka.ID, decision.String())
+	return decision.Cluster, nil
+}
+
+func (s *ScoredClusterPlacement) ExplainPlacement(kafka *dbapi.KafkaRequest) (*ClusterPlacementDecision, error) {
+	criteria := FindClusterCriteria{
+		Provider:              kafka.CloudProvider,
+		Region:                kafka.Region,
+		MultiAZ:               kafka.MultiAZ,
+		Status:                api.ClusterReady,
+		SupportedInstanceType: kafka.InstanceType,
+		ExcludedClusterID:     kafka.ClusterID,
+	}
+
+	decision := &ClusterPlacementDecision{
+		Strategy:   config.ScoredClusterPlacementStrategy,
+		Candidates: []ClusterPlacementCandidate{},
+	}
+
+	clusters, err := s.ClusterService.FindAllClusters(criteria)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find all clusters with criteria '%v'", criteria)
+	}
+
+	if len(clusters) == 0 {
+		return decision, nil
+	}
+
+	instanceSize, err := s.KafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get kafka instance size for cluster with criteria '%v'", criteria)
+	}
+
+	usages, err := s.findClusterUsages(clusters, kafka.InstanceType)
+	if err != nil {
+		return nil, err
+	}
+
+	eligibleClusterIDs := []string{}
+	for _, cluster := range clusters {
+		candidate := ClusterPlacementCandidate{ClusterID: cluster.ClusterID, Eligible: true}
+		if reason := s.ineligibilityReason(cluster, usages[cluster.ClusterID], instanceSize.CapacityConsumed); reason != "" {
+			candidate.Eligible = false
+			candidate.Reason = reason
+		} else {
+			eligibleClusterIDs = append(eligibleClusterIDs, cluster.ClusterID)
+		}
+		decision.Candidates = append(decision.Candidates, candidate)
+	}
+
+	if len(eligibleClusterIDs) == 0 {
+		return decision, nil
+	}
+
+	organisationCounts := map[string]int{}
+	if kafka.OrganisationId != "" {
+		counts, err := s.ClusterService.FindKafkaInstanceCountByOrganisation(eligibleClusterIDs, kafka.OrganisationId)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to count kafkas of organisation %q per cluster", kafka.OrganisationId)
+		}
+		for _, count := range counts {
+			organisationCounts[count.Clusterid] = count.Count
+		}
+	}
+
+	zoneScores := s.zoneBalanceScores(clusters, usages)
+	weights := s.ClusterPlacementConfig.Configuration.Weights
+
+	var bestCandidate *ClusterPlacementCandidate
+	for i := range decision.Candidates {
+		candidate := &decision.Candidates[i]
+		if !candidate.Eligible {
+			continue
+		}
+
+		candidate.Scores = map[string]float64{
+			UtilizationPlacementScore:              s.utilizationScore(usages[candidate.ClusterID], instanceSize.CapacityConsumed),
+			OrganisationAntiAffinityPlacementScore: 1 / float64(1+organisationCounts[candidate.ClusterID]),
+			PreferredClusterPlacementScore:         0,
+			ZoneBalancePlacementScore:              zoneScores[candidate.ClusterID],
+		}
+		if s.ClusterPlacementConfig.IsPreferredCluster(kafka.OrganisationId, candidate.ClusterID) {
+			candidate.Scores[PreferredClusterPlacementScore] = 1
+		}
+
+		candidate.Score = weights.Utilization*candidate.Scores[UtilizationPlacementScore] +
+			weights.OrganisationAntiAffinity*candidate.Scores[OrganisationAntiAffinityPlacementScore] +
+			weights.PreferredCluster*candidate.Scores[PreferredClusterPlacementScore] +
+			weights.ZoneBalance*candidate.Scores[ZoneBalancePlacementScore]
+
+		if bestCandidate == nil || candidate.Score > bestCandidate.Score {
+			bestCandidate = candidate
+		}
+	}
+
+	decision.Cluster = searchClusterObjInArray(clusters, bestCandidate.ClusterID)
+	return decision, nil
+}
+
+func (s *ScoredClusterPlacement) FitsCurrentCluster(kafka *dbapi.KafkaRequest, sizeId string) (bool, error) {
+	delta, err := resizeCapacityDelta(s.KafkaConfig, kafka, sizeId)
+	if err != nil {
+		return false, err
+	}
+	if delta <= 0 {
+		return true, nil
+	}
+
+	cluster, svcErr := s.ClusterService.FindClusterByID(kafka.ClusterID)
+	if svcErr != nil {
+		return false, errors.Wrapf(svcErr, "failed to find cluster %q", kafka.ClusterID)
+	}
+	if cluster == nil {
+		return false, nil
+	}
+
+	usages, err := s.findClusterUsages([]*api.Cluster{cluster}, kafka.InstanceType)
+	if err != nil {
+		return false, err
+	}
+
+	return s.ineligibilityReason(cluster, usages[cluster.ClusterID], delta) == "", nil
+}
+
+// findClusterUsages returns the usage of each cluster. When the data plane is manually scaled, the usage is the
+// capacity consumed by the kafkas in the cluster against the configured kafka instance limit. Otherwise, it is the
+// number of streaming units of the instance type used in the cluster against the maximum reported by the cluster.
+func (s *ScoredClusterPlacement) findClusterUsages(clusters []*api.Cluster, instanceType string) (map[string]clusterUsage, error) {
+	usages := map[string]clusterUsage{}
+
+	if s.DataplaneClusterConfig.IsDataPlaneManualScalingEnabled() {
+		clusterIDs := make([]string, 0, len(clusters))
+		for _, cluster := range clusters {
+			clusterIDs = append(clusterIDs, cluster.ClusterID)
+		}
+
+		counts, err := s.ClusterService.FindKafkaInstanceCount(clusterIDs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find cluster kafka instance count for clusters '%v'", clusterIDs)
+		}
+
+		for _, count := range counts {
+			usages[count.Clusterid] = clusterUsage{used: count.Count}
+		}
+		for _, cluster := range clusters {
+			usage := usages[cluster.ClusterID]
+			if limit, limited := s.DataplaneClusterConfig.ClusterConfig.GetKafkaInstanceLimit(cluster.ClusterID); limited {
+				usage.capacity = limit
+			}
+			usages[cluster.ClusterID] = usage
+		}
+
+		return usages, nil
+	}
+
+	streamingUnitCounts, err := s.ClusterService.FindStreamingUnitCountByClusterAndInstanceType()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get count of streaming units by cluster and instance type")
+	}
+
+	for _, cluster := range clusters {
+		usages[cluster.ClusterID] = clusterUsage{
+			used:     streamingUnitCounts.GetStreamingUnitCountForClusterAndInstanceType(cluster.ClusterID, instanceType),
+			capacity: int(cluster.RetrieveDynamicCapacityInfo()[instanceType].MaxUnits),
+		}
+	}
+
+	return usages, nil
+}
+
+// ineligibilityReason returns why the kafka cannot be placed on the cluster, or an empty string if it can
+func (s *ScoredClusterPlacement) ineligibilityReason(cluster *api.Cluster, usage clusterUsage, capacityConsumed int) string {
+	switch {
+	case s.DataplaneClusterConfig.IsDataPlaneManualScalingEnabled():
+		if !s.DataplaneClusterConfig.ClusterConfig.IsClusterSchedulable(cluster.ClusterID) {
+			return "cluster is not schedulable"
+		}
+		if !s.DataplaneClusterConfig.ClusterConfig.IsNumberOfKafkaWithinClusterLimit(cluster.ClusterID, usage.used+capacityConsumed) {
+			return fmt.Sprintf("kafka instance limit reached: %d of %d used", usage.used, usage.capacity)
+		}
+	case s.DataplaneClusterConfig.IsDataPlaneAutoScalingEnabled():
+		if usage.used+capacityConsumed > usage.capacity {
+			return fmt.Sprintf("not enough streaming units left: %d of %d used", usage.used, usage.capacity)
+		}
+	}
+	return ""
+}
+
+func (s *ScoredClusterPlacement) utilizationScore(usage clusterUsage, capacityConsumed int) float64 {
+	utilization := 0.0
+	if usage.capacity > 0 {
+		utilization = float64(usage.used+capacityConsumed) / float64(usage.capacity)
+		if utilization > 1 {
+			utilization = 1
+		}
+	}
+
+	if s.ClusterPlacementConfig.Configuration.Mode == config.SpreadPlacementMode {
+		return 1 - utilization
+	}
+	return utilization
+}
+
+// zoneBalanceScores scores each cluster based on the usage of its zone. The zone with the lowest usage scores 1,
+// the zone with the highest usage scores 0. All clusters score 1 when every zone has the same usage.
+func (s *ScoredClusterPlacement) zoneBalanceScores(clusters []*api.Cluster, usages map[string]clusterUsage) map[string]float64 {
+	zoneUsages := map[string]int{}
+	for _, cluster := range clusters {
+		zoneUsages[s.ClusterPlacementConfig.GetClusterZone(cluster.ClusterID)] += usages[cluster.ClusterID].used
+	}
+
+	minUsage, maxUsage := -1, 0
+	for _, used := range zoneUsages {
+		if minUsage == -1 || used < minUsage {
+			minUsage = used
+		}
+		if used > maxUsage {
+			maxUsage = used
+		}
+	}
+
+	scores := map[string]float64{}
+	for _, cluster := range clusters {
+		if maxUsage == minUsage {
+			scores[cluster.ClusterID] = 1
+			continue
+		}
+		used := zoneUsages[s.ClusterPlacementConfig.GetClusterZone(cluster.ClusterID)]
+		scores[cluster.ClusterID] = float64(maxUsage-used) / float64(maxUsage-minUsage)
+	}
+
+	return scores
+}
+
+// String returns a single line summary of the decision, suitable for logging
+func (d *ClusterPlacementDecision) String() string {
+	selected := "none"
+	if d.Cluster != nil {
+		selected = d.Cluster.ClusterID
+	}
+
+	candidates := make([]string, 0, len(d.Candidates))
+	for _, candidate := range d.Candidates {
+		if candidate.Eligible {
+			candidates = append(candidates, fmt.Sprintf("%s=%.3f %v", candidate.ClusterID, candidate.Score, candidate.Scores))
+		} else {
+			candidates = append(candidates, fmt.Sprintf("%s=ineligible (%s)", candidate.ClusterID, candidate.Reason))
+		}
+	}
+
+	return fmt.Sprintf("strategy=%s selected=%s candidates=[%s]", d.Strategy, selected, strings.Join(candidates, ", "))
+}
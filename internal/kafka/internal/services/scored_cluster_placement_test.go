@@ -0,0 +1,390 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	mockkafkas "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+const (
+	almostFullClusterID  = "almost-full-cluster"
+	almostEmptyClusterID = "almost-empty-cluster"
+	fullClusterID        = "full-cluster"
+	placementTestOrgID   = "placement-org"
+)
+
+func buildScoredPlacementTestClusters() []*api.Cluster {
+	return []*api.Cluster{
+		{ClusterID: almostFullClusterID, DynamicCapacityInfo: api.JSON([]byte(`{"standard":{"max_nodes":10,"max_units":10,"remaining_units":2}}`))},
+		{ClusterID: almostEmptyClusterID, DynamicCapacityInfo: api.JSON([]byte(`{"standard":{"max_nodes":10,"max_units":10,"remaining_units":8}}`))},
+		{ClusterID: fullClusterID, DynamicCapacityInfo: api.JSON([]byte(`{"standard":{"max_nodes":10,"max_units":10,"remaining_units":0}}`))},
+	}
+}
+
+func buildScoredPlacementTestClusterService(organisationCounts map[string]int) *ClusterServiceMock {
+	return &ClusterServiceMock{
+		FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+			return buildScoredPlacementTestClusters(), nil
+		},
+		FindStreamingUnitCountByClusterAndInstanceTypeFunc: func() (KafkaStreamingUnitCountPerClusterList, error) {
+			return KafkaStreamingUnitCountPerClusterList{
+				{ClusterId: almostFullClusterID, InstanceType: types.STANDARD.String(), Count: 8},
+				{ClusterId: almostEmptyClusterID, InstanceType: types.STANDARD.String(), Count: 2},
+				{ClusterId: fullClusterID, InstanceType: types.STANDARD.String(), Count: 10},
+			}, nil
+		},
+		FindKafkaInstanceCountByOrganisationFunc: func(clusterIDs []string, organisationID string) ([]ResKafkaInstanceCount, error) {
+			res := []ResKafkaInstanceCount{}
+			for _, clusterID := range clusterIDs {
+				res = append(res, ResKafkaInstanceCount{Clusterid: clusterID, Count: organisationCounts[clusterID]})
+			}
+			return res, nil
+		},
+	}
+}
+
+func buildScoredPlacementTestKafkaConfig() *config.KafkaConfig {
+	return &config.KafkaConfig{
+		SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+			Configuration: config.SupportedKafkaInstanceTypesConfig{
+				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+					{
+						Id: types.STANDARD.String(),
+						Sizes: []config.KafkaInstanceSize{
+							{
+								Id:               "x1",
+								CapacityConsumed: 1,
+							},
+							{
+								Id:               "x2",
+								CapacityConsumed: 3,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildScoredPlacementTestConfig(modifyFn func(configuration *config.ClusterPlacementConfiguration)) *config.ClusterPlacementConfig {
+	placementConfig := config.NewClusterPlacementConfig()
+	placementConfig.Strategy = config.ScoredClusterPlacementStrategy
+	placementConfig.Configuration.Weights = config.ClusterPlacementWeights{Utilization: 1}
+	if modifyFn != nil {
+		modifyFn(&placementConfig.Configuration)
+	}
+	return placementConfig
+}
+
+func TestScoredClusterPlacement_FindCluster(t *testing.T) {
+	autoScalingConfig := config.NewDataplaneClusterConfig()
+	autoScalingConfig.DataPlaneClusterScalingType = config.AutoScaling
+
+	type fields struct {
+		ClusterService         ClusterService
+		DataplaneClusterConfig *config.DataplaneClusterConfig
+		ClusterPlacementConfig *config.ClusterPlacementConfig
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		wantClusterID string
+		wantErr       bool
+	}{
+		{
+			name: "should select the most utilised cluster with remaining capacity when bin packing",
+			fields: fields{
+				ClusterService:         buildScoredPlacementTestClusterService(nil),
+				DataplaneClusterConfig: autoScalingConfig,
+				ClusterPlacementConfig: buildScoredPlacementTestConfig(nil),
+			},
+			wantClusterID: almostFullClusterID,
+		},
+		{
+			name: "should select the least utilised cluster when spreading",
+			fields: fields{
+				ClusterService:         buildScoredPlacementTestClusterService(nil),
+				DataplaneClusterConfig: autoScalingConfig,
+				ClusterPlacementConfig: buildScoredPlacementTestConfig(func(configuration *config.ClusterPlacementConfiguration) {
+					configuration.Mode = config.SpreadPlacementMode
+				}),
+			},
+			wantClusterID: almostEmptyClusterID,
+		},
+		{
+			name: "should select the preferred cluster of the organisation",
+			fields: fields{
+				ClusterService:         buildScoredPlacementTestClusterService(nil),
+				DataplaneClusterConfig: autoScalingConfig,
+				ClusterPlacementConfig: buildScoredPlacementTestConfig(func(configuration *config.ClusterPlacementConfiguration) {
+					configuration.Weights.PreferredCluster = 5
+					configuration.PreferredClusters = map[string][]string{placementTestOrgID: {almostEmptyClusterID}}
+				}),
+			},
+			wantClusterID: almostEmptyClusterID,
+		},
+		{
+			name: "should avoid the clusters already hosting kafkas of the same organisation",
+			fields: fields{
+				ClusterService:         buildScoredPlacementTestClusterService(map[string]int{almostFullClusterID: 3}),
+				DataplaneClusterConfig: autoScalingConfig,
+				ClusterPlacementConfig: buildScoredPlacementTestConfig(func(configuration *config.ClusterPlacementConfiguration) {
+					configuration.Weights.OrganisationAntiAffinity = 1
+				}),
+			},
+			wantClusterID: almostEmptyClusterID,
+		},
+		{
+			name: "should favour the clusters in the least used zone",
+			fields: fields{
+				ClusterService:         buildScoredPlacementTestClusterService(nil),
+				DataplaneClusterConfig: autoScalingConfig,
+				ClusterPlacementConfig: buildScoredPlacementTestConfig(func(configuration *config.ClusterPlacementConfiguration) {
+					configuration.Weights.ZoneBalance = 2
+					configuration.Zones = map[string]string{
+						almostFullClusterID:  "zone-a",
+						fullClusterID:        "zone-a",
+						almostEmptyClusterID: "zone-b",
+					}
+				}),
+			},
+			wantClusterID: almostEmptyClusterID,
+		},
+		{
+			name: "should return nil when no cluster has remaining capacity",
+			fields: fields{
+				ClusterService: &ClusterServiceMock{
+					FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+						return buildScoredPlacementTestClusters()[2:], nil
+					},
+					FindStreamingUnitCountByClusterAndInstanceTypeFunc: func() (KafkaStreamingUnitCountPerClusterList, error) {
+						return KafkaStreamingUnitCountPerClusterList{
+							{ClusterId: fullClusterID, InstanceType: types.STANDARD.String(), Count: 10},
+						}, nil
+					},
+				},
+				DataplaneClusterConfig: autoScalingConfig,
+				ClusterPlacementConfig: buildScoredPlacementTestConfig(nil),
+			},
+		},
+		{
+			name: "should return nil when no cluster matches the criteria",
+			fields: fields{
+				ClusterService: &ClusterServiceMock{
+					FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+						return nil, nil
+					},
+				},
+				DataplaneClusterConfig: autoScalingConfig,
+				ClusterPlacementConfig: buildScoredPlacementTestConfig(nil),
+			},
+		},
+		{
+			name: "should return an error when the clusters cannot be listed",
+			fields: fields{
+				ClusterService: &ClusterServiceMock{
+					FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+						return nil, errors.New("failed to find clusters")
+					},
+				},
+				DataplaneClusterConfig: autoScalingConfig,
+				ClusterPlacementConfig: buildScoredPlacementTestConfig(nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the kafkas of the organisation cannot be counted",
+			fields: fields{
+				ClusterService: func() ClusterService {
+					clusterService := buildScoredPlacementTestClusterService(nil)
+					clusterService.FindKafkaInstanceCountByOrganisationFunc = func(clusterIDs []string, organisationID string) ([]ResKafkaInstanceCount, error) {
+						return nil, errors.New("failed to count kafkas")
+					}
+					return clusterService
+				}(),
+				DataplaneClusterConfig: autoScalingConfig,
+				ClusterPlacementConfig: buildScoredPlacementTestConfig(nil),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			s := &ScoredClusterPlacement{
+				ClusterService:         tt.fields.ClusterService,
+				DataplaneClusterConfig: tt.fields.DataplaneClusterConfig,
+				KafkaConfig:            buildScoredPlacementTestKafkaConfig(),
+				ClusterPlacementConfig: tt.fields.ClusterPlacementConfig,
+			}
+
+			got, err := s.FindCluster(mockkafkas.BuildKafkaRequest(
+				mockkafkas.With(mockkafkas.INSTANCE_TYPE, types.STANDARD.String()),
+				mockkafkas.With(mockkafkas.SIZE_ID, "x1"),
+				mockkafkas.With(mockkafkas.ORGANISATION_ID, placementTestOrgID),
+			))
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantClusterID == "" {
+				g.Expect(got).To(gomega.BeNil())
+			} else {
+				g.Expect(got).ToNot(gomega.BeNil())
+				g.Expect(got.ClusterID).To(gomega.Equal(tt.wantClusterID))
+			}
+		})
+	}
+}
+
+func TestScoredClusterPlacement_ExplainPlacement(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	manualScalingConfig := config.NewDataplaneClusterConfig()
+	manualScalingConfig.DataPlaneClusterScalingType = config.ManualScaling
+	manualScalingConfig.ClusterConfig = config.NewClusterConfig(config.ClusterList{
+		{ClusterId: almostFullClusterID, Schedulable: true, KafkaInstanceLimit: 10},
+		{ClusterId: almostEmptyClusterID, Schedulable: true, KafkaInstanceLimit: 2},
+		{ClusterId: fullClusterID, Schedulable: false, KafkaInstanceLimit: 10},
+	})
+
+	s := &ScoredClusterPlacement{
+		ClusterService: &ClusterServiceMock{
+			FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+				return buildScoredPlacementTestClusters(), nil
+			},
+			FindKafkaInstanceCountFunc: func(clusterIDs []string) ([]ResKafkaInstanceCount, error) {
+				return []ResKafkaInstanceCount{
+					{Clusterid: almostFullClusterID, Count: 4},
+					{Clusterid: almostEmptyClusterID, Count: 1},
+					{Clusterid: fullClusterID, Count: 0},
+				}, nil
+			},
+		},
+		DataplaneClusterConfig: manualScalingConfig,
+		KafkaConfig:            buildScoredPlacementTestKafkaConfig(),
+		ClusterPlacementConfig: buildScoredPlacementTestConfig(nil),
+	}
+
+	decision, err := s.ExplainPlacement(&dbapi.KafkaRequest{InstanceType: types.STANDARD.String(), SizeId: "x1"})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(decision.Strategy).To(gomega.Equal(config.ScoredClusterPlacementStrategy))
+	g.Expect(decision.Cluster.ClusterID).To(gomega.Equal(almostEmptyClusterID))
+	g.Expect(decision.Candidates).To(gomega.Equal([]ClusterPlacementCandidate{
+		{
+			ClusterID: almostFullClusterID,
+			Eligible:  true,
+			Score:     0.5,
+			Scores: map[string]float64{
+				UtilizationPlacementScore:              0.5,
+				OrganisationAntiAffinityPlacementScore: 1,
+				PreferredClusterPlacementScore:         0,
+				ZoneBalancePlacementScore:              1,
+			},
+		},
+		{
+			ClusterID: almostEmptyClusterID,
+			Eligible:  true,
+			Score:     1,
+			Scores: map[string]float64{
+				UtilizationPlacementScore:              1,
+				OrganisationAntiAffinityPlacementScore: 1,
+				PreferredClusterPlacementScore:         0,
+				ZoneBalancePlacementScore:              1,
+			},
+		},
+		{
+			ClusterID: fullClusterID,
+			Reason:    "cluster is not schedulable",
+		},
+	}))
+}
+
+func TestScoredClusterPlacement_FitsCurrentCluster(t *testing.T) {
+	autoScalingConfig := config.NewDataplaneClusterConfig()
+	autoScalingConfig.DataPlaneClusterScalingType = config.AutoScaling
+
+	tests := []struct {
+		name           string
+		clusterID      string
+		currentSizeId  string
+		sizeId         string
+		clusterService ClusterService
+		want           bool
+		wantErr        bool
+	}{
+		{
+			name:           "should fit a cluster with enough remaining capacity for the resized kafka",
+			clusterID:      almostFullClusterID,
+			currentSizeId:  "x1",
+			sizeId:         "x2",
+			clusterService: buildScoredPlacementTestClusterService(nil),
+			want:           true,
+		},
+		{
+			name:           "should not fit a cluster without enough remaining capacity for the resized kafka",
+			clusterID:      fullClusterID,
+			currentSizeId:  "x1",
+			sizeId:         "x2",
+			clusterService: buildScoredPlacementTestClusterService(nil),
+			want:           false,
+		},
+		{
+			name:          "should always fit the current cluster when the kafka is shrunk",
+			clusterID:     fullClusterID,
+			currentSizeId: "x2",
+			sizeId:        "x1",
+			want:          true,
+		},
+		{
+			name:           "should return an error when the size is not supported",
+			clusterID:      almostFullClusterID,
+			currentSizeId:  "x1",
+			sizeId:         "x9",
+			clusterService: buildScoredPlacementTestClusterService(nil),
+			wantErr:        true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			clusterService := tt.clusterService
+			if clusterService != nil {
+				clusterService.(*ClusterServiceMock).FindClusterByIDFunc = func(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+					for _, cluster := range buildScoredPlacementTestClusters() {
+						if cluster.ClusterID == clusterID {
+							return cluster, nil
+						}
+					}
+					return nil, nil
+				}
+			}
+			s := &ScoredClusterPlacement{
+				ClusterService:         clusterService,
+				DataplaneClusterConfig: autoScalingConfig,
+				KafkaConfig:            buildScoredPlacementTestKafkaConfig(),
+				ClusterPlacementConfig: buildScoredPlacementTestConfig(nil),
+			}
+
+			got, err := s.FitsCurrentCluster(mockkafkas.BuildKafkaRequest(
+				mockkafkas.With(mockkafkas.INSTANCE_TYPE, types.STANDARD.String()),
+				mockkafkas.With(mockkafkas.SIZE_ID, tt.currentSizeId),
+				mockkafkas.With(mockkafkas.CLUSTER_ID, tt.clusterID),
+			), tt.sizeId)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -746,9 +746,9 @@ func (c *ClusterManager) buildResourceSet(cluster api.Cluster) types.ResourceSet
 		kasFleetshardNamespace = kasFleetshardQEAddonNamespace
 	}
 
-	// For standalone clusters, make sure that the namespaces is read from the config
+	// For standalone and EKS clusters, the operators are installed through OLM. Make sure that the namespaces is read from the config
 	// and that they are created before the pull secrets that references them
-	if cluster.ProviderType == api.ClusterProviderStandalone {
+	if cluster.ProviderType == api.ClusterProviderStandalone || cluster.ProviderType == api.ClusterProviderAwsEKS {
 		strimziNamespace = c.DataplaneClusterConfig.StrimziOperatorOLMConfig.Namespace
 		kasFleetshardNamespace = c.DataplaneClusterConfig.KasFleetshardOperatorOLMConfig.Namespace
 		r = append(r, &k8sCoreV1.Namespace{
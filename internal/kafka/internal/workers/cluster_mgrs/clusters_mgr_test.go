@@ -2292,7 +2292,7 @@ func buildResourceSet(observabilityConfig observatorium.ObservabilityConfigurati
 			},
 		},
 	}
-	if cluster.ProviderType == api.ClusterProviderStandalone {
+	if cluster.ProviderType == api.ClusterProviderStandalone || cluster.ProviderType == api.ClusterProviderAwsEKS {
 		strimziNamespace = clusterConfig.StrimziOperatorOLMConfig.Namespace
 		kasFleetshardNamespace = clusterConfig.KasFleetshardOperatorOLMConfig.Namespace
 		resources = append(resources, &k8sCoreV1.Namespace{
@@ -2405,6 +2405,19 @@ func TestClusterManager_reconcileClusterResourceSet(t *testing.T) {
 			},
 			arg: api.Cluster{ClusterID: "test-cluster-id", ProviderType: "standalone"},
 		},
+		{
+			name: "test should pass and resourceset should be created for aws eks clusters",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					ApplyResourcesFunc: func(cluster *api.Cluster, resources types.ResourceSet) *apiErrors.ServiceError {
+						want, _ := buildResourceSet(observabilityConfig, clusterConfig, ingressDNS, cluster)
+						g.Expect(resources).To(gomega.Equal(want))
+						return nil
+					},
+				},
+			},
+			arg: api.Cluster{ClusterID: "test-cluster-id", ProviderType: "aws_eks"},
+		},
 		{
 			name: "should receive error when ApplyResources returns error",
 			fields: fields{
@@ -0,0 +1,102 @@
+package kafka_mgrs
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// MaintenanceWindowKafkaManager represents a kafka manager that periodically applies the upgrades queued until the maintenance window of kafkas.
+type MaintenanceWindowKafkaManager struct {
+	workers.BaseWorker
+	kafkaService             services.KafkaService
+	maintenanceWindowService services.MaintenanceWindowService
+}
+
+// NewMaintenanceWindowKafkaManager creates a new kafka manager to apply queued upgrades inside maintenance windows.
+func NewMaintenanceWindowKafkaManager(kafkaService services.KafkaService, maintenanceWindowService services.MaintenanceWindowService, reconciler workers.Reconciler) *MaintenanceWindowKafkaManager {
+	return &MaintenanceWindowKafkaManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "maintenance_window_kafka",
+			Reconciler: reconciler,
+		},
+		kafkaService:             kafkaService,
+		maintenanceWindowService: maintenanceWindowService,
+	}
+}
+
+// Start initializes the kafka manager to apply queued upgrades.
+func (k *MaintenanceWindowKafkaManager) Start() {
+	k.StartWorker(k)
+}
+
+// Stop causes the process for applying queued upgrades to stop.
+func (k *MaintenanceWindowKafkaManager) Stop() {
+	k.StopWorker(k)
+}
+
+func (k *MaintenanceWindowKafkaManager) Reconcile() []error {
+	glog.Infoln("reconciling kafkas with pending upgrades")
+	var encounteredErrors []error
+
+	kafkas, serviceErr := k.kafkaService.ListKafkasWithPendingUpgrades()
+	if serviceErr != nil {
+		return append(encounteredErrors, errors.Wrap(serviceErr, "failed to list kafkas with pending upgrades"))
+	}
+	glog.Infof("kafkas with pending upgrades count = %d", len(kafkas))
+
+	for _, kafka := range kafkas {
+		glog.V(10).Infof("kafka with pending upgrades id = %s", kafka.ID)
+		if err := k.reconcilePendingUpgrades(kafka); err != nil {
+			encounteredErrors = append(encounteredErrors, errors.Wrapf(err, "failed to reconcile pending upgrades of kafka %s", kafka.ID))
+		}
+	}
+
+	return encounteredErrors
+}
+
+func (k *MaintenanceWindowKafkaManager) reconcilePendingUpgrades(kafka *dbapi.KafkaRequest) error {
+	// wait for any upgrade in progress to complete so that the kas-fleetshard operator rolls out one upgrade at a time
+	if kafka.KafkaUpgrading || kafka.StrimziUpgrading || kafka.KafkaIBPUpgrading {
+		glog.V(10).Infof("kafka %s is being upgraded, pending upgrades will be applied once the upgrade completes", kafka.ID)
+		return nil
+	}
+
+	window, serviceErr := k.maintenanceWindowService.FindForKafka(kafka)
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	// the upgrades are applied right away when the maintenance window has been removed since they were queued
+	if window != nil && !window.Contains(time.Now()) {
+		return nil
+	}
+
+	updates := map[string]interface{}{
+		"pending_kafka_version":     "",
+		"pending_strimzi_version":   "",
+		"pending_kafka_ibp_version": "",
+	}
+	if kafka.PendingKafkaVersion != "" {
+		updates["desired_kafka_version"] = kafka.PendingKafkaVersion
+	}
+	if kafka.PendingStrimziVersion != "" {
+		updates["desired_strimzi_version"] = kafka.PendingStrimziVersion
+	}
+	if kafka.PendingKafkaIBPVersion != "" {
+		updates["desired_kafka_ibp_version"] = kafka.PendingKafkaIBPVersion
+	}
+
+	glog.Infof("applying pending upgrades of kafka %s: strimzi version %q, kafka version %q, kafka IBP version %q", kafka.ID, kafka.PendingStrimziVersion, kafka.PendingKafkaVersion, kafka.PendingKafkaIBPVersion)
+	if err := k.kafkaService.Updates(kafka, updates); err != nil {
+		return errors.Wrap(err, "failed to apply pending upgrades")
+	}
+
+	return nil
+}
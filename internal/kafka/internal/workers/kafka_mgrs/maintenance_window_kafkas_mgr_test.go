@@ -0,0 +1,159 @@
+package kafka_mgrs
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	mockKafkas "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+	svcErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	w "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+)
+
+func Test_MaintenanceWindowKafkaManager_Reconcile(t *testing.T) {
+	now := time.Now().UTC()
+	openWindow := &dbapi.MaintenanceWindow{
+		DayOfWeek:     now.Weekday().String(),
+		StartTime:     fmt.Sprintf("%02d:00", now.Hour()),
+		DurationHours: 2,
+	}
+	closedWindow := &dbapi.MaintenanceWindow{
+		DayOfWeek:     now.AddDate(0, 0, 3).Weekday().String(),
+		StartTime:     "00:00",
+		DurationHours: 1,
+	}
+
+	pendingKafka := func(modifyFn func(kafkaRequest *dbapi.KafkaRequest)) *dbapi.KafkaRequest {
+		return mockKafkas.BuildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.DesiredKafkaVersion = "3.2.0"
+			kafkaRequest.PendingKafkaVersion = "3.3.0"
+			kafkaRequest.PendingStrimziVersion = "strimzi-cluster-operator.v0.30.0-0"
+			if modifyFn != nil {
+				modifyFn(kafkaRequest)
+			}
+		})
+	}
+
+	type fields struct {
+		kafkas                   func() ([]*dbapi.KafkaRequest, *svcErrors.ServiceError)
+		maintenanceWindowService services.MaintenanceWindowService
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		wantErr     bool
+		wantUpdates map[string]interface{}
+	}{
+		{
+			name: "should return an error if listing kafkas with pending upgrades fails",
+			fields: fields{
+				kafkas: func() ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+					return nil, svcErrors.GeneralError("failed to list kafka requests")
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error if finding the maintenance window of a kafka fails",
+			fields: fields{
+				kafkas: func() ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+					return []*dbapi.KafkaRequest{pendingKafka(nil)}, nil
+				},
+				maintenanceWindowService: &services.MaintenanceWindowServiceMock{
+					FindForKafkaFunc: func(kafka *dbapi.KafkaRequest) (*dbapi.MaintenanceWindow, *svcErrors.ServiceError) {
+						return nil, svcErrors.GeneralError("failed to find maintenance window")
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should apply the pending upgrades inside the maintenance window",
+			fields: fields{
+				kafkas: func() ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+					return []*dbapi.KafkaRequest{pendingKafka(nil)}, nil
+				},
+				maintenanceWindowService: &services.MaintenanceWindowServiceMock{
+					FindForKafkaFunc: func(kafka *dbapi.KafkaRequest) (*dbapi.MaintenanceWindow, *svcErrors.ServiceError) {
+						return openWindow, nil
+					},
+				},
+			},
+			wantUpdates: map[string]interface{}{
+				"desired_kafka_version":     "3.3.0",
+				"desired_strimzi_version":   "strimzi-cluster-operator.v0.30.0-0",
+				"pending_kafka_version":     "",
+				"pending_strimzi_version":   "",
+				"pending_kafka_ibp_version": "",
+			},
+		},
+		{
+			name: "should apply the pending upgrades when the kafka no longer has a maintenance window",
+			fields: fields{
+				kafkas: func() ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+					return []*dbapi.KafkaRequest{pendingKafka(nil)}, nil
+				},
+				maintenanceWindowService: &services.MaintenanceWindowServiceMock{
+					FindForKafkaFunc: func(kafka *dbapi.KafkaRequest) (*dbapi.MaintenanceWindow, *svcErrors.ServiceError) {
+						return nil, nil
+					},
+				},
+			},
+			wantUpdates: map[string]interface{}{
+				"desired_kafka_version":     "3.3.0",
+				"desired_strimzi_version":   "strimzi-cluster-operator.v0.30.0-0",
+				"pending_kafka_version":     "",
+				"pending_strimzi_version":   "",
+				"pending_kafka_ibp_version": "",
+			},
+		},
+		{
+			name: "should not apply the pending upgrades outside the maintenance window",
+			fields: fields{
+				kafkas: func() ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+					return []*dbapi.KafkaRequest{pendingKafka(nil)}, nil
+				},
+				maintenanceWindowService: &services.MaintenanceWindowServiceMock{
+					FindForKafkaFunc: func(kafka *dbapi.KafkaRequest) (*dbapi.MaintenanceWindow, *svcErrors.ServiceError) {
+						return closedWindow, nil
+					},
+				},
+			},
+		},
+		{
+			name: "should not apply the pending upgrades while another upgrade is in progress",
+			fields: fields{
+				kafkas: func() ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+					return []*dbapi.KafkaRequest{pendingKafka(func(kafkaRequest *dbapi.KafkaRequest) {
+						kafkaRequest.StrimziUpgrading = true
+					})}, nil
+				},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var updates map[string]interface{}
+			kafkaService := &services.KafkaServiceMock{
+				ListKafkasWithPendingUpgradesFunc: tt.fields.kafkas,
+				UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *svcErrors.ServiceError {
+					updates = values
+					return nil
+				},
+			}
+			k := NewMaintenanceWindowKafkaManager(kafkaService, tt.fields.maintenanceWindowService, w.Reconciler{})
+			g.Expect(len(k.Reconcile()) > 0).To(gomega.Equal(tt.wantErr))
+			if tt.wantUpdates == nil {
+				g.Expect(updates).To(gomega.BeNil())
+			} else {
+				g.Expect(updates).To(gomega.Equal(tt.wantUpdates))
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package kafka_mgrs
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// MigratingKafkaManager represents a kafka manager that periodically reconciles the migration of kafkas between data plane clusters.
+type MigratingKafkaManager struct {
+	workers.BaseWorker
+	kafkaService             services.KafkaService
+	clusterService           services.ClusterService
+	clusterPlacementStrategy services.ClusterPlacementStrategy
+	kafkaConfig              *config.KafkaConfig
+}
+
+// NewMigratingKafkaManager creates a new kafka manager to reconcile migrating kafkas.
+func NewMigratingKafkaManager(kafkaService services.KafkaService, clusterService services.ClusterService, clusterPlacementStrategy services.ClusterPlacementStrategy, kafkaConfig *config.KafkaConfig, reconciler workers.Reconciler) *MigratingKafkaManager {
+	return &MigratingKafkaManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "migrating_kafka",
+			Reconciler: reconciler,
+		},
+		kafkaService:             kafkaService,
+		clusterService:           clusterService,
+		clusterPlacementStrategy: clusterPlacementStrategy,
+		kafkaConfig:              kafkaConfig,
+	}
+}
+
+// Start initializes the kafka manager to reconcile migrating kafkas.
+func (k *MigratingKafkaManager) Start() {
+	k.StartWorker(k)
+}
+
+// Stop causes the process for reconciling migrating kafkas to stop.
+func (k *MigratingKafkaManager) Stop() {
+	k.StopWorker(k)
+}
+
+func (k *MigratingKafkaManager) Reconcile() []error {
+	glog.Infoln("reconciling migrating kafkas")
+	var encounteredErrors []error
+
+	// Migrations in 'provisioning' and 'deprovisioning_source' states are moved forward by the KAS Fleetshard Operator status updates.
+	// Only the 'pending' and 'switching_routes' states need to be reconciled here.
+	migratingKafkas, serviceErr := k.kafkaService.ListByMigrationStatus(constants.KafkaMigrationStatusPending, constants.KafkaMigrationStatusSwitchingRoutes)
+	if serviceErr != nil {
+		return append(encounteredErrors, errors.Wrap(serviceErr, "failed to list migrating kafkas"))
+	}
+	glog.Infof("migrating kafkas count = %d", len(migratingKafkas))
+
+	for _, kafka := range migratingKafkas {
+		glog.V(10).Infof("migrating kafka id = %s, migration status = %s", kafka.ID, kafka.MigrationStatus)
+		var err error
+		switch kafka.MigrationStatus {
+		case constants.KafkaMigrationStatusPending.String():
+			err = k.reconcilePendingMigration(kafka)
+		case constants.KafkaMigrationStatusSwitchingRoutes.String():
+			err = k.reconcileSwitchingRoutes(kafka)
+		}
+		if err != nil {
+			encounteredErrors = append(encounteredErrors, errors.Wrapf(err, "failed to reconcile migrating kafka %s", kafka.ID))
+		}
+	}
+
+	return encounteredErrors
+}
+
+// reconcilePendingMigration assigns the target cluster of the migration, unless one was requested, and moves the migration
+// to 'provisioning' so that the ManagedKafka is sent to the target cluster.
+func (k *MigratingKafkaManager) reconcilePendingMigration(kafka *dbapi.KafkaRequest) error {
+	var cluster *api.Cluster
+	if kafka.MigrationTargetClusterID == "" {
+		placedCluster, err := k.clusterPlacementStrategy.FindCluster(kafka)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find a migration target cluster for kafka %s", kafka.ID)
+		}
+		if placedCluster == nil {
+			glog.Infof("no data plane cluster can host kafka %s at this moment, its migration will be retried", kafka.ID)
+			return nil
+		}
+		cluster = placedCluster
+	} else {
+		targetCluster, err := k.clusterService.FindClusterByID(kafka.MigrationTargetClusterID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find migration target cluster %s", kafka.MigrationTargetClusterID)
+		}
+		if targetCluster == nil {
+			return k.failPendingMigration(kafka, errors.Errorf("migration target cluster %s does not exist", kafka.MigrationTargetClusterID))
+		}
+		cluster = targetCluster
+	}
+
+	available, err := k.clusterService.IsStrimziKafkaVersionAvailableInCluster(cluster, kafka.DesiredStrimziVersion, kafka.DesiredKafkaVersion, kafka.DesiredKafkaIBPVersion)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check the kafka versions available in cluster %s", cluster.ClusterID)
+	}
+	if !available {
+		return k.failPendingMigration(kafka, errors.Errorf("strimzi version %s with kafka version %s and kafka IBP version %s is not available in cluster %s", kafka.DesiredStrimziVersion, kafka.DesiredKafkaVersion, kafka.DesiredKafkaIBPVersion, cluster.ClusterID))
+	}
+
+	glog.Infof("migrating kafka %s from cluster %s to cluster %s", kafka.ID, kafka.ClusterID, cluster.ClusterID)
+	if err := k.kafkaService.Updates(kafka, map[string]interface{}{
+		"migration_target_cluster_id": cluster.ClusterID,
+		"migration_placement_id":      api.NewID(),
+		"migration_status":            constants.KafkaMigrationStatusProvisioning.String(),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to assign migration target cluster %s", cluster.ClusterID)
+	}
+
+	return nil
+}
+
+// failPendingMigration marks a migration that never reached its target cluster as failed and releases the reserved capacity
+func (k *MigratingKafkaManager) failPendingMigration(kafka *dbapi.KafkaRequest, reason error) error {
+	if err := k.kafkaService.Updates(kafka, map[string]interface{}{
+		"migration_status":            constants.KafkaMigrationStatusFailed.String(),
+		"migration_source_cluster_id": "",
+		"migration_target_cluster_id": "",
+		"migration_placement_id":      "",
+	}); err != nil {
+		return errors.Wrapf(err, "failed to mark migration as failed: %v", reason)
+	}
+
+	return errors.Wrap(reason, "migration failed")
+}
+
+// reconcileSwitchingRoutes points the CNAME records of the kafka to its routes on the target cluster. Once the records are
+// in sync, the kafka is assigned to the target cluster and the ManagedKafka on the source cluster is marked as deleted.
+func (k *MigratingKafkaManager) reconcileSwitchingRoutes(kafka *dbapi.KafkaRequest) error {
+	if k.kafkaConfig.EnableKafkaCNAMERegistration {
+		if kafka.RoutesCreationId == "" {
+			glog.Infof("switching CNAME records of kafka %s to cluster %s", kafka.ID, kafka.MigrationTargetClusterID)
+
+			changeOutput, err := k.kafkaService.ChangeKafkaCNAMErecords(kafka, services.KafkaRoutesActionUpsert)
+			if err != nil {
+				return err
+			}
+
+			kafka.RoutesCreationId = *changeOutput.ChangeInfo.Id
+			kafka.RoutesCreated = *changeOutput.ChangeInfo.Status == "INSYNC"
+		} else {
+			recordStatus, err := k.kafkaService.GetCNAMERecordStatus(kafka)
+			if err != nil {
+				return err
+			}
+			kafka.RoutesCreated = *recordStatus.Status == "INSYNC"
+		}
+	} else {
+		glog.Infof("external certificate is disabled, skip CNAME switch for Kafka %s", kafka.ID)
+		kafka.RoutesCreated = true
+	}
+
+	if !kafka.RoutesCreated {
+		if err := k.kafkaService.Updates(kafka, map[string]interface{}{"routes_creation_id": kafka.RoutesCreationId}); err != nil {
+			return errors.Wrap(err, "failed to update routes creation id")
+		}
+		return nil
+	}
+
+	glog.Infof("switching kafka %s from cluster %s to cluster %s", kafka.ID, kafka.ClusterID, kafka.MigrationTargetClusterID)
+	if err := k.kafkaService.Updates(kafka, map[string]interface{}{
+		"cluster_id":             kafka.MigrationTargetClusterID,
+		"placement_id":           kafka.MigrationPlacementId,
+		"migration_placement_id": kafka.PlacementId,
+		"routes_creation_id":     kafka.RoutesCreationId,
+		"routes_created":         true,
+		"migration_status":       constants.KafkaMigrationStatusDeprovisioningSource.String(),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to switch kafka to cluster %s", kafka.MigrationTargetClusterID)
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+package kafka_mgrs
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	mockKafkas "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	svcErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	w "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+)
+
+func Test_MigratingKafkaManager_Reconcile(t *testing.T) {
+	testChangeID := "1234"
+	testChangePending := route53.ChangeStatusPending
+
+	pendingKafka := func(targetClusterID string) *dbapi.KafkaRequest {
+		return mockKafkas.BuildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ClusterID = "source-cluster"
+			kafkaRequest.MigrationStatus = constants.KafkaMigrationStatusPending.String()
+			kafkaRequest.MigrationSourceClusterID = "source-cluster"
+			kafkaRequest.MigrationTargetClusterID = targetClusterID
+		})
+	}
+
+	switchingKafka := func() *dbapi.KafkaRequest {
+		return mockKafkas.BuildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ClusterID = "source-cluster"
+			kafkaRequest.PlacementId = "source-placement"
+			kafkaRequest.RoutesCreated = false
+			kafkaRequest.RoutesCreationId = ""
+			kafkaRequest.MigrationStatus = constants.KafkaMigrationStatusSwitchingRoutes.String()
+			kafkaRequest.MigrationSourceClusterID = "source-cluster"
+			kafkaRequest.MigrationTargetClusterID = "target-cluster"
+			kafkaRequest.MigrationPlacementId = "target-placement"
+		})
+	}
+
+	type fields struct {
+		kafkaService             func(updates *map[string]interface{}) services.KafkaService
+		clusterService           services.ClusterService
+		clusterPlacementStrategy services.ClusterPlacementStrategy
+		kafkaConfig              *config.KafkaConfig
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		wantErr     bool
+		wantUpdates map[string]interface{}
+	}{
+		{
+			name: "should return an error if listing migrating kafkas fails",
+			fields: fields{
+				kafkaService: func(updates *map[string]interface{}) services.KafkaService {
+					return &services.KafkaServiceMock{
+						ListByMigrationStatusFunc: func(status ...constants.KafkaMigrationStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+							return nil, svcErrors.GeneralError("failed to list kafka requests")
+						},
+					}
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should assign the target cluster found by the placement strategy to a pending migration",
+			fields: fields{
+				kafkaService: func(updates *map[string]interface{}) services.KafkaService {
+					return &services.KafkaServiceMock{
+						ListByMigrationStatusFunc: func(status ...constants.KafkaMigrationStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+							return []*dbapi.KafkaRequest{pendingKafka("")}, nil
+						},
+						UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *svcErrors.ServiceError {
+							*updates = values
+							return nil
+						},
+					}
+				},
+				clusterPlacementStrategy: &services.ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return &api.Cluster{ClusterID: "target-cluster"}, nil
+					},
+				},
+				clusterService: &services.ClusterServiceMock{
+					IsStrimziKafkaVersionAvailableInClusterFunc: func(cluster *api.Cluster, strimziVersion, kafkaVersion, ibpVersion string) (bool, error) {
+						return true, nil
+					},
+				},
+			},
+			wantErr: false,
+			wantUpdates: map[string]interface{}{
+				"migration_target_cluster_id": "target-cluster",
+				"migration_status":            constants.KafkaMigrationStatusProvisioning.String(),
+			},
+		},
+		{
+			name: "should retry a pending migration later when no cluster can host the kafka",
+			fields: fields{
+				kafkaService: func(updates *map[string]interface{}) services.KafkaService {
+					return &services.KafkaServiceMock{
+						ListByMigrationStatusFunc: func(status ...constants.KafkaMigrationStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+							return []*dbapi.KafkaRequest{pendingKafka("")}, nil
+						},
+					}
+				},
+				clusterPlacementStrategy: &services.ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return nil, nil
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should fail a pending migration when the kafka versions are not available on the requested target cluster",
+			fields: fields{
+				kafkaService: func(updates *map[string]interface{}) services.KafkaService {
+					return &services.KafkaServiceMock{
+						ListByMigrationStatusFunc: func(status ...constants.KafkaMigrationStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+							return []*dbapi.KafkaRequest{pendingKafka("target-cluster")}, nil
+						},
+						UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *svcErrors.ServiceError {
+							*updates = values
+							return nil
+						},
+					}
+				},
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *svcErrors.ServiceError) {
+						return &api.Cluster{ClusterID: clusterID}, nil
+					},
+					IsStrimziKafkaVersionAvailableInClusterFunc: func(cluster *api.Cluster, strimziVersion, kafkaVersion, ibpVersion string) (bool, error) {
+						return false, nil
+					},
+				},
+			},
+			wantErr: true,
+			wantUpdates: map[string]interface{}{
+				"migration_status":            constants.KafkaMigrationStatusFailed.String(),
+				"migration_source_cluster_id": "",
+				"migration_target_cluster_id": "",
+				"migration_placement_id":      "",
+			},
+		},
+		{
+			name: "should switch the kafka to the target cluster when CNAME registration is disabled",
+			fields: fields{
+				kafkaService: func(updates *map[string]interface{}) services.KafkaService {
+					return &services.KafkaServiceMock{
+						ListByMigrationStatusFunc: func(status ...constants.KafkaMigrationStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+							return []*dbapi.KafkaRequest{switchingKafka()}, nil
+						},
+						UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *svcErrors.ServiceError {
+							*updates = values
+							return nil
+						},
+					}
+				},
+				kafkaConfig: &config.KafkaConfig{EnableKafkaCNAMERegistration: false},
+			},
+			wantErr: false,
+			wantUpdates: map[string]interface{}{
+				"cluster_id":             "target-cluster",
+				"placement_id":           "target-placement",
+				"migration_placement_id": "source-placement",
+				"routes_creation_id":     "",
+				"routes_created":         true,
+				"migration_status":       constants.KafkaMigrationStatusDeprovisioningSource.String(),
+			},
+		},
+		{
+			name: "should only store the routes creation id while the CNAME records are not in sync",
+			fields: fields{
+				kafkaService: func(updates *map[string]interface{}) services.KafkaService {
+					return &services.KafkaServiceMock{
+						ListByMigrationStatusFunc: func(status ...constants.KafkaMigrationStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+							return []*dbapi.KafkaRequest{switchingKafka()}, nil
+						},
+						ChangeKafkaCNAMErecordsFunc: func(kafkaRequest *dbapi.KafkaRequest, action services.KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *svcErrors.ServiceError) {
+							if action != services.KafkaRoutesActionUpsert {
+								return nil, svcErrors.GeneralError("unexpected action %q", action)
+							}
+							return &route53.ChangeResourceRecordSetsOutput{
+								ChangeInfo: &route53.ChangeInfo{
+									Id:     &testChangeID,
+									Status: &testChangePending,
+								},
+							}, nil
+						},
+						UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *svcErrors.ServiceError {
+							*updates = values
+							return nil
+						},
+					}
+				},
+				kafkaConfig: &config.KafkaConfig{EnableKafkaCNAMERegistration: true},
+			},
+			wantErr: false,
+			wantUpdates: map[string]interface{}{
+				"routes_creation_id": testChangeID,
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var updates map[string]interface{}
+			k := NewMigratingKafkaManager(tt.fields.kafkaService(&updates), tt.fields.clusterService, tt.fields.clusterPlacementStrategy, tt.fields.kafkaConfig, w.Reconciler{})
+			g.Expect(len(k.Reconcile()) > 0).To(gomega.Equal(tt.wantErr))
+			for key, value := range tt.wantUpdates {
+				g.Expect(updates).To(gomega.HaveKeyWithValue(key, value))
+			}
+			if tt.wantUpdates == nil {
+				g.Expect(updates).To(gomega.BeNil())
+			}
+		})
+	}
+}
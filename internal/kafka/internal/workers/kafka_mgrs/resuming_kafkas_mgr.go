@@ -0,0 +1,84 @@
+package kafka_mgrs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// ResumingKafkaManager represents a kafka manager that periodically reconciles resuming kafka requests.
+type ResumingKafkaManager struct {
+	workers.BaseWorker
+	kafkaService services.KafkaService
+}
+
+// NewResumingKafkaManager creates a new kafka manager to reconcile resuming kafkas.
+func NewResumingKafkaManager(kafkaService services.KafkaService, reconciler workers.Reconciler) *ResumingKafkaManager {
+	return &ResumingKafkaManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "resuming_kafka",
+			Reconciler: reconciler,
+		},
+		kafkaService: kafkaService,
+	}
+}
+
+// Start initializes the kafka manager to reconcile resuming kafka requests.
+func (k *ResumingKafkaManager) Start() {
+	k.StartWorker(k)
+}
+
+// Stop causes the process for reconciling resuming kafka requests to stop.
+func (k *ResumingKafkaManager) Stop() {
+	k.StopWorker(k)
+}
+
+func (k *ResumingKafkaManager) Reconcile() []error {
+	glog.Infoln("reconciling resuming kafkas")
+	var encounteredErrors []error
+
+	// Kafkas in a 'resuming' state are sent to the KAS Fleetshard Operator with the 'bf2.org/suspended' label set to 'false'.
+	// The update of the Kafka request status from 'resuming' to 'ready' is handled by the KAS Fleetshard Operator status updates.
+	// Kafkas that do not become ready within the allowed duration are marked as 'failed'.
+	resumingKafkas, serviceErr := k.kafkaService.ListByStatus(constants.KafkaRequestStatusResuming)
+	if serviceErr != nil {
+		return append(encounteredErrors, errors.Wrap(serviceErr, "failed to list resuming kafkas"))
+	}
+	glog.Infof("resuming kafkas count = %d", len(resumingKafkas))
+
+	for _, kafka := range resumingKafkas {
+		glog.V(10).Infof("resuming kafka id = %s", kafka.ID)
+		if err := k.reconcileResumingKafka(kafka); err != nil {
+			encounteredErrors = append(encounteredErrors, errors.Wrapf(err, "failed to reconcile resuming kafka %s", kafka.ID))
+		}
+	}
+
+	return encounteredErrors
+}
+
+func (k *ResumingKafkaManager) reconcileResumingKafka(kafka *dbapi.KafkaRequest) error {
+	if time.Since(kafka.UpdatedAt) <= constants.ResumingKafkaMaxDuration {
+		metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants.KafkaRequestStatusResuming, kafka.ID, kafka.ClusterID, time.Since(kafka.CreatedAt))
+		return nil
+	}
+
+	glog.Infof("kafka %s did not become ready within %s after being resumed. Marking it as %q", kafka.ID, constants.ResumingKafkaMaxDuration, constants.KafkaRequestStatusFailed)
+	if err := k.kafkaService.Updates(kafka, map[string]interface{}{
+		"status":        constants.KafkaRequestStatusFailed.String(),
+		"failed_reason": fmt.Sprintf("Kafka instance did not become ready within %s after being resumed", constants.ResumingKafkaMaxDuration),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to update status of kafka %s to %q", kafka.ID, constants.KafkaRequestStatusFailed)
+	}
+	metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants.KafkaRequestStatusFailed, kafka.ID, kafka.ClusterID, time.Since(kafka.CreatedAt))
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package kafka_mgrs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	mockKafkas "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+	svcErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	w "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+)
+
+func Test_ResumingKafkaManager_Reconcile(t *testing.T) {
+	type fields struct {
+		kafkaService *services.KafkaServiceMock
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		wantErr     bool
+		wantUpdates int
+	}{
+		{
+			name: "should return an error if listing kafkas fails",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					ListByStatusFunc: func(status ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+						return nil, svcErrors.GeneralError("failed to list kafka requests")
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should not update kafkas that are resuming within the allowed duration",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					ListByStatusFunc: func(status ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+						return []*dbapi.KafkaRequest{
+							mockKafkas.BuildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+								kafkaRequest.Status = constants.KafkaRequestStatusResuming.String()
+								kafkaRequest.UpdatedAt = time.Now()
+							}),
+						}, nil
+					},
+				},
+			},
+			wantErr:     false,
+			wantUpdates: 0,
+		},
+		{
+			name: "should mark kafkas that did not become ready within the allowed duration as failed",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					ListByStatusFunc: func(status ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+						return []*dbapi.KafkaRequest{
+							mockKafkas.BuildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+								kafkaRequest.Status = constants.KafkaRequestStatusResuming.String()
+								kafkaRequest.UpdatedAt = time.Now().Add(-constants.ResumingKafkaMaxDuration - time.Minute)
+							}),
+						}, nil
+					},
+					UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *svcErrors.ServiceError {
+						if values["status"] != constants.KafkaRequestStatusFailed.String() {
+							return svcErrors.GeneralError("unexpected status")
+						}
+						return nil
+					},
+				},
+			},
+			wantErr:     false,
+			wantUpdates: 1,
+		},
+		{
+			name: "should return an error if marking the kafka as failed fails",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					ListByStatusFunc: func(status ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+						return []*dbapi.KafkaRequest{
+							mockKafkas.BuildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+								kafkaRequest.Status = constants.KafkaRequestStatusResuming.String()
+								kafkaRequest.UpdatedAt = time.Now().Add(-constants.ResumingKafkaMaxDuration - time.Minute)
+							}),
+						}, nil
+					},
+					UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *svcErrors.ServiceError {
+						return svcErrors.GeneralError("failed to update kafka")
+					},
+				},
+			},
+			wantErr:     true,
+			wantUpdates: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			k := NewResumingKafkaManager(tt.fields.kafkaService, w.Reconciler{})
+			g.Expect(len(k.Reconcile()) > 0).To(gomega.Equal(tt.wantErr))
+			g.Expect(tt.fields.kafkaService.UpdatesCalls()).To(gomega.HaveLen(tt.wantUpdates))
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package kafka_mgrs
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// SuspendingKafkaManager represents a kafka manager that periodically reconciles suspending kafka requests.
+type SuspendingKafkaManager struct {
+	workers.BaseWorker
+	kafkaService services.KafkaService
+}
+
+// NewSuspendingKafkaManager creates a new kafka manager to reconcile suspending kafkas.
+func NewSuspendingKafkaManager(kafkaService services.KafkaService, reconciler workers.Reconciler) *SuspendingKafkaManager {
+	return &SuspendingKafkaManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "suspending_kafka",
+			Reconciler: reconciler,
+		},
+		kafkaService: kafkaService,
+	}
+}
+
+// Start initializes the kafka manager to reconcile suspending kafka requests.
+func (k *SuspendingKafkaManager) Start() {
+	k.StartWorker(k)
+}
+
+// Stop causes the process for reconciling suspending kafka requests to stop.
+func (k *SuspendingKafkaManager) Stop() {
+	k.StopWorker(k)
+}
+
+func (k *SuspendingKafkaManager) Reconcile() []error {
+	glog.Infoln("reconciling suspending kafkas")
+	var encounteredErrors []error
+
+	// Kafkas in a 'suspending' state are sent to the KAS Fleetshard Operator with the 'bf2.org/suspended' label set to 'true'.
+	// The update of the Kafka request status from 'suspending' to 'suspended' is handled by the KAS Fleetshard Operator status updates.
+	// We only need to update the metrics here and report any Kafka that takes too long to be suspended.
+	suspendingKafkas, serviceErr := k.kafkaService.ListByStatus(constants.KafkaRequestStatusSuspending)
+	if serviceErr != nil {
+		return append(encounteredErrors, errors.Wrap(serviceErr, "failed to list suspending kafkas"))
+	}
+	glog.Infof("suspending kafkas count = %d", len(suspendingKafkas))
+
+	for _, kafka := range suspendingKafkas {
+		glog.V(10).Infof("suspending kafka id = %s", kafka.ID)
+		metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants.KafkaRequestStatusSuspending, kafka.ID, kafka.ClusterID, time.Since(kafka.CreatedAt))
+
+		// a 'suspending' kafka must never be moved to 'failed'. Report it so that it can be investigated instead.
+		if time.Since(kafka.UpdatedAt) > constants.SuspendingKafkaMaxDuration {
+			encounteredErrors = append(encounteredErrors, errors.Errorf("kafka %s has been in %q state for more than %s", kafka.ID, constants.KafkaRequestStatusSuspending, constants.SuspendingKafkaMaxDuration))
+		}
+	}
+
+	return encounteredErrors
+}
@@ -0,0 +1,78 @@
+package kafka_mgrs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	mockKafkas "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+	svcErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	w "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+)
+
+func Test_SuspendingKafkaManager_Reconcile(t *testing.T) {
+	type fields struct {
+		kafkaService services.KafkaService
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "should return an error if listing kafkas fails",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					ListByStatusFunc: func(status ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+						return nil, svcErrors.GeneralError("failed to list kafka requests")
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should not return an error if suspending kafkas are within the expected duration",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					ListByStatusFunc: func(status ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+						return []*dbapi.KafkaRequest{
+							mockKafkas.BuildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+								kafkaRequest.Status = constants.KafkaRequestStatusSuspending.String()
+								kafkaRequest.UpdatedAt = time.Now()
+							}),
+						}, nil
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should return an error if a kafka has been suspending for longer than the expected duration",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					ListByStatusFunc: func(status ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+						return []*dbapi.KafkaRequest{
+							mockKafkas.BuildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+								kafkaRequest.Status = constants.KafkaRequestStatusSuspending.String()
+								kafkaRequest.UpdatedAt = time.Now().Add(-constants.SuspendingKafkaMaxDuration - time.Minute)
+							}),
+						}, nil
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			k := NewSuspendingKafkaManager(tt.fields.kafkaService, w.Reconciler{})
+			g.Expect(len(k.Reconcile()) > 0).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package kafka_mgrs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// UpgradeRolloutKafkaManager represents a kafka manager that periodically moves kafka upgrade rollouts through their waves.
+type UpgradeRolloutKafkaManager struct {
+	workers.BaseWorker
+	kafkaService             services.KafkaService
+	rolloutService           services.KafkaUpgradeRolloutService
+	maintenanceWindowService services.MaintenanceWindowService
+}
+
+// NewUpgradeRolloutKafkaManager creates a new kafka manager to reconcile kafka upgrade rollouts.
+func NewUpgradeRolloutKafkaManager(kafkaService services.KafkaService, rolloutService services.KafkaUpgradeRolloutService, maintenanceWindowService services.MaintenanceWindowService, reconciler workers.Reconciler) *UpgradeRolloutKafkaManager {
+	return &UpgradeRolloutKafkaManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "upgrade_rollout_kafka",
+			Reconciler: reconciler,
+		},
+		kafkaService:             kafkaService,
+		rolloutService:           rolloutService,
+		maintenanceWindowService: maintenanceWindowService,
+	}
+}
+
+// Start initializes the kafka manager to reconcile kafka upgrade rollouts.
+func (k *UpgradeRolloutKafkaManager) Start() {
+	k.StartWorker(k)
+}
+
+// Stop causes the process for reconciling kafka upgrade rollouts to stop.
+func (k *UpgradeRolloutKafkaManager) Stop() {
+	k.StopWorker(k)
+}
+
+func (k *UpgradeRolloutKafkaManager) Reconcile() []error {
+	glog.Infoln("reconciling kafka upgrade rollouts")
+	var encounteredErrors []error
+
+	rollouts, serviceErr := k.rolloutService.ListByStatus(constants.KafkaUpgradeRolloutStatusInProgress, constants.KafkaUpgradeRolloutStatusRollingBack)
+	if serviceErr != nil {
+		return append(encounteredErrors, errors.Wrap(serviceErr, "failed to list active kafka upgrade rollouts"))
+	}
+	glog.Infof("active kafka upgrade rollouts count = %d", len(rollouts))
+
+	for _, rollout := range rollouts {
+		glog.V(10).Infof("kafka upgrade rollout id = %s status = %s wave = %d/%d", rollout.ID, rollout.Status, rollout.CurrentWave+1, rollout.TotalWaves)
+		var err error
+		if rollout.Status == constants.KafkaUpgradeRolloutStatusRollingBack.String() {
+			err = k.reconcileRollback(rollout)
+		} else {
+			err = k.reconcileRollout(rollout)
+		}
+		if err != nil {
+			encounteredErrors = append(encounteredErrors, errors.Wrapf(err, "failed to reconcile kafka upgrade rollout %s", rollout.ID))
+		}
+	}
+
+	return encounteredErrors
+}
+
+// reconcileRollout upgrades the kafkas of the current wave of the rollout. The rollout moves on to the next wave once all the
+// kafkas of the current wave have been upgraded and the soak time has elapsed. It is paused as soon as a kafka of the wave fails.
+func (k *UpgradeRolloutKafkaManager) reconcileRollout(rollout *dbapi.KafkaUpgradeRollout) error {
+	targets, serviceErr := k.rolloutService.ListTargets(rollout.ID)
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	waveUpgraded := true
+	for _, target := range targets {
+		if target.Wave != rollout.CurrentWave {
+			continue
+		}
+
+		kafka, serviceErr := k.kafkaService.GetByID(target.KafkaId)
+		if serviceErr != nil {
+			if serviceErr.Is404() {
+				// kafkas deleted since the rollout was created do not hold the rollout back
+				continue
+			}
+			return serviceErr
+		}
+
+		switch target.Status {
+		case constants.KafkaUpgradeRolloutTargetStatusPending.String():
+			if err := k.applyRolloutVersions(rollout, kafka); err != nil {
+				return err
+			}
+			if err := k.rolloutService.UpdateTargetStatus(target, constants.KafkaUpgradeRolloutTargetStatusUpgrading); err != nil {
+				return err
+			}
+			waveUpgraded = false
+		case constants.KafkaUpgradeRolloutTargetStatusUpgrading.String():
+			// the status of the kafka is set to 'failed' when the data plane reports an error for it
+			if kafka.Status == constants.KafkaRequestStatusFailed.String() {
+				if err := k.rolloutService.UpdateTargetStatus(target, constants.KafkaUpgradeRolloutTargetStatusFailed); err != nil {
+					return err
+				}
+				glog.Infof("pausing kafka upgrade rollout %s: kafka %s failed while being upgraded", rollout.ID, kafka.ID)
+				if err := k.rolloutService.Pause(rollout, fmt.Sprintf("kafka %s failed while being upgraded: %s", kafka.ID, kafka.FailedReason)); err != nil {
+					return err
+				}
+				return nil
+			}
+			if !rolloutVersionsReached(rollout, kafka) {
+				waveUpgraded = false
+				continue
+			}
+			if err := k.rolloutService.UpdateTargetStatus(target, constants.KafkaUpgradeRolloutTargetStatusUpgraded); err != nil {
+				return err
+			}
+		}
+		// failed kafkas do not hold the rollout back once it has been resumed
+	}
+
+	if !waveUpgraded {
+		return nil
+	}
+
+	now := time.Now()
+	if rollout.WaveUpgradedAt == nil {
+		if err := k.rolloutService.Updates(rollout, map[string]interface{}{"wave_upgraded_at": now}); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if !rollout.SoakTimeElapsed(now) {
+		return nil
+	}
+
+	if rollout.CurrentWave+1 >= rollout.TotalWaves {
+		glog.Infof("kafka upgrade rollout %s completed", rollout.ID)
+		if err := k.rolloutService.Updates(rollout, map[string]interface{}{"status": constants.KafkaUpgradeRolloutStatusCompleted.String()}); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	glog.Infof("kafka upgrade rollout %s moving on to wave %d/%d", rollout.ID, rollout.CurrentWave+2, rollout.TotalWaves)
+	if err := k.rolloutService.Updates(rollout, map[string]interface{}{
+		"current_wave":     rollout.CurrentWave + 1,
+		"wave_upgraded_at": nil,
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// reconcileRollback reverts the versions of the kafkas whose upgrade has been started by the rollout
+func (k *UpgradeRolloutKafkaManager) reconcileRollback(rollout *dbapi.KafkaUpgradeRollout) error {
+	targets, serviceErr := k.rolloutService.ListTargets(rollout.ID)
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	touchedStatuses := []string{
+		constants.KafkaUpgradeRolloutTargetStatusUpgrading.String(),
+		constants.KafkaUpgradeRolloutTargetStatusUpgraded.String(),
+		constants.KafkaUpgradeRolloutTargetStatusFailed.String(),
+	}
+	for _, target := range targets {
+		if !arrays.Contains(touchedStatuses, target.Status) {
+			continue
+		}
+
+		kafka, serviceErr := k.kafkaService.GetByID(target.KafkaId)
+		if serviceErr != nil && !serviceErr.Is404() {
+			return serviceErr
+		}
+
+		if kafka != nil {
+			updates := map[string]interface{}{}
+			if rollout.StrimziVersion != "" {
+				updates["desired_strimzi_version"] = target.PreviousDesiredStrimziVersion
+				updates["pending_strimzi_version"] = ""
+			}
+			if rollout.KafkaVersion != "" {
+				updates["desired_kafka_version"] = target.PreviousDesiredKafkaVersion
+				updates["pending_kafka_version"] = ""
+			}
+			if rollout.KafkaIBPVersion != "" {
+				updates["desired_kafka_ibp_version"] = target.PreviousDesiredKafkaIBPVersion
+				updates["pending_kafka_ibp_version"] = ""
+			}
+			glog.Infof("rolling back kafka %s upgraded by rollout %s", kafka.ID, rollout.ID)
+			if err := k.kafkaService.Updates(kafka, updates); err != nil {
+				return errors.Wrapf(err, "failed to roll back kafka %s", kafka.ID)
+			}
+		}
+
+		if err := k.rolloutService.UpdateTargetStatus(target, constants.KafkaUpgradeRolloutTargetStatusRolledBack); err != nil {
+			return err
+		}
+	}
+
+	glog.Infof("kafka upgrade rollout %s rolled back", rollout.ID)
+	if err := k.rolloutService.Updates(rollout, map[string]interface{}{"status": constants.KafkaUpgradeRolloutStatusRolledBack.String()}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// applyRolloutVersions sets the versions of the rollout as the desired versions of the kafka. The versions are queued
+// until the next maintenance window instead when a maintenance window applies to the kafka.
+func (k *UpgradeRolloutKafkaManager) applyRolloutVersions(rollout *dbapi.KafkaUpgradeRollout, kafka *dbapi.KafkaRequest) error {
+	window, serviceErr := k.maintenanceWindowService.FindForKafka(kafka)
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	prefix := "desired_"
+	if window != nil {
+		prefix = "pending_"
+	}
+
+	updates := map[string]interface{}{}
+	if rollout.StrimziVersion != "" {
+		updates[prefix+"strimzi_version"] = rollout.StrimziVersion
+	}
+	if rollout.KafkaVersion != "" {
+		updates[prefix+"kafka_version"] = rollout.KafkaVersion
+	}
+	if rollout.KafkaIBPVersion != "" {
+		updates[prefix+"kafka_ibp_version"] = rollout.KafkaIBPVersion
+	}
+
+	glog.Infof("upgrading kafka %s as part of rollout %s: strimzi version %q, kafka version %q, kafka IBP version %q", kafka.ID, rollout.ID, rollout.StrimziVersion, rollout.KafkaVersion, rollout.KafkaIBPVersion)
+	if err := k.kafkaService.Updates(kafka, updates); err != nil {
+		return errors.Wrapf(err, "failed to upgrade kafka %s", kafka.ID)
+	}
+	return nil
+}
+
+// rolloutVersionsReached returns whether the data plane reports the versions of the rollout for the kafka
+func rolloutVersionsReached(rollout *dbapi.KafkaUpgradeRollout, kafka *dbapi.KafkaRequest) bool {
+	if kafka.StrimziUpgrading || kafka.KafkaUpgrading || kafka.KafkaIBPUpgrading {
+		return false
+	}
+	return (rollout.StrimziVersion == "" || kafka.ActualStrimziVersion == rollout.StrimziVersion) &&
+		(rollout.KafkaVersion == "" || kafka.ActualKafkaVersion == rollout.KafkaVersion) &&
+		(rollout.KafkaIBPVersion == "" || kafka.ActualKafkaIBPVersion == rollout.KafkaIBPVersion)
+}
@@ -0,0 +1,267 @@
+package kafka_mgrs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	mockKafkas "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+	svcErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	w "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+)
+
+func Test_UpgradeRolloutKafkaManager_Reconcile(t *testing.T) {
+	longAgo := time.Now().Add(-2 * time.Hour)
+	justNow := time.Now()
+
+	buildRollout := func(modifyFn func(rollout *dbapi.KafkaUpgradeRollout)) *dbapi.KafkaUpgradeRollout {
+		rollout := &dbapi.KafkaUpgradeRollout{
+			KafkaVersion:    "3.3.0",
+			CanaryCount:     1,
+			BatchPercentage: 50,
+			SoakTimeMinutes: 30,
+			Status:          constants.KafkaUpgradeRolloutStatusInProgress.String(),
+			TotalWaves:      3,
+		}
+		rollout.ID = "rollout-id"
+		if modifyFn != nil {
+			modifyFn(rollout)
+		}
+		return rollout
+	}
+
+	buildTarget := func(status constants.KafkaUpgradeRolloutTargetStatus) *dbapi.KafkaUpgradeRolloutTarget {
+		return &dbapi.KafkaUpgradeRolloutTarget{
+			RolloutId:                   "rollout-id",
+			KafkaId:                     mockKafkas.DefaultKafkaID,
+			Status:                      status.String(),
+			PreviousDesiredKafkaVersion: "3.2.0",
+		}
+	}
+
+	buildKafka := func(modifyFn func(kafkaRequest *dbapi.KafkaRequest)) *dbapi.KafkaRequest {
+		return mockKafkas.BuildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = mockKafkas.DefaultKafkaID
+			kafkaRequest.Status = constants.KafkaRequestStatusReady.String()
+			kafkaRequest.ActualKafkaVersion = "3.2.0"
+			kafkaRequest.DesiredKafkaVersion = "3.2.0"
+			if modifyFn != nil {
+				modifyFn(kafkaRequest)
+			}
+		})
+	}
+
+	type fields struct {
+		rollouts func(statuses ...constants.KafkaUpgradeRolloutStatus) (dbapi.KafkaUpgradeRolloutList, *svcErrors.ServiceError)
+		target   *dbapi.KafkaUpgradeRolloutTarget
+		kafka    *dbapi.KafkaRequest
+		window   *dbapi.MaintenanceWindow
+	}
+	tests := []struct {
+		name               string
+		fields             fields
+		wantErr            bool
+		wantKafkaUpdates   map[string]interface{}
+		wantRolloutUpdates map[string]interface{}
+		wantTargetStatus   constants.KafkaUpgradeRolloutTargetStatus
+		wantPaused         bool
+	}{
+		{
+			name: "should return an error if listing the active rollouts fails",
+			fields: fields{
+				rollouts: func(statuses ...constants.KafkaUpgradeRolloutStatus) (dbapi.KafkaUpgradeRolloutList, *svcErrors.ServiceError) {
+					return nil, svcErrors.GeneralError("failed to list rollouts")
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should set the desired versions of the kafkas of the current wave",
+			fields: fields{
+				rollouts: func(statuses ...constants.KafkaUpgradeRolloutStatus) (dbapi.KafkaUpgradeRolloutList, *svcErrors.ServiceError) {
+					return dbapi.KafkaUpgradeRolloutList{buildRollout(nil)}, nil
+				},
+				target: buildTarget(constants.KafkaUpgradeRolloutTargetStatusPending),
+				kafka:  buildKafka(nil),
+			},
+			wantKafkaUpdates: map[string]interface{}{"desired_kafka_version": "3.3.0"},
+			wantTargetStatus: constants.KafkaUpgradeRolloutTargetStatusUpgrading,
+		},
+		{
+			name: "should queue the versions of kafkas with a maintenance window",
+			fields: fields{
+				rollouts: func(statuses ...constants.KafkaUpgradeRolloutStatus) (dbapi.KafkaUpgradeRolloutList, *svcErrors.ServiceError) {
+					return dbapi.KafkaUpgradeRolloutList{buildRollout(nil)}, nil
+				},
+				target: buildTarget(constants.KafkaUpgradeRolloutTargetStatusPending),
+				kafka:  buildKafka(nil),
+				window: &dbapi.MaintenanceWindow{DayOfWeek: "sunday", StartTime: "02:00", DurationHours: 2},
+			},
+			wantKafkaUpdates: map[string]interface{}{"pending_kafka_version": "3.3.0"},
+			wantTargetStatus: constants.KafkaUpgradeRolloutTargetStatusUpgrading,
+		},
+		{
+			name: "should pause the rollout when a kafka of the wave fails",
+			fields: fields{
+				rollouts: func(statuses ...constants.KafkaUpgradeRolloutStatus) (dbapi.KafkaUpgradeRolloutList, *svcErrors.ServiceError) {
+					return dbapi.KafkaUpgradeRolloutList{buildRollout(nil)}, nil
+				},
+				target: buildTarget(constants.KafkaUpgradeRolloutTargetStatusUpgrading),
+				kafka: buildKafka(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.Status = constants.KafkaRequestStatusFailed.String()
+				}),
+			},
+			wantTargetStatus: constants.KafkaUpgradeRolloutTargetStatusFailed,
+			wantPaused:       true,
+		},
+		{
+			name: "should wait for the kafkas of the wave to be upgraded",
+			fields: fields{
+				rollouts: func(statuses ...constants.KafkaUpgradeRolloutStatus) (dbapi.KafkaUpgradeRolloutList, *svcErrors.ServiceError) {
+					return dbapi.KafkaUpgradeRolloutList{buildRollout(nil)}, nil
+				},
+				target: buildTarget(constants.KafkaUpgradeRolloutTargetStatusUpgrading),
+				kafka: buildKafka(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.KafkaUpgrading = true
+				}),
+			},
+			wantTargetStatus: constants.KafkaUpgradeRolloutTargetStatusUpgrading,
+		},
+		{
+			name: "should start the soak time once the kafkas of the wave are upgraded",
+			fields: fields{
+				rollouts: func(statuses ...constants.KafkaUpgradeRolloutStatus) (dbapi.KafkaUpgradeRolloutList, *svcErrors.ServiceError) {
+					return dbapi.KafkaUpgradeRolloutList{buildRollout(nil)}, nil
+				},
+				target: buildTarget(constants.KafkaUpgradeRolloutTargetStatusUpgrading),
+				kafka: buildKafka(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.ActualKafkaVersion = "3.3.0"
+				}),
+			},
+			wantTargetStatus:   constants.KafkaUpgradeRolloutTargetStatusUpgraded,
+			wantRolloutUpdates: map[string]interface{}{"wave_upgraded_at": nil},
+		},
+		{
+			name: "should not start the next wave before the soak time has elapsed",
+			fields: fields{
+				rollouts: func(statuses ...constants.KafkaUpgradeRolloutStatus) (dbapi.KafkaUpgradeRolloutList, *svcErrors.ServiceError) {
+					return dbapi.KafkaUpgradeRolloutList{buildRollout(func(rollout *dbapi.KafkaUpgradeRollout) {
+						rollout.WaveUpgradedAt = &justNow
+					})}, nil
+				},
+				target: buildTarget(constants.KafkaUpgradeRolloutTargetStatusUpgraded),
+				kafka:  buildKafka(nil),
+			},
+			wantTargetStatus: constants.KafkaUpgradeRolloutTargetStatusUpgraded,
+		},
+		{
+			name: "should start the next wave once the soak time has elapsed",
+			fields: fields{
+				rollouts: func(statuses ...constants.KafkaUpgradeRolloutStatus) (dbapi.KafkaUpgradeRolloutList, *svcErrors.ServiceError) {
+					return dbapi.KafkaUpgradeRolloutList{buildRollout(func(rollout *dbapi.KafkaUpgradeRollout) {
+						rollout.WaveUpgradedAt = &longAgo
+					})}, nil
+				},
+				target: buildTarget(constants.KafkaUpgradeRolloutTargetStatusUpgraded),
+				kafka:  buildKafka(nil),
+			},
+			wantTargetStatus:   constants.KafkaUpgradeRolloutTargetStatusUpgraded,
+			wantRolloutUpdates: map[string]interface{}{"current_wave": 1, "wave_upgraded_at": nil},
+		},
+		{
+			name: "should complete the rollout once the last wave has soaked",
+			fields: fields{
+				rollouts: func(statuses ...constants.KafkaUpgradeRolloutStatus) (dbapi.KafkaUpgradeRolloutList, *svcErrors.ServiceError) {
+					return dbapi.KafkaUpgradeRolloutList{buildRollout(func(rollout *dbapi.KafkaUpgradeRollout) {
+						rollout.TotalWaves = 1
+						rollout.WaveUpgradedAt = &longAgo
+					})}, nil
+				},
+				target: buildTarget(constants.KafkaUpgradeRolloutTargetStatusUpgraded),
+				kafka:  buildKafka(nil),
+			},
+			wantTargetStatus:   constants.KafkaUpgradeRolloutTargetStatusUpgraded,
+			wantRolloutUpdates: map[string]interface{}{"status": constants.KafkaUpgradeRolloutStatusCompleted.String()},
+		},
+		{
+			name: "should revert the desired versions of the upgraded kafkas when rolling back",
+			fields: fields{
+				rollouts: func(statuses ...constants.KafkaUpgradeRolloutStatus) (dbapi.KafkaUpgradeRolloutList, *svcErrors.ServiceError) {
+					return dbapi.KafkaUpgradeRolloutList{buildRollout(func(rollout *dbapi.KafkaUpgradeRollout) {
+						rollout.Status = constants.KafkaUpgradeRolloutStatusRollingBack.String()
+					})}, nil
+				},
+				target: buildTarget(constants.KafkaUpgradeRolloutTargetStatusUpgraded),
+				kafka: buildKafka(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.DesiredKafkaVersion = "3.3.0"
+				}),
+			},
+			wantKafkaUpdates:   map[string]interface{}{"desired_kafka_version": "3.2.0", "pending_kafka_version": ""},
+			wantTargetStatus:   constants.KafkaUpgradeRolloutTargetStatusRolledBack,
+			wantRolloutUpdates: map[string]interface{}{"status": constants.KafkaUpgradeRolloutStatusRolledBack.String()},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var kafkaUpdates, rolloutUpdates map[string]interface{}
+			paused := false
+			kafkaService := &services.KafkaServiceMock{
+				GetByIDFunc: func(id string) (*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+					return tt.fields.kafka, nil
+				},
+				UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *svcErrors.ServiceError {
+					kafkaUpdates = values
+					return nil
+				},
+			}
+			rolloutService := &services.KafkaUpgradeRolloutServiceMock{
+				ListByStatusFunc: tt.fields.rollouts,
+				ListTargetsFunc: func(rolloutID string) ([]*dbapi.KafkaUpgradeRolloutTarget, *svcErrors.ServiceError) {
+					return []*dbapi.KafkaUpgradeRolloutTarget{tt.fields.target}, nil
+				},
+				UpdateTargetStatusFunc: func(target *dbapi.KafkaUpgradeRolloutTarget, status constants.KafkaUpgradeRolloutTargetStatus) *svcErrors.ServiceError {
+					target.Status = status.String()
+					return nil
+				},
+				UpdatesFunc: func(rollout *dbapi.KafkaUpgradeRollout, values map[string]interface{}) *svcErrors.ServiceError {
+					rolloutUpdates = values
+					return nil
+				},
+				PauseFunc: func(rollout *dbapi.KafkaUpgradeRollout, reason string) *svcErrors.ServiceError {
+					paused = true
+					return nil
+				},
+			}
+			maintenanceWindowService := &services.MaintenanceWindowServiceMock{
+				FindForKafkaFunc: func(kafka *dbapi.KafkaRequest) (*dbapi.MaintenanceWindow, *svcErrors.ServiceError) {
+					return tt.fields.window, nil
+				},
+			}
+			k := NewUpgradeRolloutKafkaManager(kafkaService, rolloutService, maintenanceWindowService, w.Reconciler{})
+			g.Expect(len(k.Reconcile()) > 0).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(kafkaUpdates).To(gomega.Equal(tt.wantKafkaUpdates))
+			g.Expect(paused).To(gomega.Equal(tt.wantPaused))
+			g.Expect(tt.fields.target.Status).To(gomega.Equal(tt.wantTargetStatus.String()))
+			if tt.wantRolloutUpdates == nil {
+				g.Expect(rolloutUpdates).To(gomega.BeNil())
+			} else {
+				g.Expect(rolloutUpdates).To(gomega.HaveLen(len(tt.wantRolloutUpdates)))
+				for key, value := range tt.wantRolloutUpdates {
+					g.Expect(rolloutUpdates).To(gomega.HaveKey(key))
+					if value != nil {
+						g.Expect(rolloutUpdates[key]).To(gomega.Equal(value))
+					}
+				}
+			}
+		})
+	}
+}
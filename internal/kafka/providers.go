@@ -40,12 +40,14 @@ func ConfigProviders() di.Option {
 
 		// Configuration for the Kafka service...
 		di.Provide(config.NewAWSConfig, di.As(new(environments2.ConfigModule))),
+		di.Provide(config.NewEKSConfig, di.As(new(environments2.ConfigModule))),
 		di.Provide(config.NewGCPConfig, di.As(new(environments2.ConfigModule)), di.As(new(environments2.ServiceValidator))),
 
 		di.Provide(config.NewSupportedProvidersConfig, di.As(new(environments2.ConfigModule)), di.As(new(environments2.ServiceValidator))),
 		di.Provide(observatoriumClient.NewObservabilityConfigurationConfig, di.As(new(environments2.ConfigModule))),
 		di.Provide(config.NewKafkaConfig, di.As(new(environments2.ConfigModule)), di.As(new(environments2.ServiceValidator))),
 		di.Provide(config.NewDataplaneClusterConfig, di.As(new(environments2.ConfigModule)), di.As(new(environments2.ServiceValidator))),
+		di.Provide(config.NewClusterPlacementConfig, di.As(new(environments2.ConfigModule)), di.As(new(environments2.ServiceValidator))),
 		di.Provide(config.NewKasFleetshardConfig, di.As(new(environments2.ConfigModule))),
 		di.Provide(quota_management.NewQuotaManagementListConfig, di.As(new(environments2.ConfigModule))),
 		di.Provide(acl.NewEnterpriseClusterRegistrationAccessControlListConfig, di.As(new(environments2.ConfigModule))),
@@ -69,6 +71,10 @@ func ServiceProviders() di.Option {
 		di.Provide(services.NewClusterPlacementStrategy),
 		di.Provide(services.NewDataPlaneClusterService, di.As(new(services.DataPlaneClusterService))),
 		di.Provide(services.NewDataPlaneKafkaService, di.As(new(services.DataPlaneKafkaService))),
+		di.Provide(services.NewMaintenanceWindowService),
+		di.Provide(services.NewKafkaUpgradeRolloutService),
+		di.Provide(services.NewQuotaListService),
+		di.Provide(services.NewQuotaListImporter, di.As(new(environments2.BootService))),
 		di.Provide(handlers.NewAuthenticationBuilder),
 		di.Provide(clusters.NewDefaultProviderFactory, di.As(new(clusters.ProviderFactory))),
 		di.Provide(routes.NewRouteLoader),
@@ -85,6 +91,11 @@ func ServiceProviders() di.Option {
 		di.Provide(kafka_mgrs.NewProvisioningKafkaManager, di.As(new(workers.Worker))),
 		di.Provide(kafka_mgrs.NewReadyKafkaManager, di.As(new(workers.Worker))),
 		di.Provide(kafka_mgrs.NewKafkaCNAMEManager, di.As(new(workers.Worker))),
+		di.Provide(kafka_mgrs.NewSuspendingKafkaManager, di.As(new(workers.Worker))),
+		di.Provide(kafka_mgrs.NewResumingKafkaManager, di.As(new(workers.Worker))),
+		di.Provide(kafka_mgrs.NewMigratingKafkaManager, di.As(new(workers.Worker))),
+		di.Provide(kafka_mgrs.NewMaintenanceWindowKafkaManager, di.As(new(workers.Worker))),
+		di.Provide(kafka_mgrs.NewUpgradeRolloutKafkaManager, di.As(new(workers.Worker))),
 		di.Provide(acl.NewEnterpriseClusterRegistrationAccessListMiddleware),
 	)
 }
@@ -132,6 +132,12 @@ func WithDeleted(deleted bool) KafkaRequestBuildOption {
 	}
 }
 
+func WithResourceVersion(version int64) KafkaRequestBuildOption {
+	return func(request *dbapi.KafkaRequest) {
+		request.ResourceVersion = version
+	}
+}
+
 func WithMultiAZ(multiaz bool) KafkaRequestBuildOption {
 	return func(request *dbapi.KafkaRequest) {
 		request.MultiAZ = multiaz
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"gorm.io/gorm"
+)
+
+type AuditEventOutcome string
+
+const (
+	// AuditEventOutcomeSuccess the mutation has been applied
+	AuditEventOutcomeSuccess AuditEventOutcome = "success"
+	// AuditEventOutcomeFailure the mutation has been rejected, e.g. because the user was not allowed to perform it
+	AuditEventOutcomeFailure AuditEventOutcome = "failure"
+	// AuditEventOutcomePending the request is being handled, or it never completed, e.g. because the server stopped
+	AuditEventOutcomePending AuditEventOutcome = "pending"
+)
+
+func (o AuditEventOutcome) String() string {
+	return string(o)
+}
+
+// AuditEvent records a create, update or delete request to the APIs of the control plane.
+// The events are recorded before their request is handled and completed with its outcome once it is. They are never
+// updated otherwise nor deleted so that they can be queried long after the resources are gone.
+type AuditEvent struct {
+	Meta
+	// Actor is the username of the user or of the service account that sent the request
+	Actor          string `gorm:"index"`
+	OrganisationId string `gorm:"index"`
+	// ResourceType is the collection of the resource in the path of the request, e.g. kafkas or kafka_connectors
+	ResourceType string `gorm:"index"`
+	ResourceId   string `gorm:"index"`
+	// Action is one of create, update or delete
+	Action string
+	Method string
+	// Route is the path template of the request, e.g. /api/kafkas_mgmt/v1/kafkas/{id}
+	Route string
+	// Diff is the difference between the resource before and after the request, if it could be looked up
+	Diff       string
+	RequestId  string `gorm:"index"`
+	StatusCode int
+	Outcome    string
+}
+
+type AuditEventList []*AuditEvent
+
+func (event *AuditEvent) BeforeCreate(tx *gorm.DB) error {
+	if event.ID == "" {
+		event.ID = NewID()
+	}
+	return nil
+}
@@ -66,8 +66,11 @@ type TlsSpec struct {
 }
 
 type EndpointSpec struct {
-	BootstrapServerHost string   `json:"bootstrapServerHost"`
-	Tls                 *TlsSpec `json:"tls,omitempty"`
+	BootstrapServerHost string `json:"bootstrapServerHost"`
+	// AdditionalBootstrapServerHosts are served by the kafka in addition to its bootstrap host and must be in the SANs
+	// of its certificate, e.g. the bootstrap host of the primary of a disaster recovery standby so that it can be failed over
+	AdditionalBootstrapServerHosts []string `json:"additionalBootstrapServerHosts,omitempty"`
+	Tls                            *TlsSpec `json:"tls,omitempty"`
 }
 
 type ServiceAccount struct {
@@ -76,14 +79,36 @@ type ServiceAccount struct {
 	Password  string `json:"password"`
 }
 
+// MirrorMaker2Spec configures the MirrorMaker2 replication of a disaster recovery standby from its primary
+type MirrorMaker2Spec struct {
+	SourceClusterAlias        string `json:"sourceClusterAlias"`
+	SourceBootstrapServerHost string `json:"sourceBootstrapServerHost"`
+	Topics                    string `json:"topics"`
+	Groups                    string `json:"groups"`
+	SyncGroupOffsets          bool   `json:"syncGroupOffsets"`
+	// SourceAuthentication is the SASL OAUTHBEARER authentication of MirrorMaker2 to the source cluster
+	SourceAuthentication *MirrorMaker2AuthenticationSpec `json:"sourceAuthentication,omitempty"`
+	// SourceTlsTrustedCertificate is the certificate trusted by MirrorMaker2 for the TLS connections to the source cluster
+	SourceTlsTrustedCertificate *string `json:"sourceTlsTrustedCertificate,omitempty"`
+}
+
+// MirrorMaker2AuthenticationSpec configures the SASL OAUTHBEARER authentication of MirrorMaker2 to the source cluster
+type MirrorMaker2AuthenticationSpec struct {
+	TokenEndpointURI      string  `json:"tokenEndpointURI"`
+	ClientID              string  `json:"clientId"`
+	ClientSecret          string  `json:"clientSecret"`
+	TlsTrustedCertificate *string `json:"tlsTrustedCertificate,omitempty"`
+}
+
 type ManagedKafkaSpec struct {
-	Capacity        Capacity         `json:"capacity"`
-	OAuth           OAuthSpec        `json:"oauth"`
-	Endpoint        EndpointSpec     `json:"endpoint"`
-	Versions        VersionsSpec     `json:"versions"`
-	Deleted         bool             `json:"deleted"`
-	Owners          []string         `json:"owners"`
-	ServiceAccounts []ServiceAccount `json:"service_accounts"`
+	Capacity        Capacity          `json:"capacity"`
+	OAuth           OAuthSpec         `json:"oauth"`
+	Endpoint        EndpointSpec      `json:"endpoint"`
+	Versions        VersionsSpec      `json:"versions"`
+	Deleted         bool              `json:"deleted"`
+	Owners          []string          `json:"owners"`
+	ServiceAccounts []ServiceAccount  `json:"service_accounts"`
+	MirrorMaker2    *MirrorMaker2Spec `json:"mirrorMaker2,omitempty"`
 }
 
 type ManagedKafka struct {
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"gorm.io/gorm"
+)
+
+// OidcClientRegistration is a client registered with the registration endpoint of a generic OIDC provider.
+// The provider does not store the owner of its clients, so the registration is kept to list the service accounts
+// of an organisation and to manage the client with its registration access token (RFC 7592)
+type OidcClientRegistration struct {
+	Meta
+	ClientId       string `gorm:"uniqueIndex"`
+	Name           string
+	Description    string
+	Owner          string
+	OwnerAccountId string
+	OrganisationId string `gorm:"index"`
+	// Internal is set for the service accounts of the fleet manager, e.g. the canary and the fleetshard agents.
+	// They are neither listed nor counted against the service accounts limit of the organisation
+	Internal bool
+	// RegistrationAccessToken is the encrypted bearer token used to read, update and delete the client at its RegistrationClientURI
+	RegistrationAccessToken string
+	RegistrationClientURI   string
+}
+
+type OidcClientRegistrationList []*OidcClientRegistration
+
+func (registration *OidcClientRegistration) BeforeCreate(tx *gorm.DB) error {
+	if registration.ID == "" {
+		registration.ID = NewID()
+	}
+	return nil
+}
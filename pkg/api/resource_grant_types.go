@@ -0,0 +1,43 @@
+package api
+
+import (
+	"gorm.io/gorm"
+)
+
+type GrantResourceType string
+
+const (
+	// GrantResourceTypeOrganisation the grant gives a role on all the resources of the organisation
+	GrantResourceTypeOrganisation GrantResourceType = "organisation"
+	// GrantResourceTypeKafka the grant gives a role on a single Kafka instance
+	GrantResourceTypeKafka GrantResourceType = "kafka"
+	// GrantResourceTypeConnector the grant gives a role on a single connector
+	GrantResourceTypeConnector GrantResourceType = "connector"
+)
+
+func (t GrantResourceType) String() string {
+	return string(t)
+}
+
+// ResourceGrant gives a role to a user of an organisation, either on the whole organisation or on one of its resources.
+// The resource id of the grants on the organisation is the id of the organisation.
+type ResourceGrant struct {
+	Meta
+	OrganisationId string `gorm:"uniqueIndex:idx_resource_grants_subject_resource"`
+	// Subject is the username of the user or of the service account the role is granted to
+	Subject      string `gorm:"uniqueIndex:idx_resource_grants_subject_resource"`
+	ResourceType string `gorm:"uniqueIndex:idx_resource_grants_subject_resource"`
+	ResourceId   string `gorm:"uniqueIndex:idx_resource_grants_subject_resource"`
+	Role         string
+	// CreatedBy is the username of the user that granted the role
+	CreatedBy string
+}
+
+type ResourceGrantList []*ResourceGrant
+
+func (grant *ResourceGrant) BeforeCreate(tx *gorm.DB) error {
+	if grant.ID == "" {
+		grant.ID = NewID()
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending the event has not been delivered yet, it is retried until the maximum number of attempts is reached
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryDelivered the webhook endpoint acknowledged the event with a 2xx response
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryDeadLetter the event could not be delivered within the maximum number of attempts
+	WebhookDeliveryDeadLetter WebhookDeliveryStatus = "dead_letter"
+)
+
+func (s WebhookDeliveryStatus) String() string {
+	return string(s)
+}
+
+// OutboxEvent is a lifecycle event recorded in the same transaction as the state change it describes.
+// Its fields map to the attributes of the CloudEvent delivered to the webhook endpoints.
+type OutboxEvent struct {
+	Meta
+	Type           string
+	Source         string
+	Subject        string
+	OrganisationId string `gorm:"index"`
+	Data           JSON   `gorm:"type:jsonb"`
+	// DispatchedAt is set once a delivery has been scheduled for each webhook endpoint of the organisation
+	DispatchedAt *time.Time `gorm:"index"`
+}
+
+type OutboxEventList []*OutboxEvent
+
+func (event *OutboxEvent) BeforeCreate(tx *gorm.DB) error {
+	if event.ID == "" {
+		event.ID = NewID()
+	}
+	return nil
+}
+
+// WebhookEndpoint is an URL registered by an organisation to be notified of the lifecycle events of its resources
+type WebhookEndpoint struct {
+	Meta
+	OrganisationId string `gorm:"index"`
+	Owner          string
+	URL            string
+	Description    string
+	// Secret is used to sign the events delivered to the endpoint. It is only returned when the endpoint is registered.
+	Secret string
+}
+
+type WebhookEndpointList []*WebhookEndpoint
+
+func (webhook *WebhookEndpoint) BeforeCreate(tx *gorm.DB) error {
+	webhook.ID = NewID()
+	return nil
+}
+
+// WebhookDelivery tracks the delivery of an event to a webhook endpoint
+type WebhookDelivery struct {
+	Meta
+	WebhookId     string `gorm:"index"`
+	EventId       string
+	EventType     string
+	Status        string `gorm:"index"`
+	Attempts      int
+	NextAttemptAt *time.Time `gorm:"index"`
+	LastError     string
+	DeliveredAt   *time.Time
+}
+
+type WebhookDeliveryList []*WebhookDelivery
+
+func (delivery *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	delivery.ID = NewID()
+	return nil
+}
@@ -17,6 +17,9 @@ const (
 	// FilterByOrganisation is used to determine whether resources are filtered by a user's organisation or as an individual owner
 	contextFilterByOrganisation contextKey = "filter-by-organisation"
 	contextIsAdmin              contextKey = "is_admin"
+	// IsGranted is set when the request has been allowed by a role granted to the user on the resources of its organisation,
+	// in which case the resource does not need to be owned by the user
+	contextIsGranted contextKey = "is_granted"
 )
 
 func GetIsAdminFromContext(ctx context.Context) bool {
@@ -35,6 +38,18 @@ func SetIsAdminContext(ctx context.Context, isAdmin bool) context.Context {
 	return context.WithValue(ctx, contextIsAdmin, isAdmin)
 }
 
+func SetIsGrantedContext(ctx context.Context, isGranted bool) context.Context {
+	return context.WithValue(ctx, contextIsGranted, isGranted)
+}
+
+func GetIsGrantedFromContext(ctx context.Context) bool {
+	isGranted := ctx.Value(contextIsGranted)
+	if isGranted == nil {
+		return false
+	}
+	return isGranted.(bool)
+}
+
 func GetFilterByOrganisationFromContext(ctx context.Context) bool {
 	filterByOrganisation := ctx.Value(contextFilterByOrganisation)
 	if filterByOrganisation == nil {
@@ -8,6 +8,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	shared "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	arrayUtils "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 	"gopkg.in/yaml.v2"
@@ -16,8 +17,11 @@ import (
 var _ environments.ConfigModule = (*AdminRoleAuthZConfig)(nil)
 
 // RolesConfiguration is the configuration of required roles per HTTP method of the admin API.
+// When the route is set, the roles only apply to the requests to the route template, e.g. /api/kafkas_mgmt/v1/admin/kafkas/{id},
+// in place of the roles configured for the HTTP method.
 type RolesConfiguration struct {
 	HTTPMethod string   `yaml:"method"`
+	Route      string   `yaml:"route,omitempty"`
 	RoleNames  []string `yaml:"roles"`
 }
 
@@ -48,18 +52,80 @@ func (c *AdminRoleAuthZConfig) ReadFiles() error {
 	return readRoleAuthZConfigFile(c.RolesConfigFile, &c.RolesConfig)
 }
 
-// GetRoleMapping will create a map of the required roles. The key will be the HTTP method and value will be a list of
-// allowed roles for that specific HTTP method.
-func (c *AdminRoleAuthZConfig) GetRoleMapping() map[string][]string {
-	roleMapping := make(map[string][]string, len(c.RolesConfig))
+// RoleMapping is the mapping of the roles allowed to send requests to the admin API
+type RoleMapping struct {
+	// methodRoles are the allowed roles per HTTP method
+	methodRoles map[string][]string
+	// routeRoles are the allowed roles per HTTP method and route template
+	routeRoles map[string]map[string][]string
+}
+
+// GetAllowedRoles returns the roles allowed to send a request with the given HTTP method to the given route template.
+// The roles configured for the route take precedence over the roles configured for the HTTP method.
+// It returns false if no roles are configured for the request.
+func (m RoleMapping) GetAllowedRoles(method string, routeTemplate string) ([]string, bool) {
+	if roles, ok := m.routeRoles[method][routeTemplate]; ok {
+		return roles, true
+	}
+	roles, ok := m.methodRoles[method]
+	return roles, ok
+}
+
+// GetRoleMapping will create the mapping of the required roles from the configured roles of the HTTP methods and
+// of the routes.
+func (c *AdminRoleAuthZConfig) GetRoleMapping() RoleMapping {
+	roleMapping := RoleMapping{
+		methodRoles: make(map[string][]string, len(c.RolesConfig)),
+		routeRoles:  map[string]map[string][]string{},
+	}
 
 	for _, config := range c.RolesConfig {
-		roleMapping[config.HTTPMethod] = config.RoleNames
+		if config.Route == "" {
+			roleMapping.methodRoles[config.HTTPMethod] = config.RoleNames
+			continue
+		}
+		if _, ok := roleMapping.routeRoles[config.HTTPMethod]; !ok {
+			roleMapping.routeRoles[config.HTTPMethod] = map[string][]string{}
+		}
+		roleMapping.routeRoles[config.HTTPMethod][config.Route] = config.RoleNames
 	}
 
 	return roleMapping
 }
 
+// UnmatchedRoutes returns the configured roles of the routes that match no route template of the router with the same
+// HTTP method. Their roles are never applied: the requests to the mistyped routes are authorized by the roles of the
+// HTTP method instead.
+func (c *AdminRoleAuthZConfig) UnmatchedRoutes(router *mux.Router) ([]RolesConfiguration, error) {
+	registered := map[string]bool{}
+	err := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			// the subrouters do not serve any request
+			return nil
+		}
+		for _, method := range methods {
+			registered[fmt.Sprintf("%s %s", method, template)] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var unmatched []RolesConfiguration
+	for _, config := range c.RolesConfig {
+		if config.Route != "" && !registered[fmt.Sprintf("%s %s", config.HTTPMethod, config.Route)] {
+			unmatched = append(unmatched, config)
+		}
+	}
+	return unmatched, nil
+}
+
 func readRoleAuthZConfigFile(file string, val *RoleConfig) error {
 	fileContents, err := shared.ReadFile(file)
 	if err != nil {
@@ -80,11 +146,20 @@ func (c *AdminRoleAuthZConfig) Validate(env *environments.Env) error {
 var allowedHTTPMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
 
 func validateRolesConfiguration(configs []RolesConfiguration) error {
+	configured := map[string]bool{}
 	for _, config := range configs {
 		if !arrayUtils.Contains(allowedHTTPMethods, config.HTTPMethod) {
 			return fmt.Errorf("invalid http method used %q, expected to be one of [%s]",
 				config.HTTPMethod, strings.Join(allowedHTTPMethods, ","))
 		}
+		if config.Route != "" && !strings.HasPrefix(config.Route, "/") {
+			return fmt.Errorf("invalid route %q for http method %q, expected to be an absolute route template", config.Route, config.HTTPMethod)
+		}
+		key := fmt.Sprintf("%s %s", config.HTTPMethod, config.Route)
+		if configured[key] {
+			return fmt.Errorf("roles of http method %q and route %q are configured more than once", config.HTTPMethod, config.Route)
+		}
+		configured[key] = true
 	}
 	return nil
 }
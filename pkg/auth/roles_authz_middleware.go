@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/golang/glog"
+	"github.com/gorilla/mux"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
@@ -14,12 +15,13 @@ import (
 type RolesAuthorizationMiddleware interface {
 	// RequireRealmRole will check the given realm role exists in the request token
 	RequireRealmRole(roleName string, code errors.ServiceErrorCode) func(handler http.Handler) http.Handler
-	// RequireRolesForMethods will check that at least one of the realm roles exists in the request token based on the http method in the request
+	// RequireRolesForMethods will check that at least one of the realm roles exists in the request token based on the http method
+	// and on the route template of the request
 	RequireRolesForMethods(code errors.ServiceErrorCode) func(handler http.Handler) http.Handler
 }
 
 type rolesAuthMiddleware struct {
-	roleMapping map[string][]string
+	roleMapping RoleMapping
 }
 
 var _ RolesAuthorizationMiddleware = &rolesAuthMiddleware{}
@@ -56,7 +58,11 @@ func (m *rolesAuthMiddleware) RequireRolesForMethods(code errors.ServiceErrorCod
 		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 			serviceErr := errors.New(code, "")
 			method := request.Method
-			allowedRoles, ok := m.roleMapping[method]
+			var routeTemplate string
+			if route := mux.CurrentRoute(request); route != nil {
+				routeTemplate, _ = route.GetPathTemplate()
+			}
+			allowedRoles, ok := m.roleMapping.GetAllowedRoles(method, routeTemplate)
 			if !ok {
 				// no allowed roles defined for the given method, deny the request by default to be safer
 				glog.Infof("no allowed roles defined for method %s, deny the request for url %s", method, request.URL)
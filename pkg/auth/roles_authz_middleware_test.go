@@ -3,11 +3,13 @@ package auth
 import (
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/mux"
 	"github.com/openshift-online/ocm-sdk-go/authentication"
 )
 
@@ -181,3 +183,162 @@ func TestRolesAuthMiddleware_RequireRolesForMethods(t *testing.T) {
 		})
 	}
 }
+
+func TestRolesAuthMiddleware_RequireRolesForRoutes(t *testing.T) {
+	rolesConfig := []RolesConfiguration{
+		{
+			HTTPMethod: http.MethodGet,
+			RoleNames:  []string{"read", "write"},
+		},
+		{
+			HTTPMethod: http.MethodDelete,
+			RoleNames:  []string{"write"},
+		},
+		{
+			HTTPMethod: http.MethodDelete,
+			Route:      "/admin/kafkas/{id}",
+			RoleNames:  []string{"full"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		role   string
+		method string
+		url    string
+		want   int
+	}{
+		{
+			name:   "should allow access with the roles of the method when the route has no roles",
+			role:   "write",
+			method: http.MethodDelete,
+			url:    "http://example.com/admin/kafka_connectors/123",
+			want:   http.StatusOK,
+		},
+		{
+			name:   "should allow access with the roles of the route",
+			role:   "full",
+			method: http.MethodDelete,
+			url:    "http://example.com/admin/kafkas/123",
+			want:   http.StatusOK,
+		},
+		{
+			name:   "should not allow access with the roles of the method when the route has roles",
+			role:   "write",
+			method: http.MethodDelete,
+			url:    "http://example.com/admin/kafkas/123",
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "should only apply the roles of the route to its method",
+			role:   "read",
+			method: http.MethodGet,
+			url:    "http://example.com/admin/kafkas/123",
+			want:   http.StatusOK,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			token := &jwt.Token{
+				Claims: jwt.MapClaims{
+					"realm_access": map[string]interface{}{
+						"roles": []interface{}{tt.role},
+					},
+				},
+			}
+			next := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				shared.WriteJSONResponse(writer, http.StatusOK, "")
+			})
+			router := mux.NewRouter()
+			router.Use(NewRolesAuthzMiddleware(&AdminRoleAuthZConfig{RolesConfig: rolesConfig}).RequireRolesForMethods(errors.ErrorUnauthenticated))
+			router.Handle("/admin/kafkas/{id}", next)
+			router.Handle("/admin/kafka_connectors/{id}", next)
+			toTest := setContextToken(router, token)
+			recorder := httptest.NewRecorder()
+			toTest.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.url, nil))
+			resp := recorder.Result()
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("expected status code %d but got %d", tt.want, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestAdminRoleAuthZConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		rolesConfig []RolesConfiguration
+		wantErr     bool
+	}{
+		{
+			name: "should accept the roles of a method and of its routes",
+			rolesConfig: []RolesConfiguration{
+				{HTTPMethod: http.MethodDelete, RoleNames: []string{"full"}},
+				{HTTPMethod: http.MethodDelete, Route: "/api/kafkas_mgmt/v1/admin/kafkas/{id}", RoleNames: []string{"full"}},
+			},
+		},
+		{
+			name: "should reject an unknown method",
+			rolesConfig: []RolesConfiguration{
+				{HTTPMethod: "TRACE", RoleNames: []string{"full"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should reject a relative route",
+			rolesConfig: []RolesConfiguration{
+				{HTTPMethod: http.MethodDelete, Route: "admin/kafkas/{id}", RoleNames: []string{"full"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should reject the roles of a route configured twice",
+			rolesConfig: []RolesConfiguration{
+				{HTTPMethod: http.MethodDelete, Route: "/api/kafkas_mgmt/v1/admin/kafkas/{id}", RoleNames: []string{"full"}},
+				{HTTPMethod: http.MethodDelete, Route: "/api/kafkas_mgmt/v1/admin/kafkas/{id}", RoleNames: []string{"write"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			config := &AdminRoleAuthZConfig{RolesConfig: tt.rolesConfig}
+			if err := config.Validate(nil); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAdminRoleAuthZConfig_UnmatchedRoutes(t *testing.T) {
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {})
+	router := mux.NewRouter()
+	adminRouter := router.PathPrefix("/api/kafkas_mgmt/v1/admin").Subrouter()
+	adminRouter.HandleFunc("/kafkas/{id}", handler).Methods(http.MethodGet, http.MethodDelete)
+
+	config := &AdminRoleAuthZConfig{
+		RolesConfig: []RolesConfiguration{
+			{HTTPMethod: http.MethodDelete, RoleNames: []string{"full"}},
+			{HTTPMethod: http.MethodDelete, Route: "/api/kafkas_mgmt/v1/admin/kafkas/{id}", RoleNames: []string{"full"}},
+			// mistyped route variable
+			{HTTPMethod: http.MethodGet, Route: "/api/kafkas_mgmt/v1/admin/kafkas/{kafka_id}", RoleNames: []string{"full"}},
+			// no PATCH route is registered
+			{HTTPMethod: http.MethodPatch, Route: "/api/kafkas_mgmt/v1/admin/kafkas/{id}", RoleNames: []string{"full"}},
+		},
+	}
+
+	unmatched, err := config.UnmatchedRoutes(router)
+	if err != nil {
+		t.Fatalf("UnmatchedRoutes() error = %v", err)
+	}
+	if !reflect.DeepEqual(unmatched, []RolesConfiguration(config.RolesConfig[2:])) {
+		t.Errorf("UnmatchedRoutes() = %v, want %v", unmatched, config.RolesConfig[2:])
+	}
+}
@@ -0,0 +1,258 @@
+package aws
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"github.com/onsi/gomega"
+)
+
+var (
+	eksNotFoundErr = awserr.New(eks.ErrCodeResourceNotFoundException, "not found", nil)
+	eksServerErr   = awserr.New(eks.ErrCodeServerException, "server error", nil)
+)
+
+// fakeEKSAPI overrides the handful of EKS API calls used by the client. Any other call panics.
+type fakeEKSAPI struct {
+	eksiface.EKSAPI
+	describeClusterFn     func(*eks.DescribeClusterInput) (*eks.DescribeClusterOutput, error)
+	deleteClusterFn       func(*eks.DeleteClusterInput) (*eks.DeleteClusterOutput, error)
+	describeNodegroupFn   func(*eks.DescribeNodegroupInput) (*eks.DescribeNodegroupOutput, error)
+	deleteNodegroupFn     func(*eks.DeleteNodegroupInput) (*eks.DeleteNodegroupOutput, error)
+	listNodegroupsPagesFn func(*eks.ListNodegroupsInput, func(*eks.ListNodegroupsOutput, bool) bool) error
+}
+
+func (f *fakeEKSAPI) DescribeCluster(in *eks.DescribeClusterInput) (*eks.DescribeClusterOutput, error) {
+	return f.describeClusterFn(in)
+}
+
+func (f *fakeEKSAPI) DeleteCluster(in *eks.DeleteClusterInput) (*eks.DeleteClusterOutput, error) {
+	return f.deleteClusterFn(in)
+}
+
+func (f *fakeEKSAPI) DescribeNodegroup(in *eks.DescribeNodegroupInput) (*eks.DescribeNodegroupOutput, error) {
+	return f.describeNodegroupFn(in)
+}
+
+func (f *fakeEKSAPI) DeleteNodegroup(in *eks.DeleteNodegroupInput) (*eks.DeleteNodegroupOutput, error) {
+	return f.deleteNodegroupFn(in)
+}
+
+func (f *fakeEKSAPI) ListNodegroupsPages(in *eks.ListNodegroupsInput, fn func(*eks.ListNodegroupsOutput, bool) bool) error {
+	return f.listNodegroupsPagesFn(in, fn)
+}
+
+func TestEKSClient_NewEKSClientFromFactory(t *testing.T) {
+	g := gomega.NewWithT(t)
+	c, err := NewDefaultEKSClientFactory().NewEKSClient(testConfig, "us-east-1")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(c).ToNot(gomega.BeNil())
+}
+
+func TestEKSClient_DescribeCluster(t *testing.T) {
+	tests := []struct {
+		name    string
+		api     *fakeEKSAPI
+		want    *eks.Cluster
+		wantErr bool
+	}{
+		{
+			name: "should return the cluster",
+			api: &fakeEKSAPI{
+				describeClusterFn: func(in *eks.DescribeClusterInput) (*eks.DescribeClusterOutput, error) {
+					return &eks.DescribeClusterOutput{Cluster: &eks.Cluster{Name: in.Name}}, nil
+				},
+			},
+			want: &eks.Cluster{Name: aws.String(testValue)},
+		},
+		{
+			name: "should return nil when the cluster does not exist",
+			api: &fakeEKSAPI{
+				describeClusterFn: func(in *eks.DescribeClusterInput) (*eks.DescribeClusterOutput, error) {
+					return nil, eksNotFoundErr
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "should return an error when the EKS API fails",
+			api: &fakeEKSAPI{
+				describeClusterFn: func(in *eks.DescribeClusterInput) (*eks.DescribeClusterOutput, error) {
+					return nil, eksServerErr
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			c := &eksCl{eksClient: tt.api}
+			got, err := c.DescribeCluster(testValue)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestEKSClient_DeleteCluster(t *testing.T) {
+	tests := []struct {
+		name    string
+		api     *fakeEKSAPI
+		wantNil bool
+		wantErr bool
+	}{
+		{
+			name: "should return the deleting cluster",
+			api: &fakeEKSAPI{
+				deleteClusterFn: func(in *eks.DeleteClusterInput) (*eks.DeleteClusterOutput, error) {
+					return &eks.DeleteClusterOutput{Cluster: &eks.Cluster{Name: in.Name, Status: aws.String(eks.ClusterStatusDeleting)}}, nil
+				},
+			},
+		},
+		{
+			name: "should return nil when the cluster is already gone",
+			api: &fakeEKSAPI{
+				deleteClusterFn: func(in *eks.DeleteClusterInput) (*eks.DeleteClusterOutput, error) {
+					return nil, eksNotFoundErr
+				},
+			},
+			wantNil: true,
+		},
+		{
+			name: "should return an error when the EKS API fails",
+			api: &fakeEKSAPI{
+				deleteClusterFn: func(in *eks.DeleteClusterInput) (*eks.DeleteClusterOutput, error) {
+					return nil, eksServerErr
+				},
+			},
+			wantNil: true,
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			c := &eksCl{eksClient: tt.api}
+			got, err := c.DeleteCluster(testValue)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got == nil).To(gomega.Equal(tt.wantNil))
+		})
+	}
+}
+
+func TestEKSClient_DescribeNodegroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		api     *fakeEKSAPI
+		wantNil bool
+		wantErr bool
+	}{
+		{
+			name: "should return the node group",
+			api: &fakeEKSAPI{
+				describeNodegroupFn: func(in *eks.DescribeNodegroupInput) (*eks.DescribeNodegroupOutput, error) {
+					return &eks.DescribeNodegroupOutput{Nodegroup: &eks.Nodegroup{NodegroupName: in.NodegroupName}}, nil
+				},
+			},
+		},
+		{
+			name: "should return nil when the node group does not exist",
+			api: &fakeEKSAPI{
+				describeNodegroupFn: func(in *eks.DescribeNodegroupInput) (*eks.DescribeNodegroupOutput, error) {
+					return nil, eksNotFoundErr
+				},
+			},
+			wantNil: true,
+		},
+		{
+			name: "should return an error when the EKS API fails",
+			api: &fakeEKSAPI{
+				describeNodegroupFn: func(in *eks.DescribeNodegroupInput) (*eks.DescribeNodegroupOutput, error) {
+					return nil, eksServerErr
+				},
+			},
+			wantNil: true,
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			c := &eksCl{eksClient: tt.api}
+			got, err := c.DescribeNodegroup(testValue, testValue)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got == nil).To(gomega.Equal(tt.wantNil))
+		})
+	}
+}
+
+func TestEKSClient_ListNodegroups(t *testing.T) {
+	tests := []struct {
+		name    string
+		api     *fakeEKSAPI
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "should return the node groups of every page",
+			api: &fakeEKSAPI{
+				listNodegroupsPagesFn: func(in *eks.ListNodegroupsInput, fn func(*eks.ListNodegroupsOutput, bool) bool) error {
+					fn(&eks.ListNodegroupsOutput{Nodegroups: aws.StringSlice([]string{"a", "b"})}, false)
+					fn(&eks.ListNodegroupsOutput{Nodegroups: aws.StringSlice([]string{"c"})}, true)
+					return nil
+				},
+			},
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "should return an error when the EKS API fails",
+			api: &fakeEKSAPI{
+				listNodegroupsPagesFn: func(in *eks.ListNodegroupsInput, fn func(*eks.ListNodegroupsOutput, bool) bool) error {
+					return eksServerErr
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			c := &eksCl{eksClient: tt.api}
+			got, err := c.ListNodegroups(testValue)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestEKSClient_GetClusterToken(t *testing.T) {
+	g := gomega.NewWithT(t)
+	c, err := newEKSClient(testConfig, "us-east-1")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	token, err := c.GetClusterToken("my-cluster")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(strings.HasPrefix(token, eksTokenPrefix)).To(gomega.BeTrue())
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, eksTokenPrefix))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	presignedURL, err := url.Parse(string(decoded))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(presignedURL.Query().Get("Action")).To(gomega.Equal("GetCallerIdentity"))
+	g.Expect(presignedURL.Query().Get("X-Amz-SignedHeaders")).To(gomega.ContainSubstring(eksClusterIDHeader))
+}
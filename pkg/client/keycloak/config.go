@@ -14,6 +14,7 @@ import (
 const (
 	MAS_SSO                       string = "mas_sso"
 	REDHAT_SSO                    string = "redhat_sso"
+	OIDC_SSO                      string = "oidc"
 	INTERNAL_SSO_REALM            string = "internal_sso"
 	SSO_SPEICAL_MGMT_ORG_ID_STAGE string = "13640203"
 	//AUTH_SSO SSOProvider ="auth_sso"
@@ -33,6 +34,7 @@ type KeycloakConfig struct {
 	KafkaRealm                                 *KeycloakRealmConfig `json:"kafka_realm"`
 	OSDClusterIDPRealm                         *KeycloakRealmConfig `json:"osd_cluster_idp_realm"`
 	RedhatSSORealm                             *KeycloakRealmConfig `json:"redhat_sso_config"`
+	OIDCRealm                                  *KeycloakRealmConfig `json:"oidc_config"`
 	AdminAPISSORealm                           *KeycloakRealmConfig `json:"internal_sso_config"`
 	MaxAllowedServiceAccounts                  int                  `json:"max_allowed_service_accounts"`
 	MaxLimitForGetClients                      int                  `json:"max_limit_for_get_clients"`
@@ -40,6 +42,10 @@ type KeycloakConfig struct {
 	SSOSpecialManagementOrgID                  string               `json:"-"`
 	ServiceAccounttLimitCheckSkipOrgIdListFile string               `json:"-"`
 	ServiceAccounttLimitCheckSkipOrgIdList     []string             `json:"-"`
+	// OIDCRegistrationTokenKey is the key the registration access tokens of the clients of the generic OIDC provider are
+	// encrypted with in the database
+	OIDCRegistrationTokenKey     string `json:"-"`
+	OIDCRegistrationTokenKeyFile string `json:"-"`
 }
 
 type KeycloakRealmConfig struct {
@@ -64,6 +70,8 @@ func (kc *KeycloakConfig) SSOProviderRealm() *KeycloakRealmConfig {
 		return kc.KafkaRealm
 	case REDHAT_SSO:
 		return kc.RedhatSSORealm
+	case OIDC_SSO:
+		return kc.OIDCRealm
 	case INTERNAL_SSO_REALM:
 		return kc.AdminAPISSORealm
 	default:
@@ -99,6 +107,11 @@ func NewKeycloakConfig() *KeycloakConfig {
 			GrantType:        "client_credentials",
 			Scope:            "api.iam.service_accounts",
 		},
+		OIDCRealm: &KeycloakRealmConfig{
+			ClientIDFile:     "secrets/oidc-service.clientId",
+			ClientSecretFile: "secrets/oidc-service.clientSecret",
+			GrantType:        "client_credentials",
+		},
 		AdminAPISSORealm: &KeycloakRealmConfig{
 			BaseURL:        "https://auth.redhat.com",
 			APIEndpointURI: "/auth/realms/EmployeeIDP",
@@ -115,6 +128,7 @@ func NewKeycloakConfig() *KeycloakConfig {
 		SelectSSOProvider:                          MAS_SSO,
 		SSOSpecialManagementOrgID:                  SSO_SPEICAL_MGMT_ORG_ID_STAGE,
 		ServiceAccounttLimitCheckSkipOrgIdListFile: "config/service-account-limits-check-skip-org-id-list.yaml",
+		OIDCRegistrationTokenKeyFile:               "secrets/oidc-registration-token.key",
 	}
 	return kc
 }
@@ -141,15 +155,29 @@ func (kc *KeycloakConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&kc.SsoBaseUrl, "redhat-sso-base-url", kc.SsoBaseUrl, "The base URL of the mas-sso, integration by default")
 	fs.StringVar(&kc.SSOSpecialManagementOrgID, "sso-special-management-org-id", SSO_SPEICAL_MGMT_ORG_ID_STAGE, "The Special Management Organization ID used for creating internal Service accounts")
 	fs.StringVar(&kc.ServiceAccounttLimitCheckSkipOrgIdListFile, "service-account-limits-check-skip-org-id-list-file", kc.ServiceAccounttLimitCheckSkipOrgIdListFile, "File containing a list of Org IDs for which service account limits check will be skipped")
-	fs.StringVar(&kc.SelectSSOProvider, "sso-provider-type", kc.SelectSSOProvider, "Option to choose between sso providers i.e, mas_sso or redhat_sso, mas_sso by default")
+	fs.StringVar(&kc.SelectSSOProvider, "sso-provider-type", kc.SelectSSOProvider, "Option to choose between sso providers i.e, mas_sso, redhat_sso or oidc, mas_sso by default")
+	fs.StringVar(&kc.OIDCRealm.ValidIssuerURI, "oidc-issuer-url", kc.OIDCRealm.ValidIssuerURI, "Issuer URL of the generic OIDC provider, required when the sso provider type is oidc")
+	fs.StringVar(&kc.OIDCRealm.TokenEndpointURI, "oidc-token-endpoint-uri", kc.OIDCRealm.TokenEndpointURI, "Token endpoint of the generic OIDC provider")
+	fs.StringVar(&kc.OIDCRealm.JwksEndpointURI, "oidc-jwks-endpoint-uri", kc.OIDCRealm.JwksEndpointURI, "JWKS endpoint of the generic OIDC provider")
+	fs.StringVar(&kc.OIDCRealm.APIEndpointURI, "oidc-registration-endpoint-uri", kc.OIDCRealm.APIEndpointURI, "Dynamic client registration endpoint (RFC 7591) of the generic OIDC provider")
+	fs.StringVar(&kc.OIDCRealm.ClientIDFile, "oidc-client-id-file", kc.OIDCRealm.ClientIDFile, "File containing the client-id used to get the initial access token for the client registration endpoint of the generic OIDC provider. If the file does not exist the registration endpoint is called without access token")
+	fs.StringVar(&kc.OIDCRealm.ClientSecretFile, "oidc-client-secret-file", kc.OIDCRealm.ClientSecretFile, "File containing the client-secret used to get the initial access token for the client registration endpoint of the generic OIDC provider")
+	fs.StringVar(&kc.OIDCRealm.Scope, "oidc-scope", kc.OIDCRealm.Scope, "Scope for client credentials grant request in the generic OIDC provider")
+	fs.StringVar(&kc.OIDCRegistrationTokenKeyFile, "oidc-registration-token-key-file", kc.OIDCRegistrationTokenKeyFile, "File containing the key the registration access tokens of the clients of the generic OIDC provider are encrypted with in the database")
 	fs.StringVar(&kc.AdminAPISSORealm.BaseURL, "admin-api-sso-base-url", kc.AdminAPISSORealm.BaseURL, "Base url of admin api sso realm, 'https://auth.redhat.com' by default")
 	fs.StringVar(&kc.AdminAPISSORealm.APIEndpointURI, "admin-api-sso-endpoint-uri", kc.AdminAPISSORealm.APIEndpointURI, "API Endpoint URI of admin api sso realm, '/auth/realms/EmployeeIDP' by default")
 	fs.StringVar(&kc.AdminAPISSORealm.Realm, "admin-api-sso-realm", kc.AdminAPISSORealm.Realm, "Admin api sso realm, 'EmployeeIDP' by default")
 }
 
 func (kc *KeycloakConfig) Validate(env *environments.Env) error {
-	if kc.SelectSSOProvider != REDHAT_SSO && kc.SelectSSOProvider != MAS_SSO {
-		return fmt.Errorf("invalid sso provider selected must be `mas_sso` or `redhat_sso`")
+	if kc.SelectSSOProvider != REDHAT_SSO && kc.SelectSSOProvider != MAS_SSO && kc.SelectSSOProvider != OIDC_SSO {
+		return fmt.Errorf("invalid sso provider selected must be `mas_sso`, `redhat_sso` or `oidc`")
+	}
+	if kc.SelectSSOProvider == OIDC_SSO {
+		// the endpoints are not discovered from the issuer so that no request is sent to the provider at startup
+		if kc.OIDCRealm.ValidIssuerURI == "" || kc.OIDCRealm.TokenEndpointURI == "" || kc.OIDCRealm.JwksEndpointURI == "" || kc.OIDCRealm.APIEndpointURI == "" {
+			return fmt.Errorf("the issuer url, token, jwks and registration endpoints of the oidc provider must be set")
+		}
 	}
 	return nil
 }
@@ -185,6 +213,25 @@ func (kc *KeycloakConfig) ReadFiles() error {
 			return err
 		}
 	}
+	if kc.SelectSSOProvider == OIDC_SSO {
+		// The credentials are optional as the registration endpoint of the provider may be open.
+		// When they are not provided, the clients are registered without an initial access token
+		err = shared.ReadFileValueString(kc.OIDCRealm.ClientIDFile, &kc.OIDCRealm.ClientID)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		err = shared.ReadFileValueString(kc.OIDCRealm.ClientSecretFile, &kc.OIDCRealm.ClientSecret)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		err = shared.ReadFileValueString(kc.OIDCRegistrationTokenKeyFile, &kc.OIDCRegistrationTokenKey)
+		if err != nil {
+			return err
+		}
+		if kc.OIDCRegistrationTokenKey == "" {
+			return fmt.Errorf("the registration token key file '%s' of the oidc provider is empty", kc.OIDCRegistrationTokenKeyFile)
+		}
+	}
 	// We read the MAS SSO TLS certificate file. If it does not exist we
 	// intentionally continue as if it was not provided
 	err = shared.ReadFileValueString(kc.TLSTrustedCertificatesFile, &kc.TLSTrustedCertificatesValue)
@@ -209,6 +256,7 @@ func (kc *KeycloakConfig) ReadFiles() error {
 	kc.KafkaRealm.setDefaultURIs(kc.BaseURL)
 	kc.OSDClusterIDPRealm.setDefaultURIs(kc.BaseURL)
 	kc.RedhatSSORealm.setDefaultURIs(kc.SsoBaseUrl)
+	kc.OIDCRealm.BaseURL = kc.OIDCRealm.ValidIssuerURI
 	kc.AdminAPISSORealm.setDefaultURIs((kc.AdminAPISSORealm.BaseURL))
 	return nil
 }
@@ -0,0 +1,280 @@
+package oidc
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
+	"github.com/golang/glog"
+	"github.com/patrickmn/go-cache"
+	"github.com/pkg/errors"
+)
+
+const (
+	// access token duration before expiration
+	tokenLifeDuration    = 5 * time.Minute
+	cacheCleanupInterval = 299 * time.Second
+	requestTimeout       = 30 * time.Second
+)
+
+// ClientMetadata is the client metadata sent to the registration endpoint of the provider (RFC 7591 section 2)
+type ClientMetadata struct {
+	ClientName              string   `json:"client_name,omitempty"`
+	ClientSecret            string   `json:"client_secret,omitempty"`
+	GrantTypes              []string `json:"grant_types,omitempty"`
+	ResponseTypes           []string `json:"response_types,omitempty"`
+	RedirectURIs            []string `json:"redirect_uris,omitempty"`
+	TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method,omitempty"`
+	Scope                   string   `json:"scope,omitempty"`
+}
+
+// ClientRegistration is the client information returned by the registration endpoint of the provider (RFC 7591 section 3.2.1)
+type ClientRegistration struct {
+	ClientMetadata
+	ClientID                string `json:"client_id"`
+	ClientIDIssuedAt        int64  `json:"client_id_issued_at,omitempty"`
+	ClientSecretExpiresAt   int64  `json:"client_secret_expires_at,omitempty"`
+	RegistrationAccessToken string `json:"registration_access_token,omitempty"`
+	RegistrationClientURI   string `json:"registration_client_uri,omitempty"`
+}
+
+// RegistrationError is the error returned by the registration endpoint of the provider (RFC 7591 section 3.2.2)
+type RegistrationError struct {
+	StatusCode       int    `json:"-"`
+	Code             string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (e *RegistrationError) Error() string {
+	if e.ErrorDescription != "" {
+		return fmt.Sprintf("client registration request failed [%d]: %s: %s", e.StatusCode, e.Code, e.ErrorDescription)
+	}
+	return fmt.Sprintf("client registration request failed [%d]: %s", e.StatusCode, e.Code)
+}
+
+// IsNotFound returns true if the client is not registered in the provider anymore.
+// RFC 7592 section 2 requires the provider to answer with a 401 when the client does not exist
+func (e *RegistrationError) IsNotFound() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusNotFound
+}
+
+// IsNotFound returns true if err is a RegistrationError for a client that is not registered in the provider
+func IsNotFound(err error) bool {
+	if regErr, ok := err.(*RegistrationError); ok {
+		return regErr.IsNotFound()
+	}
+	return false
+}
+
+//go:generate moq -out client_moq.go . OIDCClient
+type OIDCClient interface {
+	// GetToken returns the initial access token sent to the registration endpoint.
+	// It is empty when no credentials are configured, i.e. the registration endpoint is open
+	GetToken() (string, error)
+	GetConfig() *keycloak.KeycloakConfig
+	GetRealmConfig() *keycloak.KeycloakRealmConfig
+	// RegisterClient registers a new client with the registration endpoint (RFC 7591)
+	RegisterClient(accessToken string, metadata ClientMetadata) (*ClientRegistration, error)
+	// GetClient reads the client from its client configuration endpoint (RFC 7592)
+	GetClient(registrationAccessToken string, registrationClientURI string) (*ClientRegistration, error)
+	// UpdateClient replaces the metadata of the client at its client configuration endpoint (RFC 7592)
+	UpdateClient(registrationAccessToken string, registrationClientURI string, clientID string, metadata ClientMetadata) (*ClientRegistration, error)
+	// DeleteClient deletes the client at its client configuration endpoint (RFC 7592)
+	DeleteClient(registrationAccessToken string, registrationClientURI string) error
+}
+
+func NewOIDCClient(config *keycloak.KeycloakConfig, realmConfig *keycloak.KeycloakRealmConfig) OIDCClient {
+	return &oidcClient{
+		config:      config,
+		realmConfig: realmConfig,
+		httpClient: &http.Client{
+			Timeout: requestTimeout,
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: config.InsecureSkipVerify,
+					RootCAs:            rootCAs(config.TLSTrustedCertificatesValue),
+				},
+			},
+		},
+		cache: cache.New(tokenLifeDuration, cacheCleanupInterval),
+	}
+}
+
+// rootCAs returns the system certificates with the trusted certificates of the sso provider, e.g. the CA of a provider
+// using a self-signed certificate. The system certificates are used when no certificate is trusted
+func rootCAs(trustedCertificates string) *x509.CertPool {
+	if trustedCertificates == "" {
+		return nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM([]byte(trustedCertificates)) {
+		glog.Warningf("no certificate could be read from the trusted certificates of the oidc provider")
+	}
+	return pool
+}
+
+var _ OIDCClient = &oidcClient{}
+
+type oidcClient struct {
+	config      *keycloak.KeycloakConfig
+	realmConfig *keycloak.KeycloakRealmConfig
+	httpClient  *http.Client
+	cache       *cache.Cache
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+	Scope       string `json:"scope"`
+}
+
+func (c *oidcClient) GetToken() (string, error) {
+	if c.realmConfig.ClientID == "" {
+		return "", nil
+	}
+
+	cachedTokenKey := fmt.Sprintf("%s%s", c.realmConfig.ValidIssuerURI, c.realmConfig.ClientID)
+	if cachedToken, isCached := c.cache.Get(cachedTokenKey); isCached {
+		if token, _ := cachedToken.(string); token != "" && !keycloak.IsJWTTokenExpired(token) {
+			return token, nil
+		}
+	}
+
+	parameters := url.Values{}
+	parameters.Set("grant_type", "client_credentials")
+	if c.realmConfig.Scope != "" {
+		parameters.Set("scope", c.realmConfig.Scope)
+	}
+	req, err := http.NewRequest(http.MethodPost, c.realmConfig.TokenEndpointURI, strings.NewReader(parameters.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(url.QueryEscape(c.realmConfig.ClientID), url.QueryEscape(c.realmConfig.ClientSecret))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Content-Length", strconv.Itoa(len(parameters.Encode())))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error getting token [%d]", resp.StatusCode)
+	}
+
+	var tokenData tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenData); err != nil {
+		return "", err
+	}
+	c.cache.Set(cachedTokenKey, tokenData.AccessToken, cacheCleanupInterval)
+	return tokenData.AccessToken, nil
+}
+
+func (c *oidcClient) GetConfig() *keycloak.KeycloakConfig {
+	return c.config
+}
+
+func (c *oidcClient) GetRealmConfig() *keycloak.KeycloakRealmConfig {
+	return c.realmConfig
+}
+
+func (c *oidcClient) RegisterClient(accessToken string, metadata ClientMetadata) (*ClientRegistration, error) {
+	var registration ClientRegistration
+	if err := c.do(http.MethodPost, c.realmConfig.APIEndpointURI, accessToken, metadata, http.StatusCreated, &registration); err != nil {
+		return nil, err
+	}
+	return &registration, nil
+}
+
+func (c *oidcClient) GetClient(registrationAccessToken string, registrationClientURI string) (*ClientRegistration, error) {
+	var registration ClientRegistration
+	if err := c.do(http.MethodGet, registrationClientURI, registrationAccessToken, nil, http.StatusOK, &registration); err != nil {
+		return nil, err
+	}
+	return &registration, nil
+}
+
+func (c *oidcClient) UpdateClient(registrationAccessToken string, registrationClientURI string, clientID string, metadata ClientMetadata) (*ClientRegistration, error) {
+	// the client_id must be sent with the update request (RFC 7592 section 2.2)
+	body := struct {
+		ClientMetadata
+		ClientID string `json:"client_id"`
+	}{
+		ClientMetadata: metadata,
+		ClientID:       clientID,
+	}
+	var registration ClientRegistration
+	if err := c.do(http.MethodPut, registrationClientURI, registrationAccessToken, body, http.StatusOK, &registration); err != nil {
+		return nil, err
+	}
+	return &registration, nil
+}
+
+func (c *oidcClient) DeleteClient(registrationAccessToken string, registrationClientURI string) error {
+	return c.do(http.MethodDelete, registrationClientURI, registrationAccessToken, nil, http.StatusNoContent, nil)
+}
+
+func (c *oidcClient) do(method string, endpoint string, accessToken string, body interface{}, expectedStatus int, result interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, endpoint, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if accessToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to send %s request to %s", method, endpoint)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != expectedStatus {
+		regErr := &RegistrationError{StatusCode: resp.StatusCode}
+		if data, readErr := io.ReadAll(resp.Body); readErr == nil && len(data) > 0 {
+			// the error body is optional, the status code is enough to report the failure
+			_ = json.Unmarshal(data, regErr)
+		}
+		if regErr.Code == "" {
+			regErr.Code = http.StatusText(resp.StatusCode)
+		}
+		return regErr
+	}
+
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
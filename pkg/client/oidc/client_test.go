@@ -0,0 +1,133 @@
+package oidc
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
+	"github.com/onsi/gomega"
+)
+
+func newTestClient(serverURL string, clientID string) OIDCClient {
+	return NewOIDCClient(&keycloak.KeycloakConfig{}, &keycloak.KeycloakRealmConfig{
+		ClientID:         clientID,
+		ClientSecret:     "client-secret",
+		TokenEndpointURI: serverURL + "/token",
+		APIEndpointURI:   serverURL + "/register",
+	})
+}
+
+func Test_oidcClient_GetToken(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	token, err := newTestClient(server.URL, "").GetToken()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(token).To(gomega.BeEmpty())
+}
+
+func Test_oidcClient_RegisterClient(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		response     string
+		want         *ClientRegistration
+		wantErr      *RegistrationError
+		wantNotFound bool
+	}{
+		{
+			name:     "should return the registered client",
+			status:   http.StatusCreated,
+			response: `{"client_id": "client-id", "client_secret": "secret", "client_name": "name", "registration_access_token": "registration-token", "registration_client_uri": "https://oidc.example.com/register/client-id"}`,
+			want: &ClientRegistration{
+				ClientMetadata:          ClientMetadata{ClientName: "name", ClientSecret: "secret"},
+				ClientID:                "client-id",
+				RegistrationAccessToken: "registration-token",
+				RegistrationClientURI:   "https://oidc.example.com/register/client-id",
+			},
+		},
+		{
+			name:     "should return the error of the registration endpoint",
+			status:   http.StatusBadRequest,
+			response: `{"error": "invalid_client_metadata", "error_description": "unsupported grant type"}`,
+			wantErr:  &RegistrationError{StatusCode: http.StatusBadRequest, Code: "invalid_client_metadata", ErrorDescription: "unsupported grant type"},
+		},
+		{
+			name:         "should return an error when the response has no error body",
+			status:       http.StatusUnauthorized,
+			wantErr:      &RegistrationError{StatusCode: http.StatusUnauthorized, Code: http.StatusText(http.StatusUnauthorized)},
+			wantNotFound: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				g.Expect(r.Method).To(gomega.Equal(http.MethodPost))
+				g.Expect(r.URL.Path).To(gomega.Equal("/register"))
+				g.Expect(r.Header.Get("Authorization")).To(gomega.Equal("Bearer initial-token"))
+				var metadata ClientMetadata
+				g.Expect(json.NewDecoder(r.Body).Decode(&metadata)).To(gomega.Succeed())
+				g.Expect(metadata.GrantTypes).To(gomega.Equal([]string{"client_credentials"}))
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			got, err := newTestClient(server.URL, "").RegisterClient("initial-token", ClientMetadata{ClientName: "name", GrantTypes: []string{"client_credentials"}})
+			if tt.wantErr != nil {
+				g.Expect(err).To(gomega.Equal(tt.wantErr))
+				g.Expect(IsNotFound(err)).To(gomega.Equal(tt.wantNotFound))
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_oidcClient_UpdateClient(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(gomega.Equal(http.MethodPut))
+		g.Expect(r.Header.Get("Authorization")).To(gomega.Equal("Bearer registration-token"))
+		var body map[string]interface{}
+		g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(gomega.Succeed())
+		g.Expect(body).To(gomega.HaveKeyWithValue("client_id", "client-id"))
+		g.Expect(body).To(gomega.HaveKeyWithValue("client_secret", "new-secret"))
+		_, _ = w.Write([]byte(`{"client_id": "client-id", "client_secret": "new-secret"}`))
+	}))
+	defer server.Close()
+
+	got, err := newTestClient(server.URL, "").UpdateClient("registration-token", server.URL+"/register/client-id", "client-id", ClientMetadata{ClientSecret: "new-secret"})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(got.ClientSecret).To(gomega.Equal("new-secret"))
+}
+
+func Test_oidcClient_TrustedCertificates(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	// the certificate of the server is self-signed
+	err := newTestClient(server.URL, "").DeleteClient("registration-token", server.URL+"/register/client-id")
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	client := NewOIDCClient(&keycloak.KeycloakConfig{TLSTrustedCertificatesValue: string(certificate)}, &keycloak.KeycloakRealmConfig{})
+	err = client.DeleteClient("registration-token", server.URL+"/register/client-id")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+}
@@ -0,0 +1,17 @@
+package authz
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/spf13/cobra"
+)
+
+// authz sub-command inspects the authorization of the admin API
+func NewAuthzCommand(env *environments.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "authz",
+		Short: "Inspect the authorization of the admin API",
+		Long:  "Inspect the authorization of the admin API endpoints of the Kafka Service Fleet Manager",
+	}
+	cmd.AddCommand(NewExplainCommand(env))
+	return cmd
+}
@@ -0,0 +1,131 @@
+package authz
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/golang/glog"
+	"github.com/gorilla/mux"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// adminRouteSegment identifies the routes of the admin API, which are authorized by the roles of the admin authz configuration
+const adminRouteSegment = "/admin/"
+
+type endpoint struct {
+	method string
+	route  string
+	roles  []string
+}
+
+func NewExplainCommand(env *environments.Env) *cobra.Command {
+	var role string
+	cmd := &cobra.Command{
+		Use:   "explain",
+		Short: "Print which roles can reach which admin API endpoints",
+		Long: "Print the roles allowed by the admin authz configuration to reach each endpoint of the admin API. " +
+			"The endpoints no role is configured for are denied to every user.",
+
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			err := env.CreateServices()
+			if err != nil {
+				glog.Fatalf("Unable to initialize environment: %s", err.Error())
+			}
+		},
+
+		Run: func(cmd *cobra.Command, args []string) {
+			env.MustInvoke(func(routeLoaders []environments.RouteLoader, authzConfig *auth.AdminRoleAuthZConfig) {
+				router := mux.NewRouter()
+				for _, loader := range routeLoaders {
+					if err := loader.AddRoutes(router); err != nil {
+						glog.Fatalf("Unable to load the routes: %s", err.Error())
+					}
+				}
+
+				endpoints, err := explain(router, authzConfig.GetRoleMapping())
+				if err != nil {
+					glog.Fatalf("Unable to list the admin API endpoints: %s", err.Error())
+				}
+				printEndpoints(endpoints, role)
+
+				unmatchedRoutes, err := authzConfig.UnmatchedRoutes(router)
+				if err != nil {
+					glog.Fatalf("Unable to check the routes of the admin authz configuration: %s", err.Error())
+				}
+				printUnmatchedRoutes(unmatchedRoutes)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&role, "role", "", "Only print the endpoints the role can reach")
+	return cmd
+}
+
+// explain returns the endpoints of the admin API routes of the router with the roles allowed to reach them
+func explain(router *mux.Router, roleMapping auth.RoleMapping) ([]endpoint, error) {
+	var endpoints []endpoint
+	err := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil || !strings.Contains(template, adminRouteSegment) {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			// the subrouters do not serve any request
+			return nil
+		}
+		for _, method := range methods {
+			roles, _ := roleMapping.GetAllowedRoles(method, template)
+			endpoints = append(endpoints, endpoint{method: method, route: template, roles: roles})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		if endpoints[i].route != endpoints[j].route {
+			return endpoints[i].route < endpoints[j].route
+		}
+		return endpoints[i].method < endpoints[j].method
+	})
+	return endpoints, nil
+}
+
+func printEndpoints(endpoints []endpoint, role string) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Method", "Route", "Roles"})
+	table.SetAutoWrapText(false)
+	for _, e := range endpoints {
+		if role != "" && !arrays.AnyMatch(e.roles, arrays.StringEqualsIgnoreCasePredicate(role)) {
+			continue
+		}
+		roles := strings.Join(e.roles, "\n")
+		if len(e.roles) == 0 {
+			roles = "<denied>"
+		}
+		table.Append([]string{e.method, e.route, roles})
+	}
+	table.Render()
+}
+
+// printUnmatchedRoutes prints the configured routes whose roles never apply as they match no route of the API
+func printUnmatchedRoutes(unmatchedRoutes []auth.RolesConfiguration) {
+	if len(unmatchedRoutes) == 0 {
+		return
+	}
+	fmt.Println("The roles of the following routes of the admin authz configuration match no route of the API:")
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Method", "Route", "Roles"})
+	table.SetAutoWrapText(false)
+	for _, config := range unmatchedRoutes {
+		table.Append([]string{config.HTTPMethod, config.Route, strings.Join(config.RoleNames, "\n")})
+	}
+	table.Render()
+}
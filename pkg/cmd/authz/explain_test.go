@@ -0,0 +1,38 @@
+package authz
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+func Test_explain(t *testing.T) {
+	g := gomega.NewWithT(t)
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {})
+	router := mux.NewRouter()
+	apiRouter := router.PathPrefix("/api/kafkas_mgmt/v1").Subrouter()
+	apiRouter.HandleFunc("/kafkas", handler).Methods(http.MethodGet)
+	adminRouter := apiRouter.PathPrefix("/admin").Subrouter()
+	adminRouter.HandleFunc("/kafkas/{id}", handler).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafkas/{id}", handler).Methods(http.MethodDelete)
+	adminRouter.HandleFunc("/kafkas/{id}", handler).Methods(http.MethodPut)
+
+	config := &auth.AdminRoleAuthZConfig{
+		RolesConfig: auth.RoleConfig{
+			{HTTPMethod: http.MethodGet, RoleNames: []string{"read", "full"}},
+			{HTTPMethod: http.MethodDelete, RoleNames: []string{"full"}},
+			{HTTPMethod: http.MethodGet, Route: "/api/kafkas_mgmt/v1/admin/kafkas/{id}", RoleNames: []string{"full"}},
+		},
+	}
+
+	endpoints, err := explain(router, config.GetRoleMapping())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(endpoints).To(gomega.Equal([]endpoint{
+		{method: http.MethodDelete, route: "/api/kafkas_mgmt/v1/admin/kafkas/{id}", roles: []string{"full"}},
+		{method: http.MethodGet, route: "/api/kafkas_mgmt/v1/admin/kafkas/{id}", roles: []string{"full"}},
+		{method: http.MethodPut, route: "/api/kafkas_mgmt/v1/admin/kafkas/{id}"},
+	}))
+}
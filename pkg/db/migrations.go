@@ -8,6 +8,7 @@ import (
 	"github.com/go-gormigrate/gormigrate/v2"
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
+	"github.com/rs/xid"
 	"gorm.io/gorm"
 )
 
@@ -313,6 +314,57 @@ func ExecAction(applySql string, unapplySql string) MigrationAction {
 	}
 }
 
+// CreateSharedTablesAction creates the tables shared by the kas-fleet-manager and the cos-fleet-manager, e.g. the webhook tables.
+// When both services use the same database, the tables may already have been created by the other service, and it may still
+// use them after a rollback: the tables are only created if they don't exist yet and are never dropped.
+func CreateSharedTablesAction(tables ...interface{}) MigrationAction {
+	caller := ""
+	if _, file, no, ok := runtime.Caller(1); ok {
+		caller = fmt.Sprintf("[ %s:%d ]", file, no)
+	}
+	return func(tx *gorm.DB, apply bool) error {
+		if apply {
+			if err := tx.Migrator().AutoMigrate(tables...); err != nil {
+				return errors.Wrap(err, caller)
+			}
+		}
+		return nil
+	}
+}
+
+// CreateSharedLeaderLeaseAction creates the leader lease of a worker run by both the kas-fleet-manager and the cos-fleet-manager,
+// e.g. the webhook dispatcher. Like the shared tables, the lease is only created if it doesn't exist yet and is never deleted.
+func CreateSharedLeaderLeaseAction(leaseType string) MigrationAction {
+	type LeaderLease struct {
+		Model
+		Leader    string
+		LeaseType string
+		Expires   *time.Time
+	}
+
+	caller := ""
+	if _, file, no, ok := runtime.Caller(1); ok {
+		caller = fmt.Sprintf("[ %s:%d ]", file, no)
+	}
+	return func(tx *gorm.DB, apply bool) error {
+		if !apply {
+			return nil
+		}
+		var count int64
+		if err := tx.Model(&LeaderLease{}).Where("lease_type = ?", leaseType).Count(&count).Error; err != nil {
+			return errors.Wrap(err, caller)
+		}
+		if count > 0 {
+			return nil
+		}
+		expires := time.Now().Add(-time.Minute) // set to an expired time
+		if err := tx.Create(&LeaderLease{Model: Model{ID: xid.New().String()}, LeaseType: leaseType, Expires: &expires}).Error; err != nil {
+			return errors.Wrap(err, caller)
+		}
+		return nil
+	}
+}
+
 func FuncAction(applyFunc func(*gorm.DB) error, unapplyFunc func(*gorm.DB) error) MigrationAction {
 	caller := ""
 	if _, file, no, ok := runtime.Caller(1); ok {
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/compat"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
+)
+
+const auditEventListKind = "AuditEventList"
+
+// AuditEventHandler serves the audit events of the create, update and delete requests to the APIs to the admins.
+// It is shared by the services, the events of both of them being stored in the same table when they share a database.
+type AuditEventHandler struct {
+	auditService audit.AuditService
+}
+
+func NewAuditEventHandler(auditService audit.AuditService) *AuditEventHandler {
+	return &AuditEventHandler{
+		auditService: auditService,
+	}
+}
+
+// List returns the audit events matching the search of the request, the most recent first
+func (h AuditEventHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := services.NewListArguments(r.URL.Query())
+			events, paging, err := h.auditService.List(listArgs)
+			if err != nil {
+				return nil, err
+			}
+			result := compat.AuditEventList{
+				Kind:  auditEventListKind,
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []compat.AuditEvent{},
+			}
+			for _, event := range events {
+				result.Items = append(result.Items, presentAuditEvent(event))
+			}
+			return result, nil
+		},
+	}
+	HandleList(w, r, cfg)
+}
+
+func presentAuditEvent(event *api.AuditEvent) compat.AuditEvent {
+	return compat.AuditEvent{
+		Id:             event.ID,
+		Actor:          event.Actor,
+		OrganisationId: event.OrganisationId,
+		ResourceType:   event.ResourceType,
+		ResourceId:     event.ResourceId,
+		Action:         event.Action,
+		Method:         event.Method,
+		Route:          event.Route,
+		Diff:           event.Diff,
+		RequestId:      event.RequestId,
+		StatusCode:     int32(event.StatusCode),
+		Outcome:        event.Outcome,
+		CreatedAt:      event.CreatedAt,
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/compat"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
+	"github.com/onsi/gomega"
+)
+
+func Test_AuditEventHandler_List(t *testing.T) {
+	tests := []struct {
+		name           string
+		listErr        *errors.ServiceError
+		wantStatusCode int
+		wantIds        []string
+	}{
+		{
+			name:           "should return the audit events",
+			wantStatusCode: http.StatusOK,
+			wantIds:        []string{"event-id"},
+		},
+		{
+			name:           "should return bad request when the search can not be parsed",
+			listErr:        errors.New(errors.ErrorFailedToParseSearch, "unable to list audit events"),
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var search string
+			auditService := &audit.AuditServiceMock{
+				ListFunc: func(listArgs *services.ListArguments) (api.AuditEventList, *api.PagingMeta, *errors.ServiceError) {
+					search = listArgs.Search
+					if tt.listErr != nil {
+						return nil, nil, tt.listErr
+					}
+					events := api.AuditEventList{
+						{Meta: api.Meta{ID: "event-id"}, Actor: "user", ResourceType: "kafkas", ResourceId: "kafka-id", Action: "delete"},
+					}
+					return events, &api.PagingMeta{Page: 1, Size: 1, Total: 1}, nil
+				},
+			}
+			h := NewAuditEventHandler(auditService)
+			req := httptest.NewRequest(http.MethodGet, "/api/kafkas_mgmt/v1/admin/audit_events?search=resource_id+%3D+kafka-id", nil)
+			rw := httptest.NewRecorder()
+			h.List(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			g.Expect(search).To(gomega.Equal("resource_id = kafka-id"))
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+			var list compat.AuditEventList
+			g.Expect(json.NewDecoder(resp.Body).Decode(&list)).To(gomega.Succeed())
+			g.Expect(list.Total).To(gomega.Equal(int32(1)))
+			var ids []string
+			for _, event := range list.Items {
+				ids = append(ids, event.Id)
+			}
+			g.Expect(ids).To(gomega.Equal(tt.wantIds))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/compat"
@@ -35,6 +36,14 @@ type EventStream struct {
 	Close        func()
 }
 
+// FileResponse is an action result that HandleGet writes as a file attachment instead of JSON, such as a CSV export
+type FileResponse struct {
+	ContentType string
+	FileName    string
+	// Write writes the content of the file
+	Write func(w io.Writer) error
+}
+
 type Validate func() *errors.ServiceError
 type ErrorHandlerFunc func(r *http.Request, w http.ResponseWriter, err *errors.ServiceError)
 type HttpAction func() (interface{}, *errors.ServiceError)
@@ -137,14 +146,32 @@ func HandleGet(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig) {
 
 	result, serviceErr := cfg.Action()
 	switch {
-	case serviceErr == nil:
+	case serviceErr != nil:
+		errorHandler(r, w, cfg, serviceErr)
+	default:
+		if file, ok := result.(FileResponse); ok {
+			writeFileResponse(r, w, file)
+			return
+		}
 		shared.WriteJSONResponse(w, http.StatusOK, result)
 		success(r)
-	default:
-		errorHandler(r, w, cfg, serviceErr)
 	}
 }
 
+func writeFileResponse(r *http.Request, w http.ResponseWriter, file FileResponse) {
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.FileName))
+	w.Header().Set("Vary", "Authorization")
+	w.WriteHeader(http.StatusOK)
+
+	// the status is already sent, a failed write can only be logged
+	if err := file.Write(w); err != nil {
+		logger.NewUHCLogger(r.Context()).Errorf("failed to write file %s: %v", file.FileName, err)
+		return
+	}
+	success(r)
+}
+
 func HandleList(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig) {
 	if cfg.ErrorHandler == nil {
 		cfg.ErrorHandler = shared.HandleError
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"io"
 	"net/http"
 	"testing"
 
@@ -319,6 +320,28 @@ func Test_HandleGet(t *testing.T) {
 	}
 }
 
+func Test_HandleGet_FileResponse(t *testing.T) {
+	g := gomega.NewWithT(t)
+	req, rw := GetHandlerParams("GET", "/export", nil, t)
+
+	HandleGet(rw, req, &HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			return FileResponse{
+				ContentType: "text/csv",
+				FileName:    "export.csv",
+				Write: func(w io.Writer) error {
+					_, err := io.WriteString(w, "id,name\n1,test\n")
+					return err
+				},
+			}, nil
+		},
+	})
+	g.Expect(rw.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(rw.Header().Get("Content-Type")).To(gomega.Equal("text/csv"))
+	g.Expect(rw.Header().Get("Content-Disposition")).To(gomega.Equal(`attachment; filename="export.csv"`))
+	g.Expect(rw.Body.String()).To(gomega.Equal("id,name\n1,test\n"))
+}
+
 func Test_HandleList(t *testing.T) {
 	req, rw := GetHandlerParams("GET", "/", nil, t)
 	type args struct {
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/compat"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/rbac"
+	"github.com/gorilla/mux"
+)
+
+const (
+	resourceGrantKind     = "ResourceGrant"
+	resourceGrantListKind = "ResourceGrantList"
+)
+
+// GrantHandler serves the roles granted to the users of the organisations on their organisation and on its resources.
+// It is shared by the services, each one serving it under its own base path for the types of resources it manages.
+type GrantHandler struct {
+	rbacService rbac.RBACService
+	basePath    string
+	// lookups returns the resources of the types roles can be granted on, in addition to the organisation
+	lookups map[api.GrantResourceType]rbac.ResourceLookup
+}
+
+func NewGrantHandler(rbacService rbac.RBACService, basePath string, lookups map[api.GrantResourceType]rbac.ResourceLookup) *GrantHandler {
+	return &GrantHandler{
+		rbacService: rbacService,
+		basePath:    basePath,
+		lookups:     lookups,
+	}
+}
+
+// Create grants a role to a user on the organisation of the caller or on one of its resources.
+// Only the owners of the organisation or of the resource are allowed to grant roles.
+func (h GrantHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var grantRequest compat.ResourceGrantRequest
+	ctx := r.Context()
+	cfg := &HandlerConfig{
+		MarshalInto: &grantRequest,
+		Validate: []Validate{
+			ValidateLength(&grantRequest.Subject, "subject", MinRequiredFieldLength, nil),
+			ValidateLength(&grantRequest.Role, "role", MinRequiredFieldLength, nil),
+			h.validateResourceType(&grantRequest.ResourceType),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			claims, err := getCallerClaims(ctx)
+			if err != nil {
+				return nil, err
+			}
+			orgId, _ := claims.GetOrgId()
+			username, _ := claims.GetUsername()
+
+			grant := &api.ResourceGrant{
+				OrganisationId: orgId,
+				Subject:        grantRequest.Subject,
+				ResourceType:   grantRequest.ResourceType,
+				ResourceId:     grantRequest.ResourceId,
+				Role:           grantRequest.Role,
+				CreatedBy:      username,
+			}
+			if grant.ResourceType == api.GrantResourceTypeOrganisation.String() {
+				grant.ResourceId = orgId
+			}
+			if err := h.authorizeGrantManagement(ctx, grant); err != nil {
+				return nil, err
+			}
+			if err := h.rbacService.CreateGrant(grant); err != nil {
+				return nil, err
+			}
+			return h.presentGrant(grant), nil
+		},
+	}
+	Handle(w, r, cfg, http.StatusCreated)
+}
+
+// Get returns a grant of the organisation of the caller
+func (h GrantHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			grant, err := h.getGrant(r.Context(), mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+			return h.presentGrant(grant), nil
+		},
+	}
+	HandleGet(w, r, cfg)
+}
+
+// List returns the grants of the organisation of the caller on the organisation and on the resources served by the handler
+func (h GrantHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			orgId, err := getCallerOrgId(r.Context())
+			if err != nil {
+				return nil, err
+			}
+			listArgs := services.NewListArguments(r.URL.Query())
+			grants, paging, err := h.rbacService.ListGrants(orgId, h.resourceTypes(), listArgs)
+			if err != nil {
+				return nil, err
+			}
+			result := compat.ResourceGrantList{
+				Kind:  resourceGrantListKind,
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []compat.ResourceGrant{},
+			}
+			for _, grant := range grants {
+				result.Items = append(result.Items, h.presentGrant(grant))
+			}
+			return result, nil
+		},
+	}
+	HandleList(w, r, cfg)
+}
+
+// Delete revokes a grant of the organisation of the caller.
+// Only the owners of the organisation or of the resource of the grant are allowed to revoke it.
+func (h GrantHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cfg := &HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			grant, err := h.getGrant(ctx, mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+			if err := h.authorizeGrantManagement(ctx, grant); err != nil {
+				return nil, err
+			}
+			return nil, h.rbacService.DeleteGrant(grant.OrganisationId, grant.ID)
+		},
+	}
+	HandleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+func (h GrantHandler) getGrant(ctx context.Context, id string) (*api.ResourceGrant, *errors.ServiceError) {
+	orgId, err := getCallerOrgId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	grant, err := h.rbacService.GetGrant(orgId, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := h.lookups[api.GrantResourceType(grant.ResourceType)]; !ok && grant.ResourceType != api.GrantResourceTypeOrganisation.String() {
+		// the grants on the resources of the other services are served by these services
+		return nil, errors.NotFound("Grant with id='%s' not found", id)
+	}
+	return grant, nil
+}
+
+// authorizeGrantManagement checks that the caller is allowed to manage the grants on the resource of the grant
+func (h GrantHandler) authorizeGrantManagement(ctx context.Context, grant *api.ResourceGrant) *errors.ServiceError {
+	var resource *rbac.Resource
+	if lookup, ok := h.lookups[api.GrantResourceType(grant.ResourceType)]; ok {
+		var err *errors.ServiceError
+		resource, err = lookup(ctx, grant.ResourceId)
+		if err != nil && !err.Is404() && err.Code != errors.ErrorGone {
+			return err
+		}
+		if resource == nil || resource.OrganisationId != grant.OrganisationId {
+			if grant.ID == "" {
+				return errors.NotFound("%s with id='%s' not found", grant.ResourceType, grant.ResourceId)
+			}
+			// the grants on deleted resources are managed by the owners of the organisation
+			resource = nil
+		}
+	}
+
+	allowed, err := h.rbacService.IsAllowed(ctx, resource, rbac.PermissionManageGrants)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errors.New(errors.ErrorForbidden, "user is not allowed to manage the grants of this resource")
+	}
+	return nil
+}
+
+func (h GrantHandler) resourceTypes() []api.GrantResourceType {
+	resourceTypes := []api.GrantResourceType{api.GrantResourceTypeOrganisation}
+	for resourceType := range h.lookups {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	return resourceTypes
+}
+
+func (h GrantHandler) validateResourceType(value *string) Validate {
+	return func() *errors.ServiceError {
+		for _, resourceType := range h.resourceTypes() {
+			if *value == resourceType.String() {
+				return nil
+			}
+		}
+		return errors.Validation("resource_type must be one of %v", h.resourceTypes())
+	}
+}
+
+func (h GrantHandler) presentGrant(grant *api.ResourceGrant) compat.ResourceGrant {
+	return compat.ResourceGrant{
+		Id:             grant.ID,
+		Kind:           resourceGrantKind,
+		Href:           fmt.Sprintf("%s/%s", h.basePath, grant.ID),
+		Subject:        grant.Subject,
+		Role:           grant.Role,
+		ResourceType:   grant.ResourceType,
+		ResourceId:     grant.ResourceId,
+		OrganisationId: grant.OrganisationId,
+		CreatedBy:      grant.CreatedBy,
+		CreatedAt:      grant.CreatedAt,
+	}
+}
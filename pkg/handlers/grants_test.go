@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/compat"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/rbac"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+const grantTestBasePath = "/api/kafkas_mgmt/v1/grants"
+
+func grantTestLookups(resource *rbac.Resource) map[api.GrantResourceType]rbac.ResourceLookup {
+	return map[api.GrantResourceType]rbac.ResourceLookup{
+		api.GrantResourceTypeKafka: func(ctx context.Context, id string) (*rbac.Resource, *errors.ServiceError) {
+			if resource == nil {
+				return nil, errors.NotFound("Kafka with id='%s' not found", id)
+			}
+			return resource, nil
+		},
+	}
+}
+
+func Test_GrantHandler_Create(t *testing.T) {
+	kafka := &rbac.Resource{Type: api.GrantResourceTypeKafka, Id: "kafka-id", Owner: "other-user", OrganisationId: webhookTestOrgId}
+
+	tests := []struct {
+		name           string
+		rbacService    rbac.RBACService
+		resource       *rbac.Resource
+		body           []byte
+		wantStatusCode int
+		wantResourceId string
+	}{
+		{
+			name:           "should fail if the subject is missing",
+			body:           []byte(`{"role": "viewer", "resource_type": "organisation"}`),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should fail if the resource type is not served by the handler",
+			body:           []byte(`{"subject": "jdoe", "role": "viewer", "resource_type": "connector", "resource_id": "connector-id"}`),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should fail if the resource does not exist",
+			rbacService: &rbac.RBACServiceMock{
+				IsAllowedFunc: func(ctx context.Context, resource *rbac.Resource, permission rbac.Permission) (bool, *errors.ServiceError) {
+					return true, nil
+				},
+			},
+			body:           []byte(`{"subject": "jdoe", "role": "viewer", "resource_type": "kafka", "resource_id": "kafka-id"}`),
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should fail if the user is not allowed to manage the grants of the resource",
+			rbacService: &rbac.RBACServiceMock{
+				IsAllowedFunc: func(ctx context.Context, resource *rbac.Resource, permission rbac.Permission) (bool, *errors.ServiceError) {
+					return false, nil
+				},
+			},
+			resource:       kafka,
+			body:           []byte(`{"subject": "jdoe", "role": "viewer", "resource_type": "kafka", "resource_id": "kafka-id"}`),
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "should grant the role on the resource",
+			rbacService: &rbac.RBACServiceMock{
+				IsAllowedFunc: func(ctx context.Context, resource *rbac.Resource, permission rbac.Permission) (bool, *errors.ServiceError) {
+					return resource == kafka && permission == rbac.PermissionManageGrants, nil
+				},
+				CreateGrantFunc: func(grant *api.ResourceGrant) *errors.ServiceError {
+					grant.ID = "grant-id"
+					return nil
+				},
+			},
+			resource:       kafka,
+			body:           []byte(`{"subject": "jdoe", "role": "editor", "resource_type": "kafka", "resource_id": "kafka-id"}`),
+			wantStatusCode: http.StatusCreated,
+			wantResourceId: "kafka-id",
+		},
+		{
+			name: "should grant the role on the organisation of the user",
+			rbacService: &rbac.RBACServiceMock{
+				IsAllowedFunc: func(ctx context.Context, resource *rbac.Resource, permission rbac.Permission) (bool, *errors.ServiceError) {
+					return resource == nil, nil
+				},
+				CreateGrantFunc: func(grant *api.ResourceGrant) *errors.ServiceError {
+					grant.ID = "grant-id"
+					return nil
+				},
+			},
+			body:           []byte(`{"subject": "jdoe", "role": "billing", "resource_type": "organisation", "resource_id": "other-org"}`),
+			wantStatusCode: http.StatusCreated,
+			wantResourceId: webhookTestOrgId,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewGrantHandler(tt.rbacService, grantTestBasePath, grantTestLookups(tt.resource))
+			req := httptest.NewRequest(http.MethodPost, grantTestBasePath, bytes.NewBuffer(tt.body)).WithContext(webhookTestContext(false))
+			rw := httptest.NewRecorder()
+			h.Create(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode != http.StatusCreated {
+				return
+			}
+			var grant compat.ResourceGrant
+			g.Expect(json.NewDecoder(resp.Body).Decode(&grant)).To(gomega.Succeed())
+			g.Expect(grant.ResourceId).To(gomega.Equal(tt.wantResourceId))
+			g.Expect(grant.OrganisationId).To(gomega.Equal(webhookTestOrgId))
+			g.Expect(grant.CreatedBy).To(gomega.Equal("test-user"))
+			g.Expect(grant.Href).To(gomega.Equal(grantTestBasePath + "/grant-id"))
+		})
+	}
+}
+
+func Test_GrantHandler_Get(t *testing.T) {
+	tests := []struct {
+		name           string
+		grant          *api.ResourceGrant
+		wantStatusCode int
+	}{
+		{
+			name:           "should return a grant on the organisation",
+			grant:          &api.ResourceGrant{Meta: api.Meta{ID: "grant-id"}, ResourceType: api.GrantResourceTypeOrganisation.String()},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "should return a grant on a resource served by the handler",
+			grant:          &api.ResourceGrant{Meta: api.Meta{ID: "grant-id"}, ResourceType: api.GrantResourceTypeKafka.String()},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "should not return a grant on a resource served by another service",
+			grant:          &api.ResourceGrant{Meta: api.Meta{ID: "grant-id"}, ResourceType: api.GrantResourceTypeConnector.String()},
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			rbacService := &rbac.RBACServiceMock{
+				GetGrantFunc: func(organisationId string, id string) (*api.ResourceGrant, *errors.ServiceError) {
+					return tt.grant, nil
+				},
+			}
+			h := NewGrantHandler(rbacService, grantTestBasePath, grantTestLookups(nil))
+			req := httptest.NewRequest(http.MethodGet, grantTestBasePath+"/grant-id", nil).WithContext(webhookTestContext(false))
+			req = mux.SetURLVars(req, map[string]string{"id": "grant-id"})
+			rw := httptest.NewRecorder()
+			h.Get(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+		})
+	}
+}
+
+func Test_GrantHandler_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowed        bool
+		wantStatusCode int
+		wantDeletes    int
+	}{
+		{
+			name:           "should fail if the user is not allowed to manage the grants of the resource",
+			allowed:        false,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "should revoke the grant of a deleted resource",
+			allowed:        true,
+			wantStatusCode: http.StatusNoContent,
+			wantDeletes:    1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			rbacService := &rbac.RBACServiceMock{
+				GetGrantFunc: func(organisationId string, id string) (*api.ResourceGrant, *errors.ServiceError) {
+					return &api.ResourceGrant{Meta: api.Meta{ID: id}, OrganisationId: organisationId, ResourceType: api.GrantResourceTypeKafka.String(), ResourceId: "deleted-kafka-id"}, nil
+				},
+				IsAllowedFunc: func(ctx context.Context, resource *rbac.Resource, permission rbac.Permission) (bool, *errors.ServiceError) {
+					// the grants on deleted resources are checked against the organisation
+					return tt.allowed && resource == nil, nil
+				},
+				DeleteGrantFunc: func(organisationId string, id string) *errors.ServiceError {
+					return nil
+				},
+			}
+			h := NewGrantHandler(rbacService, grantTestBasePath, grantTestLookups(nil))
+			req := httptest.NewRequest(http.MethodDelete, grantTestBasePath+"/grant-id", nil).WithContext(webhookTestContext(false))
+			req = mux.SetURLVars(req, map[string]string{"id": "grant-id"})
+			rw := httptest.NewRecorder()
+			h.Delete(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			g.Expect(rbacService.DeleteGrantCalls()).To(gomega.HaveLen(tt.wantDeletes))
+		})
+	}
+}
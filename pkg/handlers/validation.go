@@ -96,8 +96,8 @@ func ValidateDnsName(value *string, field string) Validate {
 
 func ValidateServiceAccountClientId(value *string, field string, ssoProvider string) Validate {
 	return func() *errors.ServiceError {
-		if ssoProvider == keycloak.REDHAT_SSO {
-			// only service accounts from mas sso are prefixed with "srvc-acc-", always return nil for redhat_sso provider
+		if ssoProvider == keycloak.REDHAT_SSO || ssoProvider == keycloak.OIDC_SSO {
+			// only service accounts from mas sso are prefixed with "srvc-acc-", always return nil for redhat_sso and oidc providers
 			return nil
 		}
 		if !ValidClientIdUuidRegexp.MatchString(*value) {
@@ -246,6 +246,15 @@ func Test_ValidateServiceAccountClientId(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "No error thrown for oidc service account client id",
+			args: args{
+				field:       field,
+				value:       &validIdRedhatSSO,
+				ssoProvider: keycloak.OIDC_SSO,
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, testcase := range tests {
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+)
+
+// WaitForCancelOrTimeoutOrNotification returns true if the context has been canceled or false after the timeout or sub signal
+func WaitForCancelOrTimeoutOrNotification(ctx context.Context, timeout time.Duration, sub *signalbus.Subscription) bool {
+	tc, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	select {
+	case <-tc.Done():
+		return false
+	case <-sub.Signal():
+		return false
+	case <-ctx.Done():
+		return true
+	}
+}
@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/compat"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"github.com/gorilla/mux"
+)
+
+const (
+	webhookEndpointKind     = "WebhookEndpoint"
+	webhookEndpointListKind = "WebhookEndpointList"
+	webhookDeliveryKind     = "WebhookDelivery"
+	webhookDeliveryListKind = "WebhookDeliveryList"
+)
+
+// WebhookHandler serves the webhook endpoints registered by the organisations of the users.
+// It is shared by the services, each one serving it under its own base path.
+type WebhookHandler struct {
+	webhookService webhooks.WebhookService
+	basePath       string
+}
+
+func NewWebhookHandler(webhookService webhooks.WebhookService, basePath string) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		basePath:       basePath,
+	}
+}
+
+// Create registers a webhook endpoint for the organisation of the caller.
+// Only organisation admins are allowed to register webhook endpoints.
+func (h WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var webhookRequest compat.WebhookEndpointRequest
+	ctx := r.Context()
+	cfg := &HandlerConfig{
+		MarshalInto: &webhookRequest,
+		Validate: []Validate{
+			validateWebhookOrgAdmin(ctx),
+			ValidateLength(&webhookRequest.Url, "url", MinRequiredFieldLength, nil),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			claims, err := getCallerClaims(ctx)
+			if err != nil {
+				return nil, err
+			}
+			webhook := &api.WebhookEndpoint{
+				URL:         webhookRequest.Url,
+				Description: webhookRequest.Description,
+			}
+			webhook.OrganisationId, _ = claims.GetOrgId()
+			webhook.Owner, _ = claims.GetUsername()
+			if err := h.webhookService.Create(webhook); err != nil {
+				return nil, err
+			}
+			// the secret is only returned when the webhook endpoint is registered
+			result := h.presentWebhookEndpoint(webhook)
+			result.Secret = webhook.Secret
+			return result, nil
+		},
+	}
+	Handle(w, r, cfg, http.StatusCreated)
+}
+
+// Get returns a webhook endpoint of the organisation of the caller
+func (h WebhookHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			orgId, err := getCallerOrgId(r.Context())
+			if err != nil {
+				return nil, err
+			}
+			webhook, err := h.webhookService.Get(orgId, mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+			return h.presentWebhookEndpoint(webhook), nil
+		},
+	}
+	HandleGet(w, r, cfg)
+}
+
+// List returns the webhook endpoints of the organisation of the caller
+func (h WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			orgId, err := getCallerOrgId(r.Context())
+			if err != nil {
+				return nil, err
+			}
+			listArgs := services.NewListArguments(r.URL.Query())
+			webhookList, paging, err := h.webhookService.List(orgId, listArgs)
+			if err != nil {
+				return nil, err
+			}
+			result := compat.WebhookEndpointList{
+				Kind:  webhookEndpointListKind,
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []compat.WebhookEndpoint{},
+			}
+			for _, webhook := range webhookList {
+				result.Items = append(result.Items, h.presentWebhookEndpoint(webhook))
+			}
+			return result, nil
+		},
+	}
+	HandleList(w, r, cfg)
+}
+
+// Delete removes a webhook endpoint of the organisation of the caller.
+// Only organisation admins are allowed to remove webhook endpoints.
+func (h WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cfg := &HandlerConfig{
+		Validate: []Validate{
+			validateWebhookOrgAdmin(ctx),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			orgId, err := getCallerOrgId(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return nil, h.webhookService.Delete(orgId, mux.Vars(r)["id"])
+		},
+	}
+	HandleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+// ListDeadLetters returns the deliveries to a webhook endpoint of the organisation of the caller that
+// failed the maximum number of attempts
+func (h WebhookHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	cfg := &HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			orgId, err := getCallerOrgId(r.Context())
+			if err != nil {
+				return nil, err
+			}
+			webhook, err := h.webhookService.Get(orgId, mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+			listArgs := services.NewListArguments(r.URL.Query())
+			deliveries, paging, err := h.webhookService.ListDeadLetters(webhook.ID, listArgs)
+			if err != nil {
+				return nil, err
+			}
+			result := compat.WebhookDeliveryList{
+				Kind:  webhookDeliveryListKind,
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []compat.WebhookDelivery{},
+			}
+			for _, delivery := range deliveries {
+				result.Items = append(result.Items, h.presentWebhookDelivery(delivery))
+			}
+			return result, nil
+		},
+	}
+	HandleList(w, r, cfg)
+}
+
+func (h WebhookHandler) presentWebhookEndpoint(webhook *api.WebhookEndpoint) compat.WebhookEndpoint {
+	return compat.WebhookEndpoint{
+		Id:             webhook.ID,
+		Kind:           webhookEndpointKind,
+		Href:           fmt.Sprintf("%s/%s", h.basePath, webhook.ID),
+		Url:            webhook.URL,
+		Description:    webhook.Description,
+		OrganisationId: webhook.OrganisationId,
+		Owner:          webhook.Owner,
+		CreatedAt:      webhook.CreatedAt,
+		UpdatedAt:      webhook.UpdatedAt,
+	}
+}
+
+func (h WebhookHandler) presentWebhookDelivery(delivery *api.WebhookDelivery) compat.WebhookDelivery {
+	return compat.WebhookDelivery{
+		Id:        delivery.ID,
+		Kind:      webhookDeliveryKind,
+		Href:      fmt.Sprintf("%s/%s/dead_letters/%s", h.basePath, delivery.WebhookId, delivery.ID),
+		WebhookId: delivery.WebhookId,
+		EventId:   delivery.EventId,
+		EventType: delivery.EventType,
+		Status:    delivery.Status,
+		Attempts:  int32(delivery.Attempts),
+		LastError: delivery.LastError,
+		CreatedAt: delivery.CreatedAt,
+		UpdatedAt: delivery.UpdatedAt,
+	}
+}
+
+func getCallerClaims(ctx context.Context) (auth.KFMClaims, *errors.ServiceError) {
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, errors.Unauthenticated("user not authenticated")
+	}
+	return auth.KFMClaims(claims), nil
+}
+
+func getCallerOrgId(ctx context.Context) (string, *errors.ServiceError) {
+	claims, err := getCallerClaims(ctx)
+	if err != nil {
+		return "", err
+	}
+	orgId, _ := claims.GetOrgId()
+	return orgId, nil
+}
+
+func validateWebhookOrgAdmin(ctx context.Context) Validate {
+	return func() *errors.ServiceError {
+		claims, err := getCallerClaims(ctx)
+		if err != nil {
+			return err
+		}
+		if !claims.IsOrgAdmin() {
+			return errors.New(errors.ErrorUnauthorized, "user not authorized to perform this action")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/compat"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+const (
+	webhookTestOrgId    = "13640203"
+	webhookTestBasePath = "/api/kafkas_mgmt/v1/webhooks"
+)
+
+func webhookTestContext(isOrgAdmin bool) context.Context {
+	return auth.SetTokenInContext(context.TODO(), &jwt.Token{
+		Claims: jwt.MapClaims{
+			"username":     "test-user",
+			"org_id":       webhookTestOrgId,
+			"is_org_admin": isOrgAdmin,
+		},
+	})
+}
+
+func Test_WebhookHandler_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		webhookService webhooks.WebhookService
+		ctx            context.Context
+		body           []byte
+		wantStatusCode int
+		wantSecret     string
+	}{
+		{
+			name:           "should fail if user is not an org admin",
+			ctx:            webhookTestContext(false),
+			body:           []byte(`{"url": "https://ci.example.com/hooks/kafka"}`),
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "should fail if the url is missing",
+			ctx:            webhookTestContext(true),
+			body:           []byte(`{"description": "no url"}`),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should fail if the webhook endpoint is invalid",
+			webhookService: &webhooks.WebhookServiceMock{
+				CreateFunc: func(webhook *api.WebhookEndpoint) *errors.ServiceError {
+					return errors.Validation("webhook url must be an absolute http or https url")
+				},
+			},
+			ctx:            webhookTestContext(true),
+			body:           []byte(`{"url": "ftp://ci.example.com"}`),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return the secret once the webhook endpoint is registered",
+			webhookService: &webhooks.WebhookServiceMock{
+				CreateFunc: func(webhook *api.WebhookEndpoint) *errors.ServiceError {
+					if webhook.OrganisationId != webhookTestOrgId || webhook.Owner != "test-user" {
+						return errors.GeneralError("webhook not bound to the organisation of the user")
+					}
+					webhook.ID = "webhook-id"
+					webhook.Secret = "whsec_secret"
+					return nil
+				},
+			},
+			ctx:            webhookTestContext(true),
+			body:           []byte(`{"url": "https://ci.example.com/hooks/kafka"}`),
+			wantStatusCode: http.StatusCreated,
+			wantSecret:     "whsec_secret",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewWebhookHandler(tt.webhookService, webhookTestBasePath)
+			req := httptest.NewRequest(http.MethodPost, webhookTestBasePath, bytes.NewBuffer(tt.body)).WithContext(tt.ctx)
+			rw := httptest.NewRecorder()
+			h.Create(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode != http.StatusCreated {
+				return
+			}
+			var webhook compat.WebhookEndpoint
+			g.Expect(json.NewDecoder(resp.Body).Decode(&webhook)).To(gomega.Succeed())
+			g.Expect(webhook.Secret).To(gomega.Equal(tt.wantSecret))
+			g.Expect(webhook.Href).To(gomega.Equal(webhookTestBasePath + "/webhook-id"))
+		})
+	}
+}
+
+func Test_WebhookHandler_Get(t *testing.T) {
+	tests := []struct {
+		name           string
+		webhookService webhooks.WebhookService
+		wantStatusCode int
+	}{
+		{
+			name: "should fail if the webhook endpoint does not belong to the organisation of the user",
+			webhookService: &webhooks.WebhookServiceMock{
+				GetFunc: func(organisationId string, id string) (*api.WebhookEndpoint, *errors.ServiceError) {
+					return nil, errors.NotFound("webhook with id='%s' not found", id)
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should not return the secret of the webhook endpoint",
+			webhookService: &webhooks.WebhookServiceMock{
+				GetFunc: func(organisationId string, id string) (*api.WebhookEndpoint, *errors.ServiceError) {
+					return &api.WebhookEndpoint{
+						Meta:           api.Meta{ID: id},
+						OrganisationId: organisationId,
+						URL:            "https://ci.example.com/hooks/kafka",
+						Secret:         "whsec_secret",
+					}, nil
+				},
+			},
+			wantStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewWebhookHandler(tt.webhookService, webhookTestBasePath)
+			req := httptest.NewRequest(http.MethodGet, webhookTestBasePath+"/webhook-id", nil).WithContext(webhookTestContext(false))
+			req = mux.SetURLVars(req, map[string]string{"id": "webhook-id"})
+			rw := httptest.NewRecorder()
+			h.Get(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+			var webhook compat.WebhookEndpoint
+			g.Expect(json.NewDecoder(resp.Body).Decode(&webhook)).To(gomega.Succeed())
+			g.Expect(webhook.Secret).To(gomega.BeEmpty())
+			g.Expect(webhook.OrganisationId).To(gomega.Equal(webhookTestOrgId))
+		})
+	}
+}
+
+func Test_WebhookHandler_ListDeadLetters(t *testing.T) {
+	g := gomega.NewWithT(t)
+	webhookService := &webhooks.WebhookServiceMock{
+		GetFunc: func(organisationId string, id string) (*api.WebhookEndpoint, *errors.ServiceError) {
+			return &api.WebhookEndpoint{Meta: api.Meta{ID: id}, OrganisationId: organisationId}, nil
+		},
+		ListDeadLettersFunc: func(webhookId string, listArgs *services.ListArguments) (api.WebhookDeliveryList, *api.PagingMeta, *errors.ServiceError) {
+			return api.WebhookDeliveryList{
+				{
+					Meta:      api.Meta{ID: "delivery-id"},
+					WebhookId: webhookId,
+					Status:    api.WebhookDeliveryDeadLetter.String(),
+					Attempts:  10,
+				},
+			}, &api.PagingMeta{Page: 1, Size: 1, Total: 1}, nil
+		},
+	}
+	h := NewWebhookHandler(webhookService, webhookTestBasePath)
+	req := httptest.NewRequest(http.MethodGet, webhookTestBasePath+"/webhook-id/dead_letters", nil).WithContext(webhookTestContext(false))
+	req = mux.SetURLVars(req, map[string]string{"id": "webhook-id"})
+	rw := httptest.NewRecorder()
+	h.ListDeadLetters(rw, req)
+	resp := rw.Result()
+	defer resp.Body.Close()
+	g.Expect(resp.StatusCode).To(gomega.Equal(http.StatusOK))
+
+	var deliveries compat.WebhookDeliveryList
+	g.Expect(json.NewDecoder(resp.Body).Decode(&deliveries)).To(gomega.Succeed())
+	g.Expect(deliveries.Items).To(gomega.HaveLen(1))
+	g.Expect(deliveries.Items[0].WebhookId).To(gomega.Equal("webhook-id"))
+	g.Expect(deliveries.Items[0].Attempts).To(gomega.Equal(int32(10)))
+	g.Expect(webhookService.GetCalls()[0].OrganisationId).To(gomega.Equal(webhookTestOrgId))
+}
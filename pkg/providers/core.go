@@ -7,6 +7,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/observatorium"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/ocm"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/cmd/authz"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/cmd/migrate"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/cmd/serve"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
@@ -15,10 +16,13 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/server"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/account"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/rbac"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sentry"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
 	"github.com/goava/di"
 )
@@ -44,12 +48,16 @@ func CoreConfigProviders() di.Option {
 		// Add common CLI sub commands
 		di.Provide(serve.NewServeCommand),
 		di.Provide(migrate.NewMigrateCommand),
+		di.Provide(authz.NewAuthzCommand),
 
 		// Add other core config providers..
 		sentry.ConfigProviders(),
 		signalbus.ConfigProviders(),
 		authorization.ConfigProviders(),
 		account.ConfigProviders(),
+		webhooks.ConfigProviders(),
+		rbac.ConfigProviders(),
+		audit.ConfigProviders(),
 
 		di.Provide(environments.Func(ServiceProviders)),
 	)
@@ -82,10 +90,11 @@ func ServiceProviders() di.Option {
 
 		di.Provide(acl.NewAccessControlListMiddleware),
 		di.Provide(handlers.NewErrorsHandler),
-		di.Provide(func(c *keycloak.KeycloakConfig) sso.KafkaKeycloakService {
+		di.Provide(func(c *keycloak.KeycloakConfig, connectionFactory *db.ConnectionFactory) sso.KafkaKeycloakService {
 			return sso.NewKeycloakServiceBuilder().
 				ForKFM().
 				WithConfiguration(c).
+				WithConnectionFactory(connectionFactory).
 				Build()
 		}),
 		di.Provide(func(c *keycloak.KeycloakConfig) sso.OsdKeycloakService {
@@ -18,6 +18,20 @@ var _ yaml.Unmarshaler = &ExpirationDate{}
 
 type ExpirationDate time.Time
 
+// ParseExpirationDate parses an expiration date in the 'YYYY-MM-DD {+,-}HH:MM' format
+func ParseExpirationDate(value string) (*ExpirationDate, error) {
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return nil, err
+	}
+	e := ExpirationDate(t)
+	return &e, nil
+}
+
+func (e *ExpirationDate) String() string {
+	return time.Time(*e).Format(layout)
+}
+
 func (e *ExpirationDate) UnmarshalJSON(b []byte) error {
 	value := strings.Trim(string(b), `"`) //get rid of "
 	if value == "" || value == "null" {
@@ -33,11 +47,11 @@ func (e *ExpirationDate) UnmarshalJSON(b []byte) error {
 }
 
 func (e *ExpirationDate) MarshalJSON() ([]byte, error) {
-	return []byte(`"` + time.Time(*e).Format(layout) + `"`), nil
+	return []byte(`"` + e.String() + `"`), nil
 }
 
 func (e *ExpirationDate) MarshalYAML() (any, error) {
-	return time.Time(*e).Format(layout), nil
+	return e.String(), nil
 }
 
 func (e *ExpirationDate) UnmarshalYAML(unmarshal func(any) error) error {
@@ -13,8 +13,8 @@ var defaultBillingModel = BillingModel{
 var defaultBillingModels = []BillingModel{defaultBillingModel}
 
 type Quota struct {
-	InstanceTypeID     string           `yaml:"instance_type_id"`
-	KafkaBillingModels BillingModelList `yaml:"kafka_billing_models,omitempty"`
+	InstanceTypeID     string           `yaml:"instance_type_id" json:"instance_type_id"`
+	KafkaBillingModels BillingModelList `yaml:"kafka_billing_models,omitempty" json:"kafka_billing_models,omitempty"`
 }
 
 func (quota *Quota) GetKafkaBillingModels() BillingModelList {
@@ -22,7 +22,7 @@ func NewQuotaManagementListConfig() *QuotaManagementListConfig {
 }
 
 func (c *QuotaManagementListConfig) AddFlags(fs *pflag.FlagSet) {
-	fs.StringVar(&c.QuotaListConfigFile, "quota-management-list-config-file", c.QuotaListConfigFile, "QuotaList configuration file")
+	fs.StringVar(&c.QuotaListConfigFile, "quota-management-list-config-file", c.QuotaListConfigFile, "QuotaList configuration file, imported into the database on boot while the quota management list is empty")
 	fs.IntVar(&MaxAllowedInstances, "max-allowed-instances", MaxAllowedInstances, "Default maximum number of allowed instances that can be created by a user")
 	fs.BoolVar(&c.EnableInstanceLimitControl, "enable-instance-limit-control", c.EnableInstanceLimitControl, "Enable to enforce limits on how much instances a user can create")
 }
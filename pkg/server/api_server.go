@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 
@@ -47,6 +48,7 @@ type ServerOptions struct {
 	RouteLoaders    []environments.RouteLoader
 	Env             *environments.Env
 	ReadyConditions []ApiServerReadyCondition `di:"optional"`
+	AuthZConfig     *auth.AdminRoleAuthZConfig
 }
 
 func NewAPIServer(options ServerOptions) *ApiServer {
@@ -86,12 +88,18 @@ func NewAPIServer(options ServerOptions) *ApiServer {
 		check(loader.AddRoutes(mainRouter), "error adding routes", options.SentryConfig.Timeout)
 	}
 
+	// the roles of a route that is not registered would silently never apply, e.g. after a typo or a route rename
+	unmatchedRoutes, err := options.AuthZConfig.UnmatchedRoutes(mainRouter)
+	check(err, "unable to check the routes of the admin authz configuration", options.SentryConfig.Timeout)
+	for _, config := range unmatchedRoutes {
+		glog.Warningf("the roles of route %q of http method %q in the admin authz configuration match no route of the API", config.Route, config.HTTPMethod)
+	}
+
 	// referring to the router as type http.Handler allows us to add middleware via more handlers
 	var mainHandler http.Handler = mainRouter
 	var builder *authentication.HandlerBuilder
 	options.Env.MustResolve(&builder)
 
-	var err error
 	mainHandler, err = builder.Next(mainHandler).Build()
 	check(err, "unable to create authentication handler", options.SentryConfig.Timeout)
 
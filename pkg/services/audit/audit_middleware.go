@@ -0,0 +1,220 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/rbac"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/golang/glog"
+	"github.com/gorilla/mux"
+)
+
+// apiPathPrefix matches the base path of the APIs in the route templates, e.g. /api/kafkas_mgmt/v1/ or /api/connector_mgmt/v1/admin/
+var apiPathPrefix = regexp.MustCompile(`^/api/[^/]+/v1/(admin/)?`)
+
+// Snapshot returns the state of the resource with the given id recorded in the diff of the audit events.
+// It must not return the secrets of the resource, e.g. the credentials of a service account.
+type Snapshot func(ctx context.Context, id string) (interface{}, *errors.ServiceError)
+
+// AuditMiddleware records an audit event for each create, update and delete request to the APIs
+type AuditMiddleware interface {
+	// AuditMutations records the requests to the routes it is used on with methods other than GET and HEAD.
+	// The resource of a request is identified by the first variable of its route, e.g. {id} in /kafkas/{id}/suspend,
+	// or by the id of the response of the requests creating a resource.
+	// The state of the resources is looked up before and after the request with the snapshots of their type, if any,
+	// to record the difference between the two. The event is recorded as pending before the request is handled, so
+	// that a request whose event can not be recorded fails without changing anything, and is completed with the
+	// outcome of the request before the response is sent.
+	AuditMutations(snapshots map[string]Snapshot) func(handler http.Handler) http.Handler
+}
+
+type auditMiddleware struct {
+	auditService AuditService
+}
+
+var _ AuditMiddleware = &auditMiddleware{}
+
+func NewAuditMiddleware(auditService AuditService) AuditMiddleware {
+	return &auditMiddleware{
+		auditService: auditService,
+	}
+}
+
+func (m *auditMiddleware) AuditMutations(snapshots map[string]Snapshot) func(handler http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if request.Method == http.MethodGet || request.Method == http.MethodHead || request.Method == http.MethodOptions {
+				next.ServeHTTP(writer, request)
+				return
+			}
+
+			ctx := request.Context()
+			var pathTemplate string
+			if route := mux.CurrentRoute(request); route != nil {
+				pathTemplate, _ = route.GetPathTemplate()
+			}
+			resourceType, idVar := resourceOfRoute(pathTemplate)
+			resourceId := mux.Vars(request)[idVar]
+			action := rbac.PermissionForRequest(request.Method, pathTemplate, idVar)
+			snapshot := snapshots[resourceType]
+
+			var before map[string]interface{}
+			if snapshot != nil && resourceId != "" {
+				before = takeSnapshot(ctx, snapshot, resourceId)
+			}
+
+			event := &api.AuditEvent{
+				ResourceType: resourceType,
+				ResourceId:   resourceId,
+				Action:       string(action),
+				Method:       request.Method,
+				Route:        pathTemplate,
+				RequestId:    logger.GetOperationID(ctx),
+				Outcome:      api.AuditEventOutcomePending.String(),
+			}
+			if claims, err := auth.GetClaimsFromContext(ctx); err == nil {
+				event.Actor, _ = claims.GetUsername()
+				event.OrganisationId, _ = claims.GetOrgId()
+			}
+			// the admin requests are recorded with the organisation of the resource rather than the one of the admin
+			if orgId := organisationIdOf(before); orgId != "" {
+				event.OrganisationId = orgId
+			}
+
+			// the services don't write in the transaction of the request, the event is recorded before the request
+			// changes anything so that no change goes unaudited
+			if err := m.auditService.Record(event); err != nil {
+				logger.NewUHCLogger(ctx).Errorf("failed to record the audit event of the %s request to %s: %v", request.Method, request.URL, err)
+				shared.HandleError(request, writer, errors.GeneralError("failed to record the audit event of the request"))
+				return
+			}
+
+			recorder := &responseRecorder{header: http.Header{}, status: http.StatusOK}
+			next.ServeHTTP(recorder, request)
+
+			if resourceId == "" && action == rbac.PermissionCreate && recorder.status < http.StatusBadRequest {
+				resourceId = idOfResponse(recorder.body.Bytes())
+			}
+			var after map[string]interface{}
+			if snapshot != nil && resourceId != "" {
+				after = takeSnapshot(ctx, snapshot, resourceId)
+			}
+
+			event.ResourceId = resourceId
+			event.StatusCode = recorder.status
+			event.Outcome = api.AuditEventOutcomeSuccess.String()
+			if recorder.status >= http.StatusBadRequest {
+				event.Outcome = api.AuditEventOutcomeFailure.String()
+			}
+			if orgId := organisationIdOf(before, after); orgId != "" {
+				event.OrganisationId = orgId
+			}
+			if before != nil || after != nil {
+				event.Diff = shared.DiffAsJson(before, after, "before", "after")
+			}
+
+			// the request is handled whatever happens to its event, which stays pending when it can not be completed
+			if err := m.auditService.Complete(event); err != nil {
+				logger.NewUHCLogger(ctx).Errorf("failed to complete the audit event %s of the %s request to %s: %v", event.ID, request.Method, request.URL, err)
+			}
+			recorder.flush(writer)
+		})
+	}
+}
+
+// resourceOfRoute returns the type of the resource of a route, i.e. the path between the base path of the API and
+// the first variable of the route template, and the name of that variable
+func resourceOfRoute(pathTemplate string) (string, string) {
+	path := apiPathPrefix.ReplaceAllString(pathTemplate, "")
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			return strings.Join(segments, "/"), strings.Trim(segment, "{}")
+		}
+		segments = append(segments, segment)
+	}
+	return strings.Join(segments, "/"), ""
+}
+
+// takeSnapshot returns the state of the resource as decoded from its JSON representation, so that it is not changed
+// by the request when the snapshot returns the resource itself
+func takeSnapshot(ctx context.Context, snapshot Snapshot, id string) map[string]interface{} {
+	resource, err := snapshot(ctx, id)
+	if err != nil {
+		// the resource does not exist before a create or after a delete
+		if !err.Is404() && err.Code != errors.ErrorGone {
+			logger.NewUHCLogger(ctx).Warningf("failed to look up resource %q for the audit event: %v", id, err)
+		}
+		return nil
+	}
+	data, merr := json.Marshal(resource)
+	if merr != nil {
+		logger.NewUHCLogger(ctx).Warningf("failed to marshal resource %q for the audit event: %v", id, merr)
+		return nil
+	}
+	var state map[string]interface{}
+	if merr := json.Unmarshal(data, &state); merr != nil {
+		logger.NewUHCLogger(ctx).Warningf("failed to unmarshal resource %q for the audit event: %v", id, merr)
+		return nil
+	}
+	return state
+}
+
+func idOfResponse(body []byte) string {
+	var response struct {
+		Id string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return ""
+	}
+	return response.Id
+}
+
+func organisationIdOf(snapshots ...map[string]interface{}) string {
+	for _, snapshot := range snapshots {
+		if orgId, ok := snapshot["organisation_id"].(string); ok && orgId != "" {
+			return orgId
+		}
+	}
+	return ""
+}
+
+// responseRecorder holds the response back until the audit event is completed, and keeps its body to find the id of
+// the created resources
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *responseRecorder) Write(body []byte) (int, error) {
+	return r.body.Write(body)
+}
+
+// flush sends the recorded response
+func (r *responseRecorder) flush(writer http.ResponseWriter) {
+	for key, values := range r.header {
+		writer.Header()[key] = values
+	}
+	writer.WriteHeader(r.status)
+	if _, err := writer.Write(r.body.Bytes()); err != nil {
+		glog.Errorf("failed to write the response: %v", err)
+	}
+}
@@ -0,0 +1,280 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+	"github.com/openshift-online/ocm-sdk-go/authentication"
+)
+
+const auditTestOrgId = "13640203"
+
+func Test_auditMiddleware_AuditMutations(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		handlerStatus  int
+		recordFails    bool
+		completeFails  bool
+		wantStatusCode int
+		wantEvent      bool
+		wantType       string
+		wantId         string
+		wantAction     string
+		wantOrgId      string
+		wantOutcome    api.AuditEventOutcome
+		wantDiffChange string
+	}{
+		{
+			name:          "should not record the read requests",
+			method:        http.MethodGet,
+			url:           "/api/kafkas_mgmt/v1/kafkas/kafka-id",
+			handlerStatus: http.StatusOK,
+		},
+		{
+			name:           "should record the creation of a resource with the id of the response",
+			method:         http.MethodPost,
+			url:            "/api/kafkas_mgmt/v1/kafkas",
+			handlerStatus:  http.StatusAccepted,
+			wantEvent:      true,
+			wantType:       "kafkas",
+			wantId:         "new-kafka-id",
+			wantAction:     "create",
+			wantOrgId:      auditTestOrgId,
+			wantOutcome:    api.AuditEventOutcomeSuccess,
+			wantDiffChange: `+  "status": "accepted"`,
+		},
+		{
+			name:           "should record the deletion of a resource",
+			method:         http.MethodDelete,
+			url:            "/api/kafkas_mgmt/v1/kafkas/kafka-id",
+			handlerStatus:  http.StatusAccepted,
+			wantEvent:      true,
+			wantType:       "kafkas",
+			wantId:         "kafka-id",
+			wantAction:     "delete",
+			wantOrgId:      auditTestOrgId,
+			wantOutcome:    api.AuditEventOutcomeSuccess,
+			wantDiffChange: `-  "status": "ready"`,
+		},
+		{
+			name:          "should record the rejected requests as failures",
+			method:        http.MethodPost,
+			url:           "/api/kafkas_mgmt/v1/kafkas/kafka-id/suspend",
+			handlerStatus: http.StatusForbidden,
+			wantEvent:     true,
+			wantType:      "kafkas",
+			wantId:        "kafka-id",
+			wantAction:    "update",
+			wantOrgId:     auditTestOrgId,
+			wantOutcome:   api.AuditEventOutcomeFailure,
+		},
+		{
+			name:           "should record the admin requests with the organisation of the resource",
+			method:         http.MethodPost,
+			url:            "/api/kafkas_mgmt/v1/admin/kafkas/other-org-kafka-id/suspend",
+			handlerStatus:  http.StatusOK,
+			wantEvent:      true,
+			wantType:       "kafkas",
+			wantId:         "other-org-kafka-id",
+			wantAction:     "update",
+			wantOrgId:      "other-org",
+			wantOutcome:    api.AuditEventOutcomeSuccess,
+			wantDiffChange: `+  "status": "suspending"`,
+		},
+		{
+			name:           "should fail the request without changing the resource when its event can not be recorded",
+			method:         http.MethodDelete,
+			url:            "/api/kafkas_mgmt/v1/kafkas/kafka-id",
+			handlerStatus:  http.StatusAccepted,
+			recordFails:    true,
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "should send the response of a request whose event can not be completed",
+			method:         http.MethodDelete,
+			url:            "/api/kafkas_mgmt/v1/kafkas/kafka-id",
+			handlerStatus:  http.StatusAccepted,
+			completeFails:  true,
+			wantEvent:      true,
+			wantType:       "kafkas",
+			wantId:         "kafka-id",
+			wantAction:     "delete",
+			wantOrgId:      auditTestOrgId,
+			wantOutcome:    api.AuditEventOutcomeSuccess,
+			wantDiffChange: `-  "status": "ready"`,
+		},
+		{
+			name:          "should record the requests to the resources without snapshot",
+			method:        http.MethodDelete,
+			url:           "/api/kafkas_mgmt/v1/service_accounts/sa-id",
+			handlerStatus: http.StatusNoContent,
+			wantEvent:     true,
+			wantType:      "service_accounts",
+			wantId:        "sa-id",
+			wantAction:    "delete",
+			wantOrgId:     auditTestOrgId,
+			wantOutcome:   api.AuditEventOutcomeSuccess,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			kafkas := map[string]map[string]string{
+				"kafka-id":           {"id": "kafka-id", "status": "ready", "organisation_id": auditTestOrgId},
+				"other-org-kafka-id": {"id": "other-org-kafka-id", "status": "ready", "organisation_id": "other-org"},
+			}
+			snapshot := func(ctx context.Context, id string) (interface{}, *errors.ServiceError) {
+				kafka, ok := kafkas[id]
+				if !ok {
+					return nil, errors.NotFound("Kafka with id='%s' not found", id)
+				}
+				return kafka, nil
+			}
+			rw := httptest.NewRecorder()
+			handled := false
+			var recorded api.AuditEvent
+			auditService := &AuditServiceMock{
+				RecordFunc: func(event *api.AuditEvent) *errors.ServiceError {
+					// the event is recorded before the request is handled
+					g.Expect(handled).To(gomega.BeFalse())
+					recorded = *event
+					if tt.recordFails {
+						return errors.GeneralError("failed to record the event")
+					}
+					return nil
+				},
+				CompleteFunc: func(event *api.AuditEvent) *errors.ServiceError {
+					// the response is only sent once the event is completed
+					g.Expect(handled).To(gomega.BeTrue())
+					g.Expect(rw.Body.Len()).To(gomega.BeZero())
+					if tt.completeFails {
+						return errors.GeneralError("failed to complete the event")
+					}
+					return nil
+				},
+			}
+			middleware := NewAuditMiddleware(auditService)
+
+			handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				handled = true
+				id := mux.Vars(request)["id"]
+				if tt.handlerStatus < http.StatusBadRequest {
+					switch request.Method {
+					case http.MethodPost:
+						if id == "" {
+							id = "new-kafka-id"
+							kafkas[id] = map[string]string{"id": id, "status": "accepted", "organisation_id": auditTestOrgId}
+						} else {
+							kafkas[id]["status"] = "suspending"
+						}
+					case http.MethodDelete:
+						delete(kafkas, id)
+					}
+				}
+				writer.WriteHeader(tt.handlerStatus)
+				_ = json.NewEncoder(writer).Encode(map[string]string{"id": id})
+			})
+			router := mux.NewRouter()
+			router.Use(middleware.AuditMutations(map[string]Snapshot{"kafkas": snapshot}))
+			router.Handle("/api/kafkas_mgmt/v1/kafkas", handler)
+			router.Handle("/api/kafkas_mgmt/v1/kafkas/{id}", handler)
+			router.Handle("/api/kafkas_mgmt/v1/kafkas/{id}/suspend", handler)
+			router.Handle("/api/kafkas_mgmt/v1/admin/kafkas/{id}/suspend", handler)
+			router.Handle("/api/kafkas_mgmt/v1/service_accounts/{id}", handler)
+
+			ctx := authentication.ContextWithToken(context.Background(), &jwt.Token{
+				Claims: jwt.MapClaims{
+					"username": "user",
+					"org_id":   auditTestOrgId,
+				},
+			})
+			req := httptest.NewRequest(tt.method, tt.url, nil).WithContext(ctx)
+			router.ServeHTTP(rw, req)
+
+			wantStatusCode := tt.wantStatusCode
+			if wantStatusCode == 0 {
+				wantStatusCode = tt.handlerStatus
+			}
+			g.Expect(rw.Code).To(gomega.Equal(wantStatusCode))
+
+			if tt.recordFails {
+				g.Expect(handled).To(gomega.BeFalse())
+				g.Expect(kafkas).To(gomega.HaveKey("kafka-id"))
+				g.Expect(auditService.CompleteCalls()).To(gomega.BeEmpty())
+				return
+			}
+			if !tt.wantEvent {
+				g.Expect(auditService.RecordCalls()).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(auditService.RecordCalls()).To(gomega.HaveLen(1))
+			g.Expect(recorded.Outcome).To(gomega.Equal(api.AuditEventOutcomePending.String()))
+			g.Expect(recorded.Action).To(gomega.Equal(tt.wantAction))
+			g.Expect(auditService.CompleteCalls()).To(gomega.HaveLen(1))
+			event := auditService.CompleteCalls()[0].Event
+			g.Expect(event.Actor).To(gomega.Equal("user"))
+			g.Expect(event.ResourceType).To(gomega.Equal(tt.wantType))
+			g.Expect(event.ResourceId).To(gomega.Equal(tt.wantId))
+			g.Expect(event.Action).To(gomega.Equal(tt.wantAction))
+			g.Expect(event.Method).To(gomega.Equal(tt.method))
+			g.Expect(event.OrganisationId).To(gomega.Equal(tt.wantOrgId))
+			g.Expect(event.StatusCode).To(gomega.Equal(tt.handlerStatus))
+			g.Expect(event.Outcome).To(gomega.Equal(tt.wantOutcome.String()))
+			if tt.wantDiffChange != "" {
+				g.Expect(event.Diff).To(gomega.ContainSubstring(tt.wantDiffChange))
+			} else {
+				g.Expect(event.Diff).ToNot(gomega.ContainSubstring("status"))
+			}
+		})
+	}
+}
+
+func Test_resourceOfRoute(t *testing.T) {
+	tests := []struct {
+		pathTemplate     string
+		wantResourceType string
+		wantIdVar        string
+	}{
+		{
+			pathTemplate:     "/api/kafkas_mgmt/v1/kafkas",
+			wantResourceType: "kafkas",
+		},
+		{
+			pathTemplate:     "/api/kafkas_mgmt/v1/kafkas/{id}/maintenance_window",
+			wantResourceType: "kafkas",
+			wantIdVar:        "id",
+		},
+		{
+			pathTemplate:     "/api/connector_mgmt/v1/admin/kafka_connectors/{connector_id}",
+			wantResourceType: "kafka_connectors",
+			wantIdVar:        "connector_id",
+		},
+		{
+			pathTemplate:     "/api/kafkas_mgmt/v1/admin/quota_management_list/organisations/{id}",
+			wantResourceType: "quota_management_list/organisations",
+			wantIdVar:        "id",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.pathTemplate, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			resourceType, idVar := resourceOfRoute(tt.pathTemplate)
+			g.Expect(resourceType).To(gomega.Equal(tt.wantResourceType))
+			g.Expect(idVar).To(gomega.Equal(tt.wantIdVar))
+		})
+	}
+}
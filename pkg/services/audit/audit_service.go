@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
+)
+
+// GetValidAuditEventColumns returns the columns the audit events can be searched on
+func GetValidAuditEventColumns() []string {
+	return []string{"actor", "organisation_id", "resource_type", "resource_id", "action", "method", "request_id", "outcome"}
+}
+
+//go:generate moq -out audit_service_moq.go . AuditService
+type AuditService interface {
+	// Record stores the audit event of a request before the request is handled
+	Record(event *api.AuditEvent) *errors.ServiceError
+	// Complete stores the outcome of the request of a pending event, the resource it changed and the diff of the
+	// resource. The events are never updated otherwise nor deleted
+	Complete(event *api.AuditEvent) *errors.ServiceError
+	// List returns the audit events matching the search of the list arguments, the most recent first
+	List(listArgs *services.ListArguments) (api.AuditEventList, *api.PagingMeta, *errors.ServiceError)
+}
+
+var _ AuditService = &auditService{}
+
+type auditService struct {
+	connectionFactory *db.ConnectionFactory
+}
+
+func NewAuditService(connectionFactory *db.ConnectionFactory) AuditService {
+	return &auditService{
+		connectionFactory: connectionFactory,
+	}
+}
+
+func (a *auditService) Record(event *api.AuditEvent) *errors.ServiceError {
+	if err := a.connectionFactory.New().Create(event).Error; err != nil {
+		return services.HandleCreateError("AuditEvent", err)
+	}
+	return nil
+}
+
+func (a *auditService) Complete(event *api.AuditEvent) *errors.ServiceError {
+	if err := a.connectionFactory.New().Model(event).
+		Where("outcome = ?", api.AuditEventOutcomePending.String()).
+		Select("resource_id", "organisation_id", "diff", "status_code", "outcome").
+		Updates(event).Error; err != nil {
+		return services.HandleUpdateError("AuditEvent", err)
+	}
+	return nil
+}
+
+func (a *auditService) List(listArgs *services.ListArguments) (api.AuditEventList, *api.PagingMeta, *errors.ServiceError) {
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	dbConn := a.connectionFactory.New().Model(&api.AuditEvent{})
+	if len(listArgs.Search) > 0 {
+		searchDbQuery, err := queryparser.NewQueryParser(GetValidAuditEventColumns()...).Parse(listArgs.Search)
+		if err != nil {
+			return nil, pagingMeta, errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "unable to list audit events: %s", err.Error())
+		}
+		dbConn = dbConn.Where(searchDbQuery.Query, searchDbQuery.Values...)
+	}
+
+	var total int64
+	if err := dbConn.Count(&total).Error; err != nil {
+		return nil, pagingMeta, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list audit events")
+	}
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+
+	var events api.AuditEventList
+	if err := dbConn.Order("created_at desc").
+		Offset((pagingMeta.Page - 1) * pagingMeta.Size).
+		Limit(pagingMeta.Size).
+		Find(&events).Error; err != nil {
+		return nil, pagingMeta, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list audit events")
+	}
+	return events, pagingMeta, nil
+}
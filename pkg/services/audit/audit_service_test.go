@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_auditService_Record(t *testing.T) {
+	g := gomega.NewWithT(t)
+	mocket.Catcher.Reset()
+	a := NewAuditService(db.NewMockConnectionFactory(nil))
+
+	event := &api.AuditEvent{
+		Actor:        "user",
+		ResourceType: "kafkas",
+		ResourceId:   "kafka-id",
+		Action:       "delete",
+	}
+	g.Expect(a.Record(event)).To(gomega.BeNil())
+	g.Expect(event.ID).ToNot(gomega.BeEmpty())
+}
+
+func Test_auditService_Complete(t *testing.T) {
+	g := gomega.NewWithT(t)
+	mocket.Catcher.Reset()
+	completed := false
+	mocket.Catcher.NewMock().WithQuery(`UPDATE "audit_events" SET`).WithRowsNum(1).
+		WithCallback(func(query string, args []driver.NamedValue) {
+			// only the pending event is completed, with the outcome of its request
+			g.Expect(query).To(gomega.ContainSubstring(`WHERE outcome = $`))
+			g.Expect(query).ToNot(gomega.ContainSubstring(`"actor"`))
+			completed = true
+		})
+	a := NewAuditService(db.NewMockConnectionFactory(nil))
+
+	event := &api.AuditEvent{
+		Meta:         api.Meta{ID: "event-id"},
+		Actor:        "user",
+		ResourceType: "kafkas",
+		ResourceId:   "kafka-id",
+		Action:       "delete",
+		StatusCode:   202,
+		Outcome:      api.AuditEventOutcomeSuccess.String(),
+	}
+	g.Expect(a.Complete(event)).To(gomega.BeNil())
+	g.Expect(completed).To(gomega.BeTrue())
+}
+
+func Test_auditService_List(t *testing.T) {
+	tests := []struct {
+		name      string
+		search    string
+		setupFn   func()
+		wantCode  errors.ServiceErrorCode
+		wantTotal int
+		wantIds   []string
+	}{
+		{
+			name:     "should return an error when the search is on an unknown column",
+			search:   "diff like %secret%",
+			setupFn:  func() {},
+			wantCode: errors.ErrorFailedToParseSearch,
+		},
+		{
+			name:   "should return the events matching the search",
+			search: "resource_type = kafkas and action = delete",
+			setupFn: func() {
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT count(1) FROM "audit_events" WHERE (resource_type = $1 and action = $2)`).
+					WithArgs("kafkas", "delete").
+					WithReply([]map[string]interface{}{{"count": 1}})
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "audit_events" WHERE (resource_type = $1 and action = $2)`).
+					WithArgs("kafkas", "delete").
+					WithReply([]map[string]interface{}{{"id": "event-id", "resource_type": "kafkas", "action": "delete"}})
+			},
+			wantTotal: 1,
+			wantIds:   []string{"event-id"},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			tt.setupFn()
+			a := NewAuditService(db.NewMockConnectionFactory(nil))
+
+			events, paging, err := a.List(&services.ListArguments{Page: 1, Size: 100, Search: tt.search})
+			if tt.wantCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(paging.Total).To(gomega.Equal(tt.wantTotal))
+			var ids []string
+			for _, event := range events {
+				ids = append(ids, event.ID)
+			}
+			g.Expect(ids).To(gomega.Equal(tt.wantIds))
+		})
+	}
+}
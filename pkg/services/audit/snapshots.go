@@ -0,0 +1,50 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/rbac"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+)
+
+// WebhookSnapshot returns the snapshot of the webhook endpoints of the organisation of the user, without their secret
+func WebhookSnapshot(webhookService webhooks.WebhookService) Snapshot {
+	return func(ctx context.Context, id string) (interface{}, *errors.ServiceError) {
+		orgId, err := organisationIdOfUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+		webhook, err := webhookService.Get(orgId, id)
+		if err != nil {
+			return nil, err
+		}
+		snapshot := *webhook
+		snapshot.Secret = ""
+		return snapshot, nil
+	}
+}
+
+// GrantSnapshot returns the snapshot of the grants of the organisation of the user
+func GrantSnapshot(rbacService rbac.RBACService) Snapshot {
+	return func(ctx context.Context, id string) (interface{}, *errors.ServiceError) {
+		orgId, err := organisationIdOfUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return rbacService.GetGrant(orgId, id)
+	}
+}
+
+func organisationIdOfUser(ctx context.Context) (string, *errors.ServiceError) {
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return "", errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+	}
+	orgId, err := claims.GetOrgId()
+	if err != nil {
+		return "", errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+	}
+	return orgId, nil
+}
@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/rbac"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/onsi/gomega"
+	"github.com/openshift-online/ocm-sdk-go/authentication"
+)
+
+func Test_WebhookSnapshot(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		webhook      *api.WebhookEndpoint
+		getErr       *errors.ServiceError
+		wantSnapshot interface{}
+		wantErr      bool
+	}{
+		{
+			name: "returns the webhook of the organisation of the user without its secret",
+			ctx:  snapshotTestContext(),
+			webhook: &api.WebhookEndpoint{
+				Meta:           api.Meta{ID: "webhook-id"},
+				OrganisationId: auditTestOrgId,
+				URL:            "https://example.com/events",
+				Secret:         "secret",
+			},
+			wantSnapshot: api.WebhookEndpoint{
+				Meta:           api.Meta{ID: "webhook-id"},
+				OrganisationId: auditTestOrgId,
+				URL:            "https://example.com/events",
+			},
+		},
+		{
+			name:    "returns the error of the webhook service",
+			ctx:     snapshotTestContext(),
+			getErr:  errors.NotFound("webhook not found"),
+			wantErr: true,
+		},
+		{
+			name:    "fails without the claims of the user",
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			webhookService := &webhooks.WebhookServiceMock{
+				GetFunc: func(organisationId string, id string) (*api.WebhookEndpoint, *errors.ServiceError) {
+					g.Expect(organisationId).To(gomega.Equal(auditTestOrgId))
+					g.Expect(id).To(gomega.Equal("webhook-id"))
+					return tt.webhook, tt.getErr
+				},
+			}
+			snapshot, err := WebhookSnapshot(webhookService)(tt.ctx, "webhook-id")
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(snapshot).To(gomega.Equal(tt.wantSnapshot))
+				g.Expect(tt.webhook.Secret).To(gomega.Equal("secret"))
+			}
+		})
+	}
+}
+
+func Test_GrantSnapshot(t *testing.T) {
+	g := gomega.NewWithT(t)
+	grant := &api.ResourceGrant{Meta: api.Meta{ID: "grant-id"}, OrganisationId: auditTestOrgId}
+	rbacService := &rbac.RBACServiceMock{
+		GetGrantFunc: func(organisationId string, id string) (*api.ResourceGrant, *errors.ServiceError) {
+			g.Expect(organisationId).To(gomega.Equal(auditTestOrgId))
+			g.Expect(id).To(gomega.Equal("grant-id"))
+			return grant, nil
+		},
+	}
+
+	snapshot, err := GrantSnapshot(rbacService)(snapshotTestContext(), "grant-id")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(snapshot).To(gomega.Equal(grant))
+}
+
+func snapshotTestContext() context.Context {
+	return authentication.ContextWithToken(context.Background(), &jwt.Token{
+		Claims: jwt.MapClaims{
+			"username": "user",
+			"org_id":   auditTestOrgId,
+		},
+	})
+}
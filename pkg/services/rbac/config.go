@@ -0,0 +1,39 @@
+package rbac
+
+import (
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/spf13/pflag"
+)
+
+type Config struct {
+	Enabled bool `json:"enabled"`
+	// DefaultOrganisationRole is the role on the resources of the organisation of the users without a grant on the organisation.
+	// It defaults to member so that the users create resources while only the owners, the organisation admins and the
+	// granted users change the resources of others
+	DefaultOrganisationRole string `json:"default_organisation_role"`
+}
+
+func NewConfig() *Config {
+	return &Config{
+		Enabled:                 false,
+		DefaultOrganisationRole: RoleMember.String(),
+	}
+}
+
+func (c *Config) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&c.Enabled, "enable-rbac", c.Enabled, "Enable the role based access control of the public Kafka, connector and service account APIs.")
+	fs.StringVar(&c.DefaultOrganisationRole, "rbac-default-organisation-role", c.DefaultOrganisationRole, "The role on the resources of their organisation of the users without a grant on the organisation. One of [viewer, member, billing, editor, owner], or empty to only allow the users to access their own resources and the resources they have a grant on.")
+}
+
+func (c *Config) ReadFiles() error {
+	return nil
+}
+
+func (c *Config) Validate(env *environments.Env) error {
+	if c.DefaultOrganisationRole != "" && !Role(c.DefaultOrganisationRole).IsValid() {
+		return fmt.Errorf("invalid default organisation role %q, expected to be one of %v", c.DefaultOrganisationRole, ValidRoles)
+	}
+	return nil
+}
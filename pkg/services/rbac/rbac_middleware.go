@@ -0,0 +1,110 @@
+package rbac
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/golang/glog"
+	"github.com/gorilla/mux"
+)
+
+// RBACMiddleware enforces the roles granted to the users on the resources of their organisation.
+// It is used by the public APIs alongside the RolesAuthorizationMiddleware of the admin APIs.
+type RBACMiddleware interface {
+	// RequirePermission checks that the user of the request has the permission required by the request method on the
+	// resource identified by the idVar route variable, or on its organisation for the requests to the collection.
+	// The resources are looked up with lookup. It is nil for the collections whose resources roles can not be granted on,
+	// e.g. the service accounts, in which case only the requests to the collection are checked and the requests to a
+	// single resource are left to the owner checks of the handlers.
+	RequirePermission(idVar string, lookup ResourceLookup) func(handler http.Handler) http.Handler
+}
+
+type rbacMiddleware struct {
+	rbacService RBACService
+	config      *Config
+}
+
+var _ RBACMiddleware = &rbacMiddleware{}
+
+func NewRBACMiddleware(rbacService RBACService, config *Config) RBACMiddleware {
+	return &rbacMiddleware{
+		rbacService: rbacService,
+		config:      config,
+	}
+}
+
+func (m *rbacMiddleware) RequirePermission(idVar string, lookup ResourceLookup) func(handler http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if !m.config.Enabled {
+				next.ServeHTTP(writer, request)
+				return
+			}
+
+			ctx := request.Context()
+			id, isResourceRequest := mux.Vars(request)[idVar]
+			var pathTemplate string
+			if route := mux.CurrentRoute(request); route != nil {
+				pathTemplate, _ = route.GetPathTemplate()
+			}
+			permission := PermissionForRequest(request.Method, pathTemplate, idVar)
+
+			if isResourceRequest && lookup == nil {
+				next.ServeHTTP(writer, request)
+				return
+			}
+
+			var resource *Resource
+			if isResourceRequest {
+				var err *errors.ServiceError
+				resource, err = lookup(ctx, id)
+				if err != nil {
+					if err.Is404() || err.Code == errors.ErrorGone {
+						// the handler returns the same error the user would get without access control
+						next.ServeHTTP(writer, request)
+						return
+					}
+					shared.HandleError(request, writer, err)
+					return
+				}
+				if orgId := getOrgId(request); resource.OrganisationId != orgId {
+					// the resources of the other organisations are not found by the handlers
+					next.ServeHTTP(writer, request)
+					return
+				}
+			}
+
+			allowed, err := m.rbacService.IsAllowed(ctx, resource, permission)
+			if err != nil {
+				shared.HandleError(request, writer, err)
+				return
+			}
+			if !allowed && resource == nil && permission == PermissionRead {
+				// the users without a role on their organisation still list their own resources
+				next.ServeHTTP(writer, request)
+				return
+			}
+			if !allowed {
+				glog.Infof("user is missing the %q permission, deny the request for url %s", permission, request.URL)
+				shared.HandleError(request, writer, errors.New(errors.ErrorForbidden, "user is not allowed to %s this resource", permission))
+				return
+			}
+
+			// the resources of the organisation are visible to the users the request has been allowed to
+			ctx = auth.SetFilterByOrganisationContext(ctx, true)
+			ctx = auth.SetIsGrantedContext(ctx, true)
+			next.ServeHTTP(writer, request.WithContext(ctx))
+		})
+	}
+}
+
+func getOrgId(request *http.Request) string {
+	claims, err := auth.GetClaimsFromContext(request.Context())
+	if err != nil {
+		return ""
+	}
+	orgId, _ := claims.GetOrgId()
+	return orgId
+}
@@ -0,0 +1,127 @@
+package rbac
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+func Test_rbacMiddleware_RequirePermission(t *testing.T) {
+	kafkaLookup := func(ctx context.Context, id string) (*Resource, *errors.ServiceError) {
+		switch id {
+		case "kafka-id":
+			return &Resource{Type: api.GrantResourceTypeKafka, Id: id, Owner: "owner", OrganisationId: rbacTestOrgId}, nil
+		case "other-org-kafka-id":
+			return &Resource{Type: api.GrantResourceTypeKafka, Id: id, Owner: "owner", OrganisationId: "other-org"}, nil
+		}
+		return nil, errors.NotFound("Kafka with id='%s' not found", id)
+	}
+
+	tests := []struct {
+		name           string
+		enabled        bool
+		method         string
+		url            string
+		allowed        bool
+		wantStatusCode int
+		wantPermission Permission
+		wantGranted    bool
+	}{
+		{
+			name:           "should not check the permissions when rbac is disabled",
+			method:         http.MethodDelete,
+			url:            "/kafkas/kafka-id",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "should deny the request when the user is missing the permission",
+			enabled:        true,
+			method:         http.MethodDelete,
+			url:            "/kafkas/kafka-id",
+			wantStatusCode: http.StatusForbidden,
+			wantPermission: PermissionDelete,
+		},
+		{
+			name:           "should allow the request when the user has the permission",
+			enabled:        true,
+			allowed:        true,
+			method:         http.MethodPost,
+			url:            "/kafkas/kafka-id/suspend",
+			wantStatusCode: http.StatusOK,
+			wantPermission: PermissionUpdate,
+			wantGranted:    true,
+		},
+		{
+			name:           "should let the handler reject the requests to a resource that does not exist",
+			enabled:        true,
+			method:         http.MethodGet,
+			url:            "/kafkas/unknown-id",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "should let the handler reject the requests to a resource of another organisation",
+			enabled:        true,
+			method:         http.MethodGet,
+			url:            "/kafkas/other-org-kafka-id",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "should let the users without a role on their organisation list their own resources",
+			enabled:        true,
+			method:         http.MethodGet,
+			url:            "/kafkas",
+			wantStatusCode: http.StatusOK,
+			wantPermission: PermissionRead,
+		},
+		{
+			name:           "should deny the creation of a resource when the user is missing the permission",
+			enabled:        true,
+			method:         http.MethodPost,
+			url:            "/kafkas",
+			wantStatusCode: http.StatusForbidden,
+			wantPermission: PermissionCreate,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			rbacService := &RBACServiceMock{
+				IsAllowedFunc: func(ctx context.Context, resource *Resource, permission Permission) (bool, *errors.ServiceError) {
+					return tt.allowed, nil
+				},
+			}
+			middleware := NewRBACMiddleware(rbacService, &Config{Enabled: tt.enabled})
+
+			var granted bool
+			handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				granted = auth.GetIsGrantedFromContext(request.Context())
+			})
+			router := mux.NewRouter()
+			router.Use(middleware.RequirePermission("id", kafkaLookup))
+			router.Handle("/kafkas", handler)
+			router.Handle("/kafkas/{id}", handler)
+			router.Handle("/kafkas/{id}/suspend", handler)
+
+			req := httptest.NewRequest(tt.method, tt.url, nil).WithContext(rbacContext("user", false))
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode))
+			g.Expect(granted).To(gomega.Equal(tt.wantGranted))
+			if tt.wantPermission != "" {
+				g.Expect(rbacService.IsAllowedCalls()).To(gomega.HaveLen(1))
+				g.Expect(rbacService.IsAllowedCalls()[0].Permission).To(gomega.Equal(tt.wantPermission))
+			} else {
+				g.Expect(rbacService.IsAllowedCalls()).To(gomega.BeEmpty())
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+)
+
+// Resource is a resource of an organisation roles can be granted on, e.g. a Kafka instance or a connector
+type Resource struct {
+	Type           api.GrantResourceType
+	Id             string
+	Owner          string
+	OrganisationId string
+}
+
+// ResourceLookup returns the resource with the given id whoever the user of the request is
+type ResourceLookup func(ctx context.Context, id string) (*Resource, *errors.ServiceError)
+
+//go:generate moq -out rbac_service_moq.go . RBACService
+type RBACService interface {
+	// IsAllowed returns true if the user of the request has the permission on the resource.
+	// The resource is nil for the requests to a collection, e.g. to create a Kafka instance, which only need the
+	// permission on the organisation of the user.
+	IsAllowed(ctx context.Context, resource *Resource, permission Permission) (bool, *errors.ServiceError)
+	// GetOrganisationRole returns the role of the user of the request on the resources of its organisation
+	GetOrganisationRole(ctx context.Context) (Role, *errors.ServiceError)
+	CreateGrant(grant *api.ResourceGrant) *errors.ServiceError
+	GetGrant(organisationId, id string) (*api.ResourceGrant, *errors.ServiceError)
+	// ListGrants returns the grants of the organisation on the given resource types
+	ListGrants(organisationId string, resourceTypes []api.GrantResourceType, listArgs *services.ListArguments) (api.ResourceGrantList, *api.PagingMeta, *errors.ServiceError)
+	DeleteGrant(organisationId, id string) *errors.ServiceError
+}
+
+var _ RBACService = &rbacService{}
+
+type rbacService struct {
+	connectionFactory *db.ConnectionFactory
+	config            *Config
+}
+
+func NewRBACService(connectionFactory *db.ConnectionFactory, config *Config) RBACService {
+	return &rbacService{
+		connectionFactory: connectionFactory,
+		config:            config,
+	}
+}
+
+func (r *rbacService) IsAllowed(ctx context.Context, resource *Resource, permission Permission) (bool, *errors.ServiceError) {
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return false, errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+	}
+	username, _ := claims.GetUsername()
+	orgId, _ := claims.GetOrgId()
+	if username == "" {
+		return false, errors.Unauthenticated("user not authenticated")
+	}
+
+	if resource != nil {
+		if resource.OrganisationId != orgId {
+			return false, nil
+		}
+		// the owner of a resource is always allowed to manage it
+		if resource.Owner == username {
+			return true, nil
+		}
+	}
+
+	// organisation admins are the owners of all the resources of their organisation
+	if claims.IsOrgAdmin() {
+		return true, nil
+	}
+
+	grants, serr := r.findGrants(orgId, username, resource)
+	if serr != nil {
+		return false, serr
+	}
+
+	roles := []Role{Role(r.config.DefaultOrganisationRole)}
+	for _, grant := range grants {
+		if grant.ResourceType == api.GrantResourceTypeOrganisation.String() {
+			// the grant on the organisation replaces the default role
+			roles[0] = Role(grant.Role)
+		} else {
+			roles = append(roles, Role(grant.Role))
+		}
+	}
+
+	for _, role := range roles {
+		if role.HasPermission(permission) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *rbacService) GetOrganisationRole(ctx context.Context) (Role, *errors.ServiceError) {
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return "", errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+	}
+	if claims.IsOrgAdmin() {
+		return RoleOwner, nil
+	}
+	username, _ := claims.GetUsername()
+	orgId, _ := claims.GetOrgId()
+
+	grants, serr := r.findGrants(orgId, username, nil)
+	if serr != nil {
+		return "", serr
+	}
+	if len(grants) > 0 {
+		return Role(grants[0].Role), nil
+	}
+	return Role(r.config.DefaultOrganisationRole), nil
+}
+
+// findGrants returns the grants of the user on its organisation and, if set, on the resource
+func (r *rbacService) findGrants(orgId, username string, resource *Resource) (api.ResourceGrantList, *errors.ServiceError) {
+	dbConn := r.connectionFactory.New().
+		Where("organisation_id = ? AND subject = ?", orgId, username)
+	if resource != nil {
+		dbConn = dbConn.Where("((resource_type = ? AND resource_id = ?) OR (resource_type = ? AND resource_id = ?))",
+			api.GrantResourceTypeOrganisation.String(), orgId, resource.Type.String(), resource.Id)
+	} else {
+		dbConn = dbConn.Where("resource_type = ? AND resource_id = ?", api.GrantResourceTypeOrganisation.String(), orgId)
+	}
+
+	var grants api.ResourceGrantList
+	if err := dbConn.Find(&grants).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to find the grants of user %q", username)
+	}
+	return grants, nil
+}
+
+func (r *rbacService) CreateGrant(grant *api.ResourceGrant) *errors.ServiceError {
+	role := Role(grant.Role)
+	if !role.IsValid() {
+		return errors.Validation("invalid role %q, expected to be one of %v", grant.Role, ValidRoles)
+	}
+	if (role == RoleBilling || role == RoleMember) && grant.ResourceType != api.GrantResourceTypeOrganisation.String() {
+		return errors.Validation("the %q role can only be granted on the organisation", role)
+	}
+
+	if err := r.connectionFactory.New().Create(grant).Error; err != nil {
+		return services.HandleCreateError("Grant", err)
+	}
+	return nil
+}
+
+func (r *rbacService) GetGrant(organisationId, id string) (*api.ResourceGrant, *errors.ServiceError) {
+	if id == "" {
+		return nil, errors.Validation("grant id is undefined")
+	}
+
+	var grant api.ResourceGrant
+	if err := r.connectionFactory.New().
+		Where("id = ? AND organisation_id = ?", id, organisationId).
+		First(&grant).Error; err != nil {
+		return nil, services.HandleGetError("Grant", "id", id, err)
+	}
+	return &grant, nil
+}
+
+func (r *rbacService) ListGrants(organisationId string, resourceTypes []api.GrantResourceType, listArgs *services.ListArguments) (api.ResourceGrantList, *api.PagingMeta, *errors.ServiceError) {
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	dbConn := r.connectionFactory.New().Model(&api.ResourceGrant{}).
+		Where("organisation_id = ? AND resource_type IN (?)", organisationId, resourceTypes)
+
+	var total int64
+	if err := dbConn.Count(&total).Error; err != nil {
+		return nil, pagingMeta, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list grants")
+	}
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+
+	var grants api.ResourceGrantList
+	if err := dbConn.Order("created_at desc").
+		Offset((pagingMeta.Page - 1) * pagingMeta.Size).
+		Limit(pagingMeta.Size).
+		Find(&grants).Error; err != nil {
+		return nil, pagingMeta, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list grants")
+	}
+	return grants, pagingMeta, nil
+}
+
+func (r *rbacService) DeleteGrant(organisationId, id string) *errors.ServiceError {
+	grant, err := r.GetGrant(organisationId, id)
+	if err != nil {
+		return err
+	}
+
+	// the grant is removed for good so that the same role can be granted again to the user
+	if err := r.connectionFactory.New().Unscoped().Delete(grant).Error; err != nil {
+		return services.HandleDeleteError("Grant", "id", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/onsi/gomega"
+	"github.com/openshift-online/ocm-sdk-go/authentication"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+const rbacTestOrgId = "13640203"
+
+func rbacContext(username string, isOrgAdmin bool) context.Context {
+	return authentication.ContextWithToken(context.Background(), &jwt.Token{
+		Claims: jwt.MapClaims{
+			"username":     username,
+			"org_id":       rbacTestOrgId,
+			"is_org_admin": isOrgAdmin,
+		},
+	})
+}
+
+func grantRow(resourceType api.GrantResourceType, resourceId string, role Role) map[string]interface{} {
+	return map[string]interface{}{
+		"id":              "grant-id",
+		"organisation_id": rbacTestOrgId,
+		"subject":         "user",
+		"resource_type":   resourceType.String(),
+		"resource_id":     resourceId,
+		"role":            role.String(),
+	}
+}
+
+func Test_rbacService_IsAllowed(t *testing.T) {
+	kafka := &Resource{Type: api.GrantResourceTypeKafka, Id: "kafka-id", Owner: "owner", OrganisationId: rbacTestOrgId}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		defaultRole Role
+		resource    *Resource
+		permission  Permission
+		grants      []map[string]interface{}
+		want        bool
+	}{
+		{
+			name:        "should not allow access to the resources of another organisation",
+			ctx:         rbacContext("owner", true),
+			defaultRole: RoleOwner,
+			resource:    &Resource{Type: api.GrantResourceTypeKafka, Id: "kafka-id", Owner: "owner", OrganisationId: "other-org"},
+			permission:  PermissionRead,
+			want:        false,
+		},
+		{
+			name:       "should allow the owner of the resource",
+			ctx:        rbacContext("owner", false),
+			resource:   kafka,
+			permission: PermissionDelete,
+			want:       true,
+		},
+		{
+			name:       "should allow the organisation admins",
+			ctx:        rbacContext("user", true),
+			resource:   kafka,
+			permission: PermissionManageGrants,
+			want:       true,
+		},
+		{
+			name:        "should allow the users with the default organisation role",
+			ctx:         rbacContext("user", false),
+			defaultRole: RoleEditor,
+			resource:    kafka,
+			permission:  PermissionUpdate,
+			want:        true,
+		},
+		{
+			name:        "should not allow the users without a grant to update the resources of others by default",
+			ctx:         rbacContext("user", false),
+			defaultRole: Role(NewConfig().DefaultOrganisationRole),
+			resource:    kafka,
+			permission:  PermissionUpdate,
+			want:        false,
+		},
+		{
+			name:        "should allow the users without a grant to read the resources of others by default",
+			ctx:         rbacContext("user", false),
+			defaultRole: Role(NewConfig().DefaultOrganisationRole),
+			resource:    kafka,
+			permission:  PermissionRead,
+			want:        true,
+		},
+		{
+			name:        "should allow the users without a grant to create resources by default",
+			ctx:         rbacContext("user", false),
+			defaultRole: Role(NewConfig().DefaultOrganisationRole),
+			permission:  PermissionCreate,
+			want:        true,
+		},
+		{
+			name:        "should not allow the users without a grant to delete the resources of others by default",
+			ctx:         rbacContext("user", false),
+			defaultRole: Role(NewConfig().DefaultOrganisationRole),
+			resource:    kafka,
+			permission:  PermissionDelete,
+			want:        false,
+		},
+		{
+			name:        "should deny the permissions not allowed by the default organisation role",
+			ctx:         rbacContext("user", false),
+			defaultRole: RoleEditor,
+			resource:    kafka,
+			permission:  PermissionDelete,
+			want:        false,
+		},
+		{
+			name:        "should replace the default organisation role with the role granted on the organisation",
+			ctx:         rbacContext("user", false),
+			defaultRole: RoleEditor,
+			permission:  PermissionCreate,
+			grants:      []map[string]interface{}{grantRow(api.GrantResourceTypeOrganisation, rbacTestOrgId, RoleViewer)},
+			want:        false,
+		},
+		{
+			name:       "should allow the permissions of the role granted on the resource",
+			ctx:        rbacContext("user", false),
+			resource:   kafka,
+			permission: PermissionDelete,
+			grants: []map[string]interface{}{
+				grantRow(api.GrantResourceTypeOrganisation, rbacTestOrgId, RoleViewer),
+				grantRow(api.GrantResourceTypeKafka, "kafka-id", RoleOwner),
+			},
+			want: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "resource_grants"`).WithReply(tt.grants)
+			r := &rbacService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				config:            &Config{Enabled: true, DefaultOrganisationRole: tt.defaultRole.String()},
+			}
+			allowed, err := r.IsAllowed(tt.ctx, tt.resource, tt.permission)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(allowed).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_rbacService_CreateGrant(t *testing.T) {
+	tests := []struct {
+		name     string
+		grant    *api.ResourceGrant
+		wantCode errors.ServiceErrorCode
+	}{
+		{
+			name:     "should fail if the role is invalid",
+			grant:    &api.ResourceGrant{ResourceType: api.GrantResourceTypeOrganisation.String(), Role: "admin"},
+			wantCode: errors.ErrorValidation,
+		},
+		{
+			name:     "should fail if the billing role is granted on a resource",
+			grant:    &api.ResourceGrant{ResourceType: api.GrantResourceTypeKafka.String(), Role: RoleBilling.String()},
+			wantCode: errors.ErrorValidation,
+		},
+		{
+			name:     "should fail if the member role is granted on a resource",
+			grant:    &api.ResourceGrant{ResourceType: api.GrantResourceTypeKafka.String(), Role: RoleMember.String()},
+			wantCode: errors.ErrorValidation,
+		},
+		{
+			name:  "should grant the billing role on the organisation",
+			grant: &api.ResourceGrant{ResourceType: api.GrantResourceTypeOrganisation.String(), Role: RoleBilling.String()},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			r := &rbacService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				config:            NewConfig(),
+			}
+			err := r.CreateGrant(tt.grant)
+			if tt.wantCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantCode))
+			} else {
+				g.Expect(err).To(gomega.BeNil())
+			}
+		})
+	}
+}
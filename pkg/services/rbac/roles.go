@@ -0,0 +1,81 @@
+package rbac
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type Role string
+
+const (
+	// RoleViewer can read the resources
+	RoleViewer Role = "viewer"
+	// RoleMember can read the resources and create new ones, which it owns. It is only granted on the organisation
+	RoleMember Role = "member"
+	// RoleBilling can read the resources to follow the consumption of the organisation. It is only granted on the organisation
+	RoleBilling Role = "billing"
+	// RoleEditor can read, create and update the resources
+	RoleEditor Role = "editor"
+	// RoleOwner can read, create, update and delete the resources and manage their grants
+	RoleOwner Role = "owner"
+)
+
+var ValidRoles = []Role{RoleViewer, RoleMember, RoleBilling, RoleEditor, RoleOwner}
+
+func (r Role) String() string {
+	return string(r)
+}
+
+func (r Role) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// HasPermission returns true if the role allows the permission
+func (r Role) HasPermission(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+type Permission string
+
+const (
+	PermissionRead         Permission = "read"
+	PermissionCreate       Permission = "create"
+	PermissionUpdate       Permission = "update"
+	PermissionDelete       Permission = "delete"
+	PermissionManageGrants Permission = "manage_grants"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleViewer:  {PermissionRead},
+	RoleMember:  {PermissionRead, PermissionCreate},
+	RoleBilling: {PermissionRead},
+	RoleEditor:  {PermissionRead, PermissionCreate, PermissionUpdate},
+	RoleOwner:   {PermissionRead, PermissionCreate, PermissionUpdate, PermissionDelete, PermissionManageGrants},
+}
+
+// PermissionForRequest returns the permission required by a request, from its method and from the path template
+// of its route, e.g. /kafkas/{id}/suspend, in which idVar identifies the resource.
+// The requests to a sub-resource of a resource, e.g. to suspend a Kafka instance or to delete its maintenance
+// window, need the update permission on the resource while the POST requests to a collection need the create permission.
+func PermissionForRequest(method string, pathTemplate string, idVar string) Permission {
+	resourceVar := fmt.Sprintf("{%s}", idVar)
+	isCollectionRequest := !strings.Contains(pathTemplate, resourceVar)
+	isResourceRequest := strings.HasSuffix(pathTemplate, resourceVar)
+
+	switch {
+	case method == http.MethodGet || method == http.MethodHead:
+		return PermissionRead
+	case method == http.MethodPost && isCollectionRequest:
+		return PermissionCreate
+	case method == http.MethodDelete && isResourceRequest:
+		return PermissionDelete
+	}
+	return PermissionUpdate
+}
@@ -0,0 +1,78 @@
+package rbac
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_PermissionForRequest(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		pathTemplate string
+		want         Permission
+	}{
+		{
+			name:         "should require the read permission to list the resources",
+			method:       http.MethodGet,
+			pathTemplate: "/api/kafkas_mgmt/v1/kafkas",
+			want:         PermissionRead,
+		},
+		{
+			name:         "should require the read permission to get a sub-resource",
+			method:       http.MethodGet,
+			pathTemplate: "/api/kafkas_mgmt/v1/kafkas/{id}/metrics/query",
+			want:         PermissionRead,
+		},
+		{
+			name:         "should require the create permission to create a resource",
+			method:       http.MethodPost,
+			pathTemplate: "/api/kafkas_mgmt/v1/kafkas",
+			want:         PermissionCreate,
+		},
+		{
+			name:         "should require the update permission to update a resource",
+			method:       http.MethodPatch,
+			pathTemplate: "/api/kafkas_mgmt/v1/kafkas/{id}",
+			want:         PermissionUpdate,
+		},
+		{
+			name:         "should require the update permission to act on a resource",
+			method:       http.MethodPost,
+			pathTemplate: "/api/kafkas_mgmt/v1/kafkas/{id}/suspend",
+			want:         PermissionUpdate,
+		},
+		{
+			name:         "should require the delete permission to delete a resource",
+			method:       http.MethodDelete,
+			pathTemplate: "/api/kafkas_mgmt/v1/kafkas/{id}",
+			want:         PermissionDelete,
+		},
+		{
+			name:         "should require the update permission to delete a sub-resource",
+			method:       http.MethodDelete,
+			pathTemplate: "/api/kafkas_mgmt/v1/kafkas/{id}/maintenance_window",
+			want:         PermissionUpdate,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(PermissionForRequest(tt.method, tt.pathTemplate, "id")).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_Role_HasPermission(t *testing.T) {
+	g := gomega.NewWithT(t)
+	g.Expect(RoleViewer.HasPermission(PermissionRead)).To(gomega.BeTrue())
+	g.Expect(RoleViewer.HasPermission(PermissionUpdate)).To(gomega.BeFalse())
+	g.Expect(RoleEditor.HasPermission(PermissionCreate)).To(gomega.BeTrue())
+	g.Expect(RoleEditor.HasPermission(PermissionDelete)).To(gomega.BeFalse())
+	g.Expect(RoleOwner.HasPermission(PermissionManageGrants)).To(gomega.BeTrue())
+	g.Expect(Role("admin").HasPermission(PermissionRead)).To(gomega.BeFalse())
+}
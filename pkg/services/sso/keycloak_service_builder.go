@@ -1,8 +1,12 @@
 package sso
 
 import (
+	"crypto/sha256"
+
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/oidc"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/redhatsso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 )
 
@@ -26,11 +30,13 @@ type OSDKeycloakServiceBuilderConfigurator interface {
 
 type KeycloakServiceBuilder interface {
 	WithRealmConfig(realmConfig *keycloak.KeycloakRealmConfig) KeycloakServiceBuilder
+	WithConnectionFactory(connectionFactory *db.ConnectionFactory) KeycloakServiceBuilder
 	Build() KeycloakService
 }
 
 type OSDKeycloakServiceBuilder interface {
 	WithRealmConfig(realmConfig *keycloak.KeycloakRealmConfig) OSDKeycloakServiceBuilder
+	WithConnectionFactory(connectionFactory *db.ConnectionFactory) OSDKeycloakServiceBuilder
 	Build() OSDKeycloakService
 }
 
@@ -61,8 +67,9 @@ func (o *osdBuilderConfigurator) WithConfiguration(config *keycloak.KeycloakConf
 }
 
 type keycloakServiceBuilder struct {
-	config      *keycloak.KeycloakConfig
-	realmConfig *keycloak.KeycloakRealmConfig
+	config            *keycloak.KeycloakConfig
+	realmConfig       *keycloak.KeycloakRealmConfig
+	connectionFactory *db.ConnectionFactory
 }
 
 type osdKeycloackServiceBuilder keycloakServiceBuilder
@@ -71,7 +78,7 @@ type osdKeycloackServiceBuilder keycloakServiceBuilder
 // If a custom realm is configured (WithRealmConfig called), then always Keycloak provider is used
 // irrespective of the `builder.config.SelectSSOProvider` value
 func (builder *keycloakServiceBuilder) Build() KeycloakService {
-	return build(builder.config.SelectSSOProvider, builder.config, builder.realmConfig)
+	return build(builder.config.SelectSSOProvider, builder.config, builder.realmConfig, builder.connectionFactory)
 }
 
 func (builder *keycloakServiceBuilder) WithRealmConfig(realmConfig *keycloak.KeycloakRealmConfig) KeycloakServiceBuilder {
@@ -79,11 +86,17 @@ func (builder *keycloakServiceBuilder) WithRealmConfig(realmConfig *keycloak.Key
 	return builder
 }
 
+// WithConnectionFactory sets the database connection used to store the client registrations of the oidc provider
+func (builder *keycloakServiceBuilder) WithConnectionFactory(connectionFactory *db.ConnectionFactory) KeycloakServiceBuilder {
+	builder.connectionFactory = connectionFactory
+	return builder
+}
+
 // Build returns an instance of KeycloakService ready to be used.
 // If a custom realm is configured (WithRealmConfig called), then always Keycloak provider is used
 // irrespective of the `builder.config.SelectSSOProvider` value
 func (builder *osdKeycloackServiceBuilder) Build() OSDKeycloakService {
-	return build(builder.config.SelectSSOProvider, builder.config, builder.realmConfig).(OSDKeycloakService)
+	return build(builder.config.SelectSSOProvider, builder.config, builder.realmConfig, builder.connectionFactory).(OSDKeycloakService)
 }
 
 func (builder *osdKeycloackServiceBuilder) WithRealmConfig(realmConfig *keycloak.KeycloakRealmConfig) OSDKeycloakServiceBuilder {
@@ -91,7 +104,13 @@ func (builder *osdKeycloackServiceBuilder) WithRealmConfig(realmConfig *keycloak
 	return builder
 }
 
-func build(providerName string, keycloakConfig *keycloak.KeycloakConfig, realmConfig *keycloak.KeycloakRealmConfig) KeycloakService {
+// WithConnectionFactory sets the database connection used to store the client registrations of the oidc provider
+func (builder *osdKeycloackServiceBuilder) WithConnectionFactory(connectionFactory *db.ConnectionFactory) OSDKeycloakServiceBuilder {
+	builder.connectionFactory = connectionFactory
+	return builder
+}
+
+func build(providerName string, keycloakConfig *keycloak.KeycloakConfig, realmConfig *keycloak.KeycloakRealmConfig, connectionFactory *db.ConnectionFactory) KeycloakService {
 	notNilPredicate := func(x *keycloak.KeycloakRealmConfig) bool {
 		return x != nil
 	}
@@ -110,6 +129,18 @@ func build(providerName string, keycloakConfig *keycloak.KeycloakConfig, realmCo
 			},
 		}
 
+	} else if providerName == keycloak.OIDC_SSO {
+		client := oidc.NewOIDCClient(keycloakConfig, keycloakConfig.OIDCRealm)
+		// the configured key may have any length, an AES-256 key is derived from it
+		registrationTokenKey := sha256.Sum256([]byte(keycloakConfig.OIDCRegistrationTokenKey))
+		return &keycloakServiceProxy{
+			getToken: client.GetToken,
+			service: &oidcService{
+				client:               client,
+				connectionFactory:    connectionFactory,
+				registrationTokenKey: registrationTokenKey[:],
+			},
+		}
 	} else {
 		_, realmConfig := arrays.FindFirst([]*keycloak.KeycloakRealmConfig{realmConfig, keycloakConfig.RedhatSSORealm}, notNilPredicate)
 		client := redhatsso.NewSSOClient(keycloakConfig, realmConfig)
@@ -0,0 +1,494 @@
+package sso
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/oidc"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/golang/glog"
+)
+
+var _ keycloakServiceInternal = &oidcService{}
+
+// oidcService manages the service accounts as clients of a generic OIDC provider, registered with its
+// dynamic client registration endpoint (RFC 7591) and managed with their client configuration endpoint (RFC 7592).
+// The provider does not know the owner of its clients, so the registrations are kept in the database, with their
+// registration access token encrypted with registrationTokenKey
+type oidcService struct {
+	client               oidc.OIDCClient
+	connectionFactory    *db.ConnectionFactory
+	registrationTokenKey []byte
+}
+
+const (
+	clientCredentialsGrantType    = "client_credentials"
+	clientSecretBasicAuthMethod   = "client_secret_basic"
+	oidcGeneratedClientSecretSize = 32
+)
+
+func getOIDCErrorDescription(e error, defaultDesc string) string {
+	if regErr, ok := e.(*oidc.RegistrationError); ok && regErr.ErrorDescription != "" {
+		return regErr.ErrorDescription
+	}
+	return defaultDesc
+}
+
+func (o *oidcService) RegisterClientInSSO(accessToken string, clusterId string, clusterOathCallbackURI string) (string, *errors.ServiceError) {
+	return "", errors.New(errors.ErrorGeneral, "RegisterClientInSSO Not implemented")
+}
+
+func (o *oidcService) DeRegisterClientInSSO(accessToken string, clientId string) *errors.ServiceError {
+	glog.V(5).Infof("Deregistering client with id: %s", clientId)
+	return o.DeleteServiceAccountInternal(accessToken, clientId)
+}
+
+func (o *oidcService) GetConfig() *keycloak.KeycloakConfig {
+	return o.client.GetConfig()
+}
+
+func (o *oidcService) GetRealmConfig() *keycloak.KeycloakRealmConfig {
+	return o.client.GetRealmConfig()
+}
+
+func (o *oidcService) IsKafkaClientExist(accessToken string, clientId string) *errors.ServiceError {
+	glog.V(5).Infof("Checking if client with id: %s exists", clientId)
+	registration, err := o.findRegistration("client_id = ?", clientId)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorFailedToGetSSOClient, err, "failed to get sso client with id: %s", clientId)
+	}
+	if registration == nil {
+		return errors.New(errors.ErrorNotFound, "sso client with id: %s not found", clientId)
+	}
+
+	registrationAccessToken, err := o.decryptRegistrationAccessToken(registration)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorFailedToGetSSOClient, err, "failed to get sso client with id: %s", clientId)
+	}
+	if _, err := o.client.GetClient(registrationAccessToken, registration.RegistrationClientURI); err != nil {
+		if oidc.IsNotFound(err) {
+			return errors.New(errors.ErrorNotFound, "sso client with id: %s not found", clientId)
+		}
+		return errors.NewWithCause(errors.ErrorFailedToGetSSOClient, err, getOIDCErrorDescription(err, fmt.Sprintf("failed to get sso client with id: %s", clientId)))
+	}
+	glog.V(5).Infof("sso client with id: %s found", clientId)
+	return nil
+}
+
+func (o *oidcService) CreateServiceAccount(accessToken string, serviceAccountRequest *api.ServiceAccountRequest, ctx context.Context) (*api.ServiceAccount, *errors.ServiceError) {
+	claims, err := auth.GetClaimsFromContext(ctx) //http requester's info
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+	}
+	orgId, _ := claims.GetOrgId()
+	ownerAccountId, _ := claims.GetAccountId()
+	owner, _ := claims.GetUsername()
+
+	isAllowed, err := o.checkAllowedServiceAccountsLimits(o.GetConfig().MaxAllowedServiceAccounts, orgId)
+	if err != nil { //5xx
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to create service account")
+	}
+	if !isAllowed { //4xx over requesters' limit
+		return nil, errors.MaxLimitForServiceAccountReached("max allowed number:%d of service accounts for user in org:%s has reached", o.GetConfig().MaxAllowedServiceAccounts, orgId)
+	}
+
+	return o.registerServiceAccount(accessToken, &api.OidcClientRegistration{
+		Name:           serviceAccountRequest.Name,
+		Description:    serviceAccountRequest.Description,
+		Owner:          owner,
+		OwnerAccountId: ownerAccountId,
+		OrganisationId: orgId,
+	})
+}
+
+func (o *oidcService) CreateServiceAccountInternal(accessToken string, request CompleteServiceAccountRequest) (*api.ServiceAccount, *errors.ServiceError) {
+	// the client id is issued by the provider, the requested one is only used as the name of the client
+	return o.registerServiceAccount(accessToken, &api.OidcClientRegistration{
+		Name:           request.ClientId,
+		Description:    request.Description,
+		Owner:          request.Owner,
+		OwnerAccountId: request.OwnerAccountId,
+		OrganisationId: request.OrgId,
+		Internal:       true,
+	})
+}
+
+func (o *oidcService) ListServiceAcc(accessToken string, ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil { //4xx
+		return nil, errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+	}
+	orgId, _ := claims.GetOrgId()
+
+	var registrations api.OidcClientRegistrationList
+	dbConn := o.connectionFactory.New().
+		Where("organisation_id = ? AND internal = ?", orgId, false).
+		Order("created_at").
+		Offset(first)
+	if max > 0 {
+		dbConn = dbConn.Limit(max)
+	}
+	if err := dbConn.Find(&registrations).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to collect service accounts")
+	}
+
+	var sa []api.ServiceAccount
+	for _, registration := range registrations {
+		sa = append(sa, *convertOidcClientRegistrationToAPIServiceAccount(registration, ""))
+	}
+	return sa, nil
+}
+
+func (o *oidcService) DeleteServiceAccount(accessToken string, ctx context.Context, id string) *errors.ServiceError {
+	registration, svcErr := o.getOwnedRegistration(ctx, id, true)
+	if svcErr != nil {
+		return svcErr
+	}
+	if err := o.deleteRegistration(registration); err != nil {
+		return errors.NewWithCause(errors.ErrorFailedToDeleteServiceAccount, err, getOIDCErrorDescription(err, "failed to delete service account"))
+	}
+	glog.V(5).Infof("deleted service account clientId = %s and id = %s owned by user = %s", registration.ClientId, id, registration.Owner)
+	return nil
+}
+
+func (o *oidcService) DeleteServiceAccountInternal(accessToken string, clientId string) *errors.ServiceError {
+	registration, err := o.findRegistration("client_id = ?", clientId)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorFailedToGetSSOClient, err, "failed to get sso client with id: %s", clientId)
+	}
+	if registration == nil {
+		return nil // consider already deleted
+	}
+	if err := o.deleteRegistration(registration); err != nil {
+		return errors.NewWithCause(errors.ErrorFailedToDeleteServiceAccount, err, getOIDCErrorDescription(err, "failed to delete service account"))
+	}
+	glog.V(5).Infof("deleted service account clientId = %s and id = %s", clientId, registration.ID)
+	return nil
+}
+
+func (o *oidcService) ResetServiceAccountCredentials(accessToken string, ctx context.Context, id string) (*api.ServiceAccount, *errors.ServiceError) {
+	registration, svcErr := o.getOwnedRegistration(ctx, id, true)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+	secret, err := o.regenerateClientSecret(registration)
+	if err != nil {
+		if oidc.IsNotFound(err) {
+			return nil, errors.NewWithCause(errors.ErrorServiceAccountNotFound, err, "service account not found %s", id)
+		}
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, getOIDCErrorDescription(err, "failed to reset service account credentials"))
+	}
+	glog.V(5).Infof("Client %s with id = %s updated successfully", registration.ClientId, registration.ID)
+	return convertOidcClientRegistrationToAPIServiceAccount(registration, secret), nil
+}
+
+func (o *oidcService) GetServiceAccountById(accessToken string, ctx context.Context, id string) (*api.ServiceAccount, *errors.ServiceError) {
+	registration, err := o.getOwnedRegistration(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	return convertOidcClientRegistrationToAPIServiceAccount(registration, ""), nil
+}
+
+func (o *oidcService) GetServiceAccountByClientId(accessToken string, ctx context.Context, clientId string) (*api.ServiceAccount, *errors.ServiceError) {
+	registration, err := o.findRegistration("client_id = ? AND internal = ?", clientId, false)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorFailedToGetServiceAccount, err, "failed to get the service account %s", clientId)
+	}
+	if registration == nil {
+		return nil, errors.NewWithCause(errors.ErrorServiceAccountNotFound, nil, "service account not found %s", clientId)
+	}
+	return o.GetServiceAccountById(accessToken, ctx, registration.ID)
+}
+
+func (o *oidcService) RegisterKasFleetshardOperatorServiceAccount(accessToken string, agentClusterId string) (*api.ServiceAccount, *errors.ServiceError) {
+	return o.registerAgentServiceAccount(accessToken, kasAgentServiceAccountPrefix, agentClusterId)
+}
+
+func (o *oidcService) DeRegisterKasFleetshardOperatorServiceAccount(accessToken string, agentClusterId string) *errors.ServiceError {
+	return o.deregisterAgentServiceAccount(accessToken, kasAgentServiceAccountPrefix, agentClusterId)
+}
+
+func (o *oidcService) RegisterConnectorFleetshardOperatorServiceAccount(accessToken string, agentClusterId string) (*api.ServiceAccount, *errors.ServiceError) {
+	return o.registerAgentServiceAccount(accessToken, connectorAgentServiceAccountPrefix, agentClusterId)
+}
+
+func (o *oidcService) DeRegisterConnectorFleetshardOperatorServiceAccount(accessToken string, agentClusterId string) *errors.ServiceError {
+	return o.deregisterAgentServiceAccount(accessToken, connectorAgentServiceAccountPrefix, agentClusterId)
+}
+
+// GetKafkaClientSecret regenerates the secret of the client, as the provider does not return the secret of an existing client
+func (o *oidcService) GetKafkaClientSecret(accessToken string, clientId string) (string, *errors.ServiceError) {
+	glog.V(5).Infof("Getting client secret for client id: %s", clientId)
+	registration, err := o.findRegistration("client_id = ?", clientId)
+	if err != nil {
+		return "", errors.NewWithCause(errors.ErrorFailedToGetSSOClient, err, "failed to get sso client with id: %s", clientId)
+	}
+	if registration == nil {
+		return "", errors.New(errors.ErrorFailedToGetSSOClientSecret, "failed to get sso client secret")
+	}
+	secret, err := o.regenerateClientSecret(registration)
+	if err != nil {
+		return "", errors.NewWithCause(errors.ErrorFailedToGetSSOClientSecret, err, "failed to get sso client secret")
+	}
+	return secret, nil
+}
+
+func (o *oidcService) registerAgentServiceAccount(accessToken string, prefix string, agentClusterId string) (*api.ServiceAccount, *errors.ServiceError) {
+	serviceAccountId := buildAgentOperatorServiceAccountId(prefix, agentClusterId)
+	glog.V(5).Infof("Registering agent service account %s", serviceAccountId)
+	registration, err := o.findRegistration("name = ? AND internal = ?", serviceAccountId, true)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to check if client exists.")
+	}
+
+	if registration == nil {
+		return o.registerServiceAccount(accessToken, &api.OidcClientRegistration{
+			Name:        serviceAccountId,
+			Description: fmt.Sprintf("service account for agent on cluster %s", agentClusterId),
+			Internal:    true,
+		})
+	}
+
+	// the agent is registered again, e.g. after a failure: the secret of the existing client can only be regenerated
+	glog.V(5).Infof("Existing client found for %s with id = %s", serviceAccountId, registration.ID)
+	secret, err := o.regenerateClientSecret(registration)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorFailedToGetSSOClientSecret, err, "failed to get service account secret")
+	}
+	return convertOidcClientRegistrationToAPIServiceAccount(registration, secret), nil
+}
+
+func (o *oidcService) deregisterAgentServiceAccount(accessToken string, prefix string, agentClusterId string) *errors.ServiceError {
+	serviceAccountId := buildAgentOperatorServiceAccountId(prefix, agentClusterId)
+	registration, err := o.findRegistration("name = ? AND internal = ?", serviceAccountId, true)
+	if err != nil { //5xx
+		return errors.NewWithCause(errors.ErrorFailedToGetSSOClient, err, "failed to get sso client with id: %s", serviceAccountId)
+	}
+	if registration == nil {
+		return nil
+	}
+	if err := o.deleteRegistration(registration); err != nil {
+		return errors.NewWithCause(errors.ErrorFailedToDeleteServiceAccount, err, "failed to delete service account: %s", registration.ClientId)
+	}
+	glog.V(5).Infof("Deleted service account %s with clientId = %s", serviceAccountId, registration.ClientId)
+	return nil
+}
+
+// registerServiceAccount registers a new client with the provider and stores its registration
+func (o *oidcService) registerServiceAccount(accessToken string, registration *api.OidcClientRegistration) (*api.ServiceAccount, *errors.ServiceError) {
+	glog.V(5).Infof("Creating service account with name: %s", registration.Name)
+	client, err := o.client.RegisterClient(accessToken, clientMetadata(registration.Name, ""))
+	if err != nil {
+		if regErr, ok := err.(*oidc.RegistrationError); ok && (regErr.StatusCode == http.StatusUnauthorized || regErr.StatusCode == http.StatusForbidden) {
+			return nil, errors.NewWithCause(errors.ErrorForbidden, err, "failed to create service account")
+		}
+		return nil, errors.NewWithCause(errors.ErrorFailedToCreateServiceAccount, err, getOIDCErrorDescription(err, "failed to create service account"))
+	}
+	if client.RegistrationAccessToken == "" || client.RegistrationClientURI == "" {
+		// the client could not be deleted anymore, it is left in the provider
+		return nil, errors.New(errors.ErrorFailedToCreateServiceAccount, "the oidc provider did not return the registration access token of client %s", client.ClientID)
+	}
+
+	registration.ClientId = client.ClientID
+	registration.RegistrationClientURI = client.RegistrationClientURI
+	registration.RegistrationAccessToken, err = encryptRegistrationAccessToken(o.registrationTokenKey, client.RegistrationAccessToken)
+	if err == nil {
+		err = o.connectionFactory.New().Create(registration).Error
+	}
+	if err != nil {
+		if deleteErr := o.client.DeleteClient(client.RegistrationAccessToken, client.RegistrationClientURI); deleteErr != nil {
+			glog.Errorf("failed to delete client %s after its registration could not be stored: %v", client.ClientID, deleteErr)
+		}
+		return nil, errors.NewWithCause(errors.ErrorFailedToCreateServiceAccount, err, "failed to create service account")
+	}
+
+	glog.V(5).Infof("service account clientId = %s and id = %s created for user = %s", registration.ClientId, registration.ID, registration.Owner)
+	return convertOidcClientRegistrationToAPIServiceAccount(registration, client.ClientSecret), nil
+}
+
+// regenerateClientSecret replaces the secret of the client. RFC 7592 does not define how the secret is rotated:
+// the provider either accepts the generated secret or issues a new one, which is then returned instead
+func (o *oidcService) regenerateClientSecret(registration *api.OidcClientRegistration) (string, error) {
+	secret, err := newClientSecret()
+	if err != nil {
+		return "", err
+	}
+	registrationAccessToken, err := o.decryptRegistrationAccessToken(registration)
+	if err != nil {
+		return "", err
+	}
+	client, err := o.client.UpdateClient(registrationAccessToken, registration.RegistrationClientURI, registration.ClientId, clientMetadata(registration.Name, secret))
+	if err != nil {
+		return "", err
+	}
+	if client.ClientSecret != "" {
+		secret = client.ClientSecret
+	}
+	// the provider may rotate the registration access token with each update (RFC 7592 section 2.2)
+	if client.RegistrationAccessToken != "" && client.RegistrationAccessToken != registrationAccessToken {
+		encrypted, err := encryptRegistrationAccessToken(o.registrationTokenKey, client.RegistrationAccessToken)
+		if err != nil {
+			return "", err
+		}
+		registration.RegistrationAccessToken = encrypted
+		if err := o.connectionFactory.New().Model(registration).Update("registration_access_token", registration.RegistrationAccessToken).Error; err != nil {
+			return "", err
+		}
+	}
+	return secret, nil
+}
+
+// deleteRegistration deletes the client from the provider and then its registration.
+// A client that is not found in the provider is considered already deleted
+func (o *oidcService) deleteRegistration(registration *api.OidcClientRegistration) error {
+	registrationAccessToken, err := o.decryptRegistrationAccessToken(registration)
+	if err != nil {
+		return err
+	}
+	if err := o.client.DeleteClient(registrationAccessToken, registration.RegistrationClientURI); err != nil && !oidc.IsNotFound(err) {
+		return err
+	}
+	return o.connectionFactory.New().Delete(registration).Error
+}
+
+// getOwnedRegistration returns the registration of a service account of the requester's organisation.
+// The requester must own the service account, or be an org admin if allowOrgAdmin is set
+func (o *oidcService) getOwnedRegistration(ctx context.Context, id string, allowOrgAdmin bool) (*api.OidcClientRegistration, *errors.ServiceError) {
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil { //4xx
+		return nil, errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+	}
+	registration, err := o.findRegistration("id = ? AND internal = ?", id, false)
+	if err != nil { //5xx
+		return nil, errors.NewWithCause(errors.ErrorFailedToGetServiceAccount, err, "failed to get the service account %s", id)
+	}
+	if registration == nil {
+		return nil, errors.NewWithCause(errors.ErrorServiceAccountNotFound, nil, "service account not found %s", id)
+	}
+
+	orgId, _ := claims.GetOrgId()
+	userId, _ := claims.GetAccountId()
+	if registration.OrganisationId != orgId || (registration.OwnerAccountId != userId && !(allowOrgAdmin && claims.IsOrgAdmin())) {
+		return nil, errors.NewWithCause(errors.ErrorForbidden, nil, "failed to get service account")
+	}
+	return registration, nil
+}
+
+// findRegistration returns the first registration matching the query, or nil if there is none
+func (o *oidcService) findRegistration(query string, args ...interface{}) (*api.OidcClientRegistration, error) {
+	var registrations api.OidcClientRegistrationList
+	if err := o.connectionFactory.New().Where(query, args...).Limit(1).Find(&registrations).Error; err != nil {
+		return nil, err
+	}
+	if len(registrations) == 0 {
+		return nil, nil
+	}
+	return registrations[0], nil
+}
+
+func (o *oidcService) checkAllowedServiceAccountsLimits(maxAllowed int, orgId string) (bool, error) {
+	glog.V(5).Infof("Check if user is allowed to create service accounts: orgId = %s", orgId)
+
+	if arrays.Contains(o.GetConfig().ServiceAccounttLimitCheckSkipOrgIdList, orgId) {
+		glog.V(5).Infof("orgId = %s , present in service account limits check skip list. No limits on the number of service accounts", orgId)
+		return true, nil
+	}
+
+	var serviceAccountCount int64
+	if err := o.connectionFactory.New().Model(&api.OidcClientRegistration{}).
+		Where("organisation_id = ? AND internal = ?", orgId, false).
+		Count(&serviceAccountCount).Error; err != nil {
+		return false, err
+	}
+
+	glog.V(10).Infof("Existing number of clients found: %d & max allowed: %d, for the orgId: %s", serviceAccountCount, maxAllowed, orgId)
+	return serviceAccountCount < int64(maxAllowed), nil
+}
+
+func clientMetadata(name string, secret string) oidc.ClientMetadata {
+	return oidc.ClientMetadata{
+		ClientName:              name,
+		ClientSecret:            secret,
+		GrantTypes:              []string{clientCredentialsGrantType},
+		TokenEndpointAuthMethod: clientSecretBasicAuthMethod,
+	}
+}
+
+func (o *oidcService) decryptRegistrationAccessToken(registration *api.OidcClientRegistration) (string, error) {
+	token, err := decryptRegistrationAccessToken(o.registrationTokenKey, registration.RegistrationAccessToken)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt the registration access token of client %s: %w", registration.ClientId, err)
+	}
+	return token, nil
+}
+
+// encryptRegistrationAccessToken encrypts the token with AES-GCM, the random nonce is prepended to the encrypted token
+func encryptRegistrationAccessToken(key []byte, token string) (string, error) {
+	gcm, err := newRegistrationTokenCipher(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(token), nil)), nil
+}
+
+func decryptRegistrationAccessToken(key []byte, encrypted string) (string, error) {
+	gcm, err := newRegistrationTokenCipher(key)
+	if err != nil {
+		return "", err
+	}
+	value, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", err
+	}
+	if len(value) < gcm.NonceSize() {
+		return "", fmt.Errorf("encrypted token is too short")
+	}
+	token, err := gcm.Open(nil, value[:gcm.NonceSize()], value[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(token), nil
+}
+
+func newRegistrationTokenCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func newClientSecret() (string, error) {
+	b := make([]byte, oidcGeneratedClientSecretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func convertOidcClientRegistrationToAPIServiceAccount(registration *api.OidcClientRegistration, secret string) *api.ServiceAccount {
+	return &api.ServiceAccount{
+		ID:           registration.ID,
+		ClientID:     registration.ClientId,
+		ClientSecret: secret,
+		Name:         registration.Name,
+		Description:  registration.Description,
+		CreatedBy:    registration.Owner,
+		CreatedAt:    registration.CreatedAt,
+	}
+}
@@ -0,0 +1,370 @@
+package sso
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/oidc"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/onsi/gomega"
+	"github.com/openshift-online/ocm-sdk-go/authentication"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+const (
+	oidcRegistrationAccessToken = "registration-access-token"
+	oidcRegistrationClientURI   = "https://oidc.example.com/register/client-id"
+)
+
+var oidcRegistrationTokenKey = []byte("0123456789abcdef0123456789abcdef")
+
+func oidcContext(orgId string, accountId string, isOrgAdmin bool) context.Context {
+	return authentication.ContextWithToken(context.Background(), &jwt.Token{
+		Claims: jwt.MapClaims{
+			"username":     "user",
+			"org_id":       orgId,
+			"account_id":   accountId,
+			"is_org_admin": isOrgAdmin,
+		},
+	})
+}
+
+func oidcRegistrationRow(orgId string, ownerAccountId string) map[string]interface{} {
+	encryptedToken, err := encryptRegistrationAccessToken(oidcRegistrationTokenKey, oidcRegistrationAccessToken)
+	if err != nil {
+		panic(err)
+	}
+	return map[string]interface{}{
+		"id":                        "registration-id",
+		"client_id":                 testClientID,
+		"name":                      "service-account",
+		"owner":                     "user",
+		"owner_account_id":          ownerAccountId,
+		"organisation_id":           orgId,
+		"registration_access_token": encryptedToken,
+		"registration_client_uri":   oidcRegistrationClientURI,
+	}
+}
+
+func Test_oidcService_CreateServiceAccount(t *testing.T) {
+	tests := []struct {
+		name     string
+		client   oidc.OIDCClient
+		setupFn  func()
+		want     *api.ServiceAccount
+		wantCode errors.ServiceErrorCode
+	}{
+		{
+			name: "should return the registered service account with its secret",
+			client: &oidc.OIDCClientMock{
+				GetConfigFunc: func() *keycloak.KeycloakConfig {
+					return &keycloak.KeycloakConfig{MaxAllowedServiceAccounts: 1}
+				},
+				RegisterClientFunc: func(accessToken string, metadata oidc.ClientMetadata) (*oidc.ClientRegistration, error) {
+					if metadata.ClientName != "service-account" || metadata.GrantTypes[0] != clientCredentialsGrantType {
+						return nil, &oidc.RegistrationError{StatusCode: http.StatusBadRequest, Code: "invalid_client_metadata"}
+					}
+					return &oidc.ClientRegistration{
+						ClientMetadata:          oidc.ClientMetadata{ClientSecret: secret},
+						ClientID:                testClientID,
+						RegistrationAccessToken: oidcRegistrationAccessToken,
+						RegistrationClientURI:   oidcRegistrationClientURI,
+					}, nil
+				},
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT count(1) FROM "oidc_client_registrations"`).WithReply([]map[string]interface{}{{"count": 0}})
+			},
+			want: &api.ServiceAccount{
+				ClientID:     testClientID,
+				ClientSecret: secret,
+				Name:         "service-account",
+				CreatedBy:    "user",
+			},
+		},
+		{
+			name: "should return an error when the organisation reached the service accounts limit",
+			client: &oidc.OIDCClientMock{
+				GetConfigFunc: func() *keycloak.KeycloakConfig {
+					return &keycloak.KeycloakConfig{MaxAllowedServiceAccounts: 1}
+				},
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT count(1) FROM "oidc_client_registrations"`).WithReply([]map[string]interface{}{{"count": 1}})
+			},
+			wantCode: errors.ErrorMaxLimitForServiceAccountsReached,
+		},
+		{
+			name: "should return an error when the provider does not return the registration access token",
+			client: &oidc.OIDCClientMock{
+				GetConfigFunc: func() *keycloak.KeycloakConfig {
+					return &keycloak.KeycloakConfig{MaxAllowedServiceAccounts: 1}
+				},
+				RegisterClientFunc: func(accessToken string, metadata oidc.ClientMetadata) (*oidc.ClientRegistration, error) {
+					return &oidc.ClientRegistration{ClientID: testClientID}, nil
+				},
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT count(1) FROM "oidc_client_registrations"`).WithReply([]map[string]interface{}{{"count": 0}})
+			},
+			wantCode: errors.ErrorFailedToCreateServiceAccount,
+		},
+		{
+			name: "should return forbidden when the registration is not allowed by the provider",
+			client: &oidc.OIDCClientMock{
+				GetConfigFunc: func() *keycloak.KeycloakConfig {
+					return &keycloak.KeycloakConfig{MaxAllowedServiceAccounts: 1}
+				},
+				RegisterClientFunc: func(accessToken string, metadata oidc.ClientMetadata) (*oidc.ClientRegistration, error) {
+					return nil, &oidc.RegistrationError{StatusCode: http.StatusUnauthorized, Code: "invalid_token"}
+				},
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT count(1) FROM "oidc_client_registrations"`).WithReply([]map[string]interface{}{{"count": 0}})
+			},
+			wantCode: errors.ErrorForbidden,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			o := &oidcService{
+				client:               tt.client,
+				connectionFactory:    db.NewMockConnectionFactory(nil),
+				registrationTokenKey: oidcRegistrationTokenKey,
+			}
+			got, err := o.CreateServiceAccount(token, &api.ServiceAccountRequest{Name: "service-account"}, oidcContext("org-id", "account-id", false))
+			if tt.wantCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			got.ID = ""
+			got.CreatedAt = tt.want.CreatedAt
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_oidcService_DeleteServiceAccount(t *testing.T) {
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		deleteErr       error
+		rows            []map[string]interface{}
+		wantCode        errors.ServiceErrorCode
+		wantDeleteCalls int
+	}{
+		{
+			name:            "should delete the service account of its owner",
+			ctx:             oidcContext("org-id", "account-id", false),
+			rows:            []map[string]interface{}{oidcRegistrationRow("org-id", "account-id")},
+			wantDeleteCalls: 1,
+		},
+		{
+			name:            "should delete the service account when the client is already deleted from the provider",
+			ctx:             oidcContext("org-id", "account-id", false),
+			rows:            []map[string]interface{}{oidcRegistrationRow("org-id", "account-id")},
+			deleteErr:       &oidc.RegistrationError{StatusCode: http.StatusUnauthorized},
+			wantDeleteCalls: 1,
+		},
+		{
+			name:            "should allow an org admin to delete a service account of the organisation",
+			ctx:             oidcContext("org-id", "another-account-id", true),
+			rows:            []map[string]interface{}{oidcRegistrationRow("org-id", "account-id")},
+			wantDeleteCalls: 1,
+		},
+		{
+			name:     "should not delete the service account of another organisation",
+			ctx:      oidcContext("another-org-id", "account-id", true),
+			rows:     []map[string]interface{}{oidcRegistrationRow("org-id", "account-id")},
+			wantCode: errors.ErrorForbidden,
+		},
+		{
+			name:     "should return not found when the service account is not registered",
+			ctx:      oidcContext("org-id", "account-id", false),
+			rows:     []map[string]interface{}{},
+			wantCode: errors.ErrorServiceAccountNotFound,
+		},
+		{
+			name:            "should return an error when the provider fails to delete the client",
+			ctx:             oidcContext("org-id", "account-id", false),
+			rows:            []map[string]interface{}{oidcRegistrationRow("org-id", "account-id")},
+			deleteErr:       &oidc.RegistrationError{StatusCode: http.StatusInternalServerError},
+			wantCode:        errors.ErrorFailedToDeleteServiceAccount,
+			wantDeleteCalls: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "oidc_client_registrations"`).WithReply(tt.rows)
+			client := &oidc.OIDCClientMock{
+				DeleteClientFunc: func(registrationAccessToken string, registrationClientURI string) error {
+					return tt.deleteErr
+				},
+			}
+			o := &oidcService{
+				client:               client,
+				connectionFactory:    db.NewMockConnectionFactory(nil),
+				registrationTokenKey: oidcRegistrationTokenKey,
+			}
+			err := o.DeleteServiceAccount(token, tt.ctx, "registration-id")
+			if tt.wantCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantCode))
+			} else {
+				g.Expect(err).To(gomega.BeNil())
+			}
+			g.Expect(client.DeleteClientCalls()).To(gomega.HaveLen(tt.wantDeleteCalls))
+			if tt.wantDeleteCalls > 0 {
+				g.Expect(client.DeleteClientCalls()[0].RegistrationAccessToken).To(gomega.Equal(oidcRegistrationAccessToken))
+				g.Expect(client.DeleteClientCalls()[0].RegistrationClientURI).To(gomega.Equal(oidcRegistrationClientURI))
+			}
+		})
+	}
+}
+
+func Test_oidcService_ResetServiceAccountCredentials(t *testing.T) {
+	tests := []struct {
+		name         string
+		issuedSecret string
+		updateErr    error
+		wantCode     errors.ServiceErrorCode
+	}{
+		{
+			name: "should return the secret generated for the client",
+		},
+		{
+			name:         "should return the secret issued by the provider",
+			issuedSecret: secret,
+		},
+		{
+			name:      "should return not found when the client is not registered in the provider",
+			updateErr: &oidc.RegistrationError{StatusCode: http.StatusUnauthorized},
+			wantCode:  errors.ErrorServiceAccountNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "oidc_client_registrations"`).WithReply([]map[string]interface{}{oidcRegistrationRow("org-id", "account-id")})
+			client := &oidc.OIDCClientMock{
+				UpdateClientFunc: func(registrationAccessToken string, registrationClientURI string, clientID string, metadata oidc.ClientMetadata) (*oidc.ClientRegistration, error) {
+					if tt.updateErr != nil {
+						return nil, tt.updateErr
+					}
+					return &oidc.ClientRegistration{
+						ClientMetadata: oidc.ClientMetadata{ClientSecret: tt.issuedSecret},
+						ClientID:       clientID,
+					}, nil
+				},
+			}
+			o := &oidcService{
+				client:               client,
+				connectionFactory:    db.NewMockConnectionFactory(nil),
+				registrationTokenKey: oidcRegistrationTokenKey,
+			}
+			got, err := o.ResetServiceAccountCredentials(token, oidcContext("org-id", "account-id", false), "registration-id")
+			if tt.wantCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got.ClientID).To(gomega.Equal(testClientID))
+			g.Expect(client.UpdateClientCalls()).To(gomega.HaveLen(1))
+			g.Expect(client.UpdateClientCalls()[0].ClientID).To(gomega.Equal(testClientID))
+			if tt.issuedSecret != "" {
+				g.Expect(got.ClientSecret).To(gomega.Equal(tt.issuedSecret))
+			} else {
+				g.Expect(got.ClientSecret).To(gomega.Equal(client.UpdateClientCalls()[0].Metadata.ClientSecret))
+				g.Expect(got.ClientSecret).ToNot(gomega.BeEmpty())
+			}
+		})
+	}
+}
+
+func Test_oidcService_RegisterKasFleetshardOperatorServiceAccount(t *testing.T) {
+	tests := []struct {
+		name              string
+		rows              []map[string]interface{}
+		wantRegisterCalls int
+		wantUpdateCalls   int
+	}{
+		{
+			name:              "should register a new client for the agent",
+			rows:              []map[string]interface{}{},
+			wantRegisterCalls: 1,
+		},
+		{
+			name:            "should regenerate the secret of the client of an agent already registered",
+			rows:            []map[string]interface{}{oidcRegistrationRow("", "")},
+			wantUpdateCalls: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "oidc_client_registrations"`).WithReply(tt.rows)
+			client := &oidc.OIDCClientMock{
+				RegisterClientFunc: func(accessToken string, metadata oidc.ClientMetadata) (*oidc.ClientRegistration, error) {
+					return &oidc.ClientRegistration{
+						ClientMetadata:          oidc.ClientMetadata{ClientSecret: secret},
+						ClientID:                testClientID,
+						RegistrationAccessToken: oidcRegistrationAccessToken,
+						RegistrationClientURI:   oidcRegistrationClientURI,
+					}, nil
+				},
+				UpdateClientFunc: func(registrationAccessToken string, registrationClientURI string, clientID string, metadata oidc.ClientMetadata) (*oidc.ClientRegistration, error) {
+					return &oidc.ClientRegistration{ClientMetadata: oidc.ClientMetadata{ClientSecret: secret}, ClientID: clientID}, nil
+				},
+			}
+			o := &oidcService{
+				client:               client,
+				connectionFactory:    db.NewMockConnectionFactory(nil),
+				registrationTokenKey: oidcRegistrationTokenKey,
+			}
+			got, err := o.RegisterKasFleetshardOperatorServiceAccount(token, "cluster-id")
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got.ClientID).To(gomega.Equal(testClientID))
+			g.Expect(got.ClientSecret).To(gomega.Equal(secret))
+			g.Expect(client.RegisterClientCalls()).To(gomega.HaveLen(tt.wantRegisterCalls))
+			g.Expect(client.UpdateClientCalls()).To(gomega.HaveLen(tt.wantUpdateCalls))
+			if tt.wantRegisterCalls > 0 {
+				g.Expect(client.RegisterClientCalls()[0].Metadata.ClientName).To(gomega.Equal(buildAgentOperatorServiceAccountId(kasAgentServiceAccountPrefix, "cluster-id")))
+			}
+		})
+	}
+}
+
+func Test_registrationAccessTokenEncryption(t *testing.T) {
+	g := gomega.NewWithT(t)
+	encrypted, err := encryptRegistrationAccessToken(oidcRegistrationTokenKey, oidcRegistrationAccessToken)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(encrypted).ToNot(gomega.ContainSubstring(oidcRegistrationAccessToken))
+
+	token, err := decryptRegistrationAccessToken(oidcRegistrationTokenKey, encrypted)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(token).To(gomega.Equal(oidcRegistrationAccessToken))
+
+	_, err = decryptRegistrationAccessToken([]byte("fedcba9876543210fedcba9876543210"), encrypted)
+	g.Expect(err).ToNot(gomega.BeNil())
+	_, err = decryptRegistrationAccessToken(oidcRegistrationTokenKey, oidcRegistrationAccessToken)
+	g.Expect(err).ToNot(gomega.BeNil())
+}
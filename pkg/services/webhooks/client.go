@@ -0,0 +1,86 @@
+package webhooks
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// errDisallowedAddress is the error of the deliveries to the endpoints resolving to an address of a private network.
+	// The errors of the deliveries are returned to the organisations, so they never contain the responses of the endpoints
+	// or the details of the connections to them.
+	errDisallowedAddress = errors.New("webhook url resolves to a disallowed address")
+	errDeliveryFailed    = errors.New("failed to send the event to the webhook")
+
+	// sharedAddressSpace is the carrier grade NAT range of RFC 6598, which is not covered by net.IP.IsPrivate
+	sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+)
+
+// isAllowedAddress returns whether the webhook endpoints can be reached at the ip address, i.e. that it is not a loopback,
+// private, link local (which includes the cloud metadata services), multicast or unspecified address
+func isAllowedAddress(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified() && !sharedAddressSpace.Contains(ip)
+}
+
+// newDeliveryClient returns the http client delivering the events to the webhook endpoints. Unless the private networks
+// are allowed, the address of each connection is checked once the host of the endpoint has been resolved, so that a host
+// resolving to a private address, e.g. by DNS rebinding after the endpoint was registered, can't be reached.
+// The redirects are not followed: the response of the endpoint is final.
+func newDeliveryClient(config *Config) *http.Client {
+	dialer := &net.Dialer{Timeout: config.DeliveryTimeout}
+	if !config.AllowPrivateNetworks {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return errDisallowedAddress
+			}
+			if ip := net.ParseIP(host); ip == nil || !isAllowedAddress(ip) {
+				return errDisallowedAddress
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would be dialed in place of the endpoint
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   config.DeliveryTimeout,
+		Transport: transport,
+		CheckRedirect: func(request *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// validateURL checks that the url of a webhook endpoint is an absolute https url. Unless the private networks are allowed,
+// the hosts that are known not to be allowed without resolving them, i.e. the ip addresses of private networks and localhost,
+// are rejected. The hosts resolving to private addresses are rejected when the events are delivered.
+func validateURL(rawURL string, allowPrivateNetworks bool) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || !u.IsAbs() || u.Hostname() == "" {
+		return errors.Errorf("webhook url %q is not an absolute URL", rawURL)
+	}
+	if u.Scheme != "https" {
+		return errors.Errorf("webhook url %q must use the https scheme", rawURL)
+	}
+	if allowPrivateNetworks {
+		return nil
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errors.Errorf("webhook url %q must not target a private network", rawURL)
+	}
+	if ip := net.ParseIP(host); ip != nil && !isAllowedAddress(ip) {
+		return errors.Errorf("webhook url %q must not target a private network", rawURL)
+	}
+	return nil
+}
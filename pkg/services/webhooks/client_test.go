@@ -0,0 +1,98 @@
+package webhooks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+func Test_newDeliveryClient(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// the address is checked when connecting, whatever the host of the url resolves to
+	config := NewConfig()
+	_, err := newDeliveryClient(config).Get(server.URL)
+	g.Expect(errors.Is(err, errDisallowedAddress)).To(gomega.BeTrue())
+
+	config.AllowPrivateNetworks = true
+	response, err := newDeliveryClient(config).Get(server.URL)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	defer response.Body.Close()
+	g.Expect(response.StatusCode).To(gomega.Equal(http.StatusNoContent))
+}
+
+func Test_validateURL(t *testing.T) {
+	tests := []struct {
+		name                 string
+		url                  string
+		allowPrivateNetworks bool
+		wantErr              bool
+	}{
+		{
+			name: "should accept a public https url",
+			url:  "https://ci.example.com/hooks/kafka",
+		},
+		{
+			name:    "should reject a relative url",
+			url:     "/hooks/kafka",
+			wantErr: true,
+		},
+		{
+			name:    "should reject an http url",
+			url:     "http://ci.example.com/hooks/kafka",
+			wantErr: true,
+		},
+		{
+			name:    "should reject localhost",
+			url:     "https://localhost:8443/hooks",
+			wantErr: true,
+		},
+		{
+			name:    "should reject a private address",
+			url:     "https://10.0.0.1/hooks",
+			wantErr: true,
+		},
+		{
+			name:    "should reject the cloud metadata address",
+			url:     "https://169.254.169.254/latest/meta-data/",
+			wantErr: true,
+		},
+		{
+			name:    "should reject a loopback ipv6 address",
+			url:     "https://[::1]/hooks",
+			wantErr: true,
+		},
+		{
+			name:    "should reject a shared address space address",
+			url:     "https://100.64.0.1/hooks",
+			wantErr: true,
+		},
+		{
+			name:                 "should accept a private address when the private networks are allowed",
+			url:                  "https://10.0.0.1/hooks",
+			allowPrivateNetworks: true,
+		},
+		{
+			name:                 "should reject an http url when the private networks are allowed",
+			url:                  "http://10.0.0.1/hooks",
+			allowPrivateNetworks: true,
+			wantErr:              true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			err := validateURL(tt.url, tt.allowPrivateNetworks)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
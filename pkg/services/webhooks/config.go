@@ -0,0 +1,55 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+type Config struct {
+	MaxDeliveryAttempts int           `json:"max_delivery_attempts"`
+	InitialRetryBackoff time.Duration `json:"initial_retry_backoff"`
+	MaxRetryBackoff     time.Duration `json:"max_retry_backoff"`
+	DeliveryTimeout     time.Duration `json:"delivery_timeout"`
+	DispatchBatchSize   int           `json:"dispatch_batch_size"`
+	// AllowPrivateNetworks allows the webhook endpoints to be on loopback, private and link local addresses, e.g. in development environments
+	AllowPrivateNetworks bool `json:"allow_private_networks"`
+}
+
+func NewConfig() *Config {
+	return &Config{
+		MaxDeliveryAttempts: 10,
+		InitialRetryBackoff: 30 * time.Second,
+		MaxRetryBackoff:     1 * time.Hour,
+		DeliveryTimeout:     10 * time.Second,
+		DispatchBatchSize:   100,
+	}
+}
+
+func (c *Config) AddFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&c.MaxDeliveryAttempts, "webhook-max-delivery-attempts", c.MaxDeliveryAttempts, "The number of attempts to deliver an event to a webhook endpoint before moving it to the dead letters of the endpoint.")
+	fs.DurationVar(&c.InitialRetryBackoff, "webhook-initial-retry-backoff", c.InitialRetryBackoff, "The time to wait before retrying a failed webhook delivery for the first time. It doubles with each attempt.")
+	fs.DurationVar(&c.MaxRetryBackoff, "webhook-max-retry-backoff", c.MaxRetryBackoff, "The maximum time to wait between two attempts to deliver an event to a webhook endpoint.")
+	fs.DurationVar(&c.DeliveryTimeout, "webhook-delivery-timeout", c.DeliveryTimeout, "The timeout of the requests delivering events to webhook endpoints.")
+	fs.IntVar(&c.DispatchBatchSize, "webhook-dispatch-batch-size", c.DispatchBatchSize, "The maximum number of events and deliveries processed by each run of the webhook dispatcher.")
+	fs.BoolVar(&c.AllowPrivateNetworks, "webhook-allow-private-networks", c.AllowPrivateNetworks, "Allow the webhook endpoints to be on loopback, private and link local addresses. Must only be enabled in development environments.")
+}
+
+func (c *Config) ReadFiles() error {
+	return nil
+}
+
+// RetryBackoff returns the time to wait before the next attempt of a delivery that failed the given number of times
+func (c *Config) RetryBackoff(attempts int) time.Duration {
+	backoff := c.InitialRetryBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= c.MaxRetryBackoff {
+			return c.MaxRetryBackoff
+		}
+	}
+	if backoff > c.MaxRetryBackoff {
+		return c.MaxRetryBackoff
+	}
+	return backoff
+}
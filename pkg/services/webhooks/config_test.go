@@ -0,0 +1,40 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_Config_RetryBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		want     time.Duration
+	}{
+		{
+			name:     "should return the initial backoff after the first attempt",
+			attempts: 1,
+			want:     30 * time.Second,
+		},
+		{
+			name:     "should double the backoff with each attempt",
+			attempts: 3,
+			want:     2 * time.Minute,
+		},
+		{
+			name:     "should not exceed the maximum backoff",
+			attempts: 9,
+			want:     time.Hour,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(NewConfig().RetryBackoff(tt.attempts)).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Dispatcher is a worker that schedules the deliveries of the outbox events to the webhook endpoints of their
+// organisation and delivers them as signed CloudEvents, retrying failed deliveries with an exponential backoff.
+type Dispatcher struct {
+	workers.BaseWorker
+	webhookService WebhookService
+	config         *Config
+	httpClient     *http.Client
+	now            func() time.Time
+}
+
+// NewDispatcher creates a new webhook dispatcher
+func NewDispatcher(webhookService WebhookService, config *Config, reconciler workers.Reconciler) *Dispatcher {
+	return &Dispatcher{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "webhook_dispatcher",
+			Reconciler: reconciler,
+		},
+		webhookService: webhookService,
+		config:         config,
+		httpClient:     newDeliveryClient(config),
+		now:            time.Now,
+	}
+}
+
+// Start initializes the webhook dispatcher
+func (d *Dispatcher) Start() {
+	d.StartWorker(d)
+}
+
+// Stop causes the webhook dispatcher to stop
+func (d *Dispatcher) Stop() {
+	d.StopWorker(d)
+}
+
+func (d *Dispatcher) Reconcile() []error {
+	var encounteredErrors []error
+
+	events, serviceErr := d.webhookService.ListUndispatchedEvents(d.config.DispatchBatchSize)
+	if serviceErr != nil {
+		return append(encounteredErrors, errors.Wrap(serviceErr, "failed to list undispatched events"))
+	}
+	for _, event := range events {
+		if err := d.webhookService.DispatchEvent(event); err != nil {
+			encounteredErrors = append(encounteredErrors, errors.Wrapf(err, "failed to dispatch event %s", event.ID))
+		}
+	}
+
+	deliveries, serviceErr := d.webhookService.ListDueDeliveries(d.now(), d.config.DispatchBatchSize)
+	if serviceErr != nil {
+		return append(encounteredErrors, errors.Wrap(serviceErr, "failed to list due webhook deliveries"))
+	}
+	glog.V(10).Infof("due webhook deliveries count = %d", len(deliveries))
+
+	for _, delivery := range deliveries {
+		if err := d.reconcileDelivery(delivery); err != nil {
+			encounteredErrors = append(encounteredErrors, errors.Wrapf(err, "failed to reconcile webhook delivery %s", delivery.ID))
+		}
+	}
+
+	return encounteredErrors
+}
+
+func (d *Dispatcher) reconcileDelivery(delivery *api.WebhookDelivery) error {
+	webhook, serviceErr := d.webhookService.GetEndpoint(delivery.WebhookId)
+	if serviceErr != nil {
+		if serviceErr.Is404() {
+			// the endpoint has been deleted in the meantime
+			delivery.LastError = "webhook has been deleted"
+			return d.deadLetter(delivery)
+		}
+		return serviceErr
+	}
+
+	event, serviceErr := d.webhookService.GetEvent(delivery.EventId)
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	delivery.Attempts++
+	if err := d.deliver(webhook, delivery, event); err != nil {
+		glog.Warningf("attempt %d to deliver event %s to webhook %s failed: %v", delivery.Attempts, event.ID, webhook.ID, err)
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= d.config.MaxDeliveryAttempts {
+			return d.deadLetter(delivery)
+		}
+		nextAttemptAt := d.now().Add(d.config.RetryBackoff(delivery.Attempts))
+		delivery.NextAttemptAt = &nextAttemptAt
+		if err := d.webhookService.UpdateDelivery(delivery); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	now := d.now()
+	delivery.Status = api.WebhookDeliveryDelivered.String()
+	delivery.DeliveredAt = &now
+	delivery.NextAttemptAt = nil
+	delivery.LastError = ""
+	if err := d.webhookService.UpdateDelivery(delivery); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *Dispatcher) deadLetter(delivery *api.WebhookDelivery) error {
+	glog.Infof("moving delivery %s of event %s to the dead letters of webhook %s", delivery.ID, delivery.EventId, delivery.WebhookId)
+	delivery.Status = api.WebhookDeliveryDeadLetter.String()
+	delivery.NextAttemptAt = nil
+	if err := d.webhookService.UpdateDelivery(delivery); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *Dispatcher) deliver(webhook *api.WebhookEndpoint, delivery *api.WebhookDelivery, event *api.OutboxEvent) error {
+	body, err := json.Marshal(NewCloudEvent(event))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal cloud event")
+	}
+
+	// the endpoints registered before the validation of their url was tightened are checked again
+	if err := validateURL(webhook.URL, d.config.AllowPrivateNetworks); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.config.DeliveryTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+
+	timestamp := d.now()
+	request.Header.Set("Content-Type", cloudEventsContentType)
+	request.Header.Set(webhookIDHeader, delivery.ID)
+	request.Header.Set(webhookTimestampHeader, fmt.Sprintf("%d", timestamp.Unix()))
+	request.Header.Set(webhookSignatureHeader, Sign(webhook.Secret, delivery.ID, timestamp, body))
+
+	response, err := d.httpClient.Do(request)
+	if err != nil {
+		glog.Warningf("failed to send event %s to webhook %s: %v", event.ID, webhook.ID, err)
+		if errors.Is(err, errDisallowedAddress) {
+			return errDisallowedAddress
+		}
+		return errDeliveryFailed
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return errors.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+)
+
+func Test_Dispatcher_Reconcile(t *testing.T) {
+	now := time.Date(2023, 1, 25, 12, 0, 0, 0, time.UTC)
+	event := &api.OutboxEvent{
+		Meta:           api.Meta{ID: "event-id", CreatedAt: now},
+		Type:           KafkaStatusChangedEventType,
+		Source:         "/api/kafkas_mgmt/v1/kafkas",
+		Subject:        "kafka-id",
+		OrganisationId: "org-id",
+		Data:           api.JSON(`{"id":"kafka-id","status":"ready","previous_status":"provisioning"}`),
+	}
+
+	type fields struct {
+		statusCode       int
+		webhookDeleted   bool
+		disallowLoopback bool
+		redirectLocation string
+		responseBody     string
+	}
+
+	tests := []struct {
+		name        string
+		fields      fields
+		attempts    int
+		wantErr     bool
+		wantRequest bool
+		want        *api.WebhookDelivery
+	}{
+		{
+			name: "should mark the delivery as delivered when the webhook endpoint acknowledges the event",
+			fields: fields{
+				statusCode: http.StatusNoContent,
+			},
+			wantRequest: true,
+			want: &api.WebhookDelivery{
+				Status:    api.WebhookDeliveryDelivered.String(),
+				Attempts:  1,
+				LastError: "",
+			},
+		},
+		{
+			name: "should schedule another attempt when the webhook endpoint fails",
+			fields: fields{
+				statusCode:   http.StatusInternalServerError,
+				responseBody: "internal details of the endpoint",
+			},
+			wantRequest: true,
+			want: &api.WebhookDelivery{
+				Status:    api.WebhookDeliveryPending.String(),
+				Attempts:  1,
+				LastError: "webhook responded with status 500",
+			},
+		},
+		{
+			name: "should move the delivery to the dead letters once the maximum number of attempts is reached",
+			fields: fields{
+				statusCode: http.StatusBadGateway,
+			},
+			attempts:    9,
+			wantRequest: true,
+			want: &api.WebhookDelivery{
+				Status:    api.WebhookDeliveryDeadLetter.String(),
+				Attempts:  10,
+				LastError: "webhook responded with status 502",
+			},
+		},
+		{
+			name: "should not follow the redirects of the webhook endpoint",
+			fields: fields{
+				statusCode:       http.StatusFound,
+				redirectLocation: "http://169.254.169.254/latest/meta-data/",
+			},
+			wantRequest: true,
+			want: &api.WebhookDelivery{
+				Status:    api.WebhookDeliveryPending.String(),
+				Attempts:  1,
+				LastError: "webhook responded with status 302",
+			},
+		},
+		{
+			name: "should not deliver to the webhook endpoints registered with a loopback address",
+			fields: fields{
+				statusCode:       http.StatusNoContent,
+				disallowLoopback: true,
+			},
+			wantRequest: false,
+			want: &api.WebhookDelivery{
+				Status:    api.WebhookDeliveryPending.String(),
+				Attempts:  1,
+				LastError: "webhook url \"<server url>\" must not target a private network",
+			},
+		},
+		{
+			name: "should move the delivery to the dead letters when the webhook endpoint has been deleted",
+			fields: fields{
+				webhookDeleted: true,
+			},
+			wantRequest: false,
+			want: &api.WebhookDelivery{
+				Status:    api.WebhookDeliveryDeadLetter.String(),
+				Attempts:  0,
+				LastError: "webhook has been deleted",
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			var received *http.Request
+			var receivedEvent CloudEvent
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				g.Expect(json.NewDecoder(r.Body).Decode(&receivedEvent)).To(gomega.Succeed())
+				if tt.fields.redirectLocation != "" {
+					w.Header().Set("Location", tt.fields.redirectLocation)
+				}
+				w.WriteHeader(tt.fields.statusCode)
+				_, _ = w.Write([]byte(tt.fields.responseBody))
+			}))
+			defer server.Close()
+
+			webhook := &api.WebhookEndpoint{
+				Meta:           api.Meta{ID: "webhook-id"},
+				OrganisationId: "org-id",
+				URL:            server.URL,
+				Secret:         "whsec_secret",
+			}
+			delivery := &api.WebhookDelivery{
+				Meta:      api.Meta{ID: "delivery-id"},
+				WebhookId: webhook.ID,
+				EventId:   event.ID,
+				EventType: event.Type,
+				Status:    api.WebhookDeliveryPending.String(),
+				Attempts:  tt.attempts,
+			}
+
+			var updated *api.WebhookDelivery
+			webhookService := &WebhookServiceMock{
+				ListUndispatchedEventsFunc: func(limit int) (api.OutboxEventList, *errors.ServiceError) {
+					return api.OutboxEventList{event}, nil
+				},
+				DispatchEventFunc: func(e *api.OutboxEvent) *errors.ServiceError {
+					return nil
+				},
+				ListDueDeliveriesFunc: func(t time.Time, limit int) (api.WebhookDeliveryList, *errors.ServiceError) {
+					return api.WebhookDeliveryList{delivery}, nil
+				},
+				GetEndpointFunc: func(id string) (*api.WebhookEndpoint, *errors.ServiceError) {
+					if tt.fields.webhookDeleted {
+						return nil, errors.NotFound("webhook not found")
+					}
+					return webhook, nil
+				},
+				GetEventFunc: func(id string) (*api.OutboxEvent, *errors.ServiceError) {
+					return event, nil
+				},
+				UpdateDeliveryFunc: func(d *api.WebhookDelivery) *errors.ServiceError {
+					updated = d
+					return nil
+				},
+			}
+
+			config := NewConfig()
+			// the test server listens on a loopback address
+			config.AllowPrivateNetworks = !tt.fields.disallowLoopback
+			dispatcher := NewDispatcher(webhookService, config, workers.Reconciler{})
+			dispatcher.now = func() time.Time { return now }
+			dispatcher.httpClient.Transport.(*http.Transport).TLSClientConfig = server.Client().Transport.(*http.Transport).TLSClientConfig
+
+			errs := dispatcher.Reconcile()
+			g.Expect(len(errs) > 0).To(gomega.Equal(tt.wantErr))
+			g.Expect(webhookService.DispatchEventCalls()).To(gomega.HaveLen(1))
+
+			g.Expect(updated).ToNot(gomega.BeNil())
+			g.Expect(updated.Status).To(gomega.Equal(tt.want.Status))
+			g.Expect(updated.Attempts).To(gomega.Equal(tt.want.Attempts))
+			g.Expect(updated.LastError).To(gomega.Equal(strings.ReplaceAll(tt.want.LastError, "<server url>", server.URL)))
+			if tt.want.Status == api.WebhookDeliveryPending.String() {
+				nextAttemptAt := now.Add(30 * time.Second)
+				g.Expect(updated.NextAttemptAt).To(gomega.Equal(&nextAttemptAt))
+			} else {
+				g.Expect(updated.NextAttemptAt).To(gomega.BeNil())
+			}
+
+			if !tt.wantRequest {
+				g.Expect(received).To(gomega.BeNil())
+				return
+			}
+			g.Expect(received).ToNot(gomega.BeNil())
+			g.Expect(received.Header.Get("Content-Type")).To(gomega.Equal(cloudEventsContentType))
+			g.Expect(received.Header.Get(webhookIDHeader)).To(gomega.Equal(delivery.ID))
+			g.Expect(received.Header.Get(webhookTimestampHeader)).To(gomega.Equal(strconv.FormatInt(now.Unix(), 10)))
+			body, err := json.Marshal(NewCloudEvent(event))
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(received.Header.Get(webhookSignatureHeader)).To(gomega.Equal(Sign(webhook.Secret, delivery.ID, now, body)))
+			g.Expect(receivedEvent.ID).To(gomega.Equal(event.ID))
+			g.Expect(receivedEvent.Type).To(gomega.Equal(event.Type))
+			g.Expect(receivedEvent.Subject).To(gomega.Equal(event.Subject))
+		})
+	}
+}
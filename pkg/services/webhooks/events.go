@@ -0,0 +1,75 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// Types of the lifecycle events delivered to the webhook endpoints
+const (
+	KafkaStatusChangedEventType            = "org.bf2.kafka.status_changed"
+	DataPlaneClusterStatusChangedEventType = "org.bf2.kafka.data_plane_cluster.status_changed"
+	ConnectorStatusChangedEventType        = "org.bf2.connector.status_changed"
+	ConnectorClusterStatusChangedEventType = "org.bf2.connector.cluster.status_changed"
+	cloudEventsSpecVersion                 = "1.0"
+	cloudEventsContentType                 = "application/cloudevents+json"
+	cloudEventsDataContentType             = "application/json"
+)
+
+// StatusChange is the data of the events notifying that a resource changed status
+type StatusChange struct {
+	ID             string `json:"id"`
+	Status         string `json:"status"`
+	PreviousStatus string `json:"previous_status,omitempty"`
+}
+
+// RecordEvent stores an event in the outbox using the given connection.
+// The connection should be the transaction changing the state the event describes so that the event is only
+// recorded, and eventually delivered, if the change is committed.
+func RecordEvent(dbConn *gorm.DB, eventType, source, subject, organisationId string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal data of event %q", eventType)
+	}
+
+	event := &api.OutboxEvent{
+		Type:           eventType,
+		Source:         source,
+		Subject:        subject,
+		OrganisationId: organisationId,
+		Data:           api.JSON(payload),
+	}
+	if err := dbConn.Create(event).Error; err != nil {
+		return errors.Wrapf(err, "failed to record event %q for %q", eventType, subject)
+	}
+	return nil
+}
+
+// CloudEvent is the structured mode representation of an outbox event, as defined by the CloudEvents specification
+type CloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            api.JSON  `json:"data,omitempty"`
+}
+
+func NewCloudEvent(event *api.OutboxEvent) CloudEvent {
+	return CloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              event.ID,
+		Source:          event.Source,
+		Type:            event.Type,
+		Subject:         event.Subject,
+		Time:            event.CreatedAt.UTC(),
+		DataContentType: cloudEventsDataContentType,
+		Data:            event.Data,
+	}
+}
@@ -0,0 +1,37 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// Headers set on the requests delivering events, following the Standard Webhooks specification
+const (
+	webhookIDHeader        = "webhook-id"
+	webhookTimestampHeader = "webhook-timestamp"
+	webhookSignatureHeader = "webhook-signature"
+	webhookSecretPrefix    = "whsec_"
+	webhookSecretLength    = 32
+)
+
+// NewSecret generates the secret used to sign the events delivered to a webhook endpoint
+func NewSecret() (string, error) {
+	secret := make([]byte, webhookSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return webhookSecretPrefix + base64.StdEncoding.EncodeToString(secret), nil
+}
+
+// Sign returns the signature of a delivery. It is the base64 encoded HMAC-SHA256 of "<id>.<timestamp>.<body>",
+// keyed with the secret of the webhook endpoint and prefixed with the version of the signature scheme.
+func Sign(secret, id string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%s.%d.", id, timestamp.Unix())))
+	mac.Write(body)
+	return "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,44 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_NewSecret(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	secret, err := NewSecret()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(secret).To(gomega.HavePrefix(webhookSecretPrefix))
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, webhookSecretPrefix))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(key).To(gomega.HaveLen(webhookSecretLength))
+
+	other, err := NewSecret()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(other).ToNot(gomega.Equal(secret))
+}
+
+func Test_Sign(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	secret := "whsec_secret"
+	timestamp := time.Unix(1674648000, 0)
+	body := []byte(`{"id":"event-id"}`)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(`delivery-id.1674648000.{"id":"event-id"}`))
+	want := "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	g.Expect(Sign(secret, "delivery-id", timestamp, body)).To(gomega.Equal(want))
+	g.Expect(Sign("whsec_other", "delivery-id", timestamp, body)).ToNot(gomega.Equal(want))
+	g.Expect(Sign(secret, "delivery-id", timestamp, []byte(`{"id":"other-event-id"}`))).ToNot(gomega.Equal(want))
+}
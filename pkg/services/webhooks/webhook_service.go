@@ -0,0 +1,222 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"gorm.io/gorm"
+)
+
+//go:generate moq -out webhook_service_moq.go . WebhookService
+type WebhookService interface {
+	// Create registers a webhook endpoint and generates the secret used to sign the events delivered to it
+	Create(webhook *api.WebhookEndpoint) *errors.ServiceError
+	Get(organisationId, id string) (*api.WebhookEndpoint, *errors.ServiceError)
+	List(organisationId string, listArgs *services.ListArguments) (api.WebhookEndpointList, *api.PagingMeta, *errors.ServiceError)
+	Delete(organisationId, id string) *errors.ServiceError
+	// ListDeadLetters returns the deliveries to the webhook endpoint that failed the maximum number of attempts
+	ListDeadLetters(webhookId string, listArgs *services.ListArguments) (api.WebhookDeliveryList, *api.PagingMeta, *errors.ServiceError)
+
+	// ListUndispatchedEvents returns the oldest outbox events for which no delivery has been scheduled yet
+	ListUndispatchedEvents(limit int) (api.OutboxEventList, *errors.ServiceError)
+	// DispatchEvent schedules the delivery of the event to each webhook endpoint of its organisation
+	DispatchEvent(event *api.OutboxEvent) *errors.ServiceError
+	// ListDueDeliveries returns the pending deliveries whose next attempt is due
+	ListDueDeliveries(now time.Time, limit int) (api.WebhookDeliveryList, *errors.ServiceError)
+	GetEvent(id string) (*api.OutboxEvent, *errors.ServiceError)
+	// GetEndpoint returns a webhook endpoint regardless of the organisation it belongs to
+	GetEndpoint(id string) (*api.WebhookEndpoint, *errors.ServiceError)
+	UpdateDelivery(delivery *api.WebhookDelivery) *errors.ServiceError
+}
+
+var _ WebhookService = &webhookService{}
+
+type webhookService struct {
+	connectionFactory *db.ConnectionFactory
+	config            *Config
+}
+
+func NewWebhookService(connectionFactory *db.ConnectionFactory, config *Config) WebhookService {
+	return &webhookService{
+		connectionFactory: connectionFactory,
+		config:            config,
+	}
+}
+
+func (w *webhookService) Create(webhook *api.WebhookEndpoint) *errors.ServiceError {
+	if err := validateURL(webhook.URL, w.config.AllowPrivateNetworks); err != nil {
+		return errors.NewWithCause(errors.ErrorValidation, err, err.Error())
+	}
+
+	secret, err := NewSecret()
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to generate webhook secret")
+	}
+	webhook.Secret = secret
+
+	if err := w.connectionFactory.New().Create(webhook).Error; err != nil {
+		return services.HandleCreateError("Webhook", err)
+	}
+	return nil
+}
+
+func (w *webhookService) Get(organisationId, id string) (*api.WebhookEndpoint, *errors.ServiceError) {
+	if id == "" {
+		return nil, errors.Validation("webhook id is undefined")
+	}
+
+	var webhook api.WebhookEndpoint
+	if err := w.connectionFactory.New().
+		Where("id = ? AND organisation_id = ?", id, organisationId).
+		First(&webhook).Error; err != nil {
+		return nil, services.HandleGetError("Webhook", "id", id, err)
+	}
+	return &webhook, nil
+}
+
+func (w *webhookService) List(organisationId string, listArgs *services.ListArguments) (api.WebhookEndpointList, *api.PagingMeta, *errors.ServiceError) {
+	var webhooks api.WebhookEndpointList
+	dbConn := w.connectionFactory.New().Model(&api.WebhookEndpoint{}).Where("organisation_id = ?", organisationId)
+	pagingMeta, err := paginate(dbConn, listArgs, &webhooks)
+	if err != nil {
+		return nil, pagingMeta, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list webhooks")
+	}
+	return webhooks, pagingMeta, nil
+}
+
+func (w *webhookService) Delete(organisationId, id string) *errors.ServiceError {
+	webhook, err := w.Get(organisationId, id)
+	if err != nil {
+		return err
+	}
+
+	if err := w.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		// pending deliveries are dropped, the dead letters are kept until the endpoint is purged
+		if err := tx.Where("webhook_id = ? AND status = ?", webhook.ID, api.WebhookDeliveryPending.String()).
+			Delete(&api.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(webhook).Error
+	}); err != nil {
+		return services.HandleDeleteError("Webhook", "id", id, err)
+	}
+	return nil
+}
+
+func (w *webhookService) ListDeadLetters(webhookId string, listArgs *services.ListArguments) (api.WebhookDeliveryList, *api.PagingMeta, *errors.ServiceError) {
+	var deliveries api.WebhookDeliveryList
+	dbConn := w.connectionFactory.New().Model(&api.WebhookDelivery{}).
+		Where("webhook_id = ? AND status = ?", webhookId, api.WebhookDeliveryDeadLetter.String())
+	pagingMeta, err := paginate(dbConn, listArgs, &deliveries)
+	if err != nil {
+		return nil, pagingMeta, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list dead letters of webhook %q", webhookId)
+	}
+	return deliveries, pagingMeta, nil
+}
+
+func (w *webhookService) ListUndispatchedEvents(limit int) (api.OutboxEventList, *errors.ServiceError) {
+	var events api.OutboxEventList
+	if err := w.connectionFactory.New().
+		Where("dispatched_at IS NULL").
+		Order("created_at").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list undispatched events")
+	}
+	return events, nil
+}
+
+func (w *webhookService) DispatchEvent(event *api.OutboxEvent) *errors.ServiceError {
+	if err := w.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		var webhooks api.WebhookEndpointList
+		// events of resources that do not belong to an organisation are only kept for auditing purposes
+		if event.OrganisationId != "" {
+			if err := tx.Where("organisation_id = ?", event.OrganisationId).Find(&webhooks).Error; err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		for _, webhook := range webhooks {
+			delivery := &api.WebhookDelivery{
+				WebhookId:     webhook.ID,
+				EventId:       event.ID,
+				EventType:     event.Type,
+				Status:        api.WebhookDeliveryPending.String(),
+				NextAttemptAt: &now,
+			}
+			if err := tx.Create(delivery).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(event).Update("dispatched_at", now).Error
+	}); err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to dispatch event %q", event.ID)
+	}
+	return nil
+}
+
+func (w *webhookService) ListDueDeliveries(now time.Time, limit int) (api.WebhookDeliveryList, *errors.ServiceError) {
+	var deliveries api.WebhookDeliveryList
+	if err := w.connectionFactory.New().
+		Where("status = ? AND next_attempt_at <= ?", api.WebhookDeliveryPending.String(), now).
+		Order("next_attempt_at").
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list due webhook deliveries")
+	}
+	return deliveries, nil
+}
+
+func (w *webhookService) GetEvent(id string) (*api.OutboxEvent, *errors.ServiceError) {
+	var event api.OutboxEvent
+	if err := w.connectionFactory.New().Where("id = ?", id).First(&event).Error; err != nil {
+		return nil, services.HandleGetError("Event", "id", id, err)
+	}
+	return &event, nil
+}
+
+func (w *webhookService) GetEndpoint(id string) (*api.WebhookEndpoint, *errors.ServiceError) {
+	var webhook api.WebhookEndpoint
+	if err := w.connectionFactory.New().Where("id = ?", id).First(&webhook).Error; err != nil {
+		return nil, services.HandleGetError("Webhook", "id", id, err)
+	}
+	return &webhook, nil
+}
+
+func (w *webhookService) UpdateDelivery(delivery *api.WebhookDelivery) *errors.ServiceError {
+	if err := w.connectionFactory.New().Model(delivery).Select("status", "attempts", "next_attempt_at", "last_error", "delivered_at").
+		Updates(delivery).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update webhook delivery %q", delivery.ID)
+	}
+	return nil
+}
+
+// paginate counts the records matched by dbConn and loads the requested page, most recent first
+func paginate(dbConn *gorm.DB, listArgs *services.ListArguments, result interface{}) (*api.PagingMeta, error) {
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	var total int64
+	if err := dbConn.Count(&total).Error; err != nil {
+		return pagingMeta, err
+	}
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+
+	if err := dbConn.Order("created_at desc").
+		Offset((pagingMeta.Page - 1) * pagingMeta.Size).
+		Limit(pagingMeta.Size).
+		Find(result).Error; err != nil {
+		return pagingMeta, err
+	}
+	return pagingMeta, nil
+}